@@ -0,0 +1,127 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v38/github"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// commentMarker identifies the comment posted by the action, so later runs
+// on the same pull request update it instead of adding another one.
+const commentMarker = "<!-- scorecard-action -->"
+
+// workflowBotLogin is the author of comments posted with the workflow token,
+// whose user cannot be looked up with GET /user.
+const workflowBotLogin = "github-actions[bot]"
+
+// tokenTransport authenticates requests with the workflow token, so comments
+// are posted by the GitHub Actions bot rather than the owner of repo_token.
+type tokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", "Bearer "+t.token)
+	//nolint:wrapcheck
+	return t.base.RoundTrip(r)
+}
+
+// prCommenter posts the summary comment on a pull request.
+type prCommenter struct {
+	client      *github.Client
+	owner, repo string
+	number      int
+}
+
+// newPRCommenter returns a commenter for pull request number of repo, e.g., github.com/owner/repo.
+func newPRCommenter(apiURL, token, repo string, number int) (*prCommenter, error) {
+	const splitLen = 3
+	split := strings.SplitN(repo, "/", splitLen)
+	if len(split) != splitLen {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid repo: %s", repo))
+	}
+	baseURL, err := url.Parse(strings.TrimSuffix(apiURL, "/") + "/")
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("url.Parse: %v", err))
+	}
+	client := github.NewClient(&http.Client{
+		Transport: &tokenTransport{token: token, base: http.DefaultTransport},
+	})
+	client.BaseURL = baseURL
+	return &prCommenter{client: client, owner: split[1], repo: split[2], number: number}, nil
+}
+
+// login returns the login of the token's user, which authors the comments.
+func (c *prCommenter) login(ctx context.Context) string {
+	user, _, err := c.client.Users.Get(ctx, "")
+	if err != nil || user.GetLogin() == "" {
+		// Installation tokens, e.g. the workflow token, are refused by GET /user.
+		return workflowBotLogin
+	}
+	return user.GetLogin()
+}
+
+// findComment returns the ID of the comment previously posted by the action, or 0.
+// Only comments of the token's user are matched, since anyone on the pull request
+// could post the marker and the action cannot edit their comments.
+func (c *prCommenter) findComment(ctx context.Context) (int64, error) {
+	login := c.login(ctx)
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := c.client.Issues.ListComments(ctx, c.owner, c.repo, c.number, opts)
+		if err != nil {
+			return 0, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Issues.ListComments: %v", err))
+		}
+		for _, comment := range comments {
+			if comment.GetUser().GetLogin() == login && strings.Contains(comment.GetBody(), commentMarker) {
+				return comment.GetID(), nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return 0, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// post creates the summary comment, or updates it if it already exists.
+func (c *prCommenter) post(ctx context.Context, summary string) error {
+	id, err := c.findComment(ctx)
+	if err != nil {
+		return err
+	}
+	body := commentMarker + "\n" + summary
+	comment := &github.IssueComment{Body: &body}
+	if id != 0 {
+		if _, _, err := c.client.Issues.EditComment(ctx, c.owner, c.repo, id, comment); err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Issues.EditComment: %v", err))
+		}
+		return nil
+	}
+	if _, _, err := c.client.Issues.CreateComment(ctx, c.owner, c.repo, c.number, comment); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Issues.CreateComment: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const commentsPath = "/repos/owner/repo/issues/comments/"
+
+// fakeIssues serves the issue comment endpoints of the GitHub API for owner/repo#7.
+// Like the workflow token, its token is refused by GET /user and posts as github-actions[bot].
+type fakeIssues struct {
+	mu       sync.Mutex
+	comments map[int64]string
+	authors  map[int64]string
+	nextID   int64
+}
+
+func (f *fakeIssues) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if r.Header.Get("Authorization") != "Bearer workflow-token" {
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var req struct {
+		Body string `json:"body"`
+	}
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/user":
+		rw.WriteHeader(http.StatusForbidden)
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/owner/repo/issues/7/comments":
+		comments := []map[string]interface{}{}
+		for id, body := range f.comments {
+			comments = append(comments, map[string]interface{}{
+				"id": id, "body": body, "user": map[string]string{"login": f.authors[id]},
+			})
+		}
+		//nolint:errcheck
+		json.NewEncoder(rw).Encode(comments)
+	case r.Method == http.MethodPost && r.URL.Path == "/repos/owner/repo/issues/7/comments":
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.nextID++
+		f.comments[f.nextID] = req.Body
+		f.authors[f.nextID] = workflowBotLogin
+		rw.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(rw, `{"id": %d}`, f.nextID)
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, commentsPath):
+		var id int64
+		if _, err := fmt.Sscanf(strings.TrimPrefix(r.URL.Path, commentsPath), "%d", &id); err != nil {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, ok := f.comments[id]; !ok {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if f.authors[id] != workflowBotLogin {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.comments[id] = req.Body
+		fmt.Fprintf(rw, `{"id": %d}`, id)
+	default:
+		rw.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestPRCommenterPost(t *testing.T) {
+	t.Parallel()
+	issues := &fakeIssues{comments: map[int64]string{}, authors: map[int64]string{}, nextID: 100}
+	// Comments from someone else, which must be left alone even with the marker.
+	issues.comments[1] = "LGTM"
+	issues.authors[1] = "reviewer"
+	issues.comments[2] = commentMarker + "\nnot from the action"
+	issues.authors[2] = "reviewer"
+	server := httptest.NewServer(issues)
+	t.Cleanup(server.Close)
+
+	c, err := newPRCommenter(server.URL, "workflow-token", "github.com/owner/repo", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := c.post(ctx, "first run"); err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	if err := c.post(ctx, "second run"); err != nil {
+		t.Fatalf("post: %v", err)
+	}
+
+	if len(issues.comments) != 3 {
+		t.Fatalf("got %d comments, want 3: %v", len(issues.comments), issues.comments)
+	}
+	if got, want := issues.comments[101], commentMarker+"\nsecond run"; got != want {
+		t.Errorf("comment = %q, want %q", got, want)
+	}
+	if got := issues.comments[1]; got != "LGTM" {
+		t.Errorf("other comment = %q, want it unchanged", got)
+	}
+	if got, want := issues.comments[2], commentMarker+"\nnot from the action"; got != want {
+		t.Errorf("other comment with marker = %q, want it unchanged", got)
+	}
+}
+
+func TestNewPRCommenterInvalidRepo(t *testing.T) {
+	t.Parallel()
+	if _, err := newPRCommenter("https://api.github.com", "token", "owner/repo", 7); err == nil {
+		t.Error("newPRCommenter() succeeded for a repo without host, want error")
+	}
+}
@@ -0,0 +1,254 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main implements the GitHub Action running Scorecard on the repo of the
+// workflow, see action.yml for its inputs.
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks"
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/githubrepo"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/pkg"
+	spol "github.com/ossf/scorecard/v3/policy"
+)
+
+// readPolicy returns the policy in policyFile, or one enforcing the maximum score
+// for every check in checksToRun, so SARIF results show all the findings.
+func readPolicy(policyFile string, checksToRun checker.CheckNameToFnMap) (*spol.ScorecardPolicy, error) {
+	if policyFile != "" {
+		data, err := os.ReadFile(policyFile)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.ReadFile: %v", err))
+		}
+		sp, err := spol.ParseFromYAML(data)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("spol.ParseFromYAML: %v", err))
+		}
+		return sp, nil
+	}
+	sp := &spol.ScorecardPolicy{
+		Version:  1,
+		Policies: map[string]*spol.CheckPolicy{},
+	}
+	for name := range checksToRun {
+		sp.Policies[name] = &spol.CheckPolicy{
+			Score: checker.MaxResultScore,
+			Mode:  spol.CheckPolicy_ENFORCED,
+		}
+	}
+	return sp, nil
+}
+
+// getChecks returns the checks to run, restricted to those enforced by policyFile if set.
+func getChecks(policyFile string) (checker.CheckNameToFnMap, error) {
+	possibleChecks := checker.CheckNameToFnMap{}
+	for name, fn := range checks.AllChecks {
+		possibleChecks[name] = fn
+	}
+	// TODO: Remove this to enable the SECURITY_ADVISORIES check by default in the next release.
+	if _, securityAdvisoriesCheck := os.LookupEnv("ENABLE_SECURITY_ADVISORIES"); !securityAdvisoriesCheck {
+		delete(possibleChecks, checks.CheckSecurityAdvisories)
+	}
+	if policyFile == "" {
+		return possibleChecks, nil
+	}
+	sp, err := readPolicy(policyFile, nil)
+	if err != nil {
+		return nil, err
+	}
+	enabledChecks := checker.CheckNameToFnMap{}
+	for name, p := range sp.GetPolicies() {
+		if p.GetMode() == spol.CheckPolicy_DISABLED {
+			continue
+		}
+		fn, ok := possibleChecks[name]
+		if !ok {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid check: %s", name))
+		}
+		enabledChecks[name] = fn
+	}
+	return enabledChecks, nil
+}
+
+func run(ctx context.Context, opts *options, logger *zap.Logger) error {
+	repo, err := githubrepo.MakeGithubRepo(opts.repo)
+	if err != nil {
+		return fmt.Errorf("error during MakeGithubRepo: %w", err)
+	}
+	checksToRun, err := getChecks(opts.policyFile)
+	if err != nil {
+		return err
+	}
+	checkDocs, err := docs.Read()
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("cannot read yaml file: %v", err))
+	}
+
+	repoClient := githubrepo.CreateGithubRepoClient(ctx, logger)
+	vulnsClient := clients.DefaultVulnerabilitiesClient()
+	var ossFuzzRepoClient clients.RepoClient
+	var ciiClient clients.CIIBestPracticesClient
+	var packagesClient clients.PackagesClient
+	// Repos on GitHub Enterprise Server are not listed in public databases.
+	if !githubrepo.IsEnterpriseRepo(repo) {
+		ossFuzzRepoClient, err = githubrepo.CreateOssFuzzRepoClient(ctx, logger)
+		if err != nil {
+			return fmt.Errorf("error during CreateOssFuzzRepoClient: %w", err)
+		}
+		defer ossFuzzRepoClient.Close()
+		ciiClient = clients.DefaultCIIBestPracticesClient()
+		packagesClient = clients.DefaultPackagesClient()
+	}
+
+	runScorecards := func(commit string) (pkg.ScorecardResult, error) {
+		result, err := pkg.RunScorecards(ctx, repo, commit, false /*raw*/, checksToRun,
+			repoClient, ossFuzzRepoClient, ciiClient, vulnsClient, packagesClient)
+		if err != nil {
+			return result, fmt.Errorf("error during RunScorecards: %w", err)
+		}
+		return result, nil
+	}
+	result, err := runScorecards(opts.commit)
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	switch opts.resultsFormat {
+	case formatSARIF:
+		policy, err := readPolicy(opts.policyFile, checksToRun)
+		if err != nil {
+			return err
+		}
+		err = result.AsSARIF(true /*showDetails*/, zapcore.InfoLevel, &out, checkDocs, policy)
+		if err != nil {
+			return fmt.Errorf("error during AsSARIF: %w", err)
+		}
+	case formatJSON:
+		if err := result.AsJSON2(true /*showDetails*/, zapcore.InfoLevel, checkDocs, &out); err != nil {
+			return fmt.Errorf("error during AsJSON2: %w", err)
+		}
+	}
+	if err := os.WriteFile(opts.resultsFile, out.Bytes(), 0o600); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.WriteFile: %v", err))
+	}
+	logger.Info(fmt.Sprintf("wrote %s results to %s", opts.resultsFormat, opts.resultsFile))
+
+	var jsonResults bytes.Buffer
+	if err := result.AsJSON2(true /*showDetails*/, zapcore.InfoLevel, checkDocs, &jsonResults); err != nil {
+		return fmt.Errorf("error during AsJSON2: %w", err)
+	}
+	if ok, reason := opts.shouldPublish(); ok {
+		if err := publish(ctx, opts, repo.URI(), jsonResults.Bytes()); err != nil {
+			return err
+		}
+		logger.Info(fmt.Sprintf("published results to %s", opts.publishURL))
+	} else {
+		logger.Info(fmt.Sprintf("not publishing results: %s", reason))
+	}
+
+	if ok, reason := opts.shouldComment(); !ok {
+		logger.Info(fmt.Sprintf("not commenting on the pull request: %s", reason))
+		return nil
+	}
+	// The deltas are relative to the default branch, scored like the pull request.
+	baseline, err := runScorecards(clients.HeadSHA)
+	if err != nil {
+		return err
+	}
+	var baselineResults bytes.Buffer
+	if err := baseline.AsJSON2(true /*showDetails*/, zapcore.InfoLevel, checkDocs, &baselineResults); err != nil {
+		return fmt.Errorf("error during AsJSON2: %w", err)
+	}
+	if err := comment(ctx, opts, &baselineResults, &jsonResults); err != nil {
+		return err
+	}
+	logger.Info(fmt.Sprintf("commented on pull request #%d", opts.pullRequest))
+	return nil
+}
+
+// publish sends results to the public API, authenticated with the OIDC token of the workflow.
+func publish(ctx context.Context, opts *options, repo string, results []byte) error {
+	source, err := newOIDCSource(os.Getenv)
+	if err != nil {
+		return err
+	}
+	token, err := source.token(ctx, http.DefaultClient)
+	if err != nil {
+		return err
+	}
+	return publishResults(ctx, http.DefaultClient, opts.publishURL, repo, token, results)
+}
+
+// comment posts the scores of the pull request and their deltas versus baseline on it.
+func comment(ctx context.Context, opts *options, baseline, results io.Reader) error {
+	d, err := pkg.DiffJSONResults(baseline, results)
+	if err != nil {
+		return fmt.Errorf("error during DiffJSONResults: %w", err)
+	}
+	var summary strings.Builder
+	if err := d.AsMarkdown(&summary); err != nil {
+		return fmt.Errorf("error during AsMarkdown: %w", err)
+	}
+	c, err := newPRCommenter(opts.apiURL, opts.githubToken, opts.repo, opts.pullRequest)
+	if err != nil {
+		return err
+	}
+	return c.post(ctx, summary.String())
+}
+
+func main() {
+	opts, err := readOptions(os.Getenv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// The repo_token input takes precedence over the GITHUB_TOKEN of the workflow,
+	// which cannot read some of the settings checked, e.g., branch protection.
+	// With token_exchange_url, the repo is read with a GitHub App installation
+	// token exchanged for the OIDC token of the workflow instead.
+	if exchangeURL := os.Getenv("INPUT_TOKEN_EXCHANGE_URL"); exchangeURL != "" {
+		if err := os.Setenv("GITHUB_TOKEN_EXCHANGE_URL", exchangeURL); err != nil {
+			log.Fatal(err)
+		}
+	} else if token := os.Getenv("INPUT_REPO_TOKEN"); token != "" {
+		if err := os.Setenv("GITHUB_AUTH_TOKEN", token); err != nil {
+			log.Fatal(err)
+		}
+	}
+	logger, err := githubrepo.NewLogger(zap.InfoLevel)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer logger.Sync()
+
+	if err := run(context.Background(), opts, logger); err != nil {
+		logger.Fatal(err.Error())
+	}
+}
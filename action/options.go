@@ -0,0 +1,185 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const (
+	formatSARIF = "sarif"
+	formatJSON  = "json"
+
+	defaultResultsFile = "results.sarif"
+	defaultPublishURL  = "https://api.securityscorecards.dev"
+	defaultAPIURL      = "https://api.github.com"
+)
+
+// options configures a run of the action. Inputs declared in action.yml are passed
+// as INPUT_* environment variables, and the workflow context as GITHUB_* ones.
+type options struct {
+	// repo is the repo being scored, e.g., github.com/owner/repo.
+	repo string
+	// commit is the commit to score, clients.HeadSHA on the default branch.
+	commit        string
+	resultsFile   string
+	resultsFormat string
+	policyFile    string
+	publish       bool
+	publishURL    string
+	// defaultBranch is whether the workflow runs on the repo's default branch.
+	defaultBranch bool
+	private       bool
+	// pullRequest is the number of the pull request triggering the workflow, if any.
+	pullRequest int
+	commentOnPR bool
+	// githubToken is the workflow token, used to comment on pull requests.
+	githubToken string
+	apiURL      string
+}
+
+// event is the subset of the webhook payload in GITHUB_EVENT_PATH used by the action.
+type event struct {
+	Repository struct {
+		DefaultBranch string `json:"default_branch"`
+		Private       bool   `json:"private"`
+	} `json:"repository"`
+	PullRequest struct {
+		Number int `json:"number"`
+	} `json:"pull_request"`
+}
+
+func readEvent(path string) (*event, error) {
+	var e event
+	if path == "" {
+		return &e, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.ReadFile: %v", err))
+	}
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Unmarshal: %v", err))
+	}
+	return &e, nil
+}
+
+// readOptions returns the options set by getenv, usually os.Getenv.
+func readOptions(getenv func(string) string) (*options, error) {
+	repository := getenv("GITHUB_REPOSITORY")
+	if repository == "" {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, "GITHUB_REPOSITORY not set")
+	}
+	host := "github.com"
+	if serverURL := getenv("GITHUB_SERVER_URL"); serverURL != "" {
+		u, err := url.Parse(serverURL)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("url.Parse: %v", err))
+		}
+		host = u.Host
+	}
+	e, err := readEvent(getenv("GITHUB_EVENT_PATH"))
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &options{
+		repo:          fmt.Sprintf("%s/%s", host, repository),
+		commit:        getenv("GITHUB_SHA"),
+		resultsFile:   getenv("INPUT_RESULTS_FILE"),
+		resultsFormat: strings.ToLower(getenv("INPUT_RESULTS_FORMAT")),
+		policyFile:    getenv("INPUT_POLICY_FILE"),
+		publishURL:    strings.TrimSuffix(getenv("INPUT_PUBLISH_URL"), "/"),
+		private:       e.Repository.Private,
+		pullRequest:   e.PullRequest.Number,
+		githubToken:   getenv("INPUT_GITHUB_TOKEN"),
+		apiURL:        getenv("GITHUB_API_URL"),
+	}
+	// Branch pushes set GITHUB_REF to refs/heads/<branch>; pull requests
+	// and tags are never scored as the default branch.
+	if b := e.Repository.DefaultBranch; b != "" && getenv("GITHUB_REF") == "refs/heads/"+b {
+		opts.defaultBranch = true
+		opts.commit = clients.HeadSHA
+	}
+	if opts.commit == "" {
+		opts.commit = clients.HeadSHA
+	}
+	if opts.resultsFile == "" {
+		opts.resultsFile = defaultResultsFile
+	}
+	switch opts.resultsFormat {
+	case "":
+		opts.resultsFormat = formatSARIF
+	case formatSARIF, formatJSON:
+	default:
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("unsupported results format: %s", opts.resultsFormat))
+	}
+	if p := getenv("INPUT_PUBLISH_RESULTS"); p != "" {
+		opts.publish, err = strconv.ParseBool(p)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("strconv.ParseBool: %v", err))
+		}
+	}
+	if c := getenv("INPUT_COMMENT_ON_PR"); c != "" {
+		opts.commentOnPR, err = strconv.ParseBool(c)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("strconv.ParseBool: %v", err))
+		}
+	}
+	if opts.apiURL == "" {
+		opts.apiURL = defaultAPIURL
+	}
+	if opts.publishURL == "" {
+		opts.publishURL = defaultPublishURL
+	}
+	return opts, nil
+}
+
+// shouldPublish returns whether results can be published, and if not, why.
+// Only public repos are published, and only for their default branch, so the
+// public API always reflects the latest released state of a project.
+func (o *options) shouldPublish() (bool, string) {
+	switch {
+	case !o.publish:
+		return false, "publish_results is not set"
+	case o.private:
+		return false, "the repo is private"
+	case !o.defaultBranch:
+		return false, "the workflow does not run on the default branch"
+	}
+	return true, ""
+}
+
+// shouldComment returns whether to comment on the pull request, and if not, why.
+func (o *options) shouldComment() (bool, string) {
+	switch {
+	case !o.commentOnPR:
+		return false, "comment_on_pr is not set"
+	case o.pullRequest == 0:
+		return false, "the workflow was not triggered by a pull request"
+	case o.githubToken == "":
+		return false, "github_token is not set"
+	}
+	return true, ""
+}
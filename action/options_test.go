@@ -0,0 +1,202 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+func TestReadOptions(t *testing.T) {
+	t.Parallel()
+	eventPath := filepath.Join(t.TempDir(), "event.json")
+	if err := os.WriteFile(eventPath,
+		[]byte(`{"repository": {"default_branch": "main", "private": true}, "pull_request": {"number": 7}}`),
+		0o600); err != nil {
+		t.Fatal(err)
+	}
+	base := map[string]string{
+		"GITHUB_REPOSITORY": "owner/repo",
+		"GITHUB_SHA":        "abc123",
+		"GITHUB_EVENT_PATH": eventPath,
+	}
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    *options
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{"GITHUB_REF": "refs/heads/feature"},
+			want: &options{
+				repo:          "github.com/owner/repo",
+				commit:        "abc123",
+				resultsFile:   defaultResultsFile,
+				resultsFormat: formatSARIF,
+				publishURL:    defaultPublishURL,
+				private:       true,
+				pullRequest:   7,
+				apiURL:        defaultAPIURL,
+			},
+		},
+		{
+			name: "default branch on enterprise server",
+			env: map[string]string{
+				"GITHUB_REF":            "refs/heads/main",
+				"GITHUB_SERVER_URL":     "https://ghe.example.com",
+				"INPUT_RESULTS_FILE":    "out.json",
+				"INPUT_RESULTS_FORMAT":  "JSON",
+				"INPUT_POLICY_FILE":     "policy.yml",
+				"INPUT_PUBLISH_RESULTS": "true",
+				"INPUT_PUBLISH_URL":     "https://scorecard.example.com/",
+				"INPUT_COMMENT_ON_PR":   "true",
+				"INPUT_GITHUB_TOKEN":    "workflow-token",
+				"GITHUB_API_URL":        "https://ghe.example.com/api/v3",
+			},
+			want: &options{
+				repo:          "ghe.example.com/owner/repo",
+				commit:        clients.HeadSHA,
+				resultsFile:   "out.json",
+				resultsFormat: formatJSON,
+				policyFile:    "policy.yml",
+				publish:       true,
+				publishURL:    "https://scorecard.example.com",
+				defaultBranch: true,
+				private:       true,
+				pullRequest:   7,
+				commentOnPR:   true,
+				githubToken:   "workflow-token",
+				apiURL:        "https://ghe.example.com/api/v3",
+			},
+		},
+		{
+			name:    "unsupported format",
+			env:     map[string]string{"INPUT_RESULTS_FORMAT": "csv"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid publish_results",
+			env:     map[string]string{"INPUT_PUBLISH_RESULTS": "maybe"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid comment_on_pr",
+			env:     map[string]string{"INPUT_COMMENT_ON_PR": "maybe"},
+			wantErr: true,
+		},
+		{
+			name:    "no repository",
+			env:     map[string]string{"GITHUB_REPOSITORY": ""},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			getenv := func(key string) string {
+				if v, ok := tt.env[key]; ok {
+					return v
+				}
+				return base[key]
+			}
+			got, err := readOptions(getenv)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(options{})); diff != "" {
+				t.Errorf("readOptions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestShouldPublish(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		opts options
+		want bool
+	}{
+		{
+			name: "public default branch",
+			opts: options{publish: true, defaultBranch: true},
+			want: true,
+		},
+		{
+			name: "not requested",
+			opts: options{defaultBranch: true},
+		},
+		{
+			name: "private",
+			opts: options{publish: true, defaultBranch: true, private: true},
+		},
+		{
+			name: "other branch",
+			opts: options{publish: true},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got, reason := tt.opts.shouldPublish(); got != tt.want {
+				t.Errorf("shouldPublish() = %v (%s), want %v", got, reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestShouldComment(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		opts options
+		want bool
+	}{
+		{
+			name: "pull request",
+			opts: options{commentOnPR: true, pullRequest: 7, githubToken: "token"},
+			want: true,
+		},
+		{
+			name: "not requested",
+			opts: options{pullRequest: 7, githubToken: "token"},
+		},
+		{
+			name: "push",
+			opts: options{commentOnPR: true, githubToken: "token"},
+		},
+		{
+			name: "no token",
+			opts: options{commentOnPR: true, pullRequest: 7},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got, reason := tt.opts.shouldComment(); got != tt.want {
+				t.Errorf("shouldComment() = %v (%s), want %v", got, reason, tt.want)
+			}
+		})
+	}
+}
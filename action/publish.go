@@ -0,0 +1,106 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// oidcAudience is the audience of the OIDC tokens the public API accepts.
+const oidcAudience = "api.securityscorecards.dev"
+
+// oidcSource requests OIDC tokens from the GitHub Actions runtime.
+// The workflow needs the `id-token: write` permission for the runtime to set
+// ACTIONS_ID_TOKEN_REQUEST_URL and ACTIONS_ID_TOKEN_REQUEST_TOKEN.
+type oidcSource struct {
+	requestURL   string
+	requestToken string
+}
+
+func newOIDCSource(getenv func(string) string) (*oidcSource, error) {
+	s := &oidcSource{
+		requestURL:   getenv("ACTIONS_ID_TOKEN_REQUEST_URL"),
+		requestToken: getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN"),
+	}
+	if s.requestURL == "" || s.requestToken == "" {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			"OIDC token not available, does the workflow have the `id-token: write` permission?")
+	}
+	return s, nil
+}
+
+// token returns an OIDC token proving the identity of the workflow.
+func (s *oidcSource) token(ctx context.Context, client *http.Client) (string, error) {
+	u, err := url.Parse(s.requestURL)
+	if err != nil {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("url.Parse: %v", err))
+	}
+	q := u.Query()
+	q.Set("audience", oidcAudience)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("http.NewRequestWithContext: %v", err))
+	}
+	req.Header.Set("Authorization", "Bearer "+s.requestToken)
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("client.Do: %v", err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("OIDC token request: %s", resp.Status))
+	}
+	var body struct {
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Decode: %v", err))
+	}
+	return body.Value, nil
+}
+
+// publishResults sends the JSON results of repo to the public API at publishURL,
+// which verifies the OIDC token was issued to a workflow of repo.
+func publishResults(ctx context.Context, client *http.Client, publishURL, repo, token string,
+	results []byte) error {
+	endpoint := fmt.Sprintf("%s/projects/%s", publishURL, repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(results))
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("http.NewRequestWithContext: %v", err))
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("client.Do: %v", err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("publishing results: %s: %s", resp.Status, bytes.TrimSpace(msg)))
+	}
+	return nil
+}
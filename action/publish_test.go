@@ -0,0 +1,88 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPublishResults(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(rw http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer request-token" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if got := r.URL.Query().Get("audience"); got != oidcAudience {
+			t.Errorf("audience = %q, want %q", got, oidcAudience)
+		}
+		io.WriteString(rw, `{"value": "oidc-token"}`)
+	})
+	var published string
+	mux.HandleFunc("/projects/github.com/owner/repo", func(rw http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer oidc-token" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		published = string(b)
+		rw.WriteHeader(http.StatusCreated)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	source, err := newOIDCSource(func(key string) string {
+		return map[string]string{
+			"ACTIONS_ID_TOKEN_REQUEST_URL":   server.URL + "/token?api-version=2.0",
+			"ACTIONS_ID_TOKEN_REQUEST_TOKEN": "request-token",
+		}[key]
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	token, err := source.token(ctx, server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := `{"score": 7}`
+	err = publishResults(ctx, server.Client(), server.URL, "github.com/owner/repo", token, []byte(results))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if published != results {
+		t.Errorf("published %q, want %q", published, results)
+	}
+
+	if err := publishResults(ctx, server.Client(), server.URL, "github.com/other/repo", token,
+		[]byte(results)); err == nil {
+		t.Error("publishResults() for an unknown repo succeeded, want error")
+	}
+}
+
+func TestNewOIDCSourceMissingPermission(t *testing.T) {
+	t.Parallel()
+	if _, err := newOIDCSource(func(string) string { return "" }); err == nil {
+		t.Error("newOIDCSource() succeeded without the id-token permission, want error")
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package attestation evaluates Scorecard results against a policy and
+// produces signed in-toto attestations of the outcome.
+package attestation
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/ossf/scorecard/v3/checker"
+	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/pkg"
+	spol "github.com/ossf/scorecard/v3/policy"
+)
+
+const (
+	// StatementType is the in-toto statement type.
+	StatementType = "https://in-toto.io/Statement/v0.1"
+	// PredicateType identifies Scorecard policy attestations.
+	PredicateType = "https://github.com/ossf/scorecard/attestation/v1"
+)
+
+var gitCommitRegex = regexp.MustCompile("^[0-9a-f]{40}$")
+
+// CheckEvaluation is the outcome of evaluating a check against its policy.
+type CheckEvaluation struct {
+	Name     string `json:"name"`
+	Score    int    `json:"score"`
+	MinScore int    `json:"minScore"`
+	Passed   bool   `json:"passed"`
+}
+
+// PolicyEvaluation is the outcome of evaluating results against a policy.
+type PolicyEvaluation struct {
+	Passed bool              `json:"passed"`
+	Checks []CheckEvaluation `json:"checks"`
+}
+
+// Evaluate compares the check scores against the minimum scores of the
+// enforced checks in the policy. Enforced checks that were not run or
+// have an inconclusive score fail the policy.
+func Evaluate(result *pkg.ScorecardResult, policy *spol.ScorecardPolicy) PolicyEvaluation {
+	scores := make(map[string]int, len(result.Checks))
+	for i := range result.Checks {
+		scores[result.Checks[i].Name] = result.Checks[i].Score
+	}
+
+	names := make([]string, 0, len(policy.GetPolicies()))
+	for name, cp := range policy.GetPolicies() {
+		if cp.GetMode() == spol.CheckPolicy_ENFORCED {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	ret := PolicyEvaluation{Passed: true, Checks: []CheckEvaluation{}}
+	for _, name := range names {
+		minScore := int(policy.GetPolicies()[name].GetScore())
+		score, ran := scores[name]
+		if !ran {
+			score = checker.InconclusiveResultScore
+		}
+		passed := score != checker.InconclusiveResultScore && score >= minScore
+		ret.Passed = ret.Passed && passed
+		ret.Checks = append(ret.Checks, CheckEvaluation{
+			Name:     name,
+			Score:    score,
+			MinScore: minScore,
+			Passed:   passed,
+		})
+	}
+	return ret
+}
+
+// Subject is the artifact an in-toto statement is about.
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// ScorecardInfo identifies the Scorecard version that produced the results.
+type ScorecardInfo struct {
+	Version   string `json:"version"`
+	CommitSHA string `json:"commit"`
+}
+
+// Predicate is the Scorecard-specific content of the statement.
+type Predicate struct {
+	Date      string           `json:"date"`
+	Scorecard ScorecardInfo    `json:"scorecard"`
+	Policy    PolicyEvaluation `json:"policy"`
+}
+
+// Statement is an in-toto statement asserting that the repo,
+// at the given commit, meets the policy.
+type Statement struct {
+	Type          string    `json:"_type"`
+	Subject       []Subject `json:"subject"`
+	PredicateType string    `json:"predicateType"`
+	Predicate     Predicate `json:"predicate"`
+}
+
+// NewStatement returns the statement for a result evaluated against a policy.
+func NewStatement(result *pkg.ScorecardResult, evaluation PolicyEvaluation) (*Statement, error) {
+	if !gitCommitRegex.MatchString(result.Repo.CommitSHA) {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("invalid commit SHA: %q", result.Repo.CommitSHA))
+	}
+	return &Statement{
+		Type: StatementType,
+		Subject: []Subject{
+			{
+				Name:   result.Repo.Name,
+				Digest: map[string]string{"gitCommit": result.Repo.CommitSHA},
+			},
+		},
+		PredicateType: PredicateType,
+		Predicate: Predicate{
+			Date: result.Date.UTC().Format(time.RFC3339),
+			Scorecard: ScorecardInfo{
+				Version:   result.Scorecard.Version,
+				CommitSHA: result.Scorecard.CommitSHA,
+			},
+			Policy: evaluation,
+		},
+	}, nil
+}
+
+func (s *Statement) marshal() ([]byte, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Marshal: %v", err))
+	}
+	return b, nil
+}
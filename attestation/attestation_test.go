@@ -0,0 +1,218 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/pkg"
+	spol "github.com/ossf/scorecard/v3/policy"
+)
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+	policy := &spol.ScorecardPolicy{
+		Version: 1,
+		Policies: map[string]*spol.CheckPolicy{
+			"Binary-Artifacts":  {Score: 10, Mode: spol.CheckPolicy_ENFORCED},
+			"Code-Review":       {Score: 5, Mode: spol.CheckPolicy_ENFORCED},
+			"Branch-Protection": {Score: 8, Mode: spol.CheckPolicy_DISABLED},
+		},
+	}
+	tests := []struct {
+		name   string
+		checks []checker.CheckResult
+		want   PolicyEvaluation
+	}{
+		{
+			name: "passes",
+			checks: []checker.CheckResult{
+				{Name: "Binary-Artifacts", Score: 10},
+				{Name: "Code-Review", Score: 5},
+				{Name: "Branch-Protection", Score: 0},
+			},
+			want: PolicyEvaluation{
+				Passed: true,
+				Checks: []CheckEvaluation{
+					{Name: "Binary-Artifacts", Score: 10, MinScore: 10, Passed: true},
+					{Name: "Code-Review", Score: 5, MinScore: 5, Passed: true},
+				},
+			},
+		},
+		{
+			name: "score too low",
+			checks: []checker.CheckResult{
+				{Name: "Binary-Artifacts", Score: 10},
+				{Name: "Code-Review", Score: 4},
+			},
+			want: PolicyEvaluation{
+				Checks: []CheckEvaluation{
+					{Name: "Binary-Artifacts", Score: 10, MinScore: 10, Passed: true},
+					{Name: "Code-Review", Score: 4, MinScore: 5},
+				},
+			},
+		},
+		{
+			name: "inconclusive and missing",
+			checks: []checker.CheckResult{
+				{Name: "Binary-Artifacts", Score: checker.InconclusiveResultScore},
+			},
+			want: PolicyEvaluation{
+				Checks: []CheckEvaluation{
+					{Name: "Binary-Artifacts", Score: checker.InconclusiveResultScore, MinScore: 10},
+					{Name: "Code-Review", Score: checker.InconclusiveResultScore, MinScore: 5},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Evaluate(&pkg.ScorecardResult{Checks: tt.checks}, policy)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Evaluate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	t.Parallel()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("x509.MarshalPKCS8PrivateKey: %v", err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	signer, err := LoadKeySigner(keyFile)
+	if err != nil {
+		t.Fatalf("LoadKeySigner: %v", err)
+	}
+
+	const commit = "0123456789abcdef0123456789abcdef01234567"
+	result := &pkg.ScorecardResult{
+		Repo:      pkg.RepoInfo{Name: "github.com/owner/repo", CommitSHA: commit},
+		Date:      time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC),
+		Scorecard: pkg.ScorecardInfo{Version: "v4.0.0", CommitSHA: "def456"},
+	}
+	statement, err := NewStatement(result, PolicyEvaluation{Passed: true, Checks: []CheckEvaluation{}})
+	if err != nil {
+		t.Fatalf("NewStatement: %v", err)
+	}
+	envelope, err := Sign(context.Background(), statement, signer)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	got, err := Verify(envelope, &key.PublicKey)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if diff := cmp.Diff(statement, got); diff != "" {
+		t.Errorf("Verify() mismatch (-want +got):\n%s", diff)
+	}
+	if got.Subject[0].Digest["gitCommit"] != commit {
+		t.Errorf("subject digest = %v, want gitCommit %s", got.Subject[0].Digest, commit)
+	}
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	if _, err := Verify(envelope, &other.PublicKey); err == nil {
+		t.Error("Verify succeeded with the wrong key")
+	}
+	tampered := *envelope
+	tampered.Payload = envelope.Payload[:len(envelope.Payload)-4] + "AAAA"
+	if _, err := Verify(&tampered, &key.PublicKey); err == nil {
+		t.Error("Verify succeeded with a tampered payload")
+	}
+}
+
+func TestNewStatementRequiresCommit(t *testing.T) {
+	t.Parallel()
+	for _, sha := range []string{"", "no commits found", "abc123"} {
+		result := &pkg.ScorecardResult{Repo: pkg.RepoInfo{CommitSHA: sha}}
+		if _, err := NewStatement(result, PolicyEvaluation{}); err == nil {
+			t.Errorf("NewStatement: expected error for commit SHA %q", sha)
+		}
+	}
+}
+
+func TestSignAndVerifyResult(t *testing.T) {
+	t.Parallel()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("x509.MarshalPKIXPublicKey: %v", err)
+	}
+	pubFile := filepath.Join(t.TempDir(), "key.pub")
+	if err := os.WriteFile(pubFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	pub, err := LoadPublicKey(context.Background(), pubFile)
+	if err != nil {
+		t.Fatalf("LoadPublicKey: %v", err)
+	}
+	signer := NewKeySigner(key, "test")
+
+	result := []byte(`{"repo": {"name": "github.com/owner/repo"}, "score": 7.5}` + "\n")
+	envelope, err := SignResult(context.Background(), result, signer)
+	if err != nil {
+		t.Fatalf("SignResult: %v", err)
+	}
+	got, err := VerifyResult(envelope, pub)
+	if err != nil {
+		t.Fatalf("VerifyResult: %v", err)
+	}
+	if diff := cmp.Diff(string(result), string(got)); diff != "" {
+		t.Errorf("VerifyResult() mismatch (-want +got):\n%s", diff)
+	}
+
+	// Attestations are not results, even if signed by the same key.
+	statement := *envelope
+	statement.PayloadType = PayloadType
+	if _, err := VerifyResult(&statement, pub); err == nil {
+		t.Error("VerifyResult succeeded with an attestation")
+	}
+	if _, err := SignResult(context.Background(), []byte("not json"), signer); err == nil {
+		t.Error("SignResult succeeded with an invalid result")
+	}
+	if _, err := LoadPublicKey(context.Background(), filepath.Join(t.TempDir(), "missing.pub")); err == nil {
+		t.Error("LoadPublicKey succeeded with a missing file")
+	}
+}
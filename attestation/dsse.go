@@ -0,0 +1,146 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// PayloadType is the DSSE payload type of in-toto statements.
+const PayloadType = "application/vnd.in-toto+json"
+
+var errInvalidSignature = errors.New("no valid signature")
+
+// Signer signs the DSSE pre-authentication encoding of a payload.
+type Signer interface {
+	KeyID() string
+	// Sign returns an ASN.1 DER ECDSA signature of the SHA-256 digest of data.
+	Sign(ctx context.Context, data []byte) ([]byte, error)
+}
+
+// Signature is a DSSE signature.
+type Signature struct {
+	KeyID string `json:"keyid"`
+	Sig   string `json:"sig"`
+}
+
+// Envelope is a DSSE envelope, the format cosign and
+// admission controllers expect attestations in.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+// pae is the DSSE pre-authentication encoding.
+// See https://github.com/secure-systems-lab/dsse/blob/master/protocol.md.
+func pae(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// Sign returns the statement in a DSSE envelope signed by signer.
+func Sign(ctx context.Context, statement *Statement, signer Signer) (*Envelope, error) {
+	payload, err := statement.marshal()
+	if err != nil {
+		return nil, err
+	}
+	return signPayload(ctx, PayloadType, payload, signer)
+}
+
+func signPayload(ctx context.Context, payloadType string, payload []byte, signer Signer) (*Envelope, error) {
+	sig, err := signer.Sign(ctx, pae(payloadType, payload))
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("signing: %v", err))
+	}
+	return &Envelope{
+		PayloadType: payloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+		Signatures: []Signature{
+			{KeyID: signer.KeyID(), Sig: base64.StdEncoding.EncodeToString(sig)},
+		},
+	}, nil
+}
+
+// Verify checks that the envelope is signed by key and returns its statement.
+func Verify(envelope *Envelope, key *ecdsa.PublicKey) (*Statement, error) {
+	payload, err := verifyPayload(envelope, PayloadType, key)
+	if err != nil {
+		return nil, err
+	}
+	var statement Statement
+	if err := json.Unmarshal(payload, &statement); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Unmarshal: %v", err))
+	}
+	return &statement, nil
+}
+
+// verifyPayload checks that the envelope holds a payload of payloadType signed by key, and returns it.
+func verifyPayload(envelope *Envelope, payloadType string, key *ecdsa.PublicKey) ([]byte, error) {
+	if envelope.PayloadType != payloadType {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("unexpected payload type: %s", envelope.PayloadType))
+	}
+	payload, err := base64.StdEncoding.DecodeString(envelope.Payload)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("decoding payload: %v", err))
+	}
+	digest := sha256.Sum256(pae(envelope.PayloadType, payload))
+	verified := false
+	for _, s := range envelope.Signatures {
+		sig, err := base64.StdEncoding.DecodeString(s.Sig)
+		if err != nil {
+			continue
+		}
+		if ecdsa.VerifyASN1(key, digest[:], sig) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, errInvalidSignature.Error())
+	}
+	return payload, nil
+}
+
+// keySigner signs with a local ECDSA private key.
+type keySigner struct {
+	key   *ecdsa.PrivateKey
+	keyID string
+}
+
+// NewKeySigner returns a signer using a local ECDSA private key.
+func NewKeySigner(key *ecdsa.PrivateKey, keyID string) Signer {
+	return &keySigner{key: key, keyID: keyID}
+}
+
+func (s *keySigner) KeyID() string {
+	return s.keyID
+}
+
+func (s *keySigner) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+	//nolint:wrapcheck
+	return s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
@@ -0,0 +1,40 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// ResultPayloadType is the DSSE payload type of signed JSON results.
+const ResultPayloadType = "application/vnd.ossf.scorecard.result+json"
+
+// SignResult returns a JSON result, as written by --format=json,
+// in a DSSE envelope signed by signer.
+func SignResult(ctx context.Context, result []byte, signer Signer) (*Envelope, error) {
+	if !json.Valid(result) {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, "the result is not valid JSON")
+	}
+	return signPayload(ctx, ResultPayloadType, result, signer)
+}
+
+// VerifyResult checks that the envelope holds a result signed by key and returns the result.
+func VerifyResult(envelope *Envelope, key *ecdsa.PublicKey) ([]byte, error) {
+	return verifyPayload(envelope, ResultPayloadType, key)
+}
@@ -0,0 +1,176 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attestation
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/cloudkms/v1"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// gcpKMSPrefix is the cosign URI scheme for Google Cloud KMS keys.
+const gcpKMSPrefix = "gcpkms://"
+
+// LoadKeySigner returns a signer for the unencrypted PEM-encoded
+// ECDSA private key in path.
+func LoadKeySigner(path string) (Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.ReadFile: %v", err))
+	}
+	key, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+	keyID, err := keyFingerprint(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySigner(key, keyID), nil
+}
+
+func parsePrivateKey(data []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, "no PEM block found in key")
+	}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("x509.ParseECPrivateKey: %v", err))
+		}
+		return key, nil
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("x509.ParsePKCS8PrivateKey: %v", err))
+		}
+		ecKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, "only ECDSA keys are supported")
+		}
+		return ecKey, nil
+	default:
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("unsupported PEM block %q, encrypted keys must be decrypted first", block.Type))
+	}
+}
+
+// LoadPublicKey returns the ECDSA public key verifying the signatures of ref:
+// a PEM-encoded public key file, or a gcpkms:// key version reference.
+func LoadPublicKey(ctx context.Context, ref string) (*ecdsa.PublicKey, error) {
+	var data []byte
+	if IsKMSKey(ref) {
+		service, err := cloudkms.NewService(ctx)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("cloudkms.NewService: %v", err))
+		}
+		resp, err := service.Projects.Locations.KeyRings.CryptoKeys.CryptoKeyVersions.GetPublicKey(
+			strings.TrimPrefix(ref, gcpKMSPrefix)).Context(ctx).Do()
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetPublicKey: %v", err))
+		}
+		data = []byte(resp.Pem)
+	} else {
+		var err error
+		if data, err = os.ReadFile(ref); err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.ReadFile: %v", err))
+		}
+	}
+	return parsePublicKey(data)
+}
+
+func parsePublicKey(data []byte) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, "no PEM public key found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("x509.ParsePKIXPublicKey: %v", err))
+	}
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, "only ECDSA keys are supported")
+	}
+	return ecKey, nil
+}
+
+// keyFingerprint is the hex SHA-256 of the DER-encoded public key.
+func keyFingerprint(key *ecdsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("x509.MarshalPKIXPublicKey: %v", err))
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// kmsSigner signs with an asymmetric EC_SIGN_P256_SHA256 Cloud KMS key version.
+type kmsSigner struct {
+	service *cloudkms.Service
+	name    string
+}
+
+// IsKMSKey reports whether ref is a KMS key reference, e.g.
+// gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1.
+func IsKMSKey(ref string) bool {
+	return strings.HasPrefix(ref, gcpKMSPrefix)
+}
+
+// NewKMSSigner returns a signer for a gcpkms:// key version reference,
+// authenticating with the application default credentials.
+func NewKMSSigner(ctx context.Context, ref string) (Signer, error) {
+	if !IsKMSKey(ref) {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("unsupported KMS key: %s", ref))
+	}
+	service, err := cloudkms.NewService(ctx)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("cloudkms.NewService: %v", err))
+	}
+	return &kmsSigner{service: service, name: strings.TrimPrefix(ref, gcpKMSPrefix)}, nil
+}
+
+func (s *kmsSigner) KeyID() string {
+	return gcpKMSPrefix + s.name
+}
+
+func (s *kmsSigner) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	digest := sha256.Sum256(data)
+	resp, err := s.service.Projects.Locations.KeyRings.CryptoKeys.CryptoKeyVersions.AsymmetricSign(
+		s.name, &cloudkms.AsymmetricSignRequest{
+			Digest: &cloudkms.Digest{Sha256: base64.StdEncoding.EncodeToString(digest[:])},
+		}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("AsymmetricSign: %w", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(resp.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("decoding signature: %w", err)
+	}
+	return sig, nil
+}
@@ -22,12 +22,24 @@ import (
 
 // CheckRequest struct encapsulates all data to be passed into a CheckFn.
 type CheckRequest struct {
-	Ctx         context.Context
-	RepoClient  clients.RepoClient
-	CIIClient   clients.CIIBestPracticesClient
-	OssFuzzRepo clients.RepoClient
-	Dlogger     DetailLogger
-	Repo        clients.Repo
+	Ctx                   context.Context
+	RepoClient            clients.RepoClient
+	CIIClient             clients.CIIBestPracticesClient
+	OssFuzzRepo           clients.RepoClient
+	Dlogger               DetailLogger
+	Repo                  clients.Repo
+	VulnerabilitiesClient clients.VulnerabilitiesClient
+	PackagesClient        clients.PackagesClient
+	// Upstream is the repo the repo is a fork or a mirror of, if the
+	// RepoClient is a clients.UpstreamReporter.
+	Upstream *clients.Upstream
 	// UPGRADEv6: return raw results instead of scores.
 	RawResults *RawResults
+	// RequiredStatusChecks are the status check context name patterns
+	// (path.Match syntax) that Branch-Protection expects protected branches
+	// to require.
+	RequiredStatusChecks []string
+	// DependencyVulnerabilities makes the Vulnerabilities check also look up
+	// the dependencies pinned by the repo's lockfiles in the vulnerabilities DB.
+	DependencyVulnerabilities bool
 }
@@ -18,6 +18,12 @@ package checker
 import (
 	"fmt"
 	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
 )
 
 // UPGRADEv2: to remove.
@@ -58,6 +64,25 @@ const (
 	FileTypeURL
 )
 
+// FindingType is the machine-readable kind of a finding, so consumers
+// can filter or group details without parsing their text.
+type FindingType string
+
+const (
+	// FindingBinaryArtifact is a binary checked into the repo.
+	FindingBinaryArtifact FindingType = "binary-artifact"
+	// FindingUnpinnedDependency is a dependency not pinned by hash.
+	FindingUnpinnedDependency FindingType = "unpinned-dependency"
+	// FindingInsecureDownload is a script downloaded and run without verification.
+	FindingInsecureDownload FindingType = "insecure-download"
+	// FindingWritePermission is a write permission granted to a workflow token.
+	FindingWritePermission FindingType = "write-permission"
+	// FindingUntrustedCheckout is a checkout of untrusted code in a privileged workflow.
+	FindingUntrustedCheckout FindingType = FindingType(DangerousWorkflowUntrustedCheckout)
+	// FindingScriptInjection is an attacker-controlled expression in a workflow script.
+	FindingScriptInjection FindingType = FindingType(DangerousWorkflowScriptInjection)
+)
+
 // OffsetDefault is used if we can't determine the offset, for example when referencing a file but not a
 // specific location in the file.
 const OffsetDefault = 1
@@ -66,19 +91,82 @@ const OffsetDefault = 1
 // This allows updating the definition easily.
 //nolint
 type LogMessage struct {
-	Text    string   // A short string explaining why the detail was recorded/logged.
-	Path    string   // Fullpath to the file.
-	Type    FileType // Type of file.
-	Offset  int      // Offset in the file of Path (line for source/text files).
-	Snippet string   // Snippet of code
+	Text      string      // A short string explaining why the detail was recorded/logged.
+	Path      string      // Fullpath to the file.
+	Type      FileType    // Type of file.
+	Offset    int         // Offset in the file of Path (line for source/text files).
+	EndOffset int         // Last line of a finding spanning several lines of a source file, if known.
+	Snippet   string      // Snippet of code
+	Finding   FindingType // Kind of finding, if any.
+	// Remediation is an optional machine-readable fix for a warning.
+	Remediation *Remediation
+	// Message is the catalog message of the detail, if any.
+	// The DetailLogger renders it as the Text if Text is empty.
+	Message *Message
 	// UPGRADEv3: to remove.
 	Version int // `3` to indicate the detail was logged using new structure.
 }
 
+// RemediationEffort estimates the effort needed to apply a remediation.
+type RemediationEffort string
+
+const (
+	// RemediationEffortLow is a change of settings or a single file.
+	RemediationEffortLow RemediationEffort = "Low"
+	// RemediationEffortMedium may require changes to the build or release process.
+	RemediationEffortMedium RemediationEffort = "Medium"
+	// RemediationEffortHigh requires changes across the project.
+	RemediationEffortHigh RemediationEffort = "High"
+)
+
+// Remediation describes how to fix the problem reported by a detail.
+type Remediation struct {
+	Text   string            // A short sentence describing the fix.
+	Steps  []string          // Steps to apply the fix.
+	Effort RemediationEffort // Estimated effort.
+	URL    string            // Link to the exact settings page or documentation, if any.
+	Patch  string            // Suggested patch in unified diff format, if any.
+}
+
+// TriageState is the state attached to a finding, by users with `scorecard annotate`
+// or by the maintainers of the repo in its .scorecard.yml.
+type TriageState string
+
+const (
+	// TriageAcceptedRisk means the finding is known and the risk is accepted.
+	TriageAcceptedRisk TriageState = "accepted-risk"
+	// TriageFixInProgress means the finding is being addressed.
+	TriageFixInProgress TriageState = "fix-in-progress"
+	// TriageFalsePositive means the finding is incorrect.
+	TriageFalsePositive TriageState = "false-positive"
+	// TriageAcknowledged means the maintainers know about the finding.
+	TriageAcknowledged TriageState = "acknowledged"
+	// TriageWontFix means the maintainers decided not to address the finding.
+	TriageWontFix TriageState = "wont-fix"
+	// TriageNotApplicable means the finding does not apply to the repo,
+	// e.g., binaries used as test data.
+	TriageNotApplicable TriageState = "not-applicable"
+)
+
+// Annotation attaches a triage state to the warnings of a check: the one
+// identified by FindingID, the ones in Paths, or all of them if neither is set.
+type Annotation struct {
+	FindingID string `json:"finding-id"`
+	Check     string `json:"check"`
+	// Paths are path.Match patterns of the files of the warnings.
+	// A pattern ending in /** matches all the files in a directory.
+	Paths  []string    `json:"paths,omitempty"`
+	State  TriageState `json:"state"`
+	Reason string      `json:"reason,omitempty"`
+	Date   time.Time   `json:"date"`
+}
+
 // CheckDetail contains information for each detail.
 type CheckDetail struct {
 	Msg  LogMessage
 	Type DetailType // Any of DetailWarn, DetailInfo, DetailDebug.
+	// Annotation is set when the detail was triaged.
+	Annotation *Annotation
 }
 
 // DetailLogger logs a CheckDetail struct.
@@ -118,9 +206,32 @@ type CheckResult struct {
 	Details2 []CheckDetail `json:"-"` // Details of tests and sub-checks
 	Score    int           `json:"-"` // {[-1,0...10], -1 = Inconclusive}
 	Reason   string        `json:"-"` // A sentence describing the check result (score, etc)
-
+	// ReasonMessage is the catalog message Reason was rendered from, if any.
+	ReasonMessage *Message `json:"-"`
+	// NotApplicable is set when the check does not apply to the repo,
+	// e.g. a check for evidence only public repos can have.
+	NotApplicable bool `json:"-"`
+	// APICalls and Duration are the API calls made and the wall time
+	// spent by the check, retries included.
+	APICalls int           `json:"-"`
+	Duration time.Duration `json:"-"`
 }
 
+// ResultState tells whether a check scored the repo, and why not.
+type ResultState string
+
+const (
+	// ResultScored means the check scored the repo, even with a low score.
+	ResultScored ResultState = "scored"
+	// ResultInconclusive means the check ran but had nothing to score,
+	// e.g. a repo without releases for Signed-Releases.
+	ResultInconclusive ResultState = "inconclusive"
+	// ResultRuntimeError means the check failed to run, e.g. an API error.
+	ResultRuntimeError ResultState = "runtime-error"
+	// ResultNotApplicable means the check does not apply to the repo.
+	ResultNotApplicable ResultState = "not-applicable"
+)
+
 // ====== Raw results for checks =========.
 
 // File represents a file.
@@ -136,7 +247,51 @@ type File struct {
 // for the Security-Policy check.
 type SecurityPolicyData struct {
 	// Files contains a list of files.
-	Files []File
+	Files []SecurityPolicyFile
+	// Insights are the declarations of the repo's SECURITY-INSIGHTS.yml, if any.
+	Insights *SecurityInsightsData
+}
+
+// SecurityPolicyFile contains the raw results
+// for a single security policy file.
+type SecurityPolicyFile struct {
+	File File
+	// HasContact is set if the policy contains an email address or URL.
+	HasContact bool
+	// HasTimeline is set if the policy mentions a disclosure or response timeline.
+	HasTimeline bool
+}
+
+// SecurityInsightsData contains the declarations of a SECURITY-INSIGHTS.yml
+// file, see https://github.com/ossf/security-insights-spec.
+type SecurityInsightsData struct {
+	File File
+	// SecurityContacts are the declared security contacts, e.g. email addresses.
+	SecurityContacts []string
+	// SecurityPolicy is the URL of the declared security policy, if any.
+	SecurityPolicy string
+	// DistributionPoints are the URIs the project is distributed from.
+	DistributionPoints []string
+	// SecurityTools are the declared security testing tools.
+	SecurityTools []SecurityInsightsTool
+}
+
+// SecurityInsightsTool is a security testing tool declared in SECURITY-INSIGHTS.yml.
+type SecurityInsightsTool struct {
+	// Type is the type of the tool, e.g. "sast" or "fuzzing".
+	Type string
+	Name string
+}
+
+// Tools returns the names of the declared tools of toolType.
+func (d *SecurityInsightsData) Tools(toolType string) []string {
+	var ret []string
+	for _, t := range d.SecurityTools {
+		if strings.EqualFold(t.Type, toolType) {
+			ret = append(ret, t.Name)
+		}
+	}
+	return ret
 }
 
 // BinaryArtifactData contains the raw results
@@ -146,11 +301,136 @@ type BinaryArtifactData struct {
 	Files []File
 }
 
+// BranchProtectionsData contains the raw results
+// for the Branch-Protection check.
+type BranchProtectionsData struct {
+	// Branches contains the default branch and the branches
+	// targeted by releases.
+	Branches []clients.BranchRef
+	// Inferred is set when some settings of the default branch could not be
+	// read, typically without an admin token, and were inferred from its history.
+	Inferred *BranchProtectionInference
+	// History is set instead of Branches for repos without a branch
+	// protection API, e.g., local directories.
+	History *BranchHistory
+	// MergeBot is set if PRs are merged by a bot enforcing reviews
+	// instead of the branch protection settings.
+	MergeBot *MergeBot
+}
+
+// MergeBot is a bot merging PRs only once they were reviewed,
+// e.g., Prow's Tide.
+type MergeBot struct {
+	Name string
+	// Evidence lists what the bot was detected from.
+	Evidence []string
+}
+
+// BranchProtectionInference contains branch protection settings
+// inferred from the observed history of a branch.
+type BranchProtectionInference struct {
+	Branch string
+	// ForcePushesBlocked is set if the merge commits of all recent PRs
+	// are still part of the branch history, i.e., it was not rewritten.
+	ForcePushesBlocked bool
+	// ReviewsRequired is set if all recent merged PRs were approved.
+	ReviewsRequired bool
+	// PullRequests is the number of merged PRs the inference is based on.
+	PullRequests int
+}
+
+// BranchHistory contains evidence of the protection of a branch found in
+// its git history alone. It is a heuristic: settings are not observed.
+type BranchHistory struct {
+	Branch string
+	// Commits is the number of recent commits of the branch.
+	Commits int
+	// DirectPushes is the number of those commits not introduced by a PR,
+	// as recognized from the messages of merge commits.
+	DirectPushes int
+	// BranchUpdates is the number of updates of the branch found in the reflog.
+	BranchUpdates int
+	// ForcePushes is the number of those updates which rewrote its history.
+	ForcePushes int
+}
+
+// DangerousWorkflowType is a dangerous pattern of a GitHub workflow.
+type DangerousWorkflowType string
+
+const (
+	// DangerousWorkflowUntrustedCheckout is a checkout of the code of a
+	// pull request in a workflow triggered by pull_request_target.
+	DangerousWorkflowUntrustedCheckout DangerousWorkflowType = "untrusted-checkout"
+	// DangerousWorkflowScriptInjection is an attacker-controlled expression,
+	// e.g., the title of a pull request, expanded in an inline script.
+	DangerousWorkflowScriptInjection DangerousWorkflowType = "script-injection"
+)
+
+// DangerousWorkflow is a dangerous pattern found in a workflow.
+type DangerousWorkflow struct {
+	Type DangerousWorkflowType
+	// File is the workflow, with the line of the pattern as Offset and the
+	// untrusted ref or expression as Snippet.
+	File File
+}
+
+// DangerousWorkflowData contains the raw results
+// for the Dangerous-Workflow check.
+type DangerousWorkflowData struct {
+	Workflows []DangerousWorkflow
+}
+
+// SignedReleasesData contains the raw results
+// for the Signed-Releases check.
+type SignedReleasesData struct {
+	// Attestations maps the digests of the release assets and container
+	// images to the attestations the forge stores for them.
+	Attestations map[string][]clients.Attestation
+	// Releases contains the most recent releases with assets.
+	Releases []clients.Release
+	// ContainerImages contains the latest version of the container images
+	// of the repo.
+	ContainerImages []clients.ContainerImage
+}
+
+// LockedDependency is a dependency pinned by a lockfile, e.g. go.sum.
+type LockedDependency struct {
+	clients.Dependency
+	// File is the lockfile, and the line the dependency is pinned at if known.
+	File File
+}
+
+// DeclaredLicense is the license a package manifest declares, e.g. the
+// license field of a package.json, or the license file of a Go module.
+type DeclaredLicense struct {
+	File File
+	// License is the declared SPDX license expression, e.g. "MIT OR Apache-2.0".
+	License string
+	// Missing is set for the Go modules without a license file of their own.
+	Missing bool
+}
+
 // RawResults contains results before a policy
 // is applied.
 type RawResults struct {
-	BinaryArtifactResults BinaryArtifactData
-	SecurityPolicyResults SecurityPolicyData
+	BinaryArtifactResults    BinaryArtifactData
+	BranchProtectionResults  BranchProtectionsData
+	DangerousWorkflowResults DangerousWorkflowData
+	SecurityPolicyResults    SecurityPolicyData
+	SignedReleasesResults    SignedReleasesData
+}
+
+// Merge sets the results of r which are set in from. Each check sets its own
+// results, so that checks run with a RawResults each, e.g. to discard the
+// results of a check which failed, can be merged into one.
+func (r *RawResults) Merge(from *RawResults) {
+	dst := reflect.ValueOf(r).Elem()
+	src := reflect.ValueOf(from).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		if !src.Field(i).IsZero() {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
 }
 
 // CreateProportionalScore creates a proportional score.
@@ -268,6 +548,47 @@ func CreateInconclusiveResult(name, reason string) CheckResult {
 	}
 }
 
+// CreateNotApplicableResult is used when
+// the check does not apply to the repo, so it has no score.
+func CreateNotApplicableResult(name, reason string) CheckResult {
+	ret := CreateInconclusiveResult(name, reason)
+	ret.NotApplicable = true
+	return ret
+}
+
+// State returns whether the check scored the repo. Results which are not
+// scored all have the InconclusiveResultScore, State tells them apart.
+func (r *CheckResult) State() ResultState {
+	switch {
+	case r.Error2 != nil:
+		return ResultRuntimeError
+	case r.NotApplicable:
+		return ResultNotApplicable
+	case r.Score == InconclusiveResultScore:
+		return ResultInconclusive
+	default:
+		return ResultScored
+	}
+}
+
+// Err returns why the check did not score the repo: its error, or
+// sce.ErrScoreInconclusive if it had nothing to score, or sce.ErrNotApplicable
+// if it does not apply to the repo. It returns nil
+// if the check scored the repo, even with a low score. Use
+// sce.IsTransient to tell whether running the check again may help.
+func (r *CheckResult) Err() error {
+	if r.Error2 != nil {
+		return r.Error2
+	}
+	if r.NotApplicable {
+		return sce.WithMessage(sce.ErrNotApplicable, r.Reason)
+	}
+	if r.Score == InconclusiveResultScore {
+		return sce.WithMessage(sce.ErrScoreInconclusive, r.Reason)
+	}
+	return nil
+}
+
 // CreateRuntimeErrorResult is used when the check fails to run because of a runtime error.
 func CreateRuntimeErrorResult(name string, e error) CheckResult {
 	return CheckResult{
@@ -18,11 +18,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	opencensusstats "go.opencensus.io/stats"
 	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
 
+	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
 	"github.com/ossf/scorecard/v3/stats"
 )
@@ -34,6 +37,14 @@ type Runner struct {
 	CheckRequest CheckRequest
 	CheckName    string
 	Repo         string
+	// Timeout is the deadline of the check, including its retries.
+	// A check which does not finish in time fails with a runtime error.
+	// Zero means no timeout.
+	Timeout time.Duration
+	// Running, if set, counts the check functions still running, including
+	// the ones abandoned at the timeout, so that the caller can wait for them
+	// before closing the clients they use.
+	Running *sync.WaitGroup
 }
 
 // CheckFn defined for convenience.
@@ -70,6 +81,9 @@ func (l *logger) Info3(msg *LogMessage) {
 		Msg:  *msg,
 	}
 	cd.Msg.Version = 3
+	if cd.Msg.Text == "" && cd.Msg.Message != nil {
+		cd.Msg.Text = cd.Msg.Message.String()
+	}
 	l.messages2 = append(l.messages2, cd)
 }
 
@@ -79,6 +93,9 @@ func (l *logger) Warn3(msg *LogMessage) {
 		Msg:  *msg,
 	}
 	cd.Msg.Version = 3
+	if cd.Msg.Text == "" && cd.Msg.Message != nil {
+		cd.Msg.Text = cd.Msg.Message.String()
+	}
 	l.messages2 = append(l.messages2, cd)
 }
 
@@ -88,14 +105,18 @@ func (l *logger) Debug3(msg *LogMessage) {
 		Msg:  *msg,
 	}
 	cd.Msg.Version = 3
+	if cd.Msg.Text == "" && cd.Msg.Message != nil {
+		cd.Msg.Text = cd.Msg.Message.String()
+	}
 	l.messages2 = append(l.messages2, cd)
 }
 
 func logStats(ctx context.Context, startTime time.Time, result *CheckResult) error {
 	runTimeInSecs := time.Now().Unix() - startTime.Unix()
-	opencensusstats.Record(ctx, stats.CheckRuntimeInSec.M(runTimeInSecs))
+	opencensusstats.Record(ctx, stats.CheckRuntimeInSec.M(runTimeInSecs),
+		stats.CheckDurationInMs.M(time.Since(startTime).Milliseconds()))
 
-	if result.Error != nil {
+	if result.Error2 != nil {
 		ctx, err := tag.New(ctx, tag.Upsert(stats.ErrorName, sce.GetName(result.Error2)))
 		if err != nil {
 			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("tag.New: %v", err))
@@ -111,18 +132,31 @@ func (r *Runner) Run(ctx context.Context, f CheckFn) CheckResult {
 	if err != nil {
 		panic(err)
 	}
+	ctx, span := trace.StartSpan(ctx, "Check."+r.CheckName)
+	defer span.End()
 	startTime := time.Now()
 
+	checkCtx := ctx
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
 	var res CheckResult
-	var l logger
+	var l *logger
 	for retriesRemaining := checkRetries; retriesRemaining > 0; retriesRemaining-- {
 		checkRequest := r.CheckRequest
-		checkRequest.Ctx = ctx
-		l = logger{}
-		checkRequest.Dlogger = &l
-		res = f(&checkRequest)
-		if res.Error2 != nil && errors.Is(res.Error2, sce.ErrRepoUnreachable) {
-			checkRequest.Dlogger.Warn("%v", res.Error2)
+		checkRequest.Ctx = checkCtx
+		if checkRequest.RepoClient != nil {
+			checkRequest.RepoClient = clients.WithContext(checkCtx, checkRequest.RepoClient)
+		}
+		if checkRequest.OssFuzzRepo != nil {
+			checkRequest.OssFuzzRepo = clients.WithContext(checkCtx, checkRequest.OssFuzzRepo)
+		}
+		res, l = r.runOnce(checkCtx, f, &checkRequest)
+		if res.Error2 != nil && errors.Is(res.Error2, sce.ErrRepoUnreachable) && checkCtx.Err() == nil {
+			l.Warn("%v", res.Error2)
 			continue
 		}
 		break
@@ -134,8 +168,53 @@ func (r *Runner) Run(ctx context.Context, f CheckFn) CheckResult {
 		res.Details = append(res.Details, d.Msg.Text)
 	}
 
+	res.Duration = time.Since(startTime)
 	if err := logStats(ctx, startTime, &res); err != nil {
 		panic(err)
 	}
+	span.AddAttributes(trace.Int64Attribute("score", int64(res.Score)))
+	if res.Error2 != nil {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: res.Error2.Error()})
+	}
 	return res
 }
+
+// runOnce runs the check until it returns or ctx is done. A check which
+// does not return in time is abandoned: its RepoClient calls fail once
+// ctx is done, the ones in flight too if the RepoClient makes them with ctx
+// (see clients.WithCallContext), and the details it logs afterwards are
+// discarded. It keeps running until then, see Runner.Running.
+func (r *Runner) runOnce(ctx context.Context, f CheckFn, checkRequest *CheckRequest) (CheckResult, *logger) {
+	l := &logger{}
+	checkRequest.Dlogger = l
+	done := make(chan CheckResult, 1)
+	if r.Running != nil {
+		r.Running.Add(1)
+	}
+	go func() {
+		if r.Running != nil {
+			defer r.Running.Done()
+		}
+		done <- f(checkRequest)
+	}()
+
+	select {
+	case res := <-done:
+		// Checks report a failed call in many ways, so blame the
+		// deadline for any error returned after it passed.
+		if res.Error2 != nil && ctx.Err() != nil {
+			res = r.contextErrorResult(ctx)
+		}
+		return res, l
+	case <-ctx.Done():
+		return r.contextErrorResult(ctx), &logger{}
+	}
+}
+
+func (r *Runner) contextErrorResult(ctx context.Context) CheckResult {
+	msg := ""
+	if r.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		msg = fmt.Sprintf("check timed out after %s", r.Timeout)
+	}
+	return CreateRuntimeErrorResult(r.CheckName, sce.Wrap(sce.ErrScorecardInternal, ctx.Err(), msg))
+}
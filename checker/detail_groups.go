@@ -0,0 +1,76 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+// DetailGroup is a group of details reporting the same kind of finding,
+// e.g. every unpinned dependency found by Pinned-Dependencies, so that
+// formatters can summarize them instead of listing hundreds of them.
+type DetailGroup struct {
+	Type DetailType
+	// Finding is the kind of finding of the details, if any.
+	// Details without one are grouped by Text.
+	Finding FindingType
+	// Text is the text of the first detail of the group.
+	Text string
+	// Paths are the distinct paths of the details, in order of appearance.
+	Paths []string
+	// Indexes are the indexes of the details in the slice passed to GroupDetails.
+	Indexes []int
+}
+
+// Count returns the number of details in the group.
+func (g *DetailGroup) Count() int {
+	return len(g.Indexes)
+}
+
+type detailGroupKey struct {
+	annotation TriageState
+	finding    FindingType
+	text       string
+	typ        DetailType
+}
+
+// GroupDetails groups details of the same type and finding, or of the same
+// type and text for details without a finding, in order of first appearance.
+// Annotated details are only grouped with details of the same annotation state.
+func GroupDetails(details []CheckDetail) []DetailGroup {
+	var groups []DetailGroup
+	indexes := make(map[detailGroupKey]int)
+	paths := make(map[detailGroupKey]map[string]bool)
+	for i := range details {
+		d := &details[i]
+		key := detailGroupKey{typ: d.Type, finding: d.Msg.Finding}
+		if d.Msg.Finding == "" {
+			key.text = d.Msg.Text
+		}
+		if d.Annotation != nil {
+			key.annotation = d.Annotation.State
+		}
+		j, ok := indexes[key]
+		if !ok {
+			j = len(groups)
+			indexes[key] = j
+			paths[key] = make(map[string]bool)
+			groups = append(groups, DetailGroup{Type: d.Type, Finding: d.Msg.Finding, Text: d.Msg.Text})
+		}
+		g := &groups[j]
+		g.Indexes = append(g.Indexes, i)
+		if d.Msg.Path != "" && !paths[key][d.Msg.Path] {
+			paths[key][d.Msg.Path] = true
+			g.Paths = append(g.Paths, d.Msg.Path)
+		}
+	}
+	return groups
+}
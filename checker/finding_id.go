@@ -0,0 +1,36 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// FindingIDLength is the length of the IDs returned by NewFindingID.
+const FindingIDLength = 12
+
+// NewFindingID returns a deterministic identifier for a finding of a check,
+// or of a probe of the check, at path. Attributes are the other values which
+// tell findings at the same path apart, e.g. the message ID of a detail.
+// Line numbers should not be part of the attributes, so that the ID survives
+// unrelated edits to the file.
+func NewFindingID(check, probe, path string, attributes ...string) string {
+	// Fields are NUL-separated so that they cannot run into each other.
+	fields := append([]string{check, probe, path}, attributes...)
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x00")))
+	return hex.EncodeToString(sum[:])[:FindingIDLength]
+}
@@ -0,0 +1,480 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+// Messages of the License check.
+const (
+	MsgLicenseDetected            MessageID = "license.detected"
+	MsgLicenseNotDetected         MessageID = "license.not-detected"
+	MsgLicenseManifestInvalid     MessageID = "license.manifest-invalid"
+	MsgLicenseIdentified          MessageID = "license.identified"
+	MsgLicenseDeclarationMatch    MessageID = "license.declaration-match"
+	MsgLicenseDeclarationMismatch MessageID = "license.declaration-mismatch"
+	MsgLicenseDeclarationUnknown  MessageID = "license.declaration-unknown"
+	MsgLicenseGoModuleMissing     MessageID = "license.go-module-missing"
+)
+
+// Messages of the Vulnerabilities check.
+const (
+	MsgVulnerabilitiesNoClient             MessageID = "vulnerabilities.no-client"
+	MsgVulnerabilitiesNoCommits            MessageID = "vulnerabilities.no-commits"
+	MsgVulnerabilitiesHeadVulnerable       MessageID = "vulnerabilities.head-vulnerable"
+	MsgVulnerabilitiesDetected             MessageID = "vulnerabilities.detected"
+	MsgVulnerabilitiesNotDetected          MessageID = "vulnerabilities.not-detected"
+	MsgVulnerabilitiesLockfileInvalid      MessageID = "vulnerabilities.lockfile-invalid"
+	MsgVulnerabilitiesDependenciesChecked  MessageID = "vulnerabilities.dependencies-checked"
+	MsgVulnerabilitiesDependencyCritical   MessageID = "vulnerabilities.dependency-critical"
+	MsgVulnerabilitiesDependencyVulnerable MessageID = "vulnerabilities.dependency-vulnerable"
+	MsgVulnerabilitiesCriticalDependencies MessageID = "vulnerabilities.critical-dependencies"
+)
+
+// Messages of the Fuzzing check.
+const (
+	MsgFuzzingClusterFuzzLite MessageID = "fuzzing.clusterfuzzlite"
+	MsgFuzzingOSSFuzz         MessageID = "fuzzing.oss-fuzz"
+	MsgFuzzingNotFuzzed       MessageID = "fuzzing.not-fuzzed"
+)
+
+// Messages of the Contributors check.
+const (
+	MsgContributorsCompanies      MessageID = "contributors.companies"
+	MsgContributorsCompaniesFound MessageID = "contributors.companies-found"
+)
+
+// Messages of the CII-Best-Practices check.
+const (
+	MsgCIIBestPracticesNoClient MessageID = "cii-best-practices.no-client"
+	MsgCIIBestPracticesNoBadge  MessageID = "cii-best-practices.no-badge"
+	MsgCIIBestPracticesBadge    MessageID = "cii-best-practices.badge"
+)
+
+// Messages of the Dependency-Update-Tool check.
+const (
+	MsgDependencyUpdateToolNoDependabot MessageID = "dependency-update-tool.no-dependabot"
+	MsgDependencyUpdateToolNoRenovate   MessageID = "dependency-update-tool.no-renovate"
+	MsgDependencyUpdateToolNotDetected  MessageID = "dependency-update-tool.not-detected"
+	MsgDependencyUpdateToolDetected     MessageID = "dependency-update-tool.detected"
+	MsgDependencyUpdateToolDependabot   MessageID = "dependency-update-tool.dependabot"
+	MsgDependencyUpdateToolRenovate     MessageID = "dependency-update-tool.renovate"
+)
+
+// Messages of the Security-Advisories check.
+const (
+	MsgSecurityAdvisoriesNone      MessageID = "security-advisories.none"
+	MsgSecurityAdvisoriesCVE       MessageID = "security-advisories.cve"
+	MsgSecurityAdvisoriesNoCVE     MessageID = "security-advisories.no-cve"
+	MsgSecurityAdvisoriesFixed     MessageID = "security-advisories.fixed"
+	MsgSecurityAdvisoriesNotFixed  MessageID = "security-advisories.not-fixed"
+	MsgSecurityAdvisoriesPublished MessageID = "security-advisories.published"
+)
+
+// Messages of the Security-Policy check.
+const (
+	MsgSecurityPolicyNotDetected        MessageID = "security-policy.not-detected"
+	MsgSecurityPolicyDetectedInOrg      MessageID = "security-policy.detected-in-org"
+	MsgSecurityPolicyDetected           MessageID = "security-policy.detected"
+	MsgSecurityPolicyNoContact          MessageID = "security-policy.no-contact"
+	MsgSecurityPolicyNoTimeline         MessageID = "security-policy.no-timeline"
+	MsgSecurityPolicyFileDetected       MessageID = "security-policy.file-detected"
+	MsgSecurityPolicyMissingInformation MessageID = "security-policy.missing-information"
+)
+
+// Messages of the Binary-Artifacts check.
+const (
+	MsgBinaryArtifactsNotFound MessageID = "binary-artifacts.not-found"
+	MsgBinaryArtifactsDetected MessageID = "binary-artifacts.detected"
+	MsgBinaryArtifactsPresent  MessageID = "binary-artifacts.present"
+)
+
+// Messages of the Dangerous-Workflow check.
+const (
+	MsgDangerousWorkflowNotDetected       MessageID = "dangerous-workflow.not-detected"
+	MsgDangerousWorkflowUntrustedCheckout MessageID = "dangerous-workflow.untrusted-checkout"
+	MsgDangerousWorkflowScriptInjection   MessageID = "dangerous-workflow.script-injection"
+	MsgDangerousWorkflowDetected          MessageID = "dangerous-workflow.detected"
+)
+
+// Messages of the Signed-Releases check.
+const (
+	MsgSignedReleasesReleaseFound     MessageID = "signed-releases.release-found"
+	MsgSignedReleasesProvenance       MessageID = "signed-releases.provenance"
+	MsgSignedReleasesSigned           MessageID = "signed-releases.signed"
+	MsgSignedReleasesNoProvenance     MessageID = "signed-releases.no-provenance"
+	MsgSignedReleasesNotSigned        MessageID = "signed-releases.not-signed"
+	MsgSignedReleasesNoGitHubReleases MessageID = "signed-releases.no-github-releases"
+	MsgSignedReleasesNoReleases       MessageID = "signed-releases.no-releases"
+	MsgSignedReleasesSummary          MessageID = "signed-releases.summary"
+	MsgSignedReleasesImageFound       MessageID = "signed-releases.image-found"
+	MsgSignedReleasesImageProvenance  MessageID = "signed-releases.image-provenance"
+	MsgSignedReleasesImageSigned      MessageID = "signed-releases.image-signed"
+	MsgSignedReleasesImageNoSLSA      MessageID = "signed-releases.image-no-provenance"
+	MsgSignedReleasesImageNotSigned   MessageID = "signed-releases.image-not-signed"
+)
+
+// Messages shared by several checks.
+const (
+	MsgSampled MessageID = "sampled"
+)
+
+// Messages of the Maintained check.
+const (
+	MsgMaintainedArchived         MessageID = "maintained.archived"
+	MsgMaintainedActivity         MessageID = "maintained.activity"
+	MsgMaintainedMirror           MessageID = "maintained.mirror"
+	MsgMaintainedMirrorOf         MessageID = "maintained.mirror-of"
+	MsgMaintainedArchivedUpstream MessageID = "maintained.archived-upstream"
+	MsgMaintainedActiveUpstream   MessageID = "maintained.active-upstream"
+)
+
+// Messages of the Pinned-Dependencies check.
+const (
+	MsgPinnedInsecureDownload          MessageID = "pinned-dependencies.insecure-download"
+	MsgPinnedAllPinned                 MessageID = "pinned-dependencies.all-pinned"
+	MsgPinnedUnpinnedDetected          MessageID = "pinned-dependencies.unpinned-detected"
+	MsgPinnedActionsPinned             MessageID = "pinned-dependencies.actions-pinned"
+	MsgPinnedShellDownloadsPinned      MessageID = "pinned-dependencies.shell-downloads-pinned"
+	MsgPinnedDockerfileDownloadsPinned MessageID = "pinned-dependencies.dockerfile-downloads-pinned"
+	MsgPinnedDockerfilePinned          MessageID = "pinned-dependencies.dockerfile-pinned"
+	MsgPinnedWorkflowDownloadsPinned   MessageID = "pinned-dependencies.workflow-downloads-pinned"
+	MsgPinnedDockerfileUnpinned        MessageID = "pinned-dependencies.dockerfile-unpinned"
+	MsgPinnedActionUnpinned            MessageID = "pinned-dependencies.action-unpinned"
+)
+
+// Messages of the Token-Permissions check.
+const (
+	MsgPermissionsReadOnly                MessageID = "token-permissions.read-only"
+	MsgPermissionsNotReadOnly             MessageID = "token-permissions.not-read-only"
+	MsgPermissionsScopeSet                MessageID = "token-permissions.scope-set"
+	MsgPermissionsNone                    MessageID = "token-permissions.none"
+	MsgPermissionsSet                     MessageID = "token-permissions.set"
+	MsgPermissionsUndefined               MessageID = "token-permissions.undefined"
+	MsgPermissionsCodeQLUploadWorkflow    MessageID = "token-permissions.codeql-upload-workflow"
+	MsgPermissionsNotCodeQLUploadWorkflow MessageID = "token-permissions.not-codeql-upload-workflow"
+	MsgPermissionsCodeQLWorkflow          MessageID = "token-permissions.codeql-workflow"
+	MsgPermissionsNotCodeQLWorkflow       MessageID = "token-permissions.not-codeql-workflow"
+)
+
+// Messages of the SAST check.
+const (
+	MsgSASTInternalError              MessageID = "sast.internal-error"
+	MsgSASTRunOnAllCommits            MessageID = "sast.run-on-all-commits"
+	MsgSASTNotRunOnAllCommits         MessageID = "sast.not-run-on-all-commits"
+	MsgSASTDetectedNotRunOnAllCommits MessageID = "sast.detected-not-run-on-all-commits"
+	MsgSASTDetected                   MessageID = "sast.detected"
+	MsgSASTNotDetected                MessageID = "sast.not-detected"
+	MsgSASTNoMergedPRs                MessageID = "sast.no-merged-prs"
+	MsgSASTToolDetected               MessageID = "sast.tool-detected"
+	MsgSASTAllCommitsChecked          MessageID = "sast.all-commits-checked"
+	MsgSASTCommitsChecked             MessageID = "sast.commits-checked"
+	MsgSASTCodeQLDetected             MessageID = "sast.codeql-detected"
+	MsgSASTCodeQLToolDetected         MessageID = "sast.codeql-tool-detected"
+	MsgSASTCodeQLNotDetected          MessageID = "sast.codeql-not-detected"
+)
+
+// Messages of the Code-Review check.
+const (
+	MsgCodeReviewNoReviews              MessageID = "code-review.no-reviews"
+	MsgCodeReviewReviewsFound           MessageID = "code-review.reviews-found"
+	MsgCodeReviewReviewsFoundNormalized MessageID = "code-review.reviews-found-normalized"
+	MsgCodeReviewNoCommits              MessageID = "code-review.no-commits"
+	MsgCodeReviewSkipBotPR              MessageID = "code-review.skip-bot-pr"
+	MsgCodeReviewApprovedPR             MessageID = "code-review.approved-pr"
+	MsgCodeReviewCommitterNotAuthor     MessageID = "code-review.committer-not-author"
+	MsgCodeReviewUnreviewedPR           MessageID = "code-review.unreviewed-pr"
+	MsgCodeReviewBotPRsNotCounted       MessageID = "code-review.bot-prs-not-counted"
+	MsgCodeReviewBorsUnapproved         MessageID = "code-review.bors-unapproved"
+	MsgCodeReviewBorsApproved           MessageID = "code-review.bors-approved"
+	MsgCodeReviewSkipBotCommit          MessageID = "code-review.skip-bot-commit"
+	MsgCodeReviewGerritReview           MessageID = "code-review.gerrit-review"
+)
+
+// Messages of the CI-Tests check.
+const (
+	MsgCITestsUntestedPR MessageID = "ci-tests.untested-pr"
+	MsgCITestsNoPRs      MessageID = "ci-tests.no-prs"
+	MsgCITestsSummary    MessageID = "ci-tests.summary"
+	MsgCITestsFound      MessageID = "ci-tests.found"
+)
+
+// Messages of the Branch-Protection check.
+const (
+	MsgBranchProtectionForcePushesEnabled           MessageID = "branch-protection.force-pushes-enabled"
+	MsgBranchProtectionForcePushesDisabled          MessageID = "branch-protection.force-pushes-disabled"
+	MsgBranchProtectionDeletionEnabled              MessageID = "branch-protection.deletion-enabled"
+	MsgBranchProtectionDeletionDisabled             MessageID = "branch-protection.deletion-disabled"
+	MsgBranchProtectionLinearHistoryRequired        MessageID = "branch-protection.linear-history-required"
+	MsgBranchProtectionLinearHistoryNotRequired     MessageID = "branch-protection.linear-history-not-required"
+	MsgBranchProtectionSignedCommitsRequired        MessageID = "branch-protection.signed-commits-required"
+	MsgBranchProtectionAdminsIncluded               MessageID = "branch-protection.admins-included"
+	MsgBranchProtectionAdminsNotIncluded            MessageID = "branch-protection.admins-not-included"
+	MsgBranchProtectionAdminsUnknown                MessageID = "branch-protection.admins-unknown"
+	MsgBranchProtectionNoStatusChecks               MessageID = "branch-protection.no-status-checks"
+	MsgBranchProtectionStatusChecks                 MessageID = "branch-protection.status-checks"
+	MsgBranchProtectionRequiredWorkflow             MessageID = "branch-protection.required-workflow"
+	MsgBranchProtectionRequiredContextMatched       MessageID = "branch-protection.required-context-matched"
+	MsgBranchProtectionRequiredContextMissing       MessageID = "branch-protection.required-context-missing"
+	MsgBranchProtectionUpToDateRequired             MessageID = "branch-protection.up-to-date-required"
+	MsgBranchProtectionUpToDateNotRequired          MessageID = "branch-protection.up-to-date-not-required"
+	MsgBranchProtectionUpToDateUnknown              MessageID = "branch-protection.up-to-date-unknown"
+	MsgBranchProtectionStaleReviewDismissalEnabled  MessageID = "branch-protection.stale-review-dismissal-enabled"
+	MsgBranchProtectionStaleReviewDismissalDisabled MessageID = "branch-protection.stale-review-dismissal-disabled"
+	MsgBranchProtectionStaleReviewDismissalUnknown  MessageID = "branch-protection.stale-review-dismissal-unknown"
+	MsgBranchProtectionReviewers                    MessageID = "branch-protection.reviewers"
+	MsgBranchProtectionTooFewReviewers              MessageID = "branch-protection.too-few-reviewers"
+	MsgBranchProtectionNoReviewers                  MessageID = "branch-protection.no-reviewers"
+	MsgBranchProtectionNotEnabled                   MessageID = "branch-protection.not-enabled"
+	MsgBranchProtectionNoBranches                   MessageID = "branch-protection.no-branches"
+	MsgBranchProtectionDisabled                     MessageID = "branch-protection.disabled"
+	MsgBranchProtectionMaximal                      MessageID = "branch-protection.maximal"
+	MsgBranchProtectionNotMaximal                   MessageID = "branch-protection.not-maximal"
+	MsgBranchProtectionNoCommits                    MessageID = "branch-protection.no-commits"
+	MsgBranchProtectionNoReflog                     MessageID = "branch-protection.no-reflog"
+	MsgBranchProtectionNoForcePushes                MessageID = "branch-protection.no-force-pushes"
+	MsgBranchProtectionForcePushes                  MessageID = "branch-protection.force-pushes"
+	MsgBranchProtectionMergedFromPRs                MessageID = "branch-protection.merged-from-prs"
+	MsgBranchProtectionDirectPushes                 MessageID = "branch-protection.direct-pushes"
+	MsgBranchProtectionInferredFromHistory          MessageID = "branch-protection.inferred-from-history"
+	MsgBranchProtectionInferredForcePushesDisabled  MessageID = "branch-protection.inferred-force-pushes-disabled"
+	MsgBranchProtectionInferredReviewsRequired      MessageID = "branch-protection.inferred-reviews-required"
+	MsgBranchProtectionMergeBot                     MessageID = "branch-protection.merge-bot"
+)
+
+// Messages of the Packaging check.
+const (
+	MsgPackagingWorkflowUsed           MessageID = "packaging.workflow-used"
+	MsgPackagingWorkflowDetected       MessageID = "packaging.workflow-detected"
+	MsgPackagingWorkflowNotUsed        MessageID = "packaging.workflow-not-used"
+	MsgPackagingNoWorkflow             MessageID = "packaging.no-workflow"
+	MsgPackagingPackageDetected        MessageID = "packaging.package-detected"
+	MsgPackagingNoPackage              MessageID = "packaging.no-package"
+	MsgPackagingPackagePublished       MessageID = "packaging.package-published"
+	MsgPackagingCandidateWorkflow      MessageID = "packaging.candidate-workflow"
+	MsgPackagingCandidateWorkflowUsing MessageID = "packaging.candidate-workflow-using"
+	MsgPackagingNotPackagingWorkflow   MessageID = "packaging.not-packaging-workflow"
+)
+
+// Messages about the declarations of SECURITY-INSIGHTS.yml, used by several checks.
+const (
+	MsgSecurityInsightsInvalid                 MessageID = "security-insights.invalid"
+	MsgSecurityInsightsContactDeclared         MessageID = "security-insights.contact-declared"
+	MsgSecurityInsightsPolicyNotDetected       MessageID = "security-insights.policy-not-detected"
+	MsgSecurityInsightsToolDetected            MessageID = "security-insights.tool-detected"
+	MsgSecurityInsightsToolNotDetected         MessageID = "security-insights.tool-not-detected"
+	MsgSecurityInsightsDistributionDetected    MessageID = "security-insights.distribution-detected"
+	MsgSecurityInsightsDistributionNotDetected MessageID = "security-insights.distribution-not-detected"
+)
+
+// messageCatalog maps the message IDs to their English template.
+//nolint:lll
+var messageCatalog = map[MessageID]string{
+	MsgLicenseDetected:            "license file detected",
+	MsgLicenseNotDetected:         "license file not detected",
+	MsgLicenseManifestInvalid:     "cannot parse manifest {file}: {error}",
+	MsgLicenseIdentified:          "license file {file} identified as {license}",
+	MsgLicenseDeclarationMatch:    "{file} declares {declared}, matching the repository license {license}",
+	MsgLicenseDeclarationMismatch: "{file} declares {declared}, but the repository license is {license}",
+	MsgLicenseDeclarationUnknown:  "{file} declares {declared}, which cannot be compared with the repository license",
+	MsgLicenseGoModuleMissing:     "{file} declares a Go module without a license file of its own, so its module zip has no license",
+
+	MsgVulnerabilitiesNoClient:             "vulnerabilities client is nil",
+	MsgVulnerabilitiesNoCommits:            "no commits found",
+	MsgVulnerabilitiesHeadVulnerable:       "HEAD is vulnerable to {ids}",
+	MsgVulnerabilitiesDetected:             "existing vulnerabilities detected",
+	MsgVulnerabilitiesNotDetected:          "no vulnerabilities detected",
+	MsgVulnerabilitiesLockfileInvalid:      "cannot parse lockfile {file}: {error}",
+	MsgVulnerabilitiesDependenciesChecked:  "{count} dependencies pinned by lockfiles looked up",
+	MsgVulnerabilitiesDependencyCritical:   "{ecosystem} dependency {name}@{version} is affected by the critical advisory {id}",
+	MsgVulnerabilitiesDependencyVulnerable: "{ecosystem} dependency {name}@{version} is affected by {id}, of {severity} severity",
+	MsgVulnerabilitiesCriticalDependencies: "{count} critical advisories affect the dependencies pinned by lockfiles",
+
+	MsgFuzzingClusterFuzzLite: "project uses ClusterFuzzLite",
+	MsgFuzzingOSSFuzz:         "project is fuzzed in OSS-Fuzz",
+	MsgFuzzingNotFuzzed:       "project is not fuzzed",
+
+	MsgContributorsCompanies:      "contributors work for: {companies} (top contributors: {contributors})",
+	MsgContributorsCompaniesFound: "{count} different companies found",
+
+	MsgCIIBestPracticesNoClient: "CII client is nil",
+	MsgCIIBestPracticesNoBadge:  "no badge detected",
+	MsgCIIBestPracticesBadge:    "badge detected: {level}",
+
+	MsgDependencyUpdateToolNoDependabot: "dependabot config file not detected in source location.\n\t\t\tWe recommend setting this configuration in code so it can be easily verified by others.",
+	MsgDependencyUpdateToolNoRenovate:   "renovatebot config file not detected in source location.\n\t\t\tWe recommend setting this configuration in code so it can be easily verified by others.",
+	MsgDependencyUpdateToolNotDetected:  "no update tool detected",
+	MsgDependencyUpdateToolDetected:     "update tool detected",
+	MsgDependencyUpdateToolDependabot:   "dependabot detected",
+	MsgDependencyUpdateToolRenovate:     "renovate detected",
+
+	MsgSecurityAdvisoriesNone:      "no published security advisories",
+	MsgSecurityAdvisoriesCVE:       "advisory {advisory} has CVE {cve}",
+	MsgSecurityAdvisoriesNoCVE:     "advisory {advisory} has no CVE",
+	MsgSecurityAdvisoriesFixed:     "advisory {advisory} is fixed in release {release}",
+	MsgSecurityAdvisoriesNotFixed:  "no release found for patched versions of advisory {advisory}: {versions}",
+	MsgSecurityAdvisoriesPublished: "{count} advisories published, with {points} out of {total} expected CVEs and patched releases",
+
+	MsgSecurityPolicyNotDetected:        "security policy file not detected",
+	MsgSecurityPolicyDetectedInOrg:      "security policy detected in org repo",
+	MsgSecurityPolicyDetected:           "security policy detected",
+	MsgSecurityPolicyNoContact:          "security policy does not contain an email address or URL to report vulnerabilities",
+	MsgSecurityPolicyNoTimeline:         "security policy does not contain a disclosure timeline",
+	MsgSecurityPolicyFileDetected:       "security policy file detected",
+	MsgSecurityPolicyMissingInformation: "security policy file detected with missing information",
+
+	MsgBinaryArtifactsNotFound: "no binaries found in the repo",
+	MsgBinaryArtifactsDetected: "binary detected",
+	MsgBinaryArtifactsPresent:  "binaries present in source code",
+
+	MsgDangerousWorkflowNotDetected:       "no dangerous workflow patterns detected",
+	MsgDangerousWorkflowUntrustedCheckout: "untrusted code checkout '{ref}'",
+	MsgDangerousWorkflowScriptInjection:   "script injection with untrusted input '{input}'",
+	MsgDangerousWorkflowDetected:          "dangerous workflow patterns detected",
+
+	MsgSignedReleasesReleaseFound:     "GitHub release found: {release}",
+	MsgSignedReleasesProvenance:       "provenance for release artifacts: {asset}",
+	MsgSignedReleasesSigned:           "signed release artifact: {asset}",
+	MsgSignedReleasesNoProvenance:     "release artifact {release} has no SLSA provenance",
+	MsgSignedReleasesNotSigned:        "release artifact {release} not signed",
+	MsgSignedReleasesNoGitHubReleases: "no GitHub releases found",
+	MsgSignedReleasesNoReleases:       "no releases found",
+	MsgSignedReleasesSummary:          "{signed} out of {total} artifacts are signed or have provenance",
+	MsgSignedReleasesImageFound:       "container image found: {image}",
+	MsgSignedReleasesImageProvenance:  "provenance for container image: {image}",
+	MsgSignedReleasesImageSigned:      "signed container image: {image}",
+	MsgSignedReleasesImageNoSLSA:      "container image {image} has no SLSA provenance",
+	MsgSignedReleasesImageNotSigned:   "container image {image} not signed",
+
+	MsgSampled: "sampled {size} of {population} {what} (systematic, every {interval}; {confidence}% confidence, {margin}% margin of error)",
+
+	MsgMaintainedArchived:         "repo is marked as archived",
+	MsgMaintainedActivity:         "{commits} commit(s) out of {totalCommits} and {issues} issue activity out of {totalIssues} found in the last {days} days",
+	MsgMaintainedMirror:           "repo is a read-only mirror, its commits are pushed by {bot}: score its upstream instead",
+	MsgMaintainedMirrorOf:         "repo is a read-only mirror of {upstream}: score its upstream instead",
+	MsgMaintainedArchivedUpstream: "repo is a read-only mirror of {upstream}, which is archived",
+	MsgMaintainedActiveUpstream:   "repo is a fork of {upstream}, which was updated in the last {days} days, with no activity of its own: score its upstream instead",
+
+	MsgPinnedInsecureDownload:          "insecure (not pinned by hash) download detected",
+	MsgPinnedAllPinned:                 "all dependencies are pinned",
+	MsgPinnedUnpinnedDetected:          "dependency not pinned by hash detected",
+	MsgPinnedActionsPinned:             "{owner} actions are pinned",
+	MsgPinnedShellDownloadsPinned:      "no insecure (not pinned by hash) dependency downloads found in shell scripts",
+	MsgPinnedDockerfileDownloadsPinned: "no insecure (not pinned by hash) dependency downloads found in Dockerfiles",
+	MsgPinnedDockerfilePinned:          "Dockerfile dependencies are pinned",
+	MsgPinnedWorkflowDownloadsPinned:   "no insecure (not pinned by hash) dependency downloads found in GitHub workflows",
+	MsgPinnedDockerfileUnpinned:        "dependency not pinned by hash: '{name}'",
+	MsgPinnedActionUnpinned:            "{owner} dependency not pinned by hash (job '{job}')",
+
+	MsgPermissionsReadOnly:                "tokens are read-only in GitHub workflows",
+	MsgPermissionsNotReadOnly:             "non read-only tokens detected in GitHub workflows",
+	MsgPermissionsScopeSet:                "{level} '{permission}' permission set to '{value}'",
+	MsgPermissionsNone:                    "{level} permissions set to 'none'",
+	MsgPermissionsSet:                     "{level} permissions set to '{value}'",
+	MsgPermissionsUndefined:               "no {level} permission defined",
+	MsgPermissionsCodeQLUploadWorkflow:    "codeql SARIF upload workflow detected",
+	MsgPermissionsNotCodeQLUploadWorkflow: "not a codeql upload SARIF workflow",
+	MsgPermissionsCodeQLWorkflow:          "codeql workflow detected",
+	MsgPermissionsNotCodeQLWorkflow:       "not a codeql workflow",
+
+	MsgSASTInternalError:              "internal error",
+	MsgSASTRunOnAllCommits:            "SAST tool is run on all commits",
+	MsgSASTNotRunOnAllCommits:         "SAST tool is not run on all commits -- score normalized to {score}",
+	MsgSASTDetectedNotRunOnAllCommits: "SAST tool detected but not run on all commmits",
+	MsgSASTDetected:                   "SAST tool detected",
+	MsgSASTNotDetected:                "no SAST tool detected",
+	MsgSASTNoMergedPRs:                "no pull requests merged into dev branch",
+	MsgSASTToolDetected:               "tool detected",
+	MsgSASTAllCommitsChecked:          "all commits ({total}) are checked with a SAST tool",
+	MsgSASTCommitsChecked:             "{checked} commits out of {total} are checked with a SAST tool",
+	MsgSASTCodeQLDetected:             "CodeQL detected",
+	MsgSASTCodeQLToolDetected:         "SAST tool detected: CodeQL",
+	MsgSASTCodeQLNotDetected:          "CodeQL tool not detected",
+
+	MsgCodeReviewNoReviews:              "no reviews detected",
+	MsgCodeReviewReviewsFound:           "{review} code reviews found for {reviewed} commits out of the last {total}",
+	MsgCodeReviewReviewsFoundNormalized: "{review} code reviews found for {reviewed} commits out of the last {total} -- score normalized to {score}",
+	MsgCodeReviewNoCommits:              "no {review} commits found",
+	MsgCodeReviewSkipBotPR:              "skip PR#{pr} authored by bot account: {author}",
+	MsgCodeReviewApprovedPR:             "found review approved pr: {pr}",
+	MsgCodeReviewCommitterNotAuthor:     "found PR#{pr} with committer ({committer}) different from author ({author})",
+	MsgCodeReviewUnreviewedPR:           "merged PR without code review: {pr}",
+	MsgCodeReviewBotPRsNotCounted:       "{count} merged PRs authored by bots are not counted",
+	MsgCodeReviewBorsUnapproved:         "bors merge without approval from a reviewer: {commit}",
+	MsgCodeReviewBorsApproved:           "bors approval found for commit '{commit}'",
+	MsgCodeReviewSkipBotCommit:          "skip commit from bot account: {committer}",
+	MsgCodeReviewGerritReview:           "Gerrit review found for commit '{commit}'",
+
+	MsgCITestsUntestedPR: "merged PR without CI test: {pr}",
+	MsgCITestsNoPRs:      "no pull request found",
+	MsgCITestsSummary:    "{tested} out of {total} merged PRs checked by a CI test",
+	MsgCITestsFound:      "CI test found: pr: {pr}, context: {context}",
+
+	MsgBranchProtectionForcePushesEnabled:           "'force pushes' enabled on branch '{branch}'",
+	MsgBranchProtectionForcePushesDisabled:          "'force pushes' disabled on branch '{branch}'",
+	MsgBranchProtectionDeletionEnabled:              "'allow deletion' enabled on branch '{branch}'",
+	MsgBranchProtectionDeletionDisabled:             "'allow deletion' disabled on branch '{branch}'",
+	MsgBranchProtectionLinearHistoryRequired:        "linear history required on branch '{branch}'",
+	MsgBranchProtectionLinearHistoryNotRequired:     "linear history not required on branch '{branch}'",
+	MsgBranchProtectionSignedCommitsRequired:        "signed commits required on branch '{branch}'",
+	MsgBranchProtectionAdminsIncluded:               "settings apply to administrators on branch '{branch}'",
+	MsgBranchProtectionAdminsNotIncluded:            "settings do not apply to administrators on branch '{branch}'",
+	MsgBranchProtectionAdminsUnknown:                "unable to retrieve whether or not settings apply to administrators on branch '{branch}'",
+	MsgBranchProtectionNoStatusChecks:               "no status checks found to merge onto branch '{branch}'",
+	MsgBranchProtectionStatusChecks:                 "status check found to merge onto on branch '{branch}'",
+	MsgBranchProtectionRequiredWorkflow:             "workflow '{workflow}' required to pass on branch '{branch}'",
+	MsgBranchProtectionRequiredContextMatched:       "required status check '{name}' matches '{pattern}' on branch '{branch}'",
+	MsgBranchProtectionRequiredContextMissing:       "no status check matching '{pattern}' required on branch '{branch}'",
+	MsgBranchProtectionUpToDateRequired:             "status checks require up-to-date branches for '{branch}'",
+	MsgBranchProtectionUpToDateNotRequired:          "status checks do not require up-to-date branches for '{branch}'",
+	MsgBranchProtectionUpToDateUnknown:              "unable to retrieve whether up-to-date branches are needed to merge on branch '{branch}'",
+	MsgBranchProtectionStaleReviewDismissalEnabled:  "Stale review dismissal enabled on branch '{branch}'",
+	MsgBranchProtectionStaleReviewDismissalDisabled: "Stale review dismissal disabled on branch '{branch}'",
+	MsgBranchProtectionStaleReviewDismissalUnknown:  "unable to retrieve review dismissal on branch '{branch}'",
+	MsgBranchProtectionReviewers:                    "number of required reviewers is {reviewers} on branch '{branch}'",
+	MsgBranchProtectionTooFewReviewers:              "number of required reviewers is only {reviewers} on branch '{branch}'",
+	MsgBranchProtectionNoReviewers:                  "number of required reviewers is 0 on branch '{branch}'",
+	MsgBranchProtectionNotEnabled:                   "branch protection not enabled for branch '{branch}'",
+	MsgBranchProtectionNoBranches:                   "unable to detect any development/release branches",
+	MsgBranchProtectionDisabled:                     "branch protection not enabled on development/release branches",
+	MsgBranchProtectionMaximal:                      "branch protection is fully enabled on development and all release branches",
+	MsgBranchProtectionNotMaximal:                   "branch protection is not maximal on development and all release branches",
+	MsgBranchProtectionNoCommits:                    "no commits found on branch '{branch}'",
+	MsgBranchProtectionNoReflog:                     "inferred: no reflog found for branch '{branch}', force pushes cannot be detected",
+	MsgBranchProtectionNoForcePushes:                "inferred: no force pushes in the {updates} updates of branch '{branch}' found in the reflog",
+	MsgBranchProtectionForcePushes:                  "inferred: {forcePushes} force pushes in the {updates} updates of branch '{branch}' found in the reflog",
+	MsgBranchProtectionMergedFromPRs:                "inferred: the last {commits} commits of branch '{branch}' were merged from PRs",
+	MsgBranchProtectionDirectPushes:                 "inferred: {directPushes} of the last {commits} commits of branch '{branch}' were pushed directly",
+	MsgBranchProtectionInferredFromHistory:          "branch protection settings of branch '{branch}' inferred from its git history (heuristic)",
+	MsgBranchProtectionInferredForcePushesDisabled:  "inferred: 'force pushes' disabled on branch '{branch}', history includes the merge commits of the last {prs} merged PRs",
+	MsgBranchProtectionInferredReviewsRequired:      "inferred: reviews required on branch '{branch}', the last {prs} merged PRs were approved",
+	MsgBranchProtectionMergeBot:                     "reviews enforced by {bot} on branch '{branch}' ({evidence})",
+
+	MsgPackagingWorkflowUsed:           "GitHub publishing workflow used in run {run}",
+	MsgPackagingWorkflowDetected:       "publishing workflow detected",
+	MsgPackagingWorkflowNotUsed:        "GitHub publishing workflow not used in runs",
+	MsgPackagingNoWorkflow:             "no GitHub publishing workflow detected",
+	MsgPackagingPackageDetected:        "published package detected",
+	MsgPackagingNoPackage:              "no published package detected",
+	MsgPackagingPackagePublished:       "package {package} published to {system}",
+	MsgPackagingCandidateWorkflow:      "candidate {ecosystem} publishing workflow",
+	MsgPackagingCandidateWorkflowUsing: "candidate {ecosystem} publishing workflow using {tool}",
+	MsgPackagingNotPackagingWorkflow:   "not a publishing workflow",
+
+	MsgSecurityInsightsInvalid:                 "cannot parse {file}: {error}",
+	MsgSecurityInsightsContactDeclared:         "security contact declared in {file}: {contact}",
+	MsgSecurityInsightsPolicyNotDetected:       "{file} declares the security policy {policy}, but no security policy file was detected",
+	MsgSecurityInsightsToolDetected:            "{file} declares the {type} tool {tool}, which is corroborated by the detected {type} tool",
+	MsgSecurityInsightsToolNotDetected:         "{file} declares the {type} tool {tool}, but no {type} tool was detected",
+	MsgSecurityInsightsDistributionDetected:    "{file} declares the distribution point {uri}",
+	MsgSecurityInsightsDistributionNotDetected: "{file} declares the distribution point {uri}, but no packaging workflow or published package was detected",
+}
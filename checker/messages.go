@@ -0,0 +1,94 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MessageID is the stable ID of a message of the catalog. IDs are part of
+// the JSON output, so an ID must not change once released, even if the text
+// of its message does; a message with different parameters gets a new ID.
+type MessageID string
+
+// MessageParams are the values of the parameters of a message,
+// formatted with fmt.Sprint.
+type MessageParams map[string]interface{}
+
+// Message is a message of the catalog, with the values of its parameters,
+// so that UIs can localize or re-render reasons and details.
+type Message struct {
+	ID     MessageID
+	Params map[string]string
+}
+
+// NewMessage returns the message id with params.
+func NewMessage(id MessageID, params MessageParams) *Message {
+	m := &Message{ID: id}
+	if len(params) > 0 {
+		m.Params = make(map[string]string, len(params))
+		for k, v := range params {
+			m.Params[k] = fmt.Sprint(v)
+		}
+	}
+	return m
+}
+
+// String renders m with its template of the catalog, see MessageCatalog.
+func (m *Message) String() string {
+	template, ok := messageCatalog[m.ID]
+	if !ok {
+		// Messages are constants of this package, so this is a programming error.
+		panic(fmt.Sprintf("unknown message ID %q", m.ID))
+	}
+	return RenderMessage(template, m.Params)
+}
+
+// RenderMessage replaces the {name} placeholders of template with the
+// values of params, e.g. to render a localized template of the catalog.
+func RenderMessage(template string, params map[string]string) string {
+	if len(params) == 0 {
+		return template
+	}
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	// Sort the names so that the replacements do not depend on map order.
+	sort.Strings(names)
+	oldnew := make([]string, 0, 2*len(params))
+	for _, name := range names {
+		oldnew = append(oldnew, "{"+name+"}", params[name])
+	}
+	return strings.NewReplacer(oldnew...).Replace(template)
+}
+
+// MessageCatalog returns the English templates of the messages by ID.
+// Parameters are written {name} in the templates.
+func MessageCatalog() map[MessageID]string {
+	ret := make(map[MessageID]string, len(messageCatalog))
+	for id, template := range messageCatalog {
+		ret[id] = template
+	}
+	return ret
+}
+
+// WithReasonMessage returns r with the message its reason was rendered from.
+func (r CheckResult) WithReasonMessage(m *Message) CheckResult {
+	r.ReasonMessage = m
+	return r
+}
@@ -0,0 +1,82 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRenderMessage(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		template string
+		params   map[string]string
+		expected string
+	}{
+		{
+			name:     "no params",
+			template: "no releases found",
+			expected: "no releases found",
+		},
+		{
+			name:     "params",
+			template: "{signed} out of {total} artifacts are signed or have provenance",
+			params:   map[string]string{"signed": "2", "total": "3"},
+			expected: "2 out of 3 artifacts are signed or have provenance",
+		},
+		{
+			name:     "localized template",
+			template: "{total} artefacts, dont {signed} signés",
+			params:   map[string]string{"signed": "2", "total": "3"},
+			expected: "3 artefacts, dont 2 signés",
+		},
+		{
+			name:     "values are not expanded",
+			template: "{name}: {version}",
+			params:   map[string]string{"name": "{version}", "version": "1.0"},
+			expected: "{version}: 1.0",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := RenderMessage(tt.template, tt.params); got != tt.expected {
+				t.Errorf("RenderMessage() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMessageCatalog(t *testing.T) {
+	t.Parallel()
+	idRegex := regexp.MustCompile(`^([a-z0-9]+-)*[a-z0-9]+(\.([a-z0-9]+-)*[a-z0-9]+)?$`)
+	paramRegex := regexp.MustCompile(`{[a-zA-Z]+}`)
+	verbRegex := regexp.MustCompile(`%[-+#0-9.]*[a-zA-Z]`)
+	for id, template := range MessageCatalog() {
+		if !idRegex.MatchString(string(id)) {
+			t.Errorf("message ID %q is not of the form check-name.kebab-case", id)
+		}
+		if verbRegex.MatchString(template) {
+			t.Errorf("message %q uses a printf verb instead of a {name} parameter: %q", id, template)
+		}
+		if stripped := paramRegex.ReplaceAllString(template, ""); strings.ContainsAny(stripped, "{}") {
+			t.Errorf("message %q has a malformed parameter: %q", id, template)
+		}
+	}
+}
@@ -15,11 +15,41 @@
 // Package checks defines all Scorecard checks.
 package checks
 
-import "github.com/ossf/scorecard/v3/checker"
+import (
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+)
 
 // AllChecks is the list of all security checks that will be run.
 var AllChecks = checker.CheckNameToFnMap{}
 
-func registerCheck(name string, fn checker.CheckFn) {
+// checkFeatures are the RepoClient features each check cannot run without.
+var checkFeatures = map[string][]clients.Feature{}
+
+func registerCheck(name string, fn checker.CheckFn, features ...clients.Feature) {
 	AllChecks[name] = fn
+	checkFeatures[name] = features
+}
+
+// subPathChecks are the checks analyzing the files of the code, which can be
+// restricted to a subdirectory of the repo, e.g. a component of a monorepo.
+var subPathChecks = map[string]bool{
+	CheckBinaryArtifacts:    true,
+	CheckPinnedDependencies: true,
+	CheckLicense:            true,
+	CheckSecurityPolicy:     true,
+	CheckTokenPermissions:   true,
+}
+
+// SupportsSubPath reports whether the check named name can be restricted
+// to a subdirectory of the repo. The other checks apply to the whole repo.
+func SupportsSubPath(name string) bool {
+	return subPathChecks[name]
+}
+
+// RequiredFeatures returns the RepoClient features the check named name
+// cannot run without. Features a check can do without, with reduced
+// fidelity, are not listed.
+func RequiredFeatures(name string) []clients.Feature {
+	return checkFeatures[name]
 }
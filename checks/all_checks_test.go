@@ -0,0 +1,45 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"testing"
+
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+)
+
+func TestAllChecksDocumented(t *testing.T) {
+	t.Parallel()
+	checkDocs, err := docs.Read()
+	if err != nil {
+		t.Fatalf("docs.Read: %v", err)
+	}
+	for name := range AllChecks {
+		if !checkDocs.CheckExists(name) {
+			t.Errorf("check %s is not documented in docs/checks/internal/checks.yaml", name)
+			continue
+		}
+		doc, err := checkDocs.GetCheck(name)
+		if err != nil {
+			t.Fatalf("GetCheck: %s: %v", name, err)
+		}
+		if doc.GetShort() == "" || doc.GetDescription() == "" || len(doc.GetRemediation()) == 0 {
+			t.Errorf("check %s is missing a short description, description or remediation", name)
+		}
+		if len(doc.GetRequiredAPIs()) == 0 {
+			t.Errorf("check %s does not list the APIs it calls", name)
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/ossf/scorecard/v3/checker"
 	"github.com/ossf/scorecard/v3/checks/evaluation"
 	"github.com/ossf/scorecard/v3/checks/raw"
+	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
@@ -26,14 +27,14 @@ const CheckBinaryArtifacts string = "Binary-Artifacts"
 
 //nolint
 func init() {
-	registerCheck(CheckBinaryArtifacts, BinaryArtifacts)
+	registerCheck(CheckBinaryArtifacts, BinaryArtifacts, clients.FeatureFileContents)
 }
 
 // BinaryArtifacts  will check the repository contains binary artifacts.
 func BinaryArtifacts(c *checker.CheckRequest) checker.CheckResult {
 	rawData, err := raw.BinaryArtifacts(c.RepoClient)
 	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "")
 		return checker.CreateRuntimeErrorResult(CheckBinaryArtifacts, e)
 	}
 
@@ -15,25 +15,34 @@
 package checks
 
 import (
-	"errors"
-	"fmt"
-	"regexp"
+	"path"
+	"strings"
 
 	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks/raw"
 	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/remediation"
 )
 
 const (
 	// CheckBranchProtection is the exported name for Branch-Protected check.
 	CheckBranchProtection = "Branch-Protection"
 	minReviews            = 2
+	// Name of the GitHub setting controlling the number of reviewers.
+	requireApprovalsSetting = "Require approvals (at least 2)"
 	// Points incremented at each level.
 	adminNonAdminBasicLevel     = 3 // Level 1.
 	adminNonAdminReviewLevel    = 3 // Level 2.
 	nonAdminContextLevel        = 2 // Level 3.
 	nonAdminThoroughReviewLevel = 1 // Level 4.
 	adminThoroughReviewLevel    = 1 // Level 5.
+	// Bonus for requiring signed commits on all branches, capped at the max score.
+	signedCommitsBonus = 1
+	// Points for the evidence found in the history of branches whose settings
+	// cannot be read. Most settings cannot be observed, so the total is capped at Level 3.
+	historyNoForcePushes = 3
+	historyPullRequests  = 5
 )
 
 type scoresInfo struct {
@@ -54,52 +63,24 @@ type levelScore struct {
 
 //nolint:gochecknoinits
 func init() {
-	registerCheck(CheckBranchProtection, BranchProtection)
+	registerCheck(CheckBranchProtection, BranchProtection, clients.FeatureBranches)
 }
 
-type branchMap map[string]*clients.BranchRef
-
-func (b branchMap) getBranchByName(name string) (*clients.BranchRef, error) {
-	val, exists := b[name]
-	if exists {
-		return val, nil
-	}
-
-	// Ideally, we should check using repositories.GetBranch if there was a branch redirect.
-	// See https://github.com/google/go-github/issues/1895
-	// For now, handle the common master -> main redirect.
-	if name == "master" {
-		val, exists := b["main"]
-		if exists {
-			return val, nil
-		}
-	}
-	return nil, sce.WithMessage(sce.ErrScorecardInternal,
-		fmt.Sprintf("could not find branch name %s: %v", name, errInternalBranchNotFound))
-}
-
-func getBranchMapFrom(branches []*clients.BranchRef) branchMap {
-	ret := make(branchMap)
-	for _, branch := range branches {
-		branchName := getBranchName(branch)
-		if branchName != "" {
-			ret[branchName] = branch
-		}
+// BranchProtection runs Branch-Protection check.
+func BranchProtection(c *checker.CheckRequest) checker.CheckResult {
+	rawData, err := raw.BranchProtection(c.RepoClient)
+	if err != nil {
+		return checker.CreateRuntimeErrorResult(CheckBranchProtection, err)
 	}
-	return ret
-}
 
-func getBranchName(branch *clients.BranchRef) string {
-	if branch == nil || branch.Name == nil {
-		return ""
+	// Set the raw results.
+	if c.RawResults != nil {
+		c.RawResults.BranchProtectionResults = rawData
+		return checker.CheckResult{}
 	}
-	return *branch.Name
-}
 
-// BranchProtection runs Branch-Protection check.
-func BranchProtection(c *checker.CheckRequest) checker.CheckResult {
-	// Checks branch protection on both release and development branch.
-	return checkReleaseAndDevBranchProtection(c.RepoClient, c.Dlogger)
+	return evaluateBranchProtection(c.Dlogger, remediation.New(c.Repo), &rawData,
+		c.RequiredStatusChecks)
 }
 
 func computeNonAdminBasicScore(scores []levelScore) int {
@@ -224,143 +205,248 @@ func computeScore(scores []levelScore) (int, error) {
 	return int(score), nil
 }
 
-func info(dl checker.DetailLogger, doLogging bool, desc string, args ...interface{}) {
+func info(dl checker.DetailLogger, doLogging bool, id checker.MessageID, params checker.MessageParams) {
 	if !doLogging {
 		return
 	}
 
-	dl.Info(desc, args...)
+	dl.Info3(&checker.LogMessage{
+		Message: checker.NewMessage(id, params),
+	})
 }
 
-func debug(dl checker.DetailLogger, doLogging bool, desc string, args ...interface{}) {
+func debug(dl checker.DetailLogger, doLogging bool, id checker.MessageID, params checker.MessageParams) {
 	if !doLogging {
 		return
 	}
 
-	dl.Debug(desc, args...)
+	dl.Debug3(&checker.LogMessage{
+		Message: checker.NewMessage(id, params),
+	})
 }
 
-func warn(dl checker.DetailLogger, doLogging bool, desc string, args ...interface{}) {
+func warn(dl checker.DetailLogger, doLogging bool, rem *checker.Remediation,
+	id checker.MessageID, params checker.MessageParams) {
 	if !doLogging {
 		return
 	}
 
-	dl.Warn(desc, args...)
+	dl.Warn3(&checker.LogMessage{
+		Message:     checker.NewMessage(id, params),
+		Remediation: rem,
+	})
 }
 
 func checkReleaseAndDevBranchProtection(
 	repoClient clients.RepoClient, dl checker.DetailLogger) checker.CheckResult {
-	// Get all branches. This will include information on whether they are protected.
-	branches, err := repoClient.ListBranches()
-	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
-		return checker.CreateRuntimeErrorResult(CheckBranchProtection, e)
-	}
-	branchesMap := getBranchMapFrom(branches)
-
-	// Get release branches.
-	releases, err := repoClient.ListReleases()
-	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
-		return checker.CreateRuntimeErrorResult(CheckBranchProtection, e)
-	}
-
-	commit := regexp.MustCompile("^[a-f0-9]{40}$")
-	checkBranches := make(map[string]bool)
-	for _, release := range releases {
-		if release.TargetCommitish == "" {
-			// Log with a named error if target_commitish is nil.
-			e := sce.WithMessage(sce.ErrScorecardInternal, errInternalCommitishNil.Error())
-			return checker.CreateRuntimeErrorResult(CheckBranchProtection, e)
-		}
-
-		// TODO: if this is a sha, get the associated branch. for now, ignore.
-		if commit.Match([]byte(release.TargetCommitish)) {
-			continue
-		}
-
-		// Try to resolve the branch name.
-		b, err := branchesMap.getBranchByName(release.TargetCommitish)
-		if err != nil {
-			// If the commitish branch is still not found, fail.
-			return checker.CreateRuntimeErrorResult(CheckBranchProtection, err)
-		}
-
-		// Branch is valid, add to list of branches to check.
-		checkBranches[*b.Name] = true
-	}
-
-	// Add default branch.
-	defaultBranch, err := repoClient.GetDefaultBranch()
+	// Checks branch protection on both release and development branch.
+	rawData, err := raw.BranchProtection(repoClient)
 	if err != nil {
 		return checker.CreateRuntimeErrorResult(CheckBranchProtection, err)
 	}
-	defaultBranchName := getBranchName(defaultBranch)
-	if defaultBranchName != "" {
-		checkBranches[defaultBranchName] = true
+	return evaluateBranchProtection(dl, nil, &rawData, nil)
+}
+
+// requiredContexts are the patterns of the status checks protected branches are
+// expected to require. If empty, requiring any status check gets full credit.
+func evaluateBranchProtection(dl checker.DetailLogger, rem *remediation.Metadata,
+	r *checker.BranchProtectionsData, requiredContexts []string) checker.CheckResult {
+	if r.History != nil {
+		return evaluateBranchHistory(dl, rem, r.History)
 	}
 
 	var scores []levelScore
+	allSigned := true
 
 	// Check protections on all the branches.
-	for b := range checkBranches {
+	for i := range r.Branches {
 		var score levelScore
-		branch, err := branchesMap.getBranchByName(b)
-		if err != nil {
-			if errors.Is(err, errInternalBranchNotFound) {
-				continue
-			}
-			return checker.CreateRuntimeErrorResult(CheckBranchProtection, err)
-		}
+		branch := &r.Branches[i]
+		b := *branch.Name
 		// Protected field only indates that the branch matches
 		// one `Branch protection rules`. All settings may be disabled,
 		// so it does not provide any guarantees.
 		protected := !(branch.Protected != nil && !*branch.Protected)
 		if !protected {
-			dl.Warn("branch protection not enabled for branch '%s'", b)
+			dl.Warn3(&checker.LogMessage{
+				Message:     checker.NewMessage(checker.MsgBranchProtectionNotEnabled, checker.MessageParams{"branch": b}),
+				Remediation: rem.BranchProtection(b, "Branch protection", true),
+			})
+		}
+		rule := &branch.BranchProtectionRule
+		if protected && r.Inferred != nil && r.Inferred.Branch == b {
+			rule = applyInference(dl, r.Inferred, rule)
+		}
+		switch {
+		case !protected:
+		case rule.RequiresMergeQueue != nil && *rule.RequiresMergeQueue:
+			rule = applyMergeBot(dl, "GitHub merge queue", []string{"merge queue enabled"}, rule, b)
+		case r.MergeBot != nil:
+			rule = applyMergeBot(dl, r.MergeBot.Name, r.MergeBot.Evidence, rule, b)
 		}
 		score.scores.basic, score.maxes.basic =
-			basicNonAdminProtection(&branch.BranchProtectionRule, b, dl, protected)
+			basicNonAdminProtection(rule, b, rem, dl, protected)
 		score.scores.adminBasic, score.maxes.adminBasic =
-			basicAdminProtection(&branch.BranchProtectionRule, b, dl, protected)
+			basicAdminProtection(rule, b, rem, dl, protected)
 		score.scores.review, score.maxes.review =
-			nonAdminReviewProtection(&branch.BranchProtectionRule)
+			nonAdminReviewProtection(rule)
 		score.scores.adminReview, score.maxes.adminReview =
-			adminReviewProtection(&branch.BranchProtectionRule, b, dl, protected)
+			adminReviewProtection(rule, b, rem, dl, protected)
 		score.scores.context, score.maxes.context =
-			nonAdminContextProtection(&branch.BranchProtectionRule, b, dl, protected)
+			nonAdminContextProtection(rule, b, requiredContexts, rem, dl, protected)
 		score.scores.thoroughReview, score.maxes.thoroughReview =
-			nonAdminThoroughReviewProtection(&branch.BranchProtectionRule, b, dl, protected)
+			nonAdminThoroughReviewProtection(rule, b, rem, dl, protected)
 		score.scores.adminThoroughReview, score.maxes.adminThoroughReview =
-			adminThoroughReviewProtection(&branch.BranchProtectionRule, b, dl, protected) // Do we want this?
+			adminThoroughReviewProtection(rule, b, rem, dl, protected) // Do we want this?
+		if !signedCommitsProtection(rule, b, dl, protected) {
+			allSigned = false
+		}
 
 		scores = append(scores, score)
 	}
 
 	if len(scores) == 0 {
-		return checker.CreateInconclusiveResult(CheckBranchProtection, "unable to detect any development/release branches")
+		reason := checker.NewMessage(checker.MsgBranchProtectionNoBranches, nil)
+		return checker.CreateInconclusiveResult(CheckBranchProtection, reason.String()).WithReasonMessage(reason)
 	}
 
 	score, err := computeScore(scores)
 	if err != nil {
 		return checker.CreateRuntimeErrorResult(CheckBranchProtection, err)
 	}
+	// Signed commits are optional: projects not requiring them are not penalized.
+	if allSigned && score > checker.MinResultScore {
+		score += signedCommitsBonus
+		if score > checker.MaxResultScore {
+			score = checker.MaxResultScore
+		}
+	}
 
 	switch score {
 	case checker.MinResultScore:
-		return checker.CreateMinScoreResult(CheckBranchProtection,
-			"branch protection not enabled on development/release branches")
+		reason := checker.NewMessage(checker.MsgBranchProtectionDisabled, nil)
+		return checker.CreateMinScoreResult(CheckBranchProtection, reason.String()).WithReasonMessage(reason)
 	case checker.MaxResultScore:
-		return checker.CreateMaxScoreResult(CheckBranchProtection,
-			"branch protection is fully enabled on development and all release branches")
+		reason := checker.NewMessage(checker.MsgBranchProtectionMaximal, nil)
+		return checker.CreateMaxScoreResult(CheckBranchProtection, reason.String()).WithReasonMessage(reason)
 	default:
-		return checker.CreateResultWithScore(CheckBranchProtection,
-			"branch protection is not maximal on development and all release branches", score)
+		reason := checker.NewMessage(checker.MsgBranchProtectionNotMaximal, nil)
+		return checker.CreateResultWithScore(CheckBranchProtection, reason.String(), score).WithReasonMessage(reason)
 	}
 }
 
+// evaluateBranchHistory scores the protection of a branch from its history alone.
+func evaluateBranchHistory(dl checker.DetailLogger, rem *remediation.Metadata,
+	h *checker.BranchHistory) checker.CheckResult {
+	if h.Commits == 0 {
+		reason := checker.NewMessage(checker.MsgBranchProtectionNoCommits, checker.MessageParams{"branch": h.Branch})
+		return checker.CreateInconclusiveResult(CheckBranchProtection, reason.String()).WithReasonMessage(reason)
+	}
+
+	score := float64(0)
+	switch {
+	case h.BranchUpdates == 0:
+		dl.Debug3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgBranchProtectionNoReflog, checker.MessageParams{"branch": h.Branch}),
+		})
+	case h.ForcePushes == 0:
+		dl.Info3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgBranchProtectionNoForcePushes, checker.MessageParams{
+				"updates": h.BranchUpdates,
+				"branch":  h.Branch,
+			}),
+		})
+		score += historyNoForcePushes
+	default:
+		dl.Warn3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgBranchProtectionForcePushes, checker.MessageParams{
+				"forcePushes": h.ForcePushes,
+				"updates":     h.BranchUpdates,
+				"branch":      h.Branch,
+			}),
+			Remediation: rem.BranchProtection(h.Branch, "Allow force pushes", false),
+		})
+	}
+
+	if h.DirectPushes == 0 {
+		dl.Info3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgBranchProtectionMergedFromPRs, checker.MessageParams{
+				"commits": h.Commits,
+				"branch":  h.Branch,
+			}),
+		})
+	} else {
+		dl.Warn3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgBranchProtectionDirectPushes, checker.MessageParams{
+				"directPushes": h.DirectPushes,
+				"commits":      h.Commits,
+				"branch":       h.Branch,
+			}),
+			Remediation: rem.BranchProtection(h.Branch, "Require a pull request before merging", true),
+		})
+	}
+	score += float64(historyPullRequests*(h.Commits-h.DirectPushes)) / float64(h.Commits)
+
+	reason := checker.NewMessage(checker.MsgBranchProtectionInferredFromHistory, checker.MessageParams{"branch": h.Branch})
+	return checker.CreateResultWithScore(CheckBranchProtection, reason.String(), int(score)).WithReasonMessage(reason)
+}
+
+// applyInference returns a copy of the rule where the settings which could not be
+// read are replaced with the ones inferred from the branch history.
+func applyInference(dl checker.DetailLogger, inferred *checker.BranchProtectionInference,
+	rule *clients.BranchProtectionRule) *clients.BranchProtectionRule {
+	ret := *rule
+	if ret.AllowForcePushes == nil && inferred.ForcePushesBlocked {
+		allow := false
+		ret.AllowForcePushes = &allow
+		dl.Info3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgBranchProtectionInferredForcePushesDisabled, checker.MessageParams{
+				"branch": inferred.Branch,
+				"prs":    inferred.PullRequests,
+			}),
+		})
+	}
+	if ret.RequiredPullRequestReviews.RequiredApprovingReviewCount == nil && inferred.ReviewsRequired {
+		var reviewers int32 = 1
+		ret.RequiredPullRequestReviews.RequiredApprovingReviewCount = &reviewers
+		dl.Info3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgBranchProtectionInferredReviewsRequired, checker.MessageParams{
+				"branch": inferred.Branch,
+				"prs":    inferred.PullRequests,
+			}),
+		})
+	}
+	return &ret
+}
+
+// applyMergeBot returns a copy of the rule where the review settings are replaced
+// with the ones enforced by a bot merging the PRs, so that projects relying on the
+// bot instead of requiring approving reviews are not penalized.
+func applyMergeBot(dl checker.DetailLogger, name string, evidence []string,
+	rule *clients.BranchProtectionRule, branch string) *clients.BranchProtectionRule {
+	ret := *rule
+	reviews := &ret.RequiredPullRequestReviews
+	if reviews.RequiredApprovingReviewCount == nil || *reviews.RequiredApprovingReviewCount < minReviews {
+		var reviewers int32 = minReviews
+		reviews.RequiredApprovingReviewCount = &reviewers
+	}
+	// Bots drop approvals on new commits, like dismissing stale reviews.
+	if reviews.DismissStaleReviews != nil && !*reviews.DismissStaleReviews {
+		dismiss := true
+		reviews.DismissStaleReviews = &dismiss
+	}
+	dl.Info3(&checker.LogMessage{
+		Message: checker.NewMessage(checker.MsgBranchProtectionMergeBot, checker.MessageParams{
+			"bot":      name,
+			"branch":   branch,
+			"evidence": strings.Join(evidence, ", "),
+		}),
+	})
+	return &ret
+}
+
 func basicNonAdminProtection(protection *clients.BranchProtectionRule,
-	branch string, dl checker.DetailLogger, doLogging bool) (int, int) {
+	branch string, rem *remediation.Metadata, dl checker.DetailLogger, doLogging bool) (int, int) {
 	score := 0
 	max := 0
 
@@ -368,9 +454,10 @@ func basicNonAdminProtection(protection *clients.BranchProtectionRule,
 	if protection.AllowForcePushes != nil {
 		switch *protection.AllowForcePushes {
 		case true:
-			warn(dl, doLogging, "'force pushes' enabled on branch '%s'", branch)
+			warn(dl, doLogging, rem.BranchProtection(branch, "Allow force pushes", false),
+				checker.MsgBranchProtectionForcePushesEnabled, checker.MessageParams{"branch": branch})
 		case false:
-			info(dl, doLogging, "'force pushes' disabled on branch '%s'", branch)
+			info(dl, doLogging, checker.MsgBranchProtectionForcePushesDisabled, checker.MessageParams{"branch": branch})
 			score++
 		}
 	}
@@ -379,18 +466,42 @@ func basicNonAdminProtection(protection *clients.BranchProtectionRule,
 	if protection.AllowDeletions != nil {
 		switch *protection.AllowDeletions {
 		case true:
-			warn(dl, doLogging, "'allow deletion' enabled on branch '%s'", branch)
+			warn(dl, doLogging, rem.BranchProtection(branch, "Allow deletions", false),
+				checker.MsgBranchProtectionDeletionEnabled, checker.MessageParams{"branch": branch})
 		case false:
-			info(dl, doLogging, "'allow deletion' disabled on branch '%s'", branch)
+			info(dl, doLogging, checker.MsgBranchProtectionDeletionDisabled, checker.MessageParams{"branch": branch})
+			score++
+		}
+	}
+
+	max++
+	if protection.RequireLinearHistory != nil {
+		switch *protection.RequireLinearHistory {
+		case true:
+			info(dl, doLogging, checker.MsgBranchProtectionLinearHistoryRequired, checker.MessageParams{"branch": branch})
 			score++
+		case false:
+			warn(dl, doLogging, rem.BranchProtection(branch, "Require linear history", true),
+				checker.MsgBranchProtectionLinearHistoryNotRequired, checker.MessageParams{"branch": branch})
 		}
 	}
 
 	return score, max
 }
 
+// signedCommitsProtection returns whether signed commits are required.
+// It is not part of the score tiers, see signedCommitsBonus.
+func signedCommitsProtection(protection *clients.BranchProtectionRule,
+	branch string, dl checker.DetailLogger, doLogging bool) bool {
+	if protection.RequiresSignatures == nil || !*protection.RequiresSignatures {
+		return false
+	}
+	info(dl, doLogging, checker.MsgBranchProtectionSignedCommitsRequired, checker.MessageParams{"branch": branch})
+	return true
+}
+
 func basicAdminProtection(protection *clients.BranchProtectionRule,
-	branch string, dl checker.DetailLogger, doLogging bool) (int, int) {
+	branch string, rem *remediation.Metadata, dl checker.DetailLogger, doLogging bool) (int, int) {
 	score := 0
 	max := 0
 
@@ -400,37 +511,78 @@ func basicAdminProtection(protection *clients.BranchProtectionRule,
 		max++
 		switch *protection.EnforceAdmins {
 		case true:
-			info(dl, doLogging, "settings apply to administrators on branch '%s'", branch)
+			info(dl, doLogging, checker.MsgBranchProtectionAdminsIncluded, checker.MessageParams{"branch": branch})
 			score++
 		case false:
-			warn(dl, doLogging, "settings do not apply to administrators on branch '%s'", branch)
+			warn(dl, doLogging, rem.BranchProtection(branch, "Include administrators", true),
+				checker.MsgBranchProtectionAdminsNotIncluded, checker.MessageParams{"branch": branch})
 		}
 	} else {
-		debug(dl, doLogging, "unable to retrieve whether or not settings apply to administrators on branch '%s'", branch)
+		debug(dl, doLogging, checker.MsgBranchProtectionAdminsUnknown, checker.MessageParams{"branch": branch})
 	}
 
 	return score, max
 }
 
 func nonAdminContextProtection(protection *clients.BranchProtectionRule, branch string,
-	dl checker.DetailLogger, doLogging bool) (int, int) {
+	requiredContexts []string, rem *remediation.Metadata, dl checker.DetailLogger, doLogging bool) (int, int) {
 	score := 0
 	max := 0
 	// This means there are specific checks enabled.
 	// If only `Requires status check to pass before merging` is enabled
 	// but no specific checks are declared, it's equivalent
 	// to having no status check at all.
+	// Workflows required by organization rulesets are status checks too.
+	checks := append(append([]string{}, protection.CheckRules.Contexts...), protection.CheckRules.RequiredWorkflows...)
+	if len(checks) == 0 {
+		warn(dl, doLogging, rem.BranchProtection(branch, "Require status checks to pass before merging", true),
+			checker.MsgBranchProtectionNoStatusChecks, checker.MessageParams{"branch": branch})
+		return score, len(requiredContexts) + 1
+	}
+
 	max++
-	switch {
-	case len(protection.CheckRules.Contexts) > 0:
-		info(dl, doLogging, "status check found to merge onto on branch '%s'", branch)
-		score++
-	default:
-		warn(dl, doLogging, "no status checks found to merge onto branch '%s'", branch)
+	score++
+	info(dl, doLogging, checker.MsgBranchProtectionStatusChecks, checker.MessageParams{"branch": branch})
+	for _, w := range protection.CheckRules.RequiredWorkflows {
+		info(dl, doLogging, checker.MsgBranchProtectionRequiredWorkflow, checker.MessageParams{
+			"workflow": w,
+			"branch":   branch,
+		})
+	}
+
+	// Each required context is worth one point, so branches requiring
+	// only some of them get partial credit.
+	for _, pattern := range requiredContexts {
+		max++
+		if name, ok := matchContext(pattern, checks); ok {
+			info(dl, doLogging, checker.MsgBranchProtectionRequiredContextMatched, checker.MessageParams{
+				"name":    name,
+				"pattern": pattern,
+				"branch":  branch,
+			})
+			score++
+			continue
+		}
+		warn(dl, doLogging, rem.BranchProtection(branch, "Require status checks to pass before merging", true),
+			checker.MsgBranchProtectionRequiredContextMissing, checker.MessageParams{
+				"pattern": pattern,
+				"branch":  branch,
+			})
 	}
 	return score, max
 }
 
+// matchContext returns the first of contexts matching pattern.
+func matchContext(pattern string, contexts []string) (string, bool) {
+	for _, name := range contexts {
+		// The pattern is validated when the policy is parsed.
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return name, true
+		}
+	}
+	return "", false
+}
+
 func nonAdminReviewProtection(protection *clients.BranchProtectionRule) (int, int) {
 	score := 0
 	max := 0
@@ -445,7 +597,7 @@ func nonAdminReviewProtection(protection *clients.BranchProtectionRule) (int, in
 }
 
 func adminReviewProtection(protection *clients.BranchProtectionRule, branch string,
-	dl checker.DetailLogger, doLogging bool) (int, int) {
+	rem *remediation.Metadata, dl checker.DetailLogger, doLogging bool) (int, int) {
 	score := 0
 	max := 0
 
@@ -454,20 +606,21 @@ func adminReviewProtection(protection *clients.BranchProtectionRule, branch stri
 		max++
 		switch *protection.CheckRules.UpToDateBeforeMerge {
 		case true:
-			info(dl, doLogging, "status checks require up-to-date branches for '%s'", branch)
+			info(dl, doLogging, checker.MsgBranchProtectionUpToDateRequired, checker.MessageParams{"branch": branch})
 			score++
 		default:
-			warn(dl, doLogging, "status checks do not require up-to-date branches for '%s'", branch)
+			warn(dl, doLogging, rem.BranchProtection(branch, "Require branches to be up to date before merging", true),
+				checker.MsgBranchProtectionUpToDateNotRequired, checker.MessageParams{"branch": branch})
 		}
 	} else {
-		debug(dl, doLogging, "unable to retrieve whether up-to-date branches are needed to merge on branch '%s'", branch)
+		debug(dl, doLogging, checker.MsgBranchProtectionUpToDateUnknown, checker.MessageParams{"branch": branch})
 	}
 
 	return score, max
 }
 
 func adminThoroughReviewProtection(protection *clients.BranchProtectionRule, branch string,
-	dl checker.DetailLogger, doLogging bool) (int, int) {
+	rem *remediation.Metadata, dl checker.DetailLogger, doLogging bool) (int, int) {
 	score := 0
 	max := 0
 	if protection.RequiredPullRequestReviews.DismissStaleReviews != nil {
@@ -475,19 +628,22 @@ func adminThoroughReviewProtection(protection *clients.BranchProtectionRule, bra
 		max++
 		switch *protection.RequiredPullRequestReviews.DismissStaleReviews {
 		case true:
-			info(dl, doLogging, "Stale review dismissal enabled on branch '%s'", branch)
+			info(dl, doLogging,
+				checker.MsgBranchProtectionStaleReviewDismissalEnabled, checker.MessageParams{"branch": branch})
 			score++
 		case false:
-			warn(dl, doLogging, "Stale review dismissal disabled on branch '%s'", branch)
+			warn(dl, doLogging, rem.BranchProtection(branch, "Dismiss stale pull request approvals", true),
+				checker.MsgBranchProtectionStaleReviewDismissalDisabled, checker.MessageParams{"branch": branch})
 		}
 	} else {
-		debug(dl, doLogging, "unable to retrieve review dismissal on branch '%s'", branch)
+		debug(dl, doLogging,
+			checker.MsgBranchProtectionStaleReviewDismissalUnknown, checker.MessageParams{"branch": branch})
 	}
 	return score, max
 }
 
 func nonAdminThoroughReviewProtection(protection *clients.BranchProtectionRule, branch string,
-	dl checker.DetailLogger, doLogging bool) (int, int) {
+	rem *remediation.Metadata, dl checker.DetailLogger, doLogging bool) (int, int) {
 	score := 0
 	max := 0
 
@@ -495,15 +651,21 @@ func nonAdminThoroughReviewProtection(protection *clients.BranchProtectionRule,
 	if protection.RequiredPullRequestReviews.RequiredApprovingReviewCount != nil {
 		switch *protection.RequiredPullRequestReviews.RequiredApprovingReviewCount >= minReviews {
 		case true:
-			info(dl, doLogging, "number of required reviewers is %d on branch '%s'",
-				*protection.RequiredPullRequestReviews.RequiredApprovingReviewCount, branch)
+			info(dl, doLogging, checker.MsgBranchProtectionReviewers, checker.MessageParams{
+				"reviewers": *protection.RequiredPullRequestReviews.RequiredApprovingReviewCount,
+				"branch":    branch,
+			})
 			score++
 		default:
-			warn(dl, doLogging, "number of required reviewers is only %d on branch '%s'",
-				*protection.RequiredPullRequestReviews.RequiredApprovingReviewCount, branch)
+			warn(dl, doLogging, rem.BranchProtection(branch, requireApprovalsSetting, true),
+				checker.MsgBranchProtectionTooFewReviewers, checker.MessageParams{
+					"reviewers": *protection.RequiredPullRequestReviews.RequiredApprovingReviewCount,
+					"branch":    branch,
+				})
 		}
 	} else {
-		warn(dl, doLogging, "number of required reviewers is 0 on branch '%s'", branch)
+		warn(dl, doLogging, rem.BranchProtection(branch, requireApprovalsSetting, true),
+			checker.MsgBranchProtectionNoReviewers, checker.MessageParams{"branch": branch})
 	}
 	return score, max
 }
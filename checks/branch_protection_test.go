@@ -15,11 +15,15 @@
 package checks
 
 import (
+	"fmt"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks/checktest"
 	"github.com/ossf/scorecard/v3/clients"
 	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
 	sce "github.com/ossf/scorecard/v3/errors"
@@ -28,8 +32,7 @@ import (
 
 func getBranch(branches []*clients.BranchRef, name string) *clients.BranchRef {
 	for _, branch := range branches {
-		branchName := getBranchName(branch)
-		if branchName == name {
+		if branch != nil && branch.Name != nil && *branch.Name == name {
 			return branch
 		}
 	}
@@ -50,18 +53,50 @@ func scrubBranches(branches []*clients.BranchRef) []*clients.BranchRef {
 	return ret
 }
 
+// approvedPRs returns n approved PRs, merged a day apart.
+func approvedPRs(n int) []clients.PullRequest {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	prs := make([]clients.PullRequest, n)
+	for i := range prs {
+		prs[i] = clients.PullRequest{
+			Number:      i,
+			MergedAt:    start.AddDate(0, 0, i),
+			MergeCommit: clients.Commit{SHA: fmt.Sprintf("sha%d", i)},
+			Reviews:     []clients.Review{{State: "APPROVED"}},
+		}
+	}
+	return prs
+}
+
+// mergeCommits returns the branch history for approvedPRs(5), keeping only
+// the merge commits of the last n PRs, as if the history was rewritten.
+func mergeCommits(n int) []clients.Commit {
+	const total = 5
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	var commits []clients.Commit
+	for i := total - n; i < total; i++ {
+		commits = append(commits, clients.Commit{
+			SHA:           fmt.Sprintf("sha%d", i),
+			CommittedDate: start.AddDate(0, 0, i),
+		})
+	}
+	// Keep the same commit window, so all PRs are compared against it.
+	commits = append(commits, clients.Commit{SHA: "initial", CommittedDate: start.AddDate(0, 0, -1)})
+	return commits
+}
+
 func testScore(protection *clients.BranchProtectionRule,
 	branch string, dl checker.DetailLogger) (int, error) {
 	var score levelScore
-	score.scores.basic, score.maxes.basic = basicNonAdminProtection(protection, branch, dl, true)
-	score.scores.adminBasic, score.maxes.adminBasic = basicAdminProtection(protection, branch, dl, true)
+	score.scores.basic, score.maxes.basic = basicNonAdminProtection(protection, branch, nil, dl, true)
+	score.scores.adminBasic, score.maxes.adminBasic = basicAdminProtection(protection, branch, nil, dl, true)
 	score.scores.review, score.maxes.review = nonAdminReviewProtection(protection)
-	score.scores.adminReview, score.maxes.adminReview = adminReviewProtection(protection, branch, dl, true)
-	score.scores.context, score.maxes.context = nonAdminContextProtection(protection, branch, dl, true)
+	score.scores.adminReview, score.maxes.adminReview = adminReviewProtection(protection, branch, nil, dl, true)
+	score.scores.context, score.maxes.context = nonAdminContextProtection(protection, branch, nil, nil, dl, true)
 	score.scores.thoroughReview, score.maxes.thoroughReview =
-		nonAdminThoroughReviewProtection(protection, branch, dl, true)
+		nonAdminThoroughReviewProtection(protection, branch, nil, dl, true)
 	score.scores.adminThoroughReview, score.maxes.adminThoroughReview =
-		adminThoroughReviewProtection(protection, branch, dl, true)
+		adminThoroughReviewProtection(protection, branch, nil, dl, true)
 
 	return computeScore([]levelScore{score})
 }
@@ -86,6 +121,8 @@ func TestReleaseAndDevBranchProtected(t *testing.T) {
 		defaultBranch string
 		releases      []string
 		nonadmin      bool
+		mergedPRs     []clients.PullRequest
+		commits       []clients.Commit
 	}{
 		{
 			name: "Nil release and main branch names",
@@ -146,8 +183,8 @@ func TestReleaseAndDevBranchProtected(t *testing.T) {
 			name: "Only development branch",
 			expected: scut.TestReturn{
 				Error:         nil,
-				Score:         2,
-				NumberOfWarn:  5,
+				Score:         1,
+				NumberOfWarn:  6,
 				NumberOfInfo:  2,
 				NumberOfDebug: 0,
 			},
@@ -185,8 +222,8 @@ func TestReleaseAndDevBranchProtected(t *testing.T) {
 			expected: scut.TestReturn{
 				Error:         nil,
 				Score:         2,
-				NumberOfWarn:  6,
-				NumberOfInfo:  8,
+				NumberOfWarn:  7,
+				NumberOfInfo:  9,
 				NumberOfDebug: 0,
 			},
 			defaultBranch: main,
@@ -240,7 +277,7 @@ func TestReleaseAndDevBranchProtected(t *testing.T) {
 				Error:         nil,
 				Score:         8,
 				NumberOfWarn:  2,
-				NumberOfInfo:  12,
+				NumberOfInfo:  14,
 				NumberOfDebug: 0,
 			},
 			defaultBranch: main,
@@ -292,8 +329,8 @@ func TestReleaseAndDevBranchProtected(t *testing.T) {
 			name: "Ignore a non-branch targetcommitish",
 			expected: scut.TestReturn{
 				Error:         nil,
-				Score:         2,
-				NumberOfWarn:  5,
+				Score:         1,
+				NumberOfWarn:  6,
 				NumberOfInfo:  2,
 				NumberOfDebug: 0,
 			},
@@ -397,6 +434,46 @@ func TestReleaseAndDevBranchProtected(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Non-admin check with settings inferred from history",
+			expected: scut.TestReturn{
+				Error:         nil,
+				Score:         1,
+				NumberOfWarn:  2,
+				NumberOfInfo:  3,
+				NumberOfDebug: 3,
+			},
+			nonadmin:      true,
+			defaultBranch: main,
+			branches: []*clients.BranchRef{
+				{
+					Name:      &main,
+					Protected: &trueVal,
+				},
+			},
+			mergedPRs: approvedPRs(5),
+			commits:   mergeCommits(5),
+		},
+		{
+			name: "Non-admin check with rewritten history",
+			expected: scut.TestReturn{
+				Error:         nil,
+				Score:         0,
+				NumberOfWarn:  2,
+				NumberOfInfo:  1,
+				NumberOfDebug: 3,
+			},
+			nonadmin:      true,
+			defaultBranch: main,
+			branches: []*clients.BranchRef{
+				{
+					Name:      &main,
+					Protected: &trueVal,
+				},
+			},
+			mergedPRs: approvedPRs(5),
+			commits:   mergeCommits(4),
+		},
 	}
 
 	for _, tt := range tests {
@@ -431,6 +508,12 @@ func TestReleaseAndDevBranchProtected(t *testing.T) {
 					}
 					return tt.branches, nil
 				}).AnyTimes()
+			mockRepoClient.EXPECT().ListMergedPRs().Return(tt.mergedPRs, nil).AnyTimes()
+			mockRepoClient.EXPECT().ListCommits().Return(tt.commits, nil).AnyTimes()
+			mockRepoClient.EXPECT().GetFileContent(gomock.Any()).Return(nil, os.ErrNotExist).AnyTimes()
+			mockRepoClient.EXPECT().ListTags().Return(nil, nil).AnyTimes()
+			mockRepoClient.EXPECT().GetBranch(gomock.Any()).Return(nil, clients.ErrBranchNotFound).AnyTimes()
+			mockRepoClient.EXPECT().ListBranchesForCommit(gomock.Any()).Return(nil, nil).AnyTimes()
 			dl := scut.TestDetailLogger{}
 			r := checkReleaseAndDevBranchProtection(mockRepoClient, &dl)
 			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &r, &dl) {
@@ -457,8 +540,8 @@ func TestIsBranchProtected(t *testing.T) {
 			name: "Nothing is enabled",
 			expected: scut.TestReturn{
 				Error:         nil,
-				Score:         2,
-				NumberOfWarn:  5,
+				Score:         1,
+				NumberOfWarn:  6,
 				NumberOfInfo:  2,
 				NumberOfDebug: 0,
 			},
@@ -494,8 +577,8 @@ func TestIsBranchProtected(t *testing.T) {
 			name: "Required status check enabled",
 			expected: scut.TestReturn{
 				Error:         nil,
-				Score:         2,
-				NumberOfWarn:  3,
+				Score:         1,
+				NumberOfWarn:  4,
 				NumberOfInfo:  4,
 				NumberOfDebug: 0,
 			},
@@ -520,8 +603,8 @@ func TestIsBranchProtected(t *testing.T) {
 			name: "Required status check enabled without checking for status string",
 			expected: scut.TestReturn{
 				Error:         nil,
-				Score:         2,
-				NumberOfWarn:  4,
+				Score:         1,
+				NumberOfWarn:  5,
 				NumberOfInfo:  3,
 				NumberOfDebug: 0,
 			},
@@ -548,7 +631,7 @@ func TestIsBranchProtected(t *testing.T) {
 				Error:         nil,
 				Score:         2,
 				NumberOfWarn:  4,
-				NumberOfInfo:  3,
+				NumberOfInfo:  4,
 				NumberOfDebug: 0,
 			},
 			protection: &clients.BranchProtectionRule{
@@ -572,8 +655,8 @@ func TestIsBranchProtected(t *testing.T) {
 			name: "Required admin enforcement enabled",
 			expected: scut.TestReturn{
 				Error:         nil,
-				Score:         3,
-				NumberOfWarn:  3,
+				Score:         2,
+				NumberOfWarn:  4,
 				NumberOfInfo:  4,
 				NumberOfDebug: 0,
 			},
@@ -600,7 +683,7 @@ func TestIsBranchProtected(t *testing.T) {
 				Error:         nil,
 				Score:         2,
 				NumberOfWarn:  4,
-				NumberOfInfo:  3,
+				NumberOfInfo:  4,
 				NumberOfDebug: 0,
 			},
 			protection: &clients.BranchProtectionRule{
@@ -624,8 +707,8 @@ func TestIsBranchProtected(t *testing.T) {
 			name: "Allow force push enabled",
 			expected: scut.TestReturn{
 				Error:         nil,
-				Score:         1,
-				NumberOfWarn:  5,
+				Score:         0,
+				NumberOfWarn:  6,
 				NumberOfInfo:  2,
 				NumberOfDebug: 0,
 			},
@@ -650,8 +733,8 @@ func TestIsBranchProtected(t *testing.T) {
 			name: "Allow deletions enabled",
 			expected: scut.TestReturn{
 				Error:         nil,
-				Score:         1,
-				NumberOfWarn:  5,
+				Score:         0,
+				NumberOfWarn:  6,
 				NumberOfInfo:  2,
 				NumberOfDebug: 0,
 			},
@@ -678,7 +761,7 @@ func TestIsBranchProtected(t *testing.T) {
 				Error:         nil,
 				Score:         8,
 				NumberOfWarn:  1,
-				NumberOfInfo:  6,
+				NumberOfInfo:  7,
 				NumberOfDebug: 0,
 			},
 			protection: &clients.BranchProtectionRule{
@@ -715,3 +798,587 @@ func TestIsBranchProtected(t *testing.T) {
 		})
 	}
 }
+
+func TestSignedCommitsBonus(t *testing.T) {
+	t.Parallel()
+	trueVal := true
+	falseVal := false
+	var oneVal int32 = 1
+	var twoVal int32 = 2
+	branch := "main"
+
+	rule := func(reviewers *int32, signed *bool) clients.BranchProtectionRule {
+		return clients.BranchProtectionRule{
+			CheckRules: clients.StatusChecksRule{
+				RequiresStatusChecks: &trueVal,
+				UpToDateBeforeMerge:  &trueVal,
+				Contexts:             []string{"foo"},
+			},
+			RequiredPullRequestReviews: clients.PullRequestReviewRule{
+				DismissStaleReviews:          &trueVal,
+				RequireCodeOwnerReviews:      &trueVal,
+				RequiredApprovingReviewCount: reviewers,
+			},
+			EnforceAdmins:        &trueVal,
+			RequireLinearHistory: &trueVal,
+			RequiresSignatures:   signed,
+			AllowForcePushes:     &falseVal,
+			AllowDeletions:       &falseVal,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		rule     clients.BranchProtectionRule
+		expected scut.TestReturn
+	}{
+		{
+			name: "signed commits not required",
+			rule: rule(&oneVal, &falseVal),
+			expected: scut.TestReturn{
+				Score:        8,
+				NumberOfWarn: 1,
+				NumberOfInfo: 7,
+			},
+		},
+		{
+			name: "signed commits not readable",
+			rule: rule(&oneVal, nil),
+			expected: scut.TestReturn{
+				Score:        8,
+				NumberOfWarn: 1,
+				NumberOfInfo: 7,
+			},
+		},
+		{
+			name: "signed commits required",
+			rule: rule(&oneVal, &trueVal),
+			expected: scut.TestReturn{
+				Score:        9,
+				NumberOfWarn: 1,
+				NumberOfInfo: 8,
+			},
+		},
+		{
+			name: "bonus capped at max score",
+			rule: rule(&twoVal, &trueVal),
+			expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 9,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			data := checker.BranchProtectionsData{
+				Branches: []clients.BranchRef{
+					{
+						Name:                 &branch,
+						Protected:            &trueVal,
+						BranchProtectionRule: tt.rule,
+					},
+				},
+			}
+			actual := evaluateBranchProtection(&dl, nil, &data, nil)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &actual, &dl) {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestRequiredStatusChecks(t *testing.T) {
+	t.Parallel()
+	trueVal := true
+	falseVal := false
+	var twoVal int32 = 2
+	branch := "main"
+
+	rule := clients.BranchProtectionRule{
+		CheckRules: clients.StatusChecksRule{
+			RequiresStatusChecks: &trueVal,
+			UpToDateBeforeMerge:  &trueVal,
+			Contexts:             []string{"ci/unit-tests", "golangci-lint"},
+		},
+		RequiredPullRequestReviews: clients.PullRequestReviewRule{
+			DismissStaleReviews:          &trueVal,
+			RequireCodeOwnerReviews:      &trueVal,
+			RequiredApprovingReviewCount: &twoVal,
+		},
+		EnforceAdmins:        &trueVal,
+		RequireLinearHistory: &trueVal,
+		RequiresSignatures:   &falseVal,
+		AllowForcePushes:     &falseVal,
+		AllowDeletions:       &falseVal,
+	}
+
+	tests := []struct {
+		name     string
+		required []string
+		// workflows, if set, are required instead of the contexts.
+		workflows []string
+		expected  scut.TestReturn
+	}{
+		{
+			name: "no required contexts",
+			expected: scut.TestReturn{
+				Score:        10,
+				NumberOfInfo: 8,
+			},
+		},
+		{
+			name:     "all required contexts",
+			required: []string{"ci/*", "*lint"},
+			expected: scut.TestReturn{
+				Score:        10,
+				NumberOfInfo: 10,
+			},
+		},
+		{
+			name:     "some required contexts",
+			required: []string{"ci/*", "codeql"},
+			expected: scut.TestReturn{
+				Score:        7,
+				NumberOfWarn: 1,
+				NumberOfInfo: 9,
+			},
+		},
+		{
+			name:     "no matching contexts",
+			required: []string{"codeql", "fuzz*"},
+			expected: scut.TestReturn{
+				Score:        6,
+				NumberOfWarn: 2,
+				NumberOfInfo: 8,
+			},
+		},
+		{
+			name:      "only workflows required by organization",
+			workflows: []string{".github/workflows/org-scan.yml"},
+			expected: scut.TestReturn{
+				Score:        10,
+				NumberOfInfo: 9,
+			},
+		},
+		{
+			name:      "required contexts matching workflows",
+			required:  []string{".github/workflows/*", "ci/*"},
+			workflows: []string{".github/workflows/org-scan.yml"},
+			expected: scut.TestReturn{
+				Score:        7,
+				NumberOfWarn: 1,
+				NumberOfInfo: 10,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			rule := rule
+			if tt.workflows != nil {
+				rule.CheckRules.Contexts = nil
+				rule.CheckRules.RequiredWorkflows = tt.workflows
+			}
+			data := checker.BranchProtectionsData{
+				Branches: []clients.BranchRef{
+					{
+						Name:                 &branch,
+						Protected:            &trueVal,
+						BranchProtectionRule: rule,
+					},
+				},
+			}
+			actual := evaluateBranchProtection(&dl, nil, &data, tt.required)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &actual, &dl) {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestBranchHistory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		history  checker.BranchHistory
+		expected scut.TestReturn
+	}{
+		{
+			name:    "no commits",
+			history: checker.BranchHistory{Branch: "main"},
+			expected: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name:    "PRs only, no reflog",
+			history: checker.BranchHistory{Branch: "main", Commits: 30},
+			expected: scut.TestReturn{
+				Score:         5,
+				NumberOfInfo:  1,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name:    "PRs only, no force pushes",
+			history: checker.BranchHistory{Branch: "main", Commits: 30, BranchUpdates: 4},
+			expected: scut.TestReturn{
+				Score:        8,
+				NumberOfInfo: 2,
+			},
+		},
+		{
+			name:    "direct pushes and force pushes",
+			history: checker.BranchHistory{Branch: "main", Commits: 30, DirectPushes: 15, BranchUpdates: 4, ForcePushes: 1},
+			expected: scut.TestReturn{
+				Score:        2,
+				NumberOfWarn: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			data := checker.BranchProtectionsData{History: &tt.history}
+			actual := evaluateBranchProtection(&dl, nil, &data, nil)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &actual, &dl) {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestMergeBots(t *testing.T) {
+	t.Parallel()
+	trueVal := true
+	falseVal := false
+	var zeroVal int32
+	main := "main"
+
+	// Projects merging with a bot typically do not require approving reviews.
+	branch := func(mergeQueue *bool) *clients.BranchRef {
+		return &clients.BranchRef{
+			Name:      &main,
+			Protected: &trueVal,
+			BranchProtectionRule: clients.BranchProtectionRule{
+				CheckRules: clients.StatusChecksRule{
+					RequiresStatusChecks: &trueVal,
+					UpToDateBeforeMerge:  &trueVal,
+					Contexts:             []string{"foo"},
+				},
+				RequiredPullRequestReviews: clients.PullRequestReviewRule{
+					DismissStaleReviews:          &falseVal,
+					RequiredApprovingReviewCount: &zeroVal,
+				},
+				EnforceAdmins:        &trueVal,
+				RequireLinearHistory: &trueVal,
+				AllowForcePushes:     &falseVal,
+				AllowDeletions:       &falseVal,
+				RequiresMergeQueue:   mergeQueue,
+			},
+		}
+	}
+	labeledPRs := func(n int, labels ...string) []clients.PullRequest {
+		prs := approvedPRs(n)
+		for i := range prs {
+			for _, l := range labels {
+				prs[i].Labels = append(prs[i].Labels, clients.Label{Name: l})
+			}
+		}
+		return prs
+	}
+
+	checktest.Run(t, BranchProtection, []checktest.Case{
+		{
+			Name: "reviews not required",
+			Repo: checktest.Repo{
+				DefaultBranch: branch(nil),
+				Branches:      []*clients.BranchRef{branch(nil)},
+			},
+			Expected: scut.TestReturn{
+				Score:        4,
+				NumberOfWarn: 2,
+				NumberOfInfo: 6,
+			},
+		},
+		{
+			Name: "merge queue",
+			Repo: checktest.Repo{
+				DefaultBranch: branch(&trueVal),
+				Branches:      []*clients.BranchRef{branch(&trueVal)},
+			},
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 9,
+			},
+		},
+		{
+			Name: "tide configured in repo",
+			Repo: checktest.Repo{
+				DefaultBranch: branch(nil),
+				Branches:      []*clients.BranchRef{branch(nil)},
+				Files: map[string]string{
+					"OWNERS":           "approvers:\n- alice\n",
+					"prow/config.yaml": "tide:\n  queries:\n  - repos:\n    - org/repo\n",
+				},
+			},
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 9,
+			},
+		},
+		{
+			Name: "PRs labeled by prow",
+			Repo: checktest.Repo{
+				DefaultBranch: branch(nil),
+				Branches:      []*clients.BranchRef{branch(nil)},
+				Files:         map[string]string{"OWNERS": "approvers:\n- alice\n"},
+				MergedPRs:     labeledPRs(5, "lgtm", "approved", "size/S"),
+			},
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 9,
+			},
+		},
+		{
+			Name: "PRs not approved by prow",
+			Repo: checktest.Repo{
+				DefaultBranch: branch(nil),
+				Branches:      []*clients.BranchRef{branch(nil)},
+				Files:         map[string]string{"OWNERS": "approvers:\n- alice\n"},
+				MergedPRs:     labeledPRs(5, "lgtm"),
+			},
+			Expected: scut.TestReturn{
+				Score:        4,
+				NumberOfWarn: 2,
+				NumberOfInfo: 6,
+			},
+		},
+		{
+			Name: "labels without OWNERS",
+			Repo: checktest.Repo{
+				DefaultBranch: branch(nil),
+				Branches:      []*clients.BranchRef{branch(nil)},
+				MergedPRs:     labeledPRs(5, "lgtm", "approved"),
+			},
+			Expected: scut.TestReturn{
+				Score:        4,
+				NumberOfWarn: 2,
+				NumberOfInfo: 6,
+			},
+		},
+	})
+}
+
+// fullyProtectedBranch returns a branch with all the settings scored,
+// except signed commits, enabled.
+func fullyProtectedBranch(name string) *clients.BranchRef {
+	trueVal := true
+	falseVal := false
+	var twoVal int32 = 2
+	return &clients.BranchRef{
+		Name:      &name,
+		Protected: &trueVal,
+		BranchProtectionRule: clients.BranchProtectionRule{
+			CheckRules: clients.StatusChecksRule{
+				RequiresStatusChecks: &trueVal,
+				UpToDateBeforeMerge:  &trueVal,
+				Contexts:             []string{"foo"},
+			},
+			RequiredPullRequestReviews: clients.PullRequestReviewRule{
+				DismissStaleReviews:          &trueVal,
+				RequiredApprovingReviewCount: &twoVal,
+			},
+			EnforceAdmins:        &trueVal,
+			RequireLinearHistory: &trueVal,
+			AllowForcePushes:     &falseVal,
+			AllowDeletions:       &falseVal,
+		},
+	}
+}
+
+func TestTagBranches(t *testing.T) {
+	t.Parallel()
+	falseVal := false
+	main := "main"
+	release := "release-1.x"
+
+	protected := fullyProtectedBranch(main)
+	unprotected := &clients.BranchRef{
+		Name:      &release,
+		Protected: &falseVal,
+	}
+	repo := func(releases []clients.Release, tags ...clients.Tag) checktest.Repo {
+		return checktest.Repo{
+			DefaultBranch: protected,
+			Branches:      []*clients.BranchRef{protected, unprotected},
+			Releases:      releases,
+			Tags:          tags,
+		}
+	}
+
+	checktest.Run(t, BranchProtection, []checktest.Case{
+		{
+			Name: "no releases or tags",
+			Repo: repo(nil),
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 8,
+			},
+		},
+		{
+			Name: "semver tag on an unprotected branch",
+			Repo: repo(nil, clients.Tag{Name: "v1.2.0", SHA: "sha1", Branches: []string{release}}),
+			Expected: scut.TestReturn{
+				Score:        1,
+				NumberOfWarn: 1,
+				NumberOfInfo: 8,
+			},
+		},
+		{
+			Name: "tags not following semver",
+			Repo: repo(nil, clients.Tag{Name: "nightly", SHA: "sha1", Branches: []string{release}}),
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 8,
+			},
+		},
+		{
+			Name: "tag on a deleted branch",
+			Repo: repo(nil, clients.Tag{Name: "1.0.0", SHA: "sha1", Branches: []string{"release-0.x"}}),
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 8,
+			},
+		},
+		{
+			Name: "tags ignored when there are releases",
+			Repo: repo([]clients.Release{{TagName: "v1.0.0", TargetCommitish: main}},
+				clients.Tag{Name: "v1.2.0", SHA: "sha1", Branches: []string{release}}),
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 8,
+			},
+		},
+	})
+}
+
+func TestCommitReleaseBranches(t *testing.T) {
+	t.Parallel()
+	falseVal := false
+	const sha = "8fb3cb86082b17144a80402f5367ae65f06083bd"
+	main := fullyProtectedBranch("main")
+	minor := fullyProtectedBranch("release-1.2")
+	unprotectedName := "release-1.x"
+	major := &clients.BranchRef{
+		Name:      &unprotectedName,
+		Protected: &falseVal,
+	}
+	repo := func(branches ...string) checktest.Repo {
+		return checktest.Repo{
+			DefaultBranch:  main,
+			Branches:       []*clients.BranchRef{main, minor, major},
+			Releases:       []clients.Release{{TagName: "v1.2.3", TargetCommitish: sha}},
+			CommitBranches: map[string][]string{sha: branches},
+		}
+	}
+
+	checktest.Run(t, BranchProtection, []checktest.Case{
+		{
+			Name: "commit not found on any branch",
+			Repo: repo(),
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 8,
+			},
+		},
+		{
+			Name: "commit only on the default branch",
+			Repo: repo("main"),
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 8,
+			},
+		},
+		{
+			Name: "most specific release branch is protected",
+			Repo: repo("main", "release-1.x", "release-1.2"),
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 16,
+			},
+		},
+		{
+			Name: "release branch is unprotected",
+			Repo: repo("main", "release-1.x"),
+			Expected: scut.TestReturn{
+				Score:        1,
+				NumberOfWarn: 1,
+				NumberOfInfo: 8,
+			},
+		},
+		{
+			Name: "release branch was deleted",
+			Repo: repo("main", "release-1.1"),
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 8,
+			},
+		},
+	})
+}
+
+func TestRenamedReleaseBranches(t *testing.T) {
+	t.Parallel()
+	falseVal := false
+	main := fullyProtectedBranch("main")
+	releaseName := "release/v1"
+	release := &clients.BranchRef{
+		Name:      &releaseName,
+		Protected: &falseVal,
+	}
+	repo := func(target string) checktest.Repo {
+		return checktest.Repo{
+			DefaultBranch:   main,
+			Branches:        []*clients.BranchRef{main, release},
+			Releases:        []clients.Release{{TagName: "v1.0.0", TargetCommitish: target}},
+			RenamedBranches: map[string]string{"master": "main", "release-1": releaseName},
+		}
+	}
+
+	checktest.Run(t, BranchProtection, []checktest.Case{
+		{
+			Name: "default branch renamed",
+			Repo: repo("master"),
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 8,
+			},
+		},
+		{
+			Name: "release branch renamed",
+			Repo: repo("release-1"),
+			Expected: scut.TestReturn{
+				Score:        1,
+				NumberOfWarn: 1,
+				NumberOfInfo: 8,
+			},
+		},
+		{
+			Name: "unknown release branch",
+			Repo: repo("release-2"),
+			Expected: scut.TestReturn{
+				Error: sce.ErrScorecardInternal,
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+	})
+}
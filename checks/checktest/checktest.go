@@ -0,0 +1,174 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checktest runs Scorecard checks against canned RepoClient responses.
+package checktest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+	scut "github.com/ossf/scorecard/v3/utests"
+)
+
+// Repo holds the canned responses of the RepoClient. Each RepoClient
+// method returns the corresponding field, or nothing if it is not set.
+type Repo struct {
+	DefaultBranch *clients.BranchRef
+	// Files maps file paths to their content.
+	Files map[string]string
+	// WorkflowRuns maps workflow filenames to their successful runs.
+	WorkflowRuns map[string][]clients.WorkflowRun
+	// CheckRuns maps refs to their check runs.
+	CheckRuns map[string][]clients.CheckRun
+	// CommitBranches maps commit SHAs to the branches they were merged onto.
+	CommitBranches map[string][]string
+	// RenamedBranches maps the former names of branches to their current name.
+	RenamedBranches map[string]string
+	// Statuses maps refs to their statuses.
+	Statuses map[string][]clients.Status
+	// Attestations maps digests to the attestations of the artifacts.
+	Attestations       map[string][]clients.Attestation
+	SearchResponse     clients.SearchResponse
+	URI                string
+	MergedPRs          []clients.PullRequest
+	Branches           []*clients.BranchRef
+	Commits            []clients.Commit
+	Issues             []clients.Issue
+	Releases           []clients.Release
+	Tags               []clients.Tag
+	Contributors       []clients.Contributor
+	SecurityAdvisories []clients.SecurityAdvisory
+	ContainerImages    []clients.ContainerImage
+	Archived           bool
+}
+
+// Case is a test case of a check.
+type Case struct {
+	// Setup sets expectations on the client before the canned responses,
+	// so it can make some calls fail or return dynamic results.
+	Setup    func(client *mockrepo.MockRepoClient)
+	Name     string
+	Repo     Repo
+	Expected scut.TestReturn
+}
+
+// Run runs check against the canned responses of each case, in parallel
+// subtests, and validates its score, error and number of details.
+//nolint:thelper // t is the parent of the subtests.
+func Run(t *testing.T, check checker.CheckFn, cases []Case) {
+	for _, tt := range cases {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.Name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			client := mockrepo.NewMockRepoClient(ctrl)
+			if tt.Setup != nil {
+				tt.Setup(client)
+			}
+			SetResponses(client, &tt.Repo)
+			repo := mockrepo.NewMockRepo(ctrl)
+			repo.EXPECT().URI().Return(tt.Repo.URI).AnyTimes()
+			repo.EXPECT().String().Return(tt.Repo.URI).AnyTimes()
+
+			dl := scut.TestDetailLogger{}
+			req := checker.CheckRequest{
+				Ctx:        context.Background(),
+				RepoClient: client,
+				Repo:       repo,
+				Dlogger:    &dl,
+			}
+			res := check(&req)
+			if !scut.ValidateTestReturn(t, tt.Name, &tt.Expected, &res, &dl) {
+				t.Errorf("%s: unexpected result, score %d, error %v", tt.Name, res.Score, res.Error2)
+			}
+		})
+	}
+}
+
+// SetResponses makes client return the canned responses of repo, any number of times.
+func SetResponses(client *mockrepo.MockRepoClient, repo *Repo) {
+	client.EXPECT().URI().Return(repo.URI).AnyTimes()
+	client.EXPECT().IsArchived().Return(repo.Archived, nil).AnyTimes()
+	client.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+		func(predicate func(string) (bool, error)) ([]string, error) {
+			var ret []string
+			for name := range repo.Files {
+				matches, err := predicate(name)
+				if err != nil {
+					return nil, err
+				}
+				if matches {
+					ret = append(ret, name)
+				}
+			}
+			sort.Strings(ret)
+			return ret, nil
+		}).AnyTimes()
+	client.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(
+		func(filename string) ([]byte, error) {
+			content, ok := repo.Files[filename]
+			if !ok {
+				return nil, fmt.Errorf("%s: %w", filename, os.ErrNotExist)
+			}
+			return []byte(content), nil
+		}).AnyTimes()
+	client.EXPECT().ListMergedPRs().Return(repo.MergedPRs, nil).AnyTimes()
+	client.EXPECT().ListBranches().Return(repo.Branches, nil).AnyTimes()
+	client.EXPECT().GetDefaultBranch().Return(repo.DefaultBranch, nil).AnyTimes()
+	client.EXPECT().GetBranch(gomock.Any()).DoAndReturn(
+		func(name string) (*clients.BranchRef, error) {
+			if current, ok := repo.RenamedBranches[name]; ok {
+				name = current
+			}
+			//nolint:wrapcheck
+			return clients.FindBranch(repo.Branches, name)
+		}).AnyTimes()
+	client.EXPECT().ListCommits().Return(repo.Commits, nil).AnyTimes()
+	client.EXPECT().ListIssues().Return(repo.Issues, nil).AnyTimes()
+	client.EXPECT().ListReleases().Return(repo.Releases, nil).AnyTimes()
+	client.EXPECT().ListAttestations(gomock.Any()).DoAndReturn(
+		func(subjectDigest string) ([]clients.Attestation, error) {
+			return repo.Attestations[subjectDigest], nil
+		}).AnyTimes()
+	client.EXPECT().ListContainerImages().Return(repo.ContainerImages, nil).AnyTimes()
+	client.EXPECT().ListTags().Return(repo.Tags, nil).AnyTimes()
+	client.EXPECT().ListBranchesForCommit(gomock.Any()).DoAndReturn(
+		func(sha string) ([]string, error) {
+			return repo.CommitBranches[sha], nil
+		}).AnyTimes()
+	client.EXPECT().ListContributors().Return(repo.Contributors, nil).AnyTimes()
+	client.EXPECT().ListSuccessfulWorkflowRuns(gomock.Any()).DoAndReturn(
+		func(filename string) ([]clients.WorkflowRun, error) {
+			return repo.WorkflowRuns[filename], nil
+		}).AnyTimes()
+	client.EXPECT().ListCheckRunsForRef(gomock.Any()).DoAndReturn(
+		func(ref string) ([]clients.CheckRun, error) {
+			return repo.CheckRuns[ref], nil
+		}).AnyTimes()
+	client.EXPECT().ListStatuses(gomock.Any()).DoAndReturn(
+		func(ref string) ([]clients.Status, error) {
+			return repo.Statuses[ref], nil
+		}).AnyTimes()
+	client.EXPECT().ListSecurityAdvisories().Return(repo.SecurityAdvisories, nil).AnyTimes()
+	client.EXPECT().Search(gomock.Any()).Return(repo.SearchResponse, nil).AnyTimes()
+}
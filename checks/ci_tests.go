@@ -15,8 +15,8 @@
 package checks
 
 import (
-	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ossf/scorecard/v3/checker"
 	"github.com/ossf/scorecard/v3/clients"
@@ -31,17 +31,21 @@ const (
 
 //nolint:gochecknoinits
 func init() {
-	registerCheck(CheckCITests, CITests)
+	registerCheck(CheckCITests, CITests, clients.FeatureCheckRuns)
 }
 
 // CITests runs CI-Tests check.
 func CITests(c *checker.CheckRequest) checker.CheckResult {
 	prs, err := c.RepoClient.ListMergedPRs()
 	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("RepoClient.ListMergedPRs: %v", err))
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "RepoClient.ListMergedPRs")
 		return checker.CreateRuntimeErrorResult(CheckCITests, e)
 	}
 
+	// Each PR costs API calls, so only look at a sample of them.
+	prs, info := samplePullRequests(prs)
+	logSampleInfo(c.Dlogger, info, "merged PRs")
+
 	totalMerged := 0
 	totalTested := 0
 	for index := range prs {
@@ -76,36 +80,47 @@ func CITests(c *checker.CheckRequest) checker.CheckResult {
 
 		if !foundCI {
 			c.Dlogger.Debug3(&checker.LogMessage{
-				Text: fmt.Sprintf("merged PR without CI test: %d", pr.Number),
+				Message: checker.NewMessage(checker.MsgCITestsUntestedPR, checker.MessageParams{"pr": pr.Number}),
 			})
 		}
 	}
 
 	if totalMerged == 0 {
-		return checker.CreateInconclusiveResult(CheckCITests, "no pull request found")
+		reason := checker.NewMessage(checker.MsgCITestsNoPRs, nil)
+		return checker.CreateInconclusiveResult(CheckCITests, reason.String()).WithReasonMessage(reason)
 	}
 
-	reason := fmt.Sprintf("%d out of %d merged PRs checked by a CI test", totalTested, totalMerged)
-	return checker.CreateProportionalScoreResult(CheckCITests, reason, totalTested, totalMerged)
+	reason := checker.NewMessage(checker.MsgCITestsSummary, checker.MessageParams{
+		"tested": totalTested,
+		"total":  totalMerged,
+	})
+	return checker.CreateProportionalScoreResult(CheckCITests, reason.String(),
+		totalTested, totalMerged).WithReasonMessage(reason)
 }
 
 // PR has a status marked 'success' and a CI-related context.
 func prHasSuccessStatus(pr *clients.PullRequest, c *checker.CheckRequest) (bool, error) {
 	statuses, err := c.RepoClient.ListStatuses(pr.HeadSHA)
 	if err != nil {
-		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Client.Repositories.ListStatuses: %v", err))
+		return false, sce.Wrap(sce.ErrScorecardInternal, err, "Client.Repositories.ListStatuses")
 	}
 
 	for _, status := range statuses {
 		if status.State != success {
 			continue
 		}
+		if reportedAfterMerge(pr, status.UpdatedAt) {
+			continue
+		}
 		if isTest(status.Context) || isTest(status.TargetURL) {
+			msg := checker.NewMessage(checker.MsgCITestsFound, checker.MessageParams{
+				"pr":      pr.Number,
+				"context": status.Context,
+			})
 			c.Dlogger.Debug3(&checker.LogMessage{
-				Path: status.URL,
-				Type: checker.FileTypeURL,
-				Text: fmt.Sprintf("CI test found: pr: %d, context: %s", pr.Number,
-					status.Context),
+				Path:    status.URL,
+				Type:    checker.FileTypeURL,
+				Message: msg,
 			})
 			return true, nil
 		}
@@ -117,7 +132,7 @@ func prHasSuccessStatus(pr *clients.PullRequest, c *checker.CheckRequest) (bool,
 func prHasSuccessfulCheck(pr *clients.PullRequest, c *checker.CheckRequest) (bool, error) {
 	crs, err := c.RepoClient.ListCheckRunsForRef(pr.HeadSHA)
 	if err != nil {
-		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Client.Checks.ListCheckRunsForRef: %v", err))
+		return false, sce.Wrap(sce.ErrScorecardInternal, err, "Client.Checks.ListCheckRunsForRef")
 	}
 
 	for _, cr := range crs {
@@ -127,12 +142,18 @@ func prHasSuccessfulCheck(pr *clients.PullRequest, c *checker.CheckRequest) (boo
 		if cr.Conclusion != success {
 			continue
 		}
+		if reportedAfterMerge(pr, cr.CompletedAt) {
+			continue
+		}
 		if isTest(cr.App.Slug) {
+			msg := checker.NewMessage(checker.MsgCITestsFound, checker.MessageParams{
+				"pr":      pr.Number,
+				"context": cr.App.Slug,
+			})
 			c.Dlogger.Debug3(&checker.LogMessage{
-				Path: cr.URL,
-				Type: checker.FileTypeURL,
-				Text: fmt.Sprintf("CI test found: pr: %d, context: %s", pr.Number,
-					cr.App.Slug),
+				Path:    cr.URL,
+				Type:    checker.FileTypeURL,
+				Message: msg,
 			})
 			return true, nil
 		}
@@ -140,6 +161,12 @@ func prHasSuccessfulCheck(pr *clients.PullRequest, c *checker.CheckRequest) (boo
 	return false, nil
 }
 
+// CI results reported after the merge did not gate it.
+// Results without a timestamp are given the benefit of the doubt.
+func reportedAfterMerge(pr *clients.PullRequest, t time.Time) bool {
+	return !t.IsZero() && t.After(pr.MergedAt)
+}
+
 func isTest(s string) bool {
 	l := strings.ToLower(s)
 
@@ -0,0 +1,126 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+	scut "github.com/ossf/scorecard/v3/utests"
+)
+
+func TestCITests(t *testing.T) {
+	t.Parallel()
+	mergedAt := time.Date(2021, time.October, 1, 12, 0, 0, 0, time.UTC)
+	before := mergedAt.Add(-time.Hour)
+	after := mergedAt.Add(time.Hour)
+	tests := []struct {
+		name      string
+		statuses  []clients.Status
+		checkRuns []clients.CheckRun
+		expected  scut.TestReturn
+	}{
+		{
+			name: "status before merge",
+			statuses: []clients.Status{
+				{State: success, Context: "ci/travis-ci", UpdatedAt: before},
+			},
+			expected: scut.TestReturn{
+				Score:         checker.MaxResultScore,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name: "status after merge",
+			statuses: []clients.Status{
+				{State: success, Context: "ci/travis-ci", UpdatedAt: after},
+			},
+			expected: scut.TestReturn{
+				Score:         checker.MinResultScore,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name: "check run before merge",
+			checkRuns: []clients.CheckRun{
+				{
+					Status: "completed", Conclusion: success, CompletedAt: before,
+					App: clients.CheckRunApp{Slug: "github-actions"},
+				},
+			},
+			expected: scut.TestReturn{
+				Score:         checker.MaxResultScore,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name: "check run after merge",
+			checkRuns: []clients.CheckRun{
+				{
+					Status: "completed", Conclusion: success, CompletedAt: after,
+					App: clients.CheckRunApp{Slug: "github-actions"},
+				},
+			},
+			expected: scut.TestReturn{
+				Score:         checker.MinResultScore,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name: "no timestamp",
+			checkRuns: []clients.CheckRun{
+				{
+					Status: "completed", Conclusion: success,
+					App: clients.CheckRunApp{Slug: "github-actions"},
+				},
+			},
+			expected: scut.TestReturn{
+				Score:         checker.MaxResultScore,
+				NumberOfDebug: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListMergedPRs().Return([]clients.PullRequest{
+				{Number: 1, HeadSHA: "sha1", MergedAt: mergedAt},
+			}, nil)
+			mockRepoClient.EXPECT().ListStatuses("sha1").Return(tt.statuses, nil)
+			mockRepoClient.EXPECT().ListCheckRunsForRef("sha1").Return(tt.checkRuns, nil).AnyTimes()
+
+			dl := scut.TestDetailLogger{}
+			req := checker.CheckRequest{
+				RepoClient: mockRepoClient,
+				Dlogger:    &dl,
+			}
+			res := CITests(&req)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &res, &dl) {
+				t.Fail()
+			}
+			ctrl.Finish()
+		})
+	}
+}
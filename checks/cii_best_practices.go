@@ -38,7 +38,8 @@ func init() {
 // CIIBestPractices runs CII-Best-Practices check.
 func CIIBestPractices(c *checker.CheckRequest) checker.CheckResult {
 	if c.CIIClient == nil {
-		return checker.CreateInconclusiveResult(CheckCIIBestPractices, "CII client is nil")
+		reason := checker.NewMessage(checker.MsgCIIBestPracticesNoClient, nil)
+		return checker.CreateInconclusiveResult(CheckCIIBestPractices, reason.String()).WithReasonMessage(reason)
 	}
 
 	// TODO: not supported for local clients.
@@ -46,20 +47,26 @@ func CIIBestPractices(c *checker.CheckRequest) checker.CheckResult {
 	if err == nil {
 		switch badgeLevel {
 		case clients.NotFound:
-			return checker.CreateMinScoreResult(CheckCIIBestPractices, "no badge detected")
+			reason := checker.NewMessage(checker.MsgCIIBestPracticesNoBadge, nil)
+			return checker.CreateMinScoreResult(CheckCIIBestPractices, reason.String()).WithReasonMessage(reason)
 		case clients.InProgress:
-			return checker.CreateResultWithScore(CheckCIIBestPractices, "badge detected: in_progress", inProgressScore)
+			return badgeResult("in_progress", inProgressScore)
 		case clients.Passing:
-			return checker.CreateResultWithScore(CheckCIIBestPractices, "badge detected: passing", passingScore)
+			return badgeResult("passing", passingScore)
 		case clients.Silver:
-			return checker.CreateResultWithScore(CheckCIIBestPractices, "badge detected: silver", silverScore)
+			return badgeResult("silver", silverScore)
 		case clients.Gold:
-			return checker.CreateMaxScoreResult(CheckCIIBestPractices, "badge detected: gold")
+			return badgeResult("gold", checker.MaxResultScore)
 		case clients.Unknown:
 			e := sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("unsupported badge: %v", badgeLevel))
 			return checker.CreateRuntimeErrorResult(CheckCIIBestPractices, e)
 		}
 	}
-	e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	e := sce.Wrap(sce.ErrScorecardInternal, err, "")
 	return checker.CreateRuntimeErrorResult(CheckCIIBestPractices, e)
 }
+
+func badgeResult(level string, score int) checker.CheckResult {
+	reason := checker.NewMessage(checker.MsgCIIBestPracticesBadge, checker.MessageParams{"level": level})
+	return checker.CreateResultWithScore(CheckCIIBestPractices, reason.String(), score).WithReasonMessage(reason)
+}
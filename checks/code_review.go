@@ -15,19 +15,33 @@
 package checks
 
 import (
-	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
 // CheckCodeReview is the registered name for DoesCodeReview.
 const CheckCodeReview = "Code-Review"
 
+var (
+	// bors-ng merge commits start with `Merge #<pr> [#<pr>...]`.
+	borsMergeRegex = regexp.MustCompile(`^Merge( #\d+)+`)
+	// Each merged PR then has a line `<pr>: <title> r=<reviewers> a=<author>`.
+	borsApprovalRegex = regexp.MustCompile(`(?m)^\d+: .* r=(\S+) a=(\S+)\s*$`)
+	// Logins with these suffixes are treated as bot accounts: GitHub Apps
+	// are suffixed with [bot], other bots are usually named e.g. renovate-bot
+	// or k8s-ci-robot. A bare "bot" suffix would match people, e.g. talbot.
+	botSuffixes = []string{"[bot]", "-bot", "_bot", "-robot", "-gardener"}
+	// botLogins are well-known bot accounts without such a suffix.
+	botLogins = map[string]bool{"renovatebot": true}
+)
+
 //nolint:gochecknoinits
 func init() {
-	registerCheck(CheckCodeReview, DoesCodeReview)
+	registerCheck(CheckCodeReview, DoesCodeReview, clients.FeatureCommits)
 }
 
 // DoesCodeReview attempts to determine whether a project requires review before code gets merged.
@@ -35,6 +49,7 @@ func init() {
 // - Looking at the repo configuration to see if reviews are required.
 // - Checking if most of the recent merged PRs were "Approved".
 // - Looking for other well-known review labels.
+// - Looking for bors-ng approvals in merge commit messages.
 func DoesCodeReview(c *checker.CheckRequest) checker.CheckResult {
 	// GitHub reviews.
 	ghScore, ghReason, err := githubCodeReview(c)
@@ -57,49 +72,79 @@ func DoesCodeReview(c *checker.CheckRequest) checker.CheckResult {
 	}
 
 	score, reason = selectBestScoreAndReason(prowScore, score, prowReason, reason, c.Dlogger)
+
+	// bors-ng merge bot.
+	borsScore, borsReason, err := borsCodeReview(c)
+	if err != nil {
+		return checker.CreateRuntimeErrorResult(CheckCodeReview, err)
+	}
+
+	score, reason = selectBestScoreAndReason(borsScore, score, borsReason, reason, c.Dlogger)
 	if score == checker.MinResultScore {
 		c.Dlogger.Info3(&checker.LogMessage{
-			Text: reason,
+			Message: reason,
 		})
-		return checker.CreateResultWithScore(CheckCodeReview, "no reviews detected", score)
+		noReviews := checker.NewMessage(checker.MsgCodeReviewNoReviews, nil)
+		return checker.CreateResultWithScore(CheckCodeReview, noReviews.String(), score).WithReasonMessage(noReviews)
 	}
 
 	if score == checker.InconclusiveResultScore {
-		return checker.CreateInconclusiveResult(CheckCodeReview, "no reviews detected")
+		noReviews := checker.NewMessage(checker.MsgCodeReviewNoReviews, nil)
+		return checker.CreateInconclusiveResult(CheckCodeReview, noReviews.String()).WithReasonMessage(noReviews)
 	}
 
-	return checker.CreateResultWithScore(CheckCodeReview, checker.NormalizeReason(reason, score), score)
+	// Only reviews found by createReturn() get a score above the minimum.
+	normalized := checker.NewMessage(checker.MsgCodeReviewReviewsFoundNormalized, checker.MessageParams{
+		"review":   reason.Params["review"],
+		"reviewed": reason.Params["reviewed"],
+		"total":    reason.Params["total"],
+		"score":    score,
+	})
+	return checker.CreateResultWithScore(CheckCodeReview, normalized.String(), score).WithReasonMessage(normalized)
 }
 
 //nolint
-func selectBestScoreAndReason(s1, s2 int, r1, r2 string,
-	dl checker.DetailLogger) (int, string) {
+func selectBestScoreAndReason(s1, s2 int, r1, r2 *checker.Message,
+	dl checker.DetailLogger) (int, *checker.Message) {
 	if s1 > s2 {
 		dl.Info3(&checker.LogMessage{
-			Text: r2,
+			Message: r2,
 		})
 		return s1, r1
 	}
 
 	dl.Info3(&checker.LogMessage{
-		Text: r1,
+		Message: r1,
 	})
 	return s2, r2
 }
 
 //nolint
-func githubCodeReview(c *checker.CheckRequest) (int, string, error) {
+func githubCodeReview(c *checker.CheckRequest) (int, *checker.Message, error) {
 	// Look at some merged PRs to see if they were reviewed.
 	totalMerged := 0
 	totalReviewed := 0
 	prs, err := c.RepoClient.ListMergedPRs()
 	if err != nil {
-		return 0, "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("RepoClient.ListMergedPRs: %v", err))
+		return 0, nil, sce.Wrap(sce.ErrScorecardInternal, err, "RepoClient.ListMergedPRs")
 	}
+	prs, info := samplePullRequests(prs)
+	logSampleInfo(c.Dlogger, info, "merged PRs")
+	totalBot := 0
 	for _, pr := range prs {
 		if pr.MergedAt.IsZero() {
 			continue
 		}
+		if isBotAccount(pr.Author.Login) {
+			c.Dlogger.Debug3(&checker.LogMessage{
+				Message: checker.NewMessage(checker.MsgCodeReviewSkipBotPR, checker.MessageParams{
+					"pr":     pr.Number,
+					"author": pr.Author.Login,
+				}),
+			})
+			totalBot++
+			continue
+		}
 		totalMerged++
 
 		// Check if the PR is approved by a reviewer.
@@ -107,7 +152,7 @@ func githubCodeReview(c *checker.CheckRequest) (int, string, error) {
 		for _, r := range pr.Reviews {
 			if r.State == "APPROVED" {
 				c.Dlogger.Debug3(&checker.LogMessage{
-					Text: fmt.Sprintf("found review approved pr: %d", pr.Number),
+					Message: checker.NewMessage(checker.MsgCodeReviewApprovedPR, checker.MessageParams{"pr": pr.Number}),
 				})
 				totalReviewed++
 				foundApprovedReview = true
@@ -122,8 +167,11 @@ func githubCodeReview(c *checker.CheckRequest) (int, string, error) {
 			pr.MergeCommit.Committer.Login != "" &&
 			pr.MergeCommit.Committer.Login != pr.Author.Login {
 			c.Dlogger.Debug3(&checker.LogMessage{
-				Text: fmt.Sprintf("found PR#%d with committer (%s) different from author (%s)",
-					pr.Number, pr.Author.Login, pr.MergeCommit.Committer.Login),
+				Message: checker.NewMessage(checker.MsgCodeReviewCommitterNotAuthor, checker.MessageParams{
+					"pr":        pr.Number,
+					"committer": pr.MergeCommit.Committer.Login,
+					"author":    pr.Author.Login,
+				}),
 			})
 			totalReviewed++
 			foundApprovedReview = true
@@ -131,26 +179,34 @@ func githubCodeReview(c *checker.CheckRequest) (int, string, error) {
 
 		if !foundApprovedReview {
 			c.Dlogger.Debug3(&checker.LogMessage{
-				Text: fmt.Sprintf("merged PR without code review: %d", pr.Number),
+				Message: checker.NewMessage(checker.MsgCodeReviewUnreviewedPR, checker.MessageParams{"pr": pr.Number}),
 			})
 		}
 
 	}
 
+	if totalBot > 0 {
+		c.Dlogger.Info3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgCodeReviewBotPRsNotCounted, checker.MessageParams{"count": totalBot}),
+		})
+	}
+
 	return createReturn("GitHub", totalReviewed, totalMerged)
 }
 
 //nolint
-func prowCodeReview(c *checker.CheckRequest) (int, string, error) {
+func prowCodeReview(c *checker.CheckRequest) (int, *checker.Message, error) {
 	// Look at some merged PRs to see if they were reviewed
 	totalMerged := 0
 	totalReviewed := 0
 	prs, err := c.RepoClient.ListMergedPRs()
 	if err != nil {
-		sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("RepoClient.ListMergedPRs: %v", err))
+		return 0, nil, sce.Wrap(sce.ErrScorecardInternal, err, "RepoClient.ListMergedPRs")
 	}
+	// Use the same sample as githubCodeReview(), which logs its metadata.
+	prs, _ = samplePullRequests(prs)
 	for _, pr := range prs {
-		if pr.MergedAt.IsZero() {
+		if pr.MergedAt.IsZero() || isBotAccount(pr.Author.Login) {
 			continue
 		}
 		totalMerged++
@@ -165,28 +221,111 @@ func prowCodeReview(c *checker.CheckRequest) (int, string, error) {
 	return createReturn("Prow", totalReviewed, totalMerged)
 }
 
-//nolint
-func commitMessageHints(c *checker.CheckRequest) (int, string, error) {
+// borsCodeReview gives review credit to commits merged by bors-ng
+// after a human approved them with `bors r+`. These commits are
+// authored by the bot, so commitMessageHints() skips them.
+// Only the bors merges and the commits pushed directly are counted: the
+// commits of the PRs bors merged were reviewed as part of the merge.
+// GitLab merge trains are not supported, as no client reads GitLab
+// merge request approvals.
+func borsCodeReview(c *checker.CheckRequest) (int, *checker.Message, error) {
 	commits, err := c.RepoClient.ListCommits()
 	if err != nil {
-		return checker.InconclusiveResultScore, "",
-			sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Client.Repositories.ListCommits: %v", err))
+		return checker.InconclusiveResultScore, nil,
+			sce.Wrap(sce.ErrScorecardInternal, err, "Client.Repositories.ListCommits")
 	}
+	commits = firstParentHistory(commits)
 
-	total := 0
+	totalBors := 0
 	totalReviewed := 0
 	for _, commit := range commits {
-		isBot := false
-		committer := commit.Committer.Login
-		for _, substring := range []string{"bot", "gardener"} {
-			if strings.Contains(committer, substring) {
-				isBot = true
+		isBors, reviewed := borsApproval(commit.Message)
+		if !isBors {
+			continue
+		}
+		totalBors++
+		if !reviewed {
+			c.Dlogger.Debug3(&checker.LogMessage{
+				Message: checker.NewMessage(checker.MsgCodeReviewBorsUnapproved, checker.MessageParams{"commit": commit.SHA}),
+			})
+			continue
+		}
+		c.Dlogger.Debug3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgCodeReviewBorsApproved, checker.MessageParams{"commit": commit.SHA}),
+		})
+		totalReviewed++
+	}
+
+	// Only compete with the other heuristics if the project uses bors.
+	if totalBors == 0 {
+		return createReturn("bors", 0, 0)
+	}
+	return createReturn("bors", totalReviewed, len(commits))
+}
+
+// firstParentHistory returns the commits of the first-parent history of the
+// latest one, leaving out those brought in by merges. All the commits are
+// returned if the forge does not report their parents.
+func firstParentHistory(commits []clients.Commit) []clients.Commit {
+	if len(commits) == 0 || len(commits[0].ParentSHAs) == 0 {
+		return commits
+	}
+	bySHA := make(map[string]*clients.Commit, len(commits))
+	for i := range commits {
+		bySHA[commits[i].SHA] = &commits[i]
+	}
+	ret := []clients.Commit{}
+	for commit := &commits[0]; commit != nil; {
+		ret = append(ret, *commit)
+		if len(commit.ParentSHAs) == 0 {
+			break
+		}
+		commit = bySHA[commit.ParentSHAs[0]]
+	}
+	return ret
+}
+
+// borsApproval returns whether `message` is a bors-ng merge commit,
+// and whether every PR it merges was approved by someone other than its author.
+func borsApproval(message string) (isBors, reviewed bool) {
+	if !borsMergeRegex.MatchString(message) {
+		return false, false
+	}
+	approvals := borsApprovalRegex.FindAllStringSubmatch(message, -1)
+	if len(approvals) == 0 {
+		return true, false
+	}
+	for _, approval := range approvals {
+		author := approval[2]
+		approvedByOther := false
+		for _, reviewer := range strings.Split(approval[1], ",") {
+			if reviewer != "" && reviewer != author {
+				approvedByOther = true
 				break
 			}
 		}
-		if isBot {
+		if !approvedByOther {
+			return true, false
+		}
+	}
+	return true, true
+}
+
+//nolint
+func commitMessageHints(c *checker.CheckRequest) (int, *checker.Message, error) {
+	commits, err := c.RepoClient.ListCommits()
+	if err != nil {
+		return checker.InconclusiveResultScore, nil,
+			sce.Wrap(sce.ErrScorecardInternal, err, "Client.Repositories.ListCommits")
+	}
+
+	total := 0
+	totalReviewed := 0
+	for _, commit := range commits {
+		committer := commit.Committer.Login
+		if isBotAccount(committer) {
 			c.Dlogger.Debug3(&checker.LogMessage{
-				Text: fmt.Sprintf("skip commit from bot account: %s", committer),
+				Message: checker.NewMessage(checker.MsgCodeReviewSkipBotCommit, checker.MessageParams{"committer": committer}),
 			})
 			continue
 		}
@@ -198,7 +337,7 @@ func commitMessageHints(c *checker.CheckRequest) (int, string, error) {
 		if strings.Contains(commitMessage, "\nReviewed-on: ") &&
 			strings.Contains(commitMessage, "\nReviewed-by: ") {
 			c.Dlogger.Debug3(&checker.LogMessage{
-				Text: fmt.Sprintf("Gerrit review found for commit '%s'", commit.SHA),
+				Message: checker.NewMessage(checker.MsgCodeReviewGerritReview, checker.MessageParams{"commit": commit.SHA}),
 			})
 			totalReviewed++
 			continue
@@ -208,12 +347,31 @@ func commitMessageHints(c *checker.CheckRequest) (int, string, error) {
 	return createReturn("Gerrit", totalReviewed, total)
 }
 
+// isBotAccount returns whether login looks like a bot account, e.g. dependabot[bot].
+func isBotAccount(login string) bool {
+	login = strings.ToLower(login)
+	if botLogins[login] {
+		return true
+	}
+	for _, suffix := range botSuffixes {
+		if strings.HasSuffix(login, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
 //nolint
-func createReturn(reviewName string, reviewed, total int) (int, string, error) {
+func createReturn(reviewName string, reviewed, total int) (int, *checker.Message, error) {
 	if total > 0 {
-		reason := fmt.Sprintf("%s code reviews found for %v commits out of the last %v", reviewName, reviewed, total)
+		reason := checker.NewMessage(checker.MsgCodeReviewReviewsFound, checker.MessageParams{
+			"review":   reviewName,
+			"reviewed": reviewed,
+			"total":    total,
+		})
 		return checker.CreateProportionalScore(reviewed, total), reason, nil
 	}
 
-	return checker.InconclusiveResultScore, fmt.Sprintf("no %v commits found", reviewName), nil
+	reason := checker.NewMessage(checker.MsgCodeReviewNoCommits, checker.MessageParams{"review": reviewName})
+	return checker.InconclusiveResultScore, reason, nil
 }
@@ -0,0 +1,177 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+	scut "github.com/ossf/scorecard/v3/utests"
+)
+
+func TestBorsApproval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		message      string
+		wantBors     bool
+		wantReviewed bool
+	}{
+		{
+			name:    "regular commit",
+			message: "Fix a bug\n\nr=alice a=bob",
+		},
+		{
+			name:         "approved by reviewer",
+			message:      "Merge #42\n\n42: Fix a bug r=alice a=bob\n\nSome details.\n\nCo-authored-by: bob <bob@example.com>",
+			wantBors:     true,
+			wantReviewed: true,
+		},
+		{
+			name:         "delegated to author and approved by another reviewer",
+			message:      "Merge #42\n\n42: Fix a bug r=bob,alice a=bob\n",
+			wantBors:     true,
+			wantReviewed: true,
+		},
+		{
+			name:     "self approved",
+			message:  "Merge #42\n\n42: Fix a bug r=bob a=bob\n",
+			wantBors: true,
+		},
+		{
+			name:     "batch with one self approved PR",
+			message:  "Merge #42 #43\n\n42: Fix a bug r=alice a=bob\n\n43: Add a feature r=carol a=carol\n",
+			wantBors: true,
+		},
+		{
+			name:         "batch approved by reviewers",
+			message:      "Merge #42 #43\n\n42: Fix a bug r=alice a=bob\n\n43: Add a feature r=alice a=carol\n",
+			wantBors:     true,
+			wantReviewed: true,
+		},
+		{
+			name:     "no approval line",
+			message:  "Merge #42\n\nFix a bug\n",
+			wantBors: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			isBors, reviewed := borsApproval(tt.message)
+			if isBors != tt.wantBors || reviewed != tt.wantReviewed {
+				t.Errorf("borsApproval() = (%t, %t), want (%t, %t)", isBors, reviewed, tt.wantBors, tt.wantReviewed)
+			}
+		})
+	}
+}
+
+func TestBorsCodeReview(t *testing.T) {
+	t.Parallel()
+	// Each bors merge brings the commits of its PRs into the history.
+	history := []clients.Commit{
+		{SHA: "m2", Message: "Merge #2\n\n2: Add a feature r=alice a=bob\n", ParentSHAs: []string{"d1", "p2"}},
+		{SHA: "p2", Message: "Add a feature", ParentSHAs: []string{"m1"}},
+		{SHA: "d1", Message: "Push directly", ParentSHAs: []string{"m1"}},
+		{SHA: "m1", Message: "Merge #1\n\n1: Fix a bug r=alice a=carol\n", ParentSHAs: []string{"root", "p1b"}},
+		{SHA: "p1b", Message: "Address review comments", ParentSHAs: []string{"p1a"}},
+		{SHA: "p1a", Message: "Fix a bug", ParentSHAs: []string{"root"}},
+		{SHA: "root", Message: "Initial commit"},
+	}
+	withoutParents := make([]clients.Commit, len(history))
+	for i := range history {
+		withoutParents[i] = history[i]
+		withoutParents[i].ParentSHAs = nil
+	}
+	tests := []struct {
+		name    string
+		commits []clients.Commit
+		want    int
+	}{
+		{
+			name:    "bors merges and direct pushes",
+			commits: history,
+			// 2 reviewed bors merges out of m2, d1, m1 and root.
+			want: checker.CreateProportionalScore(2, 4),
+		},
+		{
+			name:    "parents not reported",
+			commits: withoutParents,
+			want:    checker.CreateProportionalScore(2, 7),
+		},
+		{
+			name:    "no bors merges",
+			commits: history[1:3],
+			want:    checker.InconclusiveResultScore,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListCommits().Return(tt.commits, nil)
+			dl := scut.TestDetailLogger{}
+			req := checker.CheckRequest{RepoClient: mockRepoClient, Dlogger: &dl}
+			score, _, err := borsCodeReview(&req)
+			if err != nil {
+				t.Fatalf("borsCodeReview: %v", err)
+			}
+			if score != tt.want {
+				t.Errorf("borsCodeReview() = %d, want %d", score, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsBotAccount(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		login string
+		want  bool
+	}{
+		{login: "dependabot[bot]", want: true},
+		{login: "renovate-bot", want: true},
+		{login: "renovatebot", want: true},
+		{login: "k8s-ci-robot", want: true},
+		{login: "google-oss-robot", want: true},
+		{login: "chromium-gardener", want: true},
+		{login: "Renovate-Bot", want: true},
+		{login: "alice", want: false},
+		{login: "ci_bot", want: true},
+		{login: "abbott", want: false},
+		{login: "abbot", want: false},
+		{login: "talbot", want: false},
+		{login: "robot", want: false},
+		{login: "botond", want: false},
+		{login: "bot-reviewer", want: false},
+		{login: "", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.login, func(t *testing.T) {
+			t.Parallel()
+			if got := isBotAccount(tt.login); got != tt.want {
+				t.Errorf("isBotAccount(%q) = %t, want %t", tt.login, got, tt.want)
+			}
+		})
+	}
+}
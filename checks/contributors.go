@@ -15,10 +15,11 @@
 package checks
 
 import (
-	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
@@ -31,22 +32,24 @@ const (
 
 //nolint:gochecknoinits
 func init() {
-	registerCheck(CheckContributors, Contributors)
+	registerCheck(CheckContributors, Contributors, clients.FeatureContributors)
 }
 
 // Contributors run Contributors check.
 func Contributors(c *checker.CheckRequest) checker.CheckResult {
 	contribs, err := c.RepoClient.ListContributors()
 	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Client.Repositories.ListContributors: %v", err))
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "Client.Repositories.ListContributors")
 		return checker.CreateRuntimeErrorResult(CheckContributors, e)
 	}
 
 	companies := map[string]struct{}{}
+	topContributors := []string{}
 	for _, contrib := range contribs {
 		if contrib.NumContributions < minContributionsPerUser {
 			continue
 		}
+		topContributors = append(topContributors, contrib.User.Login)
 
 		for _, org := range contrib.Organizations {
 			if org.Login != "" {
@@ -54,14 +57,7 @@ func Contributors(c *checker.CheckRequest) checker.CheckResult {
 			}
 		}
 
-		company := contrib.Company
-		if company != "" {
-			company = strings.ToLower(company)
-			company = strings.ReplaceAll(company, "inc.", "")
-			company = strings.ReplaceAll(company, "llc", "")
-			company = strings.ReplaceAll(company, ",", "")
-			company = strings.TrimLeft(company, "@")
-			company = strings.Trim(company, " ")
+		if company := normalizeCompany(contrib.Company); company != "" {
 			companies[company] = struct{}{}
 		}
 	}
@@ -69,11 +65,27 @@ func Contributors(c *checker.CheckRequest) checker.CheckResult {
 	for c := range companies {
 		names = append(names, c)
 	}
+	sort.Strings(names)
 
 	c.Dlogger.Info3(&checker.LogMessage{
-		Text: fmt.Sprintf("contributors work for: %v", strings.Join(names, ",")),
+		Message: checker.NewMessage(checker.MsgContributorsCompanies, checker.MessageParams{
+			"companies":    strings.Join(names, ","),
+			"contributors": strings.Join(topContributors, ","),
+		}),
 	})
 
-	reason := fmt.Sprintf("%d different companies found", len(companies))
-	return checker.CreateProportionalScoreResult(CheckContributors, reason, len(companies), numberCompaniesForTopScore)
+	reason := checker.NewMessage(checker.MsgContributorsCompaniesFound, checker.MessageParams{"count": len(companies)})
+	return checker.CreateProportionalScoreResult(CheckContributors, reason.String(),
+		len(companies), numberCompaniesForTopScore).WithReasonMessage(reason)
+}
+
+// normalizeCompany removes the decorations users add to the Company field
+// of their profile, e.g. "@Google Inc.", so that the same company is only counted once.
+func normalizeCompany(company string) string {
+	company = strings.ToLower(company)
+	company = strings.ReplaceAll(company, "inc.", "")
+	company = strings.ReplaceAll(company, "llc", "")
+	company = strings.ReplaceAll(company, ",", "")
+	company = strings.TrimLeft(company, "@")
+	return strings.Trim(company, " ")
 }
@@ -0,0 +1,133 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+	sce "github.com/ossf/scorecard/v3/errors"
+	scut "github.com/ossf/scorecard/v3/utests"
+)
+
+func TestContributors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err      error
+		name     string
+		contribs []clients.Contributor
+		expected scut.TestReturn
+	}{
+		{
+			name: "no contributors",
+			expected: scut.TestReturn{
+				Score:        checker.MinResultScore,
+				NumberOfInfo: 1,
+			},
+		},
+		{
+			name: "same company spelled differently",
+			contribs: []clients.Contributor{
+				{User: clients.User{Login: "alice"}, Company: "@Google", NumContributions: 10},
+				{User: clients.User{Login: "bob"}, Company: "google Inc.", NumContributions: 10},
+			},
+			expected: scut.TestReturn{
+				Score:        checker.CreateProportionalScore(1, numberCompaniesForTopScore),
+				NumberOfInfo: 1,
+			},
+		},
+		{
+			name: "occasional contributors are not counted",
+			contribs: []clients.Contributor{
+				{User: clients.User{Login: "alice"}, Company: "Google", NumContributions: 10},
+				{User: clients.User{Login: "bob"}, Company: "Microsoft", NumContributions: 1},
+			},
+			expected: scut.TestReturn{
+				Score:        checker.CreateProportionalScore(1, numberCompaniesForTopScore),
+				NumberOfInfo: 1,
+			},
+		},
+		{
+			name: "companies and organizations",
+			contribs: []clients.Contributor{
+				{User: clients.User{Login: "alice"}, Company: "Google", NumContributions: 10},
+				{
+					User:             clients.User{Login: "bob"},
+					Organizations:    []clients.User{{Login: "ossf"}, {Login: "kubernetes"}},
+					NumContributions: 5,
+				},
+			},
+			expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 1,
+			},
+		},
+		{
+			name: "client error",
+			err:  errTest,
+			expected: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+				Error: sce.ErrScorecardInternal,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListContributors().Return(tt.contribs, tt.err)
+
+			dl := scut.TestDetailLogger{}
+			req := checker.CheckRequest{
+				RepoClient: mockRepoClient,
+				Dlogger:    &dl,
+			}
+			res := Contributors(&req)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &res, &dl) {
+				t.Fail()
+			}
+			ctrl.Finish()
+		})
+	}
+}
+
+func TestNormalizeCompany(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		company string
+		want    string
+	}{
+		{company: "", want: ""},
+		{company: "@Google", want: "google"},
+		{company: "Acme, Inc.", want: "acme"},
+		{company: " Example LLC ", want: "example"},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.company, func(t *testing.T) {
+			t.Parallel()
+			if got := normalizeCompany(tt.company); got != tt.want {
+				t.Errorf("normalizeCompany(%q) = %q, want %q", tt.company, got, tt.want)
+			}
+		})
+	}
+}
@@ -15,14 +15,15 @@
 package checks
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 
 	"github.com/rhysd/actionlint"
 
 	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks/evaluation"
 	"github.com/ossf/scorecard/v3/checks/fileparser"
+	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
@@ -59,25 +60,34 @@ func containsUntrustedContextPattern(variable string) bool {
 
 //nolint:gochecknoinits
 func init() {
-	registerCheck(CheckDangerousWorkflow, DangerousWorkflow)
+	registerCheck(CheckDangerousWorkflow, DangerousWorkflow, clients.FeatureFileContents)
 }
 
-// Holds stateful data to pass thru callbacks.
-// Each field correpsonds to a dangerous GitHub workflow pattern, and
-// will hold true if the pattern is avoided, false otherwise.
+// Holds stateful data to pass thru callbacks:
+// the dangerous patterns found in all GitHub workflows.
 type patternCbData struct {
-	workflowPattern map[string]bool
+	workflows []checker.DangerousWorkflow
 }
 
 // DangerousWorkflow runs Dangerous-Workflow check.
 func DangerousWorkflow(c *checker.CheckRequest) checker.CheckResult {
 	// data is shared across all GitHub workflows.
-	data := patternCbData{
-		workflowPattern: make(map[string]bool),
-	}
+	data := patternCbData{}
 	err := fileparser.CheckFilesContent(".github/workflows/*", false,
 		c, validateGitHubActionWorkflowPatterns, &data)
-	return createResultForDangerousWorkflowPatterns(data, err)
+	if err != nil {
+		return checker.CreateRuntimeErrorResult(CheckDangerousWorkflow, err)
+	}
+
+	rawData := checker.DangerousWorkflowData{Workflows: data.workflows}
+	// Return raw results.
+	if c.RawResults != nil {
+		c.RawResults.DangerousWorkflowResults = rawData
+		return checker.CheckResult{}
+	}
+
+	// Return the score evaluation.
+	return evaluation.DangerousWorkflow(CheckDangerousWorkflow, c.Dlogger, &rawData)
 }
 
 // Check file content.
@@ -104,12 +114,12 @@ func validateGitHubActionWorkflowPatterns(path string, content []byte, dl checke
 	}
 
 	// 1. Check for untrusted code checkout with pull_request_target and a ref
-	if err := validateUntrustedCodeCheckout(workflow, path, dl, pdata); err != nil {
+	if err := validateUntrustedCodeCheckout(workflow, path, pdata); err != nil {
 		return false, err
 	}
 
 	// 2. Check for script injection in workflow inline scripts.
-	if err := validateScriptInjection(workflow, path, dl, pdata); err != nil {
+	if err := validateScriptInjection(workflow, path, pdata); err != nil {
 		return false, err
 	}
 
@@ -117,11 +127,10 @@ func validateGitHubActionWorkflowPatterns(path string, content []byte, dl checke
 	return true, nil
 }
 
-func validateUntrustedCodeCheckout(workflow *actionlint.Workflow, path string,
-	dl checker.DetailLogger, pdata *patternCbData) error {
+func validateUntrustedCodeCheckout(workflow *actionlint.Workflow, path string, pdata *patternCbData) error {
 	if checkPullRequestTrigger(workflow) {
 		for _, job := range workflow.Jobs {
-			if err := checkJobForUntrustedCodeCheckout(job, path, dl, pdata); err != nil {
+			if err := checkJobForUntrustedCodeCheckout(job, path, pdata); err != nil {
 				return err
 			}
 		}
@@ -141,8 +150,7 @@ func checkPullRequestTrigger(workflow *actionlint.Workflow) bool {
 	return false
 }
 
-func checkJobForUntrustedCodeCheckout(job *actionlint.Job, path string,
-	dl checker.DetailLogger, pdata *patternCbData) error {
+func checkJobForUntrustedCodeCheckout(job *actionlint.Job, path string, pdata *patternCbData) error {
 	if job == nil {
 		return nil
 	}
@@ -171,22 +179,22 @@ func checkJobForUntrustedCodeCheckout(job *actionlint.Job, path string,
 			if step.Pos != nil {
 				line = step.Pos.Line
 			}
-			dl.Warn3(&checker.LogMessage{
-				Path:   path,
-				Type:   checker.FileTypeSource,
-				Offset: line,
-				Text:   fmt.Sprintf("untrusted code checkout '%v'", ref.Value.Value),
-				// TODO: set Snippet.
-			})
 			// Detected untrusted checkout.
-			pdata.workflowPattern["untrusted_checkout"] = true
+			pdata.workflows = append(pdata.workflows, checker.DangerousWorkflow{
+				Type: checker.DangerousWorkflowUntrustedCheckout,
+				File: checker.File{
+					Path:    path,
+					Type:    checker.FileTypeSource,
+					Offset:  line,
+					Snippet: ref.Value.Value,
+				},
+			})
 		}
 	}
 	return nil
 }
 
-func validateScriptInjection(workflow *actionlint.Workflow, path string,
-	dl checker.DetailLogger, pdata *patternCbData) error {
+func validateScriptInjection(workflow *actionlint.Workflow, path string, pdata *patternCbData) error {
 	for _, job := range workflow.Jobs {
 		if job == nil {
 			continue
@@ -200,7 +208,7 @@ func validateScriptInjection(workflow *actionlint.Workflow, path string,
 				continue
 			}
 			// Check Run *String for user-controllable (untrustworthy) properties.
-			if err := checkVariablesInScript(run.Run.Value, run.Run.Pos, path, dl, pdata); err != nil {
+			if err := checkVariablesInScript(run.Run.Value, run.Run.Pos, path, pdata); err != nil {
 				return err
 			}
 		}
@@ -209,8 +217,7 @@ func validateScriptInjection(workflow *actionlint.Workflow, path string,
 	return nil
 }
 
-func checkVariablesInScript(script string, pos *actionlint.Pos, path string,
-	dl checker.DetailLogger, pdata *patternCbData) error {
+func checkVariablesInScript(script string, pos *actionlint.Pos, path string, pdata *patternCbData) error {
 	for {
 		s := strings.Index(script, "${{")
 		if s == -1 {
@@ -229,64 +236,26 @@ func checkVariablesInScript(script string, pos *actionlint.Pos, path string,
 			if pos != nil {
 				line = pos.Line
 			}
-			dl.Warn3(&checker.LogMessage{
-				Path:   path,
-				Type:   checker.FileTypeSource,
-				Offset: line,
-				Text:   fmt.Sprintf("script injection with untrusted input '%v'", variable),
-				// TODO: set Snippet.
+			pdata.workflows = append(pdata.workflows, checker.DangerousWorkflow{
+				Type: checker.DangerousWorkflowScriptInjection,
+				File: checker.File{
+					Path:    path,
+					Type:    checker.FileTypeSource,
+					Offset:  line,
+					Snippet: variable,
+				},
 			})
-			pdata.workflowPattern["script_injection"] = true
 		}
 		script = script[s+e:]
 	}
 }
 
-// Calculate the workflow score.
-func calculateWorkflowScore(result patternCbData) int {
-	// Start with a perfect score.
-	score := float32(checker.MaxResultScore)
-
-	// pull_request_event indicates untrusted code checkout
-	if ok := result.workflowPattern["untrusted_checkout"]; ok {
-		score -= 10
-	}
-
-	// script injection with an untrusted context
-	if ok := result.workflowPattern["script_injection"]; ok {
-		score -= 10
-	}
-
-	// We're done, calculate the final score.
-	if score < checker.MinResultScore {
-		return checker.MinResultScore
-	}
-
-	return int(score)
-}
-
-// Create the result.
-func createResultForDangerousWorkflowPatterns(result patternCbData, err error) checker.CheckResult {
-	if err != nil {
-		return checker.CreateRuntimeErrorResult(CheckDangerousWorkflow, err)
-	}
-
-	score := calculateWorkflowScore(result)
-
-	if score != checker.MaxResultScore {
-		return checker.CreateResultWithScore(CheckDangerousWorkflow,
-			"dangerous workflow patterns detected", score)
-	}
-
-	return checker.CreateMaxScoreResult(CheckDangerousWorkflow,
-		"no dangerous workflow patterns detected")
-}
-
 func testValidateGitHubActionDangerousWorkflow(pathfn string,
 	content []byte, dl checker.DetailLogger) checker.CheckResult {
-	data := patternCbData{
-		workflowPattern: make(map[string]bool),
+	data := patternCbData{}
+	if _, err := validateGitHubActionWorkflowPatterns(pathfn, content, dl, &data); err != nil {
+		return checker.CreateRuntimeErrorResult(CheckDangerousWorkflow, err)
 	}
-	_, err := validateGitHubActionWorkflowPatterns(pathfn, content, dl, &data)
-	return createResultForDangerousWorkflowPatterns(data, err)
+	return evaluation.DangerousWorkflow(CheckDangerousWorkflow, dl,
+		&checker.DangerousWorkflowData{Workflows: data.workflows})
 }
@@ -19,7 +19,9 @@ import (
 
 	"github.com/ossf/scorecard/v3/checker"
 	"github.com/ossf/scorecard/v3/checks/fileparser"
+	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/remediation"
 )
 
 // CheckDependencyUpdateTool is the exported name for Automatic-Depdendency-Update.
@@ -27,7 +29,7 @@ const CheckDependencyUpdateTool = "Dependency-Update-Tool"
 
 //nolint
 func init() {
-	registerCheck(CheckDependencyUpdateTool, UsesDependencyUpdateTool)
+	registerCheck(CheckDependencyUpdateTool, UsesDependencyUpdateTool, clients.FeatureFileContents)
 }
 
 // UsesDependencyUpdateTool will check the repository uses a dependency update tool.
@@ -35,23 +37,25 @@ func UsesDependencyUpdateTool(c *checker.CheckRequest) checker.CheckResult {
 	var r bool
 	err := fileparser.CheckIfFileExists(c, fileExists, &r)
 	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "")
 		return checker.CreateRuntimeErrorResult(CheckDependencyUpdateTool, e)
 	}
 	if !r {
 		c.Dlogger.Warn3(&checker.LogMessage{
-			Text: `dependabot config file not detected in source location.
-			We recommend setting this configuration in code so it can be easily verified by others.`,
+			Message:     checker.NewMessage(checker.MsgDependencyUpdateToolNoDependabot, nil),
+			Remediation: remediation.Dependabot(),
 		})
 		c.Dlogger.Warn3(&checker.LogMessage{
-			Text: `renovatebot config file not detected in source location.
-			We recommend setting this configuration in code so it can be easily verified by others.`,
+			Message:     checker.NewMessage(checker.MsgDependencyUpdateToolNoRenovate, nil),
+			Remediation: remediation.Renovate(),
 		})
-		return checker.CreateMinScoreResult(CheckDependencyUpdateTool, "no update tool detected")
+		reason := checker.NewMessage(checker.MsgDependencyUpdateToolNotDetected, nil)
+		return checker.CreateMinScoreResult(CheckDependencyUpdateTool, reason.String()).WithReasonMessage(reason)
 	}
 
 	// High score result.
-	return checker.CreateMaxScoreResult(CheckDependencyUpdateTool, "update tool detected")
+	reason := checker.NewMessage(checker.MsgDependencyUpdateToolDetected, nil)
+	return checker.CreateMaxScoreResult(CheckDependencyUpdateTool, reason.String()).WithReasonMessage(reason)
 }
 
 // fileExists will validate the if frozen dependencies file name exists.
@@ -61,19 +65,19 @@ func fileExists(name string, dl checker.DetailLogger, data fileparser.FileCbData
 	switch strings.ToLower(name) {
 	case ".github/dependabot.yml":
 		dl.Info3(&checker.LogMessage{
-			Path:   name,
-			Type:   checker.FileTypeSource,
-			Offset: checker.OffsetDefault,
-			Text:   "dependabot detected",
+			Path:    name,
+			Type:    checker.FileTypeSource,
+			Offset:  checker.OffsetDefault,
+			Message: checker.NewMessage(checker.MsgDependencyUpdateToolDependabot, nil),
 		})
 		// https://docs.renovatebot.com/configuration-options/
 	case ".github/renovate.json", ".github/renovate.json5", ".renovaterc.json", "renovate.json",
 		"renovate.json5", ".renovaterc":
 		dl.Info3(&checker.LogMessage{
-			Path:   name,
-			Type:   checker.FileTypeSource,
-			Offset: checker.OffsetDefault,
-			Text:   "renovate detected",
+			Path:    name,
+			Type:    checker.FileTypeSource,
+			Offset:  checker.OffsetDefault,
+			Message: checker.NewMessage(checker.MsgDependencyUpdateToolRenovate, nil),
 		})
 	default:
 		// Continue iterating.
@@ -0,0 +1,75 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks/checktest"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+	sce "github.com/ossf/scorecard/v3/errors"
+	scut "github.com/ossf/scorecard/v3/utests"
+)
+
+func TestDependencyUpdateTool(t *testing.T) {
+	t.Parallel()
+	checktest.Run(t, UsesDependencyUpdateTool, []checktest.Case{
+		{
+			Name: "dependabot",
+			Repo: checktest.Repo{
+				Files: map[string]string{"README.md": "", ".github/dependabot.yml": ""},
+			},
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 1,
+			},
+		},
+		{
+			Name: "renovate",
+			Repo: checktest.Repo{
+				Files: map[string]string{"renovate.json": ""},
+			},
+			Expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 1,
+			},
+		},
+		{
+			Name: "no update tool",
+			Repo: checktest.Repo{
+				Files: map[string]string{"README.md": ""},
+			},
+			Expected: scut.TestReturn{
+				Score:        checker.MinResultScore,
+				NumberOfWarn: 2,
+			},
+		},
+		{
+			Name: "ListFiles error",
+			Setup: func(client *mockrepo.MockRepoClient) {
+				//nolint:goerr113
+				client.EXPECT().ListFiles(gomock.Any()).Return(nil, errors.New("error"))
+			},
+			Expected: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+				Error: sce.ErrScorecardInternal,
+			},
+		},
+	})
+}
@@ -17,6 +17,7 @@ package evaluation
 import (
 	"github.com/ossf/scorecard/v3/checker"
 	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/remediation"
 )
 
 // BinaryArtifacts applies the score policy for the Binary-Artiacts check.
@@ -29,15 +30,26 @@ func BinaryArtifacts(name string, dl checker.DetailLogger,
 
 	// Apply the policy evaluation.
 	if r.Files == nil || len(r.Files) == 0 {
-		return checker.CreateMaxScoreResult(name, "no binaries found in the repo")
+		reason := checker.NewMessage(checker.MsgBinaryArtifactsNotFound, nil)
+		return checker.CreateMaxScoreResult(name, reason.String()).WithReasonMessage(reason)
 	}
 
+	// Every binary found reduces the score by one point.
+	score := checker.MaxResultScore
 	for _, f := range r.Files {
 		dl.Warn3(&checker.LogMessage{
 			Path: f.Path, Type: checker.FileTypeBinary,
-			Text: "binary detected",
+			Message:     checker.NewMessage(checker.MsgBinaryArtifactsDetected, nil),
+			Finding:     checker.FindingBinaryArtifact,
+			Remediation: remediation.BinaryArtifact(f.Path),
 		})
+		score--
 	}
 
-	return checker.CreateMinScoreResult(name, "binaries present in source code")
+	if score < checker.MinResultScore {
+		score = checker.MinResultScore
+	}
+
+	reason := checker.NewMessage(checker.MsgBinaryArtifactsPresent, nil)
+	return checker.CreateResultWithScore(name, reason.String(), score).WithReasonMessage(reason)
 }
@@ -0,0 +1,70 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v3/checker"
+	scut "github.com/ossf/scorecard/v3/utests"
+)
+
+func TestBinaryArtifacts(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		files    []checker.File
+		expected scut.TestReturn
+	}{
+		{
+			name:  "no binaries",
+			files: nil,
+			expected: scut.TestReturn{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name: "one point per binary",
+			files: []checker.File{
+				{Path: "bin/tool", Type: checker.FileTypeBinary},
+				{Path: "lib/lib.so", Type: checker.FileTypeBinary},
+				{Path: "out/Main.class", Type: checker.FileTypeBinary},
+			},
+			expected: scut.TestReturn{
+				Score:        checker.MaxResultScore - 3,
+				NumberOfWarn: 3,
+			},
+		},
+		{
+			name:  "score does not go below minimum",
+			files: make([]checker.File, checker.MaxResultScore+2),
+			expected: scut.TestReturn{
+				Score:        checker.MinResultScore,
+				NumberOfWarn: checker.MaxResultScore + 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			res := BinaryArtifacts("Binary-Artifacts", &dl, &checker.BinaryArtifactData{Files: tt.files})
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &res, &dl) {
+				t.Fail()
+			}
+		})
+	}
+}
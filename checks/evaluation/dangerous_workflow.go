@@ -0,0 +1,64 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"fmt"
+
+	"github.com/ossf/scorecard/v3/checker"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// DangerousWorkflow applies the score policy for the Dangerous-Workflow check.
+func DangerousWorkflow(name string, dl checker.DetailLogger,
+	r *checker.DangerousWorkflowData) checker.CheckResult {
+	if r == nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, "empty raw data")
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+
+	if len(r.Workflows) == 0 {
+		reason := checker.NewMessage(checker.MsgDangerousWorkflowNotDetected, nil)
+		return checker.CreateMaxScoreResult(name, reason.String()).WithReasonMessage(reason)
+	}
+
+	for _, w := range r.Workflows {
+		var message *checker.Message
+		switch w.Type {
+		case checker.DangerousWorkflowUntrustedCheckout:
+			message = checker.NewMessage(checker.MsgDangerousWorkflowUntrustedCheckout,
+				checker.MessageParams{"ref": w.File.Snippet})
+		case checker.DangerousWorkflowScriptInjection:
+			message = checker.NewMessage(checker.MsgDangerousWorkflowScriptInjection,
+				checker.MessageParams{"input": w.File.Snippet})
+		default:
+			e := sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("unknown pattern: %s", w.Type))
+			return checker.CreateRuntimeErrorResult(name, e)
+		}
+		dl.Warn3(&checker.LogMessage{
+			Path:    w.File.Path,
+			Type:    w.File.Type,
+			Offset:  w.File.Offset,
+			Message: message,
+			Snippet: w.File.Snippet,
+			Finding: checker.FindingType(w.Type),
+		})
+	}
+
+	// Any dangerous pattern lets attackers run code with the workflow's
+	// permissions, so one is enough for the minimum score.
+	reason := checker.NewMessage(checker.MsgDangerousWorkflowDetected, nil)
+	return checker.CreateMinScoreResult(name, reason.String()).WithReasonMessage(reason)
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v3/checker"
+	sce "github.com/ossf/scorecard/v3/errors"
+	scut "github.com/ossf/scorecard/v3/utests"
+)
+
+func TestDangerousWorkflow(t *testing.T) {
+	t.Parallel()
+	checkout := checker.DangerousWorkflow{
+		Type: checker.DangerousWorkflowUntrustedCheckout,
+		File: checker.File{
+			Path: ".github/workflows/ci.yml", Type: checker.FileTypeSource, Offset: 12,
+			Snippet: "${{ github.event.pull_request.head.sha }}",
+		},
+	}
+	injection := checker.DangerousWorkflow{
+		Type: checker.DangerousWorkflowScriptInjection,
+		File: checker.File{
+			Path: ".github/workflows/ci.yml", Type: checker.FileTypeSource, Offset: 20,
+			Snippet: " github.event.issue.title ",
+		},
+	}
+	tests := []struct {
+		name      string
+		workflows []checker.DangerousWorkflow
+		expected  scut.TestReturn
+	}{
+		{
+			name: "no dangerous patterns",
+			expected: scut.TestReturn{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name:      "one pattern is enough for the minimum score",
+			workflows: []checker.DangerousWorkflow{checkout},
+			expected: scut.TestReturn{
+				Score:        checker.MinResultScore,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			name:      "each pattern is reported",
+			workflows: []checker.DangerousWorkflow{checkout, injection, injection},
+			expected: scut.TestReturn{
+				Score:        checker.MinResultScore,
+				NumberOfWarn: 3,
+			},
+		},
+		{
+			name:      "unknown pattern",
+			workflows: []checker.DangerousWorkflow{{Type: "unknown"}},
+			expected: scut.TestReturn{
+				Error: sce.ErrScorecardInternal,
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			res := DangerousWorkflow("Dangerous-Workflow", &dl,
+				&checker.DangerousWorkflowData{Workflows: tt.workflows})
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &res, &dl) {
+				t.Fail()
+			}
+		})
+	}
+}
@@ -15,8 +15,19 @@
 package evaluation
 
 import (
+	"strings"
+
 	"github.com/ossf/scorecard/v3/checker"
 	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/remediation"
+)
+
+const (
+	// A policy file which is only a stub.
+	securityPolicyStubScore = 6
+	// Added for contact information and for a disclosure timeline.
+	securityPolicyContactScore  = 2
+	securityPolicyTimelineScore = 2
 )
 
 // SecurityPolicy applies the score policy for the Security-Policy check.
@@ -28,22 +39,73 @@ func SecurityPolicy(name string, dl checker.DetailLogger, r *checker.SecurityPol
 
 	// Apply the policy evaluation.
 	if r.Files == nil || len(r.Files) == 0 {
-		return checker.CreateMinScoreResult(name, "security policy file not detected")
+		if r.Insights != nil && r.Insights.SecurityPolicy != "" {
+			dl.Warn3(&checker.LogMessage{
+				Path:   r.Insights.File.Path,
+				Type:   r.Insights.File.Type,
+				Offset: r.Insights.File.Offset,
+				Message: checker.NewMessage(checker.MsgSecurityInsightsPolicyNotDetected,
+					checker.MessageParams{"file": r.Insights.File.Path, "policy": r.Insights.SecurityPolicy}),
+			})
+		}
+		reason := checker.NewMessage(checker.MsgSecurityPolicyNotDetected, nil)
+		return checker.CreateMinScoreResult(name, reason.String()).WithReasonMessage(reason)
 	}
 
+	// Score the best policy found.
+	score := checker.MinResultScore
 	for _, f := range r.Files {
 		msg := checker.LogMessage{
-			Path:   f.Path,
-			Type:   f.Type,
-			Offset: f.Offset,
+			Path:   f.File.Path,
+			Type:   f.File.Type,
+			Offset: f.File.Offset,
 		}
 		if msg.Type == checker.FileTypeURL {
-			msg.Text = "security policy detected in org repo"
+			msg.Message = checker.NewMessage(checker.MsgSecurityPolicyDetectedInOrg, nil)
 		} else {
-			msg.Text = "security policy detected"
+			msg.Message = checker.NewMessage(checker.MsgSecurityPolicyDetected, nil)
 		}
 		dl.Info3(&msg)
+
+		fileScore := securityPolicyStubScore
+		switch {
+		case f.HasContact:
+			fileScore += securityPolicyContactScore
+		case r.Insights != nil && len(r.Insights.SecurityContacts) > 0:
+			// Contacts declared in SECURITY-INSIGHTS.yml are as good as in the policy.
+			fileScore += securityPolicyContactScore
+			dl.Info3(&checker.LogMessage{
+				Path:   r.Insights.File.Path,
+				Type:   r.Insights.File.Type,
+				Offset: r.Insights.File.Offset,
+				Message: checker.NewMessage(checker.MsgSecurityInsightsContactDeclared, checker.MessageParams{
+					"file":    r.Insights.File.Path,
+					"contact": strings.Join(r.Insights.SecurityContacts, ", "),
+				}),
+			})
+		default:
+			msg.Message = checker.NewMessage(checker.MsgSecurityPolicyNoContact, nil)
+			msg.Remediation = remediation.SecurityPolicyContent(f.File.Path,
+				"an email address or URL to report vulnerabilities")
+			dl.Warn3(&msg)
+		}
+		if f.HasTimeline {
+			fileScore += securityPolicyTimelineScore
+		} else {
+			msg.Message = checker.NewMessage(checker.MsgSecurityPolicyNoTimeline, nil)
+			msg.Remediation = remediation.SecurityPolicyContent(f.File.Path,
+				"the expected time to respond to reports and to disclose vulnerabilities")
+			dl.Warn3(&msg)
+		}
+		if fileScore > score {
+			score = fileScore
+		}
 	}
 
-	return checker.CreateMaxScoreResult(name, "security policy file detected")
+	if score == checker.MaxResultScore {
+		reason := checker.NewMessage(checker.MsgSecurityPolicyFileDetected, nil)
+		return checker.CreateMaxScoreResult(name, reason.String()).WithReasonMessage(reason)
+	}
+	reason := checker.NewMessage(checker.MsgSecurityPolicyMissingInformation, nil)
+	return checker.CreateResultWithScore(name, reason.String(), score).WithReasonMessage(reason)
 }
@@ -0,0 +1,101 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v3/checker"
+	scut "github.com/ossf/scorecard/v3/utests"
+)
+
+func TestSecurityPolicy(t *testing.T) {
+	t.Parallel()
+	file := checker.File{Path: "SECURITY.md", Type: checker.FileTypeSource}
+	insights := &checker.SecurityInsightsData{
+		File:             checker.File{Path: "SECURITY-INSIGHTS.yml", Type: checker.FileTypeSource},
+		SecurityContacts: []string{"security@example.com"},
+		SecurityPolicy:   "https://example.com/SECURITY.md",
+	}
+	tests := []struct {
+		name     string
+		files    []checker.SecurityPolicyFile
+		insights *checker.SecurityInsightsData
+		expected scut.TestReturn
+	}{
+		{
+			name:  "no policy",
+			files: nil,
+			expected: scut.TestReturn{
+				Score: checker.MinResultScore,
+			},
+		},
+		{
+			name:     "no policy but policy declared in insights",
+			insights: insights,
+			expected: scut.TestReturn{
+				Score:        checker.MinResultScore,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			name:  "stub policy",
+			files: []checker.SecurityPolicyFile{{File: file}},
+			expected: scut.TestReturn{
+				Score:        securityPolicyStubScore,
+				NumberOfInfo: 1,
+				NumberOfWarn: 2,
+			},
+		},
+		{
+			name:  "policy with contact",
+			files: []checker.SecurityPolicyFile{{File: file, HasContact: true}},
+			expected: scut.TestReturn{
+				Score:        securityPolicyStubScore + securityPolicyContactScore,
+				NumberOfInfo: 1,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			name:     "stub policy with contact declared in insights",
+			files:    []checker.SecurityPolicyFile{{File: file}},
+			insights: insights,
+			expected: scut.TestReturn{
+				Score:        securityPolicyStubScore + securityPolicyContactScore,
+				NumberOfInfo: 2,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			name:  "complete policy",
+			files: []checker.SecurityPolicyFile{{File: file, HasContact: true, HasTimeline: true}},
+			expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dl := scut.TestDetailLogger{}
+			res := SecurityPolicy("Security-Policy", &dl, &checker.SecurityPolicyData{Files: tt.files, Insights: tt.insights})
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &res, &dl) {
+				t.Fail()
+			}
+		})
+	}
+}
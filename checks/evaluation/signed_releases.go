@@ -0,0 +1,194 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"strings"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// artifactExtensions are the suffixes of signatures, including the sigstore
+// bundles of cosign, which may sign any statement.
+var artifactExtensions = []string{".asc", ".minisig", ".sig", ".sign", ".sigstore", ".sigstore.json"}
+
+// provenanceExtensions are the suffixes of SLSA provenance attestations: the
+// *.intoto.jsonl of the SLSA GitHub generator.
+var provenanceExtensions = []string{".intoto.jsonl"}
+
+// slsaProvenancePredicatePrefix prefixes the in-toto predicate types of the
+// versions of SLSA provenance, e.g. https://slsa.dev/provenance/v1.
+const slsaProvenancePredicatePrefix = "https://slsa.dev/provenance/"
+
+// signedReleaseScore is the score of a release which is signed without
+// provenance. Releases with provenance get the maximum score.
+const signedReleaseScore = 8
+
+// SignedReleases applies the score policy for the Signed-Releases check.
+func SignedReleases(name string, dl checker.DetailLogger, r *checker.SignedReleasesData) checker.CheckResult {
+	if r == nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, "empty raw data")
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+
+	totalSigned := 0
+	totalScore := 0
+	for _, release := range r.Releases {
+		dl.Debug3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgSignedReleasesReleaseFound,
+				checker.MessageParams{"release": release.TagName}),
+		})
+		asset := findAsset(release.Assets, provenanceExtensions)
+		if asset == nil {
+			asset = findAttestedAsset(release.Assets, r.Attestations, true)
+		}
+		if asset != nil {
+			dl.Info3(&checker.LogMessage{
+				Path: asset.URL,
+				Type: checker.FileTypeURL,
+				Message: checker.NewMessage(checker.MsgSignedReleasesProvenance,
+					checker.MessageParams{"asset": asset.Name}),
+			})
+			totalSigned++
+			totalScore += checker.MaxResultScore
+			continue
+		}
+		asset = findAsset(release.Assets, artifactExtensions)
+		if asset == nil {
+			asset = findAttestedAsset(release.Assets, r.Attestations, false)
+		}
+		if asset != nil {
+			dl.Info3(&checker.LogMessage{
+				Path: asset.URL,
+				Type: checker.FileTypeURL,
+				Message: checker.NewMessage(checker.MsgSignedReleasesSigned,
+					checker.MessageParams{"asset": asset.Name}),
+			})
+			dl.Warn3(&checker.LogMessage{
+				Path: release.URL,
+				Type: checker.FileTypeURL,
+				Message: checker.NewMessage(checker.MsgSignedReleasesNoProvenance,
+					checker.MessageParams{"release": release.TagName}),
+			})
+			totalSigned++
+			totalScore += signedReleaseScore
+			continue
+		}
+		dl.Warn3(&checker.LogMessage{
+			Path: release.URL,
+			Type: checker.FileTypeURL,
+			Message: checker.NewMessage(checker.MsgSignedReleasesNotSigned,
+				checker.MessageParams{"release": release.TagName}),
+		})
+	}
+
+	for _, image := range r.ContainerImages {
+		if score, signed := containerImageScore(dl, image, r.Attestations[image.Digest]); signed {
+			totalSigned++
+			totalScore += score
+		}
+	}
+
+	totalReleases := len(r.Releases) + len(r.ContainerImages)
+	if totalReleases == 0 {
+		dl.Warn3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgSignedReleasesNoGitHubReleases, nil),
+		})
+		// Generic summary.
+		reason := checker.NewMessage(checker.MsgSignedReleasesNoReleases, nil)
+		return checker.CreateInconclusiveResult(name, reason.String()).WithReasonMessage(reason)
+	}
+
+	reason := checker.NewMessage(checker.MsgSignedReleasesSummary, checker.MessageParams{
+		"signed": totalSigned,
+		"total":  totalReleases,
+	})
+	return checker.CreateResultWithScore(name, reason.String(), totalScore/totalReleases).WithReasonMessage(reason)
+}
+
+// findAsset returns the first asset whose name has one of suffixes, if any.
+func findAsset(assets []clients.ReleaseAsset, suffixes []string) *clients.ReleaseAsset {
+	for i := range assets {
+		for _, suffix := range suffixes {
+			if strings.HasSuffix(assets[i].Name, suffix) {
+				return &assets[i]
+			}
+		}
+	}
+	return nil
+}
+
+// containerImageScore returns the score of a container image, and whether it
+// is signed, from its attestations.
+func containerImageScore(dl checker.DetailLogger, image clients.ContainerImage,
+	attestations []clients.Attestation) (int, bool) {
+	dl.Debug3(&checker.LogMessage{
+		Message: checker.NewMessage(checker.MsgSignedReleasesImageFound,
+			checker.MessageParams{"image": image.Name}),
+	})
+	params := checker.MessageParams{"image": image.Name}
+	switch {
+	case hasProvenance(attestations):
+		dl.Info3(&checker.LogMessage{
+			Path:    image.URL,
+			Type:    checker.FileTypeURL,
+			Message: checker.NewMessage(checker.MsgSignedReleasesImageProvenance, params),
+		})
+		return checker.MaxResultScore, true
+	case len(attestations) > 0:
+		dl.Info3(&checker.LogMessage{
+			Path:    image.URL,
+			Type:    checker.FileTypeURL,
+			Message: checker.NewMessage(checker.MsgSignedReleasesImageSigned, params),
+		})
+		dl.Warn3(&checker.LogMessage{
+			Path:    image.URL,
+			Type:    checker.FileTypeURL,
+			Message: checker.NewMessage(checker.MsgSignedReleasesImageNoSLSA, params),
+		})
+		return signedReleaseScore, true
+	default:
+		dl.Warn3(&checker.LogMessage{
+			Path:    image.URL,
+			Type:    checker.FileTypeURL,
+			Message: checker.NewMessage(checker.MsgSignedReleasesImageNotSigned, params),
+		})
+		return 0, false
+	}
+}
+
+// findAttestedAsset returns the first asset with an attestation, of SLSA
+// provenance if provenance is set, if any.
+func findAttestedAsset(assets []clients.ReleaseAsset, attestations map[string][]clients.Attestation,
+	provenance bool) *clients.ReleaseAsset {
+	for i := range assets {
+		if a := attestations[assets[i].Digest]; len(a) > 0 && (!provenance || hasProvenance(a)) {
+			return &assets[i]
+		}
+	}
+	return nil
+}
+
+// hasProvenance returns whether one of attestations is of SLSA provenance.
+func hasProvenance(attestations []clients.Attestation) bool {
+	for _, a := range attestations {
+		if strings.HasPrefix(a.PredicateType, slsaProvenancePredicatePrefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -276,7 +276,7 @@ func IsStepWindows(step *actionlint.Step) (bool, error) {
 	for _, windowsRegex := range windowsRegexes {
 		matches, err := regexp.MatchString(windowsRegex, step.If.Value)
 		if err != nil {
-			return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("error matching Windows regex: %v", err))
+			return false, sce.Wrap(sce.ErrScorecardInternal, err, "error matching Windows regex")
 		}
 		if matches {
 			return true, nil
@@ -307,8 +307,8 @@ func IsGitHubOwnedAction(actionName string) bool {
 
 // JobMatcher is rule for matching a job.
 type JobMatcher struct {
-	// The text to be logged when a job match is found.
-	LogText string
+	// The message to be logged when a job match is found.
+	LogMessage *checker.Message
 	// Each step in this field has a matching step in the job.
 	Steps []*JobMatcherStep
 }
@@ -16,7 +16,6 @@ package fileparser
 
 import (
 	"bufio"
-	"fmt"
 	"path"
 	"strings"
 
@@ -36,13 +35,13 @@ func isMatchingPath(pattern, fullpath string, caseSensitive bool) (bool, error)
 	filename := path.Base(fullpath)
 	match, err := path.Match(pattern, fullpath)
 	if err != nil {
-		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%v: %v", errInternalFilenameMatch, err))
+		return false, sce.Wrap(sce.ErrScorecardInternal, err, errInternalFilenameMatch.Error())
 	}
 
 	// No match on the fullpath, let's try on the filename only.
 	if !match {
 		if match, err = path.Match(pattern, filename); err != nil {
-			return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%v: %v", errInternalFilenameMatch, err))
+			return false, sce.Wrap(sce.ErrScorecardInternal, err, errInternalFilenameMatch.Error())
 		}
 	}
 
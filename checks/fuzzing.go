@@ -55,7 +55,7 @@ func checkOSSFuzz(c *checker.CheckRequest) (bool, error) {
 	}
 	result, err := c.OssFuzzRepo.Search(req)
 	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Client.Search.Code: %v", err))
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "Client.Search.Code")
 		return false, e
 	}
 	return result.Hits > 0, nil
@@ -63,13 +63,21 @@ func checkOSSFuzz(c *checker.CheckRequest) (bool, error) {
 
 // Fuzzing runs Fuzzing check.
 func Fuzzing(c *checker.CheckRequest) checker.CheckResult {
+	result := fuzzing(c)
+	if result.State() == checker.ResultScored {
+		logSecurityInsightsTools(c, securityInsightsFuzzing, "fuzzing", result.Score == checker.MaxResultScore)
+	}
+	return result
+}
+
+func fuzzing(c *checker.CheckRequest) checker.CheckResult {
 	usingCFLite, e := checkCFLite(c)
 	if e != nil {
 		return checker.CreateRuntimeErrorResult(CheckFuzzing, e)
 	}
 	if usingCFLite {
-		return checker.CreateMaxScoreResult(CheckFuzzing,
-			"project uses ClusterFuzzLite")
+		reason := checker.NewMessage(checker.MsgFuzzingClusterFuzzLite, nil)
+		return checker.CreateMaxScoreResult(CheckFuzzing, reason.String()).WithReasonMessage(reason)
 	}
 
 	usingOSSFuzz, e := checkOSSFuzz(c)
@@ -77,9 +85,10 @@ func Fuzzing(c *checker.CheckRequest) checker.CheckResult {
 		return checker.CreateRuntimeErrorResult(CheckFuzzing, e)
 	}
 	if usingOSSFuzz {
-		return checker.CreateMaxScoreResult(CheckFuzzing,
-			"project is fuzzed in OSS-Fuzz")
+		reason := checker.NewMessage(checker.MsgFuzzingOSSFuzz, nil)
+		return checker.CreateMaxScoreResult(CheckFuzzing, reason.String()).WithReasonMessage(reason)
 	}
 
-	return checker.CreateMinScoreResult(CheckFuzzing, "project is not fuzzed")
+	reason := checker.NewMessage(checker.MsgFuzzingNotFuzzed, nil)
+	return checker.CreateMinScoreResult(CheckFuzzing, reason.String()).WithReasonMessage(reason)
 }
@@ -15,11 +15,15 @@
 package checks
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
 	"github.com/ossf/scorecard/v3/checker"
 	"github.com/ossf/scorecard/v3/checks/fileparser"
+	"github.com/ossf/scorecard/v3/checks/raw"
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
 )
 
 type check func(str string, extCheck []string) bool
@@ -35,7 +39,7 @@ const CheckLicense = "License"
 
 //nolint:gochecknoinits
 func init() {
-	registerCheck(CheckLicense, LicenseCheck)
+	registerCheck(CheckLicense, LicenseCheck, clients.FeatureFileContents)
 }
 
 const (
@@ -119,9 +123,91 @@ func LicenseCheck(c *checker.CheckRequest) checker.CheckResult {
 		return checker.CreateRuntimeErrorResult(CheckLicense, err)
 	}
 	if r {
-		return checker.CreateMaxScoreResult(CheckLicense, "license file detected")
+		if err := checkLicenseDeclarations(c); err != nil {
+			return checker.CreateRuntimeErrorResult(CheckLicense, err)
+		}
+		reason := checker.NewMessage(checker.MsgLicenseDetected, nil)
+		return checker.CreateMaxScoreResult(CheckLicense, reason.String()).WithReasonMessage(reason)
+	}
+	reason := checker.NewMessage(checker.MsgLicenseNotDetected, nil)
+	return checker.CreateMinScoreResult(CheckLicense, reason.String()).WithReasonMessage(reason)
+}
+
+// checkLicenseDeclarations compares the licenses declared by the package
+// manifests with the license of the repo, and warns about the mismatches,
+// which cause compliance problems downstream. They do not change the score.
+func checkLicenseDeclarations(c *checker.CheckRequest) error {
+	declarations, err := raw.DeclaredLicenses(c.RepoClient, c.Dlogger)
+	if err != nil {
+		//nolint:wrapcheck
+		return err
+	}
+	if len(declarations) == 0 {
+		return nil
+	}
+	licenseFile, license, err := repoLicense(c)
+	if err != nil {
+		return err
+	}
+	if license != "" {
+		c.Dlogger.Debug3(&checker.LogMessage{
+			Path:   licenseFile,
+			Type:   checker.FileTypeSource,
+			Offset: checker.OffsetDefault,
+			Message: checker.NewMessage(checker.MsgLicenseIdentified,
+				checker.MessageParams{"file": licenseFile, "license": license}),
+		})
+	}
+
+	for i := range declarations {
+		d := &declarations[i]
+		msg := &checker.LogMessage{Path: d.File.Path, Type: d.File.Type, Offset: d.File.Offset}
+		params := checker.MessageParams{"file": d.File.Path, "declared": d.License, "license": license}
+		if d.Missing {
+			msg.Message = checker.NewMessage(checker.MsgLicenseGoModuleMissing, params)
+			c.Dlogger.Warn3(msg)
+			continue
+		}
+		ids := raw.LicenseExpressionIDs(d.License)
+		if license == "" || len(ids) == 0 {
+			msg.Message = checker.NewMessage(checker.MsgLicenseDeclarationUnknown, params)
+			c.Dlogger.Debug3(msg)
+			continue
+		}
+		matches := false
+		for _, id := range ids {
+			matches = matches || id == license
+		}
+		if matches {
+			msg.Message = checker.NewMessage(checker.MsgLicenseDeclarationMatch, params)
+			c.Dlogger.Info3(msg)
+			continue
+		}
+		msg.Message = checker.NewMessage(checker.MsgLicenseDeclarationMismatch, params)
+		c.Dlogger.Warn3(msg)
+	}
+	return nil
+}
+
+// repoLicense returns the first license file at the root of the repo whose
+// license is recognized, and its SPDX identifier, if any.
+func repoLicense(c *checker.CheckRequest) (string, string, error) {
+	files, err := c.RepoClient.ListFiles(func(f string) (bool, error) {
+		return !strings.Contains(f, "/") && checkLicense(f), nil
+	})
+	if err != nil {
+		return "", "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListFiles: %v", err))
+	}
+	for _, f := range files {
+		content, err := c.RepoClient.GetFileContent(f)
+		if err != nil {
+			return "", "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetFileContent: %v", err))
+		}
+		if license := raw.IdentifyLicense(content); license != "" {
+			return f, license, nil
+		}
 	}
-	return checker.CreateMinScoreResult(CheckLicense, "license file not detected")
+	return "", "", nil
 }
 
 // CheckLicense to check whether the name parameter fulfill license file criteria.
@@ -23,6 +23,7 @@ import (
 	"go.uber.org/zap/zapcore"
 
 	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
 	"github.com/ossf/scorecard/v3/clients/githubrepo"
 	"github.com/ossf/scorecard/v3/clients/localdir"
 	scut "github.com/ossf/scorecard/v3/utests"
@@ -127,6 +128,18 @@ func TestLicenseFileSubdirectory(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name:        "With declarations",
+			inputFolder: "file://testdata/licensedir/withdeclarations",
+			expected: scut.TestReturn{
+				Error:         nil,
+				Score:         checker.MaxResultScore,
+				NumberOfWarn:  2,
+				NumberOfInfo:  3,
+				NumberOfDebug: 2,
+			},
+			err: nil,
+		},
 		{
 			name:        "Without LICENSE",
 			inputFolder: "file://testdata/licensedir/withoutlicense",
@@ -160,7 +173,7 @@ func TestLicenseFileSubdirectory(t *testing.T) {
 			ctx := context.Background()
 
 			client := localdir.CreateLocalDirClient(ctx, logger)
-			if err := client.InitRepo(repo); err != nil {
+			if err := client.InitRepo(repo, clients.HeadSHA); err != nil {
 				t.Errorf("InitRepo: %v", err)
 			}
 
@@ -15,10 +15,10 @@
 package checks
 
 import (
-	"fmt"
-	"time"
+	"strings"
 
 	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
@@ -32,28 +32,48 @@ const (
 
 //nolint:gochecknoinits
 func init() {
-	registerCheck(CheckMaintained, IsMaintained)
+	registerCheck(CheckMaintained, IsMaintained, clients.FeatureCommits)
 }
 
 // IsMaintained runs Maintained check.
 func IsMaintained(c *checker.CheckRequest) checker.CheckResult {
 	archived, err := c.RepoClient.IsArchived()
 	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "")
 		return checker.CreateRuntimeErrorResult(CheckMaintained, e)
 	}
 	if archived {
-		return checker.CreateMinScoreResult(CheckMaintained, "repo is marked as archived")
+		reason := checker.NewMessage(checker.MsgMaintainedArchived, nil)
+		return checker.CreateMinScoreResult(CheckMaintained, reason.String()).WithReasonMessage(reason)
+	}
+
+	// Mirrors are only as maintained as their upstream, which is scanned instead.
+	upstream := c.Upstream
+	if upstream != nil && upstream.Mirror {
+		if upstream.Archived {
+			reason := checker.NewMessage(checker.MsgMaintainedArchivedUpstream, checker.MessageParams{
+				"upstream": upstream.URI,
+			})
+			return checker.CreateMinScoreResult(CheckMaintained, reason.String()).WithReasonMessage(reason)
+		}
+		reason := checker.NewMessage(checker.MsgMaintainedMirrorOf, checker.MessageParams{
+			"upstream": upstream.URI,
+		})
+		return checker.CreateNotApplicableResult(CheckMaintained, reason.String()).WithReasonMessage(reason)
 	}
 
 	// If not explicitly marked archived, look for activity in past `lookBackDays`.
-	threshold := time.Now().AddDate(0 /*years*/, 0 /*months*/, -1*lookBackDays /*days*/)
+	threshold := clients.ScanTime(c.Ctx).AddDate(0 /*years*/, 0 /*months*/, -1*lookBackDays /*days*/)
 
 	commits, err := c.RepoClient.ListCommits()
 	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "")
 		return checker.CreateRuntimeErrorResult(CheckMaintained, e)
 	}
+	if bot := mirrorBot(commits); bot != "" {
+		reason := checker.NewMessage(checker.MsgMaintainedMirror, checker.MessageParams{"bot": bot})
+		return checker.CreateNotApplicableResult(CheckMaintained, reason.String()).WithReasonMessage(reason)
+	}
 	commitsWithinThreshold := 0
 	for _, commit := range commits {
 		if commit.CommittedDate.After(threshold) {
@@ -63,7 +83,7 @@ func IsMaintained(c *checker.CheckRequest) checker.CheckResult {
 
 	issues, err := c.RepoClient.ListIssues()
 	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "")
 		return checker.CreateRuntimeErrorResult(CheckMaintained, e)
 	}
 	issuesUpdatedWithinThreshold := 0
@@ -73,8 +93,40 @@ func IsMaintained(c *checker.CheckRequest) checker.CheckResult {
 		}
 	}
 
-	return checker.CreateProportionalScoreResult(CheckMaintained, fmt.Sprintf(
-		"%d commit(s) out of %d and %d issue activity out of %d found in the last %d days",
-		commitsWithinThreshold, len(commits), issuesUpdatedWithinThreshold, len(issues), lookBackDays),
-		commitsWithinThreshold+issuesUpdatedWithinThreshold, activityPerWeek*lookBackDays/daysInOneWeek)
+	// Forks without activity of their own which track an active upstream
+	// get their fixes from it, so they are not scored as unmaintained.
+	// Forks of archived upstreams are scored on their own activity.
+	if commitsWithinThreshold+issuesUpdatedWithinThreshold == 0 &&
+		upstream != nil && !upstream.Archived && upstream.PushedAt.After(threshold) {
+		reason := checker.NewMessage(checker.MsgMaintainedActiveUpstream, checker.MessageParams{
+			"upstream": upstream.URI,
+			"days":     lookBackDays,
+		})
+		return checker.CreateNotApplicableResult(CheckMaintained, reason.String()).WithReasonMessage(reason)
+	}
+
+	reason := checker.NewMessage(checker.MsgMaintainedActivity, checker.MessageParams{
+		"commits":      commitsWithinThreshold,
+		"totalCommits": len(commits),
+		"issues":       issuesUpdatedWithinThreshold,
+		"totalIssues":  len(issues),
+		"days":         lookBackDays,
+	})
+	return checker.CreateProportionalScoreResult(CheckMaintained, reason.String(),
+		commitsWithinThreshold+issuesUpdatedWithinThreshold,
+		activityPerWeek*lookBackDays/daysInOneWeek).WithReasonMessage(reason)
+}
+
+// mirrorBot returns the login of the mirror bot which committed all the
+// commits, if any, e.g. for repos mirrored from another forge by a bot.
+func mirrorBot(commits []clients.Commit) string {
+	bot := ""
+	for i := range commits {
+		login := commits[i].Committer.Login
+		if !strings.Contains(strings.ToLower(login), "mirror") || (bot != "" && login != bot) {
+			return ""
+		}
+		bot = login
+	}
+	return bot
 }
@@ -0,0 +1,192 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+	sce "github.com/ossf/scorecard/v3/errors"
+	scut "github.com/ossf/scorecard/v3/utests"
+)
+
+func TestIsMaintained(t *testing.T) {
+	t.Parallel()
+	recent := time.Now().AddDate(0, 0, -1)
+	old := time.Now().AddDate(0, 0, -2*lookBackDays)
+	commitsAt := func(n int, date time.Time) []clients.Commit {
+		ret := make([]clients.Commit, n)
+		for i := range ret {
+			ret[i].CommittedDate = date
+		}
+		return ret
+	}
+	issuesAt := func(n int, date time.Time) []clients.Issue {
+		ret := make([]clients.Issue, n)
+		for i := range ret {
+			d := date
+			ret[i].UpdatedAt = &d
+		}
+		return ret
+	}
+	committedBy := func(login string, commits []clients.Commit) []clients.Commit {
+		for i := range commits {
+			commits[i].Committer.Login = login
+		}
+		return commits
+	}
+	tests := []struct {
+		archivedErr error
+		upstream    *clients.Upstream
+		name        string
+		commits     []clients.Commit
+		issues      []clients.Issue
+		expected    scut.TestReturn
+		archived    bool
+	}{
+		{
+			name:     "archived",
+			archived: true,
+			expected: scut.TestReturn{
+				Score: checker.MinResultScore,
+			},
+		},
+		{
+			name:        "archived error",
+			archivedErr: errTest,
+			expected: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+				Error: sce.ErrScorecardInternal,
+			},
+		},
+		{
+			name:    "no recent activity",
+			commits: commitsAt(30, old),
+			issues:  issuesAt(30, old),
+			expected: scut.TestReturn{
+				Score: checker.MinResultScore,
+			},
+		},
+		{
+			name:    "some recent activity",
+			commits: append(commitsAt(3, recent), commitsAt(27, old)...),
+			issues:  append(issuesAt(3, recent), issuesAt(27, old)...),
+			expected: scut.TestReturn{
+				Score: 5,
+			},
+		},
+		{
+			name:    "at least one activity per week",
+			commits: commitsAt(10, recent),
+			issues:  issuesAt(3, recent),
+			expected: scut.TestReturn{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name:     "mirror",
+			upstream: &clients.Upstream{URI: "https://example.com/repo.git", Mirror: true},
+			commits:  commitsAt(30, old),
+			expected: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name:     "mirror of archived upstream",
+			upstream: &clients.Upstream{URI: "github.com/owner/repo", Mirror: true, Archived: true},
+			commits:  commitsAt(10, recent),
+			expected: scut.TestReturn{
+				Score: checker.MinResultScore,
+			},
+		},
+		{
+			name:    "commits pushed by a mirror bot",
+			commits: committedBy("gitlab-mirror-bot", commitsAt(30, old)),
+			expected: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name:    "commits pushed by a mirror bot and others",
+			commits: append(committedBy("gitlab-mirror-bot", commitsAt(27, old)), committedBy("alice", commitsAt(3, old))...),
+			expected: scut.TestReturn{
+				Score: checker.MinResultScore,
+			},
+		},
+		{
+			name:     "inactive fork of active upstream",
+			upstream: &clients.Upstream{URI: "github.com/owner/repo", PushedAt: recent},
+			commits:  commitsAt(30, old),
+			expected: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name:     "active fork of active upstream",
+			upstream: &clients.Upstream{URI: "github.com/owner/repo", PushedAt: recent},
+			commits:  commitsAt(10, recent),
+			issues:   issuesAt(3, recent),
+			expected: scut.TestReturn{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name:     "inactive fork of inactive upstream",
+			upstream: &clients.Upstream{URI: "github.com/owner/repo", PushedAt: old},
+			commits:  commitsAt(30, old),
+			expected: scut.TestReturn{
+				Score: checker.MinResultScore,
+			},
+		},
+		{
+			name:     "inactive fork of archived upstream",
+			upstream: &clients.Upstream{URI: "github.com/owner/repo", PushedAt: recent, Archived: true},
+			commits:  commitsAt(30, old),
+			expected: scut.TestReturn{
+				Score: checker.MinResultScore,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().IsArchived().Return(tt.archived, tt.archivedErr)
+			mockRepoClient.EXPECT().ListCommits().Return(tt.commits, nil).AnyTimes()
+			mockRepoClient.EXPECT().ListIssues().Return(tt.issues, nil).AnyTimes()
+
+			dl := scut.TestDetailLogger{}
+			req := checker.CheckRequest{
+				RepoClient: mockRepoClient,
+				Dlogger:    &dl,
+				Upstream:   tt.upstream,
+			}
+			res := IsMaintained(&req)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &res, &dl) {
+				t.Fail()
+			}
+			ctrl.Finish()
+		})
+	}
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/ossf/scorecard/v3/checker"
 	"github.com/ossf/scorecard/v3/checks/fileparser"
+	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
@@ -31,7 +32,7 @@ const CheckPackaging = "Packaging"
 
 //nolint:gochecknoinits
 func init() {
-	registerCheck(CheckPackaging, Packaging)
+	registerCheck(CheckPackaging, Packaging, clients.FeatureFileContents)
 }
 
 func isGithubWorkflowFile(filename string) (bool, error) {
@@ -40,16 +41,24 @@ func isGithubWorkflowFile(filename string) (bool, error) {
 
 // Packaging runs Packaging check.
 func Packaging(c *checker.CheckRequest) checker.CheckResult {
+	result := packaging(c)
+	if result.State() != checker.ResultRuntimeError {
+		logSecurityInsightsDistribution(c, result.Score == checker.MaxResultScore)
+	}
+	return result
+}
+
+func packaging(c *checker.CheckRequest) checker.CheckResult {
 	matchedFiles, err := c.RepoClient.ListFiles(isGithubWorkflowFile)
 	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("RepoClient.ListFiles: %v", err))
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "RepoClient.ListFiles")
 		return checker.CreateRuntimeErrorResult(CheckPackaging, e)
 	}
 
 	for _, fp := range matchedFiles {
 		fc, err := c.RepoClient.GetFileContent(fp)
 		if err != nil {
-			e := sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("RepoClient.GetFileContent: %v", err))
+			e := sce.Wrap(sce.ErrScorecardInternal, err, "RepoClient.GetFileContent")
 			return checker.CreateRuntimeErrorResult(CheckPackaging, e)
 		}
 
@@ -64,33 +73,64 @@ func Packaging(c *checker.CheckRequest) checker.CheckResult {
 
 		runs, err := c.RepoClient.ListSuccessfulWorkflowRuns(filepath.Base(fp))
 		if err != nil {
-			e := sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Client.Actions.ListWorkflowRunsByFileName: %v", err))
+			e := sce.Wrap(sce.ErrScorecardInternal, err, "Client.Actions.ListWorkflowRunsByFileName")
 			return checker.CreateRuntimeErrorResult(CheckPackaging, e)
 		}
 		if len(runs) > 0 {
 			c.Dlogger.Info3(&checker.LogMessage{
-				Path:   fp,
-				Type:   checker.FileTypeSource,
-				Offset: checker.OffsetDefault,
-				Text:   fmt.Sprintf("GitHub publishing workflow used in run %s", runs[0].URL),
+				Path:    fp,
+				Type:    checker.FileTypeSource,
+				Offset:  checker.OffsetDefault,
+				Message: checker.NewMessage(checker.MsgPackagingWorkflowUsed, checker.MessageParams{"run": runs[0].URL}),
 			})
-			return checker.CreateMaxScoreResult(CheckPackaging,
-				"publishing workflow detected")
+			reason := checker.NewMessage(checker.MsgPackagingWorkflowDetected, nil)
+			return checker.CreateMaxScoreResult(CheckPackaging, reason.String()).WithReasonMessage(reason)
 		}
 		c.Dlogger.Info3(&checker.LogMessage{
-			Path:   fp,
-			Type:   checker.FileTypeSource,
-			Offset: checker.OffsetDefault,
-			Text:   "GitHub publishing workflow not used in runs",
+			Path:    fp,
+			Type:    checker.FileTypeSource,
+			Offset:  checker.OffsetDefault,
+			Message: checker.NewMessage(checker.MsgPackagingWorkflowNotUsed, nil),
 		})
 	}
 
 	c.Dlogger.Warn3(&checker.LogMessage{
-		Text: "no GitHub publishing workflow detected",
+		Message: checker.NewMessage(checker.MsgPackagingNoWorkflow, nil),
 	})
 
-	return checker.CreateInconclusiveResult(CheckPackaging,
-		"no published package detected")
+	// Packages may also be published without a workflow.
+	published, err := publishedPackages(c)
+	if err != nil {
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "PackagesClient.ListPackages")
+		return checker.CreateRuntimeErrorResult(CheckPackaging, e)
+	}
+	if published {
+		reason := checker.NewMessage(checker.MsgPackagingPackageDetected, nil)
+		return checker.CreateMaxScoreResult(CheckPackaging, reason.String()).WithReasonMessage(reason)
+	}
+
+	reason := checker.NewMessage(checker.MsgPackagingNoPackage, nil)
+	return checker.CreateInconclusiveResult(CheckPackaging, reason.String()).WithReasonMessage(reason)
+}
+
+// publishedPackages returns whether language ecosystems list packages published from the repo.
+func publishedPackages(c *checker.CheckRequest) (bool, error) {
+	if c.PackagesClient == nil {
+		return false, nil
+	}
+	packages, err := c.PackagesClient.ListPackages(c.Ctx, c.Repo.URI())
+	if err != nil {
+		return false, fmt.Errorf("%w", err)
+	}
+	for _, p := range packages {
+		c.Dlogger.Info3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgPackagingPackagePublished, checker.MessageParams{
+				"package": p.Name,
+				"system":  p.System,
+			}),
+		})
+	}
+	return len(packages) > 0, nil
 }
 
 // A packaging workflow.
@@ -106,7 +146,10 @@ func isPackagingWorkflow(workflow *actionlint.Workflow, fp string, dl checker.De
 					Run: "npm.*publish",
 				},
 			},
-			LogText: "candidate node publishing workflow using npm",
+			LogMessage: checker.NewMessage(checker.MsgPackagingCandidateWorkflowUsing, checker.MessageParams{
+				"ecosystem": "node",
+				"tool":      "npm",
+			}),
 		},
 		{
 			// Java packages with maven.
@@ -118,7 +161,10 @@ func isPackagingWorkflow(workflow *actionlint.Workflow, fp string, dl checker.De
 					Run: "mvn.*deploy",
 				},
 			},
-			LogText: "candidate java publishing workflow using maven",
+			LogMessage: checker.NewMessage(checker.MsgPackagingCandidateWorkflowUsing, checker.MessageParams{
+				"ecosystem": "java",
+				"tool":      "maven",
+			}),
 		},
 		{
 			// Java packages with gradle.
@@ -130,7 +176,10 @@ func isPackagingWorkflow(workflow *actionlint.Workflow, fp string, dl checker.De
 					Run: "gradle.*publish",
 				},
 			},
-			LogText: "candidate java publishing workflow using gradle",
+			LogMessage: checker.NewMessage(checker.MsgPackagingCandidateWorkflowUsing, checker.MessageParams{
+				"ecosystem": "java",
+				"tool":      "gradle",
+			}),
 		},
 		{
 			// Ruby packages.
@@ -139,7 +188,10 @@ func isPackagingWorkflow(workflow *actionlint.Workflow, fp string, dl checker.De
 					Run: "gem.*push",
 				},
 			},
-			LogText: "candidate ruby publishing workflow using gem",
+			LogMessage: checker.NewMessage(checker.MsgPackagingCandidateWorkflowUsing, checker.MessageParams{
+				"ecosystem": "ruby",
+				"tool":      "gem",
+			}),
 		},
 		{
 			// NuGet packages.
@@ -148,7 +200,7 @@ func isPackagingWorkflow(workflow *actionlint.Workflow, fp string, dl checker.De
 					Run: "nuget.*push",
 				},
 			},
-			LogText: "candidate nuget publishing workflow",
+			LogMessage: checker.NewMessage(checker.MsgPackagingCandidateWorkflow, checker.MessageParams{"ecosystem": "nuget"}),
 		},
 		{
 			// Docker packages.
@@ -157,7 +209,7 @@ func isPackagingWorkflow(workflow *actionlint.Workflow, fp string, dl checker.De
 					Run: "docker.*push",
 				},
 			},
-			LogText: "candidate docker publishing workflow",
+			LogMessage: checker.NewMessage(checker.MsgPackagingCandidateWorkflow, checker.MessageParams{"ecosystem": "docker"}),
 		},
 		{
 			// Docker packages.
@@ -166,7 +218,7 @@ func isPackagingWorkflow(workflow *actionlint.Workflow, fp string, dl checker.De
 					Uses: "docker/build-push-action",
 				},
 			},
-			LogText: "candidate docker publishing workflow",
+			LogMessage: checker.NewMessage(checker.MsgPackagingCandidateWorkflow, checker.MessageParams{"ecosystem": "docker"}),
 		},
 		{
 			// Python packages.
@@ -178,7 +230,10 @@ func isPackagingWorkflow(workflow *actionlint.Workflow, fp string, dl checker.De
 					Uses: "pypa/gh-action-pypi-publish",
 				},
 			},
-			LogText: "candidate python publishing workflow using pypi",
+			LogMessage: checker.NewMessage(checker.MsgPackagingCandidateWorkflowUsing, checker.MessageParams{
+				"ecosystem": "python",
+				"tool":      "pypi",
+			}),
 		},
 		{
 			// Go packages.
@@ -190,7 +245,7 @@ func isPackagingWorkflow(workflow *actionlint.Workflow, fp string, dl checker.De
 					Uses: "goreleaser/goreleaser-action",
 				},
 			},
-			LogText: "candidate golang publishing workflow",
+			LogMessage: checker.NewMessage(checker.MsgPackagingCandidateWorkflow, checker.MessageParams{"ecosystem": "golang"}),
 		},
 		{
 			// Rust packages. https://doc.rust-lang.org/cargo/reference/publishing.html
@@ -199,7 +254,10 @@ func isPackagingWorkflow(workflow *actionlint.Workflow, fp string, dl checker.De
 					Run: "cargo.*publish",
 				},
 			},
-			LogText: "candidate rust publishing workflow using cargo",
+			LogMessage: checker.NewMessage(checker.MsgPackagingCandidateWorkflowUsing, checker.MessageParams{
+				"ecosystem": "rust",
+				"tool":      "cargo",
+			}),
 		},
 	}
 
@@ -210,20 +268,20 @@ func isPackagingWorkflow(workflow *actionlint.Workflow, fp string, dl checker.De
 			}
 
 			dl.Info3(&checker.LogMessage{
-				Path:   fp,
-				Type:   checker.FileTypeSource,
-				Offset: fileparser.GetLineNumber(job.Pos),
-				Text:   matcher.LogText,
+				Path:    fp,
+				Type:    checker.FileTypeSource,
+				Offset:  fileparser.GetLineNumber(job.Pos),
+				Message: matcher.LogMessage,
 			})
 			return true
 		}
 	}
 
 	dl.Debug3(&checker.LogMessage{
-		Path:   fp,
-		Type:   checker.FileTypeSource,
-		Offset: checker.OffsetDefault,
-		Text:   "not a publishing workflow",
+		Path:    fp,
+		Type:    checker.FileTypeSource,
+		Offset:  checker.OffsetDefault,
+		Message: checker.NewMessage(checker.MsgPackagingNotPackagingWorkflow, nil),
 	})
 	return false
 }
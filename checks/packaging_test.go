@@ -15,12 +15,18 @@
 package checks
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/rhysd/actionlint"
 
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+	sce "github.com/ossf/scorecard/v3/errors"
 	scut "github.com/ossf/scorecard/v3/utests"
 )
 
@@ -108,3 +114,107 @@ func TestIsPackagingWorkflow(t *testing.T) {
 		})
 	}
 }
+
+func TestPackagingPublishedPackages(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err      error
+		name     string
+		packages []clients.Package
+		// insights is the content of SECURITY-INSIGHTS.yml, if any.
+		insights string
+		expected scut.TestReturn
+	}{
+		{
+			name: "no packages",
+			expected: scut.TestReturn{
+				Score:        checker.InconclusiveResultScore,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			name:     "no packages but distribution point declared",
+			insights: "distribution-points:\n  - https://www.npmjs.com/package/left-pad\n",
+			expected: scut.TestReturn{
+				Score:        checker.InconclusiveResultScore,
+				NumberOfWarn: 2,
+			},
+		},
+		{
+			name: "packages published and distribution point declared",
+			packages: []clients.Package{
+				{System: "NPM", Name: "left-pad"},
+			},
+			insights: "distribution-points:\n  - https://www.npmjs.com/package/left-pad\n",
+			expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfWarn: 1,
+				NumberOfInfo: 2,
+			},
+		},
+		{
+			name: "packages published without a workflow",
+			packages: []clients.Package{
+				{System: "NPM", Name: "left-pad"},
+				{System: "PYPI", Name: "left-pad"},
+			},
+			expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfWarn: 1,
+				NumberOfInfo: 2,
+			},
+		},
+		{
+			name: "client error",
+			err:  errTest,
+			expected: scut.TestReturn{
+				Score:        checker.InconclusiveResultScore,
+				Error:        sce.ErrScorecardInternal,
+				NumberOfWarn: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			mockRepo := mockrepo.NewMockRepo(ctrl)
+			mockRepo.EXPECT().URI().Return("github.com/org/repo").AnyTimes()
+
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					if tt.insights == "" {
+						return nil, nil
+					}
+					if match, _ := predicate("SECURITY-INSIGHTS.yml"); match {
+						return []string{"SECURITY-INSIGHTS.yml"}, nil
+					}
+					return nil, nil
+				}).AnyTimes()
+			mockRepoClient.EXPECT().GetFileContent("SECURITY-INSIGHTS.yml").Return([]byte(tt.insights), nil).AnyTimes()
+
+			mockPackagesClient := mockrepo.NewMockPackagesClient(ctrl)
+			mockPackagesClient.EXPECT().ListPackages(gomock.Any(), "github.com/org/repo").DoAndReturn(
+				func(context.Context, string) ([]clients.Package, error) {
+					return tt.packages, tt.err
+				})
+
+			dl := scut.TestDetailLogger{}
+			req := checker.CheckRequest{
+				Repo:           mockRepo,
+				RepoClient:     mockRepoClient,
+				PackagesClient: mockPackagesClient,
+				Dlogger:        &dl,
+			}
+			res := Packaging(&req)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &res, &dl) {
+				t.Fail()
+			}
+			ctrl.Finish()
+		})
+	}
+}
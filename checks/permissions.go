@@ -22,6 +22,7 @@ import (
 
 	"github.com/ossf/scorecard/v3/checker"
 	"github.com/ossf/scorecard/v3/checks/fileparser"
+	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
@@ -34,7 +35,7 @@ const (
 
 //nolint:gochecknoinits
 func init() {
-	registerCheck(CheckTokenPermissions, TokenPermissions)
+	registerCheck(CheckTokenPermissions, TokenPermissions, clients.FeatureFileContents)
 }
 
 // Holds stateful data to pass thru callbacks.
@@ -65,36 +66,40 @@ func validatePermission(permissionKey string, permissionValue *actionlint.Permis
 	}
 	val := permissionValue.Value.Value
 	lineNumber := fileparser.GetLineNumber(permissionValue.Value.Pos)
+	msg := checker.NewMessage(checker.MsgPermissionsScopeSet, checker.MessageParams{
+		"level": permLevel, "permission": permissionKey, "value": val,
+	})
 	if strings.EqualFold(val, "write") {
 		if isPermissionOfInterest(permissionKey, ignoredPermissions) {
 			dl.Warn3(&checker.LogMessage{
-				Path:   path,
-				Type:   checker.FileTypeSource,
-				Offset: lineNumber,
-				Text:   fmt.Sprintf("%s '%v' permission set to '%v'", permLevel, permissionKey, val),
-				// TODO: set Snippet.
+				Path:    path,
+				Type:    checker.FileTypeSource,
+				Offset:  lineNumber,
+				Message: msg,
+				Snippet: fmt.Sprintf("%s: %s", permissionKey, val),
+				Finding: checker.FindingWritePermission,
 			})
 			recordPermissionWrite(permissionKey, pPermissions)
 		} else {
 			// Only log for debugging, otherwise
 			// it may confuse users.
 			dl.Debug3(&checker.LogMessage{
-				Path:   path,
-				Type:   checker.FileTypeSource,
-				Offset: lineNumber,
-				Text:   fmt.Sprintf("%s '%v' permission set to '%v'", permLevel, permissionKey, val),
-				// TODO: set Snippet.
+				Path:    path,
+				Type:    checker.FileTypeSource,
+				Offset:  lineNumber,
+				Message: msg,
+				Snippet: fmt.Sprintf("%s: %s", permissionKey, val),
 			})
 		}
 		return nil
 	}
 
 	dl.Info3(&checker.LogMessage{
-		Path:   path,
-		Type:   checker.FileTypeSource,
-		Offset: lineNumber,
-		Text:   fmt.Sprintf("%s '%v' permission set to '%v'", permLevel, permissionKey, val),
-		// TODO: set Snippet.
+		Path:    path,
+		Type:    checker.FileTypeSource,
+		Offset:  lineNumber,
+		Message: msg,
+		Snippet: fmt.Sprintf("%s: %s", permissionKey, val),
 	})
 	return nil
 }
@@ -126,34 +131,41 @@ func validatePermissions(permissions *actionlint.Permissions, permLevel, path st
 	allIsSet := permissions != nil && permissions.All != nil && permissions.All.Value != ""
 	scopeIsSet := permissions != nil && len(permissions.Scopes) > 0
 	if permissions == nil || (!allIsSet && !scopeIsSet) {
+		lineNumber := checker.OffsetDefault
+		if permissions != nil {
+			lineNumber = fileparser.GetLineNumber(permissions.Pos)
+		}
 		dl.Info3(&checker.LogMessage{
-			Path:   path,
-			Type:   checker.FileTypeSource,
-			Offset: checker.OffsetDefault,
-			Text:   fmt.Sprintf("%s permissions set to 'none'", permLevel),
+			Path:    path,
+			Type:    checker.FileTypeSource,
+			Offset:  lineNumber,
+			Message: checker.NewMessage(checker.MsgPermissionsNone, checker.MessageParams{"level": permLevel}),
 		})
 	}
 	if allIsSet {
 		val := permissions.All.Value
 		lineNumber := fileparser.GetLineNumber(permissions.All.Pos)
+		msg := checker.NewMessage(checker.MsgPermissionsSet,
+			checker.MessageParams{"level": permLevel, "value": val})
 		if !strings.EqualFold(val, "read-all") && val != "" {
 			dl.Warn3(&checker.LogMessage{
-				Path:   path,
-				Type:   checker.FileTypeSource,
-				Offset: lineNumber,
-				Text:   fmt.Sprintf("%s permissions set to '%v'", permLevel, val),
-				// TODO: set Snippet.
+				Path:    path,
+				Type:    checker.FileTypeSource,
+				Offset:  lineNumber,
+				Message: msg,
+				Snippet: fmt.Sprintf("permissions: %s", val),
+				Finding: checker.FindingWritePermission,
 			})
 			recordAllPermissionsWrite(pPermissions)
 			return nil
 		}
 
 		dl.Info3(&checker.LogMessage{
-			Path:   path,
-			Type:   checker.FileTypeSource,
-			Offset: lineNumber,
-			Text:   fmt.Sprintf("%s permissions set to '%v'", permLevel, val),
-			// TODO: set Snippet.
+			Path:    path,
+			Type:    checker.FileTypeSource,
+			Offset:  lineNumber,
+			Message: msg,
+			Snippet: fmt.Sprintf("permissions: %s", val),
 		})
 	} else /* scopeIsSet == true */ if err := validateMapPermissions(permissions.Scopes, permLevel, path, dl, pPermissions,
 		ignoredPermissions); err != nil {
@@ -167,10 +179,10 @@ func validateTopLevelPermissions(workflow *actionlint.Workflow, path string,
 	// Check if permissions are set explicitly.
 	if workflow.Permissions == nil {
 		dl.Warn3(&checker.LogMessage{
-			Path:   path,
-			Type:   checker.FileTypeSource,
-			Offset: checker.OffsetDefault,
-			Text:   fmt.Sprintf("no %s permission defined", topLevelPermission),
+			Path:    path,
+			Type:    checker.FileTypeSource,
+			Offset:  checker.OffsetDefault,
+			Message: checker.NewMessage(checker.MsgPermissionsUndefined, checker.MessageParams{"level": topLevelPermission}),
 		})
 		recordAllPermissionsWrite(pdata.topLevelWritePermissions)
 		return nil
@@ -189,10 +201,10 @@ func validateRunLevelPermissions(workflow *actionlint.Workflow, path string,
 		// so only top-level read-only permissions need to be declared.
 		if job.Permissions == nil {
 			dl.Debug3(&checker.LogMessage{
-				Path:   path,
-				Type:   checker.FileTypeSource,
-				Offset: fileparser.GetLineNumber(job.Pos),
-				Text:   fmt.Sprintf("no %s permission defined", runLevelPermission),
+				Path:    path,
+				Type:    checker.FileTypeSource,
+				Offset:  fileparser.GetLineNumber(job.Pos),
+				Message: checker.NewMessage(checker.MsgPermissionsUndefined, checker.MessageParams{"level": runLevelPermission}),
 			})
 			recordAllPermissionsWrite(pdata.runLevelWritePermissions)
 			continue
@@ -323,12 +335,12 @@ func createResultForLeastPrivilegeTokens(result permissionCbData, err error) che
 	score := calculateScore(result)
 
 	if score != checker.MaxResultScore {
-		return checker.CreateResultWithScore(CheckTokenPermissions,
-			"non read-only tokens detected in GitHub workflows", score)
+		reason := checker.NewMessage(checker.MsgPermissionsNotReadOnly, nil)
+		return checker.CreateResultWithScore(CheckTokenPermissions, reason.String(), score).WithReasonMessage(reason)
 	}
 
-	return checker.CreateMaxScoreResult(CheckTokenPermissions,
-		"tokens are read-only in GitHub workflows")
+	reason := checker.NewMessage(checker.MsgPermissionsReadOnly, nil)
+	return checker.CreateMaxScoreResult(CheckTokenPermissions, reason.String()).WithReasonMessage(reason)
 }
 
 func testValidateGitHubActionTokenPermissions(pathfn string,
@@ -436,10 +448,10 @@ func isSARIFUploadAction(workflow *actionlint.Workflow, fp string, dl checker.De
 			}
 			if strings.HasPrefix(uses.Value, "github/codeql-action/upload-sarif@") {
 				dl.Debug3(&checker.LogMessage{
-					Path:   fp,
-					Type:   checker.FileTypeSource,
-					Offset: fileparser.GetLineNumber(uses.Pos),
-					Text:   "codeql SARIF upload workflow detected",
+					Path:    fp,
+					Type:    checker.FileTypeSource,
+					Offset:  fileparser.GetLineNumber(uses.Pos),
+					Message: checker.NewMessage(checker.MsgPermissionsCodeQLUploadWorkflow, nil),
 					// TODO: set Snippet.
 				})
 				return true
@@ -447,15 +459,15 @@ func isSARIFUploadAction(workflow *actionlint.Workflow, fp string, dl checker.De
 		}
 	}
 	dl.Debug3(&checker.LogMessage{
-		Path:   fp,
-		Type:   checker.FileTypeSource,
-		Offset: checker.OffsetDefault,
-		Text:   "not a codeql upload SARIF workflow",
+		Path:    fp,
+		Type:    checker.FileTypeSource,
+		Offset:  checker.OffsetDefault,
+		Message: checker.NewMessage(checker.MsgPermissionsNotCodeQLUploadWorkflow, nil),
 	})
 	return false
 }
 
-//nolint
+// nolint
 // CodeQl run within GitHub worklow automatically bubbled up to
 // security events, see
 // https://docs.github.com/en/code-security/secure-coding/automatically-scanning-your-code-for-vulnerabilities-and-errors/configuring-code-scanning.
@@ -468,10 +480,10 @@ func isCodeQlAnalysisWorkflow(workflow *actionlint.Workflow, fp string, dl check
 			}
 			if strings.HasPrefix(uses.Value, "github/codeql-action/analyze@") {
 				dl.Debug3(&checker.LogMessage{
-					Path:   fp,
-					Type:   checker.FileTypeSource,
-					Offset: fileparser.GetLineNumber(uses.Pos),
-					Text:   "codeql workflow detected",
+					Path:    fp,
+					Type:    checker.FileTypeSource,
+					Offset:  fileparser.GetLineNumber(uses.Pos),
+					Message: checker.NewMessage(checker.MsgPermissionsCodeQLWorkflow, nil),
 					// TODO: set Snippet.
 				})
 				return true
@@ -479,10 +491,10 @@ func isCodeQlAnalysisWorkflow(workflow *actionlint.Workflow, fp string, dl check
 		}
 	}
 	dl.Debug3(&checker.LogMessage{
-		Path:   fp,
-		Type:   checker.FileTypeSource,
-		Offset: checker.OffsetDefault,
-		Text:   "not a codeql workflow",
+		Path:    fp,
+		Type:    checker.FileTypeSource,
+		Offset:  checker.OffsetDefault,
+		Message: checker.NewMessage(checker.MsgPermissionsNotCodeQLWorkflow, nil),
 	})
 	return false
 }
@@ -316,6 +316,7 @@ func TestGithubTokenPermissionsLineNumber(t *testing.T) {
 		filename string
 		expected []struct {
 			lineNumber int
+			logType    checker.DetailType
 		}
 	}{
 		{
@@ -323,9 +324,11 @@ func TestGithubTokenPermissionsLineNumber(t *testing.T) {
 			filename: "./testdata/github-workflow-permissions-run-no-codeql-write.yaml",
 			expected: []struct {
 				lineNumber int
+				logType    checker.DetailType
 			}{
 				{
 					lineNumber: 22,
+					logType:    checker.DetailWarn,
 				},
 			},
 		},
@@ -334,9 +337,28 @@ func TestGithubTokenPermissionsLineNumber(t *testing.T) {
 			filename: "./testdata/github-workflow-permissions-writeall.yaml",
 			expected: []struct {
 				lineNumber int
+				logType    checker.DetailType
 			}{
 				{
 					lineNumber: 16,
+					logType:    checker.DetailWarn,
+				},
+			},
+		},
+		{
+			name:     "Empty permissions",
+			filename: "./testdata/github-workflow-permissions-empty.yaml",
+			expected: []struct {
+				lineNumber int
+				logType    checker.DetailType
+			}{
+				{
+					lineNumber: 17,
+					logType:    checker.DetailInfo,
+				},
+				{
+					lineNumber: 22,
+					logType:    checker.DetailInfo,
 				},
 			},
 		},
@@ -354,7 +376,7 @@ func TestGithubTokenPermissionsLineNumber(t *testing.T) {
 			for _, expectedLog := range tt.expected {
 				isExpectedLog := func(logMessage checker.LogMessage, logType checker.DetailType) bool {
 					return logMessage.Offset == expectedLog.lineNumber && logMessage.Path == tt.filename &&
-						logType == checker.DetailWarn
+						logType == expectedLog.logType
 				}
 				if !scut.ValidateLogMessage(isExpectedLog, &dl) {
 					t.Errorf("test failed: log message not present: %+v", tt.expected)
@@ -24,6 +24,7 @@ import (
 
 	"github.com/ossf/scorecard/v3/checker"
 	"github.com/ossf/scorecard/v3/checks/fileparser"
+	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
@@ -39,7 +40,7 @@ type worklowPinningResult struct {
 
 //nolint:gochecknoinits
 func init() {
-	registerCheck(CheckPinnedDependencies, PinnedDependencies)
+	registerCheck(CheckPinnedDependencies, PinnedDependencies, clients.FeatureFileContents)
 }
 
 // PinnedDependencies will check the repository if it contains frozen dependecies.
@@ -95,10 +96,12 @@ func PinnedDependencies(c *checker.CheckRequest) checker.CheckResult {
 		dockerDownloadScore, scriptScore, actionScriptScore)
 
 	if score == checker.MaxResultScore {
-		return checker.CreateMaxScoreResult(CheckPinnedDependencies, "all dependencies are pinned")
+		reason := checker.NewMessage(checker.MsgPinnedAllPinned, nil)
+		return checker.CreateMaxScoreResult(CheckPinnedDependencies, reason.String()).WithReasonMessage(reason)
 	}
+	reason := checker.NewMessage(checker.MsgPinnedUnpinnedDetected, nil)
 	return checker.CreateProportionalScoreResult(CheckPinnedDependencies,
-		"dependency not pinned by hash detected", score, checker.MaxResultScore)
+		reason.String(), score, checker.MaxResultScore).WithReasonMessage(reason)
 }
 
 // TODO(laurent): need to support GCB pinning.
@@ -144,7 +147,7 @@ func dataAsWorkflowResultPointer(data fileparser.FileCbData) *worklowPinningResu
 	return pdata
 }
 
-func createReturnValuesForGitHubActionsWorkflowPinned(r worklowPinningResult, infoMsg string,
+func createReturnValuesForGitHubActionsWorkflowPinned(r worklowPinningResult, infoMsg checker.MessageID,
 	dl checker.DetailLogger, err error) (int, error) {
 	if err != nil {
 		return checker.InconclusiveResultScore, err
@@ -155,18 +158,18 @@ func createReturnValuesForGitHubActionsWorkflowPinned(r worklowPinningResult, in
 	if r.gitHubOwned != notPinned {
 		score += 2
 		dl.Info3(&checker.LogMessage{
-			Type:   checker.FileTypeSource,
-			Offset: checker.OffsetDefault,
-			Text:   fmt.Sprintf("%s %s", "GitHub-owned", infoMsg),
+			Type:    checker.FileTypeSource,
+			Offset:  checker.OffsetDefault,
+			Message: checker.NewMessage(infoMsg, checker.MessageParams{"owner": "GitHub-owned"}),
 		})
 	}
 
 	if r.thirdParties != notPinned {
 		score += 8
 		dl.Info3(&checker.LogMessage{
-			Type:   checker.FileTypeSource,
-			Offset: checker.OffsetDefault,
-			Text:   fmt.Sprintf("%s %s", "Third-party", infoMsg),
+			Type:    checker.FileTypeSource,
+			Offset:  checker.OffsetDefault,
+			Message: checker.NewMessage(infoMsg, checker.MessageParams{"owner": "Third-party"}),
 		})
 	}
 
@@ -182,7 +185,7 @@ func dataAsResultPointer(data fileparser.FileCbData) *pinnedResult {
 	return pdata
 }
 
-func createReturnValues(r pinnedResult, infoMsg string, dl checker.DetailLogger, err error) (int, error) {
+func createReturnValues(r pinnedResult, infoMsg checker.MessageID, dl checker.DetailLogger, err error) (int, error) {
 	if err != nil {
 		return checker.InconclusiveResultScore, err
 	}
@@ -191,7 +194,9 @@ func createReturnValues(r pinnedResult, infoMsg string, dl checker.DetailLogger,
 	default:
 		panic("invalid value")
 	case pinned, pinnedUndefined:
-		dl.Info(infoMsg)
+		dl.Info3(&checker.LogMessage{
+			Message: checker.NewMessage(infoMsg, nil),
+		})
 		return checker.MaxResultScore, nil
 	case notPinned:
 		// No logging needed as it's done by the checks.
@@ -209,7 +214,7 @@ func isShellScriptFreeOfInsecureDownloads(c *checker.CheckRequest) (int, error)
 func createReturnForIsShellScriptFreeOfInsecureDownloads(r pinnedResult,
 	dl checker.DetailLogger, err error) (int, error) {
 	return createReturnValues(r,
-		"no insecure (not pinned by hash) dependency downloads found in shell scripts",
+		checker.MsgPinnedShellDownloadsPinned,
 		dl, err)
 }
 
@@ -250,7 +255,7 @@ func isDockerfileFreeOfInsecureDownloads(c *checker.CheckRequest) (int, error) {
 func createReturnForIsDockerfileFreeOfInsecureDownloads(r pinnedResult,
 	dl checker.DetailLogger, err error) (int, error) {
 	return createReturnValues(r,
-		"no insecure (not pinned by hash) dependency downloads found in Dockerfiles",
+		checker.MsgPinnedDockerfileDownloadsPinned,
 		dl, err)
 }
 
@@ -279,10 +284,11 @@ func validateDockerfileIsFreeOfInsecureDownloads(pathfn string, content []byte,
 	contentReader := strings.NewReader(string(content))
 	res, err := parser.Parse(contentReader)
 	if err != nil {
-		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%v: %v", errInternalInvalidDockerFile, err))
+		return false, sce.Wrap(sce.ErrScorecardInternal, err, errInternalInvalidDockerFile.Error())
 	}
 
 	var bytes []byte
+	script := shellScript{path: pathfn, lines: []scriptLines{}}
 
 	// Walk the Dockerfile's AST.
 	for _, child := range res.AST.Children {
@@ -305,9 +311,12 @@ func validateDockerfileIsFreeOfInsecureDownloads(pathfn string, content []byte,
 		cmd := strings.Join(valueList, " ")
 		bytes = append(bytes, cmd...)
 		bytes = append(bytes, '\n')
+		for i := 0; i <= strings.Count(cmd, "\n"); i++ {
+			script.lines = append(script.lines, scriptLines{start: child.StartLine, end: child.EndLine})
+		}
 	}
 
-	r, err := validateShellFile(pathfn, bytes, dl)
+	r, err := validateShellScript(&script, bytes, dl)
 	if err != nil {
 		return false, err
 	}
@@ -326,7 +335,7 @@ func isDockerfilePinned(c *checker.CheckRequest) (int, error) {
 // Create the result.
 func createReturnForIsDockerfilePinned(r pinnedResult, dl checker.DetailLogger, err error) (int, error) {
 	return createReturnValues(r,
-		"Dockerfile dependencies are pinned",
+		checker.MsgPinnedDockerfilePinned,
 		dl, err)
 }
 
@@ -367,7 +376,7 @@ func validateDockerfileIsPinned(pathfn string, content []byte,
 	pinnedAsNames := make(map[string]bool)
 	res, err := parser.Parse(contentReader)
 	if err != nil {
-		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%v: %v", errInternalInvalidDockerFile, err))
+		return false, sce.Wrap(sce.ErrScorecardInternal, err, errInternalInvalidDockerFile.Error())
 	}
 
 	for _, child := range res.AST.Children {
@@ -403,11 +412,13 @@ func validateDockerfileIsPinned(pathfn string, content []byte,
 			// Not pinned.
 			ret = false
 			dl.Warn3(&checker.LogMessage{
-				Path:    pathfn,
-				Type:    checker.FileTypeSource,
-				Offset:  child.StartLine,
-				Text:    fmt.Sprintf("dependency not pinned by hash: '%v'", name),
-				Snippet: child.Original,
+				Path:      pathfn,
+				Type:      checker.FileTypeSource,
+				Offset:    child.StartLine,
+				EndOffset: child.EndLine,
+				Message:   checker.NewMessage(checker.MsgPinnedDockerfileUnpinned, checker.MessageParams{"name": name}),
+				Snippet:   child.Original,
+				Finding:   checker.FindingUnpinnedDependency,
 			})
 
 		// FROM name.
@@ -416,11 +427,13 @@ func validateDockerfileIsPinned(pathfn string, content []byte,
 			if !regex.Match([]byte(name)) {
 				ret = false
 				dl.Warn3(&checker.LogMessage{
-					Path:    pathfn,
-					Type:    checker.FileTypeSource,
-					Offset:  child.StartLine,
-					Text:    fmt.Sprintf("dependency not pinned by hash: '%v'", name),
-					Snippet: child.Original,
+					Path:      pathfn,
+					Type:      checker.FileTypeSource,
+					Offset:    child.StartLine,
+					EndOffset: child.EndLine,
+					Message:   checker.NewMessage(checker.MsgPinnedDockerfileUnpinned, checker.MessageParams{"name": name}),
+					Snippet:   child.Original,
+					Finding:   checker.FindingUnpinnedDependency,
 				})
 			}
 
@@ -449,7 +462,7 @@ func isGitHubWorkflowScriptFreeOfInsecureDownloads(c *checker.CheckRequest) (int
 func createReturnForIsGitHubWorkflowScriptFreeOfInsecureDownloads(r pinnedResult,
 	dl checker.DetailLogger, err error) (int, error) {
 	return createReturnValues(r,
-		"no insecure (not pinned by hash) dependency downloads found in GitHub workflows",
+		checker.MsgPinnedWorkflowDownloadsPinned,
 		dl, err)
 }
 
@@ -486,6 +499,8 @@ func validateGitHubWorkflowIsFreeOfInsecureDownloads(pathfn string, content []by
 	githubVarRegex := regexp.MustCompile(`{{[^{}]*}}`)
 	validated := true
 	scriptContent := ""
+	// The script content starts with an empty line.
+	script := shellScript{path: pathfn, lines: []scriptLines{{start: checker.OffsetDefault, end: checker.OffsetDefault}}}
 	for jobName, job := range workflow.Jobs {
 		jobName := jobName
 		job := job
@@ -522,14 +537,22 @@ func validateGitHubWorkflowIsFreeOfInsecureDownloads(pathfn string, content []by
 			}
 
 			// We replace the `${{ github.variable }}` to avoid shell parsing failures.
-			script := githubVarRegex.ReplaceAll([]byte(run), []byte("GITHUB_REDACTED_VAR"))
-			scriptContent = fmt.Sprintf("%v\n%v", scriptContent, string(script))
+			runScript := githubVarRegex.ReplaceAll([]byte(run), []byte("GITHUB_REDACTED_VAR"))
+			scriptContent = fmt.Sprintf("%v\n%v", scriptContent, string(runScript))
+			// Block scalars start on the line after the `run` key.
+			start := execRun.Run.Pos.Line
+			if strings.Contains(run, "\n") {
+				start++
+			}
+			for i := 0; i <= strings.Count(string(runScript), "\n"); i++ {
+				script.lines = append(script.lines, scriptLines{start: start + i, end: start + i})
+			}
 		}
 	}
 
 	if scriptContent != "" {
 		var err error
-		validated, err = validateShellFile(pathfn, []byte(scriptContent), dl)
+		validated, err = validateShellScript(&script, []byte(scriptContent), dl)
 		if err != nil {
 			return false, err
 		}
@@ -551,7 +574,7 @@ func isGitHubActionsWorkflowPinned(c *checker.CheckRequest) (int, error) {
 func createReturnForIsGitHubActionsWorkflowPinned(r worklowPinningResult, dl checker.DetailLogger,
 	err error) (int, error) {
 	return createReturnValuesForGitHubActionsWorkflowPinned(r,
-		"actions are pinned",
+		checker.MsgPinnedActionsPinned,
 		dl, err)
 }
 
@@ -631,7 +654,9 @@ func validateGitHubActionWorkflow(pathfn string, content []byte,
 			if !match {
 				dl.Warn3(&checker.LogMessage{
 					Path: pathfn, Type: checker.FileTypeSource, Offset: execAction.Uses.Pos.Line, Snippet: execAction.Uses.Value,
-					Text: fmt.Sprintf("%s dependency not pinned by hash (job '%v')", owner, jobName),
+					Message: checker.NewMessage(checker.MsgPinnedActionUnpinned,
+						checker.MessageParams{"owner": owner, "job": jobName}),
+					Finding: checker.FindingUnpinnedDependency,
 				})
 			}
 
@@ -671,30 +696,37 @@ func isPackageManagerLockFilePresent(c *checker.CheckRequest) (int, error) {
 // TODO(laurent): handle multi-language repos.
 //nolint:unused
 func validatePackageManagerFile(name string, dl checker.DetailLogger, data fileparser.FileCbData) (bool, error) {
+	var msg string
 	switch strings.ToLower(name) {
 	// TODO(laurent): "go.mod" is for libraries
 	default:
 		return true, nil
 	case "go.sum":
-		dl.Info("go lock file detected: %s", name)
+		msg = "go lock file detected"
 	case "vendor/", "third_party/", "third-party/":
-		dl.Info("vendoring detected in: %s", name)
+		msg = "vendoring detected"
 	case "package-lock.json", "npm-shrinkwrap.json":
-		dl.Info("javascript lock file detected: %s", name)
+		msg = "javascript lock file detected"
 	// TODO(laurent): add check for hashbased pinning in requirements.txt - https://davidwalsh.name/hashin
 	// Note: because requirements.txt does not handle transitive dependencies, we consider it
 	// not a lock file, until we have remediation steps for pip-build.
 	case "pipfile.lock":
-		dl.Info("python lock file detected: %s", name)
+		msg = "python lock file detected"
 	case "gemfile.lock":
-		dl.Info("ruby lock file detected: %s", name)
+		msg = "ruby lock file detected"
 	case "cargo.lock":
-		dl.Info("rust lock file detected: %s", name)
+		msg = "rust lock file detected"
 	case "yarn.lock":
-		dl.Info("yarn lock file detected: %s", name)
+		msg = "yarn lock file detected"
 	case "composer.lock":
-		dl.Info("composer lock file detected: %s", name)
-	}
+		msg = "composer lock file detected"
+	}
+	dl.Info3(&checker.LogMessage{
+		Path:   name,
+		Type:   checker.FileTypeSource,
+		Offset: checker.OffsetDefault,
+		Text:   msg,
+	})
 
 	pdata := dataAsResultPointer(data)
 	addPinnedResult(pdata, true)
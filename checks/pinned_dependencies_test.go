@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks/fileparser"
 	scut "github.com/ossf/scorecard/v3/utests"
 )
 
@@ -1049,3 +1050,97 @@ func TestGitHubWorkflowUsesLineNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestInsecureDownloadLineNumber(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		filename string
+		validate func(string, []byte, checker.DetailLogger, fileparser.FileCbData) (bool, error)
+		expected []struct {
+			snippet   string
+			startLine int
+			endLine   int
+		}
+	}{
+		{
+			name:     "shell script",
+			filename: "./testdata/script-sh",
+			validate: validateShellScriptIsFreeOfInsecureDownloads,
+			expected: []struct {
+				snippet   string
+				startLine int
+				endLine   int
+			}{
+				{snippet: "curl -s blabla | bash", startLine: 34, endLine: 34},
+				{snippet: "./myfile", startLine: 38, endLine: 38},
+				{snippet: "curl bla | sh", startLine: 40, endLine: 40},
+			},
+		},
+		{
+			name:     "dockerfile",
+			filename: "./testdata/Dockerfile-curl-file-sh",
+			validate: validateDockerfileIsFreeOfInsecureDownloads,
+			expected: []struct {
+				snippet   string
+				startLine int
+				endLine   int
+			}{
+				{snippet: "/tmp/exe1", startLine: 18, endLine: 18},
+				{snippet: "/tmp/exe3", startLine: 21, endLine: 22},
+				{snippet: "bash /tmp/file3", startLine: 27, endLine: 28},
+				{snippet: "/tmp/exe11", startLine: 30, endLine: 30},
+			},
+		},
+		{
+			name:     "workflow inline run",
+			filename: "./testdata/github-workflow-curl-default.yaml",
+			validate: validateGitHubWorkflowIsFreeOfInsecureDownloads,
+			expected: []struct {
+				snippet   string
+				startLine int
+				endLine   int
+			}{
+				{snippet: "curl -s bla | bash", startLine: 50, endLine: 50},
+			},
+		},
+		{
+			name:     "workflow multi-line run",
+			filename: "./testdata/github-workflow-curl-multiline.yaml",
+			validate: validateGitHubWorkflowIsFreeOfInsecureDownloads,
+			expected: []struct {
+				snippet   string
+				startLine int
+				endLine   int
+			}{
+				{snippet: "curl -s https://example.com/install.sh | bash", startLine: 26, endLine: 26},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			content, err := os.ReadFile(tt.filename)
+			if err != nil {
+				t.Errorf("cannot read file: %v", err)
+			}
+			dl := scut.TestDetailLogger{}
+			var pinned pinnedResult
+			_, err = tt.validate(tt.filename, content, &dl, &pinned)
+			if err != nil {
+				t.Errorf("error during validation: %v", err)
+			}
+			for _, expectedLog := range tt.expected {
+				isExpectedLog := func(logMessage checker.LogMessage, logType checker.DetailType) bool {
+					return logMessage.Offset == expectedLog.startLine && logMessage.EndOffset == expectedLog.endLine &&
+						logMessage.Path == tt.filename && logMessage.Snippet == expectedLog.snippet &&
+						logMessage.Finding == checker.FindingInsecureDownload && logType == checker.DetailWarn
+				}
+				if !scut.ValidateLogMessage(isExpectedLog, &dl) {
+					t.Errorf("test failed: log message not present: %+v", expectedLog)
+				}
+			}
+		})
+	}
+}
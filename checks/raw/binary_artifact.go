@@ -54,6 +54,7 @@ func checkBinaryFileContent(path string, content []byte,
 		"dex":    true,
 		"dey":    true,
 		"elf":    true,
+		"macho":  true,
 		"o":      true,
 		"so":     true,
 		"iso":    true,
@@ -80,7 +81,7 @@ func checkBinaryFileContent(path string, content []byte,
 		return true, nil
 	}
 	if t, err = filetype.Get(content); err != nil {
-		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("filetype.Get:%v", err))
+		return false, sce.Wrap(sce.ErrScorecardInternal, err, "filetype.Get")
 	}
 
 	exists1 := binaryFileTypes[t.Extension]
@@ -0,0 +1,402 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+var (
+	errInternalCommitishNil   = errors.New("commitish is nil")
+	errInternalBranchNotFound = errors.New("branch not found")
+
+	commitRegex = regexp.MustCompile("^[a-f0-9]{40}$")
+	semverRegex = regexp.MustCompile(`^v?\d+\.\d+\.\d+`)
+	numberRegex = regexp.MustCompile(`\d+`)
+)
+
+// Minimum number of merged PRs needed to infer protection settings.
+const minInferencePullRequests = 5
+
+type branchMap map[string]*clients.BranchRef
+
+// getBranchByName returns a branch by name. Branches renamed since, e.g., from
+// master to main, are looked up by their current name.
+func (b branchMap) getBranchByName(c clients.RepoClient, name string) (*clients.BranchRef, error) {
+	val, exists := b[name]
+	if exists {
+		return val, nil
+	}
+
+	if name != "" {
+		if branch, err := c.GetBranch(name); err == nil && branch.Name != nil {
+			if val, exists := b[*branch.Name]; exists {
+				return val, nil
+			}
+			return branch, nil
+		}
+	}
+	return nil, sce.WithMessage(sce.ErrScorecardInternal,
+		fmt.Sprintf("could not find branch name %s: %v", name, errInternalBranchNotFound))
+}
+
+func getBranchMapFrom(branches []*clients.BranchRef) branchMap {
+	ret := make(branchMap)
+	for _, branch := range branches {
+		branchName := getBranchName(branch)
+		if branchName != "" {
+			ret[branchName] = branch
+		}
+	}
+	return ret
+}
+
+func getBranchName(branch *clients.BranchRef) string {
+	if branch == nil || branch.Name == nil {
+		return ""
+	}
+	return *branch.Name
+}
+
+// BranchProtection retrieves the protection settings of the default branch
+// and of the branches targeted by releases.
+func BranchProtection(c clients.RepoClient) (checker.BranchProtectionsData, error) {
+	// Get all branches. This will include information on whether they are protected.
+	branches, err := c.ListBranches()
+	if errors.Is(err, clients.ErrUnsupportedFeature) {
+		return branchHistory(c)
+	}
+	if err != nil {
+		return checker.BranchProtectionsData{}, sce.Wrap(sce.ErrScorecardInternal, err, "")
+	}
+	branchesMap := getBranchMapFrom(branches)
+
+	// Get release branches.
+	releases, err := c.ListReleases()
+	if err != nil {
+		return checker.BranchProtectionsData{}, sce.Wrap(sce.ErrScorecardInternal, err, "")
+	}
+
+	// Get default branch, against which release commits are resolved.
+	defaultBranch, err := c.GetDefaultBranch()
+	if err != nil {
+		//nolint:wrapcheck
+		return checker.BranchProtectionsData{}, err
+	}
+	defaultBranchName := getBranchName(defaultBranch)
+
+	checkBranches := make(map[string]bool)
+	for i := range releases {
+		release := &releases[i]
+		if release.TargetCommitish == "" {
+			// Log with a named error if target_commitish is nil.
+			return checker.BranchProtectionsData{},
+				sce.WithMessage(sce.ErrScorecardInternal, errInternalCommitishNil.Error())
+		}
+
+		if commitRegex.Match([]byte(release.TargetCommitish)) {
+			name, err := releaseBranchForCommit(c, branchesMap, defaultBranchName, release)
+			if err != nil {
+				return checker.BranchProtectionsData{}, err
+			}
+			if name != "" {
+				checkBranches[name] = true
+			}
+			continue
+		}
+
+		// Try to resolve the branch name.
+		b, err := branchesMap.getBranchByName(c, release.TargetCommitish)
+		if err != nil {
+			// If the commitish branch is still not found, fail.
+			return checker.BranchProtectionsData{}, err
+		}
+
+		// Branch is valid, add to list of branches to check.
+		checkBranches[*b.Name] = true
+	}
+	if len(releases) == 0 {
+		if err := addTagBranches(c, branchesMap, checkBranches); err != nil {
+			return checker.BranchProtectionsData{}, err
+		}
+	}
+
+	// Add default branch.
+	if defaultBranchName != "" {
+		checkBranches[defaultBranchName] = true
+	}
+
+	// Sort the branches, so results do not depend on map iteration order.
+	names := make([]string, 0, len(checkBranches))
+	for b := range checkBranches {
+		names = append(names, b)
+	}
+	sort.Strings(names)
+
+	ret := checker.BranchProtectionsData{}
+	for _, b := range names {
+		branch, err := branchesMap.getBranchByName(c, b)
+		if err != nil {
+			if errors.Is(err, errInternalBranchNotFound) {
+				continue
+			}
+			return checker.BranchProtectionsData{}, err
+		}
+		ret.Branches = append(ret.Branches, *branch)
+	}
+
+	if branch, err := branchesMap.getBranchByName(c, defaultBranchName); err == nil && needsInference(branch) {
+		ret.Inferred = inferBranchProtection(c, defaultBranchName)
+	}
+	ret.MergeBot = prowTide(c)
+	return ret, nil
+}
+
+// releaseBranchForCommit resolves the branch of a release targeting a commit.
+// It returns an empty name if the branch is unknown.
+func releaseBranchForCommit(c clients.RepoClient, branchesMap branchMap, defaultBranch string,
+	release *clients.Release) (string, error) {
+	names, err := c.ListBranchesForCommit(release.TargetCommitish)
+	if errors.Is(err, clients.ErrUnsupportedFeature) {
+		return "", nil
+	}
+	if err != nil {
+		return "", sce.Wrap(sce.ErrScorecardInternal, err, "")
+	}
+	var branches []string
+	for _, name := range names {
+		// The branch may have been deleted since the release.
+		if b, err := branchesMap.getBranchByName(c, name); err == nil {
+			branches = append(branches, *b.Name)
+		}
+	}
+	return mostSpecificBranch(branches, defaultBranch, release.TagName), nil
+}
+
+// mostSpecificBranch returns the branch of a release among the branches its
+// commit was merged onto, e.g., release-1.2 for v1.2.3 rather than release-1.x
+// or the default branch, where release branches are typically merged back.
+func mostSpecificBranch(branches []string, defaultBranch, tag string) string {
+	version := numberRegex.FindAllString(tag, -1)
+	best, bestMatch := "", -1
+	for _, name := range branches {
+		match := 0
+		// Release branches are preferred over the default branch.
+		if name != defaultBranch {
+			match = 1 + commonPrefixLen(numberRegex.FindAllString(name, -1), version)
+		}
+		if match > bestMatch || (match == bestMatch && name < best) {
+			best, bestMatch = name, match
+		}
+	}
+	return best
+}
+
+func commonPrefixLen(a, b []string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// addTagBranches adds the branches of the semver tags to checkBranches,
+// for projects which push tags without creating releases.
+func addTagBranches(c clients.RepoClient, branchesMap branchMap, checkBranches map[string]bool) error {
+	tags, err := c.ListTags()
+	if errors.Is(err, clients.ErrUnsupportedFeature) {
+		return nil
+	}
+	if err != nil {
+		return sce.Wrap(sce.ErrScorecardInternal, err, "")
+	}
+	for _, tag := range tags {
+		if !semverRegex.MatchString(tag.Name) {
+			continue
+		}
+		for _, name := range tag.Branches {
+			// The branch may have been deleted since the tag was pushed.
+			if b, err := branchesMap.getBranchByName(c, name); err == nil {
+				checkBranches[*b.Name] = true
+			}
+		}
+	}
+	return nil
+}
+
+// branchHistory looks for evidence of the protection of the default branch in
+// its history, for repos whose protection settings cannot be read.
+func branchHistory(c clients.RepoClient) (checker.BranchProtectionsData, error) {
+	defaultBranch, err := c.GetDefaultBranch()
+	if err != nil {
+		return checker.BranchProtectionsData{}, sce.Wrap(sce.ErrScorecardInternal, err, "")
+	}
+	commits, err := c.ListCommits()
+	if err != nil {
+		return checker.BranchProtectionsData{}, sce.Wrap(sce.ErrScorecardInternal, err, "")
+	}
+	updates, err := c.ListBranchUpdates(getBranchName(defaultBranch))
+	if err != nil && !errors.Is(err, clients.ErrUnsupportedFeature) {
+		return checker.BranchProtectionsData{}, sce.Wrap(sce.ErrScorecardInternal, err, "")
+	}
+
+	history := checker.BranchHistory{
+		Branch:        getBranchName(defaultBranch),
+		Commits:       len(commits),
+		BranchUpdates: len(updates),
+	}
+	for i := range commits {
+		if commits[i].AssociatedMergeRequest == nil {
+			history.DirectPushes++
+		}
+	}
+	for i := range updates {
+		if updates[i].Forced {
+			history.ForcePushes++
+		}
+	}
+	return checker.BranchProtectionsData{History: &history}, nil
+}
+
+// needsInference returns whether settings of a protected branch could not be read.
+func needsInference(branch *clients.BranchRef) bool {
+	if branch.Protected != nil && !*branch.Protected {
+		return false
+	}
+	rule := &branch.BranchProtectionRule
+	return rule.AllowForcePushes == nil || rule.RequiredPullRequestReviews.RequiredApprovingReviewCount == nil
+}
+
+// inferBranchProtection infers settings from the recent history of the branch.
+// It returns nil if there is not enough history to do so.
+func inferBranchProtection(c clients.RepoClient, branch string) *checker.BranchProtectionInference {
+	prs, err := c.ListMergedPRs()
+	if err != nil {
+		return nil
+	}
+	commits, err := c.ListCommits()
+	if err != nil || len(commits) == 0 {
+		return nil
+	}
+
+	shas := make(map[string]bool, len(commits))
+	oldest := commits[0].CommittedDate
+	for _, commit := range commits {
+		shas[commit.SHA] = true
+		if commit.CommittedDate.Before(oldest) {
+			oldest = commit.CommittedDate
+		}
+	}
+
+	ret := checker.BranchProtectionInference{
+		Branch:             branch,
+		ForcePushesBlocked: true,
+		ReviewsRequired:    true,
+	}
+	for i := range prs {
+		pr := &prs[i]
+		// Only PRs merged within the commit window can be matched against it.
+		if pr.MergedAt.IsZero() || pr.MergedAt.Before(oldest) || pr.MergeCommit.SHA == "" {
+			continue
+		}
+		ret.PullRequests++
+		if !shas[pr.MergeCommit.SHA] {
+			ret.ForcePushesBlocked = false
+		}
+		if !isApproved(pr) {
+			ret.ReviewsRequired = false
+		}
+	}
+	if ret.PullRequests < minInferencePullRequests {
+		return nil
+	}
+	return &ret
+}
+
+func isApproved(pr *clients.PullRequest) bool {
+	for _, r := range pr.Reviews {
+		if r.State == "APPROVED" {
+			return true
+		}
+	}
+	return false
+}
+
+// Locations of the Prow configuration in repos hosting their own Prow instance.
+var prowConfigFiles = []string{"prow/config.yaml", "config/prow/config.yaml", ".prow/config.yaml"}
+
+// prowTide detects whether PRs are merged by Prow's Tide, which only merges PRs
+// labeled lgtm and approved by the reviewers and approvers listed in OWNERS files.
+// It returns nil if Tide was not detected.
+func prowTide(c clients.RepoClient) *checker.MergeBot {
+	// Errors, e.g., files not being available, only mean Tide is not detected.
+	if _, err := c.GetFileContent("OWNERS"); err != nil {
+		return nil
+	}
+	ret := checker.MergeBot{
+		Name:     "Prow/Tide",
+		Evidence: []string{"OWNERS"},
+	}
+	for _, f := range prowConfigFiles {
+		content, err := c.GetFileContent(f)
+		if err == nil && strings.Contains(string(content), "tide:") {
+			ret.Evidence = append(ret.Evidence, f)
+			return &ret
+		}
+	}
+
+	// Most repos are merged by a Prow instance configured elsewhere,
+	// so look for its labels on recent PRs.
+	prs, err := c.ListMergedPRs()
+	if err != nil {
+		return nil
+	}
+	labeled := 0
+	for i := range prs {
+		if prs[i].MergedAt.IsZero() {
+			continue
+		}
+		if !hasLabels(&prs[i], "lgtm", "approved") {
+			return nil
+		}
+		labeled++
+	}
+	if labeled < minInferencePullRequests {
+		return nil
+	}
+	ret.Evidence = append(ret.Evidence, fmt.Sprintf("lgtm and approved labels on %d merged PRs", labeled))
+	return &ret
+}
+
+func hasLabels(pr *clients.PullRequest, names ...string) bool {
+	found := make(map[string]bool, len(pr.Labels))
+	for _, l := range pr.Labels {
+		found[l.Name] = true
+	}
+	for _, name := range names {
+		if !found[name] {
+			return false
+		}
+	}
+	return true
+}
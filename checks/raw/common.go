@@ -14,7 +14,11 @@
 
 package raw
 
-import "github.com/ossf/scorecard/v3/checker"
+import (
+	"strings"
+
+	"github.com/ossf/scorecard/v3/checker"
+)
 
 // File represents a file.
 type File struct {
@@ -23,3 +27,14 @@ type File struct {
 	Offset int
 	// TODO: add hash if needed.
 }
+
+// isExcludedPath reports whether f is under a testdata, vendor or
+// node_modules directory, whose files are not the repo's own.
+func isExcludedPath(f string) bool {
+	for _, dir := range []string{"testdata", "vendor", "node_modules"} {
+		if strings.Contains("/"+f, "/"+dir+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,363 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// licenseTitles identify the license of a text by the first title it
+// contains, followed by its version if any. Titles are matched in order
+// of appearance, as the GPL mentions the LGPL, and vice versa.
+var licenseTitles = []struct {
+	title    string
+	versions map[string]string
+}{
+	{title: "gnu affero general public license", versions: map[string]string{"version 3": "AGPL-3.0"}},
+	{title: "gnu lesser general public license", versions: map[string]string{
+		"version 3": "LGPL-3.0", "version 2.1": "LGPL-2.1",
+	}},
+	{title: "gnu library general public license", versions: map[string]string{"version 2": "LGPL-2.0"}},
+	{title: "gnu general public license", versions: map[string]string{
+		"version 3": "GPL-3.0", "version 2": "GPL-2.0",
+	}},
+	{title: "mozilla public license", versions: map[string]string{"version 2.0": "MPL-2.0"}},
+	{title: "apache license", versions: map[string]string{"version 2.0": "Apache-2.0"}},
+	{title: "eclipse public license", versions: map[string]string{"v 2.0": "EPL-2.0", "v 1.0": "EPL-1.0"}},
+	{title: "boost software license", versions: map[string]string{"version 1.0": "BSL-1.0"}},
+	{title: "cc0 1.0 universal", versions: map[string]string{"": "CC0-1.0"}},
+}
+
+// licenseTexts identify the licenses without a title by their distinctive text.
+var licenseTexts = []struct {
+	text    string
+	license string
+}{
+	{text: "permission is hereby granted, free of charge, to any person obtaining a copy", license: "MIT"},
+	{text: "this is free and unencumbered software released into the public domain", license: "Unlicense"},
+	{text: "permission to use, copy, modify, and/or distribute this software for any purpose", license: "ISC"},
+	{text: "neither the name of", license: "BSD-3-Clause"},
+	{text: "redistribution and use in source and binary forms", license: "BSD-2-Clause"},
+}
+
+// licenseAliases are the common names of licenses which are not SPDX
+// identifiers, e.g. in the license field of setup.cfg.
+var licenseAliases = map[string]string{
+	"mit license":                 "MIT",
+	"the mit license":             "MIT",
+	"apache 2.0":                  "Apache-2.0",
+	"apache-2":                    "Apache-2.0",
+	"apache 2":                    "Apache-2.0",
+	"apache license 2.0":          "Apache-2.0",
+	"apache license, version 2.0": "Apache-2.0",
+	"apache software license":     "Apache-2.0",
+	"isc license":                 "ISC",
+	"mpl 2.0":                     "MPL-2.0",
+	"gplv2":                       "GPL-2.0",
+	"gplv3":                       "GPL-3.0",
+	"lgplv3":                      "LGPL-3.0",
+	"agplv3":                      "AGPL-3.0",
+}
+
+var (
+	whitespace            = regexp.MustCompile(`\s+`)
+	spdxLicenseIdentifier = regexp.MustCompile(`(?i)SPDX-License-Identifier:\s*([^\s*]+)`)
+	errInvalidManifest    = errors.New("invalid manifest")
+)
+
+// IdentifyLicense returns the SPDX identifier of the license of a license
+// file, or an empty string if it is not recognized.
+func IdentifyLicense(content []byte) string {
+	if m := spdxLicenseIdentifier.FindSubmatch(content); m != nil {
+		return NormalizeLicense(string(m[1]))
+	}
+	text := whitespace.ReplaceAllString(strings.ToLower(string(content)), " ")
+
+	first, license := -1, ""
+	for _, t := range licenseTitles {
+		i := strings.Index(text, t.title)
+		if i < 0 || (first >= 0 && i > first) {
+			continue
+		}
+		// The version follows the title, e.g. "apache license version 2.0, january 2004".
+		rest := strings.TrimLeft(text[i+len(t.title):], " ,-")
+		for version, id := range t.versions {
+			if strings.HasPrefix(rest, version) {
+				first, license = i, id
+			}
+		}
+	}
+	if license != "" {
+		return license
+	}
+	for _, t := range licenseTexts {
+		if strings.Contains(text, t.text) {
+			return t.license
+		}
+	}
+	return ""
+}
+
+// NormalizeLicense returns the SPDX identifier of license, without the
+// -only and -or-later suffixes which license files cannot tell apart, or an
+// empty string if it is not an identifier known by IdentifyLicense.
+func NormalizeLicense(license string) string {
+	l := strings.TrimSpace(license)
+	if id, ok := licenseAliases[strings.ToLower(l)]; ok {
+		return id
+	}
+	l = strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(l, "+"), "-only"), "-or-later")
+	for _, t := range licenseTitles {
+		for _, id := range t.versions {
+			if strings.EqualFold(l, id) {
+				return id
+			}
+		}
+	}
+	for _, t := range licenseTexts {
+		if strings.EqualFold(l, t.license) {
+			return t.license
+		}
+	}
+	return ""
+}
+
+// LicenseExpressionIDs returns the known licenses of an SPDX license
+// expression, e.g. "MIT OR Apache-2.0", or of a common license name.
+func LicenseExpressionIDs(expression string) []string {
+	if id := NormalizeLicense(expression); id != "" {
+		return []string{id}
+	}
+	var ids []string
+	// Cargo.toml used to separate licenses with /.
+	fields := strings.Fields(strings.NewReplacer("(", " ", ")", " ", "/", " ").Replace(expression))
+	for i := 0; i < len(fields); i++ {
+		switch strings.ToUpper(fields[i]) {
+		case "OR", "AND":
+			continue
+		case "WITH":
+			// Skip the exception, e.g. Classpath-exception-2.0.
+			i++
+			continue
+		}
+		if id := NormalizeLicense(fields[i]); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+type manifestParser func(path string, content []byte) (*checker.DeclaredLicense, error)
+
+// manifestParsers are the parsers of the package manifests declaring
+// licenses, by file name.
+var manifestParsers = map[string]manifestParser{
+	"package.json": parsePackageJSONLicense,
+	"setup.cfg":    parseSetupCfgLicense,
+	"Cargo.toml":   parseCargoTomlLicense,
+}
+
+// DeclaredLicenses returns the licenses declared by the package manifests of
+// the repo, and those of the Go modules in its subdirectories, whose module
+// zips only include the license files of their own directory. Manifests
+// which do not declare a license are ignored, and those which cannot be
+// parsed are logged and skipped.
+func DeclaredLicenses(c clients.RepoClient, dl checker.DetailLogger) ([]checker.DeclaredLicense, error) {
+	files, err := c.ListFiles(func(f string) (bool, error) {
+		base := path.Base(f)
+		_, ok := manifestParsers[base]
+		return (ok || base == "go.mod" || isLicenseFile(base)) && !isExcludedPath(f), nil
+	})
+	if errors.Is(err, clients.ErrUnsupportedFeature) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListFiles: %v", err))
+	}
+	licenseFiles := map[string][]string{}
+	for _, f := range files {
+		if isLicenseFile(path.Base(f)) {
+			licenseFiles[path.Dir(f)] = append(licenseFiles[path.Dir(f)], f)
+		}
+	}
+
+	var ret []checker.DeclaredLicense
+	for _, f := range files {
+		base := path.Base(f)
+		if base == "go.mod" {
+			if path.Dir(f) == "." {
+				continue
+			}
+			declared, err := goModuleLicense(c, f, licenseFiles[path.Dir(f)])
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, *declared)
+			continue
+		}
+		parse, ok := manifestParsers[base]
+		if !ok {
+			continue
+		}
+		content, err := c.GetFileContent(f)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetFileContent: %v", err))
+		}
+		declared, err := parse(f, content)
+		if err != nil {
+			dl.Debug3(&checker.LogMessage{
+				Path:   f,
+				Type:   checker.FileTypeSource,
+				Offset: checker.OffsetDefault,
+				Message: checker.NewMessage(checker.MsgLicenseManifestInvalid,
+					checker.MessageParams{"file": f, "error": err}),
+			})
+			continue
+		}
+		if declared != nil {
+			ret = append(ret, *declared)
+		}
+	}
+	return ret, nil
+}
+
+// isLicenseFile reports whether name is the name of a license file, as
+// recognized by the Go module proxies.
+func isLicenseFile(name string) bool {
+	n := strings.ToUpper(name)
+	for _, prefix := range []string{"LICENSE", "LICENCE", "COPYING"} {
+		if strings.HasPrefix(n, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// goModuleLicense returns the license of the first of licenseFiles which is
+// recognized, or Missing if the module at goMod has no license file.
+func goModuleLicense(c clients.RepoClient, goMod string, licenseFiles []string) (*checker.DeclaredLicense, error) {
+	declared := checker.DeclaredLicense{
+		File:    checker.File{Path: goMod, Type: checker.FileTypeSource, Offset: checker.OffsetDefault},
+		Missing: len(licenseFiles) == 0,
+	}
+	for _, f := range licenseFiles {
+		content, err := c.GetFileContent(f)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetFileContent: %v", err))
+		}
+		if declared.License = IdentifyLicense(content); declared.License != "" {
+			break
+		}
+	}
+	return &declared, nil
+}
+
+type packageJSONLicense struct {
+	Type string `json:"type"`
+}
+
+func (l *packageJSONLicense) UnmarshalJSON(data []byte) error {
+	// The license is a string, or an object in deprecated package.json files.
+	if err := json.Unmarshal(data, &l.Type); err == nil {
+		return nil
+	}
+	type license packageJSONLicense
+	//nolint:wrapcheck
+	return json.Unmarshal(data, (*license)(l))
+}
+
+func parsePackageJSONLicense(path string, content []byte) (*checker.DeclaredLicense, error) {
+	var pkg struct {
+		License  *packageJSONLicense  `json:"license"`
+		Licenses []packageJSONLicense `json:"licenses"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidManifest, err)
+	}
+	var licenses []string
+	if pkg.License != nil && pkg.License.Type != "" {
+		licenses = append(licenses, pkg.License.Type)
+	}
+	for _, l := range pkg.Licenses {
+		if l.Type != "" {
+			licenses = append(licenses, l.Type)
+		}
+	}
+	if len(licenses) == 0 {
+		return nil, nil
+	}
+	return &checker.DeclaredLicense{
+		File:    checker.File{Path: path, Type: checker.FileTypeSource, Offset: checker.OffsetDefault},
+		License: strings.Join(licenses, " OR "),
+	}, nil
+}
+
+// parseSetupCfgLicense returns the license of the [metadata] section of a setup.cfg.
+func parseSetupCfgLicense(path string, content []byte) (*checker.DeclaredLicense, error) {
+	return parseSectionLicense(path, content, "[metadata]", false)
+}
+
+// parseCargoTomlLicense returns the license of the [package] section of a Cargo.toml.
+func parseCargoTomlLicense(path string, content []byte) (*checker.DeclaredLicense, error) {
+	return parseSectionLicense(path, content, "[package]", true)
+}
+
+// parseSectionLicense returns the value of the license key of the section of
+// an INI or TOML file, which is a quoted string in TOML files.
+func parseSectionLicense(path string, content []byte, section string, quoted bool) (*checker.DeclaredLicense, error) {
+	inSection := false
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(text, "[") {
+			inSection = text == section
+			continue
+		}
+		kv := strings.SplitN(text, "=", 2)
+		if !inSection || len(kv) != 2 || strings.TrimSpace(kv[0]) != "license" {
+			continue
+		}
+		value := strings.TrimSpace(kv[1])
+		if quoted {
+			var err error
+			if value, err = strconv.Unquote(value); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", errInvalidManifest, line, err)
+			}
+		}
+		if value == "" {
+			return nil, nil
+		}
+		return &checker.DeclaredLicense{
+			File:    checker.File{Path: path, Type: checker.FileTypeSource, Offset: line},
+			License: value,
+		}, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanner.Err: %w", err)
+	}
+	return nil, nil
+}
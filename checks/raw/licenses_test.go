@@ -0,0 +1,190 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/checker"
+)
+
+func TestIdentifyLicense(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "Apache-2.0",
+			content: "\n                                 Apache License\n                           Version 2.0, January 2004\n",
+			want:    "Apache-2.0",
+		},
+		{
+			name: "MIT",
+			content: `Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal`,
+			want: "MIT",
+		},
+		{
+			name: "GPL-3.0",
+			content: `GNU GENERAL PUBLIC LICENSE
+Version 3, 29 June 2007
+[...] use the GNU Lesser General Public License instead of this License.`,
+			want: "GPL-3.0",
+		},
+		{
+			name: "LGPL-2.1",
+			content: `GNU LESSER GENERAL PUBLIC LICENSE
+Version 2.1, February 1999
+[...] the GNU General Public License.`,
+			want: "LGPL-2.1",
+		},
+		{
+			name: "BSD-3-Clause",
+			content: `Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+[...] Neither the name of the copyright holder nor the names of its contributors`,
+			want: "BSD-3-Clause",
+		},
+		{
+			name:    "BSD-2-Clause",
+			content: "Redistribution and use in source and binary forms, with or without modification",
+			want:    "BSD-2-Clause",
+		},
+		{
+			name:    "SPDX identifier",
+			content: "// SPDX-License-Identifier: GPL-2.0-or-later\n",
+			want:    "GPL-2.0",
+		},
+		{
+			name:    "unknown",
+			content: "All rights reserved.",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IdentifyLicense([]byte(tt.content)); got != tt.want {
+				t.Errorf("IdentifyLicense() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLicenseExpressionIDs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		expression string
+		want       []string
+	}{
+		{expression: "MIT", want: []string{"MIT"}},
+		{expression: "mit", want: []string{"MIT"}},
+		{expression: "MIT License", want: []string{"MIT"}},
+		{expression: "MIT OR Apache-2.0", want: []string{"MIT", "Apache-2.0"}},
+		{expression: "MIT/Apache-2.0", want: []string{"MIT", "Apache-2.0"}},
+		{expression: "(GPL-3.0-only WITH Classpath-exception-2.0 AND ISC)", want: []string{"GPL-3.0", "ISC"}},
+		{expression: "Proprietary"},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.expression, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.want, LicenseExpressionIDs(tt.expression)); diff != "" {
+				t.Errorf("LicenseExpressionIDs mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseManifestLicenses(t *testing.T) {
+	t.Parallel()
+	declared := func(path, license string, line int) *checker.DeclaredLicense {
+		return &checker.DeclaredLicense{
+			File:    checker.File{Path: path, Type: checker.FileTypeSource, Offset: line},
+			License: license,
+		}
+	}
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    *checker.DeclaredLicense
+		wantErr bool
+	}{
+		{
+			name:    "package.json",
+			path:    "package.json",
+			content: `{"name": "app", "license": "ISC"}`,
+			want:    declared("package.json", "ISC", checker.OffsetDefault),
+		},
+		{
+			name:    "deprecated package.json",
+			path:    "package.json",
+			content: `{"license": {"type": "MIT"}, "licenses": [{"type": "Apache-2.0"}]}`,
+			want:    declared("package.json", "MIT OR Apache-2.0", checker.OffsetDefault),
+		},
+		{
+			name:    "package.json without license",
+			path:    "package.json",
+			content: `{"name": "app"}`,
+		},
+		{
+			name:    "invalid package.json",
+			path:    "package.json",
+			content: `{"license": 1}`,
+			wantErr: true,
+		},
+		{
+			name:    "setup.cfg",
+			path:    "setup.cfg",
+			content: "[options]\nlicense = none\n\n[metadata]\nname = app\nlicense = MIT License\n",
+			want:    declared("setup.cfg", "MIT License", 6),
+		},
+		{
+			name:    "Cargo.toml",
+			path:    "Cargo.toml",
+			content: "[package]\nname = \"app\"\nlicense = \"MIT/Apache-2.0\"\n",
+			want:    declared("Cargo.toml", "MIT/Apache-2.0", 3),
+		},
+		{
+			name:    "Cargo.toml of a workspace",
+			path:    "Cargo.toml",
+			content: "[workspace]\nmembers = [\"app\"]\n",
+		},
+		{
+			name:    "invalid Cargo.toml",
+			path:    "Cargo.toml",
+			content: "[package]\nlicense = MIT\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := manifestParsers[tt.path](tt.path, []byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parse error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parse mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,358 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+type lockfileParser func(path string, content []byte) ([]checker.LockedDependency, error)
+
+// lockfileParsers are the parsers of the supported lockfiles, by file name.
+var lockfileParsers = map[string]lockfileParser{
+	"go.sum":            parseGoSum,
+	"package-lock.json": parsePackageLock,
+	"requirements.txt":  parseRequirements,
+	"Cargo.lock":        parseCargoLock,
+}
+
+var errInvalidLockfile = errors.New("invalid lockfile")
+
+// LockedDependencies returns the dependencies pinned by the lockfiles of the
+// repo, except those under testdata, vendor or node_modules directories.
+// Lockfiles which cannot be parsed are logged and skipped.
+func LockedDependencies(c clients.RepoClient, dl checker.DetailLogger) ([]checker.LockedDependency, error) {
+	files, err := c.ListFiles(func(f string) (bool, error) {
+		_, ok := lockfileParsers[path.Base(f)]
+		return ok && !isExcludedPath(f), nil
+	})
+	if errors.Is(err, clients.ErrUnsupportedFeature) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListFiles: %v", err))
+	}
+
+	var ret []checker.LockedDependency
+	for _, f := range files {
+		content, err := c.GetFileContent(f)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetFileContent: %v", err))
+		}
+		deps, err := lockfileParsers[path.Base(f)](f, content)
+		if err != nil {
+			dl.Debug3(&checker.LogMessage{
+				Path:   f,
+				Type:   checker.FileTypeSource,
+				Offset: checker.OffsetDefault,
+				Message: checker.NewMessage(checker.MsgVulnerabilitiesLockfileInvalid,
+					checker.MessageParams{"file": f, "error": err}),
+			})
+			continue
+		}
+		ret = append(ret, deps...)
+	}
+	return ret, nil
+}
+
+func lockedDependency(ecosystem, name, version, path string, line int) checker.LockedDependency {
+	return checker.LockedDependency{
+		Dependency: clients.Dependency{Ecosystem: ecosystem, Name: name, Version: version},
+		File: checker.File{
+			Path:   path,
+			Type:   checker.FileTypeSource,
+			Offset: line,
+		},
+	}
+}
+
+// parseGoSum returns the modules of a go.sum whose content is hashed, as
+// opposed to those whose go.mod only is, which are not part of the build.
+// A go.sum may hash several versions of a module, of which the build uses
+// the highest one, see https://go.dev/ref/mod#minimal-version-selection.
+func parseGoSum(path string, content []byte) ([]checker.LockedDependency, error) {
+	var ret []checker.LockedDependency
+	// modules maps the modules to their index in ret.
+	modules := map[string]int{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%w: line %d", errInvalidLockfile, line)
+		}
+		if strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		// OSV versions of Go modules have no "v" prefix.
+		dep := lockedDependency(clients.EcosystemGo, fields[0], strings.TrimPrefix(fields[1], "v"), path, line)
+		i, ok := modules[dep.Name]
+		switch {
+		case !ok:
+			modules[dep.Name] = len(ret)
+			ret = append(ret, dep)
+		case compareGoVersions(dep.Version, ret[i].Version) > 0:
+			ret[i] = dep
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanner.Err: %w", err)
+	}
+	return ret, nil
+}
+
+// compareGoVersions compares two semantic versions of Go modules without
+// their "v" prefix, e.g. 1.2.3-pre+incompatible, like golang.org/x/mod/semver:
+// the result is negative if a < b, zero if a == b and positive if a > b.
+func compareGoVersions(a, b string) int {
+	// Build metadata, e.g. +incompatible, does not order versions.
+	if i := strings.Index(a, "+"); i >= 0 {
+		a = a[:i]
+	}
+	if i := strings.Index(b, "+"); i >= 0 {
+		b = b[:i]
+	}
+	a, aPre := splitPrerelease(a)
+	b, bPre := splitPrerelease(b)
+	if c := compareIdentifiers(strings.Split(a, "."), strings.Split(b, "."), true); c != 0 {
+		return c
+	}
+	// A version without prerelease is higher than its prereleases.
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	return compareIdentifiers(strings.Split(aPre, "."), strings.Split(bPre, "."), false)
+}
+
+// splitPrerelease splits a version without build metadata into its core,
+// e.g. 1.2.3, and its prerelease, e.g. 0.20210817164053-32db794688a5.
+func splitPrerelease(v string) (string, string) {
+	if i := strings.Index(v, "-"); i >= 0 {
+		return v[:i], v[i+1:]
+	}
+	return v, ""
+}
+
+// compareIdentifiers compares dot-separated version identifiers: numeric
+// ones numerically and lower than alphanumeric ones, which compare in ASCII
+// order. Missing identifiers are zeros in the core of a version, while a
+// prerelease with fewer identifiers is lower.
+func compareIdentifiers(a, b []string, core bool) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y string
+		switch {
+		case i < len(a) && i < len(b):
+			x, y = a[i], b[i]
+		case core && i < len(a):
+			x, y = a[i], "0"
+		case core:
+			x, y = "0", b[i]
+		case i < len(a):
+			return 1
+		default:
+			return -1
+		}
+		xn, xErr := strconv.ParseUint(x, 10, 64)
+		yn, yErr := strconv.ParseUint(y, 10, 64)
+		switch {
+		case xErr == nil && yErr == nil:
+			if xn != yn {
+				if xn < yn {
+					return -1
+				}
+				return 1
+			}
+		case xErr == nil:
+			return -1
+		case yErr == nil:
+			return 1
+		case x != y:
+			return strings.Compare(x, y)
+		}
+	}
+	return 0
+}
+
+type packageLockDependency struct {
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+	Version      string                           `json:"version"`
+}
+
+type packageLock struct {
+	// Packages is set by lockfileVersion 2 and 3, keyed by install path.
+	Packages map[string]struct {
+		Version string `json:"version"`
+		Link    bool   `json:"link"`
+	} `json:"packages"`
+	// Dependencies is set by lockfileVersion 1 and 2, keyed by name.
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+// parsePackageLock returns the packages installed by a package-lock.json,
+// except those which are links to local packages or not installed from the
+// registry, e.g. from a git repo.
+func parsePackageLock(path string, content []byte) ([]checker.LockedDependency, error) {
+	var lock packageLock
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidLockfile, err)
+	}
+	versions := map[clients.Dependency]bool{}
+	add := func(name, version string) {
+		// Versions of packages not installed from the registry are URLs.
+		if name == "" || version == "" || strings.Contains(version, ":") {
+			return
+		}
+		versions[clients.Dependency{Ecosystem: clients.EcosystemNPM, Name: name, Version: version}] = true
+	}
+	if len(lock.Packages) > 0 {
+		for installPath, p := range lock.Packages {
+			i := strings.LastIndex(installPath, "node_modules/")
+			if i < 0 || p.Link {
+				continue
+			}
+			add(installPath[i+len("node_modules/"):], p.Version)
+		}
+	} else {
+		var walk func(deps map[string]packageLockDependency)
+		walk = func(deps map[string]packageLockDependency) {
+			for name, d := range deps {
+				add(name, d.Version)
+				walk(d.Dependencies)
+			}
+		}
+		walk(lock.Dependencies)
+	}
+
+	ret := make([]checker.LockedDependency, 0, len(versions))
+	for d := range versions {
+		ret = append(ret, lockedDependency(d.Ecosystem, d.Name, d.Version, path, checker.OffsetDefault))
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Name != ret[j].Name {
+			return ret[i].Name < ret[j].Name
+		}
+		return ret[i].Version < ret[j].Version
+	})
+	return ret, nil
+}
+
+// parseRequirements returns the packages pinned with == by a requirements.txt.
+// Other requirements do not pin a version and are ignored.
+func parseRequirements(path string, content []byte) ([]checker.LockedDependency, error) {
+	var ret []checker.LockedDependency
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		req := scanner.Text()
+		if i := strings.Index(req, "#"); i >= 0 {
+			req = req[:i]
+		}
+		// Drop the environment markers, e.g. ; python_version < "3.8".
+		if i := strings.Index(req, ";"); i >= 0 {
+			req = req[:i]
+		}
+		req = strings.TrimSpace(req)
+		if req == "" || strings.HasPrefix(req, "-") {
+			continue
+		}
+		i := strings.Index(req, "==")
+		if i < 0 || strings.HasPrefix(req[i:], "===") {
+			continue
+		}
+		name := strings.TrimSpace(req[:i])
+		// Drop the extras, e.g. requests[security].
+		if j := strings.Index(name, "["); j >= 0 {
+			name = strings.TrimSpace(name[:j])
+		}
+		// The version can be followed by options, e.g. --hash.
+		fields := strings.Fields(req[i+len("=="):])
+		if name == "" || len(fields) == 0 {
+			return nil, fmt.Errorf("%w: line %d", errInvalidLockfile, line)
+		}
+		ret = append(ret, lockedDependency(clients.EcosystemPyPI, name, fields[0], path, line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanner.Err: %w", err)
+	}
+	return ret, nil
+}
+
+// parseCargoLock returns the crates of a Cargo.lock which come from a
+// registry, as opposed to those of the workspace or from git repos.
+func parseCargoLock(path string, content []byte) ([]checker.LockedDependency, error) {
+	var ret []checker.LockedDependency
+	var name, version, source string
+	start := 0
+	flush := func() {
+		if start > 0 && name != "" && version != "" && strings.HasPrefix(source, "registry+") {
+			ret = append(ret, lockedDependency(clients.EcosystemCratesIO, name, version, path, start))
+		}
+		name, version, source, start = "", "", "", 0
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(text, "[") {
+			flush()
+			if text == "[[package]]" {
+				start = line
+			}
+			continue
+		}
+		if start == 0 {
+			continue
+		}
+		kv := strings.SplitN(text, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		value, err := strconv.Unquote(strings.TrimSpace(kv[1]))
+		if err != nil {
+			// Not a string, e.g. the dependencies array.
+			continue
+		}
+		switch strings.TrimSpace(kv[0]) {
+		case "name":
+			name = value
+		case "version":
+			version = value
+		case "source":
+			source = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanner.Err: %w", err)
+	}
+	flush()
+	return ret, nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"path"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+func TestParseLockfiles(t *testing.T) {
+	t.Parallel()
+	dep := func(ecosystem, name, version, path string, line int) checker.LockedDependency {
+		return checker.LockedDependency{
+			Dependency: clients.Dependency{Ecosystem: ecosystem, Name: name, Version: version},
+			File:       checker.File{Path: path, Type: checker.FileTypeSource, Offset: line},
+		}
+	}
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    []checker.LockedDependency
+		wantErr bool
+	}{
+		{
+			name: "go.sum",
+			path: "go.sum",
+			content: `github.com/google/go-cmp v0.5.6 h1:BKbKCqvP6I+rmFHt06ZmyQtvB8xAkWdhFyr0ZUNZcxQ=
+github.com/google/go-cmp v0.5.6/go.mod h1:v8dTdLbMG2kIc/vJvl+f65V22dbkXbowE6jgT/gNBxE=
+github.com/google/go-cmp v0.4.0/go.mod h1:v8dTdLbMG2kIc/vJvl+f65V22dbkXbowE6jgT/gNBxE=
+
+golang.org/x/text v0.3.7 h1:olpwvP2KacW1ZWvsR7uQhoyTYvKAupfQrRGBFM352Gk=
+`,
+			want: []checker.LockedDependency{
+				dep(clients.EcosystemGo, "github.com/google/go-cmp", "0.5.6", "go.sum", 1),
+				dep(clients.EcosystemGo, "golang.org/x/text", "0.3.7", "go.sum", 5),
+			},
+		},
+		{
+			name: "go.sum with several versions of a module",
+			path: "go.sum",
+			content: `golang.org/x/text v0.3.0 h1:g61tztE5qeGQ89tm6NTjjM9VPIm088od1l6aSorWRWg=
+golang.org/x/text v0.3.10 h1:olpwvP2KacW1ZWvsR7uQhoyTYvKAupfQrRGBFM352Gk=
+golang.org/x/text v0.3.7 h1:olpwvP2KacW1ZWvsR7uQhoyTYvKAupfQrRGBFM352Gk=
+golang.org/x/text v0.3.11-0.20230101000000-abcdefabcdef h1:olpwvP2KacW1ZWvsR7uQhoyTYvKAupfQrRGBFM352Gk=
+github.com/docker/docker v20.10.7+incompatible h1:Z6O9Nhsjv+ayUEeI1IojKbYcsGdgYSNqxe1s2MYzUhQ=
+github.com/docker/docker v1.13.1 h1:IkZjBSIc8hBjLpqeAbeE5mca5mNgeatLHBy3GO78BWo=
+`,
+			want: []checker.LockedDependency{
+				dep(clients.EcosystemGo, "golang.org/x/text", "0.3.11-0.20230101000000-abcdefabcdef", "go.sum", 4),
+				dep(clients.EcosystemGo, "github.com/docker/docker", "20.10.7+incompatible", "go.sum", 5),
+			},
+		},
+		{
+			name:    "invalid go.sum",
+			path:    "go.sum",
+			content: "github.com/google/go-cmp v0.5.6\n",
+			wantErr: true,
+		},
+		{
+			name: "package-lock.json v2",
+			path: "web/package-lock.json",
+			content: `{
+  "lockfileVersion": 2,
+  "packages": {
+    "": {"name": "app", "version": "1.0.0"},
+    "node_modules/lodash": {"version": "4.17.20"},
+    "node_modules/a/node_modules/@scope/b": {"version": "1.0.0"},
+    "node_modules/local": {"resolved": "packages/local", "link": true},
+    "node_modules/git": {"version": "git+ssh://git@github.com/owner/git.git#abc"},
+    "packages/local": {"version": "0.1.0"}
+  },
+  "dependencies": {
+    "lodash": {"version": "4.17.20"}
+  }
+}`,
+			want: []checker.LockedDependency{
+				dep(clients.EcosystemNPM, "@scope/b", "1.0.0", "web/package-lock.json", checker.OffsetDefault),
+				dep(clients.EcosystemNPM, "lodash", "4.17.20", "web/package-lock.json", checker.OffsetDefault),
+			},
+		},
+		{
+			name: "package-lock.json v1",
+			path: "package-lock.json",
+			content: `{
+  "lockfileVersion": 1,
+  "dependencies": {
+    "a": {"version": "1.0.0", "dependencies": {"lodash": {"version": "4.17.20"}}},
+    "lodash": {"version": "4.17.21"}
+  }
+}`,
+			want: []checker.LockedDependency{
+				dep(clients.EcosystemNPM, "a", "1.0.0", "package-lock.json", checker.OffsetDefault),
+				dep(clients.EcosystemNPM, "lodash", "4.17.20", "package-lock.json", checker.OffsetDefault),
+				dep(clients.EcosystemNPM, "lodash", "4.17.21", "package-lock.json", checker.OffsetDefault),
+			},
+		},
+		{
+			name:    "invalid package-lock.json",
+			path:    "package-lock.json",
+			content: "{",
+			wantErr: true,
+		},
+		{
+			name: "requirements.txt",
+			path: "requirements.txt",
+			content: `# Pinned requirements.
+-r base.txt
+--index-url https://pypi.org/simple
+Django==3.2.1  # LTS
+requests[security] == 2.25.0 ; python_version >= "3.6"
+urllib3==1.26.4 \
+    --hash=sha256:2f4da4594db7e1e110a944bb1b551fdf4e6c136ad42e4234131391e21eb5b0df
+flask>=2.0
+arbitrary===1.0
+`,
+			want: []checker.LockedDependency{
+				dep(clients.EcosystemPyPI, "Django", "3.2.1", "requirements.txt", 4),
+				dep(clients.EcosystemPyPI, "requests", "2.25.0", "requirements.txt", 5),
+				dep(clients.EcosystemPyPI, "urllib3", "1.26.4", "requirements.txt", 6),
+			},
+		},
+		{
+			name:    "invalid requirements.txt",
+			path:    "requirements.txt",
+			content: "==1.0\n",
+			wantErr: true,
+		},
+		{
+			name: "Cargo.lock",
+			path: "Cargo.lock",
+			content: `# This file is automatically @generated by Cargo.
+version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = [
+ "smallvec",
+]
+
+[[package]]
+name = "smallvec"
+version = "1.6.0"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "fe0f37c9e8f3c5a4a66ad655a93c74daac4ad00c441533bf5c6e7990bb42604e"
+
+[[package]]
+name = "fork"
+version = "0.2.0"
+source = "git+https://github.com/owner/fork#abc"
+
+[metadata]
+`,
+			want: []checker.LockedDependency{
+				dep(clients.EcosystemCratesIO, "smallvec", "1.6.0", "Cargo.lock", 11),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			parse := lockfileParsers[path.Base(tt.path)]
+			got, err := parse(tt.path, []byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parse error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parse mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompareGoVersions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.2.3", b: "1.2.3", want: 0},
+		{a: "1.2.10", b: "1.2.9", want: 1},
+		{a: "1.2", b: "1.2.0", want: 0},
+		{a: "2.0.0+incompatible", b: "2.0.0", want: 0},
+		{a: "1.0.0-rc.1", b: "1.0.0", want: -1},
+		{a: "1.0.0-rc.2", b: "1.0.0-rc.10", want: -1},
+		{a: "1.0.0-rc.1", b: "1.0.0-rc", want: 1},
+		{a: "1.0.0-1", b: "1.0.0-alpha", want: -1},
+		{a: "0.0.0-20230102000000-abcdefabcdef", b: "0.0.0-20230101000000-abcdefabcdef", want: 1},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			t.Parallel()
+			if got := compareGoVersions(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareGoVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := compareGoVersions(tt.b, tt.a); got != -tt.want {
+				t.Errorf("compareGoVersions(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// securityInsightsFile is the name of the file defined by the
+// OpenSSF Security Insights spec, at the root of the repo.
+const securityInsightsFile = "SECURITY-INSIGHTS.yml"
+
+// securityInsights is the subset of the Security Insights spec used by the checks.
+// See https://github.com/ossf/security-insights-spec/blob/main/specification.md.
+type securityInsights struct {
+	SecurityContacts []struct {
+		Type  string `yaml:"type"`
+		Value string `yaml:"value"`
+	} `yaml:"security-contacts"`
+	VulnerabilityReporting struct {
+		EmailContact   string `yaml:"email-contact"`
+		SecurityPolicy string `yaml:"security-policy"`
+	} `yaml:"vulnerability-reporting"`
+	DistributionPoints []string `yaml:"distribution-points"`
+	SecurityTesting    []struct {
+		ToolType string `yaml:"tool-type"`
+		ToolName string `yaml:"tool-name"`
+	} `yaml:"security-testing"`
+}
+
+// SecurityInsights reads the declarations of the repo's SECURITY-INSIGHTS.yml.
+// It returns nil if the repo has no such file, or if the file cannot be parsed,
+// in which case the parse error is logged.
+func SecurityInsights(c clients.RepoClient, dl checker.DetailLogger) (*checker.SecurityInsightsData, error) {
+	files, err := c.ListFiles(func(f string) (bool, error) {
+		return strings.EqualFold(f, securityInsightsFile), nil
+	})
+	if errors.Is(err, clients.ErrUnsupportedFeature) || (err == nil && len(files) == 0) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListFiles: %v", err))
+	}
+	content, err := c.GetFileContent(files[0])
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetFileContent: %v", err))
+	}
+	data, err := parseSecurityInsights(files[0], content)
+	if err != nil {
+		dl.Debug3(&checker.LogMessage{
+			Path:   files[0],
+			Type:   checker.FileTypeSource,
+			Offset: checker.OffsetDefault,
+			Message: checker.NewMessage(checker.MsgSecurityInsightsInvalid,
+				checker.MessageParams{"file": files[0], "error": err}),
+		})
+		return nil, nil
+	}
+	return data, nil
+}
+
+func parseSecurityInsights(path string, content []byte) (*checker.SecurityInsightsData, error) {
+	var si securityInsights
+	if err := yaml.Unmarshal(content, &si); err != nil {
+		return nil, fmt.Errorf("yaml.Unmarshal: %w", err)
+	}
+	data := checker.SecurityInsightsData{
+		File: checker.File{
+			Path:   path,
+			Type:   checker.FileTypeSource,
+			Offset: checker.OffsetDefault,
+		},
+		SecurityPolicy:     si.VulnerabilityReporting.SecurityPolicy,
+		DistributionPoints: si.DistributionPoints,
+	}
+	for _, contact := range si.SecurityContacts {
+		if contact.Value != "" {
+			data.SecurityContacts = append(data.SecurityContacts, contact.Value)
+		}
+	}
+	if si.VulnerabilityReporting.EmailContact != "" {
+		data.SecurityContacts = append(data.SecurityContacts, si.VulnerabilityReporting.EmailContact)
+	}
+	for _, tool := range si.SecurityTesting {
+		data.SecurityTools = append(data.SecurityTools, checker.SecurityInsightsTool{
+			Type: tool.ToolType,
+			Name: tool.ToolName,
+		})
+	}
+	return &data, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/checker"
+)
+
+func TestParseSecurityInsights(t *testing.T) {
+	t.Parallel()
+	file := checker.File{Path: "SECURITY-INSIGHTS.yml", Type: checker.FileTypeSource, Offset: checker.OffsetDefault}
+	tests := []struct {
+		name    string
+		content string
+		want    *checker.SecurityInsightsData
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			content: "",
+			want:    &checker.SecurityInsightsData{File: file},
+		},
+		{
+			name: "declarations",
+			content: `header:
+  schema-version: 1.0.0
+security-contacts:
+  - type: email
+    value: security@example.com
+    primary: true
+  - type: url
+    value: ""
+vulnerability-reporting:
+  accepts-vulnerability-reports: true
+  email-contact: vulns@example.com
+  security-policy: https://example.com/SECURITY.md
+distribution-points:
+  - https://pypi.org/project/example
+security-testing:
+  - tool-type: sast
+    tool-name: CodeQL
+  - tool-type: fuzzing
+    tool-name: OSS-Fuzz
+`,
+			want: &checker.SecurityInsightsData{
+				File:               file,
+				SecurityContacts:   []string{"security@example.com", "vulns@example.com"},
+				SecurityPolicy:     "https://example.com/SECURITY.md",
+				DistributionPoints: []string{"https://pypi.org/project/example"},
+				SecurityTools: []checker.SecurityInsightsTool{
+					{Type: "sast", Name: "CodeQL"},
+					{Type: "fuzzing", Name: "OSS-Fuzz"},
+				},
+			},
+		},
+		{
+			name:    "invalid",
+			content: "security-contacts: [",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSecurityInsights("SECURITY-INSIGHTS.yml", []byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSecurityInsights() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,16 +17,26 @@ package raw
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"go.uber.org/zap"
 
 	"github.com/ossf/scorecard/v3/checker"
 	"github.com/ossf/scorecard/v3/checks/fileparser"
+	"github.com/ossf/scorecard/v3/clients"
 	"github.com/ossf/scorecard/v3/clients/githubrepo"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
+var (
+	securityPolicyContactRegex = regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.-]+|https?://\S+`)
+	// e.g. "within 48 hours", "90 days", "two weeks".
+	securityPolicyTimelineRegex = regexp.MustCompile(
+		`(?i)\b(\d+|one|two|three|four|five|six|seven|ten|thirty|sixty|ninety)[\s-]*` +
+			`(business |working |calendar )?(hours?|days?|weeks?|months?)\b`)
+)
+
 // SecurityPolicy checks for presence of security policy.
 func SecurityPolicy(c *checker.CheckRequest) (checker.SecurityPolicyData, error) {
 	// TODO: not supported for local clients.
@@ -65,15 +75,37 @@ func SecurityPolicy(c *checker.CheckRequest) (checker.SecurityPolicyData, error)
 		return checker.SecurityPolicyData{}, err
 	}
 
+	// The declarations of SECURITY-INSIGHTS.yml corroborate the policy files.
+	insights, err := SecurityInsights(c.RepoClient, c.Dlogger)
+	if err != nil {
+		return checker.SecurityPolicyData{}, err
+	}
+
 	// If we found files in the repo, return immediately.
 	if len(files) > 0 {
-		return checker.SecurityPolicyData{Files: files}, nil
+		return checker.SecurityPolicyData{Files: analyzeSecurityPolicyFiles(c, files), Insights: insights}, nil
 	}
 
 	// https://docs.github.com/en/github/building-a-strong-community/creating-a-default-community-health-file.
+	// The org's `.github` repo is shared by all the repos of the org, so only look it up once.
+	orgFiles, err := clients.SharedOrgCache().Get("security-policy/"+c.Repo.Org().URI(),
+		func() (interface{}, error) {
+			return orgSecurityPolicy(c)
+		})
+	if err != nil {
+		return checker.SecurityPolicyData{}, err
+	}
+
+	// Return raw results.
+	//nolint:forcetypeassert
+	return checker.SecurityPolicyData{Files: orgFiles.([]checker.SecurityPolicyFile), Insights: insights}, nil
+}
+
+// orgSecurityPolicy looks for a security policy in the org's `.github` repo.
+func orgSecurityPolicy(c *checker.CheckRequest) ([]checker.SecurityPolicyFile, error) {
 	logger, err := githubrepo.NewLogger(zap.InfoLevel)
 	if err != nil {
-		return checker.SecurityPolicyData{}, fmt.Errorf("%w", err)
+		return nil, fmt.Errorf("%w", err)
 	}
 	dotGitHub := &checker.CheckRequest{
 		Ctx:        c.Ctx,
@@ -82,11 +114,11 @@ func SecurityPolicy(c *checker.CheckRequest) (checker.SecurityPolicyData, error)
 		Repo:       c.Repo.Org(),
 	}
 
-	err = dotGitHub.RepoClient.InitRepo(dotGitHub.Repo)
+	err = dotGitHub.RepoClient.InitRepo(dotGitHub.Repo, clients.HeadSHA)
 	switch {
 	case err == nil:
 		defer dotGitHub.RepoClient.Close()
-		onFile = func(name string, dl checker.DetailLogger, data fileparser.FileCbData) (bool, error) {
+		onFile := func(name string, dl checker.DetailLogger, data fileparser.FileCbData) (bool, error) {
 			pfiles, ok := data.(*[]checker.File)
 			if !ok {
 				// This never happens.
@@ -104,19 +136,38 @@ func SecurityPolicy(c *checker.CheckRequest) (checker.SecurityPolicyData, error)
 			}
 			return true, nil
 		}
+		files := make([]checker.File, 0)
 		err = fileparser.CheckIfFileExists(dotGitHub, onFile, &files)
 		if err != nil {
-			return checker.SecurityPolicyData{}, err
+			return nil, err
 		}
+		return analyzeSecurityPolicyFiles(dotGitHub, files), nil
 
-	case errors.Is(err, sce.ErrRepoUnreachable):
-		break
+	case errors.Is(err, sce.ErrRepoUnreachable), errors.Is(err, sce.ErrRepoNotFound):
+		return []checker.SecurityPolicyFile{}, nil
 	default:
-		return checker.SecurityPolicyData{}, err
+		return nil, err
+	}
+}
+
+// analyzeSecurityPolicyFiles looks for contact information and
+// disclosure timelines in the policy files found by `c`.
+func analyzeSecurityPolicyFiles(c *checker.CheckRequest, files []checker.File) []checker.SecurityPolicyFile {
+	ret := make([]checker.SecurityPolicyFile, 0, len(files))
+	for _, file := range files {
+		policy := checker.SecurityPolicyFile{File: file}
+		// An unreadable file is treated as an empty stub.
+		content, err := c.RepoClient.GetFileContent(file.Path)
+		if err == nil {
+			policy.HasContact, policy.HasTimeline = analyzeSecurityPolicy(content)
+		}
+		ret = append(ret, policy)
 	}
+	return ret
+}
 
-	// Return raw results.
-	return checker.SecurityPolicyData{Files: files}, err
+func analyzeSecurityPolicy(content []byte) (hasContact, hasTimeline bool) {
+	return securityPolicyContactRegex.Match(content), securityPolicyTimelineRegex.Match(content)
 }
 
 func isSecurityRstFound(name string) bool {
@@ -0,0 +1,66 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"testing"
+)
+
+func TestAnalyzeSecurityPolicy(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		content      string
+		wantContact  bool
+		wantTimeline bool
+	}{
+		{
+			name:    "stub",
+			content: "# Security Policy\n\nTODO\n",
+		},
+		{
+			name:        "email",
+			content:     "Please report vulnerabilities to security@example.com.",
+			wantContact: true,
+		},
+		{
+			name:        "url",
+			content:     "Report vulnerabilities at https://example.com/security.",
+			wantContact: true,
+		},
+		{
+			name:         "contact and timeline",
+			content:      "Email security@example.com. We respond within 48 hours and disclose after 90 days.",
+			wantContact:  true,
+			wantTimeline: true,
+		},
+		{
+			name:         "timeline in words",
+			content:      "We aim to publish a fix within two business days.",
+			wantTimeline: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			hasContact, hasTimeline := analyzeSecurityPolicy([]byte(tt.content))
+			if hasContact != tt.wantContact || hasTimeline != tt.wantTimeline {
+				t.Errorf("analyzeSecurityPolicy() = (%t, %t), want (%t, %t)",
+					hasContact, hasTimeline, tt.wantContact, tt.wantTimeline)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+// releaseLookBack is the number of recent releases with assets the check looks at.
+const releaseLookBack = 5
+
+// attestedAssetsLookBack is the number of assets of a release whose
+// attestations the check looks up.
+const attestedAssetsLookBack = 10
+
+// SignedReleases retrieves the raw data for the Signed-Releases check.
+func SignedReleases(c clients.RepoClient) (checker.SignedReleasesData, error) {
+	releases, err := c.ListReleases()
+	if err != nil {
+		return checker.SignedReleasesData{}, fmt.Errorf("%w", err)
+	}
+
+	ret := []clients.Release{}
+	for _, r := range releases {
+		// Releases without assets have nothing to sign.
+		if len(r.Assets) == 0 {
+			continue
+		}
+		ret = append(ret, r)
+		if len(ret) >= releaseLookBack {
+			break
+		}
+	}
+	data := checker.SignedReleasesData{
+		Releases:     ret,
+		Attestations: map[string][]clients.Attestation{},
+	}
+
+	images, err := c.ListContainerImages()
+	switch {
+	case errors.Is(err, clients.ErrUnsupportedFeature):
+		// E.g. the GitHub token cannot read packages.
+	case err != nil:
+		return checker.SignedReleasesData{}, fmt.Errorf("%w", err)
+	default:
+		data.ContainerImages = images
+	}
+
+	var digests []string
+	for _, r := range ret {
+		n := 0
+		for _, a := range r.Assets {
+			if a.Digest == "" || n >= attestedAssetsLookBack {
+				continue
+			}
+			digests = append(digests, a.Digest)
+			n++
+		}
+	}
+	for _, image := range data.ContainerImages {
+		digests = append(digests, image.Digest)
+	}
+	for _, digest := range digests {
+		if _, ok := data.Attestations[digest]; ok {
+			continue
+		}
+		attestations, err := c.ListAttestations(digest)
+		if errors.Is(err, clients.ErrUnsupportedFeature) {
+			break
+		}
+		if err != nil {
+			return checker.SignedReleasesData{}, fmt.Errorf("%w", err)
+		}
+		data.Attestations[digest] = attestations
+	}
+	return data, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+const (
+	// Confidence level, in percent, and its z-score.
+	samplingConfidenceLevel = 95
+	samplingZScore          = 1.96
+	// Accepted margin of error on the measured proportion.
+	samplingMarginOfError = 0.1
+	// Populations up to this size are analyzed entirely.
+	minSamplingPopulation = 30
+)
+
+// sampleInfo records how a sample was drawn, so results can be audited.
+type sampleInfo struct {
+	population int
+	size       int
+	interval   float64
+}
+
+func (s sampleInfo) isSampled() bool {
+	return s.size < s.population
+}
+
+// sampleSize returns the sample size needed to estimate a proportion
+// in a population of `population` elements, using Cochran's formula with
+// a finite population correction. The worst-case proportion 0.5 is assumed.
+func sampleSize(population int) int {
+	if population <= minSamplingPopulation {
+		return population
+	}
+	n0 := samplingZScore * samplingZScore * 0.25 / (samplingMarginOfError * samplingMarginOfError)
+	n := math.Ceil(n0 / (1 + (n0-1)/float64(population)))
+	// Never look at fewer PRs than we would without sampling.
+	return int(math.Min(math.Max(n, minSamplingPopulation), float64(population)))
+}
+
+// samplePullRequests draws a systematic sample of the PRs: every k-th PR,
+// starting with the first one. The sample is deterministic so that
+// re-running the check on the same data yields the same PRs.
+func samplePullRequests(prs []clients.PullRequest) ([]clients.PullRequest, sampleInfo) {
+	info := sampleInfo{
+		population: len(prs),
+		size:       sampleSize(len(prs)),
+		interval:   1,
+	}
+	if !info.isSampled() {
+		return prs, info
+	}
+
+	info.interval = float64(info.population) / float64(info.size)
+	ret := make([]clients.PullRequest, 0, info.size)
+	for i := 0; i < info.size; i++ {
+		ret = append(ret, prs[int(float64(i)*info.interval)])
+	}
+	return ret, info
+}
+
+// logSampleInfo records the sample metadata in the details.
+func logSampleInfo(dl checker.DetailLogger, info sampleInfo, what string) {
+	if !info.isSampled() {
+		return
+	}
+	dl.Info3(&checker.LogMessage{
+		Message: checker.NewMessage(checker.MsgSampled, checker.MessageParams{
+			"size":       info.size,
+			"population": info.population,
+			"what":       what,
+			"interval":   fmt.Sprintf("%.2f", info.interval),
+			"confidence": samplingConfidenceLevel,
+			"margin":     fmt.Sprintf("%.0f", samplingMarginOfError*100),
+		}),
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+func TestSamplePullRequests(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		population  int
+		expected    int
+		wantSampled bool
+	}{
+		{
+			name:       "empty",
+			population: 0,
+			expected:   0,
+		},
+		{
+			name:       "small population is not sampled",
+			population: 30,
+			expected:   30,
+		},
+		{
+			name:        "sample is never smaller than the unsampled window",
+			population:  40,
+			expected:    30,
+			wantSampled: true,
+		},
+		{
+			name:        "medium population",
+			population:  100,
+			expected:    50,
+			wantSampled: true,
+		},
+		{
+			name:        "large population",
+			population:  500,
+			expected:    81,
+			wantSampled: true,
+		},
+		{
+			name:        "huge population is bounded",
+			population:  100000,
+			expected:    96,
+			wantSampled: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			prs := make([]clients.PullRequest, tt.population)
+			for i := range prs {
+				prs[i].Number = i
+			}
+
+			sample, info := samplePullRequests(prs)
+			if len(sample) != tt.expected || info.size != tt.expected {
+				t.Errorf("expected sample size %d, got %d (info: %d)", tt.expected, len(sample), info.size)
+			}
+			if info.population != tt.population {
+				t.Errorf("expected population %d, got %d", tt.population, info.population)
+			}
+			if info.isSampled() != tt.wantSampled {
+				t.Errorf("expected isSampled() %t, got %t", tt.wantSampled, info.isSampled())
+			}
+
+			// The sample must be deterministic and contain distinct PRs.
+			seen := make(map[int]bool)
+			for _, pr := range sample {
+				if seen[pr.Number] {
+					t.Errorf("PR %d sampled twice", pr.Number)
+				}
+				seen[pr.Number] = true
+			}
+			again, _ := samplePullRequests(prs)
+			for i := range sample {
+				if sample[i].Number != again[i].Number {
+					t.Errorf("sample is not deterministic at index %d", i)
+				}
+			}
+		})
+	}
+}
@@ -15,8 +15,6 @@
 package checks
 
 import (
-	"fmt"
-
 	"github.com/ossf/scorecard/v3/checker"
 	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
@@ -31,11 +29,19 @@ var allowedConclusions = map[string]bool{"success": true, "neutral": true}
 
 //nolint:gochecknoinits
 func init() {
-	registerCheck(CheckSAST, SAST)
+	registerCheck(CheckSAST, SAST, clients.FeatureCheckRuns)
 }
 
 // SAST runs SAST check.
 func SAST(c *checker.CheckRequest) checker.CheckResult {
+	result := sast(c)
+	if result.State() == checker.ResultScored {
+		logSecurityInsightsTools(c, securityInsightsSAST, "SAST", result.Score > checker.MinResultScore)
+	}
+	return result
+}
+
+func sast(c *checker.CheckRequest) checker.CheckResult {
 	sastScore, sastErr := sastToolInCheckRuns(c)
 	if sastErr != nil {
 		return checker.CreateRuntimeErrorResult(CheckSAST, sastErr)
@@ -52,7 +58,8 @@ func SAST(c *checker.CheckRequest) checker.CheckResult {
 		codeQlScore == checker.InconclusiveResultScore {
 		// That can never happen since sastToolInCheckRuns can never
 		// retun checker.InconclusiveResultScore.
-		return checker.CreateInconclusiveResult(CheckSAST, "internal error")
+		reason := checker.NewMessage(checker.MsgSASTInternalError, nil)
+		return checker.CreateInconclusiveResult(CheckSAST, reason.String()).WithReasonMessage(reason)
 	}
 
 	// Both scores are conclusive.
@@ -65,17 +72,19 @@ func SAST(c *checker.CheckRequest) checker.CheckResult {
 		codeQlScore != checker.InconclusiveResultScore {
 		switch {
 		case sastScore == checker.MaxResultScore:
-			return checker.CreateMaxScoreResult(CheckSAST, "SAST tool is run on all commits")
+			reason := checker.NewMessage(checker.MsgSASTRunOnAllCommits, nil)
+			return checker.CreateMaxScoreResult(CheckSAST, reason.String()).WithReasonMessage(reason)
 		case codeQlScore == checker.MinResultScore:
-			return checker.CreateResultWithScore(CheckSAST,
-				checker.NormalizeReason("SAST tool is not run on all commits", sastScore), sastScore)
+			reason := checker.NewMessage(checker.MsgSASTNotRunOnAllCommits, checker.MessageParams{"score": sastScore})
+			return checker.CreateResultWithScore(CheckSAST, reason.String(), sastScore).WithReasonMessage(reason)
 
 		// codeQl is enabled and sast has 0+ (but not all) PRs checks.
 		case codeQlScore == checker.MaxResultScore:
 			const sastWeight = 3
 			const codeQlWeight = 7
 			score := checker.AggregateScoresWithWeight(map[int]int{sastScore: sastWeight, codeQlScore: codeQlWeight})
-			return checker.CreateResultWithScore(CheckSAST, "SAST tool detected but not run on all commmits", score)
+			reason := checker.NewMessage(checker.MsgSASTDetectedNotRunOnAllCommits, nil)
+			return checker.CreateResultWithScore(CheckSAST, reason.String(), score).WithReasonMessage(reason)
 		default:
 			return checker.CreateRuntimeErrorResult(CheckSAST, sce.WithMessage(sce.ErrScorecardInternal, "contact team"))
 		}
@@ -84,19 +93,22 @@ func SAST(c *checker.CheckRequest) checker.CheckResult {
 	// Sast inconclusive.
 	if codeQlScore != checker.InconclusiveResultScore {
 		if codeQlScore == checker.MaxResultScore {
-			return checker.CreateMaxScoreResult(CheckSAST, "SAST tool detected")
+			reason := checker.NewMessage(checker.MsgSASTDetected, nil)
+			return checker.CreateMaxScoreResult(CheckSAST, reason.String()).WithReasonMessage(reason)
 		}
-		return checker.CreateMinScoreResult(CheckSAST, "no SAST tool detected")
+		reason := checker.NewMessage(checker.MsgSASTNotDetected, nil)
+		return checker.CreateMinScoreResult(CheckSAST, reason.String()).WithReasonMessage(reason)
 	}
 
 	// CodeQl inconclusive.
 	if sastScore != checker.InconclusiveResultScore {
 		if sastScore == checker.MaxResultScore {
-			return checker.CreateMaxScoreResult(CheckSAST, "SAST tool is run on all commits")
+			reason := checker.NewMessage(checker.MsgSASTRunOnAllCommits, nil)
+			return checker.CreateMaxScoreResult(CheckSAST, reason.String()).WithReasonMessage(reason)
 		}
 
-		return checker.CreateResultWithScore(CheckSAST,
-			checker.NormalizeReason("SAST tool is not run on all commits", sastScore), sastScore)
+		reason := checker.NewMessage(checker.MsgSASTNotRunOnAllCommits, checker.MessageParams{"score": sastScore})
+		return checker.CreateResultWithScore(CheckSAST, reason.String(), sastScore).WithReasonMessage(reason)
 	}
 
 	// Should never happen.
@@ -109,7 +121,7 @@ func sastToolInCheckRuns(c *checker.CheckRequest) (int, error) {
 	if err != nil {
 		//nolint
 		return checker.InconclusiveResultScore,
-			sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("RepoClient.ListMergedPRs: %v", err))
+			sce.Wrap(sce.ErrScorecardInternal, err, "RepoClient.ListMergedPRs")
 	}
 
 	totalMerged := 0
@@ -122,11 +134,11 @@ func sastToolInCheckRuns(c *checker.CheckRequest) (int, error) {
 		crs, err := c.RepoClient.ListCheckRunsForRef(pr.HeadSHA)
 		if err != nil {
 			return checker.InconclusiveResultScore,
-				sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Client.Checks.ListCheckRunsForRef: %v", err))
+				sce.Wrap(sce.ErrScorecardInternal, err, "Client.Checks.ListCheckRunsForRef")
 		}
 		if crs == nil {
 			c.Dlogger.Warn3(&checker.LogMessage{
-				Text: "no pull requests merged into dev branch",
+				Message: checker.NewMessage(checker.MsgSASTNoMergedPRs, nil),
 			})
 			return checker.InconclusiveResultScore, nil
 		}
@@ -139,9 +151,9 @@ func sastToolInCheckRuns(c *checker.CheckRequest) (int, error) {
 			}
 			if sastTools[cr.App.Slug] {
 				c.Dlogger.Debug3(&checker.LogMessage{
-					Path: cr.URL,
-					Type: checker.FileTypeURL,
-					Text: "tool detected",
+					Path:    cr.URL,
+					Type:    checker.FileTypeURL,
+					Message: checker.NewMessage(checker.MsgSASTToolDetected, nil),
 				})
 				totalTested++
 				break
@@ -150,18 +162,21 @@ func sastToolInCheckRuns(c *checker.CheckRequest) (int, error) {
 	}
 	if totalMerged == 0 {
 		c.Dlogger.Warn3(&checker.LogMessage{
-			Text: "no pull requests merged into dev branch",
+			Message: checker.NewMessage(checker.MsgSASTNoMergedPRs, nil),
 		})
 		return checker.InconclusiveResultScore, nil
 	}
 
 	if totalTested == totalMerged {
 		c.Dlogger.Info3(&checker.LogMessage{
-			Text: fmt.Sprintf("all commits (%v) are checked with a SAST tool", totalMerged),
+			Message: checker.NewMessage(checker.MsgSASTAllCommitsChecked, checker.MessageParams{"total": totalMerged}),
 		})
 	} else {
 		c.Dlogger.Warn3(&checker.LogMessage{
-			Text: fmt.Sprintf("%v commits out of %v are checked with a SAST tool", totalTested, totalMerged),
+			Message: checker.NewMessage(checker.MsgSASTCommitsChecked, checker.MessageParams{
+				"checked": totalTested,
+				"total":   totalMerged,
+			}),
 		})
 	}
 
@@ -177,15 +192,15 @@ func codeQLInCheckDefinitions(c *checker.CheckRequest) (int, error) {
 	resp, err := c.RepoClient.Search(searchRequest)
 	if err != nil {
 		return checker.InconclusiveResultScore,
-			sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Client.Search.Code: %v", err))
+			sce.Wrap(sce.ErrScorecardInternal, err, "Client.Search.Code")
 	}
 
 	for _, result := range resp.Results {
 		c.Dlogger.Debug3(&checker.LogMessage{
-			Path: result.Path,
-			Type: checker.FileTypeSource,
-			Offset: checker.OffsetDefault,
-			Text:   "CodeQL detected",
+			Path:    result.Path,
+			Type:    checker.FileTypeSource,
+			Offset:  checker.OffsetDefault,
+			Message: checker.NewMessage(checker.MsgSASTCodeQLDetected, nil),
 		})
 	}
 
@@ -193,13 +208,13 @@ func codeQLInCheckDefinitions(c *checker.CheckRequest) (int, error) {
 	// TODO: check which branches it is enabled on. We should find main.
 	if resp.Hits > 0 {
 		c.Dlogger.Info3(&checker.LogMessage{
-			Text: "SAST tool detected: CodeQL",
+			Message: checker.NewMessage(checker.MsgSASTCodeQLToolDetected, nil),
 		})
 		return checker.MaxResultScore, nil
 	}
 
 	c.Dlogger.Warn3(&checker.LogMessage{
-		Text: "CodeQL tool not detected",
+		Message: checker.NewMessage(checker.MsgSASTCodeQLNotDetected, nil),
 	})
 	return checker.MinResultScore, nil
 }
@@ -0,0 +1,95 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"fmt"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks/evaluation"
+	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/remediation"
+)
+
+// Scorer computes the score of a check from the raw results it collected.
+type Scorer func(raw checker.RawResults) int
+
+type evaluationFn func(c *checker.CheckRequest, raw *checker.RawResults) checker.CheckResult
+
+// evaluations are the default scoring functions of the checks
+// which collect raw results.
+var evaluations = map[string]evaluationFn{
+	CheckBinaryArtifacts: func(c *checker.CheckRequest, raw *checker.RawResults) checker.CheckResult {
+		return evaluation.BinaryArtifacts(CheckBinaryArtifacts, c.Dlogger, &raw.BinaryArtifactResults)
+	},
+	CheckBranchProtection: func(c *checker.CheckRequest, raw *checker.RawResults) checker.CheckResult {
+		return evaluateBranchProtection(c.Dlogger, remediation.New(c.Repo), &raw.BranchProtectionResults,
+			c.RequiredStatusChecks)
+	},
+	CheckDangerousWorkflow: func(c *checker.CheckRequest, raw *checker.RawResults) checker.CheckResult {
+		return evaluation.DangerousWorkflow(CheckDangerousWorkflow, c.Dlogger, &raw.DangerousWorkflowResults)
+	},
+	CheckSecurityPolicy: func(c *checker.CheckRequest, raw *checker.RawResults) checker.CheckResult {
+		return evaluation.SecurityPolicy(CheckSecurityPolicy, c.Dlogger, &raw.SecurityPolicyResults)
+	},
+	CheckSignedReleases: func(c *checker.CheckRequest, raw *checker.RawResults) checker.CheckResult {
+		return evaluation.SignedReleases(CheckSignedReleases, c.Dlogger, &raw.SignedReleasesResults)
+	},
+}
+
+// WithScorer returns the check named checkName with its score computed by
+// scorer instead of the default scoring model. The check collects the same
+// data and logs the same details, so organizations with a different risk
+// model can re-score the same evidence. Only the checks which collect raw
+// results support it: others fail with a runtime error.
+func WithScorer(checkName string, scorer Scorer) checker.CheckFn {
+	return func(c *checker.CheckRequest) checker.CheckResult {
+		check, registered := AllChecks[checkName]
+		evaluate, supported := evaluations[checkName]
+		if !registered || !supported {
+			e := sce.WithMessage(sce.ErrScorecardInternal,
+				fmt.Sprintf("check %s does not support custom scorers", checkName))
+			return checker.CreateRuntimeErrorResult(checkName, e)
+		}
+
+		// Raw results are not scored.
+		if c.RawResults != nil {
+			return check(c)
+		}
+
+		var raw checker.RawResults
+		req := *c
+		req.RawResults = &raw
+		if res := check(&req); res.Error2 != nil {
+			return res
+		}
+
+		// Keep the details and reason of the default evaluation.
+		res := evaluate(c, &raw)
+		if res.Error2 != nil {
+			return res
+		}
+		score := scorer(raw)
+		switch {
+		case score == checker.InconclusiveResultScore:
+			return checker.CreateInconclusiveResult(checkName, res.Reason).WithReasonMessage(res.ReasonMessage)
+		case score < checker.MinResultScore || score > checker.MaxResultScore:
+			e := sce.WithMessage(sce.ErrScorecardInternal,
+				fmt.Sprintf("custom scorer of check %s returned invalid score %d", checkName, score))
+			return checker.CreateRuntimeErrorResult(checkName, e)
+		}
+		return checker.CreateResultWithScore(checkName, res.Reason, score).WithReasonMessage(res.ReasonMessage)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks/checktest"
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+	scut "github.com/ossf/scorecard/v3/utests"
+)
+
+func TestWithScorer(t *testing.T) {
+	t.Parallel()
+	signed := clients.Release{
+		TagName: "v1",
+		Assets:  []clients.ReleaseAsset{{Name: "bin.tar.gz"}, {Name: "bin.tar.gz.sig"}},
+	}
+	unsigned := clients.Release{
+		TagName: "v2",
+		Assets:  []clients.ReleaseAsset{{Name: "bin.tar.gz"}},
+	}
+	// Any unsigned release fails the check.
+	strict := func(raw checker.RawResults) int {
+		for _, r := range raw.SignedReleasesResults.Releases {
+			if len(r.Assets) < 2 {
+				return checker.MinResultScore
+			}
+		}
+		return checker.MaxResultScore
+	}
+
+	t.Run("custom score", func(t *testing.T) {
+		t.Parallel()
+		checktest.Run(t, WithScorer(CheckSignedReleases, strict), []checktest.Case{
+			{
+				Name: "all releases signed",
+				Repo: checktest.Repo{
+					Releases: []clients.Release{signed, signed},
+				},
+				Expected: scut.TestReturn{
+					Score:         checker.MaxResultScore,
+					NumberOfInfo:  2,
+					NumberOfWarn:  2,
+					NumberOfDebug: 2,
+				},
+			},
+			{
+				Name: "half the releases signed",
+				Repo: checktest.Repo{
+					Releases: []clients.Release{signed, unsigned},
+				},
+				// The details of the default evaluation are kept.
+				Expected: scut.TestReturn{
+					Score:         checker.MinResultScore,
+					NumberOfInfo:  1,
+					NumberOfWarn:  2,
+					NumberOfDebug: 2,
+				},
+			},
+		})
+	})
+
+	t.Run("inconclusive score", func(t *testing.T) {
+		t.Parallel()
+		inconclusive := func(raw checker.RawResults) int { return checker.InconclusiveResultScore }
+		checktest.Run(t, WithScorer(CheckSignedReleases, inconclusive), []checktest.Case{
+			{
+				Name: "releases",
+				Repo: checktest.Repo{
+					Releases: []clients.Release{signed},
+				},
+				Expected: scut.TestReturn{
+					Score:         checker.InconclusiveResultScore,
+					NumberOfInfo:  1,
+					NumberOfWarn:  1,
+					NumberOfDebug: 1,
+				},
+			},
+		})
+	})
+
+	t.Run("invalid score", func(t *testing.T) {
+		t.Parallel()
+		invalid := func(raw checker.RawResults) int { return checker.MaxResultScore + 1 }
+		checktest.Run(t, WithScorer(CheckSignedReleases, invalid), []checktest.Case{
+			{
+				Name: "releases",
+				Repo: checktest.Repo{
+					Releases: []clients.Release{signed},
+				},
+				Expected: scut.TestReturn{
+					Error:         sce.ErrScorecardInternal,
+					Score:         checker.InconclusiveResultScore,
+					NumberOfInfo:  1,
+					NumberOfWarn:  1,
+					NumberOfDebug: 1,
+				},
+			},
+		})
+	})
+
+	t.Run("unsupported check", func(t *testing.T) {
+		t.Parallel()
+		checktest.Run(t, WithScorer(CheckLicense, strict), []checktest.Case{
+			{
+				Name: "no raw results",
+				Expected: scut.TestReturn{
+					Error: sce.ErrScorecardInternal,
+					Score: checker.InconclusiveResultScore,
+				},
+			},
+		})
+	})
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"strings"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// CheckSecurityAdvisories is the registered name for SecurityAdvisories.
+const CheckSecurityAdvisories = "Security-Advisories"
+
+// Each advisory earns one point for a CVE and one for a patched release.
+const pointsPerAdvisory = 2
+
+//nolint:gochecknoinits
+func init() {
+	registerCheck(CheckSecurityAdvisories, SecurityAdvisories, clients.FeatureReleases)
+}
+
+// SecurityAdvisories runs the Security-Advisories check.
+// It looks at the advisories the project published, and whether
+// each one was assigned a CVE and shipped in a patched release.
+func SecurityAdvisories(c *checker.CheckRequest) checker.CheckResult {
+	advisories, err := c.RepoClient.ListSecurityAdvisories()
+	if err != nil {
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "RepoClient.ListSecurityAdvisories")
+		return checker.CreateRuntimeErrorResult(CheckSecurityAdvisories, e)
+	}
+	if len(advisories) == 0 {
+		reason := checker.NewMessage(checker.MsgSecurityAdvisoriesNone, nil)
+		return checker.CreateInconclusiveResult(CheckSecurityAdvisories, reason.String()).WithReasonMessage(reason)
+	}
+
+	releases, err := c.RepoClient.ListReleases()
+	if err != nil {
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "RepoClient.ListReleases")
+		return checker.CreateRuntimeErrorResult(CheckSecurityAdvisories, e)
+	}
+
+	points := 0
+	for i := range advisories {
+		advisory := &advisories[i]
+		if advisory.CVEID != "" {
+			c.Dlogger.Info3(&checker.LogMessage{
+				Message: checker.NewMessage(checker.MsgSecurityAdvisoriesCVE, checker.MessageParams{
+					"advisory": advisory.ID,
+					"cve":      advisory.CVEID,
+				}),
+			})
+			points++
+		} else {
+			c.Dlogger.Warn3(&checker.LogMessage{
+				Message: checker.NewMessage(checker.MsgSecurityAdvisoriesNoCVE, checker.MessageParams{
+					"advisory": advisory.ID,
+				}),
+			})
+		}
+
+		if tag := patchedRelease(advisory, releases); tag != "" {
+			c.Dlogger.Info3(&checker.LogMessage{
+				Message: checker.NewMessage(checker.MsgSecurityAdvisoriesFixed, checker.MessageParams{
+					"advisory": advisory.ID,
+					"release":  tag,
+				}),
+			})
+			points++
+		} else {
+			c.Dlogger.Warn3(&checker.LogMessage{
+				Message: checker.NewMessage(checker.MsgSecurityAdvisoriesNotFixed, checker.MessageParams{
+					"advisory": advisory.ID,
+					"versions": strings.Join(advisory.PatchedVersions, ", "),
+				}),
+			})
+		}
+	}
+
+	reason := checker.NewMessage(checker.MsgSecurityAdvisoriesPublished, checker.MessageParams{
+		"count":  len(advisories),
+		"points": points,
+		"total":  pointsPerAdvisory * len(advisories),
+	})
+	return checker.CreateProportionalScoreResult(CheckSecurityAdvisories, reason.String(),
+		points, pointsPerAdvisory*len(advisories)).WithReasonMessage(reason)
+}
+
+// patchedRelease returns the tag of a release which ships
+// one of the advisory's patched versions, if any.
+func patchedRelease(advisory *clients.SecurityAdvisory, releases []clients.Release) string {
+	for _, version := range advisory.PatchedVersions {
+		// Versions may be given as ranges, e.g. `>= 1.2.3`.
+		v := strings.TrimPrefix(strings.TrimLeft(version, "<>=~^ "), "v")
+		if v == "" {
+			continue
+		}
+		for _, r := range releases {
+			tag := strings.TrimPrefix(r.TagName, "v")
+			// Also match prefixed tags, e.g. `mylib-1.2.3`.
+			if tag == v || strings.HasSuffix(tag, "-"+v) {
+				return r.TagName
+			}
+		}
+	}
+	return ""
+}
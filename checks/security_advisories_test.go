@@ -0,0 +1,102 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+	sce "github.com/ossf/scorecard/v3/errors"
+	scut "github.com/ossf/scorecard/v3/utests"
+)
+
+func TestSecurityAdvisories(t *testing.T) {
+	t.Parallel()
+	releases := []clients.Release{
+		{TagName: "v1.0.1"},
+		{TagName: "mylib-2.0.1"},
+	}
+	tests := []struct {
+		err        error
+		name       string
+		advisories []clients.SecurityAdvisory
+		expected   scut.TestReturn
+	}{
+		{
+			name: "no advisories",
+			expected: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name: "error",
+			err:  errTest,
+			expected: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+				Error: sce.ErrScorecardInternal,
+			},
+		},
+		{
+			name: "CVEs and patched releases",
+			advisories: []clients.SecurityAdvisory{
+				{ID: "GHSA-1", CVEID: "CVE-2021-1", PatchedVersions: []string{"1.0.1"}},
+				{ID: "GHSA-2", CVEID: "CVE-2021-2", PatchedVersions: []string{">= 2.0.1"}},
+			},
+			expected: scut.TestReturn{
+				Score:        checker.MaxResultScore,
+				NumberOfInfo: 4,
+			},
+		},
+		{
+			name: "missing CVE and unreleased fix",
+			advisories: []clients.SecurityAdvisory{
+				{ID: "GHSA-1", CVEID: "CVE-2021-1", PatchedVersions: []string{"1.0.1"}},
+				{ID: "GHSA-2", PatchedVersions: []string{"3.0.0"}},
+			},
+			expected: scut.TestReturn{
+				Score:        5,
+				NumberOfInfo: 2,
+				NumberOfWarn: 2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListSecurityAdvisories().Return(tt.advisories, tt.err)
+			mockRepoClient.EXPECT().ListReleases().Return(releases, nil).AnyTimes()
+
+			dl := scut.TestDetailLogger{}
+			req := checker.CheckRequest{
+				RepoClient: mockRepoClient,
+				Dlogger:    &dl,
+			}
+			res := SecurityAdvisories(&req)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &res, &dl) {
+				t.Fail()
+			}
+			ctrl.Finish()
+		})
+	}
+}
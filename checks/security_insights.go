@@ -0,0 +1,89 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks/raw"
+)
+
+// Types of the security testing tools declared in SECURITY-INSIGHTS.yml.
+const (
+	securityInsightsFuzzing = "fuzzing"
+	securityInsightsSAST    = "sast"
+)
+
+// securityInsights returns the declarations of the repo's SECURITY-INSIGHTS.yml, if any.
+// They only corroborate the evidence found by the checks, so errors are logged
+// rather than failing the check.
+func securityInsights(c *checker.CheckRequest) *checker.SecurityInsightsData {
+	insights, err := raw.SecurityInsights(c.RepoClient, c.Dlogger)
+	if err != nil {
+		c.Dlogger.Debug3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgSecurityInsightsInvalid,
+				checker.MessageParams{"file": "SECURITY-INSIGHTS.yml", "error": err}),
+		})
+		return nil
+	}
+	return insights
+}
+
+// logSecurityInsightsTools logs whether the tools of toolType declared in
+// SECURITY-INSIGHTS.yml are corroborated by the tool the check detected, if any.
+func logSecurityInsightsTools(c *checker.CheckRequest, toolType, toolTypeName string, detected bool) {
+	insights := securityInsights(c)
+	if insights == nil {
+		return
+	}
+	for _, tool := range insights.Tools(toolType) {
+		msg := checker.LogMessage{
+			Path:   insights.File.Path,
+			Type:   insights.File.Type,
+			Offset: insights.File.Offset,
+		}
+		params := checker.MessageParams{"file": insights.File.Path, "type": toolTypeName, "tool": tool}
+		if detected {
+			msg.Message = checker.NewMessage(checker.MsgSecurityInsightsToolDetected, params)
+			c.Dlogger.Info3(&msg)
+		} else {
+			msg.Message = checker.NewMessage(checker.MsgSecurityInsightsToolNotDetected, params)
+			c.Dlogger.Warn3(&msg)
+		}
+	}
+}
+
+// logSecurityInsightsDistribution logs whether the distribution points declared
+// in SECURITY-INSIGHTS.yml are corroborated by the packaging the check detected, if any.
+func logSecurityInsightsDistribution(c *checker.CheckRequest, detected bool) {
+	insights := securityInsights(c)
+	if insights == nil {
+		return
+	}
+	for _, uri := range insights.DistributionPoints {
+		msg := checker.LogMessage{
+			Path:   insights.File.Path,
+			Type:   insights.File.Type,
+			Offset: insights.File.Offset,
+		}
+		params := checker.MessageParams{"file": insights.File.Path, "uri": uri}
+		if detected {
+			msg.Message = checker.NewMessage(checker.MsgSecurityInsightsDistributionDetected, params)
+			c.Dlogger.Info3(&msg)
+		} else {
+			msg.Message = checker.NewMessage(checker.MsgSecurityInsightsDistributionNotDetected, params)
+			c.Dlogger.Warn3(&msg)
+		}
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/ossf/scorecard/v3/checker"
 	"github.com/ossf/scorecard/v3/checks/evaluation"
 	"github.com/ossf/scorecard/v3/checks/raw"
+	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
@@ -26,14 +27,14 @@ const CheckSecurityPolicy = "Security-Policy"
 
 //nolint:gochecknoinits
 func init() {
-	registerCheck(CheckSecurityPolicy, SecurityPolicy)
+	registerCheck(CheckSecurityPolicy, SecurityPolicy, clients.FeatureFileContents)
 }
 
 // SecurityPolicy runs Security-Policy check.
 func SecurityPolicy(c *checker.CheckRequest) checker.CheckResult {
 	rawData, err := raw.SecurityPolicy(c)
 	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "")
 		return checker.CreateRuntimeErrorResult(CheckSecurityPolicy, e)
 	}
 
@@ -18,7 +18,6 @@ import (
 	"bufio"
 	"bytes"
 	"errors"
-	"fmt"
 	"net/url"
 	"path"
 	"path/filepath"
@@ -54,6 +53,50 @@ var downloadUtils = []string{
 	"curl", "wget", "gsutil",
 }
 
+// scriptLines is the range of lines of a file a line of a script comes from.
+type scriptLines struct {
+	start, end int
+}
+
+// shellScript is a script being validated. It is either a whole file
+// or embedded in one, e.g., in the RUN commands of a Dockerfile.
+type shellScript struct {
+	path string
+	// lines maps the lines of an embedded script to the lines of its file.
+	lines []scriptLines
+}
+
+func (s *shellScript) fileLines(line uint) scriptLines {
+	if s.lines == nil {
+		return scriptLines{start: int(line), end: int(line)}
+	}
+	if line == 0 || int(line) > len(s.lines) {
+		return scriptLines{start: checker.OffsetDefault, end: checker.OffsetDefault}
+	}
+	return s.lines[line-1]
+}
+
+// embedded returns the script passed as a string to an interpreter at pos.
+func (s *shellScript) embedded(pos syntax.Pos, content string) *shellScript {
+	e := shellScript{path: s.path}
+	for i := 0; i <= strings.Count(content, "\n"); i++ {
+		e.lines = append(e.lines, s.fileLines(pos.Line()+uint(i)))
+	}
+	return &e
+}
+
+func (s *shellScript) warnInsecureDownload(node syntax.Node, cmd string, dl checker.DetailLogger) {
+	dl.Warn3(&checker.LogMessage{
+		Path:      s.path,
+		Type:      checker.FileTypeSource,
+		Offset:    s.fileLines(node.Pos().Line()).start,
+		EndOffset: s.fileLines(node.End().Line()).end,
+		Snippet:   cmd,
+		Message:   checker.NewMessage(checker.MsgPinnedInsecureDownload, nil),
+		Finding:   checker.FindingInsecureDownload,
+	})
+}
+
 func isBinaryName(expected, name string) bool {
 	return strings.EqualFold(path.Base(name), expected)
 }
@@ -107,7 +150,7 @@ func getWgetOutputFile(cmd []string) (pathfn string, ok bool, err error) {
 
 			u, err := url.Parse(cmd[i])
 			if err != nil {
-				return "", false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("url.Parse: %v", err))
+				return "", false, sce.Wrap(sce.ErrScorecardInternal, err, "url.Parse")
 			}
 			return path.Base(u.Path), true, nil
 		}
@@ -126,7 +169,7 @@ func getGsutilOutputFile(cmd []string) (pathfn string, ok bool, err error) {
 				// Directory.
 				u, err := url.Parse(cmd[i])
 				if err != nil {
-					return "", false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("url.Parse: %v", err))
+					return "", false, sce.Wrap(sce.ErrScorecardInternal, err, "url.Parse")
 				}
 				return filepath.Join(filepath.Dir(pathfn), path.Base(u.Path)), true, nil
 			}
@@ -151,7 +194,7 @@ func getAWSOutputFile(cmd []string) (pathfn string, ok bool, err error) {
 		if filepath.Clean(filepath.Dir(ofile)) == filepath.Clean(ofile) {
 			u, err := url.Parse(ifile)
 			if err != nil {
-				return "", false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("url.Parse: %v", err))
+				return "", false, sce.Wrap(sce.ErrScorecardInternal, err, "url.Parse")
 			}
 			return filepath.Join(filepath.Dir(ofile), path.Base(u.Path)), true, nil
 		}
@@ -283,7 +326,7 @@ func extractCommand(cmd interface{}) ([]string, bool) {
 	return ret, true
 }
 
-func isFetchPipeExecute(node syntax.Node, cmd, pathfn string,
+func isFetchPipeExecute(node syntax.Node, cmd string, script *shellScript,
 	dl checker.DetailLogger) bool {
 	// BinaryCmd {Op=|, X=CallExpr{Args={curl, -s, url}}, Y=CallExpr{Args={bash,}}}.
 	bc, ok := node.(*syntax.BinaryCmd)
@@ -313,13 +356,7 @@ func isFetchPipeExecute(node syntax.Node, cmd, pathfn string,
 		return false
 	}
 
-	dl.Warn3(&checker.LogMessage{
-		Path:    pathfn,
-		Type:    checker.FileTypeSource,
-		Offset:  0, // TODO: add line numbers
-		Snippet: cmd,
-		Text:    "insecure (not pinned by hash) download detected",
-	})
+	script.warnInsecureDownload(node, cmd, dl)
 	return true
 }
 
@@ -344,7 +381,7 @@ func getRedirectFile(red []*syntax.Redirect) (string, bool) {
 	return "", false
 }
 
-func isExecuteFiles(node syntax.Node, cmd, pathfn string, files map[string]bool,
+func isExecuteFiles(node syntax.Node, cmd string, script *shellScript, files map[string]bool,
 	dl checker.DetailLogger) bool {
 	ce, ok := node.(*syntax.CallExpr)
 	if !ok {
@@ -359,13 +396,7 @@ func isExecuteFiles(node syntax.Node, cmd, pathfn string, files map[string]bool,
 	ok = false
 	for fn := range files {
 		if isInterpreterWithFile(c, fn) || isExecuteFile(c, fn) {
-			dl.Warn3(&checker.LogMessage{
-				Path:    pathfn,
-				Type:    checker.FileTypeSource,
-				Offset:  0, // TODO: add line numbers
-				Snippet: cmd,
-				Text:    "insecure (not pinned by hash) download detected",
-			})
+			script.warnInsecureDownload(node, cmd, dl)
 			ok = true
 		}
 	}
@@ -555,7 +586,7 @@ func isPipUnpinnedDownload(cmd []string) bool {
 	return false
 }
 
-func isUnpinnedPakageManagerDownload(node syntax.Node, cmd, pathfn string,
+func isUnpinnedPakageManagerDownload(node syntax.Node, cmd string, script *shellScript,
 	dl checker.DetailLogger) bool {
 	ce, ok := node.(*syntax.CallExpr)
 	if !ok {
@@ -569,37 +600,19 @@ func isUnpinnedPakageManagerDownload(node syntax.Node, cmd, pathfn string,
 
 	// Go get/install.
 	if isGoUnpinnedDownload(c) {
-		dl.Warn3(&checker.LogMessage{
-			Path:    pathfn,
-			Type:    checker.FileTypeSource,
-			Offset:  0, // TODO: add line numbers
-			Snippet: cmd,
-			Text:    "insecure (not pinned by hash) download detected",
-		})
+		script.warnInsecureDownload(node, cmd, dl)
 		return true
 	}
 
 	// Pip install.
 	if isPipUnpinnedDownload(c) {
-		dl.Warn3(&checker.LogMessage{
-			Path:    pathfn,
-			Type:    checker.FileTypeSource,
-			Offset:  0, // TODO: add line numbers
-			Snippet: cmd,
-			Text:    "insecure (not pinned by hash) download detected",
-		})
+		script.warnInsecureDownload(node, cmd, dl)
 		return true
 	}
 
 	// Npm install.
 	if isNpmUnpinnedDownload(c) {
-		dl.Warn3(&checker.LogMessage{
-			Path:    pathfn,
-			Type:    checker.FileTypeSource,
-			Offset:  0, // TODO: add line numbers
-			Snippet: cmd,
-			Text:    "insecure (not pinned by hash) download detected",
-		})
+		script.warnInsecureDownload(node, cmd, dl)
 		return true
 	}
 
@@ -631,7 +644,7 @@ func recordFetchFileFromNode(node syntax.Node) (pathfn string, ok bool, err erro
 	return fn, true, nil
 }
 
-func isFetchProcSubsExecute(node syntax.Node, cmd, pathfn string,
+func isFetchProcSubsExecute(node syntax.Node, cmd string, script *shellScript,
 	dl checker.DetailLogger) bool {
 	ce, ok := node.(*syntax.CallExpr)
 	if !ok {
@@ -682,13 +695,7 @@ func isFetchProcSubsExecute(node syntax.Node, cmd, pathfn string,
 		return false
 	}
 
-	dl.Warn3(&checker.LogMessage{
-		Path:    pathfn,
-		Type:    checker.FileTypeSource,
-		Offset:  0, // TODO: add line numbers
-		Snippet: cmd,
-		Text:    "insecure (not pinned by hash) download detected",
-	})
+	script.warnInsecureDownload(node, cmd, dl)
 	return true
 }
 
@@ -760,20 +767,20 @@ func nodeToString(p *syntax.Printer, node syntax.Node) (string, error) {
 	err := p.Print(&buf, node)
 	// This is ugly, but the parser does not have a defined error type :/.
 	if err != nil && !strings.Contains(err.Error(), "unsupported node type") {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("syntax.Printer.Print: %v", err))
+		return "", sce.Wrap(sce.ErrScorecardInternal, err, "syntax.Printer.Print")
 	}
 	return buf.String(), nil
 }
 
-func validateShellFileAndRecord(pathfn string, content []byte, files map[string]bool,
+func validateShellFileAndRecord(script *shellScript, content []byte, files map[string]bool,
 	dl checker.DetailLogger) (bool, error) {
 	in := strings.NewReader(string(content))
-	f, err := syntax.NewParser().Parse(in, pathfn)
+	f, err := syntax.NewParser().Parse(in, script.path)
 	if err != nil {
 		// Note: this is caught by internal caller and only printed
 		// to avoid failing on shell scripts that our parser does not understand.
 		// Example: https://github.com/openssl/openssl/blob/master/util/shlib_wrap.sh.in
-		return false, sce.WithMessage(sce.ErrorShellParsing, err.Error())
+		return false, sce.Wrap(sce.ErrorShellParsing, err, "")
 	}
 
 	printer := syntax.NewPrinter()
@@ -793,7 +800,7 @@ func validateShellFileAndRecord(pathfn string, content []byte, files map[string]
 		// HOST_PYTHON_VERSION=$(python3 -c 'import sys; print(f"{sys.version_info[0]}.{sys.version_info[1]}")')``
 		// nolinter
 		if ok && isShellInterpreterOrCommand([]string{i}) {
-			ok, e := validateShellFileAndRecord(pathfn, []byte(c), files, dl)
+			ok, e := validateShellFileAndRecord(script.embedded(node.Pos(), c), []byte(c), files, dl)
 			validated = ok
 			if e != nil {
 				err = e
@@ -802,23 +809,23 @@ func validateShellFileAndRecord(pathfn string, content []byte, files map[string]
 		}
 
 		// `curl | bash` (supports `sudo`).
-		if isFetchPipeExecute(node, cmdStr, pathfn, dl) {
+		if isFetchPipeExecute(node, cmdStr, script, dl) {
 			validated = false
 		}
 
 		// Check if we're calling a file we previously downloaded.
 		// Includes `curl > /tmp/file [&&|;] [bash] /tmp/file`
-		if isExecuteFiles(node, cmdStr, pathfn, files, dl) {
+		if isExecuteFiles(node, cmdStr, script, files, dl) {
 			validated = false
 		}
 
 		// `bash <(wget -qO- http://website.com/my-script.sh)`. (supports `sudo`).
-		if isFetchProcSubsExecute(node, cmdStr, pathfn, dl) {
+		if isFetchProcSubsExecute(node, cmdStr, script, dl) {
 			validated = false
 		}
 
 		// Package manager's unpinned installs.
-		if isUnpinnedPakageManagerDownload(node, cmdStr, pathfn, dl) {
+		if isUnpinnedPakageManagerDownload(node, cmdStr, script, dl) {
 			validated = false
 		}
 		// TODO(laurent): add check for cat file | bash.
@@ -914,8 +921,12 @@ func isMatchingShellScriptFile(pathfn string, content []byte, shellsToMatch []st
 }
 
 func validateShellFile(pathfn string, content []byte, dl checker.DetailLogger) (bool, error) {
+	return validateShellScript(&shellScript{path: pathfn}, content, dl)
+}
+
+func validateShellScript(script *shellScript, content []byte, dl checker.DetailLogger) (bool, error) {
 	files := make(map[string]bool)
-	r, err := validateShellFileAndRecord(pathfn, content, files, dl)
+	r, err := validateShellFileAndRecord(script, content, files, dl)
 	if err != nil && errors.Is(err, sce.ErrorShellParsing) {
 		// Discard and print this particular error for now.
 		dl.Debug(err.Error())
@@ -0,0 +1,157 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks/checktest"
+	"github.com/ossf/scorecard/v3/clients"
+	scut "github.com/ossf/scorecard/v3/utests"
+)
+
+func TestSignedReleases(t *testing.T) {
+	t.Parallel()
+	signed := clients.Release{
+		TagName: "v1",
+		Assets:  []clients.ReleaseAsset{{Name: "bin.tar.gz"}, {Name: "bin.tar.gz.sig"}},
+	}
+	provenance := clients.Release{
+		TagName: "v3",
+		Assets:  []clients.ReleaseAsset{{Name: "bin.tar.gz"}, {Name: "bin.tar.gz.sig"}, {Name: "multiple.intoto.jsonl"}},
+	}
+	// A cosign bundle is a signature, not provenance.
+	bundled := clients.Release{
+		TagName: "v4",
+		Assets:  []clients.ReleaseAsset{{Name: "bin.tar.gz"}, {Name: "bin.tar.gz.sigstore.json"}},
+	}
+	attested := clients.Release{
+		TagName: "v5",
+		Assets:  []clients.ReleaseAsset{{Name: "bin.tar.gz", Digest: "sha256:attested"}},
+	}
+	sbom := clients.Release{
+		TagName: "v6",
+		Assets:  []clients.ReleaseAsset{{Name: "bin.tar.gz", Digest: "sha256:sbom"}},
+	}
+	attestations := map[string][]clients.Attestation{
+		"sha256:attested": {
+			{PredicateType: "https://spdx.dev/Document/v2.3"},
+			{PredicateType: "https://slsa.dev/provenance/v1"},
+		},
+		"sha256:sbom": {{PredicateType: "https://spdx.dev/Document/v2.3"}},
+	}
+	unsigned := clients.Release{
+		TagName: "v2",
+		Assets:  []clients.ReleaseAsset{{Name: "bin.tar.gz"}},
+	}
+	checktest.Run(t, SignedReleases, []checktest.Case{
+		{
+			Name: "no releases",
+			Expected: scut.TestReturn{
+				Score:        checker.InconclusiveResultScore,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			Name: "releases without assets",
+			Repo: checktest.Repo{
+				Releases: []clients.Release{{TagName: "v1"}},
+			},
+			Expected: scut.TestReturn{
+				Score:        checker.InconclusiveResultScore,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			Name: "all releases signed without provenance",
+			Repo: checktest.Repo{
+				Releases: []clients.Release{signed, signed},
+			},
+			Expected: scut.TestReturn{
+				Score:         8,
+				NumberOfInfo:  2,
+				NumberOfWarn:  2,
+				NumberOfDebug: 2,
+			},
+		},
+		{
+			Name: "half the releases signed",
+			Repo: checktest.Repo{
+				Releases: []clients.Release{signed, unsigned},
+			},
+			Expected: scut.TestReturn{
+				Score:         4,
+				NumberOfInfo:  1,
+				NumberOfWarn:  2,
+				NumberOfDebug: 2,
+			},
+		},
+		{
+			Name: "all releases with provenance",
+			Repo: checktest.Repo{
+				Releases:     []clients.Release{provenance, attested},
+				Attestations: attestations,
+			},
+			Expected: scut.TestReturn{
+				Score:         checker.MaxResultScore,
+				NumberOfInfo:  2,
+				NumberOfDebug: 2,
+			},
+		},
+		{
+			Name: "sigstore bundles and attestations without provenance",
+			Repo: checktest.Repo{
+				Releases:     []clients.Release{bundled, sbom},
+				Attestations: attestations,
+			},
+			Expected: scut.TestReturn{
+				Score:         8,
+				NumberOfInfo:  2,
+				NumberOfWarn:  2,
+				NumberOfDebug: 2,
+			},
+		},
+		{
+			Name: "container images",
+			Repo: checktest.Repo{
+				ContainerImages: []clients.ContainerImage{
+					{Name: "attested", Digest: "sha256:attested"},
+					{Name: "sbom", Digest: "sha256:sbom"},
+					{Name: "unsigned", Digest: "sha256:unsigned"},
+				},
+				Attestations: attestations,
+			},
+			Expected: scut.TestReturn{
+				Score:         6,
+				NumberOfInfo:  2,
+				NumberOfWarn:  2,
+				NumberOfDebug: 3,
+			},
+		},
+		{
+			Name: "releases with provenance, signed and unsigned",
+			Repo: checktest.Repo{
+				Releases: []clients.Release{provenance, signed, unsigned},
+			},
+			Expected: scut.TestReturn{
+				Score:         6,
+				NumberOfInfo:  2,
+				NumberOfWarn:  2,
+				NumberOfDebug: 3,
+			},
+		},
+	})
+}
@@ -0,0 +1,3 @@
+module github.com/owner/app/other
+
+go 1.17
@@ -0,0 +1,3 @@
+module github.com/owner/app/sub
+
+go 1.17
@@ -15,40 +15,29 @@
 package checks
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
-	"net/http"
 	"strings"
 
 	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks/raw"
+	"github.com/ossf/scorecard/v3/clients"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
-const (
-	// CheckVulnerabilities is the registered name for the OSV check.
-	CheckVulnerabilities = "Vulnerabilities"
-	osvQueryEndpoint     = "https://api.osv.dev/v1/query"
-)
-
-type osvQuery struct {
-	Commit string `json:"commit"`
-}
+// CheckVulnerabilities is the registered name for the OSV check.
+const CheckVulnerabilities = "Vulnerabilities"
 
-type osvResponse struct {
-	Vulns []struct {
-		ID string `json:"id"`
-	} `json:"vulns"`
-}
+// criticalAdvisoryPenalty is the score deducted for each critical advisory
+// affecting the dependencies pinned by the repo's lockfiles.
+const criticalAdvisoryPenalty = 3
 
 //nolint:gochecknoinits
 func init() {
-	registerCheck(CheckVulnerabilities, HasUnfixedVulnerabilities)
+	registerCheck(CheckVulnerabilities, HasUnfixedVulnerabilities, clients.FeatureCommits)
 }
 
-func (resp *osvResponse) getVulnerabilities() []string {
-	ids := make([]string, 0, len(resp.Vulns))
-	for _, vuln := range resp.Vulns {
+func getVulnerabilities(resp *clients.VulnerabilitiesResponse) []string {
+	ids := make([]string, 0, len(resp.Vulnerabilities))
+	for _, vuln := range resp.Vulnerabilities {
 		ids = append(ids, vuln.ID)
 	}
 	return ids
@@ -56,54 +45,120 @@ func (resp *osvResponse) getVulnerabilities() []string {
 
 // HasUnfixedVulnerabilities runs Vulnerabilities check.
 func HasUnfixedVulnerabilities(c *checker.CheckRequest) checker.CheckResult {
+	if c.VulnerabilitiesClient == nil {
+		reason := checker.NewMessage(checker.MsgVulnerabilitiesNoClient, nil)
+		return checker.CreateInconclusiveResult(CheckVulnerabilities, reason.String()).WithReasonMessage(reason)
+	}
+
 	commits, err := c.RepoClient.ListCommits()
 	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, "Client.Repositories.ListCommits")
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "Client.Repositories.ListCommits")
 		return checker.CreateRuntimeErrorResult(CheckVulnerabilities, e)
 	}
 
 	if len(commits) < 1 || commits[0].SHA == "" {
-		return checker.CreateInconclusiveResult(CheckVulnerabilities, "no commits found")
+		reason := checker.NewMessage(checker.MsgVulnerabilitiesNoCommits, nil)
+		return checker.CreateInconclusiveResult(CheckVulnerabilities, reason.String()).WithReasonMessage(reason)
 	}
 
-	query, err := json.Marshal(&osvQuery{
-		Commit: commits[0].SHA,
-	})
+	resp, err := c.VulnerabilitiesClient.HasUnfixedVulnerabilities(c.Ctx, commits[0].SHA)
 	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, "json.Marshal")
+		e := sce.Wrap(sce.ErrScorecardInternal, err, "VulnerabilitiesClient.HasUnfixedVulnerabilities")
 		return checker.CreateRuntimeErrorResult(CheckVulnerabilities, e)
 	}
 
-	req, err := http.NewRequestWithContext(c.Ctx, http.MethodPost, osvQueryEndpoint, bytes.NewReader(query))
-	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("http.NewRequestWithContext: %v", err))
-		return checker.CreateRuntimeErrorResult(CheckVulnerabilities, e)
+	// TODO: take severity into account.
+	vulnIDs := getVulnerabilities(&resp)
+	if len(vulnIDs) > 0 {
+		c.Dlogger.Warn3(&checker.LogMessage{
+			Message: checker.NewMessage(checker.MsgVulnerabilitiesHeadVulnerable, checker.MessageParams{
+				"ids": strings.Join(vulnIDs, ", "),
+			}),
+		})
 	}
 
-	// Use our own http client as the one from CheckRequest adds GitHub tokens to the headers.
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("httpClient.Do: %v", err))
-		return checker.CreateRuntimeErrorResult(CheckVulnerabilities, e)
+	critical := 0
+	if c.DependencyVulnerabilities {
+		critical, err = criticalDependencyVulnerabilities(c)
+		if err != nil {
+			return checker.CreateRuntimeErrorResult(CheckVulnerabilities, err)
+		}
 	}
-	defer resp.Body.Close()
 
-	var osvResp osvResponse
-	decoder := json.NewDecoder(resp.Body)
-	if err := decoder.Decode(&osvResp); err != nil {
-		e := sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("decoder.Decode: %v", err))
-		return checker.CreateRuntimeErrorResult(CheckVulnerabilities, e)
+	if len(vulnIDs) > 0 {
+		reason := checker.NewMessage(checker.MsgVulnerabilitiesDetected, nil)
+		return checker.CreateMinScoreResult(CheckVulnerabilities, reason.String()).WithReasonMessage(reason)
+	}
+	if critical > 0 {
+		reason := checker.NewMessage(checker.MsgVulnerabilitiesCriticalDependencies,
+			checker.MessageParams{"count": critical})
+		score := checker.MaxResultScore - criticalAdvisoryPenalty*critical
+		if score < checker.MinResultScore {
+			score = checker.MinResultScore
+		}
+		return checker.CreateResultWithScore(CheckVulnerabilities, reason.String(), score).WithReasonMessage(reason)
 	}
 
-	// TODO: take severity into account.
-	vulnIDs := osvResp.getVulnerabilities()
-	if len(vulnIDs) > 0 {
-		c.Dlogger.Warn3(&checker.LogMessage{
-			Text: fmt.Sprintf("HEAD is vulnerable to %s", strings.Join(vulnIDs, ", ")),
-		})
-		return checker.CreateMinScoreResult(CheckVulnerabilities, "existing vulnerabilities detected")
+	reason := checker.NewMessage(checker.MsgVulnerabilitiesNotDetected, nil)
+	return checker.CreateMaxScoreResult(CheckVulnerabilities, reason.String()).WithReasonMessage(reason)
+}
+
+// criticalDependencyVulnerabilities looks up the dependencies pinned by the
+// repo's lockfiles in the vulnerabilities DB, logs the advisories affecting
+// them, and returns the number of distinct critical advisories.
+func criticalDependencyVulnerabilities(c *checker.CheckRequest) (int, error) {
+	locked, err := raw.LockedDependencies(c.RepoClient, c.Dlogger)
+	if err != nil {
+		//nolint:wrapcheck
+		return 0, err
+	}
+	// A dependency pinned by several lockfiles is looked up once,
+	// and reported at the first of them.
+	var deps []clients.Dependency
+	files := map[clients.Dependency]checker.File{}
+	for _, d := range locked {
+		if _, ok := files[d.Dependency]; !ok {
+			deps = append(deps, d.Dependency)
+			files[d.Dependency] = d.File
+		}
+	}
+	c.Dlogger.Debug3(&checker.LogMessage{
+		Message: checker.NewMessage(checker.MsgVulnerabilitiesDependenciesChecked,
+			checker.MessageParams{"count": len(deps)}),
+	})
+	if len(deps) == 0 {
+		return 0, nil
 	}
 
-	return checker.CreateMaxScoreResult(CheckVulnerabilities, "no vulnerabilities detected")
+	resps, err := c.VulnerabilitiesClient.QueryDependencies(c.Ctx, deps)
+	if err != nil {
+		return 0, sce.Wrap(sce.ErrScorecardInternal, err, "VulnerabilitiesClient.QueryDependencies")
+	}
+	critical := map[string]bool{}
+	for i := range resps {
+		d := deps[i]
+		f := files[d]
+		for _, vuln := range resps[i].Vulnerabilities {
+			params := checker.MessageParams{
+				"ecosystem": d.Ecosystem,
+				"name":      d.Name,
+				"version":   d.Version,
+				"id":        vuln.ID,
+			}
+			msg := &checker.LogMessage{Path: f.Path, Type: f.Type, Offset: f.Offset}
+			if vuln.Severity == clients.SeverityCritical {
+				critical[vuln.ID] = true
+				msg.Message = checker.NewMessage(checker.MsgVulnerabilitiesDependencyCritical, params)
+				c.Dlogger.Warn3(msg)
+				continue
+			}
+			params["severity"] = "unknown"
+			if vuln.Severity != "" {
+				params["severity"] = strings.ToLower(vuln.Severity)
+			}
+			msg.Message = checker.NewMessage(checker.MsgVulnerabilitiesDependencyVulnerable, params)
+			c.Dlogger.Info3(msg)
+		}
+	}
+	return len(critical), nil
 }
@@ -0,0 +1,239 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+	sce "github.com/ossf/scorecard/v3/errors"
+	scut "github.com/ossf/scorecard/v3/utests"
+)
+
+func TestHasUnfixedVulnerabilities(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err      error
+		name     string
+		commits  []clients.Commit
+		vulns    clients.VulnerabilitiesResponse
+		expected scut.TestReturn
+	}{
+		{
+			name:    "no vulnerabilities",
+			commits: []clients.Commit{{SHA: "sha1"}},
+			expected: scut.TestReturn{
+				Score: checker.MaxResultScore,
+			},
+		},
+		{
+			name:    "vulnerabilities",
+			commits: []clients.Commit{{SHA: "sha1"}},
+			vulns: clients.VulnerabilitiesResponse{
+				Vulnerabilities: []clients.Vulnerability{{ID: "OSV-2021-1"}, {ID: "OSV-2021-2"}},
+			},
+			expected: scut.TestReturn{
+				Score:        checker.MinResultScore,
+				NumberOfWarn: 1,
+			},
+		},
+		{
+			name:    "no commits",
+			commits: []clients.Commit{},
+			expected: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+			},
+		},
+		{
+			name:    "client error",
+			commits: []clients.Commit{{SHA: "sha1"}},
+			err:     errTest,
+			expected: scut.TestReturn{
+				Score: checker.InconclusiveResultScore,
+				Error: sce.ErrScorecardInternal,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListCommits().Return(tt.commits, nil)
+
+			mockVulnClient := mockrepo.NewMockVulnerabilitiesClient(ctrl)
+			mockVulnClient.EXPECT().HasUnfixedVulnerabilities(gomock.Any(), "sha1").DoAndReturn(
+				func(context.Context, string) (clients.VulnerabilitiesResponse, error) {
+					return tt.vulns, tt.err
+				}).MaxTimes(1)
+
+			dl := scut.TestDetailLogger{}
+			req := checker.CheckRequest{
+				RepoClient:            mockRepoClient,
+				VulnerabilitiesClient: mockVulnClient,
+				Dlogger:               &dl,
+			}
+			res := HasUnfixedVulnerabilities(&req)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &res, &dl) {
+				t.Fail()
+			}
+			ctrl.Finish()
+		})
+	}
+}
+
+func TestDependencyVulnerabilities(t *testing.T) {
+	t.Parallel()
+	goSum := []byte("github.com/owner/a v1.0.0 h1:abc=\ngithub.com/owner/b v2.0.0 h1:def=\n")
+	tests := []struct {
+		err      error
+		name     string
+		headVuln bool
+		vulns    []clients.VulnerabilitiesResponse
+		expected scut.TestReturn
+	}{
+		{
+			name:  "no vulnerabilities",
+			vulns: []clients.VulnerabilitiesResponse{{}, {}},
+			expected: scut.TestReturn{
+				Score:         checker.MaxResultScore,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name: "non-critical vulnerabilities",
+			vulns: []clients.VulnerabilitiesResponse{
+				{Vulnerabilities: []clients.Vulnerability{{ID: "GHSA-1", Severity: clients.SeverityHigh}}},
+				{Vulnerabilities: []clients.Vulnerability{{ID: "GO-2"}}},
+			},
+			expected: scut.TestReturn{
+				Score:         checker.MaxResultScore,
+				NumberOfInfo:  2,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name: "critical vulnerabilities",
+			vulns: []clients.VulnerabilitiesResponse{
+				{Vulnerabilities: []clients.Vulnerability{
+					{ID: "GHSA-1", Severity: clients.SeverityCritical},
+					{ID: "GHSA-2", Severity: clients.SeverityCritical},
+				}},
+				{Vulnerabilities: []clients.Vulnerability{{ID: "GHSA-1", Severity: clients.SeverityCritical}}},
+			},
+			expected: scut.TestReturn{
+				Score:         checker.MaxResultScore - 2*criticalAdvisoryPenalty,
+				NumberOfWarn:  3,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name: "many critical vulnerabilities",
+			vulns: []clients.VulnerabilitiesResponse{
+				{Vulnerabilities: []clients.Vulnerability{
+					{ID: "GHSA-1", Severity: clients.SeverityCritical},
+					{ID: "GHSA-2", Severity: clients.SeverityCritical},
+					{ID: "GHSA-3", Severity: clients.SeverityCritical},
+					{ID: "GHSA-4", Severity: clients.SeverityCritical},
+				}},
+				{},
+			},
+			expected: scut.TestReturn{
+				Score:         checker.MinResultScore,
+				NumberOfWarn:  4,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name:     "vulnerable HEAD",
+			headVuln: true,
+			vulns: []clients.VulnerabilitiesResponse{
+				{Vulnerabilities: []clients.Vulnerability{{ID: "GHSA-1", Severity: clients.SeverityCritical}}},
+				{},
+			},
+			expected: scut.TestReturn{
+				Score:         checker.MinResultScore,
+				NumberOfWarn:  2,
+				NumberOfDebug: 1,
+			},
+		},
+		{
+			name: "client error",
+			err:  errTest,
+			expected: scut.TestReturn{
+				Score:         checker.InconclusiveResultScore,
+				Error:         sce.ErrScorecardInternal,
+				NumberOfDebug: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+
+			mockRepoClient := mockrepo.NewMockRepoClient(ctrl)
+			mockRepoClient.EXPECT().ListCommits().Return([]clients.Commit{{SHA: "sha1"}}, nil)
+			mockRepoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					var files []string
+					for _, f := range []string{"go.sum", "testdata/go.sum", "main.go"} {
+						if ok, _ := predicate(f); ok {
+							files = append(files, f)
+						}
+					}
+					return files, nil
+				})
+			mockRepoClient.EXPECT().GetFileContent("go.sum").Return(goSum, nil)
+
+			var headVulns clients.VulnerabilitiesResponse
+			if tt.headVuln {
+				headVulns.Vulnerabilities = []clients.Vulnerability{{ID: "OSV-2021-1"}}
+			}
+			mockVulnClient := mockrepo.NewMockVulnerabilitiesClient(ctrl)
+			mockVulnClient.EXPECT().HasUnfixedVulnerabilities(gomock.Any(), "sha1").Return(headVulns, nil)
+			mockVulnClient.EXPECT().QueryDependencies(gomock.Any(), []clients.Dependency{
+				{Ecosystem: clients.EcosystemGo, Name: "github.com/owner/a", Version: "1.0.0"},
+				{Ecosystem: clients.EcosystemGo, Name: "github.com/owner/b", Version: "2.0.0"},
+			}).Return(tt.vulns, tt.err)
+
+			dl := scut.TestDetailLogger{}
+			req := checker.CheckRequest{
+				Ctx:                       context.Background(),
+				RepoClient:                mockRepoClient,
+				VulnerabilitiesClient:     mockVulnClient,
+				Dlogger:                   &dl,
+				DependencyVulnerabilities: true,
+			}
+			res := HasUnfixedVulnerabilities(&req)
+			if !scut.ValidateTestReturn(t, tt.name, &tt.expected, &res, &dl) {
+				t.Fail()
+			}
+			ctrl.Finish()
+		})
+	}
+}
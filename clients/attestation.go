@@ -0,0 +1,33 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+// Attestation is an attestation a forge stores for an artifact, e.g. a GitHub
+// artifact attestation.
+type Attestation struct {
+	// PredicateType is the in-toto predicate type of the attestation, e.g.
+	// https://slsa.dev/provenance/v1 for SLSA provenance.
+	PredicateType string
+}
+
+// ContainerImage is the latest version of a container image the forge hosts
+// for a repo.
+type ContainerImage struct {
+	// Name is the package name of the image, e.g. repo for ghcr.io/owner/repo.
+	Name string
+	URL  string
+	// Digest is the digest of the image, e.g. sha256:...
+	Digest string
+}
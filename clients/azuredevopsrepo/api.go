@@ -0,0 +1,113 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package azuredevopsrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+)
+
+const (
+	apiBaseURL = "https://dev.azure.com"
+	apiVersion = "7.0"
+	// Personal access token.
+	tokenEnv = "AZURE_DEVOPS_AUTH_TOKEN"
+	// Header holding the token of the next page of list APIs.
+	continuationHeader = "x-ms-continuationtoken"
+)
+
+// list is the result of a list API.
+type list struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// apiClient calls the Azure DevOps Services REST API.
+type apiClient struct {
+	client  *forge.Client
+	baseURL string
+}
+
+func newAPIClient(httpClient *http.Client, baseURL string) *apiClient {
+	token := os.Getenv(tokenEnv)
+	return &apiClient{
+		client: forge.NewClient(httpClient, func(req *http.Request) {
+			if token != "" {
+				// Personal access tokens are sent as the password of an empty user.
+				req.SetBasicAuth("", token)
+			}
+		}),
+		baseURL: baseURL,
+	}
+}
+
+func (c *apiClient) requestURL(path string, query url.Values) string {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	q.Set("api-version", apiVersion)
+	return c.baseURL + path + "?" + q.Encode()
+}
+
+// do sends a GET request for path and returns the response body and
+// the continuation token of the next page, if any.
+func (c *apiClient) do(ctx context.Context, path string, query url.Values) ([]byte, string, error) {
+	body, header, err := c.client.Get(ctx, c.requestURL(path, query))
+	if err != nil {
+		return nil, "", err
+	}
+	return body, header.Get(continuationHeader), nil
+}
+
+// get decodes the JSON response for path into v.
+func (c *apiClient) get(ctx context.Context, path string, query url.Values, v interface{}) error {
+	return c.client.GetJSON(ctx, c.requestURL(path, query), v)
+}
+
+// list calls a list API, following continuation tokens until the last page
+// or until max items were read. appendPage decodes the values of a page and
+// returns their number.
+func (c *apiClient) list(ctx context.Context, path string, query url.Values, max int,
+	appendPage func(values []byte) (int, error)) error {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	for read := 0; read < max; {
+		body, next, err := c.do(ctx, path, q)
+		if err != nil {
+			return err
+		}
+		var l list
+		if err := json.Unmarshal(body, &l); err != nil {
+			return fmt.Errorf("json.Unmarshal: %w", err)
+		}
+		n, err := appendPage(l.Value)
+		if err != nil {
+			return err
+		}
+		read += n
+		if next == "" || n == 0 {
+			break
+		}
+		q.Set("continuationToken", next)
+	}
+	return nil
+}
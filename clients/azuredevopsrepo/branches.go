@@ -0,0 +1,260 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package azuredevopsrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// Maximum number of branches and policies listed.
+const (
+	branchesToAnalyze = 500
+	policiesToAnalyze = 500
+)
+
+const headsPrefix = "refs/heads/"
+
+// IDs of the policy types.
+// See https://learn.microsoft.com/en-us/azure/devops/repos/git/branch-policies.
+const (
+	policyMinimumReviewers  = "fa4e907d-c16b-4a4c-9dfa-4906e5d171dd"
+	policyRequiredReviewers = "fd2167ab-b0be-447a-8ec8-39368250530e"
+	policyBuild             = "0609b952-1397-4640-95ec-e00a01b2c241"
+	policyStatus            = "cbdc66da-9728-4af8-aada-9a5a32e4a226"
+	policyMergeStrategy     = "fa4e907d-c16b-4a4c-9dfa-4916e5d171ab"
+)
+
+type ref struct {
+	Name     string `json:"name"`
+	ObjectID string `json:"objectId"`
+}
+
+type policyScope struct {
+	// RepositoryID is empty for policies applying to all the repos of the project.
+	RepositoryID string `json:"repositoryId"`
+	RefName      string `json:"refName"`
+	// MatchKind is "Exact" or "Prefix".
+	MatchKind string `json:"matchKind"`
+}
+
+// policy is a policy configuration. Settings depend on the type.
+type policy struct {
+	IsEnabled  bool `json:"isEnabled"`
+	IsBlocking bool `json:"isBlocking"`
+	IsDeleted  bool `json:"isDeleted"`
+	Type       struct {
+		ID string `json:"id"`
+	} `json:"type"`
+	Settings struct {
+		Scope []policyScope `json:"scope"`
+		// Minimum number of reviewers.
+		MinimumApproverCount int32 `json:"minimumApproverCount"`
+		ResetOnSourcePush    bool  `json:"resetOnSourcePush"`
+		// Build.
+		DisplayName string `json:"displayName"`
+		// Status.
+		StatusGenre string `json:"statusGenre"`
+		StatusName  string `json:"statusName"`
+		// Merge strategy.
+		AllowNoFastForward bool `json:"allowNoFastForward"`
+		AllowRebaseMerge   bool `json:"allowRebaseMerge"`
+	} `json:"settings"`
+}
+
+// appliesTo returns whether the policy is enforced on a branch of the repo.
+func (p *policy) appliesTo(repoID, branchRef string) bool {
+	if !p.IsEnabled || !p.IsBlocking || p.IsDeleted {
+		return false
+	}
+	for _, s := range p.Settings.Scope {
+		if s.RepositoryID != "" && !strings.EqualFold(s.RepositoryID, repoID) {
+			continue
+		}
+		switch {
+		case s.RefName == "",
+			strings.EqualFold(s.MatchKind, "exact") && s.RefName == branchRef,
+			strings.EqualFold(s.MatchKind, "prefix") && strings.HasPrefix(branchRef, s.RefName):
+			return true
+		}
+	}
+	return false
+}
+
+// context returns the name of the status reported for a build or status policy.
+func (p *policy) context() string {
+	if p.Type.ID == policyStatus {
+		if p.Settings.StatusGenre == "" {
+			return p.Settings.StatusName
+		}
+		return p.Settings.StatusGenre + "/" + p.Settings.StatusName
+	}
+	return p.Settings.DisplayName
+}
+
+type branchesHandler struct {
+	api           *apiClient
+	once          *forge.Once
+	base          string
+	repoID        string
+	defaultBranch string
+	branches      []*clients.BranchRef
+}
+
+func (handler *branchesHandler) init(r *repository) {
+	handler.base = r.apiPath()
+	handler.repoID = r.ID
+	handler.defaultBranch = strings.TrimPrefix(r.DefaultBranch, headsPrefix)
+	handler.once = new(forge.Once)
+}
+
+func (handler *branchesHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		var refs []ref
+		query := url.Values{"filter": {"heads/"}}
+		err := handler.api.list(ctx, handler.base+"/git/repositories/"+handler.repoID+"/refs",
+			query, branchesToAnalyze,
+			func(values []byte) (int, error) {
+				var page []ref
+				if err := json.Unmarshal(values, &page); err != nil {
+					return 0, fmt.Errorf("json.Unmarshal: %w", err)
+				}
+				refs = append(refs, page...)
+				return len(page), nil
+			})
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list refs: %v", err))
+		}
+
+		var policies []policy
+		err = handler.api.list(ctx, handler.base+"/policy/configurations", nil, policiesToAnalyze,
+			func(values []byte) (int, error) {
+				var page []policy
+				if err := json.Unmarshal(values, &page); err != nil {
+					return 0, fmt.Errorf("json.Unmarshal: %w", err)
+				}
+				policies = append(policies, page...)
+				return len(page), nil
+			})
+		switch {
+		// Without access to the policies, nothing is known about the protection of branches.
+		case forge.HasStatus(err, http.StatusForbidden, http.StatusNotFound, http.StatusUnauthorized):
+			handler.branches = nil
+			for i := range refs {
+				name := strings.TrimPrefix(refs[i].Name, headsPrefix)
+				handler.branches = append(handler.branches, &clients.BranchRef{Name: &name})
+			}
+			return nil
+		case err != nil:
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list policies: %v", err))
+		}
+
+		handler.branches = nil
+		for i := range refs {
+			var applied []*policy
+			for j := range policies {
+				if policies[j].appliesTo(handler.repoID, refs[i].Name) {
+					applied = append(applied, &policies[j])
+				}
+			}
+			handler.branches = append(handler.branches,
+				branchFrom(strings.TrimPrefix(refs[i].Name, headsPrefix), applied))
+		}
+		return nil
+	})
+}
+
+func (handler *branchesHandler) listBranches(ctx context.Context) ([]*clients.BranchRef, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during branchesHandler.setup: %w", err)
+	}
+	return handler.branches, nil
+}
+
+func (handler *branchesHandler) getDefaultBranch(ctx context.Context) (*clients.BranchRef, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during branchesHandler.setup: %w", err)
+	}
+	for _, b := range handler.branches {
+		if *b.Name == handler.defaultBranch {
+			return b, nil
+		}
+	}
+	return nil, sce.WithMessage(sce.ErrScorecardInternal,
+		fmt.Sprintf("default branch %s not found", handler.defaultBranch))
+}
+
+// branchFrom maps the blocking policies of a branch onto the settings of
+// a GitHub protection rule. Branches with policies require pull requests,
+// so they reject direct pushes, including force pushes, and deletions.
+// Users with the "Bypass policies when pushing" permission are not
+// reported, as permissions cannot be read through the API.
+func branchFrom(name string, policies []*policy) *clients.BranchRef {
+	protected := len(policies) > 0
+	ret := &clients.BranchRef{
+		Name:      &name,
+		Protected: &protected,
+	}
+	if !protected {
+		return ret
+	}
+
+	rule := &ret.BranchProtectionRule
+	rule.AllowForcePushes = newFalse()
+	rule.AllowDeletions = newFalse()
+
+	var approvals int32
+	var dismissStale, codeOwners, requiresStatusChecks bool
+	linearHistory := false
+	for _, p := range policies {
+		switch p.Type.ID {
+		case policyMinimumReviewers:
+			if p.Settings.MinimumApproverCount > approvals {
+				approvals = p.Settings.MinimumApproverCount
+			}
+			dismissStale = dismissStale || p.Settings.ResetOnSourcePush
+		case policyRequiredReviewers:
+			// Reviewers required for some paths, like code owners.
+			codeOwners = true
+		case policyBuild, policyStatus:
+			requiresStatusChecks = true
+			if c := p.context(); c != "" {
+				rule.CheckRules.Contexts = append(rule.CheckRules.Contexts, c)
+			}
+		case policyMergeStrategy:
+			linearHistory = !p.Settings.AllowNoFastForward && !p.Settings.AllowRebaseMerge
+		}
+	}
+	rule.RequireLinearHistory = &linearHistory
+	rule.RequiredPullRequestReviews = clients.PullRequestReviewRule{
+		RequiredApprovingReviewCount: &approvals,
+		DismissStaleReviews:          &dismissStale,
+		RequireCodeOwnerReviews:      &codeOwners,
+	}
+	rule.CheckRules.RequiresStatusChecks = &requiresStatusChecks
+	return ret
+}
+
+func newFalse() *bool {
+	ret := false
+	return &ret
+}
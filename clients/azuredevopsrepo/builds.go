@@ -0,0 +1,177 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package azuredevopsrepo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const (
+	runsToList = 30
+	// Number of successful builds searched for builds of tags.
+	buildsToAnalyze   = 100
+	releasesToAnalyze = 30
+	tagsPrefix        = "refs/tags/"
+)
+
+type definition struct {
+	ID      int `json:"id"`
+	Process struct {
+		// YAMLFilename is only set for YAML pipelines.
+		YAMLFilename string `json:"yamlFilename"`
+	} `json:"process"`
+}
+
+type build struct {
+	ID            int    `json:"id"`
+	SourceBranch  string `json:"sourceBranch"`
+	SourceVersion string `json:"sourceVersion"`
+	Links         struct {
+		Web struct {
+			Href string `json:"href"`
+		} `json:"web"`
+	} `json:"_links"`
+}
+
+type artifact struct {
+	Name     string `json:"name"`
+	Resource struct {
+		DownloadURL string `json:"downloadUrl"`
+	} `json:"resource"`
+}
+
+// buildsHandler lists the runs of Azure Pipelines building the repo.
+type buildsHandler struct {
+	api      *apiClient
+	once     *forge.Once
+	base     string
+	repoID   string
+	releases []clients.Release
+}
+
+func (handler *buildsHandler) init(r *repository) {
+	handler.base = r.apiPath() + "/build"
+	handler.repoID = r.ID
+	handler.once = new(forge.Once)
+}
+
+func (handler *buildsHandler) repoQuery() url.Values {
+	return url.Values{
+		"repositoryId":   {handler.repoID},
+		"repositoryType": {"TfsGit"},
+	}
+}
+
+func (handler *buildsHandler) successfulBuilds(ctx context.Context, query url.Values, top int) ([]build, error) {
+	query.Set("resultFilter", "succeeded")
+	query.Set("queryOrder", "finishTimeDescending")
+	query.Set("$top", strconv.Itoa(top))
+	var builds struct {
+		Value []build `json:"value"`
+	}
+	if err := handler.api.get(ctx, handler.base+"/builds", query, &builds); err != nil {
+		return nil, fmt.Errorf("list builds: %w", err)
+	}
+	return builds.Value, nil
+}
+
+// setup reports the successful builds of tags as releases,
+// with the artifacts they published as assets.
+func (handler *buildsHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		builds, err := handler.successfulBuilds(ctx, handler.repoQuery(), buildsToAnalyze)
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		}
+		handler.releases = nil
+		for i := range builds {
+			b := &builds[i]
+			if !strings.HasPrefix(b.SourceBranch, tagsPrefix) {
+				continue
+			}
+			if len(handler.releases) >= releasesToAnalyze {
+				break
+			}
+			var artifacts struct {
+				Value []artifact `json:"value"`
+			}
+			artifactsPath := fmt.Sprintf("%s/builds/%d/artifacts", handler.base, b.ID)
+			if err := handler.api.get(ctx, artifactsPath, nil, &artifacts); err != nil {
+				return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list artifacts: %v", err))
+			}
+			release := clients.Release{
+				TagName:         strings.TrimPrefix(b.SourceBranch, tagsPrefix),
+				URL:             b.Links.Web.Href,
+				TargetCommitish: b.SourceVersion,
+			}
+			for _, a := range artifacts.Value {
+				release.Assets = append(release.Assets, clients.ReleaseAsset{
+					Name: a.Name,
+					URL:  a.Resource.DownloadURL,
+				})
+			}
+			handler.releases = append(handler.releases, release)
+		}
+		return nil
+	})
+}
+
+func (handler *buildsHandler) getReleases(ctx context.Context) ([]clients.Release, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during buildsHandler.setup: %w", err)
+	}
+	return handler.releases, nil
+}
+
+// listSuccessfulRuns returns the latest successful runs of the YAML pipelines
+// defined in a file named filename, e.g., "azure-pipelines.yml".
+func (handler *buildsHandler) listSuccessfulRuns(ctx context.Context, filename string) ([]clients.WorkflowRun, error) {
+	query := handler.repoQuery()
+	query.Set("includeAllProperties", "true")
+	var definitions struct {
+		Value []definition `json:"value"`
+	}
+	if err := handler.api.get(ctx, handler.base+"/definitions", query, &definitions); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list definitions: %v", err))
+	}
+	var ids []string
+	for _, d := range definitions.Value {
+		if d.Process.YAMLFilename != "" && path.Base(d.Process.YAMLFilename) == filename {
+			ids = append(ids, strconv.Itoa(d.ID))
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query = url.Values{"definitions": {strings.Join(ids, ",")}}
+	builds, err := handler.successfulBuilds(ctx, query, runsToList)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+	var ret []clients.WorkflowRun
+	for i := range builds {
+		ret = append(ret, clients.WorkflowRun{URL: builds[i].Links.Web.Href})
+	}
+	return ret, nil
+}
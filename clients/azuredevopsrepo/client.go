@@ -0,0 +1,287 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package azuredevopsrepo implements clients.RepoClient for Azure Repos,
+// the git repos of Azure DevOps Services.
+package azuredevopsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+var errInputRepoType = errors.New("input repo should be of type repoURL")
+
+type repository struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// DefaultBranch is a ref, e.g., "refs/heads/main". It is empty for empty repos.
+	DefaultBranch string `json:"defaultBranch"`
+	Project       struct {
+		Name       string `json:"name"`
+		Visibility string `json:"visibility"`
+	} `json:"project"`
+	organization string
+}
+
+// apiPath returns the path of the APIs of the project of the repo.
+func (r *repository) apiPath() string {
+	return fmt.Sprintf("/%s/%s/_apis", url.PathEscape(r.organization), url.PathEscape(r.Project.Name))
+}
+
+// Client is Azure DevOps-specific implementation of RepoClient.
+type Client struct {
+	repo     *repository
+	api      *apiClient
+	contents *contentsHandler
+	commits  *commitsHandler
+	branches *branchesHandler
+	builds   *buildsHandler
+	statuses *statusesHandler
+	ctx      context.Context
+	// origin is the Client a view returned by WithCallContext was made from.
+	origin *Client
+}
+
+// InitRepo sets up the Azure DevOps repo.
+func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string) error {
+	if client.origin != nil {
+		return client.origin.initRepo(client.ctx, inputRepo, commitSHA)
+	}
+	return client.initRepo(client.ctx, inputRepo, commitSHA)
+}
+
+func (client *Client) initRepo(ctx context.Context, inputRepo clients.Repo, commitSHA string) error {
+	azureRepo, ok := inputRepo.(*repoURL)
+	if !ok {
+		return fmt.Errorf("%w: %v", errInputRepoType, inputRepo)
+	}
+
+	// Sanity check.
+	repo := repository{organization: azureRepo.organization}
+	path := fmt.Sprintf("/%s/%s/_apis/git/repositories/%s", url.PathEscape(azureRepo.organization),
+		url.PathEscape(azureRepo.project), url.PathEscape(azureRepo.repo))
+	if err := client.api.get(ctx, path, nil, &repo); err != nil {
+		return forge.RepoError(err, "")
+	}
+	if repo.DefaultBranch == "" {
+		return sce.WithMessage(sce.ErrRepoNotFound, "repo has no default branch")
+	}
+	client.repo = &repo
+
+	if commitSHA == clients.HeadSHA {
+		var refs struct {
+			Value []ref `json:"value"`
+		}
+		// The filter matches refs by prefix.
+		query := url.Values{"filter": {strings.TrimPrefix(repo.DefaultBranch, "refs/")}}
+		if err := client.api.get(ctx, repo.apiPath()+"/git/repositories/"+repo.ID+"/refs",
+			query, &refs); err != nil {
+			return forge.RepoError(err, "default branch")
+		}
+		for _, r := range refs.Value {
+			if r.Name == repo.DefaultBranch {
+				commitSHA = r.ObjectID
+			}
+		}
+		if commitSHA == clients.HeadSHA {
+			return sce.WithMessage(sce.ErrRepoNotFound, fmt.Sprintf("ref %s not found", repo.DefaultBranch))
+		}
+	}
+
+	client.contents.init(&repo, commitSHA)
+	client.commits.init(&repo, commitSHA)
+	client.branches.init(&repo)
+	client.builds.init(&repo)
+	client.statuses.init(&repo)
+	return nil
+}
+
+// WithCallContext implements clients.CallContextClient: the view makes its API
+// calls with ctx and shares the data fetched with the client.
+func (client *Client) WithCallContext(ctx context.Context) clients.RepoClient {
+	view := *client
+	view.ctx = ctx
+	view.origin = client
+	if client.origin != nil {
+		view.origin = client.origin
+	}
+	return &view
+}
+
+// URI implements RepoClient.URI.
+func (client *Client) URI() string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", azureDevOpsHost, client.repo.organization,
+		client.repo.Project.Name, gitSegment, client.repo.Name)
+}
+
+// IsArchived implements RepoClient.IsArchived.
+// Azure Repos cannot be archived.
+func (client *Client) IsArchived() (bool, error) {
+	return false, nil
+}
+
+// IsPrivate implements clients.VisibilityReporter.
+// Repos inherit the visibility of their project.
+func (client *Client) IsPrivate() bool {
+	return client.repo.Project.Visibility == "private"
+}
+
+// SupportsFeature implements clients.FeatureReporter.
+func (client *Client) SupportsFeature(f clients.Feature) bool {
+	return f != clients.FeatureContributors && f != clients.FeatureCheckRuns
+}
+
+// ListFiles implements RepoClient.ListFiles.
+func (client *Client) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
+	return client.contents.listFiles(client.ctx, predicate)
+}
+
+// GetFileContent implements RepoClient.GetFileContent.
+func (client *Client) GetFileContent(filename string) ([]byte, error) {
+	return client.contents.getFileContent(client.ctx, filename)
+}
+
+// ListMergedPRs implements RepoClient.ListMergedPRs.
+func (client *Client) ListMergedPRs() ([]clients.PullRequest, error) {
+	return client.commits.listMergedPRs(client.ctx)
+}
+
+// ListBranches implements RepoClient.ListBranches.
+func (client *Client) ListBranches() ([]*clients.BranchRef, error) {
+	return client.branches.listBranches(client.ctx)
+}
+
+// GetDefaultBranch implements RepoClient.GetDefaultBranch.
+func (client *Client) GetDefaultBranch() (*clients.BranchRef, error) {
+	return client.branches.getDefaultBranch(client.ctx)
+}
+
+// GetBranch implements RepoClient.GetBranch.
+// Azure DevOps branches cannot be renamed.
+func (client *Client) GetBranch(name string) (*clients.BranchRef, error) {
+	branches, err := client.branches.listBranches(client.ctx)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return clients.FindBranch(branches, name)
+}
+
+// ListBranchUpdates implements RepoClient.ListBranchUpdates.
+func (client *Client) ListBranchUpdates(branch string) ([]clients.BranchUpdate, error) {
+	return nil, fmt.Errorf("ListBranchUpdates: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListCommits implements RepoClient.ListCommits.
+func (client *Client) ListCommits() ([]clients.Commit, error) {
+	return client.commits.listCommits(client.ctx)
+}
+
+// ListIssues implements RepoClient.ListIssues.
+// Work items are tracked by Azure Boards per project, not per repo.
+func (client *Client) ListIssues() ([]clients.Issue, error) {
+	return nil, fmt.Errorf("ListIssues: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListReleases implements RepoClient.ListReleases.
+// Successful pipeline runs building tags are reported as releases.
+func (client *Client) ListReleases() ([]clients.Release, error) {
+	return client.builds.getReleases(client.ctx)
+}
+
+// ListTags implements RepoClient.ListTags.
+func (client *Client) ListTags() ([]clients.Tag, error) {
+	return nil, fmt.Errorf("ListTags: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListBranchesForCommit implements RepoClient.ListBranchesForCommit.
+func (client *Client) ListBranchesForCommit(sha string) ([]string, error) {
+	return nil, fmt.Errorf("ListBranchesForCommit: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListContributors implements RepoClient.ListContributors.
+// Azure DevOps has no API listing the contributors of a repo.
+func (client *Client) ListContributors() ([]clients.Contributor, error) {
+	return nil, fmt.Errorf("ListContributors: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListSuccessfulWorkflowRuns implements RepoClient.ListSuccessfulWorkflowRuns.
+func (client *Client) ListSuccessfulWorkflowRuns(filename string) ([]clients.WorkflowRun, error) {
+	return client.builds.listSuccessfulRuns(client.ctx, filename)
+}
+
+// ListCheckRunsForRef implements RepoClient.ListCheckRunsForRef.
+// Azure DevOps reports CI results as statuses, see ListStatuses.
+func (client *Client) ListCheckRunsForRef(ref string) ([]clients.CheckRun, error) {
+	return nil, fmt.Errorf("ListCheckRunsForRef: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListStatuses implements RepoClient.ListStatuses.
+func (client *Client) ListStatuses(ref string) ([]clients.Status, error) {
+	return client.statuses.listStatuses(client.ctx, ref)
+}
+
+// ListAttestations implements RepoClient.ListAttestations.
+func (client *Client) ListAttestations(subjectDigest string) ([]clients.Attestation, error) {
+	return nil, fmt.Errorf("ListAttestations: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListContainerImages implements RepoClient.ListContainerImages.
+func (client *Client) ListContainerImages() ([]clients.ContainerImage, error) {
+	return nil, fmt.Errorf("ListContainerImages: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListSecurityAdvisories implements RepoClient.ListSecurityAdvisories.
+func (client *Client) ListSecurityAdvisories() ([]clients.SecurityAdvisory, error) {
+	return nil, fmt.Errorf("ListSecurityAdvisories: %w", clients.ErrUnsupportedFeature)
+}
+
+// Search implements RepoClient.Search.
+func (client *Client) Search(request clients.SearchRequest) (clients.SearchResponse, error) {
+	return clients.SearchResponse{}, fmt.Errorf("Search: %w", clients.ErrUnsupportedFeature)
+}
+
+// Close implements RepoClient.Close.
+func (client *Client) Close() error {
+	return nil
+}
+
+// CreateAzureDevOpsRepoClient returns a Client which implements RepoClient interface.
+// Requests are authenticated with the personal access token in AZURE_DEVOPS_AUTH_TOKEN, if set.
+func CreateAzureDevOpsRepoClient(ctx context.Context, logger *zap.Logger) clients.RepoClient {
+	return createAzureDevOpsRepoClient(ctx, forge.NewHTTPClient(logger), apiBaseURL)
+}
+
+func createAzureDevOpsRepoClient(ctx context.Context, httpClient *http.Client, baseURL string) *Client {
+	api := newAPIClient(httpClient, baseURL)
+	return &Client{
+		ctx:      ctx,
+		api:      api,
+		contents: &contentsHandler{api: api},
+		commits:  &commitsHandler{api: api},
+		branches: &branchesHandler{api: api},
+		builds:   &buildsHandler{api: api},
+		statuses: &statusesHandler{api: api},
+	}
+}
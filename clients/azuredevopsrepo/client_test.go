@@ -0,0 +1,277 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package azuredevopsrepo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge/forgetest"
+)
+
+const (
+	apiPath  = "/org/project/_apis"
+	repoPath = apiPath + "/git/repositories/id"
+)
+
+// newTestClient returns a client for the repo org/project/_git/repo served by
+// a fake Azure DevOps API answering the given paths with canned JSON.
+// Responses to requests for a path, a continuation token or build definitions
+// are keyed by "path?path=", "path?continuationToken=" or "path?definitions=".
+func newTestClient(t *testing.T, responses map[string]string) clients.RepoClient {
+	t.Helper()
+	server := forgetest.NewServer(t, responses, func(w http.ResponseWriter, r *http.Request) string {
+		key := r.URL.Path
+		for _, param := range []string{"path", "continuationToken", "definitions"} {
+			if v := r.URL.Query().Get(param); v != "" {
+				key += "?" + param + "=" + v
+			}
+		}
+		if next, ok := responses[key+"?continuationToken=next"]; ok && next != "" {
+			w.Header().Set(continuationHeader, "next")
+		}
+		return key
+	})
+
+	client := createAzureDevOpsRepoClient(context.Background(), server.Client(), server.URL)
+	repo := &repoURL{host: azureDevOpsHost, organization: "org", project: "project", repo: "repo"}
+	if err := client.InitRepo(repo, clients.HeadSHA); err != nil {
+		t.Fatalf("InitRepo: %v", err)
+	}
+	return client
+}
+
+var baseResponses = map[string]string{
+	apiPath + "/git/repositories/repo": `{"id": "id", "name": "repo", "defaultBranch": "refs/heads/main",
+		"project": {"name": "project", "visibility": "private"}}`,
+	repoPath + "/refs": `{"value": [
+		{"name": "refs/heads/main", "objectId": "sha2"},
+		{"name": "refs/heads/release/v1", "objectId": "sha3"}]}`,
+	repoPath + "/refs?continuationToken=next": `{"value": [{"name": "refs/heads/feature", "objectId": "sha4"}]}`,
+	repoPath + "/commits": `{"value": [
+		{"commitId": "sha2", "comment": "Merged PR 2: Fix", "author": {"email": "alice@example.com"},
+		 "committer": {"date": "2022-01-02T00:00:00Z"}},
+		{"commitId": "sha1", "comment": "Direct push", "author": {"email": "bob@example.com"},
+		 "committer": {"date": "2022-01-01T00:00:00Z"}}]}`,
+	repoPath + "/pullrequests": `{"value": [
+		{"pullRequestId": 2, "createdBy": {"uniqueName": "bob@example.com"}, "closedDate": "2022-01-02T00:00:00Z",
+		 "lastMergeSourceCommit": {"commitId": "head2"}, "lastMergeCommit": {"commitId": "sha2"},
+		 "reviewers": [
+			{"uniqueName": "alice@example.com", "vote": 10},
+			{"uniqueName": "carol@example.com", "vote": 0}],
+		 "labels": [{"name": "bug"}]}]}`,
+	repoPath + "/items": `{"value": [
+		{"path": "/", "isFolder": true},
+		{"path": "/README.md"},
+		{"path": "/src", "isFolder": true},
+		{"path": "/src/main.go"}]}`,
+	repoPath + "/items?path=/src/main.go": `package main`,
+	repoPath + "/commits/head2/statuses": `{"value": [
+		{"state": "succeeded", "context": {"genre": "continuous-integration", "name": "tests"},
+		 "targetUrl": "https://ci", "creationDate": "2022-01-01T12:00:00Z"}]}`,
+	apiPath + "/build/builds": `{"value": [
+		{"id": 3, "sourceBranch": "refs/heads/main", "sourceVersion": "sha2"},
+		{"id": 2, "sourceBranch": "refs/tags/v1.0.0", "sourceVersion": "sha1",
+		 "_links": {"web": {"href": "https://dev.azure.com/org/project/_build/results?buildId=2"}}}]}`,
+	apiPath + "/build/builds/2/artifacts": `{"value": [
+		{"name": "app.tar.gz", "resource": {"downloadUrl": "https://example.com/app"}},
+		{"name": "app.tar.gz.sig", "resource": {"downloadUrl": "https://example.com/sig"}}]}`,
+	apiPath + "/build/definitions": `{"value": [
+		{"id": 1, "process": {"yamlFilename": "/azure-pipelines.yml"}},
+		{"id": 2, "process": {}}]}`,
+	apiPath + "/build/builds?definitions=1": `{"value": [
+		{"id": 3, "_links": {"web": {"href": "https://dev.azure.com/org/project/_build/results?buildId=3"}}}]}`,
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+	responses := map[string]string{
+		apiPath + "/policy/configurations": `{"value": [
+			{"isEnabled": true, "isBlocking": true, "type": {"id": "` + policyMinimumReviewers + `"},
+			 "settings": {"minimumApproverCount": 2, "resetOnSourcePush": true,
+				"scope": [{"repositoryId": "id", "refName": "refs/heads/main", "matchKind": "Exact"}]}},
+			{"isEnabled": true, "isBlocking": true, "type": {"id": "` + policyBuild + `"},
+			 "settings": {"displayName": "CI",
+				"scope": [{"repositoryId": null, "refName": "refs/heads/main", "matchKind": "Exact"}]}},
+			{"isEnabled": true, "isBlocking": true, "type": {"id": "` + policyMergeStrategy + `"},
+			 "settings": {"allowSquash": true,
+				"scope": [{"repositoryId": "id", "refName": "refs/heads/release/", "matchKind": "Prefix"}]}},
+			{"isEnabled": true, "isBlocking": false, "type": {"id": "` + policyStatus + `"},
+			 "settings": {"statusName": "optional",
+				"scope": [{"repositoryId": "id", "refName": "refs/heads/feature", "matchKind": "Exact"}]}},
+			{"isEnabled": true, "isBlocking": true, "type": {"id": "` + policyMinimumReviewers + `"},
+			 "settings": {"minimumApproverCount": 1,
+				"scope": [{"repositoryId": "other", "refName": "refs/heads/feature", "matchKind": "Exact"}]}}]}`,
+	}
+	for k, v := range baseResponses {
+		responses[k] = v
+	}
+	client := newTestClient(t, responses)
+
+	if reporter, ok := client.(clients.VisibilityReporter); !ok || !reporter.IsPrivate() {
+		t.Error("IsPrivate: got false")
+	}
+
+	branches, err := client.ListBranches()
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	trueVal, falseVal := true, false
+	var zero, two int32 = 0, 2
+	main, release, feature := "main", "release/v1", "feature"
+	expectedBranches := []*clients.BranchRef{
+		{
+			Name:      &main,
+			Protected: &trueVal,
+			BranchProtectionRule: clients.BranchProtectionRule{
+				AllowDeletions:       &falseVal,
+				AllowForcePushes:     &falseVal,
+				RequireLinearHistory: &falseVal,
+				CheckRules: clients.StatusChecksRule{
+					RequiresStatusChecks: &trueVal,
+					Contexts:             []string{"CI"},
+				},
+				RequiredPullRequestReviews: clients.PullRequestReviewRule{
+					RequiredApprovingReviewCount: &two,
+					DismissStaleReviews:          &trueVal,
+					RequireCodeOwnerReviews:      &falseVal,
+				},
+			},
+		},
+		{
+			Name:      &release,
+			Protected: &trueVal,
+			BranchProtectionRule: clients.BranchProtectionRule{
+				AllowDeletions:       &falseVal,
+				AllowForcePushes:     &falseVal,
+				RequireLinearHistory: &trueVal,
+				CheckRules: clients.StatusChecksRule{
+					RequiresStatusChecks: &falseVal,
+				},
+				RequiredPullRequestReviews: clients.PullRequestReviewRule{
+					RequiredApprovingReviewCount: &zero,
+					DismissStaleReviews:          &falseVal,
+					RequireCodeOwnerReviews:      &falseVal,
+				},
+			},
+		},
+		{
+			// Optional policies and policies of other repos do not protect the branch.
+			Name:      &feature,
+			Protected: &falseVal,
+		},
+	}
+	if diff := cmp.Diff(expectedBranches, branches); diff != "" {
+		t.Errorf("ListBranches mismatch (-want +got):\n%s", diff)
+	}
+	if b, err := client.GetDefaultBranch(); err != nil || *b.Name != "main" {
+		t.Errorf("GetDefaultBranch: got %v, %v", b, err)
+	}
+
+	commits, err := client.ListCommits()
+	if err != nil {
+		t.Fatalf("ListCommits: %v", err)
+	}
+	if len(commits) != 2 || commits[0].SHA != "sha2" || commits[0].Author.Login != "alice@example.com" ||
+		commits[0].AssociatedMergeRequest == nil || commits[0].AssociatedMergeRequest.Number != 2 ||
+		commits[1].AssociatedMergeRequest != nil {
+		t.Errorf("ListCommits: unexpected commits %+v", commits)
+	}
+
+	prs, err := client.ListMergedPRs()
+	if err != nil {
+		t.Fatalf("ListMergedPRs: %v", err)
+	}
+	if len(prs) != 1 || prs[0].HeadSHA != "head2" || len(prs[0].Labels) != 1 ||
+		len(prs[0].Reviews) != 1 || prs[0].Reviews[0].State != "APPROVED" {
+		t.Errorf("ListMergedPRs: unexpected PRs %+v", prs)
+	}
+
+	files, err := client.ListFiles(func(string) (bool, error) { return true, nil })
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+	if diff := cmp.Diff([]string{"README.md", "src/main.go"}, files); diff != "" {
+		t.Errorf("ListFiles mismatch (-want +got):\n%s", diff)
+	}
+	content, err := client.GetFileContent("src/main.go")
+	if err != nil || string(content) != "package main" {
+		t.Errorf("GetFileContent: got %q, %v", content, err)
+	}
+	if _, err := client.GetFileContent("missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("GetFileContent: got %v, expected %v", err, os.ErrNotExist)
+	}
+
+	releases, err := client.ListReleases()
+	if err != nil {
+		t.Fatalf("ListReleases: %v", err)
+	}
+	expectedReleases := []clients.Release{
+		{
+			TagName:         "v1.0.0",
+			URL:             "https://dev.azure.com/org/project/_build/results?buildId=2",
+			TargetCommitish: "sha1",
+			Assets: []clients.ReleaseAsset{
+				{Name: "app.tar.gz", URL: "https://example.com/app"},
+				{Name: "app.tar.gz.sig", URL: "https://example.com/sig"},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedReleases, releases); diff != "" {
+		t.Errorf("ListReleases mismatch (-want +got):\n%s", diff)
+	}
+
+	statuses, err := client.ListStatuses("head2")
+	if err != nil {
+		t.Fatalf("ListStatuses: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].State != "success" ||
+		statuses[0].Context != "continuous-integration/tests" || statuses[0].UpdatedAt.IsZero() {
+		t.Errorf("ListStatuses: unexpected statuses %+v", statuses)
+	}
+
+	runs, err := client.ListSuccessfulWorkflowRuns("azure-pipelines.yml")
+	if err != nil {
+		t.Fatalf("ListSuccessfulWorkflowRuns: %v", err)
+	}
+	expectedRuns := []clients.WorkflowRun{{URL: "https://dev.azure.com/org/project/_build/results?buildId=3"}}
+	if diff := cmp.Diff(expectedRuns, runs); diff != "" {
+		t.Errorf("ListSuccessfulWorkflowRuns mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := client.ListIssues(); !errors.Is(err, clients.ErrUnsupportedFeature) {
+		t.Errorf("ListIssues: got %v, expected %v", err, clients.ErrUnsupportedFeature)
+	}
+}
+
+func TestClient_NoPolicyAccess(t *testing.T) {
+	t.Parallel()
+	// policy/configurations is not served, as for tokens without access to policies.
+	client := newTestClient(t, baseResponses)
+
+	b, err := client.GetDefaultBranch()
+	if err != nil {
+		t.Fatalf("GetDefaultBranch: %v", err)
+	}
+	main := "main"
+	if diff := cmp.Diff(&clients.BranchRef{Name: &main}, b); diff != "" {
+		t.Errorf("GetDefaultBranch mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package azuredevopsrepo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const (
+	commitsToAnalyze      = 30
+	pullRequestsToAnalyze = 30
+)
+
+// Votes of reviewers.
+const (
+	voteApproved            = 10
+	voteApprovedSuggestions = 5
+	voteRejected            = -10
+)
+
+type identity struct {
+	UniqueName string `json:"uniqueName"`
+}
+
+type commit struct {
+	CommitID string `json:"commitId"`
+	// Comment is truncated to the first lines of the message.
+	Comment string `json:"comment"`
+	Author  struct {
+		Email string `json:"email"`
+	} `json:"author"`
+	Committer struct {
+		Date time.Time `json:"date"`
+	} `json:"committer"`
+}
+
+type pullRequest struct {
+	PullRequestID         int       `json:"pullRequestId"`
+	CreatedBy             identity  `json:"createdBy"`
+	ClosedDate            time.Time `json:"closedDate"`
+	LastMergeSourceCommit *struct {
+		CommitID string `json:"commitId"`
+	} `json:"lastMergeSourceCommit"`
+	// LastMergeCommit is the commit merged into the target branch.
+	LastMergeCommit *struct {
+		CommitID string `json:"commitId"`
+	} `json:"lastMergeCommit"`
+	Reviewers []struct {
+		identity
+		Vote int `json:"vote"`
+	} `json:"reviewers"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// commitsHandler lists the latest commits and merged PRs of the repo.
+type commitsHandler struct {
+	api       *apiClient
+	base      string
+	commitSHA string
+	commits   *forge.Commits
+}
+
+func (handler *commitsHandler) init(r *repository, commitSHA string) {
+	handler.base = r.apiPath() + "/git/repositories/" + r.ID
+	handler.commitSHA = commitSHA
+	handler.commits = forge.NewCommits(handler.list)
+}
+
+func (handler *commitsHandler) list(ctx context.Context) ([]clients.Commit, []clients.PullRequest, error) {
+	query := url.Values{
+		"searchCriteria.itemVersion.version":     {handler.commitSHA},
+		"searchCriteria.itemVersion.versionType": {"commit"},
+		"searchCriteria.$top":                    {strconv.Itoa(commitsToAnalyze)},
+	}
+	var commits struct {
+		Value []commit `json:"value"`
+	}
+	if err := handler.api.get(ctx, handler.base+"/commits", query, &commits); err != nil {
+		return nil, nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list commits: %v", err))
+	}
+
+	// Completed pull requests are merged, abandoned ones are not.
+	query = url.Values{
+		"searchCriteria.status": {"completed"},
+		"$top":                  {strconv.Itoa(pullRequestsToAnalyze)},
+	}
+	var prs struct {
+		Value []pullRequest `json:"value"`
+	}
+	if err := handler.api.get(ctx, handler.base+"/pullrequests", query, &prs); err != nil {
+		return nil, nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list pullrequests: %v", err))
+	}
+
+	var merged []clients.PullRequest
+	for i := range prs.Value {
+		merged = append(merged, pullRequestFrom(&prs.Value[i]))
+	}
+	return commitsFrom(commits.Value, merged), merged, nil
+}
+
+func (handler *commitsHandler) listCommits(ctx context.Context) ([]clients.Commit, error) {
+	return handler.commits.ListCommits(ctx)
+}
+
+func (handler *commitsHandler) listMergedPRs(ctx context.Context) ([]clients.PullRequest, error) {
+	return handler.commits.ListMergedPRs(ctx)
+}
+
+func pullRequestFrom(pr *pullRequest) clients.PullRequest {
+	ret := clients.PullRequest{
+		Number:   pr.PullRequestID,
+		MergedAt: pr.ClosedDate,
+		Author:   clients.User{Login: pr.CreatedBy.UniqueName},
+	}
+	if pr.LastMergeSourceCommit != nil {
+		ret.HeadSHA = pr.LastMergeSourceCommit.CommitID
+	}
+	if pr.LastMergeCommit != nil {
+		ret.MergeCommit.SHA = pr.LastMergeCommit.CommitID
+	}
+	for _, l := range pr.Labels {
+		ret.Labels = append(ret.Labels, clients.Label{Name: l.Name})
+	}
+	for _, r := range pr.Reviewers {
+		var state string
+		switch r.Vote {
+		case voteApproved, voteApprovedSuggestions:
+			state = "APPROVED"
+		case voteRejected:
+			state = "CHANGES_REQUESTED"
+		default:
+			continue
+		}
+		ret.Reviews = append(ret.Reviews, clients.Review{
+			State:  state,
+			Author: clients.User{Login: r.UniqueName},
+		})
+	}
+	return ret
+}
+
+// commitsFrom converts the commits, associating them with the merged PRs
+// whose merge commit they are.
+func commitsFrom(commits []commit, prs []clients.PullRequest) []clients.Commit {
+	ret := []clients.Commit{}
+	for i := range commits {
+		c := &commits[i]
+		ret = append(ret, clients.Commit{
+			CommittedDate: c.Committer.Date,
+			Message:       c.Comment,
+			SHA:           c.CommitID,
+			// Commits are attributed to emails, not to users.
+			Author:                 clients.User{Login: c.Author.Email},
+			AssociatedMergeRequest: forge.MergedBy(c.CommitID, prs),
+		})
+	}
+	return ret
+}
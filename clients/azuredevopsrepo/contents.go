@@ -0,0 +1,85 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package azuredevopsrepo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+type item struct {
+	// Path is absolute, e.g., "/README.md".
+	Path     string `json:"path"`
+	IsFolder bool   `json:"isFolder"`
+}
+
+// contentsHandler lists the files of the repo at a commit and reads their content.
+type contentsHandler struct {
+	api       *apiClient
+	base      string
+	commitSHA string
+	files     *forge.Files
+}
+
+func (handler *contentsHandler) init(r *repository, commitSHA string) {
+	handler.base = r.apiPath() + "/git/repositories/" + r.ID
+	handler.commitSHA = commitSHA
+	handler.files = forge.NewFiles(handler.listItems, handler.readFile)
+}
+
+func (handler *contentsHandler) versionQuery() url.Values {
+	return url.Values{
+		"versionDescriptor.version":     {handler.commitSHA},
+		"versionDescriptor.versionType": {"commit"},
+	}
+}
+
+func (handler *contentsHandler) listItems(ctx context.Context) ([]string, error) {
+	query := handler.versionQuery()
+	query.Set("recursionLevel", "Full")
+	var items struct {
+		Value []item `json:"value"`
+	}
+	if err := handler.api.get(ctx, handler.base+"/items", query, &items); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list items: %v", err))
+	}
+	var files []string
+	for _, i := range items.Value {
+		if !i.IsFolder {
+			files = append(files, strings.TrimPrefix(i.Path, "/"))
+		}
+	}
+	return files, nil
+}
+
+func (handler *contentsHandler) readFile(ctx context.Context, filename string) ([]byte, error) {
+	query := handler.versionQuery()
+	query.Set("path", "/"+filename)
+	query.Set("$format", "octetStream")
+	content, _, err := handler.api.do(ctx, handler.base+"/items", query)
+	return content, err
+}
+
+func (handler *contentsHandler) listFiles(ctx context.Context, predicate func(string) (bool, error)) ([]string, error) {
+	return handler.files.List(ctx, predicate)
+}
+
+func (handler *contentsHandler) getFileContent(ctx context.Context, filename string) ([]byte, error) {
+	return handler.files.Read(ctx, filename)
+}
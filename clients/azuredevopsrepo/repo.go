@@ -0,0 +1,115 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package azuredevopsrepo
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const (
+	azureDevOpsHost = "dev.azure.com"
+	// Path segment preceding the name of a repo in the URLs of Azure Repos.
+	gitSegment = "_git"
+)
+
+type repoURL struct {
+	host, organization, project, repo string
+	metadata                          []string
+}
+
+// Parses input string into repoURL struct.
+// Accepts "dev.azure.com/organization/project/_git/repo", with an optional scheme.
+func (r *repoURL) parse(input string) error {
+	t := input
+	// Allow skipping scheme for ease-of-use, default to https.
+	if !strings.Contains(t, "://") {
+		t = "https://" + t
+	}
+
+	u, e := url.Parse(t)
+	if e != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("url.Parse: %v", e))
+	}
+
+	const splitLen = 4
+	split := strings.SplitN(strings.Trim(u.Path, "/"), "/", splitLen)
+	if len(split) != splitLen || split[2] != gitSegment {
+		return sce.WithMessage(sce.ErrorInvalidURL, fmt.Sprintf("%v. Expected full repository url", input))
+	}
+
+	r.host, r.organization, r.project, r.repo = u.Host, split[0], split[1], split[3]
+	return nil
+}
+
+// URI implements Repo.URI().
+func (r *repoURL) URI() string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", r.host, r.organization, r.project, gitSegment, r.repo)
+}
+
+// String implements Repo.String.
+func (r *repoURL) String() string {
+	return fmt.Sprintf("%s-%s-%s-%s", r.host, r.organization, r.project, r.repo)
+}
+
+// Org implements Repo.Org.
+// Azure DevOps has no repo shared by the repos of an organization,
+// so the organization itself is returned.
+func (r *repoURL) Org() clients.Repo {
+	return &repoURL{
+		host:         r.host,
+		organization: r.organization,
+	}
+}
+
+// IsValid implements Repo.IsValid.
+func (r *repoURL) IsValid() error {
+	if !strings.EqualFold(r.host, azureDevOpsHost) {
+		return sce.WithMessage(sce.ErrRepoUnsupportedHost, r.host)
+	}
+
+	if strings.TrimSpace(r.organization) == "" || strings.TrimSpace(r.project) == "" ||
+		strings.TrimSpace(r.repo) == "" || strings.Contains(r.repo, "/") {
+		return sce.WithMessage(sce.ErrorInvalidURL,
+			fmt.Sprintf("%v. Expected the full repository url", r.URI()))
+	}
+	return nil
+}
+
+// AppendMetadata implements Repo.AppendMetadata.
+func (r *repoURL) AppendMetadata(metadata ...string) {
+	r.metadata = append(r.metadata, metadata...)
+}
+
+// Metadata implements Repo.Metadata.
+func (r *repoURL) Metadata() []string {
+	return r.metadata
+}
+
+// MakeAzureDevOpsRepo takes input of form "dev.azure.com/organization/project/_git/repo"
+// and returns an implementation of clients.Repo interface.
+func MakeAzureDevOpsRepo(input string) (clients.Repo, error) {
+	var repo repoURL
+	if err := repo.parse(input); err != nil {
+		return nil, fmt.Errorf("error during parse: %w", err)
+	}
+	if err := repo.IsValid(); err != nil {
+		return nil, fmt.Errorf("error in IsValid: %w", err)
+	}
+	return &repo, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package azuredevopsrepo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// States of Azure DevOps statuses, mapped onto the states of GitHub statuses.
+var statusStates = map[string]string{
+	"succeeded": "success",
+	"failed":    "failure",
+	"pending":   "pending",
+	"error":     "error",
+}
+
+type status struct {
+	State   string `json:"state"`
+	Context struct {
+		Genre string `json:"genre"`
+		Name  string `json:"name"`
+	} `json:"context"`
+	URL          string    `json:"url"`
+	TargetURL    string    `json:"targetUrl"`
+	CreationDate time.Time `json:"creationDate"`
+	UpdatedDate  time.Time `json:"updatedDate"`
+}
+
+type statusesHandler struct {
+	api  *apiClient
+	base string
+}
+
+func (handler *statusesHandler) init(r *repository) {
+	handler.base = r.apiPath() + "/git/repositories/" + r.ID
+}
+
+func (handler *statusesHandler) listStatuses(ctx context.Context, ref string) ([]clients.Status, error) {
+	path := fmt.Sprintf("%s/commits/%s/statuses", handler.base, url.PathEscape(ref))
+	var statuses struct {
+		Value []status `json:"value"`
+	}
+	if err := handler.api.get(ctx, path, nil, &statuses); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list statuses: %v", err))
+	}
+	ret := []clients.Status{}
+	for _, s := range statuses.Value {
+		// The context of statuses is "genre/name", as in status policies.
+		name := s.Context.Name
+		if s.Context.Genre != "" {
+			name = s.Context.Genre + "/" + name
+		}
+		updatedAt := s.UpdatedDate
+		if updatedAt.IsZero() {
+			updatedAt = s.CreationDate
+		}
+		ret = append(ret, clients.Status{
+			UpdatedAt: updatedAt,
+			State:     statusStates[s.State],
+			Context:   name,
+			URL:       s.URL,
+			TargetURL: s.TargetURL,
+		})
+	}
+	return ret, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bitbucketrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+)
+
+const (
+	apiBaseURL = "https://api.bitbucket.org/2.0"
+	// Repository or workspace access token.
+	tokenEnv = "BITBUCKET_AUTH_TOKEN"
+	// Username and app password, used if no access token is set.
+	usernameEnv    = "BITBUCKET_USERNAME"
+	appPasswordEnv = "BITBUCKET_APP_PASSWORD"
+	// Number of items requested per page of list APIs.
+	pageSize = 50
+)
+
+// page is a page of the results of a list API.
+// See https://developer.atlassian.com/cloud/bitbucket/rest/intro/#pagination.
+type page struct {
+	Values json.RawMessage `json:"values"`
+	// Next is the URL of the next page, empty on the last page.
+	Next string `json:"next"`
+}
+
+// apiClient calls the Bitbucket Cloud REST API.
+type apiClient struct {
+	client  *forge.Client
+	baseURL string
+}
+
+func newAPIClient(httpClient *http.Client, baseURL string) *apiClient {
+	token := os.Getenv(tokenEnv)
+	username := os.Getenv(usernameEnv)
+	appPassword := os.Getenv(appPasswordEnv)
+	return &apiClient{
+		client: forge.NewClient(httpClient, func(req *http.Request) {
+			switch {
+			case token != "":
+				req.Header.Set("Authorization", "Bearer "+token)
+			case username != "":
+				req.SetBasicAuth(username, appPassword)
+			}
+		}),
+		baseURL: baseURL,
+	}
+}
+
+func (c *apiClient) requestURL(path string, query url.Values) string {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// do sends a GET request for path and returns the response body.
+func (c *apiClient) do(ctx context.Context, path string, query url.Values) ([]byte, error) {
+	body, _, err := c.client.Get(ctx, c.requestURL(path, query))
+	return body, err
+}
+
+// get decodes the JSON response for path into v.
+func (c *apiClient) get(ctx context.Context, path string, query url.Values, v interface{}) error {
+	return c.client.GetJSON(ctx, c.requestURL(path, query), v)
+}
+
+// list follows the next links of a paginated API until the last page or
+// until max items were read. appendPage decodes the values of a page and
+// returns their number.
+func (c *apiClient) list(ctx context.Context, path string, query url.Values, max int,
+	appendPage func(values []byte) (int, error)) error {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	q.Set("pagelen", strconv.Itoa(pageSize))
+	u := c.requestURL(path, q)
+	for read := 0; u != "" && read < max; {
+		body, _, err := c.client.Get(ctx, u)
+		if err != nil {
+			return err
+		}
+		var p page
+		if err := json.Unmarshal(body, &p); err != nil {
+			return fmt.Errorf("json.Unmarshal: %w", err)
+		}
+		n, err := appendPage(p.Values)
+		if err != nil {
+			return err
+		}
+		read += n
+		u = p.Next
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bitbucketrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// Maximum number of branches and branch restrictions listed.
+const (
+	branchesToAnalyze     = 500
+	restrictionsToAnalyze = 500
+)
+
+// Kinds of branch restrictions.
+// See https://support.atlassian.com/bitbucket-cloud/docs/use-branch-permissions/.
+const (
+	kindForce              = "force"
+	kindDelete             = "delete"
+	kindEnforceMergeChecks = "enforce_merge_checks"
+	kindApprovals          = "require_approvals_to_merge"
+	kindPassingBuilds      = "require_passing_builds_to_merge"
+	kindResetApprovals     = "reset_pullrequest_approvals_on_change"
+	kindSmartReset         = "smart_reset_pullrequest_approvals"
+)
+
+type branch struct {
+	Name string `json:"name"`
+}
+
+// branchRestriction is a branch permission or a merge check.
+type branchRestriction struct {
+	Kind string `json:"kind"`
+	// BranchMatchKind is "glob" for Pattern, or "branching_model" for BranchType.
+	BranchMatchKind string `json:"branch_match_kind"`
+	Pattern         string `json:"pattern"`
+	BranchType      string `json:"branch_type"`
+	// Value is the number of approvals or builds required by merge checks.
+	Value *int32 `json:"value"`
+}
+
+// branchingModel maps branch types onto branches.
+type branchingModel struct {
+	Development *struct {
+		Branch *branch `json:"branch"`
+	} `json:"development"`
+	Production *struct {
+		Branch *branch `json:"branch"`
+	} `json:"production"`
+	BranchTypes []struct {
+		Kind   string `json:"kind"`
+		Prefix string `json:"prefix"`
+	} `json:"branch_types"`
+}
+
+// branchType returns the type of the branch in the branching model, or "".
+func (m *branchingModel) branchType(name string) string {
+	switch {
+	case m.Development != nil && m.Development.Branch != nil && m.Development.Branch.Name == name:
+		return "development"
+	case m.Production != nil && m.Production.Branch != nil && m.Production.Branch.Name == name:
+		return "production"
+	}
+	for _, t := range m.BranchTypes {
+		if t.Prefix != "" && strings.HasPrefix(name, t.Prefix) {
+			return t.Kind
+		}
+	}
+	return ""
+}
+
+type branchesHandler struct {
+	api           *apiClient
+	once          *forge.Once
+	workspace     string
+	repo          string
+	defaultBranch string
+	branches      []*clients.BranchRef
+}
+
+func (handler *branchesHandler) init(workspace, repo, defaultBranch string) {
+	handler.workspace = workspace
+	handler.repo = repo
+	handler.defaultBranch = defaultBranch
+	handler.once = new(forge.Once)
+}
+
+func (handler *branchesHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		base := fmt.Sprintf("/repositories/%s/%s", handler.workspace, handler.repo)
+		var branches []branch
+		err := handler.api.list(ctx, base+"/refs/branches", nil, branchesToAnalyze,
+			func(values []byte) (int, error) {
+				var page []branch
+				if err := json.Unmarshal(values, &page); err != nil {
+					return 0, fmt.Errorf("json.Unmarshal: %w", err)
+				}
+				branches = append(branches, page...)
+				return len(page), nil
+			})
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list branches: %v", err))
+		}
+
+		var restrictions []branchRestriction
+		err = handler.api.list(ctx, base+"/branch-restrictions", nil, restrictionsToAnalyze,
+			func(values []byte) (int, error) {
+				var page []branchRestriction
+				if err := json.Unmarshal(values, &page); err != nil {
+					return 0, fmt.Errorf("json.Unmarshal: %w", err)
+				}
+				restrictions = append(restrictions, page...)
+				return len(page), nil
+			})
+		switch {
+		// Reading branch restrictions requires admin access to the repo.
+		// Without it, nothing is known about the protection of branches.
+		case forge.HasStatus(err, http.StatusForbidden, http.StatusNotFound, http.StatusUnauthorized):
+			handler.branches = nil
+			for i := range branches {
+				name := branches[i].Name
+				handler.branches = append(handler.branches, &clients.BranchRef{Name: &name})
+			}
+			return nil
+		case err != nil:
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list branch restrictions: %v", err))
+		}
+
+		model := &branchingModel{}
+		if usesBranchingModel(restrictions) {
+			if err := handler.api.get(ctx, base+"/branching-model", nil, model); err != nil {
+				return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("get branching model: %v", err))
+			}
+		}
+
+		handler.branches = nil
+		for i := range branches {
+			handler.branches = append(handler.branches,
+				branchFrom(branches[i].Name, matchRestrictions(branches[i].Name, restrictions, model)))
+		}
+		return nil
+	})
+}
+
+func (handler *branchesHandler) listBranches(ctx context.Context) ([]*clients.BranchRef, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during branchesHandler.setup: %w", err)
+	}
+	return handler.branches, nil
+}
+
+func (handler *branchesHandler) getDefaultBranch(ctx context.Context) (*clients.BranchRef, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during branchesHandler.setup: %w", err)
+	}
+	for _, b := range handler.branches {
+		if *b.Name == handler.defaultBranch {
+			return b, nil
+		}
+	}
+	return nil, sce.WithMessage(sce.ErrScorecardInternal,
+		fmt.Sprintf("default branch %s not found", handler.defaultBranch))
+}
+
+func usesBranchingModel(restrictions []branchRestriction) bool {
+	for i := range restrictions {
+		if restrictions[i].BranchMatchKind == "branching_model" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRestrictions returns the restrictions applying to a branch, by kind.
+// If several restrictions of a kind apply, the strictest value is kept.
+func matchRestrictions(name string, restrictions []branchRestriction,
+	model *branchingModel) map[string]*branchRestriction {
+	ret := make(map[string]*branchRestriction)
+	for i := range restrictions {
+		r := &restrictions[i]
+		var matched bool
+		if r.BranchMatchKind == "branching_model" {
+			matched = r.BranchType != "" && r.BranchType == model.branchType(name)
+		} else {
+			matched, _ = path.Match(r.Pattern, name)
+		}
+		if !matched {
+			continue
+		}
+		if prev, ok := ret[r.Kind]; ok && value(prev) >= value(r) {
+			continue
+		}
+		ret[r.Kind] = r
+	}
+	return ret
+}
+
+func value(r *branchRestriction) int32 {
+	if r == nil || r.Value == nil {
+		return 0
+	}
+	return *r.Value
+}
+
+// branchFrom maps the Bitbucket restrictions of a branch onto the settings of
+// a GitHub protection rule. Merge checks, i.e., required approvals and builds,
+// only block merges if enforce_merge_checks applies too, which requires
+// a Premium plan; otherwise they are only warnings and not reported.
+func branchFrom(name string, restrictions map[string]*branchRestriction) *clients.BranchRef {
+	protected := len(restrictions) > 0
+	ret := &clients.BranchRef{
+		Name:      &name,
+		Protected: &protected,
+	}
+	if !protected {
+		return ret
+	}
+
+	rule := &ret.BranchProtectionRule
+	allowForcePushes := restrictions[kindForce] == nil
+	rule.AllowForcePushes = &allowForcePushes
+	allowDeletions := restrictions[kindDelete] == nil
+	rule.AllowDeletions = &allowDeletions
+
+	enforced := restrictions[kindEnforceMergeChecks] != nil
+	var approvals int32
+	if enforced {
+		approvals = value(restrictions[kindApprovals])
+	}
+	dismissStale := restrictions[kindResetApprovals] != nil || restrictions[kindSmartReset] != nil
+	rule.RequiredPullRequestReviews = clients.PullRequestReviewRule{
+		RequiredApprovingReviewCount: &approvals,
+		DismissStaleReviews:          &dismissStale,
+	}
+
+	// Bitbucket requires a number of passing builds, not specific ones,
+	// so there are no contexts to report.
+	requiresStatusChecks := enforced && value(restrictions[kindPassingBuilds]) > 0
+	upToDate := false
+	rule.CheckRules = clients.StatusChecksRule{
+		RequiresStatusChecks: &requiresStatusChecks,
+		UpToDateBeforeMerge:  &upToDate,
+	}
+	return ret
+}
@@ -0,0 +1,278 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package bitbucketrepo implements clients.RepoClient for Bitbucket Cloud.
+package bitbucketrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+var errInputRepoType = errors.New("input repo should be of type repoURL")
+
+type repository struct {
+	Slug      string `json:"slug"`
+	Workspace struct {
+		Slug string `json:"slug"`
+	} `json:"workspace"`
+	MainBranch *struct {
+		Name string `json:"name"`
+	} `json:"mainbranch"`
+	IsPrivate bool `json:"is_private"`
+}
+
+// Client is Bitbucket-specific implementation of RepoClient.
+type Client struct {
+	workspace string
+	repo      string
+	private   bool
+	api       *apiClient
+	contents  *contentsHandler
+	commits   *commitsHandler
+	branches  *branchesHandler
+	downloads *downloadsHandler
+	issues    *issuesHandler
+	statuses  *statusesHandler
+	ctx       context.Context
+	// origin is the Client a view returned by WithCallContext was made from.
+	origin *Client
+}
+
+// InitRepo sets up the Bitbucket repo.
+func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string) error {
+	if client.origin != nil {
+		return client.origin.initRepo(client.ctx, inputRepo, commitSHA)
+	}
+	return client.initRepo(client.ctx, inputRepo, commitSHA)
+}
+
+func (client *Client) initRepo(ctx context.Context, inputRepo clients.Repo, commitSHA string) error {
+	bitbucketRepo, ok := inputRepo.(*repoURL)
+	if !ok {
+		return fmt.Errorf("%w: %v", errInputRepoType, inputRepo)
+	}
+
+	// Sanity check.
+	var repo repository
+	path := fmt.Sprintf("/repositories/%s/%s", bitbucketRepo.workspace, bitbucketRepo.repo)
+	if err := client.api.get(ctx, path, nil, &repo); err != nil {
+		return forge.RepoError(err, "")
+	}
+	// Empty repos have no main branch.
+	if repo.MainBranch == nil {
+		return sce.WithMessage(sce.ErrRepoNotFound, "repo has no main branch")
+	}
+	client.workspace, client.repo = repo.Workspace.Slug, repo.Slug
+	client.private = repo.IsPrivate
+
+	if commitSHA == clients.HeadSHA {
+		var b struct {
+			Target struct {
+				Hash string `json:"hash"`
+			} `json:"target"`
+		}
+		path := fmt.Sprintf("/repositories/%s/%s/refs/branches/%s",
+			client.workspace, client.repo, url.PathEscape(repo.MainBranch.Name))
+		if err := client.api.get(ctx, path, nil, &b); err != nil {
+			return forge.RepoError(err, "main branch")
+		}
+		commitSHA = b.Target.Hash
+	}
+
+	client.contents.init(client.workspace, client.repo, commitSHA)
+	client.commits.init(client.workspace, client.repo, commitSHA)
+	client.branches.init(client.workspace, client.repo, repo.MainBranch.Name)
+	client.downloads.init(client.workspace, client.repo)
+	client.issues.init(client.workspace, client.repo)
+	client.statuses.init(client.workspace, client.repo)
+	return nil
+}
+
+// WithCallContext implements clients.CallContextClient: the view makes its API
+// calls with ctx and shares the data fetched with the client.
+func (client *Client) WithCallContext(ctx context.Context) clients.RepoClient {
+	view := *client
+	view.ctx = ctx
+	view.origin = client
+	if client.origin != nil {
+		view.origin = client.origin
+	}
+	return &view
+}
+
+// URI implements RepoClient.URI.
+func (client *Client) URI() string {
+	return fmt.Sprintf("%s/%s/%s", bitbucketHost, client.workspace, client.repo)
+}
+
+// IsArchived implements RepoClient.IsArchived.
+// Bitbucket Cloud repos cannot be archived.
+func (client *Client) IsArchived() (bool, error) {
+	return false, nil
+}
+
+// IsPrivate implements clients.VisibilityReporter.
+func (client *Client) IsPrivate() bool {
+	return client.private
+}
+
+// SupportsFeature implements clients.FeatureReporter.
+func (client *Client) SupportsFeature(f clients.Feature) bool {
+	return f != clients.FeatureContributors && f != clients.FeatureCheckRuns
+}
+
+// ListFiles implements RepoClient.ListFiles.
+func (client *Client) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
+	return client.contents.listFiles(client.ctx, predicate)
+}
+
+// GetFileContent implements RepoClient.GetFileContent.
+func (client *Client) GetFileContent(filename string) ([]byte, error) {
+	return client.contents.getFileContent(client.ctx, filename)
+}
+
+// ListMergedPRs implements RepoClient.ListMergedPRs.
+func (client *Client) ListMergedPRs() ([]clients.PullRequest, error) {
+	return client.commits.listMergedPRs(client.ctx)
+}
+
+// ListBranches implements RepoClient.ListBranches.
+func (client *Client) ListBranches() ([]*clients.BranchRef, error) {
+	return client.branches.listBranches(client.ctx)
+}
+
+// GetDefaultBranch implements RepoClient.GetDefaultBranch.
+func (client *Client) GetDefaultBranch() (*clients.BranchRef, error) {
+	return client.branches.getDefaultBranch(client.ctx)
+}
+
+// GetBranch implements RepoClient.GetBranch.
+// Bitbucket branches cannot be renamed.
+func (client *Client) GetBranch(name string) (*clients.BranchRef, error) {
+	branches, err := client.branches.listBranches(client.ctx)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return clients.FindBranch(branches, name)
+}
+
+// ListBranchUpdates implements RepoClient.ListBranchUpdates.
+func (client *Client) ListBranchUpdates(branch string) ([]clients.BranchUpdate, error) {
+	return nil, fmt.Errorf("ListBranchUpdates: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListCommits implements RepoClient.ListCommits.
+func (client *Client) ListCommits() ([]clients.Commit, error) {
+	return client.commits.listCommits(client.ctx)
+}
+
+// ListIssues implements RepoClient.ListIssues.
+func (client *Client) ListIssues() ([]clients.Issue, error) {
+	return client.issues.listIssues(client.ctx)
+}
+
+// ListReleases implements RepoClient.ListReleases.
+// Each artifact on the Downloads page of the repo is reported as a release.
+func (client *Client) ListReleases() ([]clients.Release, error) {
+	return client.downloads.getReleases(client.ctx)
+}
+
+// ListTags implements RepoClient.ListTags.
+func (client *Client) ListTags() ([]clients.Tag, error) {
+	return nil, fmt.Errorf("ListTags: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListBranchesForCommit implements RepoClient.ListBranchesForCommit.
+func (client *Client) ListBranchesForCommit(sha string) ([]string, error) {
+	return nil, fmt.Errorf("ListBranchesForCommit: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListContributors implements RepoClient.ListContributors.
+// Bitbucket has no API listing the contributors of a repo.
+func (client *Client) ListContributors() ([]clients.Contributor, error) {
+	return nil, fmt.Errorf("ListContributors: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListSuccessfulWorkflowRuns implements RepoClient.ListSuccessfulWorkflowRuns.
+// Only bitbucket-pipelines.yml has runs.
+func (client *Client) ListSuccessfulWorkflowRuns(filename string) ([]clients.WorkflowRun, error) {
+	return client.statuses.listSuccessfulPipelines(client.ctx, filename)
+}
+
+// ListCheckRunsForRef implements RepoClient.ListCheckRunsForRef.
+// Bitbucket reports CI results as build statuses, see ListStatuses.
+func (client *Client) ListCheckRunsForRef(ref string) ([]clients.CheckRun, error) {
+	return nil, fmt.Errorf("ListCheckRunsForRef: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListStatuses implements RepoClient.ListStatuses.
+func (client *Client) ListStatuses(ref string) ([]clients.Status, error) {
+	return client.statuses.listStatuses(client.ctx, ref)
+}
+
+// ListAttestations implements RepoClient.ListAttestations.
+func (client *Client) ListAttestations(subjectDigest string) ([]clients.Attestation, error) {
+	return nil, fmt.Errorf("ListAttestations: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListContainerImages implements RepoClient.ListContainerImages.
+func (client *Client) ListContainerImages() ([]clients.ContainerImage, error) {
+	return nil, fmt.Errorf("ListContainerImages: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListSecurityAdvisories implements RepoClient.ListSecurityAdvisories.
+func (client *Client) ListSecurityAdvisories() ([]clients.SecurityAdvisory, error) {
+	return nil, fmt.Errorf("ListSecurityAdvisories: %w", clients.ErrUnsupportedFeature)
+}
+
+// Search implements RepoClient.Search.
+func (client *Client) Search(request clients.SearchRequest) (clients.SearchResponse, error) {
+	return clients.SearchResponse{}, fmt.Errorf("Search: %w", clients.ErrUnsupportedFeature)
+}
+
+// Close implements RepoClient.Close.
+func (client *Client) Close() error {
+	return nil
+}
+
+// CreateBitbucketRepoClient returns a Client which implements RepoClient interface.
+// Requests are authenticated with the access token in BITBUCKET_AUTH_TOKEN or,
+// if not set, with BITBUCKET_USERNAME and BITBUCKET_APP_PASSWORD.
+func CreateBitbucketRepoClient(ctx context.Context, logger *zap.Logger) clients.RepoClient {
+	return createBitbucketRepoClient(ctx, forge.NewHTTPClient(logger), apiBaseURL)
+}
+
+func createBitbucketRepoClient(ctx context.Context, httpClient *http.Client, baseURL string) *Client {
+	api := newAPIClient(httpClient, baseURL)
+	return &Client{
+		ctx:       ctx,
+		api:       api,
+		contents:  &contentsHandler{api: api},
+		commits:   &commitsHandler{api: api},
+		branches:  &branchesHandler{api: api},
+		downloads: &downloadsHandler{api: api},
+		issues:    &issuesHandler{api: api},
+		statuses:  &statusesHandler{api: api},
+	}
+}
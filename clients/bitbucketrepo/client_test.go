@@ -0,0 +1,261 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bitbucketrepo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge/forgetest"
+)
+
+// newTestClient returns a client for the repo ws/repo served by a fake
+// Bitbucket API answering the given paths with canned JSON.
+// "{{server}}" in responses is replaced with the URL of the API.
+func newTestClient(t *testing.T, responses map[string]string) clients.RepoClient {
+	t.Helper()
+	server := forgetest.NewServer(t, responses, func(w http.ResponseWriter, r *http.Request) string {
+		path := r.URL.Path
+		if p := r.URL.Query().Get("page"); p != "" {
+			path += "?page=" + p
+		}
+		return path
+	})
+
+	client := createBitbucketRepoClient(context.Background(), server.Client(), server.URL)
+	repo := &repoURL{host: bitbucketHost, workspace: "ws", repo: "repo"}
+	if err := client.InitRepo(repo, clients.HeadSHA); err != nil {
+		t.Fatalf("InitRepo: %v", err)
+	}
+	return client
+}
+
+var baseResponses = map[string]string{
+	"/repositories/ws/repo": `{"slug": "repo", "workspace": {"slug": "ws"}, "mainbranch": {"name": "main"},
+		"is_private": true}`,
+	"/repositories/ws/repo/refs/branches/main": `{"name": "main", "target": {"hash": "sha2"}}`,
+	"/repositories/ws/repo/refs/branches": `{"values": [{"name": "main"}, {"name": "release/v1"}],
+		"next": "{{server}}/repositories/ws/repo/refs/branches?page=2"}`,
+	"/repositories/ws/repo/refs/branches?page=2": `{"values": [{"name": "feature/x"}]}`,
+	"/repositories/ws/repo/commits/sha2": `{"values": [
+		{"hash": "sha2abcdef", "date": "2022-01-02T00:00:00Z", "message": "Merged in feature (pull request #2)",
+		 "author": {"user": {"nickname": "alice"}}},
+		{"hash": "sha1abcdef", "date": "2022-01-01T00:00:00Z", "message": "Direct push", "author": {}}]}`,
+	"/repositories/ws/repo/pullrequests": `{"values": [
+		{"id": 2, "author": {"nickname": "bob"}, "source": {"commit": {"hash": "head2"}},
+		 "merge_commit": {"hash": "sha2abc"}, "updated_on": "2022-01-02T00:00:00Z",
+		 "participants": [
+			{"approved": true, "state": "approved", "user": {"nickname": "alice"}},
+			{"approved": false, "state": null, "user": {"nickname": "carol"}}]}]}`,
+	"/repositories/ws/repo/src/sha2/": `{"values": [
+		{"path": "README.md", "type": "commit_file"},
+		{"path": "src", "type": "commit_directory"},
+		{"path": "src/main.go", "type": "commit_file"}]}`,
+	"/repositories/ws/repo/src/sha2/src/main.go": `package main`,
+	"/repositories/ws/repo/downloads": `{"values": [
+		{"name": "app.tar.gz", "links": {"self": {"href": "https://example.com/app.tar.gz"}}},
+		{"name": "app.tar.gz.asc", "links": {"self": {"href": "https://example.com/app.tar.gz.asc"}}},
+		{"name": "tool.zip", "links": {"self": {"href": "https://example.com/tool.zip"}}}]}`,
+	"/repositories/ws/repo/commit/head2/statuses": `{"values": [
+		{"state": "SUCCESSFUL", "key": "{uuid}", "name": "Pipeline #7 for feature", "url": "https://ci"}]}`,
+	"/repositories/ws/repo/pipelines": `{"values": [
+		{"build_number": 8, "state": {"name": "IN_PROGRESS"}},
+		{"build_number": 7, "state": {"name": "COMPLETED", "result": {"name": "SUCCESSFUL"}}},
+		{"build_number": 6, "state": {"name": "COMPLETED", "result": {"name": "FAILED"}}}]}`,
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+	responses := map[string]string{
+		"/repositories/ws/repo/branch-restrictions": `{"values": [
+			{"kind": "force", "branch_match_kind": "branching_model", "branch_type": "development"},
+			{"kind": "delete", "branch_match_kind": "glob", "pattern": "main"},
+			{"kind": "enforce_merge_checks", "branch_match_kind": "glob", "pattern": "main"},
+			{"kind": "require_approvals_to_merge", "branch_match_kind": "glob", "pattern": "main", "value": 1},
+			{"kind": "require_approvals_to_merge", "branch_match_kind": "glob", "pattern": "*", "value": 2},
+			{"kind": "require_passing_builds_to_merge", "branch_match_kind": "glob", "pattern": "main", "value": 1},
+			{"kind": "reset_pullrequest_approvals_on_change", "branch_match_kind": "glob", "pattern": "main"},
+			{"kind": "delete", "branch_match_kind": "glob", "pattern": "release/*"}]}`,
+		"/repositories/ws/repo/branching-model": `{"development": {"branch": {"name": "main"}},
+			"branch_types": [{"kind": "feature", "prefix": "feature/"}]}`,
+	}
+	for k, v := range baseResponses {
+		responses[k] = v
+	}
+	client := newTestClient(t, responses)
+
+	if reporter, ok := client.(clients.VisibilityReporter); !ok || !reporter.IsPrivate() {
+		t.Error("IsPrivate: got false")
+	}
+
+	branches, err := client.ListBranches()
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	trueVal, falseVal := true, false
+	var zero, two int32 = 0, 2
+	main, release, feature := "main", "release/v1", "feature/x"
+	expectedBranches := []*clients.BranchRef{
+		{
+			Name:      &main,
+			Protected: &trueVal,
+			BranchProtectionRule: clients.BranchProtectionRule{
+				AllowDeletions:   &falseVal,
+				AllowForcePushes: &falseVal,
+				CheckRules: clients.StatusChecksRule{
+					RequiresStatusChecks: &trueVal,
+					UpToDateBeforeMerge:  &falseVal,
+				},
+				RequiredPullRequestReviews: clients.PullRequestReviewRule{
+					RequiredApprovingReviewCount: &two,
+					DismissStaleReviews:          &trueVal,
+				},
+			},
+		},
+		{
+			Name:      &release,
+			Protected: &trueVal,
+			BranchProtectionRule: clients.BranchProtectionRule{
+				AllowDeletions:   &falseVal,
+				AllowForcePushes: &trueVal,
+				CheckRules: clients.StatusChecksRule{
+					RequiresStatusChecks: &falseVal,
+					UpToDateBeforeMerge:  &falseVal,
+				},
+				RequiredPullRequestReviews: clients.PullRequestReviewRule{
+					// Merge checks are not enforced.
+					RequiredApprovingReviewCount: &zero,
+					DismissStaleReviews:          &falseVal,
+				},
+			},
+		},
+		{
+			Name:      &feature,
+			Protected: &falseVal,
+		},
+	}
+	// "*" does not match "/", so feature/x has no restriction.
+	if diff := cmp.Diff(expectedBranches, branches); diff != "" {
+		t.Errorf("ListBranches mismatch (-want +got):\n%s", diff)
+	}
+	if b, err := client.GetDefaultBranch(); err != nil || *b.Name != "main" {
+		t.Errorf("GetDefaultBranch: got %v, %v", b, err)
+	}
+
+	commits, err := client.ListCommits()
+	if err != nil {
+		t.Fatalf("ListCommits: %v", err)
+	}
+	if len(commits) != 2 || commits[0].SHA != "sha2abcdef" || commits[0].Author.Login != "alice" ||
+		commits[0].AssociatedMergeRequest == nil || commits[0].AssociatedMergeRequest.Number != 2 ||
+		commits[1].AssociatedMergeRequest != nil {
+		t.Errorf("ListCommits: unexpected commits %+v", commits)
+	}
+
+	prs, err := client.ListMergedPRs()
+	if err != nil {
+		t.Fatalf("ListMergedPRs: %v", err)
+	}
+	if len(prs) != 1 || prs[0].HeadSHA != "head2" || prs[0].Author.Login != "bob" ||
+		len(prs[0].Reviews) != 1 || prs[0].Reviews[0].State != "APPROVED" {
+		t.Errorf("ListMergedPRs: unexpected PRs %+v", prs)
+	}
+
+	files, err := client.ListFiles(func(string) (bool, error) { return true, nil })
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+	if diff := cmp.Diff([]string{"README.md", "src/main.go"}, files); diff != "" {
+		t.Errorf("ListFiles mismatch (-want +got):\n%s", diff)
+	}
+	content, err := client.GetFileContent("src/main.go")
+	if err != nil || string(content) != "package main" {
+		t.Errorf("GetFileContent: got %q, %v", content, err)
+	}
+	if _, err := client.GetFileContent("missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("GetFileContent: got %v, expected %v", err, os.ErrNotExist)
+	}
+
+	releases, err := client.ListReleases()
+	if err != nil {
+		t.Fatalf("ListReleases: %v", err)
+	}
+	url := "https://bitbucket.org/ws/repo/downloads/"
+	expectedReleases := []clients.Release{
+		{
+			TagName: "app.tar.gz",
+			URL:     url,
+			Assets: []clients.ReleaseAsset{
+				{Name: "app.tar.gz", URL: "https://example.com/app.tar.gz"},
+				{Name: "app.tar.gz.asc", URL: "https://example.com/app.tar.gz.asc"},
+			},
+		},
+		{
+			TagName: "tool.zip",
+			URL:     url,
+			Assets:  []clients.ReleaseAsset{{Name: "tool.zip", URL: "https://example.com/tool.zip"}},
+		},
+	}
+	if diff := cmp.Diff(expectedReleases, releases); diff != "" {
+		t.Errorf("ListReleases mismatch (-want +got):\n%s", diff)
+	}
+
+	statuses, err := client.ListStatuses("head2")
+	if err != nil {
+		t.Fatalf("ListStatuses: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].State != "success" || statuses[0].Context != "Pipeline #7 for feature" ||
+		statuses[0].TargetURL != "https://ci" {
+		t.Errorf("ListStatuses: unexpected statuses %+v", statuses)
+	}
+
+	runs, err := client.ListSuccessfulWorkflowRuns("bitbucket-pipelines.yml")
+	if err != nil {
+		t.Fatalf("ListSuccessfulWorkflowRuns: %v", err)
+	}
+	expectedRuns := []clients.WorkflowRun{{URL: "https://bitbucket.org/ws/repo/pipelines/results/7"}}
+	if diff := cmp.Diff(expectedRuns, runs); diff != "" {
+		t.Errorf("ListSuccessfulWorkflowRuns mismatch (-want +got):\n%s", diff)
+	}
+
+	// The issue tracker is disabled.
+	if issues, err := client.ListIssues(); err != nil || len(issues) != 0 {
+		t.Errorf("ListIssues: got %v, %v", issues, err)
+	}
+
+	if _, err := client.ListContributors(); !errors.Is(err, clients.ErrUnsupportedFeature) {
+		t.Errorf("ListContributors: got %v, expected %v", err, clients.ErrUnsupportedFeature)
+	}
+}
+
+func TestClient_NoAdminAccess(t *testing.T) {
+	t.Parallel()
+	// branch-restrictions is not served, as for tokens without admin access.
+	client := newTestClient(t, baseResponses)
+
+	b, err := client.GetDefaultBranch()
+	if err != nil {
+		t.Fatalf("GetDefaultBranch: %v", err)
+	}
+	main := "main"
+	if diff := cmp.Diff(&clients.BranchRef{Name: &main}, b); diff != "" {
+		t.Errorf("GetDefaultBranch mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bitbucketrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const (
+	commitsToAnalyze      = 30
+	pullRequestsToAnalyze = 30
+)
+
+type user struct {
+	Nickname string `json:"nickname"`
+}
+
+type commit struct {
+	Hash    string    `json:"hash"`
+	Date    time.Time `json:"date"`
+	Message string    `json:"message"`
+	Author  struct {
+		// User is nil if the email does not match any account.
+		User *user `json:"user"`
+	} `json:"author"`
+}
+
+// Commit hashes in pull requests are abbreviated.
+type commitRef struct {
+	Hash string `json:"hash"`
+}
+
+type pullRequest struct {
+	ID     int  `json:"id"`
+	Author user `json:"author"`
+	Source struct {
+		Commit *commitRef `json:"commit"`
+	} `json:"source"`
+	MergeCommit *commitRef `json:"merge_commit"`
+	// Merged pull requests are no longer updated, so this is the merge time.
+	UpdatedOn    time.Time `json:"updated_on"`
+	Participants []struct {
+		Approved bool `json:"approved"`
+		// State is "approved", "changes_requested" or null.
+		State *string `json:"state"`
+		User  user    `json:"user"`
+	} `json:"participants"`
+}
+
+// commitsHandler lists the latest commits and merged PRs of the repo.
+type commitsHandler struct {
+	api       *apiClient
+	workspace string
+	repo      string
+	commitSHA string
+	commits   *forge.Commits
+}
+
+func (handler *commitsHandler) init(workspace, repo, commitSHA string) {
+	handler.workspace = workspace
+	handler.repo = repo
+	handler.commitSHA = commitSHA
+	handler.commits = forge.NewCommits(handler.list)
+}
+
+func (handler *commitsHandler) list(ctx context.Context) ([]clients.Commit, []clients.PullRequest, error) {
+	base := fmt.Sprintf("/repositories/%s/%s", handler.workspace, handler.repo)
+	var commits []commit
+	err := handler.api.list(ctx, base+"/commits/"+url.PathEscape(handler.commitSHA), nil, commitsToAnalyze,
+		func(values []byte) (int, error) {
+			var page []commit
+			if err := json.Unmarshal(values, &page); err != nil {
+				return 0, fmt.Errorf("json.Unmarshal: %w", err)
+			}
+			commits = append(commits, page...)
+			return len(page), nil
+		})
+	if err != nil {
+		return nil, nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list commits: %v", err))
+	}
+	if len(commits) > commitsToAnalyze {
+		commits = commits[:commitsToAnalyze]
+	}
+
+	// Participants, i.e., reviewers, are not listed by default.
+	query := url.Values{"state": {"MERGED"}, "fields": {"+values.participants"}}
+	var prs []clients.PullRequest
+	err = handler.api.list(ctx, base+"/pullrequests", query, pullRequestsToAnalyze,
+		func(values []byte) (int, error) {
+			var page []pullRequest
+			if err := json.Unmarshal(values, &page); err != nil {
+				return 0, fmt.Errorf("json.Unmarshal: %w", err)
+			}
+			for i := range page {
+				if len(prs) < pullRequestsToAnalyze {
+					prs = append(prs, pullRequestFrom(&page[i]))
+				}
+			}
+			return len(page), nil
+		})
+	if err != nil {
+		return nil, nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list pullrequests: %v", err))
+	}
+	return commitsFrom(commits, prs), prs, nil
+}
+
+func (handler *commitsHandler) listCommits(ctx context.Context) ([]clients.Commit, error) {
+	return handler.commits.ListCommits(ctx)
+}
+
+func (handler *commitsHandler) listMergedPRs(ctx context.Context) ([]clients.PullRequest, error) {
+	return handler.commits.ListMergedPRs(ctx)
+}
+
+func pullRequestFrom(pr *pullRequest) clients.PullRequest {
+	ret := clients.PullRequest{
+		Number:   pr.ID,
+		MergedAt: pr.UpdatedOn,
+		Author:   clients.User{Login: pr.Author.Nickname},
+	}
+	if pr.Source.Commit != nil {
+		ret.HeadSHA = pr.Source.Commit.Hash
+	}
+	if pr.MergeCommit != nil {
+		ret.MergeCommit.SHA = pr.MergeCommit.Hash
+	}
+	for _, p := range pr.Participants {
+		var state string
+		switch {
+		case p.Approved:
+			state = "APPROVED"
+		case p.State != nil && *p.State == "changes_requested":
+			state = "CHANGES_REQUESTED"
+		default:
+			continue
+		}
+		ret.Reviews = append(ret.Reviews, clients.Review{
+			State:  state,
+			Author: clients.User{Login: p.User.Nickname},
+		})
+	}
+	return ret
+}
+
+// commitsFrom converts the commits, associating them with the merged PRs
+// whose (abbreviated) merge commit they are.
+func commitsFrom(commits []commit, prs []clients.PullRequest) []clients.Commit {
+	ret := []clients.Commit{}
+	for i := range commits {
+		c := &commits[i]
+		var author clients.User
+		if c.Author.User != nil {
+			author.Login = c.Author.User.Nickname
+		}
+		ret = append(ret, clients.Commit{
+			CommittedDate:          c.Date,
+			Message:                c.Message,
+			SHA:                    c.Hash,
+			Author:                 author,
+			AssociatedMergeRequest: forge.MergedBy(c.Hash, prs),
+		})
+	}
+	return ret
+}
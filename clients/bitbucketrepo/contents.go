@@ -0,0 +1,93 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bitbucketrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const (
+	// Maximum number of directory entries listed, which bounds the number of pages read.
+	entriesToList = 100000
+	// Depth of the directories listed.
+	maxDepth = 100
+)
+
+type entry struct {
+	Path string `json:"path"`
+	// Type is "commit_file" or "commit_directory".
+	Type string `json:"type"`
+}
+
+// contentsHandler lists the files of the repo at a commit and reads their content.
+type contentsHandler struct {
+	api       *apiClient
+	workspace string
+	repo      string
+	commitSHA string
+	files     *forge.Files
+}
+
+func (handler *contentsHandler) init(workspace, repo, commitSHA string) {
+	handler.workspace = workspace
+	handler.repo = repo
+	handler.commitSHA = commitSHA
+	handler.files = forge.NewFiles(handler.listSrc, handler.readFile)
+}
+
+func (handler *contentsHandler) listSrc(ctx context.Context) ([]string, error) {
+	// The trailing slash lists the root directory rather than reading a file.
+	path := fmt.Sprintf("/repositories/%s/%s/src/%s/", handler.workspace, handler.repo,
+		url.PathEscape(handler.commitSHA))
+	query := url.Values{"max_depth": {strconv.Itoa(maxDepth)}}
+	var files []string
+	err := handler.api.list(ctx, path, query, entriesToList,
+		func(values []byte) (int, error) {
+			var page []entry
+			if err := json.Unmarshal(values, &page); err != nil {
+				return 0, fmt.Errorf("json.Unmarshal: %w", err)
+			}
+			for _, e := range page {
+				if e.Type == "commit_file" {
+					files = append(files, e.Path)
+				}
+			}
+			return len(page), nil
+		})
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list src: %v", err))
+	}
+	return files, nil
+}
+
+func (handler *contentsHandler) readFile(ctx context.Context, filename string) ([]byte, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/src/%s/%s", handler.workspace, handler.repo,
+		url.PathEscape(handler.commitSHA), forge.EscapePath(filename))
+	return handler.api.do(ctx, path, nil)
+}
+
+func (handler *contentsHandler) listFiles(ctx context.Context, predicate func(string) (bool, error)) ([]string, error) {
+	return handler.files.List(ctx, predicate)
+}
+
+func (handler *contentsHandler) getFileContent(ctx context.Context, filename string) ([]byte, error) {
+	return handler.files.Read(ctx, filename)
+}
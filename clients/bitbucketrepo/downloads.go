@@ -0,0 +1,117 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bitbucketrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const downloadsToAnalyze = 100
+
+type download struct {
+	Name  string `json:"name"`
+	Links struct {
+		Self struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+// downloadsHandler reports the files uploaded to the Downloads page of the repo
+// as releases. Bitbucket has no releases, and downloads are not attached to tags.
+type downloadsHandler struct {
+	api       *apiClient
+	once      *forge.Once
+	workspace string
+	repo      string
+	releases  []clients.Release
+}
+
+func (handler *downloadsHandler) init(workspace, repo string) {
+	handler.workspace = workspace
+	handler.repo = repo
+	handler.once = new(forge.Once)
+}
+
+func (handler *downloadsHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		path := fmt.Sprintf("/repositories/%s/%s/downloads", handler.workspace, handler.repo)
+		var downloads []download
+		err := handler.api.list(ctx, path, nil, downloadsToAnalyze,
+			func(values []byte) (int, error) {
+				var page []download
+				if err := json.Unmarshal(values, &page); err != nil {
+					return 0, fmt.Errorf("json.Unmarshal: %w", err)
+				}
+				downloads = append(downloads, page...)
+				return len(page), nil
+			})
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list downloads: %v", err))
+		}
+		handler.releases = releasesFrom(downloads,
+			fmt.Sprintf("https://%s/%s/%s/downloads/", bitbucketHost, handler.workspace, handler.repo))
+		return nil
+	})
+}
+
+func (handler *downloadsHandler) getReleases(ctx context.Context) ([]clients.Release, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during downloadsHandler.setup: %w", err)
+	}
+	return handler.releases, nil
+}
+
+// releasesFrom returns a release per artifact, i.e., per download which does not
+// extend the name of another one. Its assets are the artifact and the downloads
+// extending its name, e.g., "app.tar.gz" and "app.tar.gz.asc".
+func releasesFrom(downloads []download, url string) []clients.Release {
+	isArtifact := func(name string) bool {
+		for i := range downloads {
+			if strings.HasPrefix(name, downloads[i].Name+".") {
+				return false
+			}
+		}
+		return true
+	}
+	var ret []clients.Release
+	for i := range downloads {
+		artifact := downloads[i].Name
+		if !isArtifact(artifact) {
+			continue
+		}
+		release := clients.Release{
+			TagName: artifact,
+			URL:     url,
+		}
+		for j := range downloads {
+			d := &downloads[j]
+			if d.Name == artifact || strings.HasPrefix(d.Name, artifact+".") {
+				release.Assets = append(release.Assets, clients.ReleaseAsset{
+					Name: d.Name,
+					URL:  d.Links.Self.Href,
+				})
+			}
+		}
+		ret = append(ret, release)
+	}
+	return ret
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bitbucketrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const issuesToAnalyze = 30
+
+type issue struct {
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+	UpdatedOn time.Time `json:"updated_on"`
+}
+
+type issuesHandler struct {
+	api       *apiClient
+	once      *forge.Once
+	workspace string
+	repo      string
+	issues    []clients.Issue
+}
+
+func (handler *issuesHandler) init(workspace, repo string) {
+	handler.workspace = workspace
+	handler.repo = repo
+	handler.once = new(forge.Once)
+}
+
+func (handler *issuesHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		path := fmt.Sprintf("/repositories/%s/%s/issues", handler.workspace, handler.repo)
+		query := url.Values{"sort": {"-updated_on"}}
+		handler.issues = nil
+		err := handler.api.list(ctx, path, query, issuesToAnalyze,
+			func(values []byte) (int, error) {
+				var page []issue
+				if err := json.Unmarshal(values, &page); err != nil {
+					return 0, fmt.Errorf("json.Unmarshal: %w", err)
+				}
+				for i := range page {
+					uri, updatedAt := page[i].Links.HTML.Href, page[i].UpdatedOn
+					handler.issues = append(handler.issues, clients.Issue{URI: &uri, UpdatedAt: &updatedAt})
+				}
+				return len(page), nil
+			})
+		// Repos with the issue tracker disabled have no issues.
+		if err != nil && !forge.HasStatus(err, http.StatusNotFound) {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list issues: %v", err))
+		}
+		return nil
+	})
+}
+
+func (handler *issuesHandler) listIssues(ctx context.Context) ([]clients.Issue, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during issuesHandler.setup: %w", err)
+	}
+	return handler.issues, nil
+}
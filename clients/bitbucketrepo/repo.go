@@ -0,0 +1,111 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bitbucketrepo
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const bitbucketHost = "bitbucket.org"
+
+type repoURL struct {
+	host, workspace, repo string
+	metadata              []string
+}
+
+// Parses input string into repoURL struct.
+// Accepts "bitbucket.org/workspace/repo", with an optional scheme.
+func (r *repoURL) parse(input string) error {
+	t := input
+	// Allow skipping scheme for ease-of-use, default to https.
+	if !strings.Contains(t, "://") {
+		t = "https://" + t
+	}
+
+	u, e := url.Parse(t)
+	if e != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("url.Parse: %v", e))
+	}
+
+	const splitLen = 2
+	split := strings.SplitN(strings.Trim(u.Path, "/"), "/", splitLen)
+	if len(split) != splitLen {
+		return sce.WithMessage(sce.ErrorInvalidURL, fmt.Sprintf("%v. Expected full repository url", input))
+	}
+
+	r.host, r.workspace, r.repo = u.Host, split[0], split[1]
+	return nil
+}
+
+// URI implements Repo.URI().
+func (r *repoURL) URI() string {
+	return fmt.Sprintf("%s/%s/%s", r.host, r.workspace, r.repo)
+}
+
+// String implements Repo.String.
+func (r *repoURL) String() string {
+	return fmt.Sprintf("%s-%s-%s", r.host, r.workspace, r.repo)
+}
+
+// Org implements Repo.Org.
+// Bitbucket has no repo shared by the repos of a workspace,
+// so the workspace itself is returned.
+func (r *repoURL) Org() clients.Repo {
+	return &repoURL{
+		host:      r.host,
+		workspace: r.workspace,
+	}
+}
+
+// IsValid implements Repo.IsValid.
+func (r *repoURL) IsValid() error {
+	if !strings.EqualFold(r.host, bitbucketHost) {
+		return sce.WithMessage(sce.ErrRepoUnsupportedHost, r.host)
+	}
+
+	if strings.TrimSpace(r.workspace) == "" || strings.TrimSpace(r.repo) == "" ||
+		strings.Contains(r.repo, "/") {
+		return sce.WithMessage(sce.ErrorInvalidURL,
+			fmt.Sprintf("%v. Expected the full repository url", r.URI()))
+	}
+	return nil
+}
+
+// AppendMetadata implements Repo.AppendMetadata.
+func (r *repoURL) AppendMetadata(metadata ...string) {
+	r.metadata = append(r.metadata, metadata...)
+}
+
+// Metadata implements Repo.Metadata.
+func (r *repoURL) Metadata() []string {
+	return r.metadata
+}
+
+// MakeBitbucketRepo takes input of form "bitbucket.org/workspace/repo"
+// and returns an implementation of clients.Repo interface.
+func MakeBitbucketRepo(input string) (clients.Repo, error) {
+	var repo repoURL
+	if err := repo.parse(input); err != nil {
+		return nil, fmt.Errorf("error during parse: %w", err)
+	}
+	if err := repo.IsValid(); err != nil {
+		return nil, fmt.Errorf("error in IsValid: %w", err)
+	}
+	return &repo, nil
+}
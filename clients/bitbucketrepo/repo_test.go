@@ -0,0 +1,75 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bitbucketrepo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+func TestMakeBitbucketRepo(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		inputURL string
+		expected repoURL
+		wantErr  error
+	}{
+		{
+			name:     "without scheme",
+			inputURL: "bitbucket.org/atlassian/python-bitbucket",
+			expected: repoURL{host: "bitbucket.org", workspace: "atlassian", repo: "python-bitbucket"},
+		},
+		{
+			name:     "with scheme and trailing slash",
+			inputURL: "https://bitbucket.org/atlassian/python-bitbucket/",
+			expected: repoURL{host: "bitbucket.org", workspace: "atlassian", repo: "python-bitbucket"},
+		},
+		{
+			name:     "unknown host",
+			inputURL: "github.com/ossf/scorecard",
+			wantErr:  sce.ErrRepoUnsupportedHost,
+		},
+		{
+			name:     "workspace only",
+			inputURL: "bitbucket.org/atlassian",
+			wantErr:  sce.ErrorInvalidURL,
+		},
+		{
+			name:     "path in repo",
+			inputURL: "bitbucket.org/atlassian/python-bitbucket/src/master",
+			wantErr:  sce.ErrorInvalidURL,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			repo, err := MakeBitbucketRepo(tt.inputURL)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MakeBitbucketRepo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(&tt.expected, repo, cmp.AllowUnexported(repoURL{})); diff != "" {
+				t.Errorf("MakeBitbucketRepo() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
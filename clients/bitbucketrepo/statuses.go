@@ -0,0 +1,136 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bitbucketrepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const (
+	statusesToAnalyze = 100
+	pipelinesToList   = 30
+	// The file configuring Bitbucket Pipelines.
+	pipelinesFile = "bitbucket-pipelines.yml"
+)
+
+// Build states of Bitbucket, mapped onto the states of GitHub statuses.
+var statusStates = map[string]string{
+	"SUCCESSFUL": "success",
+	"FAILED":     "failure",
+	"INPROGRESS": "pending",
+	"STOPPED":    "error",
+}
+
+// status is a build status, reported by Bitbucket Pipelines or by other CI systems.
+type status struct {
+	State     string    `json:"state"`
+	Key       string    `json:"key"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	UpdatedOn time.Time `json:"updated_on"`
+	Links     struct {
+		Self struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+type pipeline struct {
+	BuildNumber int `json:"build_number"`
+	State       struct {
+		Result *struct {
+			Name string `json:"name"`
+		} `json:"result"`
+	} `json:"state"`
+}
+
+type statusesHandler struct {
+	api       *apiClient
+	workspace string
+	repo      string
+}
+
+func (handler *statusesHandler) init(workspace, repo string) {
+	handler.workspace = workspace
+	handler.repo = repo
+}
+
+func (handler *statusesHandler) listStatuses(ctx context.Context, ref string) ([]clients.Status, error) {
+	path := fmt.Sprintf("/repositories/%s/%s/commit/%s/statuses", handler.workspace, handler.repo, url.PathEscape(ref))
+	ret := []clients.Status{}
+	err := handler.api.list(ctx, path, nil, statusesToAnalyze,
+		func(values []byte) (int, error) {
+			var page []status
+			if err := json.Unmarshal(values, &page); err != nil {
+				return 0, fmt.Errorf("json.Unmarshal: %w", err)
+			}
+			for _, s := range page {
+				name := s.Name
+				if name == "" {
+					name = s.Key
+				}
+				ret = append(ret, clients.Status{
+					UpdatedAt: s.UpdatedOn,
+					State:     statusStates[s.State],
+					Context:   name,
+					URL:       s.Links.Self.Href,
+					TargetURL: s.URL,
+				})
+			}
+			return len(page), nil
+		})
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list statuses: %v", err))
+	}
+	return ret, nil
+}
+
+// listSuccessfulPipelines returns the successful runs among the latest runs of
+// Bitbucket Pipelines. The pipelines of a repo are all configured in one file.
+func (handler *statusesHandler) listSuccessfulPipelines(ctx context.Context,
+	filename string) ([]clients.WorkflowRun, error) {
+	if filename != pipelinesFile {
+		return nil, nil
+	}
+	path := fmt.Sprintf("/repositories/%s/%s/pipelines", handler.workspace, handler.repo)
+	query := url.Values{"sort": {"-created_on"}}
+	var ret []clients.WorkflowRun
+	err := handler.api.list(ctx, path, query, pipelinesToList,
+		func(values []byte) (int, error) {
+			var page []pipeline
+			if err := json.Unmarshal(values, &page); err != nil {
+				return 0, fmt.Errorf("json.Unmarshal: %w", err)
+			}
+			for _, p := range page {
+				if p.State.Result != nil && p.State.Result.Name == "SUCCESSFUL" {
+					ret = append(ret, clients.WorkflowRun{
+						URL: fmt.Sprintf("https://%s/%s/%s/pipelines/results/%d",
+							bitbucketHost, handler.workspace, handler.repo, p.BuildNumber),
+					})
+				}
+			}
+			return len(page), nil
+		})
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list pipelines: %v", err))
+	}
+	return ret, nil
+}
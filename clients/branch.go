@@ -14,6 +14,15 @@
 
 package clients
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrBranchNotFound is returned by RepoClient.GetBranch for unknown branches.
+var ErrBranchNotFound = errors.New("branch not found")
+
 // BranchRef represents a single branch reference and its protection rules.
 type BranchRef struct {
 	Name                 *string
@@ -27,8 +36,11 @@ type BranchProtectionRule struct {
 	AllowDeletions             *bool
 	AllowForcePushes           *bool
 	RequireLinearHistory       *bool
+	RequiresSignatures         *bool
 	EnforceAdmins              *bool
-	CheckRules                 StatusChecksRule
+	// RequiresMergeQueue is set if PRs are merged by GitHub's merge queue.
+	RequiresMergeQueue *bool
+	CheckRules         StatusChecksRule
 }
 
 // StatusChecksRule captures settings on status checks.
@@ -36,6 +48,9 @@ type StatusChecksRule struct {
 	UpToDateBeforeMerge  *bool
 	RequiresStatusChecks *bool
 	Contexts             []string
+	// RequiredWorkflows are the paths of the workflows required to pass,
+	// typically by organization rulesets.
+	RequiredWorkflows []string
 }
 
 // PullRequestReviewRule captures settings on a PullRequest.
@@ -44,3 +59,25 @@ type PullRequestReviewRule struct {
 	DismissStaleReviews          *bool
 	RequireCodeOwnerReviews      *bool
 }
+
+// BranchUpdate is an update of a branch recorded in the git reflog.
+type BranchUpdate struct {
+	UpdatedAt time.Time
+	OldSHA    string
+	NewSHA    string
+	Message   string
+	// Forced is set if the update rewrote the history of the branch,
+	// i.e., OldSHA is not an ancestor of NewSHA.
+	Forced bool
+}
+
+// FindBranch returns the branch named name among branches,
+// for RepoClients whose branches cannot be renamed.
+func FindBranch(branches []*BranchRef, name string) (*BranchRef, error) {
+	for _, b := range branches {
+		if b != nil && b.Name != nil && *b.Name == name {
+			return b, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrBranchNotFound, name)
+}
@@ -14,12 +14,15 @@
 
 package clients
 
+import "time"
+
 // CheckRun is a single instance of a VCS CheckRun.
 type CheckRun struct {
-	Status     string
-	Conclusion string
-	URL        string
-	App        CheckRunApp
+	CompletedAt time.Time
+	Status      string
+	Conclusion  string
+	URL         string
+	App         CheckRunApp
 }
 
 // CheckRunApp is the app running the Check.
@@ -21,22 +21,34 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"net/url"
 	"time"
+
+	"github.com/ossf/scorecard/v3/clients/githubrepo/roundtripper"
 )
 
-var errTooManyRequests = errors.New("failed after exponential backoff")
+const ciiBestPracticesProjectsURL = "https://bestpractices.coreinfrastructure.org/projects.json"
+
+var (
+	errTooManyRequests  = errors.New("failed after exponential backoff")
+	errUnexpectedStatus = errors.New("unexpected status")
+)
 
 // httpClientCIIBestPractices implements the CIIBestPracticesClient interface.
 // A HTTP client with exponential backoff is used to communicate with the CII Best Practices servers.
-type httpClientCIIBestPractices struct{}
+type httpClientCIIBestPractices struct {
+	// projectsURL overrides ciiBestPracticesProjectsURL in tests.
+	projectsURL string
+}
 
 type expBackoffTransport struct {
 	numRetries uint8
 }
 
 func (transport *expBackoffTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	httpClient := &http.Client{Transport: roundtripper.WithCassette(roundtripper.BaseTransport())}
 	for i := 0; i < int(transport.numRetries); i++ {
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := httpClient.Do(req)
 		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
 			// nolint: wrapcheck
 			return resp, err
@@ -48,9 +60,13 @@ func (transport *expBackoffTransport) RoundTrip(req *http.Request) (*http.Respon
 
 // GetBadgeLevel implements CIIBestPracticesClient.GetBadgeLevel.
 func (client *httpClientCIIBestPractices) GetBadgeLevel(ctx context.Context, uri string) (BadgeLevel, error) {
+	projectsURL := ciiBestPracticesProjectsURL
+	if client.projectsURL != "" {
+		projectsURL = client.projectsURL
+	}
 	repoURI := fmt.Sprintf("https://%s", uri)
-	url := fmt.Sprintf("https://bestpractices.coreinfrastructure.org/projects.json?url=%s", repoURI)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET",
+		fmt.Sprintf("%s?url=%s", projectsURL, url.QueryEscape(repoURI)), nil)
 	if err != nil {
 		return Unknown, fmt.Errorf("error during http.NewRequestWithContext: %w", err)
 	}
@@ -65,6 +81,9 @@ func (client *httpClientCIIBestPractices) GetBadgeLevel(ctx context.Context, uri
 		return Unknown, fmt.Errorf("error during http.Do: %w", err)
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Unknown, fmt.Errorf("%w: %d", errUnexpectedStatus, resp.StatusCode)
+	}
 
 	jsonData, err := io.ReadAll(resp.Body)
 	if err != nil {
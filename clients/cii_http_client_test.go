@@ -0,0 +1,102 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPClientCIIBestPractices(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		body     string
+		status   int
+		expected BadgeLevel
+		err      error
+	}{
+		{
+			name:     "not found",
+			body:     `[]`,
+			status:   http.StatusOK,
+			expected: NotFound,
+		},
+		{
+			name:     "in progress",
+			body:     `[{"badge_level":"in_progress"}]`,
+			status:   http.StatusOK,
+			expected: InProgress,
+		},
+		{
+			name:     "passing",
+			body:     `[{"badge_level":"passing"}]`,
+			status:   http.StatusOK,
+			expected: Passing,
+		},
+		{
+			name:     "silver",
+			body:     `[{"badge_level":"silver"}]`,
+			status:   http.StatusOK,
+			expected: Silver,
+		},
+		{
+			name:     "gold",
+			body:     `[{"badge_level":"gold"}]`,
+			status:   http.StatusOK,
+			expected: Gold,
+		},
+		{
+			name:     "unsupported badge",
+			body:     `[{"badge_level":"platinum"}]`,
+			status:   http.StatusOK,
+			expected: Unknown,
+			err:      errUnsupportedBadge,
+		},
+		{
+			name:     "server error",
+			status:   http.StatusInternalServerError,
+			expected: Unknown,
+			err:      errUnexpectedStatus,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("url"); got != "https://github.com/org/repo" {
+					t.Errorf("unexpected url query: %s", got)
+				}
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			client := &httpClientCIIBestPractices{projectsURL: server.URL}
+			level, err := client.GetBadgeLevel(context.Background(), "github.com/org/repo")
+			if !errors.Is(err, tt.err) {
+				t.Errorf("expected error %v, got %v", tt.err, err)
+			}
+			if level != tt.expected {
+				t.Errorf("expected badge level %v, got %v", tt.expected, level)
+			}
+		})
+	}
+}
@@ -19,7 +19,15 @@ import "time"
 // Commit represents a Git commit.
 type Commit struct {
 	CommittedDate time.Time
-	Message       string
-	SHA           string
-	Committer     User
+	// AssociatedMergeRequest is the merged PR which introduced the commit,
+	// with its labels and reviews, or nil if the commit was pushed directly
+	// or the forge does not report it.
+	AssociatedMergeRequest *PullRequest
+	Message                string
+	SHA                    string
+	// ParentSHAs are the SHAs of the parents, the first parent first,
+	// or nil if the forge does not report them.
+	ParentSHAs []string
+	Author     User
+	Committer  User
 }
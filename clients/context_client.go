@@ -0,0 +1,280 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import "context"
+
+// WithContext returns a RepoClient whose calls fail with ctx.Err() once ctx
+// is done, so that a check whose deadline passed stops at its next call.
+// Calls already in flight are only interrupted if client makes them with ctx,
+// see WithCallContext.
+func WithContext(ctx context.Context, client RepoClient) RepoClient {
+	return &contextRepoClient{ctx: ctx, client: client}
+}
+
+// CallContextClient is implemented by RepoClients which can make the API
+// calls of a caller with the caller's context, so that they are counted with
+// its API call counters, see roundtripper.WithAPICallCounter, and interrupted
+// once it is done.
+type CallContextClient interface {
+	// WithCallContext returns a view of the client sharing its data, whose
+	// API calls are made with ctx.
+	WithCallContext(ctx context.Context) RepoClient
+}
+
+// WithCallContext returns a view of client making its API calls with ctx if
+// it is a CallContextClient, or client otherwise.
+func WithCallContext(ctx context.Context, client RepoClient) RepoClient {
+	if c, ok := client.(CallContextClient); ok {
+		return c.WithCallContext(ctx)
+	}
+	return client
+}
+
+type contextRepoClient struct {
+	ctx    context.Context
+	client RepoClient
+}
+
+// InitRepo implements RepoClient.InitRepo.
+func (c *contextRepoClient) InitRepo(repo Repo, commitSHA string) error {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return err
+	}
+	//nolint:wrapcheck
+	return c.client.InitRepo(repo, commitSHA)
+}
+
+// URI implements RepoClient.URI.
+func (c *contextRepoClient) URI() string {
+	return c.client.URI()
+}
+
+// IsArchived implements RepoClient.IsArchived.
+func (c *contextRepoClient) IsArchived() (bool, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return false, err
+	}
+	//nolint:wrapcheck
+	return c.client.IsArchived()
+}
+
+// ListFiles implements RepoClient.ListFiles.
+func (c *contextRepoClient) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListFiles(predicate)
+}
+
+// GetFileContent implements RepoClient.GetFileContent.
+func (c *contextRepoClient) GetFileContent(filename string) ([]byte, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.GetFileContent(filename)
+}
+
+// ListMergedPRs implements RepoClient.ListMergedPRs.
+func (c *contextRepoClient) ListMergedPRs() ([]PullRequest, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListMergedPRs()
+}
+
+// ListBranches implements RepoClient.ListBranches.
+func (c *contextRepoClient) ListBranches() ([]*BranchRef, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListBranches()
+}
+
+// GetDefaultBranch implements RepoClient.GetDefaultBranch.
+func (c *contextRepoClient) GetDefaultBranch() (*BranchRef, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.GetDefaultBranch()
+}
+
+// GetBranch implements RepoClient.GetBranch.
+func (c *contextRepoClient) GetBranch(name string) (*BranchRef, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.GetBranch(name)
+}
+
+// ListCommits implements RepoClient.ListCommits.
+func (c *contextRepoClient) ListCommits() ([]Commit, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListCommits()
+}
+
+// ListIssues implements RepoClient.ListIssues.
+func (c *contextRepoClient) ListIssues() ([]Issue, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListIssues()
+}
+
+// ListReleases implements RepoClient.ListReleases.
+func (c *contextRepoClient) ListReleases() ([]Release, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListReleases()
+}
+
+// ListAttestations implements RepoClient.ListAttestations.
+func (c *contextRepoClient) ListAttestations(subjectDigest string) ([]Attestation, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListAttestations(subjectDigest)
+}
+
+// ListContainerImages implements RepoClient.ListContainerImages.
+func (c *contextRepoClient) ListContainerImages() ([]ContainerImage, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListContainerImages()
+}
+
+// ListTags implements RepoClient.ListTags.
+func (c *contextRepoClient) ListTags() ([]Tag, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListTags()
+}
+
+// ListBranchesForCommit implements RepoClient.ListBranchesForCommit.
+func (c *contextRepoClient) ListBranchesForCommit(sha string) ([]string, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListBranchesForCommit(sha)
+}
+
+// ListContributors implements RepoClient.ListContributors.
+func (c *contextRepoClient) ListContributors() ([]Contributor, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListContributors()
+}
+
+// ListSuccessfulWorkflowRuns implements RepoClient.ListSuccessfulWorkflowRuns.
+func (c *contextRepoClient) ListSuccessfulWorkflowRuns(filename string) ([]WorkflowRun, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListSuccessfulWorkflowRuns(filename)
+}
+
+// ListCheckRunsForRef implements RepoClient.ListCheckRunsForRef.
+func (c *contextRepoClient) ListCheckRunsForRef(ref string) ([]CheckRun, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListCheckRunsForRef(ref)
+}
+
+// ListStatuses implements RepoClient.ListStatuses.
+func (c *contextRepoClient) ListStatuses(ref string) ([]Status, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListStatuses(ref)
+}
+
+// ListBranchUpdates implements RepoClient.ListBranchUpdates.
+func (c *contextRepoClient) ListBranchUpdates(branch string) ([]BranchUpdate, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListBranchUpdates(branch)
+}
+
+// ListSecurityAdvisories implements RepoClient.ListSecurityAdvisories.
+func (c *contextRepoClient) ListSecurityAdvisories() ([]SecurityAdvisory, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return c.client.ListSecurityAdvisories()
+}
+
+// Search implements RepoClient.Search.
+func (c *contextRepoClient) Search(request SearchRequest) (SearchResponse, error) {
+	if err := c.ctx.Err(); err != nil {
+		//nolint:wrapcheck
+		return SearchResponse{}, err
+	}
+	//nolint:wrapcheck
+	return c.client.Search(request)
+}
+
+// Close implements RepoClient.Close.
+func (c *contextRepoClient) Close() error {
+	//nolint:wrapcheck
+	return c.client.Close()
+}
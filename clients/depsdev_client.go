@@ -0,0 +1,143 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ossf/scorecard/v3/clients/githubrepo/roundtripper"
+)
+
+const depsDevAPI = "https://api.deps.dev/v3alpha"
+
+var errDepsDevStatus = errors.New("unexpected deps.dev status")
+
+type depsDevPackageVersions struct {
+	Versions []struct {
+		VersionKey struct {
+			System string `json:"system"`
+			Name   string `json:"name"`
+		} `json:"versionKey"`
+	} `json:"versions"`
+}
+
+type depsDevPackage struct {
+	Versions []struct {
+		VersionKey struct {
+			Version string `json:"version"`
+		} `json:"versionKey"`
+		IsDefault bool `json:"isDefault"`
+	} `json:"versions"`
+}
+
+type depsDevDependents struct {
+	DependentCount int `json:"dependentCount"`
+}
+
+// depsDevClient implements the PackagesClient interface using https://deps.dev.
+type depsDevClient struct {
+	// baseURL defaults to depsDevAPI.
+	baseURL string
+}
+
+// ListPackages implements PackagesClient.ListPackages.
+func (d depsDevClient) ListPackages(ctx context.Context, repoURI string) ([]Package, error) {
+	// Nothing is listed when deps.dev does not know about the project.
+	var versions depsDevPackageVersions
+	path := fmt.Sprintf("/projects/%s:packageversions", url.PathEscape(repoURI))
+	if _, err := d.get(ctx, path, &versions); err != nil {
+		return nil, err
+	}
+
+	// Each version of a package is listed, only return the package once.
+	var ret []Package
+	seen := make(map[Package]bool)
+	for _, v := range versions.Versions {
+		p := Package{
+			System: v.VersionKey.System,
+			Name:   v.VersionKey.Name,
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+// CountDependents implements DependentsCounter.CountDependents,
+// counting the dependents of the default version of the package.
+func (d depsDevClient) CountDependents(ctx context.Context, p Package) (int, error) {
+	packagePath := fmt.Sprintf("/systems/%s/packages/%s", url.PathEscape(p.System), url.PathEscape(p.Name))
+	var pkg depsDevPackage
+	if found, err := d.get(ctx, packagePath, &pkg); err != nil || !found {
+		return 0, err
+	}
+	version := ""
+	for _, v := range pkg.Versions {
+		if v.IsDefault {
+			version = v.VersionKey.Version
+		}
+	}
+	if version == "" {
+		return 0, nil
+	}
+	var dependents depsDevDependents
+	if _, err := d.get(ctx, fmt.Sprintf("%s/versions/%s:dependents", packagePath, url.PathEscape(version)),
+		&dependents); err != nil {
+		return 0, err
+	}
+	return dependents.DependentCount, nil
+}
+
+// get decodes the response of the deps.dev API at path into v,
+// and returns false if deps.dev does not know about it.
+func (d depsDevClient) get(ctx context.Context, path string, v interface{}) (bool, error) {
+	baseURL := d.baseURL
+	if baseURL == "" {
+		baseURL = depsDevAPI
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, nil)
+	if err != nil {
+		return false, fmt.Errorf("error during http.NewRequestWithContext: %w", err)
+	}
+
+	// Use our own http client as the one from the RepoClient adds GitHub tokens to the headers.
+	httpClient := &http.Client{Transport: roundtripper.WithCassette(roundtripper.BaseTransport())}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("error during http.Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("%w: %d", errDepsDevStatus, resp.StatusCode)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	if err := decoder.Decode(v); err != nil {
+		return false, fmt.Errorf("error during decoder.Decode: %w", err)
+	}
+	return true, nil
+}
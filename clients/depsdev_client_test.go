@@ -0,0 +1,86 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDepsDevClient(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/projects/github.com%2Fowner%2Frepo:packageversions":
+			fmt.Fprint(w, `{"versions": [
+				{"versionKey": {"system": "NPM", "name": "@owner/pkg", "version": "1.0.0"}},
+				{"versionKey": {"system": "NPM", "name": "@owner/pkg", "version": "2.0.0"}},
+				{"versionKey": {"system": "PYPI", "name": "pkg", "version": "1.0.0"}}
+			]}`)
+		case "/systems/NPM/packages/@owner%2Fpkg":
+			fmt.Fprint(w, `{"versions": [
+				{"versionKey": {"version": "1.0.0"}},
+				{"versionKey": {"version": "2.0.0"}, "isDefault": true}
+			]}`)
+		case "/systems/NPM/packages/@owner%2Fpkg/versions/2.0.0:dependents":
+			fmt.Fprint(w, `{"dependentCount": 42, "directDependentCount": 40}`)
+		case "/systems/PYPI/packages/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	ctx := context.Background()
+	client := depsDevClient{baseURL: server.URL}
+
+	packages, err := client.ListPackages(ctx, "github.com/owner/repo")
+	if err != nil {
+		t.Fatalf("ListPackages: %v", err)
+	}
+	want := []Package{{System: "NPM", Name: "@owner/pkg"}, {System: "PYPI", Name: "pkg"}}
+	if diff := cmp.Diff(want, packages); diff != "" {
+		t.Errorf("ListPackages mismatch (-want +got):\n%s", diff)
+	}
+	if packages, err := client.ListPackages(ctx, "github.com/owner/unknown"); err != nil || packages != nil {
+		t.Errorf("ListPackages of an unknown project = %v, %v, want nil, nil", packages, err)
+	}
+
+	tests := []struct {
+		err  error
+		name string
+		pkg  Package
+		want int
+	}{
+		{name: "default version", pkg: Package{System: "NPM", Name: "@owner/pkg"}, want: 42},
+		{name: "unknown package", pkg: Package{System: "PYPI", Name: "pkg"}},
+		{name: "error", pkg: Package{System: "PYPI", Name: "broken"}, err: errDepsDevStatus},
+	}
+	for _, tt := range tests {
+		got, err := client.CountDependents(ctx, tt.pkg)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: CountDependents error = %v, want %v", tt.name, err, tt.err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: CountDependents = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
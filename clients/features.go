@@ -0,0 +1,41 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+// Feature is a group of RepoClient APIs a check may need.
+type Feature string
+
+const (
+	// FeatureFileContents is ListFiles and GetFileContent.
+	FeatureFileContents Feature = "file contents"
+	// FeatureCommits is ListCommits.
+	FeatureCommits Feature = "commits"
+	// FeatureBranches is GetDefaultBranch.
+	FeatureBranches Feature = "branches"
+	// FeatureReleases is ListReleases.
+	FeatureReleases Feature = "releases"
+	// FeatureContributors is ListContributors.
+	FeatureContributors Feature = "contributors"
+	// FeatureCheckRuns is ListCheckRunsForRef.
+	FeatureCheckRuns Feature = "check runs"
+)
+
+// FeatureReporter is implemented by RepoClients which can tell, once InitRepo
+// succeeded, whether they support a Feature. The APIs of an unsupported
+// Feature return ErrUnsupportedFeature. RepoClients which do not implement
+// it are assumed to support every Feature.
+type FeatureReporter interface {
+	SupportsFeature(f Feature) bool
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gitearepo
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+)
+
+const (
+	tokenEnv = "GITEA_AUTH_TOKEN"
+	// Number of items requested per page of list APIs.
+	pageSize = 50
+)
+
+// apiClient calls the REST API of a Gitea instance.
+type apiClient struct {
+	client  *forge.Client
+	baseURL string
+}
+
+func newAPIClient(httpClient *http.Client, baseURL string) *apiClient {
+	token := os.Getenv(tokenEnv)
+	return &apiClient{
+		client: forge.NewClient(httpClient, func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("Authorization", "token "+token)
+			}
+		}),
+		baseURL: baseURL,
+	}
+}
+
+func (c *apiClient) requestURL(path string, query url.Values) string {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	return u
+}
+
+// do sends a GET request for path and returns the response body.
+func (c *apiClient) do(ctx context.Context, path string, query url.Values) ([]byte, error) {
+	body, _, err := c.client.Get(ctx, c.requestURL(path, query))
+	return body, err
+}
+
+// get decodes the JSON response for path into v.
+func (c *apiClient) get(ctx context.Context, path string, query url.Values, v interface{}) error {
+	return c.client.GetJSON(ctx, c.requestURL(path, query), v)
+}
+
+// list calls a paginated API until a page is not full or max items were read.
+// appendPage decodes a page and returns its number of items.
+func (c *apiClient) list(ctx context.Context, path string, query url.Values, max int,
+	appendPage func(body []byte) (int, error)) error {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	q.Set("limit", strconv.Itoa(pageSize))
+	read := 0
+	for page := 1; read < max; page++ {
+		q.Set("page", strconv.Itoa(page))
+		body, err := c.do(ctx, path, q)
+		if err != nil {
+			return err
+		}
+		n, err := appendPage(body)
+		if err != nil {
+			return err
+		}
+		read += n
+		if n < pageSize {
+			break
+		}
+	}
+	return nil
+}
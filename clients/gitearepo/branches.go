@@ -0,0 +1,220 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gitearepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// Maximum number of branches listed.
+const branchesToAnalyze = 500
+
+type branch struct {
+	Name      string `json:"name"`
+	Protected bool   `json:"protected"`
+}
+
+// See https://try.gitea.io/api/swagger#/repository/repoListBranchProtection.
+// Reading branch protections requires admin access to the repo.
+type branchProtection struct {
+	// RuleName is a branch name or, since Gitea 1.19, a glob pattern.
+	RuleName   string `json:"rule_name"`
+	BranchName string `json:"branch_name"`
+	// Only reported by Forgejo and Gitea 1.22+. Before, protected branches rejected force pushes.
+	EnableForcePush       *bool    `json:"enable_force_push"`
+	EnableStatusCheck     bool     `json:"enable_status_check"`
+	StatusCheckContexts   []string `json:"status_check_contexts"`
+	RequiredApprovals     int32    `json:"required_approvals"`
+	DismissStaleApprovals bool     `json:"dismiss_stale_approvals"`
+	BlockOnOutdatedBranch bool     `json:"block_on_outdated_branch"`
+	RequireSignedCommits  bool     `json:"require_signed_commits"`
+}
+
+type branchesHandler struct {
+	api           *apiClient
+	once          *forge.Once
+	owner         string
+	repo          string
+	defaultBranch string
+	// linearHistory is set if the repo only allows merge styles which do not create merge commits.
+	linearHistory bool
+	branches      []*clients.BranchRef
+}
+
+func (handler *branchesHandler) init(r *repository) {
+	handler.owner = r.Owner.Login
+	handler.repo = r.Name
+	handler.defaultBranch = r.DefaultBranch
+	handler.linearHistory = !r.AllowMergeCommits && !r.AllowRebaseExplicit
+	handler.once = new(forge.Once)
+}
+
+func (handler *branchesHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		base := fmt.Sprintf("/repos/%s/%s", handler.owner, handler.repo)
+		var branches []branch
+		err := handler.api.list(ctx, base+"/branches", nil, branchesToAnalyze,
+			func(body []byte) (int, error) {
+				var page []branch
+				if err := json.Unmarshal(body, &page); err != nil {
+					return 0, fmt.Errorf("json.Unmarshal: %w", err)
+				}
+				branches = append(branches, page...)
+				return len(page), nil
+			})
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list branches: %v", err))
+		}
+
+		var protections []branchProtection
+		err = handler.api.get(ctx, base+"/branch_protections", nil, &protections)
+		switch {
+		// Without admin access, only whether branches are protected is known.
+		case forge.HasStatus(err, http.StatusForbidden, http.StatusNotFound, http.StatusUnauthorized):
+			protections = nil
+		case err != nil:
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list branch protections: %v", err))
+		}
+
+		handler.branches = nil
+		for i := range branches {
+			handler.branches = append(handler.branches,
+				branchFrom(&branches[i], protections, handler.linearHistory))
+		}
+		return nil
+	})
+}
+
+func (handler *branchesHandler) listBranches(ctx context.Context) ([]*clients.BranchRef, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during branchesHandler.setup: %w", err)
+	}
+	return handler.branches, nil
+}
+
+func (handler *branchesHandler) getDefaultBranch(ctx context.Context) (*clients.BranchRef, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during branchesHandler.setup: %w", err)
+	}
+	for _, b := range handler.branches {
+		if *b.Name == handler.defaultBranch {
+			return b, nil
+		}
+	}
+	return nil, sce.WithMessage(sce.ErrScorecardInternal,
+		fmt.Sprintf("default branch %s not found", handler.defaultBranch))
+}
+
+// getBranch returns a branch by name. Gitea redirects the requests
+// for renamed branches to their current name.
+func (handler *branchesHandler) getBranch(ctx context.Context, name string) (*clients.BranchRef, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during branchesHandler.setup: %w", err)
+	}
+	if b, err := clients.FindBranch(handler.branches, name); err == nil {
+		return b, nil
+	}
+	var b branch
+	u := fmt.Sprintf("/repos/%s/%s/branches/%s", handler.owner, handler.repo, url.PathEscape(name))
+	err := handler.api.get(ctx, u, nil, &b)
+	if forge.HasStatus(err, http.StatusNotFound) {
+		return nil, fmt.Errorf("%w: %s", clients.ErrBranchNotFound, name)
+	}
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("get branch: %v", err))
+	}
+	// Listed branches also have the protection settings.
+	if ret, err := clients.FindBranch(handler.branches, b.Name); err == nil {
+		return ret, nil
+	}
+	return branchFrom(&b, nil, handler.linearHistory), nil
+}
+
+// matchProtection returns the protection rule applying to a branch, if any.
+// Rules naming the branch take precedence over glob patterns.
+func matchProtection(name string, protections []branchProtection) *branchProtection {
+	var ret *branchProtection
+	for i := range protections {
+		p := &protections[i]
+		rule := p.RuleName
+		if rule == "" {
+			rule = p.BranchName
+		}
+		if rule == name {
+			return p
+		}
+		if matched, err := path.Match(rule, name); err == nil && matched && ret == nil {
+			ret = p
+		}
+	}
+	return ret
+}
+
+func branchFrom(b *branch, protections []branchProtection, linearHistory bool) *clients.BranchRef {
+	name := b.Name
+	protected := b.Protected
+	ret := &clients.BranchRef{
+		Name:      &name,
+		Protected: &protected,
+	}
+	if !protected {
+		return ret
+	}
+
+	rule := &ret.BranchProtectionRule
+	// Protected branches cannot be deleted.
+	rule.AllowDeletions = newFalse()
+	p := matchProtection(name, protections)
+	if p == nil {
+		return ret
+	}
+
+	allowForcePushes := p.EnableForcePush != nil && *p.EnableForcePush
+	rule.AllowForcePushes = &allowForcePushes
+	rule.RequireLinearHistory = &linearHistory
+	requiresSignatures := p.RequireSignedCommits
+	rule.RequiresSignatures = &requiresSignatures
+
+	requiresStatusChecks := p.EnableStatusCheck
+	upToDate := p.BlockOnOutdatedBranch
+	rule.CheckRules = clients.StatusChecksRule{
+		RequiresStatusChecks: &requiresStatusChecks,
+		UpToDateBeforeMerge:  &upToDate,
+	}
+	if requiresStatusChecks {
+		rule.CheckRules.Contexts = p.StatusCheckContexts
+	}
+
+	approvals := p.RequiredApprovals
+	dismissStale := p.DismissStaleApprovals
+	rule.RequiredPullRequestReviews = clients.PullRequestReviewRule{
+		RequiredApprovingReviewCount: &approvals,
+		DismissStaleReviews:          &dismissStale,
+	}
+	return ret
+}
+
+func newFalse() *bool {
+	ret := false
+	return &ret
+}
@@ -0,0 +1,287 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package gitearepo implements clients.RepoClient for Gitea and Forgejo,
+// e.g., for repos hosted on Codeberg.
+package gitearepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+)
+
+var errInputRepoType = errors.New("input repo should be of type repoURL")
+
+type repository struct {
+	Name                string `json:"name"`
+	Owner               user   `json:"owner"`
+	DefaultBranch       string `json:"default_branch"`
+	Archived            bool   `json:"archived"`
+	Private             bool   `json:"private"`
+	AllowMergeCommits   bool   `json:"allow_merge_commits"`
+	AllowRebaseExplicit bool   `json:"allow_rebase_explicit"`
+	Fork                bool   `json:"fork"`
+	Mirror              bool   `json:"mirror"`
+	OriginalURL         string `json:"original_url"`
+	Parent              *struct {
+		FullName  string    `json:"full_name"`
+		Archived  bool      `json:"archived"`
+		UpdatedAt time.Time `json:"updated_at"`
+	} `json:"parent"`
+}
+
+// Client is Gitea-specific implementation of RepoClient.
+type Client struct {
+	host     string
+	repo     *repository
+	api      *apiClient
+	contents *contentsHandler
+	commits  *commitsHandler
+	branches *branchesHandler
+	releases *releasesHandler
+	issues   *issuesHandler
+	statuses *statusesHandler
+	ctx      context.Context
+	// origin is the Client a view returned by WithCallContext was made from.
+	origin *Client
+}
+
+// InitRepo sets up the Gitea repo.
+func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string) error {
+	if client.origin != nil {
+		return client.origin.initRepo(client.ctx, inputRepo, commitSHA)
+	}
+	return client.initRepo(client.ctx, inputRepo, commitSHA)
+}
+
+func (client *Client) initRepo(ctx context.Context, inputRepo clients.Repo, commitSHA string) error {
+	giteaRepo, ok := inputRepo.(*repoURL)
+	if !ok {
+		return fmt.Errorf("%w: %v", errInputRepoType, inputRepo)
+	}
+	client.host = giteaRepo.host
+	client.api.baseURL = giteaRepo.apiURL()
+
+	// Sanity check.
+	var repo repository
+	path := fmt.Sprintf("/repos/%s/%s", giteaRepo.owner, giteaRepo.repo)
+	if err := client.api.get(ctx, path, nil, &repo); err != nil {
+		return forge.RepoError(err, "")
+	}
+	client.repo = &repo
+
+	if commitSHA == clients.HeadSHA {
+		var b struct {
+			Commit struct {
+				ID string `json:"id"`
+			} `json:"commit"`
+		}
+		path := fmt.Sprintf("/repos/%s/%s/branches/%s", repo.Owner.Login, repo.Name, url.PathEscape(repo.DefaultBranch))
+		if err := client.api.get(ctx, path, nil, &b); err != nil {
+			return forge.RepoError(err, "default branch")
+		}
+		commitSHA = b.Commit.ID
+	}
+
+	client.contents.init(repo.Owner.Login, repo.Name, commitSHA)
+	client.commits.init(repo.Owner.Login, repo.Name, commitSHA)
+	client.branches.init(&repo)
+	client.releases.init(repo.Owner.Login, repo.Name)
+	client.issues.init(repo.Owner.Login, repo.Name)
+	client.statuses.init(repo.Owner.Login, repo.Name)
+	return nil
+}
+
+// WithCallContext implements clients.CallContextClient: the view makes its API
+// calls with ctx and shares the data fetched with the client.
+func (client *Client) WithCallContext(ctx context.Context) clients.RepoClient {
+	view := *client
+	view.ctx = ctx
+	view.origin = client
+	if client.origin != nil {
+		view.origin = client.origin
+	}
+	return &view
+}
+
+// URI implements RepoClient.URI.
+func (client *Client) URI() string {
+	return fmt.Sprintf("%s/%s/%s", client.host, client.repo.Owner.Login, client.repo.Name)
+}
+
+// IsArchived implements RepoClient.IsArchived.
+func (client *Client) IsArchived() (bool, error) {
+	return client.repo.Archived, nil
+}
+
+// IsPrivate implements clients.VisibilityReporter.
+func (client *Client) IsPrivate() bool {
+	return client.repo.Private
+}
+
+// Upstream implements clients.UpstreamReporter.
+// Gitea does not report when repos were last pushed to, so the last update of the parent is used.
+func (client *Client) Upstream() *clients.Upstream {
+	if client.repo.Mirror {
+		return &clients.Upstream{URI: client.repo.OriginalURL, Mirror: true}
+	}
+	if !client.repo.Fork || client.repo.Parent == nil {
+		return nil
+	}
+	return &clients.Upstream{
+		URI:      fmt.Sprintf("%s/%s", client.host, client.repo.Parent.FullName),
+		PushedAt: client.repo.Parent.UpdatedAt,
+		Archived: client.repo.Parent.Archived,
+	}
+}
+
+// SupportsFeature implements clients.FeatureReporter.
+func (client *Client) SupportsFeature(f clients.Feature) bool {
+	return f != clients.FeatureContributors && f != clients.FeatureCheckRuns
+}
+
+// ListFiles implements RepoClient.ListFiles.
+func (client *Client) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
+	return client.contents.listFiles(client.ctx, predicate)
+}
+
+// GetFileContent implements RepoClient.GetFileContent.
+func (client *Client) GetFileContent(filename string) ([]byte, error) {
+	return client.contents.getFileContent(client.ctx, filename)
+}
+
+// ListMergedPRs implements RepoClient.ListMergedPRs.
+func (client *Client) ListMergedPRs() ([]clients.PullRequest, error) {
+	return client.commits.listMergedPRs(client.ctx)
+}
+
+// ListBranches implements RepoClient.ListBranches.
+func (client *Client) ListBranches() ([]*clients.BranchRef, error) {
+	return client.branches.listBranches(client.ctx)
+}
+
+// GetDefaultBranch implements RepoClient.GetDefaultBranch.
+func (client *Client) GetDefaultBranch() (*clients.BranchRef, error) {
+	return client.branches.getDefaultBranch(client.ctx)
+}
+
+// GetBranch implements RepoClient.GetBranch.
+func (client *Client) GetBranch(name string) (*clients.BranchRef, error) {
+	return client.branches.getBranch(client.ctx, name)
+}
+
+// ListBranchUpdates implements RepoClient.ListBranchUpdates.
+func (client *Client) ListBranchUpdates(branch string) ([]clients.BranchUpdate, error) {
+	return nil, fmt.Errorf("ListBranchUpdates: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListCommits implements RepoClient.ListCommits.
+func (client *Client) ListCommits() ([]clients.Commit, error) {
+	return client.commits.listCommits(client.ctx)
+}
+
+// ListIssues implements RepoClient.ListIssues.
+func (client *Client) ListIssues() ([]clients.Issue, error) {
+	return client.issues.listIssues(client.ctx)
+}
+
+// ListReleases implements RepoClient.ListReleases.
+func (client *Client) ListReleases() ([]clients.Release, error) {
+	return client.releases.getReleases(client.ctx)
+}
+
+// ListTags implements RepoClient.ListTags.
+func (client *Client) ListTags() ([]clients.Tag, error) {
+	return nil, fmt.Errorf("ListTags: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListBranchesForCommit implements RepoClient.ListBranchesForCommit.
+func (client *Client) ListBranchesForCommit(sha string) ([]string, error) {
+	return nil, fmt.Errorf("ListBranchesForCommit: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListContributors implements RepoClient.ListContributors.
+// Gitea has no API listing the contributors of a repo.
+func (client *Client) ListContributors() ([]clients.Contributor, error) {
+	return nil, fmt.Errorf("ListContributors: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListSuccessfulWorkflowRuns implements RepoClient.ListSuccessfulWorkflowRuns.
+func (client *Client) ListSuccessfulWorkflowRuns(filename string) ([]clients.WorkflowRun, error) {
+	return nil, fmt.Errorf("ListSuccessfulWorkflowRuns: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListCheckRunsForRef implements RepoClient.ListCheckRunsForRef.
+// Gitea reports CI results as statuses, see ListStatuses.
+func (client *Client) ListCheckRunsForRef(ref string) ([]clients.CheckRun, error) {
+	return nil, fmt.Errorf("ListCheckRunsForRef: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListStatuses implements RepoClient.ListStatuses.
+func (client *Client) ListStatuses(ref string) ([]clients.Status, error) {
+	return client.statuses.listStatuses(client.ctx, ref)
+}
+
+// ListAttestations implements RepoClient.ListAttestations.
+func (client *Client) ListAttestations(subjectDigest string) ([]clients.Attestation, error) {
+	return nil, fmt.Errorf("ListAttestations: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListContainerImages implements RepoClient.ListContainerImages.
+func (client *Client) ListContainerImages() ([]clients.ContainerImage, error) {
+	return nil, fmt.Errorf("ListContainerImages: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListSecurityAdvisories implements RepoClient.ListSecurityAdvisories.
+func (client *Client) ListSecurityAdvisories() ([]clients.SecurityAdvisory, error) {
+	return nil, fmt.Errorf("ListSecurityAdvisories: %w", clients.ErrUnsupportedFeature)
+}
+
+// Search implements RepoClient.Search.
+func (client *Client) Search(request clients.SearchRequest) (clients.SearchResponse, error) {
+	return clients.SearchResponse{}, fmt.Errorf("Search: %w", clients.ErrUnsupportedFeature)
+}
+
+// Close implements RepoClient.Close.
+func (client *Client) Close() error {
+	return nil
+}
+
+// CreateGiteaRepoClient returns a Client which implements RepoClient interface.
+// Requests are authenticated with the token in GITEA_AUTH_TOKEN, if set.
+func CreateGiteaRepoClient(ctx context.Context, logger *zap.Logger) clients.RepoClient {
+	return createGiteaRepoClient(ctx, forge.NewHTTPClient(logger))
+}
+
+func createGiteaRepoClient(ctx context.Context, httpClient *http.Client) *Client {
+	api := newAPIClient(httpClient, "")
+	return &Client{
+		ctx:      ctx,
+		api:      api,
+		contents: &contentsHandler{api: api},
+		commits:  &commitsHandler{api: api},
+		branches: &branchesHandler{api: api},
+		releases: &releasesHandler{api: api},
+		issues:   &issuesHandler{api: api},
+		statuses: &statusesHandler{api: api},
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gitearepo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge/forgetest"
+)
+
+// newTestClient returns a client for the repo owner/repo served by a fake
+// Gitea API answering the given paths with canned JSON.
+func newTestClient(t *testing.T, responses map[string]string) clients.RepoClient {
+	t.Helper()
+	server := forgetest.NewServer(t, responses, func(w http.ResponseWriter, r *http.Request) string {
+		return strings.TrimPrefix(r.URL.Path, "/api/v1")
+	})
+
+	client := createGiteaRepoClient(context.Background(), server.Client())
+	repo := &repoURL{
+		scheme: "http",
+		host:   strings.TrimPrefix(server.URL, "http://"),
+		owner:  "owner",
+		repo:   "repo",
+	}
+	if err := client.InitRepo(repo, clients.HeadSHA); err != nil {
+		t.Fatalf("InitRepo: %v", err)
+	}
+	return client
+}
+
+var baseResponses = map[string]string{
+	"/repos/owner/repo": `{"name": "repo", "owner": {"login": "owner"}, "default_branch": "main",
+		"archived": true, "private": true, "allow_merge_commits": false, "allow_rebase_explicit": false}`,
+	"/repos/owner/repo/branches/main": `{"name": "main", "commit": {"id": "sha2"}}`,
+	"/repos/owner/repo/branches": `[
+		{"name": "main", "protected": true},
+		{"name": "release/v1", "protected": true},
+		{"name": "feature", "protected": false}]`,
+	"/repos/owner/repo/commits": `[
+		{"sha": "sha2", "commit": {"message": "Merge PR", "committer": {"date": "2022-01-02T00:00:00Z"}},
+		 "author": {"login": "alice"}, "committer": null},
+		{"sha": "sha1", "commit": {"message": "Direct push", "committer": {"date": "2022-01-01T00:00:00Z"}},
+		 "author": null, "committer": null}]`,
+	"/repos/owner/repo/pulls": `[
+		{"number": 2, "merged": true, "merged_at": "2022-01-02T00:00:00Z", "merge_commit_sha": "sha2",
+		 "head": {"sha": "head2"}, "labels": [{"name": "bug"}], "user": {"login": "bob"}},
+		{"number": 1, "merged": false, "head": {"sha": "head1"}}]`,
+	"/repos/owner/repo/pulls/2/reviews": `[{"state": "APPROVED", "user": {"login": "alice"}}]`,
+	"/repos/owner/repo/git/trees/sha2": `{"tree": [
+		{"path": "README.md", "type": "blob"},
+		{"path": ".gitea", "type": "tree"},
+		{"path": ".gitea/workflows/ci.yml", "type": "blob"}], "truncated": false}`,
+	"/repos/owner/repo/raw/.gitea/workflows/ci.yml": `on: push`,
+	"/repos/owner/repo/releases": `[{"tag_name": "v1.0.0", "target_commitish": "release/v1",
+		"url": "https://codeberg.org/api/v1/repos/owner/repo/releases/1",
+		"assets": [{"name": "bin.tar.gz", "browser_download_url": "https://codeberg.org/bin.tar.gz"}]}]`,
+	"/repos/owner/repo/commits/sha2/statuses": `[{"status": "success", "context": "ci/woodpecker"}]`,
+}
+
+func TestClient(t *testing.T) {
+	t.Parallel()
+	responses := map[string]string{
+		"/repos/owner/repo/branch_protections": `[
+			{"rule_name": "main", "enable_status_check": true, "status_check_contexts": ["ci/*"],
+			 "required_approvals": 2, "dismiss_stale_approvals": true, "require_signed_commits": true},
+			{"rule_name": "release/*", "enable_force_push": true}]`,
+		// Branch renamed to main.
+		"/repos/owner/repo/branches/master": `{"name": "main", "commit": {"id": "sha2"}}`,
+	}
+	for k, v := range baseResponses {
+		responses[k] = v
+	}
+	client := newTestClient(t, responses)
+
+	if archived, err := client.IsArchived(); err != nil || !archived {
+		t.Errorf("IsArchived: got %v, %v", archived, err)
+	}
+	if reporter, ok := client.(clients.VisibilityReporter); !ok || !reporter.IsPrivate() {
+		t.Error("IsPrivate: got false")
+	}
+
+	branches, err := client.ListBranches()
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	trueVal, falseVal := true, false
+	var zero, two int32 = 0, 2
+	main, release, feature := "main", "release/v1", "feature"
+	expectedBranches := []*clients.BranchRef{
+		{
+			Name:      &main,
+			Protected: &trueVal,
+			BranchProtectionRule: clients.BranchProtectionRule{
+				AllowDeletions:       &falseVal,
+				AllowForcePushes:     &falseVal,
+				RequireLinearHistory: &trueVal,
+				RequiresSignatures:   &trueVal,
+				CheckRules: clients.StatusChecksRule{
+					RequiresStatusChecks: &trueVal,
+					UpToDateBeforeMerge:  &falseVal,
+					Contexts:             []string{"ci/*"},
+				},
+				RequiredPullRequestReviews: clients.PullRequestReviewRule{
+					RequiredApprovingReviewCount: &two,
+					DismissStaleReviews:          &trueVal,
+				},
+			},
+		},
+		{
+			Name:      &release,
+			Protected: &trueVal,
+			BranchProtectionRule: clients.BranchProtectionRule{
+				AllowDeletions:       &falseVal,
+				AllowForcePushes:     &trueVal,
+				RequireLinearHistory: &trueVal,
+				RequiresSignatures:   &falseVal,
+				CheckRules: clients.StatusChecksRule{
+					RequiresStatusChecks: &falseVal,
+					UpToDateBeforeMerge:  &falseVal,
+				},
+				RequiredPullRequestReviews: clients.PullRequestReviewRule{
+					RequiredApprovingReviewCount: &zero,
+					DismissStaleReviews:          &falseVal,
+				},
+			},
+		},
+		{
+			Name:      &feature,
+			Protected: &falseVal,
+		},
+	}
+	if diff := cmp.Diff(expectedBranches, branches); diff != "" {
+		t.Errorf("ListBranches mismatch (-want +got):\n%s", diff)
+	}
+	if b, err := client.GetDefaultBranch(); err != nil || *b.Name != "main" {
+		t.Errorf("GetDefaultBranch: got %v, %v", b, err)
+	}
+	for name, want := range map[string]*clients.BranchRef{
+		"release/v1": expectedBranches[1],
+		"master":     expectedBranches[0],
+	} {
+		b, err := client.GetBranch(name)
+		if err != nil {
+			t.Fatalf("GetBranch(%s): %v", name, err)
+		}
+		if diff := cmp.Diff(want, b); diff != "" {
+			t.Errorf("GetBranch(%s) mismatch (-want +got):\n%s", name, diff)
+		}
+	}
+	if _, err := client.GetBranch("deleted"); !errors.Is(err, clients.ErrBranchNotFound) {
+		t.Errorf("GetBranch(deleted): got %v, want %v", err, clients.ErrBranchNotFound)
+	}
+
+	commits, err := client.ListCommits()
+	if err != nil {
+		t.Fatalf("ListCommits: %v", err)
+	}
+	if len(commits) != 2 || commits[0].SHA != "sha2" || commits[0].Author.Login != "alice" ||
+		commits[0].AssociatedMergeRequest == nil || commits[0].AssociatedMergeRequest.Number != 2 ||
+		commits[1].AssociatedMergeRequest != nil {
+		t.Errorf("ListCommits: unexpected commits %+v", commits)
+	}
+
+	prs, err := client.ListMergedPRs()
+	if err != nil {
+		t.Fatalf("ListMergedPRs: %v", err)
+	}
+	if len(prs) != 1 || prs[0].Number != 2 || prs[0].MergeCommit.SHA != "sha2" ||
+		len(prs[0].Reviews) != 1 || prs[0].Reviews[0].State != "APPROVED" {
+		t.Errorf("ListMergedPRs: unexpected PRs %+v", prs)
+	}
+
+	files, err := client.ListFiles(func(string) (bool, error) { return true, nil })
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+	if diff := cmp.Diff([]string{"README.md", ".gitea/workflows/ci.yml"}, files); diff != "" {
+		t.Errorf("ListFiles mismatch (-want +got):\n%s", diff)
+	}
+	content, err := client.GetFileContent(".gitea/workflows/ci.yml")
+	if err != nil || string(content) != "on: push" {
+		t.Errorf("GetFileContent: got %q, %v", content, err)
+	}
+	if _, err := client.GetFileContent("missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("GetFileContent: got %v, expected %v", err, os.ErrNotExist)
+	}
+
+	releases, err := client.ListReleases()
+	if err != nil {
+		t.Fatalf("ListReleases: %v", err)
+	}
+	if len(releases) != 1 || releases[0].TargetCommitish != "release/v1" || len(releases[0].Assets) != 1 {
+		t.Errorf("ListReleases: unexpected releases %+v", releases)
+	}
+
+	statuses, err := client.ListStatuses("sha2")
+	if err != nil {
+		t.Fatalf("ListStatuses: %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].State != "success" || statuses[0].Context != "ci/woodpecker" {
+		t.Errorf("ListStatuses: unexpected statuses %+v", statuses)
+	}
+
+	if _, err := client.ListContributors(); !errors.Is(err, clients.ErrUnsupportedFeature) {
+		t.Errorf("ListContributors: got %v, expected %v", err, clients.ErrUnsupportedFeature)
+	}
+	if reporter, ok := client.(clients.UpstreamReporter); !ok || reporter.Upstream() != nil {
+		t.Error("Upstream: expected no upstream")
+	}
+}
+
+func TestClient_Upstream(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		repo     string
+		upstream string
+		expected clients.Upstream
+	}{
+		{
+			name: "fork",
+			repo: `{"name": "repo", "owner": {"login": "owner"}, "default_branch": "main", "fork": true,
+				"parent": {"full_name": "upstream/repo", "archived": true, "updated_at": "2022-01-03T00:00:00Z"}}`,
+			upstream: "upstream/repo",
+			expected: clients.Upstream{
+				PushedAt: time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC),
+				Archived: true,
+			},
+		},
+		{
+			name: "mirror",
+			repo: `{"name": "repo", "owner": {"login": "owner"}, "default_branch": "main", "mirror": true,
+				"original_url": "https://example.com/repo.git"}`,
+			expected: clients.Upstream{
+				URI:    "https://example.com/repo.git",
+				Mirror: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			responses := map[string]string{}
+			for k, v := range baseResponses {
+				responses[k] = v
+			}
+			responses["/repos/owner/repo"] = tt.repo
+			client := newTestClient(t, responses)
+
+			if tt.upstream != "" {
+				// Forks on the same host, which the test server listens on.
+				tt.expected.URI = strings.TrimSuffix(client.URI(), "owner/repo") + tt.upstream
+			}
+			reporter, ok := client.(clients.UpstreamReporter)
+			if !ok || reporter.Upstream() == nil {
+				t.Fatal("Upstream: expected an upstream")
+			}
+			if diff := cmp.Diff(tt.expected, *reporter.Upstream()); diff != "" {
+				t.Errorf("Upstream mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClient_NoAdminAccess(t *testing.T) {
+	t.Parallel()
+	// branch_protections is not served, as for tokens without admin access.
+	client := newTestClient(t, baseResponses)
+
+	b, err := client.GetDefaultBranch()
+	if err != nil {
+		t.Fatalf("GetDefaultBranch: %v", err)
+	}
+	falseVal := false
+	expected := clients.BranchProtectionRule{AllowDeletions: &falseVal}
+	if !*b.Protected {
+		t.Errorf("GetDefaultBranch: branch not protected")
+	}
+	if diff := cmp.Diff(expected, b.BranchProtectionRule); diff != "" {
+		t.Errorf("GetDefaultBranch mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_WithCallContext(t *testing.T) {
+	t.Parallel()
+	client := newTestClient(t, baseResponses)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	view := clients.WithCallContext(cancelled, client)
+	if _, err := view.ListReleases(); err == nil {
+		t.Errorf("ListReleases: got no error with a cancelled context")
+	}
+	// The listing interrupted in the view is run again by the client.
+	releases, err := client.ListReleases()
+	if err != nil {
+		t.Fatalf("ListReleases: %v", err)
+	}
+	if len(releases) != 1 {
+		t.Errorf("ListReleases: got %d releases, want 1", len(releases))
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gitearepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const (
+	commitsToAnalyze      = 30
+	pullRequestsToAnalyze = 30
+)
+
+type user struct {
+	Login string `json:"login"`
+}
+
+type commit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Message   string `json:"message"`
+		Committer struct {
+			Date time.Time `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+	// Author and Committer are nil if the emails do not match any user.
+	Author    *user `json:"author"`
+	Committer *user `json:"committer"`
+}
+
+type pullRequest struct {
+	Number         int        `json:"number"`
+	Merged         bool       `json:"merged"`
+	MergedAt       *time.Time `json:"merged_at"`
+	MergeCommitSHA *string    `json:"merge_commit_sha"`
+	Head           struct {
+		SHA string `json:"sha"`
+	} `json:"head"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	User *user `json:"user"`
+}
+
+type review struct {
+	State string `json:"state"`
+	User  *user  `json:"user"`
+}
+
+// commitsHandler lists the latest commits and merged PRs of the repo.
+type commitsHandler struct {
+	api       *apiClient
+	owner     string
+	repo      string
+	commitSHA string
+	commits   *forge.Commits
+}
+
+func (handler *commitsHandler) init(owner, repo, commitSHA string) {
+	handler.owner = owner
+	handler.repo = repo
+	handler.commitSHA = commitSHA
+	handler.commits = forge.NewCommits(handler.list)
+}
+
+func (handler *commitsHandler) list(ctx context.Context) ([]clients.Commit, []clients.PullRequest, error) {
+	base := fmt.Sprintf("/repos/%s/%s", handler.owner, handler.repo)
+	query := url.Values{"sha": {handler.commitSHA}, "stat": {"false"}, "files": {"false"}}
+	var commits []commit
+	err := handler.api.list(ctx, base+"/commits", query, commitsToAnalyze,
+		func(body []byte) (int, error) {
+			var page []commit
+			if err := json.Unmarshal(body, &page); err != nil {
+				return 0, fmt.Errorf("json.Unmarshal: %w", err)
+			}
+			commits = append(commits, page...)
+			return len(page), nil
+		})
+	if err != nil {
+		return nil, nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list commits: %v", err))
+	}
+	if len(commits) > commitsToAnalyze {
+		commits = commits[:commitsToAnalyze]
+	}
+
+	var prs []pullRequest
+	query = url.Values{"state": {"closed"}, "sort": {"recentupdate"}}
+	err = handler.api.list(ctx, base+"/pulls", query, pullRequestsToAnalyze,
+		func(body []byte) (int, error) {
+			var page []pullRequest
+			if err := json.Unmarshal(body, &page); err != nil {
+				return 0, fmt.Errorf("json.Unmarshal: %w", err)
+			}
+			for i := range page {
+				if page[i].Merged && len(prs) < pullRequestsToAnalyze {
+					prs = append(prs, page[i])
+				}
+			}
+			return len(page), nil
+		})
+	if err != nil {
+		return nil, nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list pulls: %v", err))
+	}
+
+	var merged []clients.PullRequest
+	for i := range prs {
+		var reviews []review
+		path := fmt.Sprintf("%s/pulls/%d/reviews", base, prs[i].Number)
+		if err := handler.api.get(ctx, path, nil, &reviews); err != nil {
+			return nil, nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list reviews: %v", err))
+		}
+		merged = append(merged, pullRequestFrom(&prs[i], reviews))
+	}
+	return commitsFrom(commits, merged), merged, nil
+}
+
+func (handler *commitsHandler) listCommits(ctx context.Context) ([]clients.Commit, error) {
+	return handler.commits.ListCommits(ctx)
+}
+
+func (handler *commitsHandler) listMergedPRs(ctx context.Context) ([]clients.PullRequest, error) {
+	return handler.commits.ListMergedPRs(ctx)
+}
+
+func login(u *user) clients.User {
+	if u == nil {
+		return clients.User{}
+	}
+	return clients.User{Login: u.Login}
+}
+
+func pullRequestFrom(pr *pullRequest, reviews []review) clients.PullRequest {
+	ret := clients.PullRequest{
+		Number:  pr.Number,
+		HeadSHA: pr.Head.SHA,
+		Author:  login(pr.User),
+	}
+	if pr.MergedAt != nil {
+		ret.MergedAt = *pr.MergedAt
+	}
+	if pr.MergeCommitSHA != nil {
+		ret.MergeCommit.SHA = *pr.MergeCommitSHA
+	}
+	for _, l := range pr.Labels {
+		ret.Labels = append(ret.Labels, clients.Label{Name: l.Name})
+	}
+	for _, r := range reviews {
+		// Gitea reports "APPROVED", "REQUEST_CHANGES", "COMMENT"...
+		ret.Reviews = append(ret.Reviews, clients.Review{State: r.State, Author: login(r.User)})
+	}
+	return ret
+}
+
+// commitsFrom converts the commits, associating them with the merged PRs
+// whose merge commit they are.
+func commitsFrom(commits []commit, prs []clients.PullRequest) []clients.Commit {
+	ret := []clients.Commit{}
+	for i := range commits {
+		c := &commits[i]
+		ret = append(ret, clients.Commit{
+			CommittedDate:          c.Commit.Committer.Date,
+			Message:                c.Commit.Message,
+			SHA:                    c.SHA,
+			Author:                 login(c.Author),
+			Committer:              login(c.Committer),
+			AssociatedMergeRequest: forge.MergedBy(c.SHA, prs),
+		})
+	}
+	return ret
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gitearepo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// Maximum number of tree entries listed, which bounds the number of pages read.
+const treeEntriesToList = 100000
+
+type tree struct {
+	Tree []struct {
+		Path string `json:"path"`
+		Type string `json:"type"`
+	} `json:"tree"`
+	Truncated bool `json:"truncated"`
+}
+
+// contentsHandler lists the files of the repo at a commit and reads their content.
+type contentsHandler struct {
+	api       *apiClient
+	owner     string
+	repo      string
+	commitSHA string
+	files     *forge.Files
+}
+
+func (handler *contentsHandler) init(owner, repo, commitSHA string) {
+	handler.owner = owner
+	handler.repo = repo
+	handler.commitSHA = commitSHA
+	handler.files = forge.NewFiles(handler.listTree, handler.readFile)
+}
+
+func (handler *contentsHandler) listTree(ctx context.Context) ([]string, error) {
+	path := fmt.Sprintf("/repos/%s/%s/git/trees/%s", handler.owner, handler.repo, url.PathEscape(handler.commitSHA))
+	query := url.Values{"recursive": {"true"}, "per_page": {strconv.Itoa(pageSize * pageSize)}}
+	var files []string
+	listed := 0
+	for page := 1; listed < treeEntriesToList; page++ {
+		query.Set("page", strconv.Itoa(page))
+		var t tree
+		if err := handler.api.get(ctx, path, query, &t); err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("get tree: %v", err))
+		}
+		for _, e := range t.Tree {
+			if e.Type == "blob" {
+				files = append(files, e.Path)
+			}
+		}
+		listed += len(t.Tree)
+		if !t.Truncated || len(t.Tree) == 0 {
+			break
+		}
+	}
+	return files, nil
+}
+
+func (handler *contentsHandler) readFile(ctx context.Context, filename string) ([]byte, error) {
+	path := fmt.Sprintf("/repos/%s/%s/raw/%s", handler.owner, handler.repo, forge.EscapePath(filename))
+	return handler.api.do(ctx, path, url.Values{"ref": {handler.commitSHA}})
+}
+
+func (handler *contentsHandler) listFiles(ctx context.Context, predicate func(string) (bool, error)) ([]string, error) {
+	return handler.files.List(ctx, predicate)
+}
+
+func (handler *contentsHandler) getFileContent(ctx context.Context, filename string) ([]byte, error) {
+	return handler.files.Read(ctx, filename)
+}
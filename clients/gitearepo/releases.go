@@ -0,0 +1,94 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gitearepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const releasesToAnalyze = 30
+
+type release struct {
+	TagName         string `json:"tag_name"`
+	TargetCommitish string `json:"target_commitish"`
+	URL             string `json:"url"`
+	Assets          []struct {
+		Name               string `json:"name"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+type releasesHandler struct {
+	api      *apiClient
+	once     *forge.Once
+	owner    string
+	repo     string
+	releases []clients.Release
+}
+
+func (handler *releasesHandler) init(owner, repo string) {
+	handler.owner = owner
+	handler.repo = repo
+	handler.once = new(forge.Once)
+}
+
+func (handler *releasesHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		path := fmt.Sprintf("/repos/%s/%s/releases", handler.owner, handler.repo)
+		handler.releases = nil
+		err := handler.api.list(ctx, path, nil, releasesToAnalyze,
+			func(body []byte) (int, error) {
+				var page []release
+				if err := json.Unmarshal(body, &page); err != nil {
+					return 0, fmt.Errorf("json.Unmarshal: %w", err)
+				}
+				for i := range page {
+					handler.releases = append(handler.releases, releaseFrom(&page[i]))
+				}
+				return len(page), nil
+			})
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list releases: %v", err))
+		}
+		return nil
+	})
+}
+
+func (handler *releasesHandler) getReleases(ctx context.Context) ([]clients.Release, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during releasesHandler.setup: %w", err)
+	}
+	return handler.releases, nil
+}
+
+func releaseFrom(r *release) clients.Release {
+	ret := clients.Release{
+		TagName:         r.TagName,
+		URL:             r.URL,
+		TargetCommitish: r.TargetCommitish,
+	}
+	for _, a := range r.Assets {
+		ret.Assets = append(ret.Assets, clients.ReleaseAsset{
+			Name: a.Name,
+			URL:  a.BrowserDownloadURL,
+		})
+	}
+	return ret
+}
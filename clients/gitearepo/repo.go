@@ -0,0 +1,139 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gitearepo
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const (
+	// Comma-separated list of the hosts of self-hosted Gitea and Forgejo instances.
+	hostsEnv = "SCORECARD_GITEA_HOSTS"
+	// Name of the repo holding the profile of an org.
+	giteaOrgRepo = ".profile"
+)
+
+// Public instances which are always supported.
+var knownHosts = []string{"codeberg.org", "gitea.com"}
+
+type repoURL struct {
+	scheme, host, owner, repo string
+	metadata                  []string
+}
+
+// Parses input string into repoURL struct.
+// Accepts "host/owner/repo", with an optional scheme.
+func (r *repoURL) parse(input string) error {
+	t := input
+	// Allow skipping scheme for ease-of-use, default to https.
+	if !strings.Contains(t, "://") {
+		t = "https://" + t
+	}
+
+	u, e := url.Parse(t)
+	if e != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("url.Parse: %v", e))
+	}
+
+	const splitLen = 2
+	split := strings.SplitN(strings.Trim(u.Path, "/"), "/", splitLen)
+	if len(split) != splitLen {
+		return sce.WithMessage(sce.ErrorInvalidURL, fmt.Sprintf("%v. Expected full repository url", input))
+	}
+
+	r.scheme, r.host, r.owner, r.repo = u.Scheme, u.Host, split[0], split[1]
+	return nil
+}
+
+// URI implements Repo.URI().
+func (r *repoURL) URI() string {
+	return fmt.Sprintf("%s/%s/%s", r.host, r.owner, r.repo)
+}
+
+// String implements Repo.String.
+func (r *repoURL) String() string {
+	return fmt.Sprintf("%s-%s-%s", r.host, r.owner, r.repo)
+}
+
+// Org implements Repo.Org.
+func (r *repoURL) Org() clients.Repo {
+	return &repoURL{
+		scheme: r.scheme,
+		host:   r.host,
+		owner:  r.owner,
+		repo:   giteaOrgRepo,
+	}
+}
+
+// IsValid implements Repo.IsValid.
+func (r *repoURL) IsValid() error {
+	if !isGiteaHost(r.host) {
+		return sce.WithMessage(sce.ErrRepoUnsupportedHost, r.host)
+	}
+
+	if strings.TrimSpace(r.owner) == "" || strings.TrimSpace(r.repo) == "" ||
+		strings.Contains(r.repo, "/") {
+		return sce.WithMessage(sce.ErrorInvalidURL,
+			fmt.Sprintf("%v. Expected the full repository url", r.URI()))
+	}
+	return nil
+}
+
+// AppendMetadata implements Repo.AppendMetadata.
+func (r *repoURL) AppendMetadata(metadata ...string) {
+	r.metadata = append(r.metadata, metadata...)
+}
+
+// Metadata implements Repo.Metadata.
+func (r *repoURL) Metadata() []string {
+	return r.metadata
+}
+
+// apiURL returns the base URL of the Gitea API of the instance hosting the repo.
+func (r *repoURL) apiURL() string {
+	return fmt.Sprintf("%s://%s/api/v1", r.scheme, r.host)
+}
+
+func isGiteaHost(host string) bool {
+	hosts := append([]string{}, knownHosts...)
+	if env := os.Getenv(hostsEnv); env != "" {
+		hosts = append(hosts, strings.Split(env, ",")...)
+	}
+	for _, h := range hosts {
+		if strings.EqualFold(strings.TrimSpace(h), host) {
+			return true
+		}
+	}
+	return false
+}
+
+// MakeGiteaRepo takes input of form "host/owner/repo", where host is codeberg.org,
+// gitea.com or one of the hosts listed in SCORECARD_GITEA_HOSTS, and returns
+// an implementation of clients.Repo interface.
+func MakeGiteaRepo(input string) (clients.Repo, error) {
+	var repo repoURL
+	if err := repo.parse(input); err != nil {
+		return nil, fmt.Errorf("error during parse: %w", err)
+	}
+	if err := repo.IsValid(); err != nil {
+		return nil, fmt.Errorf("error in IsValid: %w", err)
+	}
+	return &repo, nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gitearepo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+//nolint:paralleltest // Uses t.Setenv.
+func TestMakeGiteaRepo(t *testing.T) {
+	t.Setenv(hostsEnv, "git.example.com, gitea.internal:3000")
+	tests := []struct {
+		name     string
+		inputURL string
+		expected repoURL
+		wantErr  error
+	}{
+		{
+			name:     "codeberg",
+			inputURL: "codeberg.org/forgejo/forgejo",
+			expected: repoURL{scheme: "https", host: "codeberg.org", owner: "forgejo", repo: "forgejo"},
+		},
+		{
+			name:     "codeberg with scheme and trailing slash",
+			inputURL: "https://codeberg.org/forgejo/forgejo/",
+			expected: repoURL{scheme: "https", host: "codeberg.org", owner: "forgejo", repo: "forgejo"},
+		},
+		{
+			name:     "self-hosted",
+			inputURL: "http://gitea.internal:3000/team/service",
+			expected: repoURL{scheme: "http", host: "gitea.internal:3000", owner: "team", repo: "service"},
+		},
+		{
+			name:     "self-hosted without scheme",
+			inputURL: "git.example.com/team/service",
+			expected: repoURL{scheme: "https", host: "git.example.com", owner: "team", repo: "service"},
+		},
+		{
+			name:     "unknown host",
+			inputURL: "github.com/ossf/scorecard",
+			wantErr:  sce.ErrRepoUnsupportedHost,
+		},
+		{
+			name:     "owner only",
+			inputURL: "codeberg.org/forgejo",
+			wantErr:  sce.ErrorInvalidURL,
+		},
+		{
+			name:     "path in repo",
+			inputURL: "codeberg.org/forgejo/forgejo/src/branch/main",
+			wantErr:  sce.ErrorInvalidURL,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := MakeGiteaRepo(tt.inputURL)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MakeGiteaRepo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(&tt.expected, repo, cmp.AllowUnexported(repoURL{})); diff != "" {
+				t.Errorf("MakeGiteaRepo() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
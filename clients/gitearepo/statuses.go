@@ -0,0 +1,63 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package gitearepo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+type status struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	// Gitea reports the state as "status".
+	Status    string `json:"status"`
+	Context   string `json:"context"`
+	URL       string `json:"url"`
+	TargetURL string `json:"target_url"`
+}
+
+type statusesHandler struct {
+	api   *apiClient
+	owner string
+	repo  string
+}
+
+func (handler *statusesHandler) init(owner, repo string) {
+	handler.owner = owner
+	handler.repo = repo
+}
+
+func (handler *statusesHandler) listStatuses(ctx context.Context, ref string) ([]clients.Status, error) {
+	path := fmt.Sprintf("/repos/%s/%s/commits/%s/statuses", handler.owner, handler.repo, url.PathEscape(ref))
+	var statuses []status
+	if err := handler.api.get(ctx, path, nil, &statuses); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list statuses: %v", err))
+	}
+	ret := []clients.Status{}
+	for _, s := range statuses {
+		ret = append(ret, clients.Status{
+			UpdatedAt: s.UpdatedAt,
+			State:     s.Status,
+			Context:   s.Context,
+			URL:       s.URL,
+			TargetURL: s.TargetURL,
+		})
+	}
+	return ret, nil
+}
@@ -0,0 +1,155 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// advisoriesPerPage is the maximum page size of the advisories APIs.
+const advisoriesPerPage = 100
+
+// go-github does not support security advisories yet.
+// https://docs.github.com/en/rest/security-advisories/repository-advisories
+// https://docs.github.com/en/rest/security-advisories/global-advisories
+type securityAdvisory struct {
+	PublishedAt     *time.Time `json:"published_at"`
+	GHSAID          string     `json:"ghsa_id"`
+	CVEID           string     `json:"cve_id"`
+	Severity        string     `json:"severity"`
+	Vulnerabilities []struct {
+		// PatchedVersions is set by the repository advisories API.
+		PatchedVersions string `json:"patched_versions"`
+		// FirstPatchedVersion is set by the global advisories API.
+		FirstPatchedVersion string `json:"first_patched_version"`
+	} `json:"vulnerabilities"`
+}
+
+type advisoriesHandler struct {
+	client     *github.Client
+	once       *forge.Once
+	owner      string
+	repo       string
+	advisories []clients.SecurityAdvisory
+}
+
+func (handler *advisoriesHandler) init(owner, repo string) {
+	handler.owner = owner
+	handler.repo = repo
+	handler.once = new(forge.Once)
+}
+
+// setup lists the advisories the repo published, and the reviewed advisories
+// of the GitHub Advisory Database about the packages named after the repo,
+// i.e. its Go module and GitHub Action, which other people may have reported.
+func (handler *advisoriesHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		u := fmt.Sprintf("repos/%s/%s/security-advisories", handler.owner, handler.repo)
+		advisories, err := handler.list(ctx, u, url.Values{"state": {"published"}})
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list repository advisories: %v", err))
+		}
+		packages := fmt.Sprintf("github.com/%s/%s,%s/%s", handler.owner, handler.repo, handler.owner, handler.repo)
+		global, err := handler.list(ctx, "advisories", url.Values{"type": {"reviewed"}, "affects": {packages}})
+		// GitHub Enterprise Server instances may not serve the Advisory Database.
+		var errResp *github.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+			err = nil
+		}
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("list global advisories: %v", err))
+		}
+		advisories = append(advisories, global...)
+		handler.advisories = advisoriesFrom(advisories)
+		return nil
+	})
+}
+
+// list reads all the pages of the advisories API at u.
+func (handler *advisoriesHandler) list(ctx context.Context, u string,
+	query url.Values) ([]*securityAdvisory, error) {
+	query.Set("per_page", strconv.Itoa(advisoriesPerPage))
+	var ret []*securityAdvisory
+	for {
+		req, err := handler.client.NewRequest(http.MethodGet, u+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("client.NewRequest: %w", err)
+		}
+		var advisories []*securityAdvisory
+		resp, err := handler.client.Do(ctx, req, &advisories)
+		if err != nil {
+			return nil, fmt.Errorf("client.Do: %w", err)
+		}
+		ret = append(ret, advisories...)
+		if resp.NextPage == 0 {
+			return ret, nil
+		}
+		query.Set("page", strconv.Itoa(resp.NextPage))
+	}
+}
+
+func (handler *advisoriesHandler) listSecurityAdvisories(ctx context.Context) ([]clients.SecurityAdvisory, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during advisoriesHandler.setup: %w", err)
+	}
+	return handler.advisories, nil
+}
+
+// advisoriesFrom converts the advisories, listing those the repository
+// advisories API and the Advisory Database both return once.
+func advisoriesFrom(data []*securityAdvisory) []clients.SecurityAdvisory {
+	var advisories []clients.SecurityAdvisory
+	seen := map[string]bool{}
+	for _, a := range data {
+		if seen[a.GHSAID] {
+			continue
+		}
+		seen[a.GHSAID] = true
+		advisory := clients.SecurityAdvisory{
+			ID:       a.GHSAID,
+			CVEID:    a.CVEID,
+			Severity: a.Severity,
+		}
+		if a.PublishedAt != nil {
+			advisory.PublishedAt = *a.PublishedAt
+		}
+		for _, v := range a.Vulnerabilities {
+			// Patched versions are a comma-separated list.
+			for _, version := range strings.Split(v.PatchedVersions, ",") {
+				if version = strings.TrimSpace(version); version != "" {
+					advisory.PatchedVersions = append(advisory.PatchedVersions, version)
+				}
+			}
+			if v.FirstPatchedVersion != "" {
+				advisory.PatchedVersions = append(advisory.PatchedVersions, v.FirstPatchedVersion)
+			}
+		}
+		advisories = append(advisories, advisory)
+	}
+	return advisories
+}
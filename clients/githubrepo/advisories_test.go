@@ -0,0 +1,97 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+func TestListSecurityAdvisories(t *testing.T) {
+	t.Parallel()
+	published := time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		globalStatus int
+		want         []clients.SecurityAdvisory
+	}{
+		{
+			name: "repository and global advisories",
+			want: []clients.SecurityAdvisory{
+				{ID: "GHSA-1", CVEID: "CVE-2022-1", Severity: "high", PublishedAt: published,
+					PatchedVersions: []string{"1.0.1", "2.0.1"}},
+				{ID: "GHSA-2", Severity: "low"},
+				{ID: "GHSA-3", Severity: "medium", PatchedVersions: []string{"1.2.0"}},
+			},
+		},
+		{
+			name:         "no Advisory Database",
+			globalStatus: http.StatusNotFound,
+			want: []clients.SecurityAdvisory{
+				{ID: "GHSA-1", CVEID: "CVE-2022-1", Severity: "high", PublishedAt: published,
+					PatchedVersions: []string{"1.0.1", "2.0.1"}},
+				{ID: "GHSA-2", Severity: "low"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := newTestGitHubClient(t, func(w http.ResponseWriter, r *http.Request) {
+				q := r.URL.Query()
+				switch {
+				case r.URL.Path == "/repos/owner/repo/security-advisories" && q.Get("page") == "":
+					if q.Get("state") != "published" || q.Get("per_page") != "100" {
+						t.Errorf("unexpected query %s", r.URL.RawQuery)
+					}
+					w.Header().Set("Link", "<"+r.URL.Path+`?page=2&per_page=100&state=published>; rel="next"`)
+					fmt.Fprint(w, `[{"ghsa_id": "GHSA-1", "cve_id": "CVE-2022-1", "severity": "high",
+						"published_at": "2022-01-02T00:00:00Z",
+						"vulnerabilities": [{"patched_versions": "1.0.1, 2.0.1"}]}]`)
+				case r.URL.Path == "/repos/owner/repo/security-advisories" && q.Get("page") == "2":
+					fmt.Fprint(w, `[{"ghsa_id": "GHSA-2", "severity": "low"}]`)
+				case r.URL.Path == "/advisories" && tt.globalStatus == 0:
+					if q.Get("affects") != "github.com/owner/repo,owner/repo" {
+						t.Errorf("unexpected query %s", r.URL.RawQuery)
+					}
+					// Reviewed repository advisories are also in the Advisory Database.
+					fmt.Fprint(w, `[{"ghsa_id": "GHSA-1", "severity": "high"},
+						{"ghsa_id": "GHSA-3", "severity": "medium",
+						"vulnerabilities": [{"first_patched_version": "1.2.0"}]}]`)
+				default:
+					http.NotFound(w, r)
+				}
+			})
+			handler := &advisoriesHandler{client: client}
+			handler.init("owner", "repo")
+
+			got, err := handler.listSecurityAdvisories(context.Background())
+			if err != nil {
+				t.Fatalf("listSecurityAdvisories: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("listSecurityAdvisories() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
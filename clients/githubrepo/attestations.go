@@ -0,0 +1,176 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// go-github does not support artifact attestations and packages yet.
+// https://docs.github.com/en/rest/repos/attestations
+type attestationsResponse struct {
+	Attestations []struct {
+		Bundle struct {
+			DSSEEnvelope struct {
+				Payload string `json:"payload"`
+			} `json:"dsseEnvelope"`
+		} `json:"bundle"`
+	} `json:"attestations"`
+}
+
+// inTotoStatement is the payload of the DSSE envelope of an attestation.
+type inTotoStatement struct {
+	PredicateType string `json:"predicateType"`
+}
+
+// https://docs.github.com/en/rest/packages/packages
+type containerPackage struct {
+	Repository *struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Name    string `json:"name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// packageVersion is a version of a package, named by its digest for
+// container images.
+type packageVersion struct {
+	Name    string `json:"name"`
+	HTMLURL string `json:"html_url"`
+}
+
+type attestationsHandler struct {
+	client *github.Client
+	once   *forge.Once
+	owner  string
+	repo   string
+	// org is whether the owner of the repo is an organization.
+	org    bool
+	images []clients.ContainerImage
+}
+
+func (handler *attestationsHandler) init(owner, repo string, org bool) {
+	handler.owner = owner
+	handler.repo = repo
+	handler.org = org
+	handler.once = new(forge.Once)
+}
+
+func (handler *attestationsHandler) listAttestations(ctx context.Context, subjectDigest string) (
+	[]clients.Attestation, error) {
+	u := fmt.Sprintf("repos/%s/%s/attestations/%s", handler.owner, handler.repo, url.PathEscape(subjectDigest))
+	req, err := handler.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("client.NewRequest: %v", err))
+	}
+	var resp attestationsResponse
+	r, err := handler.client.Do(ctx, req, &resp)
+	if r != nil && r.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("client.Do: %v", err))
+	}
+	return attestationsFrom(&resp), nil
+}
+
+func attestationsFrom(resp *attestationsResponse) []clients.Attestation {
+	var attestations []clients.Attestation
+	for _, a := range resp.Attestations {
+		payload, err := base64.StdEncoding.DecodeString(a.Bundle.DSSEEnvelope.Payload)
+		if err != nil {
+			continue
+		}
+		var statement inTotoStatement
+		if err := json.Unmarshal(payload, &statement); err != nil {
+			continue
+		}
+		attestations = append(attestations, clients.Attestation{PredicateType: statement.PredicateType})
+	}
+	return attestations
+}
+
+func (handler *attestationsHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		handler.images = nil
+		owners := "users"
+		if handler.org {
+			owners = "orgs"
+		}
+		u := fmt.Sprintf("%s/%s/packages?package_type=container&per_page=100", owners, handler.owner)
+		var packages []*containerPackage
+		if err := handler.get(ctx, u, &packages); err != nil {
+			return err
+		}
+		fullName := handler.owner + "/" + handler.repo
+		for _, p := range packages {
+			if p.Repository == nil || !strings.EqualFold(p.Repository.FullName, fullName) {
+				continue
+			}
+			// Versions are listed from the most recent.
+			u := fmt.Sprintf("%s/%s/packages/container/%s/versions?per_page=1",
+				owners, handler.owner, url.PathEscape(p.Name))
+			var versions []*packageVersion
+			if err := handler.get(ctx, u, &versions); err != nil {
+				return err
+			}
+			if len(versions) == 0 {
+				continue
+			}
+			handler.images = append(handler.images, clients.ContainerImage{
+				Name:   p.Name,
+				URL:    versions[0].HTMLURL,
+				Digest: versions[0].Name,
+			})
+		}
+		return nil
+	})
+}
+
+// get decodes the response to a GET request of u into v.
+func (handler *attestationsHandler) get(ctx context.Context, u string, v interface{}) error {
+	req, err := handler.client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("client.NewRequest: %v", err))
+	}
+	resp, err := handler.client.Do(ctx, req, v)
+	switch {
+	case resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden):
+		// Listing packages, even public ones, needs a token with read:packages.
+		return fmt.Errorf("listing packages: %w", clients.ErrUnsupportedFeature)
+	case err != nil:
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("client.Do: %v", err))
+	}
+	return nil
+}
+
+func (handler *attestationsHandler) listContainerImages(ctx context.Context) ([]clients.ContainerImage, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during attestationsHandler.setup: %w", err)
+	}
+	return handler.images, nil
+}
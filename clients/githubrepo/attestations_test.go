@@ -0,0 +1,195 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+func newTestGitHubClient(t *testing.T, handler http.HandlerFunc) *github.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	client.BaseURL = baseURL
+	return client
+}
+
+func TestListAttestations(t *testing.T) {
+	t.Parallel()
+	statement := func(predicateType string) string {
+		return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(`{"predicateType": %q}`, predicateType)))
+	}
+	client := newTestGitHubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/attestations/sha256:abc" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"attestations": [
+			{"bundle": {"dsseEnvelope": {"payload": %q}}},
+			{"bundle": {"dsseEnvelope": {"payload": "not base64"}}},
+			{"bundle": {"dsseEnvelope": {"payload": %q}}}
+		]}`, statement("https://slsa.dev/provenance/v1"), statement("https://spdx.dev/Document/v2.3"))
+	})
+	handler := &attestationsHandler{client: client}
+	handler.init("owner", "repo", true)
+
+	got, err := handler.listAttestations(context.Background(), "sha256:abc")
+	if err != nil {
+		t.Fatalf("listAttestations: %v", err)
+	}
+	want := []clients.Attestation{
+		{PredicateType: "https://slsa.dev/provenance/v1"},
+		{PredicateType: "https://spdx.dev/Document/v2.3"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("listAttestations() mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err = handler.listAttestations(context.Background(), "sha256:none")
+	if err != nil {
+		t.Fatalf("listAttestations: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("listAttestations() = %v, want none", got)
+	}
+}
+
+func TestListContainerImages(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		org     bool
+		status  int
+		want    []clients.ContainerImage
+		wantErr error
+	}{
+		{
+			name: "org",
+			org:  true,
+			want: []clients.ContainerImage{
+				{Name: "repo", URL: "https://github.com/orgs/owner/packages/container/repo/2", Digest: "sha256:new"},
+				{Name: "repo/sub", URL: "https://github.com/orgs/owner/packages/container/sub/3", Digest: "sha256:sub"},
+			},
+		},
+		{
+			name:    "token without read:packages",
+			org:     true,
+			status:  http.StatusForbidden,
+			wantErr: clients.ErrUnsupportedFeature,
+		},
+		{
+			name: "user",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := newTestGitHubClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if tt.status != 0 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				var body string
+				switch r.URL.EscapedPath() {
+				case "/orgs/owner/packages":
+					body = `[
+						{"name": "repo", "repository": {"full_name": "owner/repo"}},
+						{"name": "repo/sub", "repository": {"full_name": "Owner/Repo"}},
+						{"name": "other", "repository": {"full_name": "owner/other"}},
+						{"name": "unlinked"}
+					]`
+				case "/users/owner/packages":
+					body = `[]`
+				case "/orgs/owner/packages/container/repo/versions":
+					body = `[
+						{"name": "sha256:new", "html_url": "https://github.com/orgs/owner/packages/container/repo/2"},
+						{"name": "sha256:old", "html_url": "https://github.com/orgs/owner/packages/container/repo/1"}
+					]`
+				case "/orgs/owner/packages/container/repo%2Fsub/versions":
+					body = `[
+						{"name": "sha256:sub", "html_url": "https://github.com/orgs/owner/packages/container/sub/3"}
+					]`
+				default:
+					http.NotFound(w, r)
+					return
+				}
+				w.Write([]byte(body)) //nolint:errcheck
+			})
+			handler := &attestationsHandler{client: client}
+			handler.init("owner", "repo", tt.org)
+
+			got, err := handler.listContainerImages(context.Background())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("listContainerImages() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("listContainerImages() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReleasesDigest(t *testing.T) {
+	t.Parallel()
+	client := newTestGitHubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/releases" {
+			http.NotFound(w, r)
+			return
+		}
+		//nolint:errcheck
+		w.Write([]byte(`[{"tag_name": "v1", "assets": [
+			{"name": "bin.tar.gz", "url": "https://api.github.com/assets/1", "digest": "sha256:abc"},
+			{"name": "bin.tar.gz.sig", "url": "https://api.github.com/assets/2"}
+		]}]`))
+	})
+	handler := &releasesHandler{client: client}
+	handler.init("owner", "repo")
+
+	got, err := handler.getReleases(context.Background())
+	if err != nil {
+		t.Fatalf("getReleases: %v", err)
+	}
+	want := []clients.Release{{
+		TagName: "v1",
+		Assets: []clients.ReleaseAsset{
+			{Name: "bin.tar.gz", URL: "https://api.github.com/assets/1", Digest: "sha256:abc"},
+			{Name: "bin.tar.gz.sig", URL: "https://api.github.com/assets/2"},
+		},
+	}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("getReleases() mismatch (-want +got):\n%s", diff)
+	}
+}
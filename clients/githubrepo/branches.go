@@ -17,12 +17,14 @@ package githubrepo
 import (
 	"context"
 	"fmt"
-	"sync"
+	"net/http"
+	"strings"
 
 	"github.com/google/go-github/v38/github"
 	"github.com/shurcooL/githubv4"
 
 	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
@@ -71,6 +73,7 @@ type refUpdateRule struct {
 	RequiredApprovingReviewCount *int32
 	RequiresCodeOwnerReviews     *bool
 	RequiresLinearHistory        *bool
+	RequiredSignatures           *bool
 	RequiredStatusCheckContexts  []string
 }
 
@@ -86,6 +89,7 @@ type branchProtectionRule struct {
 	RequiredApprovingReviewCount *int32
 	RequiresCodeOwnerReviews     *bool
 	RequiresLinearHistory        *bool
+	RequiresCommitSignatures     *bool
 	RequiredStatusCheckContexts  []string
 	// TODO: verify there is no conflicts.
 	// BranchProtectionRuleConflicts interface{}
@@ -106,29 +110,98 @@ type branchesData struct {
 	} `graphql:"repository(owner: $owner, name: $name)"`
 }
 
+// The merge queue of a branch is set if it is enabled, either with classic
+// branch protection or with a ruleset.
+type mergeQueueData struct {
+	Repository struct {
+		MergeQueue *struct {
+			ID githubv4.ID
+		} `graphql:"mergeQueue(branch: $branch)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// Older GitHub Enterprise Server versions lack some of the fields queried above,
+// so the corresponding settings are left unknown there.
+type legacyRefUpdateRule struct {
+	RequiredApprovingReviewCount *int32
+	RequiresCodeOwnerReviews     *bool
+	RequiredStatusCheckContexts  []string
+}
+
+type legacyBranchProtectionRule struct {
+	DismissesStaleReviews        *bool
+	IsAdminEnforced              *bool
+	RequiresStrictStatusChecks   *bool
+	RequiresStatusChecks         *bool
+	RequiredApprovingReviewCount *int32
+	RequiresCodeOwnerReviews     *bool
+	RequiresCommitSignatures     *bool
+	RequiredStatusCheckContexts  []string
+}
+
+type legacyBranch struct {
+	Name                 *string
+	RefUpdateRule        *legacyRefUpdateRule
+	BranchProtectionRule *legacyBranchProtectionRule
+}
+
+type legacyBranchesData struct {
+	Repository struct {
+		DefaultBranchRef legacyBranch
+		Refs             struct {
+			Nodes []legacyBranch
+		} `graphql:"refs(first: $refsToAnalyze, refPrefix: $refPrefix)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+func (b legacyBranch) branch() branch {
+	ret := branch{Name: b.Name}
+	if rule := b.RefUpdateRule; rule != nil {
+		ret.RefUpdateRule = &refUpdateRule{
+			RequiredApprovingReviewCount: rule.RequiredApprovingReviewCount,
+			RequiresCodeOwnerReviews:     rule.RequiresCodeOwnerReviews,
+			RequiredStatusCheckContexts:  rule.RequiredStatusCheckContexts,
+		}
+	}
+	if rule := b.BranchProtectionRule; rule != nil {
+		ret.BranchProtectionRule = &branchProtectionRule{
+			DismissesStaleReviews:        rule.DismissesStaleReviews,
+			IsAdminEnforced:              rule.IsAdminEnforced,
+			RequiresStrictStatusChecks:   rule.RequiresStrictStatusChecks,
+			RequiresStatusChecks:         rule.RequiresStatusChecks,
+			RequiredApprovingReviewCount: rule.RequiredApprovingReviewCount,
+			RequiresCodeOwnerReviews:     rule.RequiresCodeOwnerReviews,
+			RequiresCommitSignatures:     rule.RequiresCommitSignatures,
+			RequiredStatusCheckContexts:  rule.RequiredStatusCheckContexts,
+		}
+	}
+	return ret
+}
+
+// isUndefinedFieldError returns whether the GraphQL schema of the server lacks a queried field.
+func isUndefinedFieldError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "doesn't exist on type")
+}
+
 type branchesHandler struct {
 	ghClient         *github.Client
 	graphClient      *githubv4.Client
 	data             *branchesData
-	once             *sync.Once
-	ctx              context.Context
-	errSetup         error
+	once             *forge.Once
 	owner            string
 	repo             string
 	defaultBranchRef *clients.BranchRef
 	branches         []*clients.BranchRef
 }
 
-func (handler *branchesHandler) init(ctx context.Context, owner, repo string) {
-	handler.ctx = ctx
+func (handler *branchesHandler) init(owner, repo string) {
 	handler.owner = owner
 	handler.repo = repo
-	handler.errSetup = nil
-	handler.once = new(sync.Once)
+	handler.once = new(forge.Once)
 }
 
-func (handler *branchesHandler) setup() error {
-	handler.once.Do(func() {
+func (handler *branchesHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
 		vars := map[string]interface{}{
 			"owner":         githubv4.String(handler.owner),
 			"name":          githubv4.String(handler.repo),
@@ -136,24 +209,136 @@ func (handler *branchesHandler) setup() error {
 			"refPrefix":     githubv4.String(refPrefix),
 		}
 		handler.data = new(branchesData)
-		if err := handler.graphClient.Query(handler.ctx, handler.data, vars); err != nil {
-			handler.errSetup = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+		err := handler.graphClient.Query(ctx, handler.data, vars)
+		if isUndefinedFieldError(err) {
+			err = handler.queryLegacy(ctx, vars)
 		}
 		handler.defaultBranchRef = getBranchRefFrom(handler.data.Repository.DefaultBranchRef)
 		handler.branches = getBranchRefsFrom(handler.data.Repository.Refs.Nodes, handler.defaultBranchRef)
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+		}
+		handler.applyRulesets(ctx)
+		handler.applyMergeQueue(ctx)
+		return nil
 	})
-	return handler.errSetup
 }
 
-func (handler *branchesHandler) getDefaultBranch() (*clients.BranchRef, error) {
-	if err := handler.setup(); err != nil {
+// queryLegacy fills handler.data using only the fields known to older
+// GitHub Enterprise Server versions.
+func (handler *branchesHandler) queryLegacy(ctx context.Context, vars map[string]interface{}) error {
+	data := new(legacyBranchesData)
+	if err := handler.graphClient.Query(ctx, data, vars); err != nil {
+		return fmt.Errorf("legacy query: %w", err)
+	}
+	handler.data = new(branchesData)
+	handler.data.Repository.DefaultBranchRef = data.Repository.DefaultBranchRef.branch()
+	for _, b := range data.Repository.Refs.Nodes {
+		handler.data.Repository.Refs.Nodes = append(handler.data.Repository.Refs.Nodes, b.branch())
+	}
+	return nil
+}
+
+// getBranch returns a branch by name, following renames with the REST API
+// since the GraphQL API does not know about them.
+func (handler *branchesHandler) getBranch(ctx context.Context, name string) (*clients.BranchRef, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during branchesHandler.setup: %w", err)
+	}
+	if ret := handler.findBranch(name); ret != nil {
+		return ret, nil
+	}
+	b, resp, err := handler.ghClient.Repositories.GetBranch(ctx, handler.owner, handler.repo, name, true)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", clients.ErrBranchNotFound, name)
+	}
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Repositories.GetBranch: %v", err))
+	}
+	// Listed branches also have the protection settings.
+	if ret := handler.findBranch(b.GetName()); ret != nil {
+		return ret, nil
+	}
+	return &clients.BranchRef{
+		Name:      b.Name,
+		Protected: b.Protected,
+	}, nil
+}
+
+func (handler *branchesHandler) findBranch(name string) *clients.BranchRef {
+	if handler.defaultBranchRef != nil && handler.defaultBranchRef.Name != nil &&
+		*handler.defaultBranchRef.Name == name {
+		return handler.defaultBranchRef
+	}
+	if ret, err := clients.FindBranch(handler.branches, name); err == nil {
+		return ret
+	}
+	return nil
+}
+
+// applyRulesets merges repository rulesets into the branch protection rules.
+// Reading rulesets may not be permitted for the token, in which case only
+// classic branch protection is used.
+func (handler *branchesHandler) applyRulesets(ctx context.Context) {
+	vars := map[string]interface{}{
+		"owner":             githubv4.String(handler.owner),
+		"name":              githubv4.String(handler.repo),
+		"rulesetsToAnalyze": githubv4.Int(rulesetsToAnalyze),
+		"rulesPerRuleset":   githubv4.Int(rulesPerRuleset),
+	}
+	data := new(rulesetsData)
+	if err := handler.graphClient.Query(ctx, data, vars); err != nil {
+		return
+	}
+	rulesets := data.Repository.Rulesets.Nodes
+	applyRulesets(handler.defaultBranchRef, rulesets, true)
+	for _, branchRef := range handler.branches {
+		if branchRef == handler.defaultBranchRef {
+			continue
+		}
+		applyRulesets(branchRef, rulesets, isDefaultBranch(branchRef, handler.defaultBranchRef))
+	}
+}
+
+// applyMergeQueue records whether PRs are merged onto the default branch by
+// a merge queue. Servers without merge queues leave the setting unknown.
+func (handler *branchesHandler) applyMergeQueue(ctx context.Context) {
+	if handler.defaultBranchRef == nil || handler.defaultBranchRef.Name == nil {
+		return
+	}
+	vars := map[string]interface{}{
+		"owner":  githubv4.String(handler.owner),
+		"name":   githubv4.String(handler.repo),
+		"branch": githubv4.String(*handler.defaultBranchRef.Name),
+	}
+	data := new(mergeQueueData)
+	if err := handler.graphClient.Query(ctx, data, vars); err != nil ||
+		data.Repository.MergeQueue == nil {
+		return
+	}
+	handler.defaultBranchRef.BranchProtectionRule.RequiresMergeQueue = boolPtr(true)
+	for _, branchRef := range handler.branches {
+		if isDefaultBranch(branchRef, handler.defaultBranchRef) {
+			branchRef.BranchProtectionRule.RequiresMergeQueue = boolPtr(true)
+		}
+	}
+}
+
+func isDefaultBranch(branchRef, defaultBranchRef *clients.BranchRef) bool {
+	return branchRef != nil && branchRef.Name != nil &&
+		defaultBranchRef != nil && defaultBranchRef.Name != nil &&
+		*branchRef.Name == *defaultBranchRef.Name
+}
+
+func (handler *branchesHandler) getDefaultBranch(ctx context.Context) (*clients.BranchRef, error) {
+	if err := handler.setup(ctx); err != nil {
 		return nil, fmt.Errorf("error during branchesHandler.setup: %w", err)
 	}
 	return handler.defaultBranchRef, nil
 }
 
-func (handler *branchesHandler) listBranches() ([]*clients.BranchRef, error) {
-	if err := handler.setup(); err != nil {
+func (handler *branchesHandler) listBranches(ctx context.Context) ([]*clients.BranchRef, error) {
+	if err := handler.setup(ctx); err != nil {
 		return nil, fmt.Errorf("error during branchesHandler.setup: %w", err)
 	}
 	return handler.branches, nil
@@ -169,12 +354,13 @@ func copyAdminSettings(src *branchProtectionRule, dst *clients.BranchProtectionR
 }
 
 func copyNonAdminSettings(src interface{}, dst *clients.BranchProtectionRule) {
-	// TODO: requiresConversationResolution, requiresSignatures, viewerAllowedToDismissReviews, viewerCanPush
+	// TODO: requiresConversationResolution, viewerAllowedToDismissReviews, viewerCanPush
 	switch v := src.(type) {
 	case *branchProtectionRule:
 		copyBoolPtr(v.AllowsDeletions, &dst.AllowDeletions)
 		copyBoolPtr(v.AllowsForcePushes, &dst.AllowForcePushes)
 		copyBoolPtr(v.RequiresLinearHistory, &dst.RequireLinearHistory)
+		copyBoolPtr(v.RequiresCommitSignatures, &dst.RequiresSignatures)
 		copyInt32Ptr(v.RequiredApprovingReviewCount, &dst.RequiredPullRequestReviews.RequiredApprovingReviewCount)
 		copyBoolPtr(v.RequiresCodeOwnerReviews, &dst.RequiredPullRequestReviews.RequireCodeOwnerReviews)
 		copyStringSlice(v.RequiredStatusCheckContexts, &dst.CheckRules.Contexts)
@@ -183,6 +369,7 @@ func copyNonAdminSettings(src interface{}, dst *clients.BranchProtectionRule) {
 		copyBoolPtr(v.AllowsDeletions, &dst.AllowDeletions)
 		copyBoolPtr(v.AllowsForcePushes, &dst.AllowForcePushes)
 		copyBoolPtr(v.RequiresLinearHistory, &dst.RequireLinearHistory)
+		copyBoolPtr(v.RequiredSignatures, &dst.RequiresSignatures)
 		copyInt32Ptr(v.RequiredApprovingReviewCount, &dst.RequiredPullRequestReviews.RequiredApprovingReviewCount)
 		copyBoolPtr(v.RequiresCodeOwnerReviews, &dst.RequiredPullRequestReviews.RequireCodeOwnerReviews)
 		copyStringSlice(v.RequiredStatusCheckContexts, &dst.CheckRules.Contexts)
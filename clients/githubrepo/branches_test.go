@@ -0,0 +1,228 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v38/github"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+// TestBranchesLegacyQuery checks branch protection is read from servers
+// lacking newer fields, like older GitHub Enterprise Server versions.
+func TestBranchesLegacyQuery(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("io.ReadAll: %v", err)
+		}
+		var resp string
+		switch query := string(body); {
+		case strings.Contains(query, "rulesets"):
+			resp = `{"errors": [{"message": "Field 'rulesets' doesn't exist on type 'Repository'"}]}`
+		case strings.Contains(query, "allowsDeletions"):
+			resp = `{"errors": [{"message": "Field 'allowsDeletions' doesn't exist on type 'RefUpdateRule'"}]}`
+		default:
+			resp = `{"data": {"repository": {
+  "defaultBranchRef": {
+    "name": "main",
+    "refUpdateRule": {"requiredApprovingReviewCount": 2, "requiresCodeOwnerReviews": true},
+    "branchProtectionRule": null
+  },
+  "refs": {"nodes": [{"name": "dev", "refUpdateRule": null, "branchProtectionRule": null}]}
+}}}`
+		}
+		//nolint:errcheck
+		w.Write([]byte(resp))
+	}))
+	t.Cleanup(server.Close)
+
+	handler := &branchesHandler{
+		graphClient: githubv4.NewEnterpriseClient(server.URL, server.Client()),
+	}
+	handler.init("owner", "repo")
+	got, err := handler.listBranches(context.Background())
+	if err != nil {
+		t.Fatalf("listBranches: %v", err)
+	}
+
+	main, dev := "main", "dev"
+	protected, unprotected := true, false
+	var approvals int32 = 2
+	codeOwners := true
+	want := []*clients.BranchRef{
+		{
+			Name:      &dev,
+			Protected: &unprotected,
+		},
+		{
+			Name:      &main,
+			Protected: &protected,
+			BranchProtectionRule: clients.BranchProtectionRule{
+				RequiredPullRequestReviews: clients.PullRequestReviewRule{
+					RequiredApprovingReviewCount: &approvals,
+					RequireCodeOwnerReviews:      &codeOwners,
+				},
+				CheckRules: clients.StatusChecksRule{
+					Contexts: []string{},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("listBranches() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBranchesMergeQueue(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		mergeQueue string
+		want       *bool
+	}{
+		{
+			name:       "merge queue enabled",
+			mergeQueue: `{"data": {"repository": {"mergeQueue": {"id": "MQ_1"}}}}`,
+			want:       boolPtr(true),
+		},
+		{
+			name:       "merge queue disabled",
+			mergeQueue: `{"data": {"repository": {"mergeQueue": null}}}`,
+		},
+		{
+			name:       "merge queues not supported",
+			mergeQueue: `{"errors": [{"message": "Field 'mergeQueue' doesn't exist on type 'Repository'"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("io.ReadAll: %v", err)
+				}
+				var resp string
+				switch query := string(body); {
+				case strings.Contains(query, "mergeQueue"):
+					resp = tt.mergeQueue
+				case strings.Contains(query, "rulesets"):
+					resp = `{"data": {"repository": {"rulesets": {"nodes": []}}}}`
+				default:
+					resp = `{"data": {"repository": {
+  "defaultBranchRef": {"name": "main", "refUpdateRule": null, "branchProtectionRule": null},
+  "refs": {"nodes": [{"name": "main", "refUpdateRule": null, "branchProtectionRule": null}]}
+}}}`
+				}
+				//nolint:errcheck
+				w.Write([]byte(resp))
+			}))
+			t.Cleanup(server.Close)
+
+			handler := &branchesHandler{
+				graphClient: githubv4.NewEnterpriseClient(server.URL, server.Client()),
+			}
+			handler.init("owner", "repo")
+			branches, err := handler.listBranches(context.Background())
+			if err != nil {
+				t.Fatalf("listBranches: %v", err)
+			}
+			defaultBranch, err := handler.getDefaultBranch(context.Background())
+			if err != nil {
+				t.Fatalf("getDefaultBranch: %v", err)
+			}
+			for _, b := range append(branches, defaultBranch) {
+				if diff := cmp.Diff(tt.want, b.BranchProtectionRule.RequiresMergeQueue); diff != "" {
+					t.Errorf("RequiresMergeQueue of branch %s mismatch (-want +got):\n%s", *b.Name, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestGetBranchRenamed(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp string
+		switch r.URL.Path {
+		case "/graphql":
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Errorf("io.ReadAll: %v", err)
+			}
+			switch query := string(body); {
+			case strings.Contains(query, "rulesets"):
+				resp = `{"data": {"repository": {"rulesets": {"nodes": []}}}}`
+			case strings.Contains(query, "mergeQueue"):
+				resp = `{"data": {"repository": {"mergeQueue": null}}}`
+			default:
+				resp = `{"data": {"repository": {
+  "defaultBranchRef": {"name": "main", "refUpdateRule": null, "branchProtectionRule": null},
+  "refs": {"nodes": [{"name": "main", "refUpdateRule": null, "branchProtectionRule": null}]}
+}}}`
+			}
+		case "/repos/owner/repo/branches/master":
+			http.Redirect(w, r, "/repos/owner/repo/branches/main", http.StatusMovedPermanently)
+			return
+		case "/repos/owner/repo/branches/main":
+			resp = `{"name": "main", "protected": true}`
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		//nolint:errcheck
+		w.Write([]byte(resp))
+	}))
+	t.Cleanup(server.Close)
+
+	ghClient := github.NewClient(server.Client())
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	ghClient.BaseURL = baseURL
+	handler := &branchesHandler{
+		ghClient:    ghClient,
+		graphClient: githubv4.NewEnterpriseClient(server.URL+"/graphql", server.Client()),
+	}
+	handler.init("owner", "repo")
+
+	for _, name := range []string{"main", "master"} {
+		branch, err := handler.getBranch(context.Background(), name)
+		if err != nil {
+			t.Fatalf("getBranch(%q): %v", name, err)
+		}
+		if got := *branch.Name; got != "main" {
+			t.Errorf("getBranch(%q) = %q, want main", name, got)
+		}
+	}
+	if _, err := handler.getBranch(context.Background(), "gone"); !errors.Is(err, clients.ErrBranchNotFound) {
+		t.Errorf("getBranch(gone) error = %v, want %v", err, clients.ErrBranchNotFound)
+	}
+}
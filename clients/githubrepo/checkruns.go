@@ -26,19 +26,17 @@ import (
 
 type checkrunsHandler struct {
 	client *github.Client
-	ctx    context.Context
 	owner  string
 	repo   string
 }
 
-func (handler *checkrunsHandler) init(ctx context.Context, owner, repo string) {
-	handler.ctx = ctx
+func (handler *checkrunsHandler) init(owner, repo string) {
 	handler.owner = owner
 	handler.repo = repo
 }
 
-func (handler *checkrunsHandler) listCheckRunsForRef(ref string) ([]clients.CheckRun, error) {
-	checkRuns, _, err := handler.client.Checks.ListCheckRunsForRef(handler.ctx, handler.owner, handler.repo, ref,
+func (handler *checkrunsHandler) listCheckRunsForRef(ctx context.Context, ref string) ([]clients.CheckRun, error) {
+	checkRuns, _, err := handler.client.Checks.ListCheckRunsForRef(ctx, handler.owner, handler.repo, ref,
 		&github.ListCheckRunsOptions{})
 	if err != nil {
 		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListCheckRunsForRef: %v", err))
@@ -50,9 +48,10 @@ func checkRunsFrom(data *github.ListCheckRunsResults) []clients.CheckRun {
 	var checkRuns []clients.CheckRun
 	for _, checkRun := range data.CheckRuns {
 		checkRuns = append(checkRuns, clients.CheckRun{
-			Status:     checkRun.GetStatus(),
-			Conclusion: checkRun.GetConclusion(),
-			URL:        checkRun.GetURL(),
+			CompletedAt: checkRun.GetCompletedAt().Time,
+			Status:      checkRun.GetStatus(),
+			Conclusion:  checkRun.GetConclusion(),
+			URL:         checkRun.GetURL(),
 			App: clients.CheckRunApp{
 				Slug: checkRun.GetApp().GetSlug(),
 			},
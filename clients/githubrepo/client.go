@@ -20,6 +20,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/google/go-github/v38/github"
 	"github.com/shurcooL/githubv4"
@@ -28,6 +30,7 @@ import (
 
 	"github.com/ossf/scorecard/v3/clients"
 	"github.com/ossf/scorecard/v3/clients/githubrepo/roundtripper"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
@@ -35,154 +38,358 @@ var errInputRepoType = errors.New("input repo should be of type repoURL")
 
 // Client is GitHub-specific implementation of RepoClient.
 type Client struct {
+	host         string
 	owner        string
 	repoName     string
 	repo         *github.Repository
 	repoClient   *github.Client
+	httpClient   *http.Client
+	endpoints    endpoints
 	graphClient  *graphqlHandler
 	contributors *contributorsHandler
 	branches     *branchesHandler
 	releases     *releasesHandler
+	tags         *tagsHandler
 	workflows    *workflowsHandler
 	checkruns    *checkrunsHandler
 	statuses     *statusesHandler
+	advisories   *advisoriesHandler
+	attestations *attestationsHandler
 	search       *searchHandler
 	ctx          context.Context
-	tarball      tarballHandler
+	tarball      *tarballHandler
+	// origin is the Client a view returned by WithCallContext was made from.
+	origin      *Client
+	fast        bool
+	permissions clients.TokenPermissions
+}
+
+// tokenPermissions reads the token's access to the repo from the repo's
+// `permissions`, and its scopes from the X-OAuth-Scopes header of classic tokens.
+func tokenPermissions(repo *github.Repository, resp *github.Response) clients.TokenPermissions {
+	ret := clients.TokenPermissions{
+		Known: repo.Permissions != nil,
+		Admin: repo.Permissions["admin"],
+	}
+	if resp == nil || resp.Response == nil {
+		return ret
+	}
+	if header, ok := resp.Header[http.CanonicalHeaderKey("X-OAuth-Scopes")]; ok {
+		ret.Scopes = []string{}
+		for _, s := range strings.Split(strings.Join(header, ","), ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				ret.Scopes = append(ret.Scopes, s)
+			}
+		}
+	}
+	return ret
 }
 
 // InitRepo sets up the GitHub repo in local storage for improving performance and GitHub token usage efficiency.
-func (client *Client) InitRepo(inputRepo clients.Repo) error {
+func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string) error {
+	if client.origin != nil {
+		return client.origin.initRepo(client.ctx, inputRepo, commitSHA)
+	}
+	return client.initRepo(client.ctx, inputRepo, commitSHA)
+}
+
+func (client *Client) initRepo(ctx context.Context, inputRepo clients.Repo, commitSHA string) error {
 	ghRepo, ok := inputRepo.(*repoURL)
 	if !ok {
 		return fmt.Errorf("%w: %v", errInputRepoType, inputRepo)
 	}
+	if err := client.setEndpoints(endpointsFor(ghRepo.host)); err != nil {
+		return err
+	}
 
 	// Sanity check.
-	repo, _, err := client.repoClient.Repositories.Get(client.ctx, ghRepo.owner, ghRepo.repo)
+	repo, resp, err := client.repoClient.Repositories.Get(ctx, ghRepo.owner, ghRepo.repo)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return sce.Wrap(sce.ErrRepoNotFound, err, "")
+	}
 	if err != nil {
-		return sce.WithMessage(sce.ErrRepoUnreachable, err.Error())
+		return sce.Wrap(sce.ErrRepoUnreachable, err, "")
 	}
 	client.repo = repo
+	client.host = ghRepo.host
+	client.permissions = tokenPermissions(repo, resp)
 	client.owner = repo.Owner.GetLogin()
 	client.repoName = repo.GetName()
 
-	// Init tarballHandler.
-	if err := client.tarball.init(client.ctx, client.repo); err != nil {
-		return fmt.Errorf("error during tarballHandler.init: %w", err)
+	// Init tarballHandler. The tarball is downloaded on first use.
+	if !client.fast {
+		if err := client.tarball.init(client.repo, commitSHA); err != nil {
+			return fmt.Errorf("error during tarballHandler.init: %w", err)
+		}
 	}
 
 	// Setup GraphQL.
-	client.graphClient.init(client.ctx, client.owner, client.repoName)
+	client.graphClient.init(client.owner, client.repoName, commitSHA)
 
 	// Setup contributorsHandler.
-	client.contributors.init(client.ctx, client.owner, client.repoName)
+	client.contributors.init(client.owner, client.repoName)
 
 	// Setup branchesHandler.
-	client.branches.init(client.ctx, client.owner, client.repoName)
+	client.branches.init(client.owner, client.repoName)
 
 	// Setup releasesHandler.
-	client.releases.init(client.ctx, client.owner, client.repoName)
+	client.releases.init(client.owner, client.repoName)
+
+	// Setup tagsHandler.
+	client.tags.init(client.owner, client.repoName)
 
 	// Setup workflowsHandler.
-	client.workflows.init(client.ctx, client.owner, client.repoName)
+	client.workflows.init(client.owner, client.repoName)
 
 	// Setup checkrunsHandler.
-	client.checkruns.init(client.ctx, client.owner, client.repoName)
+	client.checkruns.init(client.owner, client.repoName)
 
 	// Setup statusesHandler.
-	client.statuses.init(client.ctx, client.owner, client.repoName)
+	client.statuses.init(client.owner, client.repoName)
+
+	// Setup advisoriesHandler.
+	client.advisories.init(client.owner, client.repoName)
+
+	// Setup attestationsHandler.
+	client.attestations.init(client.owner, client.repoName, repo.Owner.GetType() == "Organization")
 
 	// Setup searchHandler.
-	client.search.init(client.ctx, client.owner, client.repoName)
+	client.search.init(client.owner, client.repoName)
 
 	return nil
 }
 
+// setEndpoints points the clients at the APIs of the host of the repo,
+// i.e., github.com or a GitHub Enterprise Server instance.
+func (client *Client) setEndpoints(e endpoints) error {
+	if client.endpoints == e {
+		return nil
+	}
+	baseURL, err := url.Parse(e.rest)
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("url.Parse: %v", err))
+	}
+	// The handlers share the REST client, but each keeps the GraphQL client.
+	client.repoClient.BaseURL = baseURL
+	graphClient := githubv4.NewEnterpriseClient(e.graphql, client.httpClient)
+	client.graphClient.client = graphClient
+	client.branches.graphClient = graphClient
+	client.tags.graphClient = graphClient
+	if e != dotcomEndpoints {
+		// Enterprise instances may not serve tarballs anonymously.
+		client.tarball.httpClient = client.httpClient
+	}
+	client.endpoints = e
+	return nil
+}
+
+// WithCallContext returns a view of the client which makes its API calls with
+// ctx, e.g. the context of the check making them: they are counted with the
+// counters of ctx, see roundtripper.WithAPICallCounter, and interrupted once
+// ctx is done. The data fetched is still shared with the client and the other
+// views; a fetch interrupted by the deadline of one check is run again for the
+// next check calling, see forge.Once.
+func (client *Client) WithCallContext(ctx context.Context) clients.RepoClient {
+	view := *client
+	view.ctx = ctx
+	view.origin = client
+	if client.origin != nil {
+		view.origin = client.origin
+	}
+	return &view
+}
+
 // URI implements RepoClient.URI.
 func (client *Client) URI() string {
-	return fmt.Sprintf("github.com/%s/%s", client.owner, client.repoName)
+	return fmt.Sprintf("%s/%s/%s", client.host, client.owner, client.repoName)
 }
 
 // ListFiles implements RepoClient.ListFiles.
 func (client *Client) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
-	return client.tarball.listFiles(predicate)
+	if client.fast {
+		return nil, fmt.Errorf("ListFiles: %w", clients.ErrUnsupportedFeature)
+	}
+	return client.tarball.listFiles(client.ctx, predicate)
 }
 
 // GetFileContent implements RepoClient.GetFileContent.
 func (client *Client) GetFileContent(filename string) ([]byte, error) {
-	return client.tarball.getFileContent(filename)
+	if client.fast {
+		return nil, fmt.Errorf("GetFileContent: %w", clients.ErrUnsupportedFeature)
+	}
+	return client.tarball.getFileContent(client.ctx, filename)
 }
 
 // ListMergedPRs implements RepoClient.ListMergedPRs.
 func (client *Client) ListMergedPRs() ([]clients.PullRequest, error) {
-	return client.graphClient.getMergedPRs()
+	return client.graphClient.getMergedPRs(client.ctx)
 }
 
 // ListCommits implements RepoClient.ListCommits.
 func (client *Client) ListCommits() ([]clients.Commit, error) {
-	return client.graphClient.getCommits()
+	return client.graphClient.getCommits(client.ctx)
 }
 
 // ListIssues implements RepoClient.ListIssues.
 func (client *Client) ListIssues() ([]clients.Issue, error) {
-	return client.graphClient.getIssues()
+	return client.graphClient.getIssues(client.ctx)
 }
 
 // ListReleases implements RepoClient.ListReleases.
 func (client *Client) ListReleases() ([]clients.Release, error) {
-	return client.releases.getReleases()
+	if client.fast {
+		return nil, fmt.Errorf("ListReleases: %w", clients.ErrUnsupportedFeature)
+	}
+	return client.releases.getReleases(client.ctx)
+}
+
+// ListAttestations implements RepoClient.ListAttestations.
+func (client *Client) ListAttestations(subjectDigest string) ([]clients.Attestation, error) {
+	return client.attestations.listAttestations(client.ctx, subjectDigest)
+}
+
+// ListContainerImages implements RepoClient.ListContainerImages.
+func (client *Client) ListContainerImages() ([]clients.ContainerImage, error) {
+	return client.attestations.listContainerImages(client.ctx)
+}
+
+// ListTags implements RepoClient.ListTags.
+func (client *Client) ListTags() ([]clients.Tag, error) {
+	return client.tags.listTags(client.ctx)
+}
+
+// ListBranchesForCommit implements RepoClient.ListBranchesForCommit.
+func (client *Client) ListBranchesForCommit(sha string) ([]string, error) {
+	return client.tags.listBranchesForCommit(client.ctx, sha)
 }
 
 // ListContributors implements RepoClient.ListContributors.
 func (client *Client) ListContributors() ([]clients.Contributor, error) {
-	return client.contributors.getContributors()
+	if client.fast {
+		return nil, fmt.Errorf("ListContributors: %w", clients.ErrUnsupportedFeature)
+	}
+	return client.contributors.getContributors(client.ctx)
 }
 
 // IsArchived implements RepoClient.IsArchived.
 func (client *Client) IsArchived() (bool, error) {
-	return client.graphClient.isArchived()
+	return client.graphClient.isArchived(client.ctx)
 }
 
 // GetDefaultBranch implements RepoClient.GetDefaultBranch.
 func (client *Client) GetDefaultBranch() (*clients.BranchRef, error) {
-	return client.branches.getDefaultBranch()
+	return client.branches.getDefaultBranch(client.ctx)
+}
+
+// GetBranch implements RepoClient.GetBranch.
+func (client *Client) GetBranch(name string) (*clients.BranchRef, error) {
+	return client.branches.getBranch(client.ctx, name)
 }
 
 // ListBranches implements RepoClient.ListBranches.
 func (client *Client) ListBranches() ([]*clients.BranchRef, error) {
-	return client.branches.listBranches()
+	return client.branches.listBranches(client.ctx)
 }
 
 // ListSuccessfulWorkflowRuns implements RepoClient.WorkflowRunsByFilename.
 func (client *Client) ListSuccessfulWorkflowRuns(filename string) ([]clients.WorkflowRun, error) {
-	return client.workflows.listSuccessfulWorkflowRuns(filename)
+	return client.workflows.listSuccessfulWorkflowRuns(client.ctx, filename)
 }
 
 // ListCheckRunsForRef implements RepoClient.ListCheckRunsForRef.
 func (client *Client) ListCheckRunsForRef(ref string) ([]clients.CheckRun, error) {
-	return client.checkruns.listCheckRunsForRef(ref)
+	return client.checkruns.listCheckRunsForRef(client.ctx, ref)
 }
 
 // ListStatuses implements RepoClient.ListStatuses.
 func (client *Client) ListStatuses(ref string) ([]clients.Status, error) {
-	return client.statuses.listStatuses(ref)
+	return client.statuses.listStatuses(client.ctx, ref)
+}
+
+// TokenPermissions implements clients.TokenPermissionsReporter.
+func (client *Client) TokenPermissions() clients.TokenPermissions {
+	return client.permissions
+}
+
+// IsPrivate implements clients.VisibilityReporter.
+func (client *Client) IsPrivate() bool {
+	return client.repo.GetPrivate()
+}
+
+// Upstream implements clients.UpstreamReporter.
+func (client *Client) Upstream() *clients.Upstream {
+	if mirrorURL := client.repo.GetMirrorURL(); mirrorURL != "" {
+		return &clients.Upstream{URI: mirrorURL, Mirror: true}
+	}
+	parent := client.repo.GetParent()
+	if !client.repo.GetFork() || parent == nil {
+		return nil
+	}
+	return &clients.Upstream{
+		URI:      fmt.Sprintf("github.com/%s", parent.GetFullName()),
+		PushedAt: parent.GetPushedAt().Time,
+		Archived: parent.GetArchived(),
+	}
+}
+
+// SupportsFeature implements clients.FeatureReporter.
+// The fast client does not download the tarball nor iterate releases and contributors.
+func (client *Client) SupportsFeature(f clients.Feature) bool {
+	switch f {
+	case clients.FeatureFileContents, clients.FeatureReleases, clients.FeatureContributors:
+		return !client.fast
+	default:
+		return true
+	}
+}
+
+// ListBranchUpdates implements RepoClient.ListBranchUpdates.
+// GitHub does not expose the update history of branches.
+func (client *Client) ListBranchUpdates(branch string) ([]clients.BranchUpdate, error) {
+	return nil, fmt.Errorf("ListBranchUpdates: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListSecurityAdvisories implements RepoClient.ListSecurityAdvisories.
+func (client *Client) ListSecurityAdvisories() ([]clients.SecurityAdvisory, error) {
+	return client.advisories.listSecurityAdvisories(client.ctx)
 }
 
 // Search implements RepoClient.Search.
 func (client *Client) Search(request clients.SearchRequest) (clients.SearchResponse, error) {
-	return client.search.search(request)
+	return client.search.search(client.ctx, request)
 }
 
 // Close implements RepoClient.Close.
 func (client *Client) Close() error {
+	if client.tarball == nil {
+		return nil
+	}
 	return client.tarball.cleanup()
 }
 
 // CreateGithubRepoClient returns a Client which implements RepoClient interface.
 func CreateGithubRepoClient(ctx context.Context, logger *zap.Logger) clients.RepoClient {
+	return createGithubRepoClient(ctx, logger)
+}
+
+// CreateFastGithubRepoClient returns a Client which only uses the repo metadata APIs.
+// It does not download the repo tarball or iterate releases and contributors:
+// the corresponding APIs return clients.ErrUnsupportedFeature.
+func CreateFastGithubRepoClient(ctx context.Context, logger *zap.Logger) clients.RepoClient {
+	client := createGithubRepoClient(ctx, logger)
+	client.fast = true
+	return client
+}
+
+// NewTransport returns the transport of the GitHub API clients, authenticated
+// with the credentials configured in the environment.
+func NewTransport(ctx context.Context, logger *zap.Logger) http.RoundTripper {
+	return roundtripper.NewTransport(ctx, logger.Sugar(), appAPIURL())
+}
+
+func createGithubRepoClient(ctx context.Context, logger *zap.Logger) *Client {
 	// Use our custom roundtripper
-	rt := roundtripper.NewTransport(ctx, logger.Sugar())
+	rt := NewTransport(ctx, logger)
 	httpClient := &http.Client{
 		Transport: rt,
 	}
@@ -192,11 +399,19 @@ func CreateGithubRepoClient(ctx context.Context, logger *zap.Logger) clients.Rep
 	return &Client{
 		ctx:        ctx,
 		repoClient: client,
+		httpClient: httpClient,
+		endpoints:  dotcomEndpoints,
+		// The tarball is downloaded without authentication, but still
+		// retries transient errors.
+		tarball: &tarballHandler{
+			httpClient: forge.NewHTTPClient(logger),
+		},
 		graphClient: &graphqlHandler{
 			client: graphClient,
 		},
 		contributors: &contributorsHandler{
 			ghClient: client,
+			orgCache: clients.SharedOrgCache(),
 		},
 		branches: &branchesHandler{
 			ghClient:    client,
@@ -205,6 +420,9 @@ func CreateGithubRepoClient(ctx context.Context, logger *zap.Logger) clients.Rep
 		releases: &releasesHandler{
 			client: client,
 		},
+		tags: &tagsHandler{
+			graphClient: graphClient,
+		},
 		workflows: &workflowsHandler{
 			client: client,
 		},
@@ -214,6 +432,12 @@ func CreateGithubRepoClient(ctx context.Context, logger *zap.Logger) clients.Rep
 		statuses: &statusesHandler{
 			client: client,
 		},
+		advisories: &advisoriesHandler{
+			client: client,
+		},
+		attestations: &attestationsHandler{
+			client: client,
+		},
 		search: &searchHandler{
 			ghClient: client,
 		},
@@ -234,13 +458,22 @@ func NewLogger(logLevel zapcore.Level) (*zap.Logger, error) {
 // CreateOssFuzzRepoClient returns a RepoClient implementation
 // intialized to `google/oss-fuzz` GitHub repository.
 func CreateOssFuzzRepoClient(ctx context.Context, logger *zap.Logger) (clients.RepoClient, error) {
+	return initOssFuzzRepoClient(CreateGithubRepoClient(ctx, logger))
+}
+
+// CreateFastOssFuzzRepoClient is like CreateOssFuzzRepoClient, but does not
+// download the `google/oss-fuzz` tarball. Only Search is supported.
+func CreateFastOssFuzzRepoClient(ctx context.Context, logger *zap.Logger) (clients.RepoClient, error) {
+	return initOssFuzzRepoClient(CreateFastGithubRepoClient(ctx, logger))
+}
+
+func initOssFuzzRepoClient(ossFuzzRepoClient clients.RepoClient) (clients.RepoClient, error) {
 	ossFuzzRepo, err := MakeGithubRepo("google/oss-fuzz")
 	if err != nil {
 		return nil, fmt.Errorf("error during githubrepo.MakeGithubRepo: %w", err)
 	}
 
-	ossFuzzRepoClient := CreateGithubRepoClient(ctx, logger)
-	if err := ossFuzzRepoClient.InitRepo(ossFuzzRepo); err != nil {
+	if err := ossFuzzRepoClient.InitRepo(ossFuzzRepo, clients.HeadSHA); err != nil {
 		return nil, fmt.Errorf("error during InitRepo: %w", err)
 	}
 	return ossFuzzRepoClient, nil
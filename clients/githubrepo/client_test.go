@@ -0,0 +1,151 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+func TestFastClientUnsupportedAPIs(t *testing.T) {
+	t.Parallel()
+	// CreateFastGithubRepoClient requires a GitHub token, so construct the client directly.
+	client := &Client{fast: true}
+
+	if _, err := client.ListFiles(func(string) (bool, error) { return true, nil }); !errors.Is(err, clients.ErrUnsupportedFeature) {
+		t.Errorf("ListFiles: expected ErrUnsupportedFeature, got %v", err)
+	}
+	if _, err := client.GetFileContent("README.md"); !errors.Is(err, clients.ErrUnsupportedFeature) {
+		t.Errorf("GetFileContent: expected ErrUnsupportedFeature, got %v", err)
+	}
+	if _, err := client.ListReleases(); !errors.Is(err, clients.ErrUnsupportedFeature) {
+		t.Errorf("ListReleases: expected ErrUnsupportedFeature, got %v", err)
+	}
+	if _, err := client.ListContributors(); !errors.Is(err, clients.ErrUnsupportedFeature) {
+		t.Errorf("ListContributors: expected ErrUnsupportedFeature, got %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+}
+
+func TestTokenPermissions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		permissions map[string]bool
+		header      http.Header
+		want        clients.TokenPermissions
+	}{
+		{
+			name: "anonymous",
+			want: clients.TokenPermissions{},
+		},
+		{
+			name:        "classic token",
+			permissions: map[string]bool{"admin": false, "push": true, "pull": true},
+			header:      http.Header{"X-Oauth-Scopes": []string{"repo, read:org"}},
+			want:        clients.TokenPermissions{Known: true, Scopes: []string{"repo", "read:org"}},
+		},
+		{
+			name:        "classic token without scopes",
+			permissions: map[string]bool{"admin": false, "pull": true},
+			header:      http.Header{"X-Oauth-Scopes": []string{""}},
+			want:        clients.TokenPermissions{Known: true, Scopes: []string{}},
+		},
+		{
+			name:        "app token",
+			permissions: map[string]bool{"admin": true, "pull": true},
+			header:      http.Header{},
+			want:        clients.TokenPermissions{Known: true, Admin: true},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := &github.Response{Response: &http.Response{Header: tt.header}}
+			got := tokenPermissions(&github.Repository{Permissions: tt.permissions}, resp)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("tokenPermissions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithCallContextInterruptsCalls(t *testing.T) {
+	t.Parallel()
+	tarball, err := os.ReadFile("testdata/basic.tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	interrupted := make(chan struct{})
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			// Hang until the client gives up.
+			<-r.Context().Done()
+			close(interrupted)
+			return
+		}
+		w.Write(tarball) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+
+	client := &Client{ctx: context.Background(), tarball: &tarballHandler{}}
+	repo := &github.Repository{
+		ArchiveURL: github.String(server.URL + "/repos/owner/repo/{archive_format}{/ref}"),
+	}
+	if err := client.tarball.init(repo, clients.HeadSHA); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := client.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	checkCtx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	view := client.WithCallContext(checkCtx)
+	if _, err := view.ListFiles(func(string) (bool, error) { return true, nil }); err == nil {
+		t.Error("ListFiles: expected an error once the check's context is done")
+	}
+	select {
+	case <-interrupted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the request of the check was not interrupted")
+	}
+
+	// The interrupted download does not fail the other checks.
+	files, err := client.ListFiles(func(string) (bool, error) { return true, nil })
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+	if len(files) != 3 {
+		t.Errorf("ListFiles returned %q, want 3 files", files)
+	}
+}
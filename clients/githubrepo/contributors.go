@@ -17,38 +17,33 @@ package githubrepo
 import (
 	"context"
 	"fmt"
-	"sync"
 
 	"github.com/google/go-github/v38/github"
 
 	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
 )
 
 type contributorsHandler struct {
 	ghClient     *github.Client
-	once         *sync.Once
-	ctx          context.Context
-	errSetup     error
+	orgCache     *clients.OrgCache
+	once         *forge.Once
 	owner        string
 	repo         string
 	contributors []clients.Contributor
 }
 
-func (handler *contributorsHandler) init(ctx context.Context, owner, repo string) {
-	handler.ctx = ctx
+func (handler *contributorsHandler) init(owner, repo string) {
 	handler.owner = owner
 	handler.repo = repo
-	handler.errSetup = nil
-	handler.once = new(sync.Once)
+	handler.once = new(forge.Once)
 }
 
-func (handler *contributorsHandler) setup() error {
-	handler.once.Do(func() {
-		contribs, _, err := handler.ghClient.Repositories.ListContributors(
-			handler.ctx, handler.owner, handler.repo, &github.ListContributorsOptions{})
-		if err != nil {
-			handler.errSetup = fmt.Errorf("error during ListContributors: %w", err)
-		}
+func (handler *contributorsHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		handler.contributors = nil
+		contribs, _, _ := handler.ghClient.Repositories.ListContributors(
+			ctx, handler.owner, handler.repo, &github.ListContributorsOptions{})
 
 		for _, contrib := range contribs {
 			if contrib.GetLogin() == "" {
@@ -60,29 +55,47 @@ func (handler *contributorsHandler) setup() error {
 					Login: contrib.GetLogin(),
 				},
 			}
-			orgs, _, err := handler.ghClient.Organizations.List(handler.ctx, contrib.GetLogin(), nil)
-			// This call can fail due to token scopes. So ignore error.
-			if err == nil {
-				for _, org := range orgs {
-					contributor.Organizations = append(contributor.Organizations, clients.User{
-						Login: org.GetLogin(),
-					})
-				}
-			}
-			user, _, err := handler.ghClient.Users.Get(handler.ctx, contrib.GetLogin())
-			if err != nil {
-				handler.errSetup = fmt.Errorf("error during Users.Get: %w", err)
-			}
-			contributor.Company = user.GetCompany()
+			affiliation, _ := handler.getAffiliation(ctx, contrib.GetLogin())
+			contributor.Organizations = affiliation.organizations
+			contributor.Company = affiliation.company
 			handler.contributors = append(handler.contributors, contributor)
 		}
-		handler.errSetup = nil
+		// Errors are not reported, but a fetch interrupted by ctx is retried.
+		return ctx.Err()
+	})
+}
+
+// affiliation is the org-level data of a contributor, shared by all the repos they contribute to.
+type affiliation struct {
+	organizations []clients.User
+	company       string
+}
+
+func (handler *contributorsHandler) getAffiliation(ctx context.Context, login string) (affiliation, error) {
+	ret, err := handler.orgCache.Get("contributor/"+login, func() (interface{}, error) {
+		var a affiliation
+		orgs, _, err := handler.ghClient.Organizations.List(ctx, login, nil)
+		// This call can fail due to token scopes. So ignore error.
+		if err == nil {
+			for _, org := range orgs {
+				a.organizations = append(a.organizations, clients.User{
+					Login: org.GetLogin(),
+				})
+			}
+		}
+		user, _, err := handler.ghClient.Users.Get(ctx, login)
+		if err != nil {
+			return a, fmt.Errorf("error during Users.Get: %w", err)
+		}
+		a.company = user.GetCompany()
+		return a, nil
 	})
-	return handler.errSetup
+	//nolint:forcetypeassert
+	return ret.(affiliation), err
 }
 
-func (handler *contributorsHandler) getContributors() ([]clients.Contributor, error) {
-	if err := handler.setup(); err != nil {
+func (handler *contributorsHandler) getContributors(ctx context.Context) ([]clients.Contributor, error) {
+	if err := handler.setup(ctx); err != nil {
 		return nil, fmt.Errorf("error during contributorsHandler.setup: %w", err)
 	}
 	return handler.contributors, nil
@@ -0,0 +1,133 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+const (
+	githubHost = "github.com"
+	// HostEnv is the hostname of a GitHub Enterprise Server instance, as used by the gh CLI.
+	HostEnv = "GH_HOST"
+	// APIURLEnv is the URL of the REST API, e.g., "https://ghe.example.com/api/v3".
+	// It is set by GitHub Actions.
+	APIURLEnv = "GITHUB_API_URL"
+	// GraphQLURLEnv is the URL of the GraphQL API, e.g., "https://ghe.example.com/api/graphql".
+	// It is set by GitHub Actions, and derived from the REST API URL otherwise.
+	GraphQLURLEnv = "GITHUB_GRAPHQL_URL"
+)
+
+// configuredAPIURL is the REST API URL set with SetAPIURL, if any.
+var configuredAPIURL string
+
+// SetAPIURL sets the URL of the REST API of a GitHub Enterprise Server instance,
+// e.g., from a flag, instead of the one in GITHUB_API_URL. It must be called
+// before creating any client.
+func SetAPIURL(apiURL string) {
+	configuredAPIURL = apiURL
+}
+
+func restAPIURL() string {
+	if configuredAPIURL != "" {
+		return configuredAPIURL
+	}
+	return os.Getenv(APIURLEnv)
+}
+
+// endpoints are the URLs of the APIs serving the repos of a host.
+type endpoints struct {
+	rest, graphql string
+}
+
+var dotcomEndpoints = endpoints{
+	rest:    "https://api.github.com/",
+	graphql: "https://api.github.com/graphql",
+}
+
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+func isDotcom(host string) bool {
+	return strings.EqualFold(host, githubHost) || strings.EqualFold(host, "api.github.com")
+}
+
+// enterpriseHost returns the host of the configured GitHub Enterprise Server instance, if any.
+func enterpriseHost() string {
+	if host := hostOf(restAPIURL()); host != "" && !isDotcom(host) {
+		return host
+	}
+	if host := os.Getenv(HostEnv); host != "" && !isDotcom(host) {
+		return host
+	}
+	return ""
+}
+
+func isGitHubHost(host string) bool {
+	if isDotcom(host) {
+		return true
+	}
+	enterprise := enterpriseHost()
+	return enterprise != "" && strings.EqualFold(host, enterprise)
+}
+
+// endpointsFor returns the endpoints serving the repos of host.
+// GitHub Enterprise Server serves the REST API under /api/v3
+// and the GraphQL API under /api/graphql, unless configured otherwise.
+func endpointsFor(host string) endpoints {
+	if isDotcom(host) {
+		return dotcomEndpoints
+	}
+	ret := endpoints{
+		rest:    fmt.Sprintf("https://%s/api/v3/", host),
+		graphql: fmt.Sprintf("https://%s/api/graphql", host),
+	}
+	if apiURL := restAPIURL(); strings.EqualFold(hostOf(apiURL), host) {
+		ret.rest = strings.TrimSuffix(apiURL, "/") + "/"
+		ret.graphql = strings.TrimSuffix(strings.TrimSuffix(apiURL, "/"), "/v3") + "/graphql"
+	}
+	if graphqlURL := os.Getenv(GraphQLURLEnv); strings.EqualFold(hostOf(graphqlURL), host) {
+		ret.graphql = graphqlURL
+	}
+	return ret
+}
+
+// appAPIURL returns the REST API URL of the configured GitHub Enterprise Server
+// instance, where the installation tokens of a GitHub App are created, if any.
+func appAPIURL() string {
+	host := enterpriseHost()
+	if host == "" {
+		return ""
+	}
+	return strings.TrimSuffix(endpointsFor(host).rest, "/")
+}
+
+// IsEnterpriseRepo returns whether repo is hosted on GitHub Enterprise Server.
+// Such repos are typically private to a company, so they should not be looked
+// up in public databases like OSS-Fuzz.
+func IsEnterpriseRepo(repo clients.Repo) bool {
+	r, ok := repo.(*repoURL)
+	return ok && !isDotcom(r.host)
+}
@@ -0,0 +1,190 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+//nolint:paralleltest // Uses t.Setenv.
+func TestEndpointsFor(t *testing.T) {
+	tests := []struct {
+		name       string
+		host       string
+		apiURL     string
+		graphqlURL string
+		expected   endpoints
+	}{
+		{
+			name:     "github.com",
+			host:     "github.com",
+			expected: dotcomEndpoints,
+		},
+		{
+			name:     "github.com with enterprise API URL",
+			host:     "github.com",
+			apiURL:   "https://ghe.example.com/api/v3",
+			expected: dotcomEndpoints,
+		},
+		{
+			name: "enterprise default",
+			host: "ghe.example.com",
+			expected: endpoints{
+				rest:    "https://ghe.example.com/api/v3/",
+				graphql: "https://ghe.example.com/api/graphql",
+			},
+		},
+		{
+			name:   "enterprise API URL",
+			host:   "ghe.example.com",
+			apiURL: "http://ghe.example.com/api/v3",
+			expected: endpoints{
+				rest:    "http://ghe.example.com/api/v3/",
+				graphql: "http://ghe.example.com/api/graphql",
+			},
+		},
+		{
+			name:       "enterprise GraphQL URL",
+			host:       "ghe.example.com",
+			apiURL:     "https://ghe.example.com/api/v3/",
+			graphqlURL: "https://ghe.example.com/graphql",
+			expected: endpoints{
+				rest:    "https://ghe.example.com/api/v3/",
+				graphql: "https://ghe.example.com/graphql",
+			},
+		},
+		{
+			name:   "API URL of another host",
+			host:   "ghe.example.com",
+			apiURL: "https://other.example.com/api/v3",
+			expected: endpoints{
+				rest:    "https://ghe.example.com/api/v3/",
+				graphql: "https://ghe.example.com/api/graphql",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(APIURLEnv, tt.apiURL)
+			t.Setenv(GraphQLURLEnv, tt.graphqlURL)
+			got := endpointsFor(tt.host)
+			if diff := cmp.Diff(tt.expected, got, cmp.AllowUnexported(endpoints{})); diff != "" {
+				t.Errorf("endpointsFor() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest // Uses t.Setenv.
+func TestMakeEnterpriseRepo(t *testing.T) {
+	tests := []struct {
+		name       string
+		ghHost     string
+		apiURL     string
+		input      string
+		wantErr    bool
+		enterprise bool
+	}{
+		{
+			name:  "github.com",
+			input: "github.com/foo/bar",
+		},
+		{
+			name:    "unconfigured host",
+			input:   "ghe.example.com/foo/bar",
+			wantErr: true,
+		},
+		{
+			name:       "GH_HOST",
+			ghHost:     "ghe.example.com",
+			input:      "https://ghe.example.com/foo/bar",
+			enterprise: true,
+		},
+		{
+			name:       "GITHUB_API_URL",
+			apiURL:     "https://ghe.example.com/api/v3",
+			input:      "ghe.example.com/foo/bar",
+			enterprise: true,
+		},
+		{
+			name:    "other host",
+			ghHost:  "ghe.example.com",
+			input:   "gitlab.com/foo/bar",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(HostEnv, tt.ghHost)
+			t.Setenv(APIURLEnv, tt.apiURL)
+			repo, err := MakeGithubRepo(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MakeGithubRepo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := IsEnterpriseRepo(repo); got != tt.enterprise {
+				t.Errorf("IsEnterpriseRepo() = %v, want %v", got, tt.enterprise)
+			}
+		})
+	}
+}
+
+func TestAppAPIURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		ghHost string
+		apiURL string
+		set    string
+		want   string
+	}{
+		{
+			name: "github.com",
+		},
+		{
+			name:   "GH_HOST",
+			ghHost: "ghe.example.com",
+			want:   "https://ghe.example.com/api/v3",
+		},
+		{
+			name:   "GITHUB_API_URL",
+			apiURL: "https://ghe.example.com/custom/api/",
+			want:   "https://ghe.example.com/custom/api",
+		},
+		{
+			name:   "SetAPIURL",
+			apiURL: "https://other.example.com/api/v3",
+			set:    "https://ghe.example.com/custom/api",
+			want:   "https://ghe.example.com/custom/api",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(HostEnv, tt.ghHost)
+			t.Setenv(APIURLEnv, tt.apiURL)
+			SetAPIURL(tt.set)
+			t.Cleanup(func() { SetAPIURL("") })
+			if got := appAPIURL(); got != tt.want {
+				t.Errorf("appAPIURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,12 +17,12 @@ package githubrepo
 import (
 	"context"
 	"fmt"
-	"sync"
 	"time"
 
 	"github.com/shurcooL/githubv4"
 
 	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
@@ -32,58 +32,96 @@ const (
 	reviewsToAnalyze      = 30
 	labelsToAnalyze       = 30
 	commitsToAnalyze      = 30
+	// Busy repos merge more than `pullRequestsToAnalyze` PRs in a few days.
+	// For those, keep paging back until the PRs cover `pullRequestsLookBackDays`,
+	// or we hit `maxPullRequestsToAnalyze`. Checks then sample from that window.
+	pullRequestsPerPage      = 100
+	pullRequestsLookBackDays = 30
+	maxPullRequestsToAnalyze = 500
 )
 
+// nolint: govet
+type pullRequest struct {
+	Author struct {
+		Login githubv4.String
+	}
+	Number      githubv4.Int
+	HeadRefOid  githubv4.String
+	MergeCommit struct {
+		Author struct {
+			User struct {
+				Login githubv4.String
+			}
+		}
+	}
+	MergedAt githubv4.DateTime
+	Labels   struct {
+		Nodes []struct {
+			Name githubv4.String
+		}
+	} `graphql:"labels(last: $labelsToAnalyze)"`
+	Reviews struct {
+		Nodes []struct {
+			State  githubv4.String
+			Author struct {
+				Login githubv4.String
+			}
+		}
+	} `graphql:"reviews(last: $reviewsToAnalyze)"`
+}
+
+// nolint: govet
+type pullRequests struct {
+	PageInfo struct {
+		HasPreviousPage githubv4.Boolean
+		StartCursor     githubv4.String
+	}
+	Nodes []pullRequest
+}
+
+// Used to page through merged PRs older than the ones in graphqlData.
+type pullRequestsData struct {
+	Repository struct {
+		PullRequests pullRequests `graphql:"pullRequests(last: $pullRequestsPerPage, before: $cursor, states: MERGED)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
 // nolint: govet
 type graphqlData struct {
 	Repository struct {
-		IsArchived       githubv4.Boolean
-		DefaultBranchRef struct {
-			Target struct {
-				Commit struct {
-					History struct {
-						Nodes []struct {
-							CommittedDate githubv4.DateTime
-							Message       githubv4.String
-							Oid           githubv4.GitObjectID
-							Committer     struct {
-								User struct {
-									Login githubv4.String
-								}
+		IsArchived githubv4.Boolean
+		Object     struct {
+			Commit struct {
+				History struct {
+					Nodes []struct {
+						CommittedDate githubv4.DateTime
+						Message       githubv4.String
+						Oid           githubv4.GitObjectID
+						Author        struct {
+							User struct {
+								Login githubv4.String
 							}
 						}
-					} `graphql:"history(first: $commitsToAnalyze)"`
-				} `graphql:"... on Commit"`
-			}
-		}
-		PullRequests struct {
-			Nodes []struct {
-				Author struct {
-					Login githubv4.String
-				}
-				Number      githubv4.Int
-				HeadRefOid  githubv4.String
-				MergeCommit struct {
-					Author struct {
-						User struct {
-							Login githubv4.String
+						Committer struct {
+							User struct {
+								Login githubv4.String
+							}
 						}
+						Parents struct {
+							Nodes []struct {
+								Oid githubv4.GitObjectID
+							}
+						} `graphql:"parents(first: 2)"`
+						// The merged PR which introduced the commit, if any.
+						AssociatedPullRequests struct {
+							Nodes []pullRequest
+						} `graphql:"associatedPullRequests(first: 1)"`
 					}
-				}
-				MergedAt githubv4.DateTime
-				Labels   struct {
-					Nodes []struct {
-						Name githubv4.String
-					}
-				} `graphql:"labels(last: $labelsToAnalyze)"`
-				Reviews struct {
-					Nodes []struct {
-						State githubv4.String
-					}
-				} `graphql:"reviews(last: $reviewsToAnalyze)"`
-			}
-		} `graphql:"pullRequests(last: $pullRequestsToAnalyze, states: MERGED)"`
-		Issues struct {
+				} `graphql:"history(first: $commitsToAnalyze)"`
+			} `graphql:"... on Commit"`
+		} `graphql:"object(expression: $commitExpression)"`
+		PullRequests pullRequests `graphql:"pullRequests(last: $pullRequestsToAnalyze, states: MERGED)"`
+		Issues       struct {
 			Nodes []struct {
 				// nolint: revive,stylecheck // naming according to githubv4 convention.
 				Url       *string
@@ -94,30 +132,29 @@ type graphqlData struct {
 }
 
 type graphqlHandler struct {
-	client   *githubv4.Client
-	data     *graphqlData
-	once     *sync.Once
-	ctx      context.Context
-	errSetup error
-	owner    string
-	repo     string
-	prs      []clients.PullRequest
-	commits  []clients.Commit
-	issues   []clients.Issue
-	archived bool
-}
-
-func (handler *graphqlHandler) init(ctx context.Context, owner, repo string) {
-	handler.ctx = ctx
+	client    *githubv4.Client
+	data      *graphqlData
+	once      *forge.Once
+	owner     string
+	repo      string
+	commitSHA string
+	prs       []clients.PullRequest
+	commits   []clients.Commit
+	issues    []clients.Issue
+	archived  bool
+}
+
+func (handler *graphqlHandler) init(owner, repo, commitSHA string) {
 	handler.owner = owner
 	handler.repo = repo
+	handler.commitSHA = commitSHA
 	handler.data = new(graphqlData)
-	handler.errSetup = nil
-	handler.once = new(sync.Once)
+	handler.once = new(forge.Once)
 }
 
-func (handler *graphqlHandler) setup() error {
-	handler.once.Do(func() {
+func (handler *graphqlHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		handler.data = new(graphqlData)
 		vars := map[string]interface{}{
 			"owner":                 githubv4.String(handler.owner),
 			"name":                  githubv4.String(handler.repo),
@@ -126,89 +163,144 @@ func (handler *graphqlHandler) setup() error {
 			"reviewsToAnalyze":      githubv4.Int(reviewsToAnalyze),
 			"labelsToAnalyze":       githubv4.Int(labelsToAnalyze),
 			"commitsToAnalyze":      githubv4.Int(commitsToAnalyze),
+			"commitExpression":      githubv4.String(handler.commitSHA),
 		}
-		if err := handler.client.Query(handler.ctx, handler.data, vars); err != nil {
-			handler.errSetup = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+		if err := handler.client.Query(ctx, handler.data, vars); err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
 		}
 		handler.archived = bool(handler.data.Repository.IsArchived)
-		handler.prs = pullRequestsFrom(handler.data)
+		handler.prs = pullRequestsFrom(&handler.data.Repository.PullRequests)
+		if err := handler.fetchOlderPullRequests(ctx); err != nil {
+			return err
+		}
 		handler.commits = commitsFrom(handler.data)
 		handler.issues = issuesFrom(handler.data)
+		return nil
 	})
-	return handler.errSetup
 }
 
-func (handler *graphqlHandler) getMergedPRs() ([]clients.PullRequest, error) {
-	if err := handler.setup(); err != nil {
+// fetchOlderPullRequests pages back through merged PRs while the
+// PRs fetched so far were all merged within `pullRequestsLookBackDays`.
+func (handler *graphqlHandler) fetchOlderPullRequests(ctx context.Context) error {
+	threshold := clients.ScanTime(ctx).AddDate(0 /*years*/, 0 /*months*/, -1*pullRequestsLookBackDays /*days*/)
+	pageInfo := handler.data.Repository.PullRequests.PageInfo
+	for bool(pageInfo.HasPreviousPage) &&
+		len(handler.prs) > 0 &&
+		len(handler.prs) < maxPullRequestsToAnalyze &&
+		handler.prs[0].MergedAt.After(threshold) {
+		vars := map[string]interface{}{
+			"owner":               githubv4.String(handler.owner),
+			"name":                githubv4.String(handler.repo),
+			"pullRequestsPerPage": githubv4.Int(pullRequestsPerPage),
+			"reviewsToAnalyze":    githubv4.Int(reviewsToAnalyze),
+			"labelsToAnalyze":     githubv4.Int(labelsToAnalyze),
+			"cursor":              pageInfo.StartCursor,
+		}
+		data := new(pullRequestsData)
+		if err := handler.client.Query(ctx, data, vars); err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+		}
+		// Older PRs go first, to keep the list sorted like a single page.
+		handler.prs = append(pullRequestsFrom(&data.Repository.PullRequests), handler.prs...)
+		pageInfo = data.Repository.PullRequests.PageInfo
+	}
+	return nil
+}
+
+func (handler *graphqlHandler) getMergedPRs(ctx context.Context) ([]clients.PullRequest, error) {
+	if err := handler.setup(ctx); err != nil {
 		return nil, fmt.Errorf("error during graphqlHandler.setup: %w", err)
 	}
 	return handler.prs, nil
 }
 
-func (handler *graphqlHandler) getCommits() ([]clients.Commit, error) {
-	if err := handler.setup(); err != nil {
+func (handler *graphqlHandler) getCommits(ctx context.Context) ([]clients.Commit, error) {
+	if err := handler.setup(ctx); err != nil {
 		return nil, fmt.Errorf("error during graphqlHandler.setup: %w", err)
 	}
 	return handler.commits, nil
 }
 
-func (handler *graphqlHandler) getIssues() ([]clients.Issue, error) {
-	if err := handler.setup(); err != nil {
+func (handler *graphqlHandler) getIssues(ctx context.Context) ([]clients.Issue, error) {
+	if err := handler.setup(ctx); err != nil {
 		return nil, fmt.Errorf("error during graphqlHandler.setup: %w", err)
 	}
 	return handler.issues, nil
 }
 
-func (handler *graphqlHandler) isArchived() (bool, error) {
-	if err := handler.setup(); err != nil {
+func (handler *graphqlHandler) isArchived(ctx context.Context) (bool, error) {
+	if err := handler.setup(ctx); err != nil {
 		return false, fmt.Errorf("error during graphqlHandler.setup: %w", err)
 	}
 	return handler.archived, nil
 }
 
-func pullRequestsFrom(data *graphqlData) []clients.PullRequest {
-	ret := make([]clients.PullRequest, len(data.Repository.PullRequests.Nodes))
-	for i := range data.Repository.PullRequests.Nodes {
-		pr := data.Repository.PullRequests.Nodes[i]
-		toAppend := clients.PullRequest{
-			Number:   int(pr.Number),
-			HeadSHA:  string(pr.HeadRefOid),
-			MergedAt: pr.MergedAt.Time,
-			Author: clients.User{
-				Login: string(pr.Author.Login),
+func pullRequestsFrom(data *pullRequests) []clients.PullRequest {
+	ret := make([]clients.PullRequest, len(data.Nodes))
+	for i := range data.Nodes {
+		ret[i] = pullRequestFrom(&data.Nodes[i])
+	}
+	return ret
+}
+
+func pullRequestFrom(pr *pullRequest) clients.PullRequest {
+	ret := clients.PullRequest{
+		Number:   int(pr.Number),
+		HeadSHA:  string(pr.HeadRefOid),
+		MergedAt: pr.MergedAt.Time,
+		Author: clients.User{
+			Login: string(pr.Author.Login),
+		},
+		MergeCommit: clients.Commit{
+			Committer: clients.User{
+				Login: string(pr.MergeCommit.Author.User.Login),
 			},
-			MergeCommit: clients.Commit{
-				Committer: clients.User{
-					Login: string(pr.MergeCommit.Author.User.Login),
-				},
+		},
+	}
+	for _, label := range pr.Labels.Nodes {
+		ret.Labels = append(ret.Labels, clients.Label{
+			Name: string(label.Name),
+		})
+	}
+	for _, review := range pr.Reviews.Nodes {
+		ret.Reviews = append(ret.Reviews, clients.Review{
+			State: string(review.State),
+			Author: clients.User{
+				Login: string(review.Author.Login),
 			},
-		}
-		for _, label := range pr.Labels.Nodes {
-			toAppend.Labels = append(toAppend.Labels, clients.Label{
-				Name: string(label.Name),
-			})
-		}
-		for _, review := range pr.Reviews.Nodes {
-			toAppend.Reviews = append(toAppend.Reviews, clients.Review{
-				State: string(review.State),
-			})
-		}
-		ret[i] = toAppend
+		})
 	}
 	return ret
 }
 
 func commitsFrom(data *graphqlData) []clients.Commit {
 	ret := make([]clients.Commit, 0)
-	for _, commit := range data.Repository.DefaultBranchRef.Target.Commit.History.Nodes {
-		ret = append(ret, clients.Commit{
+	for _, commit := range data.Repository.Object.Commit.History.Nodes {
+		toAppend := clients.Commit{
 			CommittedDate: commit.CommittedDate.Time,
 			Message:       string(commit.Message),
 			SHA:           string(commit.Oid),
+			Author: clients.User{
+				Login: string(commit.Author.User.Login),
+			},
 			Committer: clients.User{
 				Login: string(commit.Committer.User.Login),
 			},
-		})
+		}
+		for _, parent := range commit.Parents.Nodes {
+			toAppend.ParentSHAs = append(toAppend.ParentSHAs, string(parent.Oid))
+		}
+		for i := range commit.AssociatedPullRequests.Nodes {
+			pr := &commit.AssociatedPullRequests.Nodes[i]
+			// Commits not yet on the default branch can be associated with open PRs.
+			if pr.MergedAt.IsZero() {
+				continue
+			}
+			associated := pullRequestFrom(pr)
+			toAppend.AssociatedMergeRequest = &associated
+			break
+		}
+		ret = append(ret, toAppend)
 	}
 	return ret
 }
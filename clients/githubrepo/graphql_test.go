@@ -0,0 +1,148 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+func TestCommitsFrom(t *testing.T) {
+	t.Parallel()
+	const data = `{
+  "repository": {
+    "object": {
+      "commit": {
+        "history": {
+          "nodes": [
+            {
+              "committedDate": "2021-10-01T00:00:00Z",
+              "message": "Merge pull request #2",
+              "oid": "sha2",
+              "author": {
+                "user": {
+                  "login": "author"
+                }
+              },
+              "committer": {
+                "user": {
+                  "login": "web-flow"
+                }
+              },
+              "associatedPullRequests": {
+                "nodes": [
+                  {
+                    "author": {
+                      "login": "author"
+                    },
+                    "number": 2,
+                    "headRefOid": "head2",
+                    "mergedAt": "2021-10-01T00:00:00Z",
+                    "labels": {
+                      "nodes": [
+                        {
+                          "name": "lgtm"
+                        }
+                      ]
+                    },
+                    "reviews": {
+                      "nodes": [
+                        {
+                          "state": "APPROVED",
+                          "author": {
+                            "login": "reviewer"
+                          }
+                        }
+                      ]
+                    }
+                  }
+                ]
+              }
+            },
+            {
+              "committedDate": "2021-09-01T00:00:00Z",
+              "message": "Pushed directly",
+              "oid": "sha1",
+              "author": {
+                "user": {
+                  "login": "maintainer"
+                }
+              },
+              "committer": {
+                "user": {
+                  "login": "maintainer"
+                }
+              },
+              "associatedPullRequests": {
+                "nodes": [
+                  {
+                    "author": {
+                      "login": "contributor"
+                    },
+                    "number": 1,
+                    "headRefOid": "head1",
+                    "mergedAt": null
+                  }
+                ]
+              }
+            }
+          ]
+        }
+      }
+    }
+  }
+}`
+	// encoding/json does not flatten the "... on Commit" fragment
+	// like the GraphQL client does, hence the "commit" object.
+	var gd graphqlData
+	if err := json.Unmarshal([]byte(data), &gd); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	want := []clients.Commit{
+		{
+			CommittedDate: time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC),
+			Message:       "Merge pull request #2",
+			SHA:           "sha2",
+			Author:        clients.User{Login: "author"},
+			Committer:     clients.User{Login: "web-flow"},
+			AssociatedMergeRequest: &clients.PullRequest{
+				Number:   2,
+				HeadSHA:  "head2",
+				MergedAt: time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC),
+				Author:   clients.User{Login: "author"},
+				Labels:   []clients.Label{{Name: "lgtm"}},
+				Reviews: []clients.Review{
+					{State: "APPROVED", Author: clients.User{Login: "reviewer"}},
+				},
+			},
+		},
+		{
+			// Open PRs are not associated with the commit.
+			CommittedDate: time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC),
+			Message:       "Pushed directly",
+			SHA:           "sha1",
+			Author:        clients.User{Login: "maintainer"},
+			Committer:     clients.User{Login: "maintainer"},
+		},
+	}
+	if diff := cmp.Diff(want, commitsFrom(&gd)); diff != "" {
+		t.Errorf("commitsFrom() mismatch (-want +got):\n%s", diff)
+	}
+}
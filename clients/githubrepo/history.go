@@ -0,0 +1,64 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v38/github"
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// ListCommitsAt returns, for each of times, the SHA of the latest commit of the
+// default branch of repo committed at or before it, or "" if there was none yet.
+func ListCommitsAt(ctx context.Context, logger *zap.Logger, repo clients.Repo,
+	times []time.Time) ([]string, error) {
+	r, ok := repo.(*repoURL)
+	if !ok {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("not a GitHub repo: %s", repo.URI()))
+	}
+	client := github.NewClient(&http.Client{
+		Transport: NewTransport(ctx, logger),
+	})
+	return listCommitsAt(ctx, client, r.owner, r.repo, times)
+}
+
+func listCommitsAt(ctx context.Context, client *github.Client, owner, repo string,
+	times []time.Time) ([]string, error) {
+	ret := make([]string, 0, len(times))
+	for _, t := range times {
+		// Without a SHA, the commits of the default branch are listed, most recent first.
+		opts := &github.CommitsListOptions{
+			Until:       t,
+			ListOptions: github.ListOptions{PerPage: 1},
+		}
+		commits, _, err := client.Repositories.ListCommits(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Repositories.ListCommits: %v", err))
+		}
+		sha := ""
+		if len(commits) > 0 {
+			sha = commits[0].GetSHA()
+		}
+		ret = append(ret, sha)
+	}
+	return ret, nil
+}
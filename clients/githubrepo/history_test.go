@@ -0,0 +1,76 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v38/github"
+)
+
+func TestListCommitsAt(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/commits" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("per_page") != "1" {
+			http.Error(w, "expected a single commit to be requested", http.StatusBadRequest)
+			return
+		}
+		body := `[]`
+		switch r.URL.Query().Get("until") {
+		case "2023-02-01T00:00:00Z":
+			body = `[{"sha": "aaa"}]`
+		case "2023-03-01T00:00:00Z":
+			body = `[{"sha": "bbb"}]`
+		}
+		//nolint:errcheck
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	client.BaseURL = baseURL
+
+	times := []time.Time{
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	got, err := listCommitsAt(context.Background(), client, "owner", "repo", times)
+	if err != nil {
+		t.Fatalf("listCommitsAt: %v", err)
+	}
+	want := []string{"", "aaa", "bbb"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("listCommitsAt() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := listCommitsAt(context.Background(), client, "owner", "other", times); err == nil {
+		t.Error("expected error for unknown repo")
+	}
+}
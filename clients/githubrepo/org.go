@@ -0,0 +1,59 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v38/github"
+	"go.uber.org/zap"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// ListOrgRepos returns the URIs of the non-archived repos of a GitHub organization.
+func ListOrgRepos(ctx context.Context, logger *zap.Logger, org string) ([]string, error) {
+	client := github.NewClient(&http.Client{
+		Transport: NewTransport(ctx, logger),
+	})
+	return listOrgRepos(ctx, client, org)
+}
+
+func listOrgRepos(ctx context.Context, client *github.Client, org string) ([]string, error) {
+	const perPage = 100
+	opts := &github.RepositoryListByOrgOptions{
+		Type:        "all",
+		ListOptions: github.ListOptions{PerPage: perPage},
+	}
+	var ret []string
+	for {
+		repos, resp, err := client.Repositories.ListByOrg(ctx, org, opts)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("Repositories.ListByOrg: %v", err))
+		}
+		for _, r := range repos {
+			if r.GetArchived() || r.GetDisabled() {
+				continue
+			}
+			ret = append(ret, fmt.Sprintf("github.com/%s/%s", org, r.GetName()))
+		}
+		if resp.NextPage == 0 {
+			return ret, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v38/github"
+)
+
+func TestListOrgRepos(t *testing.T) {
+	t.Parallel()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/myorg/repos" {
+			http.NotFound(w, r)
+			return
+		}
+		body := `[{"name": "a"}, {"name": "archived", "archived": true}]`
+		if r.URL.Query().Get("page") == "2" {
+			body = `[{"name": "b"}, {"name": "disabled", "disabled": true}]`
+		} else {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/myorg/repos?page=2>; rel="next"`, server.URL))
+		}
+		//nolint:errcheck
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	client.BaseURL = baseURL
+
+	got, err := listOrgRepos(context.Background(), client, "myorg")
+	if err != nil {
+		t.Fatalf("listOrgRepos: %v", err)
+	}
+	want := []string{"github.com/myorg/a", "github.com/myorg/b"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("listOrgRepos() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := listOrgRepos(context.Background(), client, "other"); err == nil {
+		t.Error("expected error for unknown org")
+	}
+}
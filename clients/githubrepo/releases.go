@@ -17,52 +17,65 @@ package githubrepo
 import (
 	"context"
 	"fmt"
-	"sync"
+	"net/http"
 
 	"github.com/google/go-github/v38/github"
 
 	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
+// repositoryRelease is a github.RepositoryRelease whose assets have their
+// digest, which go-github does not decode yet.
+type repositoryRelease struct {
+	github.RepositoryRelease
+	Assets []*releaseAsset `json:"assets,omitempty"`
+}
+
+type releaseAsset struct {
+	github.ReleaseAsset
+	Digest *string `json:"digest,omitempty"`
+}
+
 type releasesHandler struct {
 	client   *github.Client
-	once     *sync.Once
-	ctx      context.Context
-	errSetup error
+	once     *forge.Once
 	owner    string
 	repo     string
 	releases []clients.Release
 }
 
-func (handler *releasesHandler) init(ctx context.Context, owner, repo string) {
-	handler.ctx = ctx
+func (handler *releasesHandler) init(owner, repo string) {
 	handler.owner = owner
 	handler.repo = repo
-	handler.errSetup = nil
-	handler.once = new(sync.Once)
+	handler.once = new(forge.Once)
 }
 
-func (handler *releasesHandler) setup() error {
-	handler.once.Do(func() {
-		releases, _, err := handler.client.Repositories.ListReleases(
-			handler.ctx, handler.owner, handler.repo, &github.ListOptions{})
-		if err != nil {
-			handler.errSetup = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+func (handler *releasesHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		var releases []*repositoryRelease
+		u := fmt.Sprintf("repos/%s/%s/releases", handler.owner, handler.repo)
+		req, err := handler.client.NewRequest(http.MethodGet, u, nil)
+		if err == nil {
+			_, err = handler.client.Do(ctx, req, &releases)
 		}
 		handler.releases = releasesFrom(releases)
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+		}
+		return nil
 	})
-	return handler.errSetup
 }
 
-func (handler *releasesHandler) getReleases() ([]clients.Release, error) {
-	if err := handler.setup(); err != nil {
+func (handler *releasesHandler) getReleases(ctx context.Context) ([]clients.Release, error) {
+	if err := handler.setup(ctx); err != nil {
 		return nil, fmt.Errorf("error during graphqlHandler.setup: %w", err)
 	}
 	return handler.releases, nil
 }
 
-func releasesFrom(data []*github.RepositoryRelease) []clients.Release {
+func releasesFrom(data []*repositoryRelease) []clients.Release {
 	var releases []clients.Release
 	for _, r := range data {
 		release := clients.Release{
@@ -72,11 +85,20 @@ func releasesFrom(data []*github.RepositoryRelease) []clients.Release {
 		}
 		for _, a := range r.Assets {
 			release.Assets = append(release.Assets, clients.ReleaseAsset{
-				Name: a.GetName(),
-				URL:  a.GetURL(),
+				Name:   a.GetName(),
+				URL:    a.GetURL(),
+				Digest: a.GetDigest(),
 			})
 		}
 		releases = append(releases, release)
 	}
 	return releases
 }
+
+// GetDigest returns the Digest field if it's non-nil, zero value otherwise.
+func (a *releaseAsset) GetDigest() string {
+	if a == nil || a.Digest == nil {
+		return ""
+	}
+	return *a.Digest
+}
@@ -92,10 +92,8 @@ func (r *repoURL) Org() clients.Repo {
 
 // IsValid implements Repo.IsValid.
 func (r *repoURL) IsValid() error {
-	switch r.host {
-	case "github.com":
-	default:
-		return sce.WithMessage(sce.ErrorUnsupportedHost, r.host)
+	if !isGitHubHost(r.host) {
+		return sce.WithMessage(sce.ErrRepoUnsupportedHost, r.host)
 	}
 
 	if strings.TrimSpace(r.owner) == "" || strings.TrimSpace(r.repo) == "" {
@@ -116,6 +114,8 @@ func (r *repoURL) Metadata() []string {
 
 // MakeGithubRepo takes input of form "owner/repo" or "github.com/owner/repo"
 // and returns an implementation of clients.Repo interface.
+// Repos of the GitHub Enterprise Server instance configured with GH_HOST
+// or GITHUB_API_URL are accepted too, e.g., "ghe.example.com/owner/repo".
 func MakeGithubRepo(input string) (clients.Repo, error) {
 	var repo repoURL
 	if err := repo.parse(input); err != nil {
@@ -0,0 +1,97 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// ErrAPICallBudgetExceeded is returned for the API calls made once the budget
+// of an APICallCounter is spent.
+var ErrAPICallBudgetExceeded = errors.New("API call budget exceeded")
+
+type apiCallCountersKey struct{}
+
+// APICallCounter counts the API calls made with the contexts it was added to
+// with WithAPICallCounter, failing them once its budget is spent.
+type APICallCounter struct {
+	calls int64
+	max   int64
+}
+
+// NewAPICallCounter returns a counter which allows max API calls, or any number if max is zero.
+func NewAPICallCounter(max int) *APICallCounter {
+	return &APICallCounter{max: int64(max)}
+}
+
+// Calls returns the API calls counted so far, including the ones refused for exceeding the budget.
+func (c *APICallCounter) Calls() int {
+	return int(atomic.LoadInt64(&c.calls))
+}
+
+// Exceeded returns whether an API call was refused for exceeding the budget.
+func (c *APICallCounter) Exceeded() bool {
+	return c.max > 0 && atomic.LoadInt64(&c.calls) > c.max
+}
+
+// WithAPICallCounter returns a copy of ctx whose API calls are counted by c,
+// as well as by the counters ctx already had, e.g. per check and per scan.
+func WithAPICallCounter(ctx context.Context, c *APICallCounter) context.Context {
+	counters := apiCallCounters(ctx)
+	return context.WithValue(ctx, apiCallCountersKey{}, append(counters[:len(counters):len(counters)], c))
+}
+
+// WithAPICallCountersOf returns a copy of ctx whose API calls are counted by
+// the counters of from instead, e.g. for a client shared by several checks
+// to count its calls against the check it makes them for.
+func WithAPICallCountersOf(ctx, from context.Context) context.Context {
+	return context.WithValue(ctx, apiCallCountersKey{}, apiCallCounters(from))
+}
+
+func apiCallCounters(ctx context.Context) []*APICallCounter {
+	counters, _ := ctx.Value(apiCallCountersKey{}).([]*APICallCounter)
+	return counters
+}
+
+// countAPICall counts an API call made with ctx, failing if it exceeds the budget of one of its counters.
+func countAPICall(ctx context.Context) error {
+	var err error
+	for _, c := range apiCallCounters(ctx) {
+		if n := atomic.AddInt64(&c.calls, 1); c.max > 0 && n > c.max && err == nil {
+			err = sce.Wrap(sce.ErrScorecardInternal, ErrAPICallBudgetExceeded, fmt.Sprintf("%d calls allowed", c.max))
+		}
+	}
+	return err
+}
+
+// apiCallCountingTransport counts the requests sent, retries included, with the counters of their context.
+type apiCallCountingTransport struct {
+	inner http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.RoundTrip.
+func (t *apiCallCountingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if err := countAPICall(r.Context()); err != nil {
+		return nil, err
+	}
+	//nolint:wrapcheck
+	return t.inner.RoundTrip(r)
+}
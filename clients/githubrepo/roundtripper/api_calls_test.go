@@ -0,0 +1,80 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+func TestAPICallCountingTransport(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	scan := NewAPICallCounter(3)
+	check := NewAPICallCounter(0)
+	scanCtx := WithAPICallCounter(context.Background(), scan)
+	checkCtx := WithAPICallCounter(scanCtx, check)
+	rt := &apiCallCountingTransport{inner: http.DefaultTransport}
+	get := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	// Calls made for another check of the same scan only count against the scan.
+	if err := get(scanCtx); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	// A client bound to the scan's context counts the calls made for the check.
+	if err := get(WithAPICallCountersOf(context.Background(), checkCtx)); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	if err := get(checkCtx); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	if scan.Exceeded() {
+		t.Error("budget exceeded before the call past it")
+	}
+	err := get(checkCtx)
+	if !errors.Is(err, ErrAPICallBudgetExceeded) || !errors.Is(err, sce.ErrScorecardInternal) {
+		t.Errorf("RoundTrip past the budget: %v", err)
+	}
+	if !scan.Exceeded() || check.Exceeded() {
+		t.Errorf("Exceeded: scan %v, check %v", scan.Exceeded(), check.Exceeded())
+	}
+	if scan.Calls() != 4 || check.Calls() != 3 {
+		t.Errorf("Calls: scan %d, check %d, want 4 and 3", scan.Calls(), check.Calls())
+	}
+	// Calls without counters are not budgeted.
+	if err := get(context.Background()); err != nil {
+		t.Errorf("RoundTrip without counters: %v", err)
+	}
+}
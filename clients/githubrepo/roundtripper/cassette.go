@@ -0,0 +1,216 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const (
+	// cassetteDir is the environment variable for the directory
+	// where API interactions are recorded to or replayed from.
+	cassetteDir = "SCORECARD_CASSETTE_DIR"
+	// cassetteMode is the environment variable selecting whether
+	// to record or replay the cassette: "record" or "replay".
+	cassetteMode = "SCORECARD_CASSETTE_MODE"
+
+	cassetteRecord = "record"
+	cassetteReplay = "replay"
+)
+
+var errNoInteraction = errors.New("no recorded interaction")
+
+// interaction is a recorded HTTP request and its response.
+type interaction struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Body    string      `json:"body,omitempty"`
+	Status  int         `json:"status"`
+	Header  http.Header `json:"header,omitempty"`
+	Content []byte      `json:"content,omitempty"`
+}
+
+// cassette stores each interaction in its own file of dir, named after
+// the request and its sequence number, so that parallel test processes
+// can record to and replay from the same directory.
+type cassette struct {
+	mu   sync.Mutex
+	seen map[string]int
+	dir  string
+	mode string
+}
+
+//nolint:gochecknoglobals
+var (
+	cassettesMu sync.Mutex
+	cassettes   = map[string]*cassette{}
+)
+
+// cassetteFromEnv returns the cassette configured in the environment,
+// shared by all transports of the process, or nil if none is.
+func cassetteFromEnv() *cassette {
+	dir, mode := os.Getenv(cassetteDir), os.Getenv(cassetteMode)
+	if dir == "" || (mode != cassetteRecord && mode != cassetteReplay) {
+		return nil
+	}
+	cassettesMu.Lock()
+	defer cassettesMu.Unlock()
+	key := mode + ":" + dir
+	if c, ok := cassettes[key]; ok {
+		return c
+	}
+	c := &cassette{dir: dir, mode: mode, seen: make(map[string]int)}
+	cassettes[key] = c
+	return c
+}
+
+// IsReplayingCassette returns whether API responses are replayed from a
+// cassette, in which case no credentials are needed.
+func IsReplayingCassette() bool {
+	c := cassetteFromEnv()
+	return c != nil && c.mode == cassetteReplay
+}
+
+// WithCassette returns innerTransport, recording its interactions to the
+// cassette configured with SCORECARD_CASSETTE_DIR and
+// SCORECARD_CASSETTE_MODE=record, or replaces it with the recorded
+// interactions if SCORECARD_CASSETTE_MODE=replay. Without a cassette,
+// innerTransport is returned as is.
+func WithCassette(innerTransport http.RoundTripper) http.RoundTripper {
+	c := cassetteFromEnv()
+	if c == nil {
+		return innerTransport
+	}
+	return &cassetteTransport{innerTransport: innerTransport, cassette: c}
+}
+
+type cassetteTransport struct {
+	innerTransport http.RoundTripper
+	cassette       *cassette
+}
+
+func (ct *cassetteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	var body []byte
+	if r.Body != nil && r.Body != http.NoBody {
+		b, err := io.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("io.ReadAll: %v", err))
+		}
+		body = b
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	name := ct.cassette.next(r.Method, r.URL.String(), body)
+
+	if ct.cassette.mode == cassetteReplay {
+		return ct.cassette.replay(r, name)
+	}
+
+	resp, err := ct.innerTransport.RoundTrip(r)
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	content, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("io.ReadAll: %v", err))
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(content))
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	if err := ct.cassette.record(name, &interaction{
+		Method:  r.Method,
+		URL:     r.URL.String(),
+		Body:    string(body),
+		Status:  resp.StatusCode,
+		Header:  header,
+		Content: content,
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// next returns the file name of the next interaction for the request.
+// Requests made several times get one file per occurrence.
+func (c *cassette) next(method, url string, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", method, url)
+	h.Write(body)
+	key := hex.EncodeToString(h.Sum(nil))[:16]
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := c.seen[key]
+	c.seen[key]++
+	return fmt.Sprintf("%s-%d.json", key, n)
+}
+
+func (c *cassette) record(name string, i *interaction) error {
+	content, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.MarshalIndent: %v", err))
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.MkdirAll: %v", err))
+	}
+	//nolint:gosec // Cassettes are checked in, like other test data.
+	if err := os.WriteFile(filepath.Join(c.dir, name), content, 0o644); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.WriteFile: %v", err))
+	}
+	return nil
+}
+
+func (c *cassette) replay(r *http.Request, name string) (*http.Response, error) {
+	content, err := os.ReadFile(filepath.Join(c.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("%v for %s %s in %s", errNoInteraction, r.Method, r.URL, c.dir))
+	}
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.ReadFile: %v", err))
+	}
+	var i interaction
+	if err := json.Unmarshal(content, &i); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Unmarshal: %v", err))
+	}
+	if i.Header == nil {
+		i.Header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Status, http.StatusText(i.Status)),
+		StatusCode:    i.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        i.Header,
+		Body:          io.NopCloser(bytes.NewReader(i.Content)),
+		ContentLength: int64(len(i.Content)),
+		Request:       r,
+	}, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+func TestCassetteRecordReplay(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		n := calls
+		mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Call", strings.Repeat("i", n))
+		w.WriteHeader(http.StatusAccepted)
+		io.WriteString(w, r.Method+" "+r.URL.Path+" "+string(body)) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	requests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{method: http.MethodGet, path: "/repos/owner/repo"},
+		{method: http.MethodPost, path: "/graphql", body: `{"query": "a"}`},
+		{method: http.MethodPost, path: "/graphql", body: `{"query": "b"}`},
+		// Repeated requests replay their responses in order.
+		{method: http.MethodGet, path: "/repos/owner/repo"},
+	}
+	roundTrips := func(rt http.RoundTripper) []string {
+		var ret []string
+		for _, r := range requests {
+			var body io.Reader
+			if r.body != "" {
+				body = strings.NewReader(r.body)
+			}
+			req, err := http.NewRequestWithContext(context.Background(), r.method, server.URL+r.path, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := rt.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip: %v", err)
+			}
+			content, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			ret = append(ret, resp.Status+" "+resp.Header.Get("X-Call")+" "+string(content))
+		}
+		return ret
+	}
+
+	recorded := roundTrips(&cassetteTransport{
+		innerTransport: http.DefaultTransport,
+		cassette:       &cassette{dir: dir, mode: cassetteRecord, seen: make(map[string]int)},
+	})
+	// The server is not called again when replaying.
+	replayed := roundTrips(&cassetteTransport{
+		cassette: &cassette{dir: dir, mode: cassetteReplay, seen: make(map[string]int)},
+	})
+	if calls != len(requests) {
+		t.Errorf("server called %d times, want %d", calls, len(requests))
+	}
+	for i := range recorded {
+		if recorded[i] != replayed[i] {
+			t.Errorf("request %d: replayed %q, recorded %q", i, replayed[i], recorded[i])
+		}
+	}
+	if recorded[0] == recorded[3] {
+		t.Errorf("repeated requests got the same response %q", recorded[0])
+	}
+}
+
+func TestCassetteReplayMissingInteraction(t *testing.T) {
+	t.Parallel()
+	rt := &cassetteTransport{
+		cassette: &cassette{dir: t.TempDir(), mode: cassetteReplay, seen: make(map[string]int)},
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://api.github.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//nolint:bodyclose // No response is returned.
+	if _, err := rt.RoundTrip(req); !errors.Is(err, sce.ErrScorecardInternal) {
+		t.Errorf("RoundTrip() error = %v, want %v", err, sce.ErrScorecardInternal)
+	}
+}
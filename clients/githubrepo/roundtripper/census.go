@@ -15,8 +15,10 @@
 package roundtripper
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"go.opencensus.io/plugin/ochttp"
 	opencensusstats "go.opencensus.io/stats"
@@ -44,7 +46,9 @@ type censusTransport struct {
 
 // Roundtrip handles context update and measurement recording.
 func (ct *censusTransport) RoundTrip(r *http.Request) (*http.Response, error) {
-	ctx, err := tag.New(r.Context(), tag.Upsert(stats.RequestTag, "requested"))
+	ctx, err := tag.New(r.Context(),
+		tag.Upsert(stats.RequestTag, "requested"),
+		tag.Upsert(stats.RequestHost, r.URL.Host))
 	if err != nil {
 		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("tag.New: %v", err))
 	}
@@ -52,14 +56,18 @@ func (ct *censusTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 	r = r.WithContext(ctx)
 	resp, err := ct.innerTransport.RoundTrip(r)
 	if err != nil {
+		recordRequest(ctx, tag.Upsert(stats.ResponseStatus, "error"))
 		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("innerTransport.RoundTrip: %v", err))
 	}
+	mutators := []tag.Mutator{tag.Upsert(stats.ResponseStatus, strconv.Itoa(resp.StatusCode))}
 	if resp.Header.Get(fromCacheHeader) != "" {
-		ctx, err = tag.New(ctx, tag.Upsert(stats.RequestTag, fromCacheHeader))
-		if err != nil {
-			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("tag.New: %v", err))
-		}
+		mutators = append(mutators, tag.Upsert(stats.RequestTag, fromCacheHeader))
 	}
-	opencensusstats.Record(ctx, stats.HTTPRequests.M(1))
+	recordRequest(ctx, mutators...)
 	return resp, nil
 }
+
+func recordRequest(ctx context.Context, mutators ...tag.Mutator) {
+	//nolint:errcheck
+	opencensusstats.RecordWithTags(ctx, mutators, stats.HTTPRequests.M(1))
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// Evidence identifies the data an API served in a recorded interaction:
+// when it was served, and which version of the resource it was.
+type Evidence struct {
+	File         string `json:"file"`
+	Method       string `json:"method"`
+	URL          string `json:"url"`
+	Date         string `json:"date,omitempty"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Status       int    `json:"status"`
+}
+
+// UseCassette records the API interactions of the transports created afterwards
+// to dir, or replays them from dir if replay is set, see WithCassette.
+func UseCassette(dir string, replay bool) error {
+	mode := cassetteRecord
+	if replay {
+		mode = cassetteReplay
+	}
+	if err := os.Setenv(cassetteDir, dir); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.Setenv: %v", err))
+	}
+	if err := os.Setenv(cassetteMode, mode); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.Setenv: %v", err))
+	}
+	return nil
+}
+
+// ListEvidence returns the evidence of the interactions recorded in dir, sorted by file.
+func ListEvidence(dir string) ([]Evidence, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*-*.json"))
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("filepath.Glob: %v", err))
+	}
+	sort.Strings(files)
+	ret := []Evidence{}
+	for _, f := range files {
+		content, err := os.ReadFile(f)
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.ReadFile: %v", err))
+		}
+		var i interaction
+		if err := json.Unmarshal(content, &i); err != nil || i.URL == "" {
+			// Not an interaction, e.g. a manifest stored next to them.
+			continue
+		}
+		ret = append(ret, Evidence{
+			File:         strings.TrimPrefix(f, filepath.Clean(dir)+string(filepath.Separator)),
+			Method:       i.Method,
+			URL:          i.URL,
+			Status:       i.Status,
+			Date:         i.Header.Get("Date"),
+			ETag:         i.Header.Get("ETag"),
+			LastModified: i.Header.Get("Last-Modified"),
+		})
+	}
+	return ret, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestListEvidence(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", "Mon, 02 Jan 2023 15:04:05 GMT")
+		if r.URL.Path == "/repos/owner/repo" {
+			w.Header().Set("ETag", `W/"abc"`)
+			w.Header().Set("Last-Modified", "Sun, 01 Jan 2023 00:00:00 GMT")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	rt := &cassetteTransport{
+		innerTransport: http.DefaultTransport,
+		cassette:       &cassette{dir: dir, mode: cassetteRecord, seen: make(map[string]int)},
+	}
+	for _, path := range []string{"/repos/owner/repo", "/missing"} {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("RoundTrip: %v", err)
+		}
+		io.Copy(io.Discard, resp.Body) //nolint:errcheck
+		resp.Body.Close()
+	}
+	// Files which are not interactions are skipped.
+	if err := os.WriteFile(filepath.Join(dir, "evidence-manifest.json"), []byte(`{}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ListEvidence(dir)
+	if err != nil {
+		t.Fatalf("ListEvidence: %v", err)
+	}
+	want := map[string]Evidence{
+		server.URL + "/repos/owner/repo": {
+			Method:       http.MethodGet,
+			URL:          server.URL + "/repos/owner/repo",
+			Status:       http.StatusOK,
+			Date:         "Mon, 02 Jan 2023 15:04:05 GMT",
+			ETag:         `W/"abc"`,
+			LastModified: "Sun, 01 Jan 2023 00:00:00 GMT",
+		},
+		server.URL + "/missing": {
+			Method: http.MethodGet,
+			URL:    server.URL + "/missing",
+			Status: http.StatusNotFound,
+			Date:   "Mon, 02 Jan 2023 15:04:05 GMT",
+		},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ListEvidence() returned %d entries, want %d: %v", len(got), len(want), got)
+	}
+	for _, e := range got {
+		if _, err := os.Stat(filepath.Join(dir, e.File)); err != nil {
+			t.Errorf("evidence file %q: %v", e.File, err)
+		}
+		e.File = ""
+		if diff := cmp.Diff(want[e.URL], e); diff != "" {
+			t.Errorf("evidence mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+)
+
+const (
+	// proxyURL is the URL of the proxy all requests are sent through. It overrides
+	// the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables, which are used otherwise.
+	proxyURL = "SCORECARD_PROXY"
+	// caFile is the path to a PEM file of CA certificates trusted in addition
+	// to the system ones, e.g., the CA of a TLS-intercepting proxy.
+	caFile = "SCORECARD_CA_FILE"
+)
+
+var (
+	errNetworkConfig = errors.New("invalid network configuration")
+
+	baseTransportOnce sync.Once
+	baseTransport     http.RoundTripper
+	errBaseTransport  error
+)
+
+func initBaseTransport() {
+	baseTransportOnce.Do(func() {
+		var transport http.RoundTripper
+		transport, errBaseTransport = makeBaseTransport(os.Getenv(proxyURL), os.Getenv(caFile))
+		if errBaseTransport != nil {
+			transport = &failingTransport{err: errBaseTransport}
+		}
+		baseTransport = &apiCallCountingTransport{inner: &certificateErrorTransport{inner: transport}}
+	})
+}
+
+// CheckNetworkConfig returns an error if the proxy or CA certificates
+// configured in the environment are invalid. Commands call it once on startup,
+// BaseTransport fails every request with the same error otherwise.
+func CheckNetworkConfig() error {
+	initBaseTransport()
+	return errBaseTransport
+}
+
+// BaseTransport returns the transport all clients send their requests through,
+// using the proxy and CA certificates configured in the environment. It counts
+// the requests with the counters of their context, see WithAPICallCounter.
+func BaseTransport() http.RoundTripper {
+	initBaseTransport()
+	return baseTransport
+}
+
+func makeBaseTransport(proxy, caPath string) (*http.Transport, error) {
+	//nolint:forcetypeassert
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy != "" {
+		u, err := url.Parse(proxy)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("%w: %s: invalid URL %q", errNetworkConfig, proxyURL, proxy)
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+	if caPath != "" {
+		certs, err := os.ReadFile(caPath)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", errNetworkConfig, caFile, err)
+		}
+		// The system pool is unavailable on some platforms, in which case
+		// only the configured certificates are trusted.
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(certs) {
+			return nil, fmt.Errorf("%w: %s: no PEM certificates in %s", errNetworkConfig, caFile, caPath)
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+	return transport, nil
+}
+
+// failingTransport fails every request with the error of an invalid network configuration.
+type failingTransport struct {
+	err error
+}
+
+// RoundTrip implements http.RoundTripper.RoundTrip.
+func (t *failingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+// isCertificateError returns whether err is caused by an untrusted or invalid server certificate.
+func isCertificateError(err error) bool {
+	var unknownAuthority x509.UnknownAuthorityError
+	var invalid x509.CertificateInvalidError
+	var hostname x509.HostnameError
+	return errors.As(err, &unknownAuthority) || errors.As(err, &invalid) || errors.As(err, &hostname)
+}
+
+// certificateErrorTransport explains certificate errors, which are typically
+// caused by a proxy intercepting TLS connections with its own CA.
+type certificateErrorTransport struct {
+	inner http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.RoundTrip.
+func (t *certificateErrorTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := t.inner.RoundTrip(r)
+	if err != nil && isCertificateError(err) {
+		return nil, fmt.Errorf("%w (if a proxy intercepts TLS connections, set %s to its CA certificates)", err, caFile)
+	}
+	//nolint:wrapcheck
+	return resp, err
+}
@@ -0,0 +1,137 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMakeBaseTransportProxy(t *testing.T) {
+	t.Parallel()
+	transport, err := makeBaseTransport("http://proxy.example.com:3128", "")
+	if err != nil {
+		t.Fatalf("makeBaseTransport: %v", err)
+	}
+	req, err := http.NewRequest(http.MethodGet, "https://api.github.com", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	proxy, err := transport.Proxy(req)
+	if err != nil {
+		t.Fatalf("Proxy: %v", err)
+	}
+	if proxy == nil || proxy.Host != "proxy.example.com:3128" {
+		t.Errorf("Proxy() = %v, want proxy.example.com:3128", proxy)
+	}
+
+	if _, err := makeBaseTransport("proxy.example.com", ""); err == nil {
+		t.Error("expected error for proxy without scheme")
+	}
+}
+
+func TestMakeBaseTransportCAFile(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	certs := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caPath, certs, 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	invalidPath := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(invalidPath, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		caPath    string
+		wantErr   string
+		configErr bool
+	}{
+		{
+			name:    "untrusted certificate",
+			wantErr: caFile,
+		},
+		{
+			name:   "trusted certificate",
+			caPath: caPath,
+		},
+		{
+			name:      "missing file",
+			caPath:    filepath.Join(dir, "missing.pem"),
+			configErr: true,
+		},
+		{
+			name:      "no certificates",
+			caPath:    invalidPath,
+			configErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			transport, err := makeBaseTransport("", tt.caPath)
+			if (err != nil) != tt.configErr {
+				t.Fatalf("makeBaseTransport() error = %v, configErr %v", err, tt.configErr)
+			}
+			if err != nil {
+				return
+			}
+			client := &http.Client{Transport: &certificateErrorTransport{inner: transport}}
+			resp, err := client.Get(server.URL)
+			if resp != nil {
+				resp.Body.Close()
+			}
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Get: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !isCertificateError(err) {
+				t.Errorf("Get() error = %v, want certificate error mentioning %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFailingTransport(t *testing.T) {
+	t.Parallel()
+	_, err := makeBaseTransport("proxy.example.com", "")
+	if err == nil {
+		t.Fatal("expected error for proxy without scheme")
+	}
+	req, err2 := http.NewRequest(http.MethodGet, "https://api.github.com", nil)
+	if err2 != nil {
+		t.Fatalf("http.NewRequest: %v", err2)
+	}
+	//nolint:bodyclose
+	if _, got := (&failingTransport{err: err}).RoundTrip(req); !errors.Is(got, errNetworkConfig) {
+		t.Errorf("RoundTrip() error = %v, want %v", got, errNetworkConfig)
+	}
+}
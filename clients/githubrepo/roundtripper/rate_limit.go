@@ -15,9 +15,11 @@
 package roundtripper
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -25,11 +27,67 @@ import (
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
+// rateLimitBudget tracks when the GitHub quota, shared by all the transports of
+// this process, resets. Once one request finds it exhausted, the others wait for
+// the reset too, instead of each spending a request to find out, e.g., when
+// scanning repos concurrently.
+type rateLimitBudget struct {
+	mu         sync.Mutex
+	resetAfter time.Time
+	sleep      func(ctx context.Context, d time.Duration) error
+	// remaining is the quota reported by the last response, if known.
+	remaining      int
+	remainingKnown bool
+}
+
+var sharedRateLimitBudget = &rateLimitBudget{sleep: sleepContext}
+
+// RemainingQuota returns the GitHub API requests left, as reported by the
+// last response to this process, and whether any response reported it.
+func RemainingQuota() (int, bool) {
+	return sharedRateLimitBudget.quota()
+}
+
+// record records the quota left reported by a response.
+func (b *rateLimitBudget) record(remaining int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remaining = remaining
+	b.remainingKnown = true
+}
+
+func (b *rateLimitBudget) quota() (int, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.remaining, b.remainingKnown
+}
+
+// exhaust records that the quota is exhausted until reset.
+func (b *rateLimitBudget) exhaust(reset time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if reset.After(b.resetAfter) {
+		b.resetAfter = reset
+	}
+}
+
+// wait blocks until the quota resets, if it is exhausted.
+func (b *rateLimitBudget) wait(ctx context.Context) (time.Duration, error) {
+	b.mu.Lock()
+	d := time.Until(b.resetAfter)
+	b.mu.Unlock()
+	if d <= 0 {
+		return 0, nil
+	}
+	return d, b.sleep(ctx, d)
+}
+
 // MakeRateLimitedTransport returns a RoundTripper which rate limits GitHub requests.
 func MakeRateLimitedTransport(innerTransport http.RoundTripper, logger *zap.SugaredLogger) http.RoundTripper {
 	return &rateLimitTransport{
 		logger:         logger,
 		innerTransport: innerTransport,
+		budget:         sharedRateLimitBudget,
 	}
 }
 
@@ -37,10 +95,16 @@ func MakeRateLimitedTransport(innerTransport http.RoundTripper, logger *zap.Suga
 type rateLimitTransport struct {
 	logger         *zap.SugaredLogger
 	innerTransport http.RoundTripper
+	budget         *rateLimitBudget
 }
 
 // Roundtrip handles caching and ratelimiting of responses from GitHub.
 func (gh *rateLimitTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if d, err := gh.budget.wait(r.Context()); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("waiting for rate limit reset: %v", err))
+	} else if d > 0 {
+		gh.logger.Warnf("Rate limit exceeded. Waited %s for it to reset.", d)
+	}
 	resp, err := gh.innerTransport.RoundTrip(r)
 	if err != nil {
 		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("innerTransport.RoundTrip: %v", err))
@@ -50,19 +114,20 @@ func (gh *rateLimitTransport) RoundTrip(r *http.Request) (*http.Response, error)
 	if err != nil {
 		return resp, nil
 	}
+	gh.budget.record(remaining)
 
 	if remaining <= 0 {
 		reset, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Reset"))
 		if err != nil {
 			return resp, nil
 		}
+		if !rewindBody(r) {
+			return resp, nil
+		}
+		resp.Body.Close()
 
-		duration := time.Until(time.Unix(int64(reset), 0))
-		gh.logger.Warnf("Rate limit exceeded. Waiting %s to retry...", duration)
-
-		// Retry
-		time.Sleep(duration)
-		gh.logger.Warnf("Rate limit exceeded. Retrying...")
+		// Retry once the quota resets.
+		gh.budget.exhaust(time.Unix(int64(reset), 0))
 		return gh.RoundTrip(r)
 	}
 
@@ -0,0 +1,101 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestRateLimitTransportSharesBudget(t *testing.T) {
+	t.Parallel()
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		first := requests == 1
+		mu.Unlock()
+		if first {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset.Unix()))
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	var delays []time.Duration
+	budget := &rateLimitBudget{}
+	budget.sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		// The quota resets while sleeping.
+		budget.resetAfter = time.Time{}
+		return nil
+	}
+	newTransport := func() http.RoundTripper {
+		return &rateLimitTransport{
+			logger:         zap.NewNop().Sugar(),
+			innerTransport: http.DefaultTransport,
+			budget:         budget,
+		}
+	}
+
+	get := func(rt http.RoundTripper) {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("RoundTrip: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+	}
+	get(newTransport())
+	if len(delays) != 1 || delays[0] <= 0 || delays[0] > time.Hour {
+		t.Fatalf("delays = %v, want one wait until the reset", delays)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+	if remaining, ok := budget.quota(); !ok || remaining != 4999 {
+		t.Errorf("quota() = %d, %v, want 4999, true", remaining, ok)
+	}
+
+	// Another transport, e.g. of another repo scanned concurrently, waits for
+	// the same reset before sending anything.
+	budget.exhaust(reset)
+	get(newTransport())
+	if len(delays) != 2 {
+		t.Errorf("delays = %v, want the second transport to wait", delays)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
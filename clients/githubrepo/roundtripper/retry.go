@@ -0,0 +1,211 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// maxRetries is the environment variable overriding RetryConfig.MaxRetries.
+const maxRetries = "SCORECARD_API_MAX_RETRIES"
+
+// secondaryRateLimitDelay is how long GitHub asks clients to wait after hitting
+// a secondary rate limit which does not come with a Retry-After header.
+const secondaryRateLimitDelay = time.Minute
+
+// RetryConfig configures the retries of transient API errors.
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// BaseDelay is the delay before the first retry, doubled for each retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential backoff. It does not apply to
+	// delays requested by the server with Retry-After.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryConfig returns the retry configuration, with the number
+// of retries read from SCORECARD_API_MAX_RETRIES if set.
+func DefaultRetryConfig() RetryConfig {
+	//nolint:gomnd
+	config := RetryConfig{
+		MaxRetries: 3,
+		BaseDelay:  time.Second,
+		MaxDelay:   30 * time.Second,
+	}
+	if v, err := strconv.Atoi(os.Getenv(maxRetries)); err == nil && v >= 0 {
+		config.MaxRetries = v
+	}
+	return config
+}
+
+// MakeRetryTransport returns a RoundTripper which retries transient errors:
+// network errors, 502, 503 and 504 responses, and secondary rate limits.
+// It backs off exponentially with jitter, or waits as long as the
+// server asks with Retry-After.
+func MakeRetryTransport(innerTransport http.RoundTripper, logger *zap.SugaredLogger,
+	config RetryConfig) http.RoundTripper {
+	return &retryTransport{
+		innerTransport: innerTransport,
+		logger:         logger,
+		config:         config,
+		sleep:          sleepContext,
+	}
+}
+
+type retryTransport struct {
+	innerTransport http.RoundTripper
+	logger         *zap.SugaredLogger
+	sleep          func(ctx context.Context, d time.Duration) error
+	config         RetryConfig
+}
+
+func (rt *retryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := rt.innerTransport.RoundTrip(r)
+		delay, retry := rt.retryDelay(r, resp, err, attempt)
+		if !retry || attempt >= rt.config.MaxRetries || !rewindBody(r) {
+			//nolint:wrapcheck
+			return resp, err
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		rt.logger.Warnf("Transient error on %s %s (attempt %d/%d), retrying in %s: %s",
+			r.Method, r.URL.Redacted(), attempt+1, rt.config.MaxRetries+1, delay, describe(resp, err))
+		if err := rt.sleep(r.Context(), delay); err != nil {
+			//nolint:wrapcheck
+			return nil, err
+		}
+	}
+}
+
+// retryDelay returns whether the attempt should be retried, and after how long.
+func (rt *retryTransport) retryDelay(r *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if err != nil {
+		// Give up if the caller did.
+		if r.Context().Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return 0, false
+		}
+		// Errors of our own transports, e.g. a request missing from a
+		// replayed cassette, are not transient.
+		if errors.Is(err, sce.ErrScorecardInternal) {
+			return 0, false
+		}
+		// Neither are untrusted certificates.
+		if isCertificateError(err) {
+			return 0, false
+		}
+		return rt.backoff(attempt), true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if d, ok := retryAfter(resp); ok {
+			return d, true
+		}
+		return rt.backoff(attempt), true
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if d, ok := retryAfter(resp); ok {
+			return d, true
+		}
+		// Exhausted primary rate limits are handled by rateLimitTransport.
+		if isRateLimited(resp) {
+			return 0, false
+		}
+		if isSecondaryRateLimit(resp) {
+			return secondaryRateLimitDelay, true
+		}
+	}
+	return 0, false
+}
+
+// backoff returns the exponential backoff for the attempt,
+// with "equal jitter": a random delay between half and all of it.
+func (rt *retryTransport) backoff(attempt int) time.Duration {
+	d := rt.config.BaseDelay << attempt
+	if d <= 0 || d > rt.config.MaxDelay {
+		d = rt.config.MaxDelay
+	}
+	half := d / 2
+	//nolint:gosec // Jitter does not need a secure random number generator.
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryAfter parses the Retry-After header, in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// isSecondaryRateLimit returns whether a 403 or 429 response is GitHub's
+// secondary rate limit or abuse detection, keeping the body readable.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	if resp.Body == nil {
+		return false
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	msg := strings.ToLower(string(body))
+	return strings.Contains(msg, "secondary rate limit") || strings.Contains(msg, "abuse detection")
+}
+
+func describe(resp *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return resp.Status
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		//nolint:wrapcheck
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
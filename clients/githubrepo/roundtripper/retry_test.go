@@ -0,0 +1,231 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+	type response struct {
+		header http.Header
+		body   string
+		status int
+	}
+	tests := []struct {
+		name         string
+		responses    []response
+		wantDelays   []time.Duration
+		wantStatus   int
+		wantBody     string
+		wantRequests int
+	}{
+		{
+			name:         "success is not retried",
+			responses:    []response{{status: http.StatusOK, body: "ok"}},
+			wantStatus:   http.StatusOK,
+			wantBody:     "ok",
+			wantRequests: 1,
+		},
+		{
+			name: "server errors are retried",
+			responses: []response{
+				{status: http.StatusBadGateway},
+				{status: http.StatusServiceUnavailable},
+				{status: http.StatusOK, body: "ok"},
+			},
+			wantStatus:   http.StatusOK,
+			wantBody:     "ok",
+			wantRequests: 3,
+		},
+		{
+			name: "retries are bounded",
+			responses: []response{
+				{status: http.StatusGatewayTimeout},
+				{status: http.StatusGatewayTimeout},
+				{status: http.StatusGatewayTimeout},
+				{status: http.StatusGatewayTimeout},
+				{status: http.StatusOK},
+			},
+			wantStatus:   http.StatusGatewayTimeout,
+			wantRequests: 4,
+		},
+		{
+			name: "retry after is respected",
+			responses: []response{
+				{status: http.StatusTooManyRequests, header: http.Header{"Retry-After": []string{"42"}}},
+				{status: http.StatusOK},
+			},
+			wantDelays:   []time.Duration{42 * time.Second},
+			wantStatus:   http.StatusOK,
+			wantRequests: 2,
+		},
+		{
+			name: "secondary rate limit",
+			responses: []response{
+				{status: http.StatusForbidden, body: `{"message": "You have exceeded a secondary rate limit."}`},
+				{status: http.StatusOK},
+			},
+			wantDelays:   []time.Duration{secondaryRateLimitDelay},
+			wantStatus:   http.StatusOK,
+			wantRequests: 2,
+		},
+		{
+			name: "primary rate limit is left to the rate limiter",
+			responses: []response{
+				{status: http.StatusForbidden, header: http.Header{"X-Ratelimit-Remaining": []string{"0"}}},
+			},
+			wantStatus:   http.StatusForbidden,
+			wantRequests: 1,
+		},
+		{
+			name: "permission errors are not retried",
+			responses: []response{
+				{status: http.StatusForbidden, body: `{"message": "Resource not accessible by integration"}`},
+			},
+			wantStatus:   http.StatusForbidden,
+			wantBody:     `{"message": "Resource not accessible by integration"}`,
+			wantRequests: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var mu sync.Mutex
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				resp := tt.responses[requests]
+				requests++
+				mu.Unlock()
+				for k, v := range resp.header {
+					w.Header()[k] = v
+				}
+				w.WriteHeader(resp.status)
+				io.WriteString(w, resp.body) //nolint:errcheck
+			}))
+			t.Cleanup(server.Close)
+
+			var delays []time.Duration
+			rt := &retryTransport{
+				innerTransport: http.DefaultTransport,
+				logger:         zap.NewNop().Sugar(),
+				config:         RetryConfig{MaxRetries: 3, BaseDelay: time.Second, MaxDelay: 4 * time.Second},
+				sleep: func(ctx context.Context, d time.Duration) error {
+					delays = append(delays, d)
+					return nil
+				},
+			}
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := rt.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip: %v", err)
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+			if len(delays) != tt.wantRequests-1 {
+				t.Errorf("got %d delays, want %d", len(delays), tt.wantRequests-1)
+			}
+			for i, d := range tt.wantDelays {
+				if delays[i] != d {
+					t.Errorf("delay %d = %s, want %s", i, delays[i], d)
+				}
+			}
+			for _, d := range delays {
+				if d > secondaryRateLimitDelay {
+					t.Errorf("delay %s is too long", d)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	t.Parallel()
+	rt := &retryTransport{config: RetryConfig{BaseDelay: time.Second, MaxDelay: 5 * time.Second}}
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		for i := 0; i < 10; i++ {
+			d := rt.backoff(attempt)
+			if d < want/2 || d > want {
+				t.Errorf("backoff(%d) = %s, want between %s and %s", attempt, d, want/2, want)
+			}
+		}
+	}
+}
+
+func TestRetryTransportRewindsBody(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	rt := &retryTransport{
+		innerTransport: http.DefaultTransport,
+		logger:         zap.NewNop().Sugar(),
+		config:         RetryConfig{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+		sleep:          sleepContext,
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL,
+		strings.NewReader(`{"query": "q"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Errorf("request bodies = %q, want the same body twice", bodies)
+	}
+}
@@ -17,6 +17,8 @@ package roundtripper
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -31,37 +33,76 @@ import (
 const (
 	// githubAppKeyPath is the path to file for GitHub App key.
 	githubAppKeyPath = "GITHUB_APP_KEY_PATH"
+	// githubAppKey is the PEM-encoded GitHub App key, for CI systems which
+	// provide secrets as environment variables rather than files.
+	githubAppKey = "GITHUB_APP_KEY"
 	// githubAppID is the app ID for the GitHub App.
 	githubAppID = "GITHUB_APP_ID"
 	// githubAppInstallationID is the installation ID for the GitHub App.
 	githubAppInstallationID = "GITHUB_APP_INSTALLATION_ID"
 )
 
+var errGitHubAppConfig = errors.New("invalid GitHub App configuration")
+
 // NewTransport returns a configured http.Transport for use with GitHub.
-func NewTransport(ctx context.Context, logger *zap.SugaredLogger) http.RoundTripper {
-	transport := http.DefaultTransport
+// A GitHub App creates its installation tokens with the REST API at appAPIURL,
+// that of github.com if empty.
+func NewTransport(ctx context.Context, logger *zap.SugaredLogger, appAPIURL string) http.RoundTripper {
+	transport := BaseTransport()
 
 	// nolint
-	if tokenAccessor := tokens.MakeTokenAccessor(); tokenAccessor != nil {
+	if IsReplayingCassette() {
+		// Replayed responses need no credentials, see WithCassette.
+	} else if tokenAccessor := tokens.MakeTokenAccessor(); tokenAccessor != nil {
 		// Use GitHub PAT
 		transport = makeGitHubTransport(transport, tokenAccessor)
-	} else if keyPath := os.Getenv(githubAppKeyPath); keyPath != "" { // Also try a GITHUB_APP
-		appID, err := strconv.Atoi(os.Getenv(githubAppID))
-		if err != nil {
-			log.Panic(err)
-		}
-		installationID, err := strconv.Atoi(os.Getenv(githubAppInstallationID))
-		if err != nil {
-			log.Panic(err)
-		}
-		transport, err = ghinstallation.NewKeyFromFile(transport, int64(appID), int64(installationID), keyPath)
+	} else if hasGitHubAppKey() { // Also try a GITHUB_APP
+		appTransport, err := makeGitHubAppTransport(transport, appAPIURL)
 		if err != nil {
 			log.Panic(err)
 		}
+		transport = appTransport
+	} else if hasTokenExchange() { // Or exchange the OIDC token of the workload
+		transport = makeTokenExchangeTransport(transport)
 	} else {
 		log.Fatalf("GitHub token env var is not set. " +
 			"Please read https://github.com/ossf/scorecard#authentication")
 	}
 
+	transport = WithCassette(transport)
+	// Retry transient errors below the rate limiter, so retries of a
+	// request exhausting the quota also wait for it to reset.
+	transport = MakeRetryTransport(transport, logger, DefaultRetryConfig())
 	return MakeCensusTransport(MakeRateLimitedTransport(transport, logger))
 }
+
+func hasGitHubAppKey() bool {
+	return os.Getenv(githubAppKeyPath) != "" || os.Getenv(githubAppKey) != ""
+}
+
+// makeGitHubAppTransport authenticates as a GitHub App installation.
+// Installation tokens are short-lived and refreshed automatically before they expire.
+func makeGitHubAppTransport(transport http.RoundTripper, apiURL string) (http.RoundTripper, error) {
+	appID, err := strconv.ParseInt(os.Getenv(githubAppID), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", errGitHubAppConfig, githubAppID, err)
+	}
+	installationID, err := strconv.ParseInt(os.Getenv(githubAppInstallationID), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", errGitHubAppConfig, githubAppInstallationID, err)
+	}
+
+	var appTransport *ghinstallation.Transport
+	if keyPath := os.Getenv(githubAppKeyPath); keyPath != "" {
+		appTransport, err = ghinstallation.NewKeyFromFile(transport, appID, installationID, keyPath)
+	} else {
+		appTransport, err = ghinstallation.New(transport, appID, installationID, []byte(os.Getenv(githubAppKey)))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errGitHubAppConfig, err)
+	}
+	if apiURL != "" {
+		appTransport.BaseURL = apiURL
+	}
+	return appTransport, nil
+}
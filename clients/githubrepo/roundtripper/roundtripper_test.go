@@ -0,0 +1,97 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func generateKey(t *testing.T) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}))
+}
+
+//nolint:paralleltest // t.Setenv is not compatible with t.Parallel.
+func TestMakeGitHubAppTransport(t *testing.T) {
+	key := generateKey(t)
+	tests := []struct {
+		name           string
+		appID          string
+		installationID string
+		key            string
+		wantErr        error
+	}{
+		{
+			name:           "key from env",
+			appID:          "1234",
+			installationID: "5678",
+			key:            key,
+		},
+		{
+			name:           "invalid app ID",
+			appID:          "not-a-number",
+			installationID: "5678",
+			key:            key,
+			wantErr:        errGitHubAppConfig,
+		},
+		{
+			name:           "missing installation ID",
+			appID:          "1234",
+			installationID: "",
+			key:            key,
+			wantErr:        errGitHubAppConfig,
+		},
+		{
+			name:           "invalid key",
+			appID:          "1234",
+			installationID: "5678",
+			key:            "not a key",
+			wantErr:        errGitHubAppConfig,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(githubAppKeyPath, "")
+			t.Setenv(githubAppKey, tt.key)
+			t.Setenv(githubAppID, tt.appID)
+			t.Setenv(githubAppInstallationID, tt.installationID)
+
+			if !hasGitHubAppKey() {
+				t.Fatalf("hasGitHubAppKey() = false, want true")
+			}
+			transport, err := makeGitHubAppTransport(http.DefaultTransport, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("makeGitHubAppTransport: got err %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && transport == nil {
+				t.Errorf("makeGitHubAppTransport returned a nil transport")
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// githubTokenExchangeURL is the URL of a service exchanging the OIDC token
+	// of the workload for a GitHub App installation token.
+	githubTokenExchangeURL = "GITHUB_TOKEN_EXCHANGE_URL"
+	// githubTokenExchangeAudience is the audience of the OIDC token, the exchange URL by default.
+	githubTokenExchangeAudience = "GITHUB_TOKEN_EXCHANGE_AUDIENCE"
+	// actionsIDTokenRequestURL and actionsIDTokenRequestToken are set by the
+	// GitHub Actions runtime when the workflow has the `id-token: write` permission.
+	actionsIDTokenRequestURL   = "ACTIONS_ID_TOKEN_REQUEST_URL"
+	actionsIDTokenRequestToken = "ACTIONS_ID_TOKEN_REQUEST_TOKEN"
+	// gceMetadataHost overrides the host of the GCP metadata server, as in the Google Cloud client libraries.
+	gceMetadataHost        = "GCE_METADATA_HOST"
+	defaultGCEMetadataHost = "metadata.google.internal"
+)
+
+// tokenRefreshMargin is how long before they expire installation tokens are exchanged again.
+const tokenRefreshMargin = 5 * time.Minute
+
+var errTokenExchange = errors.New("token exchange failed")
+
+// idTokenSource returns an OIDC token for audience proving the identity of the workload.
+type idTokenSource func(ctx context.Context, client *http.Client, audience string) (string, error)
+
+func hasTokenExchange() bool {
+	return os.Getenv(githubTokenExchangeURL) != ""
+}
+
+// makeTokenExchangeTransport authenticates with short-lived GitHub App installation
+// tokens, obtained by exchanging the OIDC token of the workflow on GitHub Actions,
+// or of the service account on GCP, so that no long-lived secret is needed.
+func makeTokenExchangeTransport(transport http.RoundTripper) http.RoundTripper {
+	exchangeURL := os.Getenv(githubTokenExchangeURL)
+	audience := os.Getenv(githubTokenExchangeAudience)
+	if audience == "" {
+		audience = exchangeURL
+	}
+	source := gcpIDToken
+	if os.Getenv(actionsIDTokenRequestURL) != "" {
+		source = actionsIDToken
+	}
+	return &tokenExchangeTransport{
+		innerTransport: transport,
+		exchangeURL:    exchangeURL,
+		audience:       audience,
+		idToken:        source,
+		now:            time.Now,
+	}
+}
+
+// tokenExchangeTransport caches the installation token until it is about to expire.
+type tokenExchangeTransport struct {
+	innerTransport http.RoundTripper
+	exchangeURL    string
+	audience       string
+	idToken        idTokenSource
+	now            func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+func (t *tokenExchangeTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	token, err := t.installationToken(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	// RoundTrippers must not modify the request.
+	r = r.Clone(r.Context())
+	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	//nolint:wrapcheck
+	return t.innerTransport.RoundTrip(r)
+}
+
+func (t *tokenExchangeTransport) installationToken(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token != "" && t.now().Add(tokenRefreshMargin).Before(t.expiresAt) {
+		return t.token, nil
+	}
+
+	client := &http.Client{Transport: t.innerTransport}
+	idToken, err := t.idToken(ctx, client, t.audience)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.exchangeURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errTokenExchange, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+idToken)
+	var body struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := getJSON(client, req, &body); err != nil {
+		return "", err
+	}
+	if body.Token == "" {
+		return "", fmt.Errorf("%w: no token in the response of %s", errTokenExchange, t.exchangeURL)
+	}
+	t.token, t.expiresAt = body.Token, body.ExpiresAt
+	return t.token, nil
+}
+
+// actionsIDToken requests the OIDC token of the workflow from the GitHub Actions runtime.
+func actionsIDToken(ctx context.Context, client *http.Client, audience string) (string, error) {
+	u, err := url.Parse(os.Getenv(actionsIDTokenRequestURL))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %v", errTokenExchange, actionsIDTokenRequestURL, err)
+	}
+	q := u.Query()
+	q.Set("audience", audience)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errTokenExchange, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+os.Getenv(actionsIDTokenRequestToken))
+	var body struct {
+		Value string `json:"value"`
+	}
+	if err := getJSON(client, req, &body); err != nil {
+		return "", err
+	}
+	return body.Value, nil
+}
+
+// gcpIDToken requests the OIDC token of the service account of the workload,
+// e.g., through GKE workload identity, from the GCP metadata server.
+func gcpIDToken(ctx context.Context, client *http.Client, audience string) (string, error) {
+	host := os.Getenv(gceMetadataHost)
+	if host == "" {
+		host = defaultGCEMetadataHost
+	}
+	u := url.URL{
+		Scheme:   "http",
+		Host:     host,
+		Path:     "/computeMetadata/v1/instance/service-accounts/default/identity",
+		RawQuery: url.Values{"audience": {audience}, "format": {"full"}}.Encode(),
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errTokenExchange, err)
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errTokenExchange, err)
+	}
+	defer resp.Body.Close()
+	token, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errTokenExchange, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %s: %s", errTokenExchange, u.String(), resp.Status)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+func getJSON(client *http.Client, req *http.Request, v interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errTokenExchange, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%w: %s: %s", errTokenExchange, req.URL.Redacted(), resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", errTokenExchange, err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+//nolint:paralleltest // t.Setenv is not compatible with t.Parallel.
+func TestTokenExchangeTransport(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	exchanges := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oidc":
+			if r.Header.Get("Authorization") != "Bearer request-token" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			if got, want := r.URL.Query().Get("audience"), server.URL+"/exchange"; got != want {
+				t.Errorf("audience = %q, want %q", got, want)
+			}
+			//nolint:errcheck
+			io.WriteString(w, `{"value": "oidc-token"}`)
+		case "/exchange":
+			if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer oidc-token" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			exchanges++
+			//nolint:errcheck
+			fmt.Fprintf(w, `{"token": "installation-token-%d", "expires_at": "2023-01-01T13:00:00Z"}`, exchanges)
+		case "/api":
+			//nolint:errcheck
+			io.WriteString(w, r.Header.Get("Authorization"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv(githubTokenExchangeURL, server.URL+"/exchange")
+	t.Setenv(githubTokenExchangeAudience, "")
+	t.Setenv(actionsIDTokenRequestURL, server.URL+"/oidc")
+	t.Setenv(actionsIDTokenRequestToken, "request-token")
+	if !hasTokenExchange() {
+		t.Fatal("hasTokenExchange() = false, want true")
+	}
+	transport, ok := makeTokenExchangeTransport(http.DefaultTransport).(*tokenExchangeTransport)
+	if !ok {
+		t.Fatal("makeTokenExchangeTransport() is not a *tokenExchangeTransport")
+	}
+	transport.now = func() time.Time { return now }
+	client := &http.Client{Transport: transport}
+
+	get := func() string {
+		t.Helper()
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/api", nil)
+		if err != nil {
+			t.Fatalf("http.NewRequestWithContext: %v", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("client.Do: %v", err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("io.ReadAll: %v", err)
+		}
+		if req.Header.Get("Authorization") != "" {
+			t.Error("the request was modified")
+		}
+		return string(body)
+	}
+
+	if got, want := get(), "Bearer installation-token-1"; got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+	// The token is reused until it is about to expire.
+	now = now.Add(50 * time.Minute)
+	if got, want := get(), "Bearer installation-token-1"; got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+	now = now.Add(6 * time.Minute)
+	if got, want := get(), "Bearer installation-token-2"; got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+
+	t.Setenv(actionsIDTokenRequestToken, "wrong-token")
+	now = now.Add(time.Hour)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/api", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequestWithContext: %v", err)
+	}
+	if resp, err := client.Do(req); err == nil {
+		resp.Body.Close()
+		t.Error("expected error when the OIDC token cannot be requested")
+	}
+}
+
+//nolint:paralleltest // t.Setenv is not compatible with t.Parallel.
+func TestGCPIDToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/computeMetadata/v1/instance/service-accounts/default/identity" ||
+			r.Header.Get("Metadata-Flavor") != "Google" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.URL.Query().Get("audience"); got != "https://exchange.example.com" {
+			t.Errorf("audience = %q, want %q", got, "https://exchange.example.com")
+		}
+		//nolint:errcheck
+		io.WriteString(w, "gcp-token\n")
+	}))
+	defer server.Close()
+
+	t.Setenv(gceMetadataHost, server.Listener.Addr().String())
+	got, err := gcpIDToken(context.Background(), server.Client(), "https://exchange.example.com")
+	if err != nil {
+		t.Fatalf("gcpIDToken: %v", err)
+	}
+	if got != "gcp-token" {
+		t.Errorf("gcpIDToken() = %q, want %q", got, "gcp-token")
+	}
+}
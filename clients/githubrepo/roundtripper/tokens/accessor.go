@@ -18,6 +18,7 @@ package tokens
 import (
 	"os"
 	"strings"
+	"time"
 )
 
 // githubAuthServer is the RPC URL for the token server.
@@ -30,8 +31,21 @@ type TokenAccessor interface {
 	Release(uint64)
 }
 
+// QuotaTracker is implemented by TokenAccessors which track the
+// remaining rate limit quota of each token, and favor tokens with quota left.
+type QuotaTracker interface {
+	// UpdateQuota records the quota reported by GitHub for token id.
+	UpdateQuota(id uint64, remaining int, reset time.Time)
+	// HasQuota returns whether any token has quota left.
+	HasQuota() bool
+}
+
 func readGitHubTokens() (string, bool) {
-	githubAuthTokens := []string{"GITHUB_AUTH_TOKEN", "GITHUB_TOKEN", "GH_TOKEN", "GH_AUTH_TOKEN"}
+	githubAuthTokens := []string{
+		"GITHUB_AUTH_TOKEN", "GITHUB_TOKEN", "GH_TOKEN", "GH_AUTH_TOKEN",
+		// Used by the gh CLI for GitHub Enterprise Server.
+		"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN",
+	}
 	for _, name := range githubAuthTokens {
 		if token, exists := os.LookupEnv(name); exists && token != "" {
 			return token, exists
@@ -19,20 +19,28 @@ import (
 	"time"
 )
 
-const expiryTimeInSec = 30
+const (
+	expiryTimeInSec = 30
+	// Tokens with fewer remaining requests are skipped while other tokens have quota.
+	minRemainingQuota = 50
+	// unknownQuota is used until a response reports the quota of a token.
+	unknownQuota = -1
+)
 
-// roundRobinAccessor implements TokenAccessor.
+// roundRobinAccessor implements TokenAccessor and QuotaTracker.
 type roundRobinAccessor struct {
 	accessTokens []string
 	accessState  []int64
-	counter      uint64
+	// Remaining requests and reset time (unix seconds) of each token.
+	remaining []int64
+	reset     []int64
+	counter   uint64
 }
 
 // Next implements TokenAccessor.Next.
 func (tokens *roundRobinAccessor) Next() (uint64, string) {
 	c := atomic.AddUint64(&tokens.counter, 1)
-	l := len(tokens.accessTokens)
-	index := c % uint64(l)
+	index := tokens.pick(c)
 
 	// If selected accessToken is unavailable, wait.
 	for !atomic.CompareAndSwapInt64(&tokens.accessState[index], 0, time.Now().Unix()) {
@@ -48,14 +56,62 @@ func (tokens *roundRobinAccessor) Next() (uint64, string) {
 	return index, tokens.accessTokens[index]
 }
 
+// pick returns the next token in round robin order which has quota left.
+// If all tokens are nearly exhausted, it returns the one whose quota resets first.
+func (tokens *roundRobinAccessor) pick(c uint64) uint64 {
+	l := uint64(len(tokens.accessTokens))
+	now := time.Now().Unix()
+	earliest := c % l
+	for i := uint64(0); i < l; i++ {
+		index := (c + i) % l
+		if tokens.hasQuota(index, now) {
+			return index
+		}
+		if atomic.LoadInt64(&tokens.reset[index]) < atomic.LoadInt64(&tokens.reset[earliest]) {
+			earliest = index
+		}
+	}
+	return earliest
+}
+
+func (tokens *roundRobinAccessor) hasQuota(index uint64, now int64) bool {
+	remaining := atomic.LoadInt64(&tokens.remaining[index])
+	return remaining == unknownQuota ||
+		remaining >= minRemainingQuota ||
+		atomic.LoadInt64(&tokens.reset[index]) <= now
+}
+
 // Release implements TokenAccessor.Release.
 func (tokens *roundRobinAccessor) Release(id uint64) {
 	atomic.SwapInt64(&tokens.accessState[id], 0)
 }
 
+// UpdateQuota implements QuotaTracker.UpdateQuota.
+func (tokens *roundRobinAccessor) UpdateQuota(id uint64, remaining int, reset time.Time) {
+	atomic.StoreInt64(&tokens.reset[id], reset.Unix())
+	atomic.StoreInt64(&tokens.remaining[id], int64(remaining))
+}
+
+// HasQuota implements QuotaTracker.HasQuota.
+func (tokens *roundRobinAccessor) HasQuota() bool {
+	now := time.Now().Unix()
+	for i := range tokens.accessTokens {
+		if tokens.hasQuota(uint64(i), now) {
+			return true
+		}
+	}
+	return false
+}
+
 func makeRoundRobinAccessor(accessTokens []string) TokenAccessor {
+	remaining := make([]int64, len(accessTokens))
+	for i := range remaining {
+		remaining[i] = unknownQuota
+	}
 	return &roundRobinAccessor{
 		accessTokens: accessTokens,
 		accessState:  make([]int64, len(accessTokens)),
+		remaining:    remaining,
+		reset:        make([]int64, len(accessTokens)),
 	}
 }
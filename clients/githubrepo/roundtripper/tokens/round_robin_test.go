@@ -0,0 +1,84 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tokens
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoundRobinQuota(t *testing.T) {
+	t.Parallel()
+	future := time.Now().Add(time.Hour)
+	tests := []struct {
+		name      string
+		remaining []int
+		reset     []time.Time
+		want      []string
+		hasQuota  bool
+	}{
+		{
+			name:      "unknown quota rotates",
+			remaining: nil,
+			want:      []string{"t1", "t2", "t0", "t1"},
+			hasQuota:  true,
+		},
+		{
+			name:      "exhausted token is skipped",
+			remaining: []int{100, 0, 100},
+			reset:     []time.Time{future, future, future},
+			want:      []string{"t2", "t2", "t0", "t2"},
+			hasQuota:  true,
+		},
+		{
+			name:      "token whose quota was reset is used again",
+			remaining: []int{100, 0, 100},
+			reset:     []time.Time{future, time.Now().Add(-time.Minute), future},
+			want:      []string{"t1", "t2", "t0", "t1"},
+			hasQuota:  true,
+		},
+		{
+			name:      "all exhausted picks earliest reset",
+			remaining: []int{0, 0, 0},
+			reset:     []time.Time{future, future.Add(-time.Minute), future},
+			want:      []string{"t1", "t1"},
+			hasQuota:  false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			accessor := makeRoundRobinAccessor([]string{"t0", "t1", "t2"})
+			tracker, ok := accessor.(QuotaTracker)
+			if !ok {
+				t.Fatalf("round robin accessor does not implement QuotaTracker")
+			}
+			for i, remaining := range tt.remaining {
+				tracker.UpdateQuota(uint64(i), remaining, tt.reset[i])
+			}
+			if got := tracker.HasQuota(); got != tt.hasQuota {
+				t.Errorf("HasQuota() = %v, want %v", got, tt.hasQuota)
+			}
+			for i, want := range tt.want {
+				id, token := accessor.Next()
+				accessor.Release(id)
+				if token != want {
+					t.Errorf("Next() #%d = %s, want %s", i, token, want)
+				}
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
@@ -42,15 +43,32 @@ type githubTransport struct {
 
 func (gt *githubTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 	id, token := gt.tokens.Next()
-	defer gt.tokens.Release(id)
+	resp, err := gt.roundTripWithToken(r, id, token)
+	gt.tokens.Release(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// If this token ran out of quota but another one has some left,
+	// switch tokens now rather than waiting for the quota to reset.
+	tracker, ok := gt.tokens.(tokens.QuotaTracker)
+	if ok && isRateLimited(resp) && tracker.HasQuota() && rewindBody(r) {
+		resp.Body.Close()
+		id, token = gt.tokens.Next()
+		defer gt.tokens.Release(id)
+		return gt.roundTripWithToken(r, id, token)
+	}
+	return resp, nil
+}
 
+func (gt *githubTransport) roundTripWithToken(r *http.Request, id uint64, token string) (*http.Response, error) {
 	ctx, err := tag.New(r.Context(), tag.Upsert(githubstats.TokenIndex, fmt.Sprint(id)))
 	if err != nil {
 		return nil, fmt.Errorf("error updating context: %w", err)
 	}
 	*r = *r.WithContext(ctx)
 
-	r.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+	r.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	resp, err := gt.innerTransport.RoundTrip(r)
 	if err != nil {
 		return nil, fmt.Errorf("error in HTTP: %w", err)
@@ -63,6 +81,37 @@ func (gt *githubTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
 	if err == nil {
 		stats.Record(ctx, githubstats.RemainingTokens.M(int64(remaining)))
+		reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if tracker, ok := gt.tokens.(tokens.QuotaTracker); ok && err == nil {
+			tracker.UpdateQuota(id, remaining, time.Unix(reset, 0))
+		}
 	}
 	return resp, nil
 }
+
+// rewindBody resets the request body so the request can be sent again.
+// It returns false if the body cannot be replayed.
+func rewindBody(r *http.Request) bool {
+	if r.Body == nil || r.Body == http.NoBody {
+		return true
+	}
+	if r.GetBody == nil {
+		return false
+	}
+	body, err := r.GetBody()
+	if err != nil {
+		return false
+	}
+	r.Body = body
+	return true
+}
+
+// isRateLimited returns whether the response was refused for an exhausted quota.
+// The last request of the quota succeeds, even though it reports none left.
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	return err == nil && remaining <= 0
+}
@@ -0,0 +1,148 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package roundtripper
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeAccessor struct {
+	mu        sync.Mutex
+	tokens    []string
+	remaining []int
+	next      int
+}
+
+func (f *fakeAccessor) Next() (uint64, string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.tokens {
+		index := (f.next + i) % len(f.tokens)
+		if f.remaining[index] != 0 {
+			f.next = index + 1
+			return uint64(index), f.tokens[index]
+		}
+	}
+	return 0, f.tokens[0]
+}
+
+func (f *fakeAccessor) Release(uint64) {}
+
+func (f *fakeAccessor) UpdateQuota(id uint64, remaining int, reset time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.remaining[id] = remaining
+}
+
+func (f *fakeAccessor) HasQuota() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, r := range f.remaining {
+		if r != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGitHubTransportSwitchesTokens(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		quota        map[string]int
+		status       int
+		wantStatus   int
+		wantRequests int
+	}{
+		{
+			name:         "switches to token with quota",
+			quota:        map[string]int{"Bearer t0": 0, "Bearer t1": 100},
+			status:       http.StatusForbidden,
+			wantStatus:   http.StatusOK,
+			wantRequests: 2,
+		},
+		{
+			name:         "switches on too many requests",
+			quota:        map[string]int{"Bearer t0": 0, "Bearer t1": 100},
+			status:       http.StatusTooManyRequests,
+			wantStatus:   http.StatusOK,
+			wantRequests: 2,
+		},
+		{
+			name:         "last request of the quota succeeds",
+			quota:        map[string]int{"Bearer t0": 0, "Bearer t1": 100},
+			status:       http.StatusOK,
+			wantStatus:   http.StatusOK,
+			wantRequests: 1,
+		},
+		{
+			name:         "all tokens exhausted",
+			quota:        map[string]int{"Bearer t0": 0, "Bearer t1": 0},
+			status:       http.StatusForbidden,
+			wantStatus:   http.StatusForbidden,
+			wantRequests: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var mu sync.Mutex
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				requests++
+				mu.Unlock()
+				body, err := io.ReadAll(r.Body)
+				if err != nil || string(body) != "query" {
+					t.Errorf("unexpected body %q: %v", body, err)
+				}
+				remaining := tt.quota[r.Header.Get("Authorization")]
+				w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+				if remaining == 0 {
+					w.WriteHeader(tt.status)
+				}
+			}))
+			defer server.Close()
+
+			accessor := &fakeAccessor{tokens: []string{"t0", "t1"}, remaining: []int{-1, -1}}
+			transport := makeGitHubTransport(http.DefaultTransport, accessor)
+			req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("query"))
+			if err != nil {
+				t.Fatalf("http.NewRequest: %v", err)
+			}
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+const (
+	rulesetsToAnalyze     = 100
+	rulesPerRuleset       = 50
+	rulesetDefaultBranch  = "~DEFAULT_BRANCH"
+	rulesetAllBranches    = "~ALL"
+	rulesetActive         = "ACTIVE"
+	rulesetTargetBranch   = "BRANCH"
+	ruleDeletion          = "DELETION"
+	ruleNonFastForward    = "NON_FAST_FORWARD"
+	ruleLinearHistory     = "REQUIRED_LINEAR_HISTORY"
+	ruleSignatures        = "REQUIRED_SIGNATURES"
+	rulePullRequest       = "PULL_REQUEST"
+	ruleStatusChecks      = "REQUIRED_STATUS_CHECKS"
+	ruleMergeQueue        = "MERGE_QUEUE"
+	ruleWorkflows         = "WORKFLOWS"
+	refNamePatternAnyPath = "**"
+)
+
+// Repository rulesets can enforce the same settings as classic branch protection.
+// Organization rulesets targeting the repo are included, and can also require
+// workflows to pass.
+// See https://docs.github.com/en/graphql/reference/objects#repositoryruleset.
+type ruleset struct {
+	Enforcement *string
+	Target      *string
+	Conditions  struct {
+		RefName *struct {
+			Include []string
+			Exclude []string
+		}
+	}
+	Rules struct {
+		Nodes []rule
+	} `graphql:"rules(first: $rulesPerRuleset)"`
+}
+
+type rule struct {
+	Type       *string
+	Parameters struct {
+		PullRequestParameters struct {
+			DismissStaleReviewsOnPush    *bool
+			RequireCodeOwnerReview       *bool
+			RequiredApprovingReviewCount *int32
+		} `graphql:"... on PullRequestParameters"`
+		RequiredStatusChecksParameters struct {
+			StrictRequiredStatusChecksPolicy *bool
+			RequiredStatusChecks             []struct {
+				Context string
+			}
+		} `graphql:"... on RequiredStatusChecksParameters"`
+		WorkflowsParameters struct {
+			Workflows []struct {
+				Path string
+			}
+		} `graphql:"... on WorkflowsParameters"`
+	}
+}
+
+type rulesetsData struct {
+	Repository struct {
+		Rulesets struct {
+			Nodes []ruleset
+		} `graphql:"rulesets(first: $rulesetsToAnalyze, includeParents: true)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// appliesTo returns whether an active ruleset targets the given branch.
+func (r *ruleset) appliesTo(branch string, isDefault bool) bool {
+	if r.Enforcement == nil || *r.Enforcement != rulesetActive ||
+		r.Target == nil || *r.Target != rulesetTargetBranch ||
+		r.Conditions.RefName == nil {
+		return false
+	}
+	for _, pattern := range r.Conditions.RefName.Exclude {
+		if refNameMatches(pattern, branch, isDefault) {
+			return false
+		}
+	}
+	for _, pattern := range r.Conditions.RefName.Include {
+		if refNameMatches(pattern, branch, isDefault) {
+			return true
+		}
+	}
+	return false
+}
+
+func refNameMatches(pattern, branch string, isDefault bool) bool {
+	switch pattern {
+	case rulesetAllBranches:
+		return true
+	case rulesetDefaultBranch:
+		return isDefault
+	}
+	return refNameRegexp(strings.TrimPrefix(pattern, refPrefix)).MatchString(branch)
+}
+
+// refNameRegexp converts an fnmatch pattern, where `*` does not match `/`
+// but `**` does, into a regular expression.
+func refNameRegexp(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], refNamePatternAnyPath):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
+
+// applyRulesets merges the rules of the rulesets targeting a branch into its
+// protection rule, keeping the most restrictive value of each setting.
+func applyRulesets(branchRef *clients.BranchRef, rulesets []ruleset, isDefault bool) {
+	if branchRef == nil || branchRef.Name == nil {
+		return
+	}
+	for i := range rulesets {
+		if !rulesets[i].appliesTo(*branchRef.Name, isDefault) {
+			continue
+		}
+		branchRef.Protected = boolPtr(true)
+		for j := range rulesets[i].Rules.Nodes {
+			applyRule(&rulesets[i].Rules.Nodes[j], &branchRef.BranchProtectionRule)
+		}
+	}
+}
+
+func applyRule(r *rule, dst *clients.BranchProtectionRule) {
+	if r.Type == nil {
+		return
+	}
+	switch *r.Type {
+	case ruleDeletion:
+		dst.AllowDeletions = boolPtr(false)
+	case ruleNonFastForward:
+		dst.AllowForcePushes = boolPtr(false)
+	case ruleLinearHistory:
+		dst.RequireLinearHistory = boolPtr(true)
+	case ruleSignatures:
+		dst.RequiresSignatures = boolPtr(true)
+	case rulePullRequest:
+		params := r.Parameters.PullRequestParameters
+		reviews := &dst.RequiredPullRequestReviews
+		if count := params.RequiredApprovingReviewCount; count != nil &&
+			(reviews.RequiredApprovingReviewCount == nil || *count > *reviews.RequiredApprovingReviewCount) {
+			copyInt32Ptr(count, &reviews.RequiredApprovingReviewCount)
+		}
+		mergeTrue(params.DismissStaleReviewsOnPush, &reviews.DismissStaleReviews)
+		mergeTrue(params.RequireCodeOwnerReview, &reviews.RequireCodeOwnerReviews)
+	case ruleMergeQueue:
+		dst.RequiresMergeQueue = boolPtr(true)
+	case ruleStatusChecks:
+		params := r.Parameters.RequiredStatusChecksParameters
+		dst.CheckRules.RequiresStatusChecks = boolPtr(true)
+		mergeTrue(params.StrictRequiredStatusChecksPolicy, &dst.CheckRules.UpToDateBeforeMerge)
+		for _, check := range params.RequiredStatusChecks {
+			dst.CheckRules.Contexts = append(dst.CheckRules.Contexts, check.Context)
+		}
+	case ruleWorkflows:
+		// Required workflows are only available in organization rulesets.
+		dst.CheckRules.RequiresStatusChecks = boolPtr(true)
+		for _, w := range r.Parameters.WorkflowsParameters.Workflows {
+			dst.CheckRules.RequiredWorkflows = append(dst.CheckRules.RequiredWorkflows, w.Path)
+		}
+	}
+}
+
+// mergeTrue sets dst to src unless dst is already true.
+func mergeTrue(src *bool, dst **bool) {
+	if src == nil || (*dst != nil && **dst) {
+		return
+	}
+	copyBoolPtr(src, dst)
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
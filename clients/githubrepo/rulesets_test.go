@@ -0,0 +1,292 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+func TestRefNameMatches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		pattern   string
+		branch    string
+		isDefault bool
+		want      bool
+	}{
+		{
+			name:    "all branches",
+			pattern: "~ALL",
+			branch:  "feature/foo",
+			want:    true,
+		},
+		{
+			name:      "default branch",
+			pattern:   "~DEFAULT_BRANCH",
+			branch:    "main",
+			isDefault: true,
+			want:      true,
+		},
+		{
+			name:    "not the default branch",
+			pattern: "~DEFAULT_BRANCH",
+			branch:  "release/v1",
+			want:    false,
+		},
+		{
+			name:    "exact ref",
+			pattern: "refs/heads/main",
+			branch:  "main",
+			want:    true,
+		},
+		{
+			name:    "single segment wildcard",
+			pattern: "refs/heads/release/*",
+			branch:  "release/v1",
+			want:    true,
+		},
+		{
+			name:    "wildcard does not cross slashes",
+			pattern: "refs/heads/release/*",
+			branch:  "release/v1/hotfix",
+			want:    false,
+		},
+		{
+			name:    "double wildcard crosses slashes",
+			pattern: "refs/heads/release/**",
+			branch:  "release/v1/hotfix",
+			want:    true,
+		},
+		{
+			name:    "dots are literal",
+			pattern: "refs/heads/v1.0",
+			branch:  "v1x0",
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := refNameMatches(tt.pattern, tt.branch, tt.isDefault); got != tt.want {
+				t.Errorf("refNameMatches(%q, %q) = %v, want %v", tt.pattern, tt.branch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyRulesets(t *testing.T) {
+	t.Parallel()
+	active := rulesetActive
+	evaluate := "EVALUATE"
+	target := rulesetTargetBranch
+	main := "main"
+	trueVal := true
+	falseVal := false
+	var oneVal int32 = 1
+	var twoVal int32 = 2
+
+	newRuleset := func(enforcement *string, include []string, rules ...rule) ruleset {
+		r := ruleset{
+			Enforcement: enforcement,
+			Target:      &target,
+		}
+		r.Conditions.RefName = &struct {
+			Include []string
+			Exclude []string
+		}{Include: include}
+		r.Rules.Nodes = rules
+		return r
+	}
+	newRule := func(ruleType string) rule {
+		return rule{Type: &ruleType}
+	}
+	pullRequest := newRule(rulePullRequest)
+	pullRequest.Parameters.PullRequestParameters.RequiredApprovingReviewCount = &twoVal
+	pullRequest.Parameters.PullRequestParameters.DismissStaleReviewsOnPush = &trueVal
+	statusChecks := newRule(ruleStatusChecks)
+	statusChecks.Parameters.RequiredStatusChecksParameters.StrictRequiredStatusChecksPolicy = &trueVal
+	statusChecks.Parameters.RequiredStatusChecksParameters.RequiredStatusChecks = []struct {
+		Context string
+	}{{Context: "build"}}
+	workflows := newRule(ruleWorkflows)
+	workflows.Parameters.WorkflowsParameters.Workflows = []struct {
+		Path string
+	}{{Path: ".github/workflows/org-scan.yml"}}
+
+	tests := []struct {
+		name     string
+		branch   clients.BranchRef
+		rulesets []ruleset
+		want     clients.BranchRef
+	}{
+		{
+			name: "ruleset protects an unprotected branch",
+			branch: clients.BranchRef{
+				Name:      &main,
+				Protected: &falseVal,
+			},
+			rulesets: []ruleset{
+				newRuleset(&active, []string{rulesetDefaultBranch},
+					newRule(ruleDeletion), newRule(ruleNonFastForward), newRule(ruleLinearHistory),
+					newRule(ruleSignatures), newRule(ruleMergeQueue), pullRequest, statusChecks),
+			},
+			want: clients.BranchRef{
+				Name:      &main,
+				Protected: &trueVal,
+				BranchProtectionRule: clients.BranchProtectionRule{
+					AllowDeletions:       &falseVal,
+					AllowForcePushes:     &falseVal,
+					RequireLinearHistory: &trueVal,
+					RequiresSignatures:   &trueVal,
+					RequiresMergeQueue:   &trueVal,
+					RequiredPullRequestReviews: clients.PullRequestReviewRule{
+						RequiredApprovingReviewCount: &twoVal,
+						DismissStaleReviews:          &trueVal,
+					},
+					CheckRules: clients.StatusChecksRule{
+						RequiresStatusChecks: &trueVal,
+						UpToDateBeforeMerge:  &trueVal,
+						Contexts:             []string{"build"},
+					},
+				},
+			},
+		},
+		{
+			name: "ruleset does not weaken classic protection",
+			branch: clients.BranchRef{
+				Name:      &main,
+				Protected: &trueVal,
+				BranchProtectionRule: clients.BranchProtectionRule{
+					RequiredPullRequestReviews: clients.PullRequestReviewRule{
+						RequiredApprovingReviewCount: &twoVal,
+						DismissStaleReviews:          &trueVal,
+					},
+				},
+			},
+			rulesets: func() []ruleset {
+				weak := newRule(rulePullRequest)
+				weak.Parameters.PullRequestParameters.RequiredApprovingReviewCount = &oneVal
+				weak.Parameters.PullRequestParameters.DismissStaleReviewsOnPush = &falseVal
+				return []ruleset{newRuleset(&active, []string{"refs/heads/main"}, weak)}
+			}(),
+			want: clients.BranchRef{
+				Name:      &main,
+				Protected: &trueVal,
+				BranchProtectionRule: clients.BranchProtectionRule{
+					RequiredPullRequestReviews: clients.PullRequestReviewRule{
+						RequiredApprovingReviewCount: &twoVal,
+						DismissStaleReviews:          &trueVal,
+					},
+				},
+			},
+		},
+		{
+			name: "organization ruleset requires workflows",
+			branch: clients.BranchRef{
+				Name:      &main,
+				Protected: &trueVal,
+				BranchProtectionRule: clients.BranchProtectionRule{
+					CheckRules: clients.StatusChecksRule{
+						Contexts: []string{"test"},
+					},
+				},
+			},
+			rulesets: []ruleset{
+				newRuleset(&active, []string{rulesetAllBranches}, workflows, statusChecks),
+			},
+			want: clients.BranchRef{
+				Name:      &main,
+				Protected: &trueVal,
+				BranchProtectionRule: clients.BranchProtectionRule{
+					CheckRules: clients.StatusChecksRule{
+						RequiresStatusChecks: &trueVal,
+						UpToDateBeforeMerge:  &trueVal,
+						Contexts:             []string{"test", "build"},
+						RequiredWorkflows:    []string{".github/workflows/org-scan.yml"},
+					},
+				},
+			},
+		},
+		{
+			name: "evaluate mode rulesets are ignored",
+			branch: clients.BranchRef{
+				Name:      &main,
+				Protected: &falseVal,
+			},
+			rulesets: []ruleset{
+				newRuleset(&evaluate, []string{rulesetAllBranches}, newRule(ruleDeletion)),
+			},
+			want: clients.BranchRef{
+				Name:      &main,
+				Protected: &falseVal,
+			},
+		},
+		{
+			name: "ruleset targeting other branches",
+			branch: clients.BranchRef{
+				Name:      &main,
+				Protected: &falseVal,
+			},
+			rulesets: []ruleset{
+				newRuleset(&active, []string{"refs/heads/release/*"}, newRule(ruleDeletion)),
+			},
+			want: clients.BranchRef{
+				Name:      &main,
+				Protected: &falseVal,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			applyRulesets(&tt.branch, tt.rulesets, true)
+			if diff := cmp.Diff(tt.want, tt.branch); diff != "" {
+				t.Errorf("applyRulesets() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyRulesetsExclude(t *testing.T) {
+	t.Parallel()
+	active := rulesetActive
+	target := rulesetTargetBranch
+	deletion := ruleDeletion
+	branchName := "release/legacy"
+	falseVal := false
+
+	r := ruleset{Enforcement: &active, Target: &target}
+	r.Conditions.RefName = &struct {
+		Include []string
+		Exclude []string
+	}{
+		Include: []string{"refs/heads/release/*"},
+		Exclude: []string{"refs/heads/release/legacy"},
+	}
+	r.Rules.Nodes = []rule{{Type: &deletion}}
+
+	branch := clients.BranchRef{Name: &branchName, Protected: &falseVal}
+	applyRulesets(&branch, []ruleset{r}, false)
+	if *branch.Protected || branch.BranchProtectionRule.AllowDeletions != nil {
+		t.Errorf("excluded branch should not be protected by the ruleset")
+	}
+}
@@ -29,24 +29,23 @@ var errEmptyQuery = errors.New("search query is empty")
 
 type searchHandler struct {
 	ghClient *github.Client
-	ctx      context.Context
 	owner    string
 	repo     string
 }
 
-func (handler *searchHandler) init(ctx context.Context, owner, repo string) {
-	handler.ctx = ctx
+func (handler *searchHandler) init(owner, repo string) {
 	handler.owner = owner
 	handler.repo = repo
 }
 
-func (handler *searchHandler) search(request clients.SearchRequest) (clients.SearchResponse, error) {
+func (handler *searchHandler) search(ctx context.Context,
+	request clients.SearchRequest) (clients.SearchResponse, error) {
 	query, err := handler.buildQuery(request)
 	if err != nil {
 		return clients.SearchResponse{}, fmt.Errorf("handler.buildQuery: %w", err)
 	}
 
-	resp, _, err := handler.ghClient.Search.Code(handler.ctx, query, &github.SearchOptions{})
+	resp, _, err := handler.ghClient.Search.Code(ctx, query, &github.SearchOptions{})
 	if err != nil {
 		return clients.SearchResponse{}, fmt.Errorf("Search.Code: %w", err)
 	}
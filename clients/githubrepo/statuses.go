@@ -26,19 +26,17 @@ import (
 
 type statusesHandler struct {
 	client *github.Client
-	ctx    context.Context
 	owner  string
 	repo   string
 }
 
-func (handler *statusesHandler) init(ctx context.Context, owner, repo string) {
-	handler.ctx = ctx
+func (handler *statusesHandler) init(owner, repo string) {
 	handler.owner = owner
 	handler.repo = repo
 }
 
-func (handler *statusesHandler) listStatuses(ref string) ([]clients.Status, error) {
-	statuses, _, err := handler.client.Repositories.ListStatuses(handler.ctx, handler.owner, handler.repo, ref,
+func (handler *statusesHandler) listStatuses(ctx context.Context, ref string) ([]clients.Status, error) {
+	statuses, _, err := handler.client.Repositories.ListStatuses(ctx, handler.owner, handler.repo, ref,
 		&github.ListOptions{})
 	if err != nil {
 		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListStatuses: %v", err))
@@ -50,6 +48,7 @@ func statusesFrom(data []*github.RepoStatus) []clients.Status {
 	var statuses []clients.Status
 	for _, status := range data {
 		statuses = append(statuses, clients.Status{
+			UpdatedAt: status.GetUpdatedAt(),
 			State:     status.GetState(),
 			Context:   status.GetContext(),
 			URL:       status.GetURL(),
@@ -0,0 +1,164 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shurcooL/githubv4"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const (
+	tagsToAnalyze   = 30
+	tagRefPrefix    = "refs/tags/"
+	commitPullsRead = 5
+)
+
+// associatedPullRequests are the PRs a commit belongs to.
+type associatedPullRequests struct {
+	Nodes []struct {
+		BaseRefName string
+		Merged      bool
+	}
+}
+
+// baseBranches returns the base branches of the merged PRs, which are
+// the branches the commit was merged onto.
+func (prs *associatedPullRequests) baseBranches() []string {
+	var ret []string
+	seen := make(map[string]bool)
+	for _, pr := range prs.Nodes {
+		if !pr.Merged || pr.BaseRefName == "" || seen[pr.BaseRefName] {
+			continue
+		}
+		seen[pr.BaseRefName] = true
+		ret = append(ret, pr.BaseRefName)
+	}
+	return ret
+}
+
+// Lightweight tags point to a commit directly, so only annotated
+// tags have a Tag target.
+type tagsData struct {
+	Repository struct {
+		Refs struct {
+			Nodes []struct {
+				Name   string
+				Target struct {
+					Tag struct {
+						Target struct {
+							Commit struct {
+								Oid                    string
+								AssociatedPullRequests associatedPullRequests `graphql:"associatedPullRequests(first: $commitPullsRead)"`
+							} `graphql:"... on Commit"`
+						}
+					} `graphql:"... on Tag"`
+				}
+			}
+		} `graphql:"refs(first: $tagsToAnalyze, refPrefix: $tagRefPrefix, orderBy: $tagOrder)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+type commitBranchesData struct {
+	Repository struct {
+		Object struct {
+			Commit struct {
+				AssociatedPullRequests associatedPullRequests `graphql:"associatedPullRequests(first: $commitPullsRead)"`
+			} `graphql:"... on Commit"`
+		} `graphql:"object(oid: $oid)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+type tagsHandler struct {
+	graphClient *githubv4.Client
+	once        *forge.Once
+	owner       string
+	repo        string
+	tags        []clients.Tag
+}
+
+func (handler *tagsHandler) init(owner, repo string) {
+	handler.owner = owner
+	handler.repo = repo
+	handler.once = new(forge.Once)
+}
+
+func (handler *tagsHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		vars := map[string]interface{}{
+			"owner":           githubv4.String(handler.owner),
+			"name":            githubv4.String(handler.repo),
+			"tagsToAnalyze":   githubv4.Int(tagsToAnalyze),
+			"tagRefPrefix":    githubv4.String(tagRefPrefix),
+			"commitPullsRead": githubv4.Int(commitPullsRead),
+			"tagOrder": githubv4.RefOrder{
+				Field:     githubv4.RefOrderFieldTagCommitDate,
+				Direction: githubv4.OrderDirectionDesc,
+			},
+		}
+		data := new(tagsData)
+		if err := handler.graphClient.Query(ctx, data, vars); err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+		}
+		handler.tags = tagsFrom(data)
+		return nil
+	})
+}
+
+func (handler *tagsHandler) listTags(ctx context.Context) ([]clients.Tag, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during tagsHandler.setup: %w", err)
+	}
+	return handler.tags, nil
+}
+
+// tagsFrom converts the annotated tags. The branches of a tagged commit are
+// the base branches of the merged PRs it belongs to.
+func tagsFrom(data *tagsData) []clients.Tag {
+	var tags []clients.Tag
+	for _, ref := range data.Repository.Refs.Nodes {
+		commit := ref.Target.Tag.Target.Commit
+		if commit.Oid == "" {
+			continue
+		}
+		tags = append(tags, clients.Tag{
+			Name:     ref.Name,
+			SHA:      commit.Oid,
+			Branches: commit.AssociatedPullRequests.baseBranches(),
+		})
+	}
+	return tags
+}
+
+// listBranchesForCommit returns the branches a commit was merged onto,
+// e.g., to resolve the release branch of a release targeting a commit.
+func (handler *tagsHandler) listBranchesForCommit(ctx context.Context, sha string) ([]string, error) {
+	vars := map[string]interface{}{
+		"owner":           githubv4.String(handler.owner),
+		"name":            githubv4.String(handler.repo),
+		"oid":             githubv4.GitObjectID(sha),
+		"commitPullsRead": githubv4.Int(commitPullsRead),
+	}
+	data := new(commitBranchesData)
+	if err := handler.graphClient.Query(ctx, data, vars); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("githubv4.Query: %v", err))
+	}
+	return data.Repository.Object.Commit.AssociatedPullRequests.baseBranches(), nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githubrepo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shurcooL/githubv4"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+func TestListTags(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("io.ReadAll: %v", err)
+		}
+		if !strings.Contains(string(body), `"tagRefPrefix":"refs/tags/"`) {
+			t.Errorf("unexpected query: %s", body)
+		}
+		//nolint:errcheck
+		w.Write([]byte(`{"data": {"repository": {"refs": {"nodes": [
+  {"name": "v1.1.0", "target": {"target": {"oid": "sha2", "associatedPullRequests": {"nodes": [
+    {"baseRefName": "release-1.1", "merged": true},
+    {"baseRefName": "main", "merged": true},
+    {"baseRefName": "release-1.1", "merged": true},
+    {"baseRefName": "feature", "merged": false}
+  ]}}}},
+  {"name": "v1.0.0", "target": {"target": {"oid": "sha1", "associatedPullRequests": {"nodes": []}}}},
+  {"name": "nightly", "target": {}}
+]}}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	handler := &tagsHandler{
+		graphClient: githubv4.NewEnterpriseClient(server.URL, server.Client()),
+	}
+	handler.init("owner", "repo")
+	tags, err := handler.listTags(context.Background())
+	if err != nil {
+		t.Fatalf("listTags: %v", err)
+	}
+	want := []clients.Tag{
+		{Name: "v1.1.0", SHA: "sha2", Branches: []string{"release-1.1", "main"}},
+		{Name: "v1.0.0", SHA: "sha1"},
+	}
+	if diff := cmp.Diff(want, tags); diff != "" {
+		t.Errorf("listTags() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestListBranchesForCommit(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("io.ReadAll: %v", err)
+		}
+		if !strings.Contains(string(body), `"oid":"sha1"`) {
+			t.Errorf("unexpected query: %s", body)
+		}
+		//nolint:errcheck
+		w.Write([]byte(`{"data": {"repository": {"object": {"associatedPullRequests": {"nodes": [
+  {"baseRefName": "main", "merged": true},
+  {"baseRefName": "release-1.2", "merged": true},
+  {"baseRefName": "release-1.1", "merged": false}
+]}}}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	handler := &tagsHandler{
+		graphClient: githubv4.NewEnterpriseClient(server.URL, server.Client()),
+	}
+	handler.init("owner", "repo")
+	branches, err := handler.listBranchesForCommit(context.Background(), "sha1")
+	if err != nil {
+		t.Fatalf("listBranchesForCommit: %v", err)
+	}
+	if diff := cmp.Diff([]string{"main", "release-1.2"}, branches); diff != "" {
+		t.Errorf("listBranchesForCommit() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -26,9 +26,12 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/google/go-github/v38/github"
 
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
@@ -62,48 +65,82 @@ func extractAndValidateArchivePath(path, dest string) (string, error) {
 	return cleanpath, nil
 }
 
+// tarballHandler downloads and extracts the repo tarball the first time
+// a file is accessed, so that all checks share a single download.
 type tarballHandler struct {
+	// httpClient downloads the tarball, http.DefaultClient if nil.
+	httpClient *http.Client
+	// mu guards the files against their cleanup, which may happen while a
+	// check abandoned at its deadline still reads them.
+	mu          sync.RWMutex
+	once        *forge.Once
+	repo        *github.Repository
+	commitSHA   string
 	tempDir     string
 	tempTarFile string
 	files       []string
 }
 
-func (handler *tarballHandler) init(ctx context.Context, repo *github.Repository) error {
+func (handler *tarballHandler) init(repo *github.Repository, commitSHA string) error {
 	// Cleanup any previous state.
 	if err := handler.cleanup(); err != nil {
 		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
 	}
 
-	// Setup temp dir/files and download repo tarball.
-	if err := handler.getTarball(ctx, repo); errors.Is(err, errTarballNotFound) {
-		log.Printf("unable to get tarball %v. Skipping...", err)
-		return nil
-	} else if err != nil {
-		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
-	}
+	handler.repo = repo
+	handler.commitSHA = commitSHA
+	handler.once = new(forge.Once)
+	return nil
+}
 
-	// Extract file names and content from tarball.
-	if err := handler.extractTarball(); errors.Is(err, errTarballCorrupted) {
-		log.Printf("unable to extract tarball %v. Skipping...", err)
-		return nil
-	} else if err != nil {
-		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
-	}
+func (handler *tarballHandler) setup(ctx context.Context) error {
+	return handler.once.Do(ctx, func() error {
+		handler.mu.Lock()
+		defer handler.mu.Unlock()
+		// Remove what a download interrupted by the context of a previous
+		// call left behind.
+		if err := handler.removeFiles(); err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		}
+		// Setup temp dir/files and download repo tarball.
+		if err := handler.getTarball(ctx, handler.repo, handler.commitSHA); ctx.Err() != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, ctx.Err().Error())
+		} else if errors.Is(err, errTarballNotFound) {
+			log.Printf("unable to get tarball %v. Skipping...", err)
+			return nil
+		} else if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		}
 
-	return nil
+		// Extract file names and content from tarball.
+		if err := handler.extractTarball(); errors.Is(err, errTarballCorrupted) {
+			log.Printf("unable to extract tarball %v. Skipping...", err)
+		} else if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+		}
+		return nil
+	})
 }
 
-func (handler *tarballHandler) getTarball(ctx context.Context, repo *github.Repository) error {
+func (handler *tarballHandler) getTarball(ctx context.Context, repo *github.Repository, commitSHA string) error {
 	url := repo.GetArchiveURL()
 	url = strings.Replace(url, "{archive_format}", "tarball/", 1)
-	url = strings.Replace(url, "{/ref}", "", 1)
+	if commitSHA == clients.HeadSHA {
+		url = strings.Replace(url, "{/ref}", "", 1)
+	} else {
+		url = strings.Replace(url, "{/ref}", commitSHA, 1)
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return fmt.Errorf("http.NewRequestWithContext: %w", err)
 	}
-	resp, err := http.DefaultClient.Do(req)
+	httpClient := handler.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("http.DefaultClient.Do: %w", err)
+		return fmt.Errorf("httpClient.Do: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -118,6 +155,7 @@ func (handler *tarballHandler) getTarball(ctx context.Context, repo *github.Repo
 	if err != nil {
 		return fmt.Errorf("os.MkdirTemp: %w", err)
 	}
+	handler.tempDir = tempDir
 	repoFile, err := os.CreateTemp(tempDir, repoFilename)
 	if err != nil {
 		return fmt.Errorf("os.CreateTemp: %w", err)
@@ -128,7 +166,6 @@ func (handler *tarballHandler) getTarball(ctx context.Context, repo *github.Repo
 		return fmt.Errorf("%w io.Copy: %v", errTarballNotFound, err)
 	}
 
-	handler.tempDir = tempDir
 	handler.tempTarFile = repoFile.Name()
 	return nil
 }
@@ -204,7 +241,12 @@ func (handler *tarballHandler) extractTarball() error {
 	return nil
 }
 
-func (handler *tarballHandler) listFiles(predicate func(string) (bool, error)) ([]string, error) {
+func (handler *tarballHandler) listFiles(ctx context.Context, predicate func(string) (bool, error)) ([]string, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during tarballHandler.setup: %w", err)
+	}
+	handler.mu.RLock()
+	defer handler.mu.RUnlock()
 	ret := make([]string, 0)
 	for _, file := range handler.files {
 		matches, err := predicate(file)
@@ -218,7 +260,12 @@ func (handler *tarballHandler) listFiles(predicate func(string) (bool, error)) (
 	return ret, nil
 }
 
-func (handler *tarballHandler) getFileContent(filename string) ([]byte, error) {
+func (handler *tarballHandler) getFileContent(ctx context.Context, filename string) ([]byte, error) {
+	if err := handler.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during tarballHandler.setup: %w", err)
+	}
+	handler.mu.RLock()
+	defer handler.mu.RUnlock()
 	content, err := os.ReadFile(filepath.Join(handler.tempDir, filename))
 	if err != nil {
 		return content, fmt.Errorf("os.ReadFile: %w", err)
@@ -227,6 +274,12 @@ func (handler *tarballHandler) getFileContent(filename string) ([]byte, error) {
 }
 
 func (handler *tarballHandler) cleanup() error {
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	return handler.removeFiles()
+}
+
+func (handler *tarballHandler) removeFiles() error {
 	if err := os.RemoveAll(handler.tempDir); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("os.Remove: %w", err)
 	}
@@ -15,15 +15,23 @@
 package githubrepo
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
 )
 
 type listfileTest struct {
@@ -42,28 +50,37 @@ func isSortedString(x, y string) bool {
 	return x < y
 }
 
-func setup(inputFile string) (tarballHandler, error) {
+func setup(inputFile string) (*tarballHandler, error) {
 	tempDir, err := os.MkdirTemp("", repoDir)
 	if err != nil {
-		return tarballHandler{}, fmt.Errorf("test failed to create TempDir: %w", err)
+		return nil, fmt.Errorf("test failed to create TempDir: %w", err)
 	}
 	tempFile, err := os.CreateTemp(tempDir, repoFilename)
 	if err != nil {
-		return tarballHandler{}, fmt.Errorf("test failed to create TempFile: %w", err)
+		return nil, fmt.Errorf("test failed to create TempFile: %w", err)
 	}
 	testFile, err := os.OpenFile(inputFile, os.O_RDONLY, 0o644)
 	if err != nil {
-		return tarballHandler{}, fmt.Errorf("unable to open testfile: %w", err)
+		return nil, fmt.Errorf("unable to open testfile: %w", err)
 	}
 	if _, err := io.Copy(tempFile, testFile); err != nil {
-		return tarballHandler{}, fmt.Errorf("unable to do io.Copy: %w", err)
+		return nil, fmt.Errorf("unable to do io.Copy: %w", err)
 	}
-	return tarballHandler{
+	// The tarball is already downloaded, so mark the setup as done.
+	return &tarballHandler{
+		once:        doneOnce(),
 		tempDir:     tempDir,
 		tempTarFile: tempFile.Name(),
 	}, nil
 }
 
+// doneOnce returns a forge.Once whose fetch completed.
+func doneOnce() *forge.Once {
+	once := new(forge.Once)
+	once.Do(context.Background(), func() error { return nil })
+	return once
+}
+
 // nolint: gocognit
 func TestExtractTarball(t *testing.T) {
 	t.Parallel()
@@ -132,7 +149,7 @@ func TestExtractTarball(t *testing.T) {
 
 			// Test ListFiles API.
 			for _, listfiletest := range testcase.listfileTests {
-				matchedFiles, err := handler.listFiles(listfiletest.predicate)
+				matchedFiles, err := handler.listFiles(context.Background(), listfiletest.predicate)
 				if !errors.Is(err, listfiletest.err) {
 					t.Errorf("test failed: expected - %v, got - %v", listfiletest.err, err)
 					continue
@@ -146,7 +163,7 @@ func TestExtractTarball(t *testing.T) {
 
 			// Test GetFileContent API.
 			for _, getcontenttest := range testcase.getcontentTests {
-				content, err := handler.getFileContent(getcontenttest.filename)
+				content, err := handler.getFileContent(context.Background(), getcontenttest.filename)
 				if getcontenttest.err != nil && !errors.Is(err, getcontenttest.err) {
 					t.Errorf("test failed: expected - %v, got - %v", getcontenttest.err, err)
 				}
@@ -168,3 +185,66 @@ func TestExtractTarball(t *testing.T) {
 		})
 	}
 }
+
+func TestTarballDownloadedOnce(t *testing.T) {
+	t.Parallel()
+	tarball, err := os.ReadFile("testdata/basic.tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		downloads++
+		mu.Unlock()
+		if r.URL.Path != "/repos/owner/repo/tarball/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(tarball) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+
+	repo := &github.Repository{
+		ArchiveURL: github.String(server.URL + "/repos/owner/repo/{archive_format}{/ref}"),
+	}
+	var handler tarballHandler
+	if err := handler.init(repo, clients.HeadSHA); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := handler.cleanup(); err != nil {
+			t.Error(err)
+		}
+	})
+	if downloads != 0 {
+		t.Errorf("tarball downloaded %d times before use, want 0", downloads)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			files, err := handler.listFiles(context.Background(), func(string) (bool, error) { return true, nil })
+			if err != nil {
+				t.Errorf("listFiles: %v", err)
+			}
+			if len(files) != 3 {
+				t.Errorf("listFiles returned %q, want 3 files", files)
+			}
+		}()
+	}
+	wg.Wait()
+	content, err := handler.getFileContent(context.Background(), "file0")
+	if err != nil {
+		t.Fatalf("getFileContent: %v", err)
+	}
+	if string(content) != "content0\n" {
+		t.Errorf("getFileContent = %q, want %q", content, "content0\n")
+	}
+	if downloads != 1 {
+		t.Errorf("tarball downloaded %d times, want 1", downloads)
+	}
+}
@@ -26,20 +26,19 @@ import (
 
 type workflowsHandler struct {
 	client *github.Client
-	ctx    context.Context
 	owner  string
 	repo   string
 }
 
-func (handler *workflowsHandler) init(ctx context.Context, owner, repo string) {
-	handler.ctx = ctx
+func (handler *workflowsHandler) init(owner, repo string) {
 	handler.owner = owner
 	handler.repo = repo
 }
 
-func (handler *workflowsHandler) listSuccessfulWorkflowRuns(filename string) ([]clients.WorkflowRun, error) {
+func (handler *workflowsHandler) listSuccessfulWorkflowRuns(ctx context.Context,
+	filename string) ([]clients.WorkflowRun, error) {
 	workflowRuns, _, err := handler.client.Actions.ListWorkflowRunsByFileName(
-		handler.ctx, handler.owner, handler.repo, filename, &github.ListWorkflowRunsOptions{
+		ctx, handler.owner, handler.repo, filename, &github.ListWorkflowRunsOptions{
 			Status: "success",
 		})
 	if err != nil {
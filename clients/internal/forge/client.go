@@ -0,0 +1,135 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package forge holds the parts shared by the clients of forges whose REST
+// APIs serve repo data one request at a time, e.g., Gitea, Bitbucket and
+// Azure DevOps. Parsing the responses of each forge is left to its client.
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/clients/githubrepo/roundtripper"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// ErrUnexpectedStatus is wrapped by errors for responses with a non-2xx status code.
+var ErrUnexpectedStatus = errors.New("unexpected status code")
+
+// StatusError is returned for responses with a non-2xx status code.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%v: GET %s: %d", ErrUnexpectedStatus, e.URL, e.StatusCode)
+}
+
+func (e *StatusError) Unwrap() error {
+	return ErrUnexpectedStatus
+}
+
+// HasStatus returns whether err is a response with one of the codes.
+func HasStatus(err error, codes ...int) bool {
+	var e *StatusError
+	if !errors.As(err, &e) {
+		return false
+	}
+	for _, code := range codes {
+		if e.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// RepoError wraps an error getting the repo or the ref to score as
+// sce.ErrRepoNotFound for a 404, which retrying does not fix, and as
+// sce.ErrRepoUnreachable otherwise, e.g. for 5xx and network errors.
+func RepoError(err error, msg string) error {
+	if HasStatus(err, http.StatusNotFound) {
+		return sce.Wrap(sce.ErrRepoNotFound, err, msg)
+	}
+	return sce.Wrap(sce.ErrRepoUnreachable, err, msg)
+}
+
+// NewHTTPClient returns the HTTP client the forge clients send their requests
+// with: through the configured proxy and the cassette, if any, retrying
+// transient errors and recording OpenCensus stats.
+func NewHTTPClient(logger *zap.Logger) *http.Client {
+	return &http.Client{
+		Transport: roundtripper.MakeCensusTransport(roundtripper.MakeRetryTransport(
+			roundtripper.WithCassette(roundtripper.BaseTransport()), logger.Sugar(), roundtripper.DefaultRetryConfig())),
+	}
+}
+
+// Client sends GET requests to the REST API of a forge.
+type Client struct {
+	httpClient *http.Client
+	authorize  func(*http.Request)
+}
+
+// NewClient returns a Client sending requests with httpClient.
+// authorize adds the credentials of the forge to each request, if any.
+func NewClient(httpClient *http.Client, authorize func(*http.Request)) *Client {
+	return &Client{
+		httpClient: httpClient,
+		authorize:  authorize,
+	}
+}
+
+// Get sends a GET request for u and returns the response body and headers.
+func (c *Client) Get(ctx context.Context, u string) ([]byte, http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	if c.authorize != nil {
+		c.authorize(req)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("http.Do: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, nil, &StatusError{URL: u, StatusCode: resp.StatusCode}
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("io.ReadAll: %w", err)
+	}
+	return body, resp.Header, nil
+}
+
+// GetJSON decodes the JSON response for u into v.
+func (c *Client) GetJSON(ctx context.Context, u string, v interface{}) error {
+	body, _, err := c.Get(ctx, u)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+// Commits lists the latest commits and merged PRs of a repo once.
+type Commits struct {
+	once    Once
+	list    func(ctx context.Context) ([]clients.Commit, []clients.PullRequest, error)
+	commits []clients.Commit
+	prs     []clients.PullRequest
+}
+
+// NewCommits returns Commits listed by list, which makes its API calls with ctx.
+func NewCommits(list func(ctx context.Context) ([]clients.Commit, []clients.PullRequest, error)) *Commits {
+	return &Commits{list: list}
+}
+
+func (c *Commits) setup(ctx context.Context) error {
+	return c.once.Do(ctx, func() error {
+		var err error
+		c.commits, c.prs, err = c.list(ctx)
+		return err
+	})
+}
+
+// ListCommits returns the latest commits.
+func (c *Commits) ListCommits(ctx context.Context) ([]clients.Commit, error) {
+	if err := c.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during commitsHandler.setup: %w", err)
+	}
+	return c.commits, nil
+}
+
+// ListMergedPRs returns the latest merged PRs.
+func (c *Commits) ListMergedPRs(ctx context.Context) ([]clients.PullRequest, error) {
+	if err := c.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during commitsHandler.setup: %w", err)
+	}
+	return c.prs, nil
+}
+
+// MergedBy returns the PR of prs whose merge commit is sha, if any.
+// The SHAs of merge commits may be abbreviated.
+func MergedBy(sha string, prs []clients.PullRequest) *clients.PullRequest {
+	for i := range prs {
+		if prs[i].MergeCommit.SHA != "" && strings.HasPrefix(sha, prs[i].MergeCommit.SHA) {
+			return &prs[i]
+		}
+	}
+	return nil
+}
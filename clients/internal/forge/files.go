@@ -0,0 +1,90 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// Files lists the files of a repo at a commit once and reads their content.
+// Unlike for GitHub repos, files are fetched one by one rather than as a tarball.
+type Files struct {
+	once  Once
+	list  func(ctx context.Context) ([]string, error)
+	read  func(ctx context.Context, filename string) ([]byte, error)
+	files []string
+}
+
+// NewFiles returns Files listed by list and read by read, which make their
+// API calls with ctx and return the errors of the forge API as is.
+func NewFiles(list func(ctx context.Context) ([]string, error),
+	read func(ctx context.Context, filename string) ([]byte, error)) *Files {
+	return &Files{list: list, read: read}
+}
+
+func (f *Files) setup(ctx context.Context) error {
+	return f.once.Do(ctx, func() error {
+		var err error
+		f.files, err = f.list(ctx)
+		return err
+	})
+}
+
+// List returns the files for which predicate is true.
+func (f *Files) List(ctx context.Context, predicate func(string) (bool, error)) ([]string, error) {
+	if err := f.setup(ctx); err != nil {
+		return nil, fmt.Errorf("error during contentsHandler.setup: %w", err)
+	}
+	ret := make([]string, 0)
+	for _, file := range f.files {
+		matches, err := predicate(file)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			ret = append(ret, file)
+		}
+	}
+	return ret, nil
+}
+
+// Read returns the content of filename.
+func (f *Files) Read(ctx context.Context, filename string) ([]byte, error) {
+	content, err := f.read(ctx, filename)
+	// Report missing files like the other clients.
+	if HasStatus(err, http.StatusNotFound) {
+		return nil, fmt.Errorf("%s: %w", filename, os.ErrNotExist)
+	}
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("get file content: %v", err))
+	}
+	return content, nil
+}
+
+// EscapePath escapes each segment of the slash-separated path p.
+func EscapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return strings.Join(segments, "/")
+}
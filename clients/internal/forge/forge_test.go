@@ -0,0 +1,268 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge/forgetest"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+func TestClient_GetJSON(t *testing.T) {
+	t.Parallel()
+	responses := map[string]string{"/repo token": `{"name": "repo"}`}
+	server := forgetest.NewServer(t, responses, func(w http.ResponseWriter, r *http.Request) string {
+		return r.URL.Path + " " + r.Header.Get("Authorization")
+	})
+	tests := []struct {
+		name      string
+		authorize func(*http.Request)
+		want      string
+		status    int
+	}{
+		{
+			name: "authorized",
+			authorize: func(req *http.Request) {
+				req.Header.Set("Authorization", "token")
+			},
+			want: "repo",
+		},
+		{
+			name:   "unauthorized",
+			status: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var repo struct {
+				Name string `json:"name"`
+			}
+			err := NewClient(server.Client(), tt.authorize).GetJSON(context.Background(), server.URL+"/repo", &repo)
+			if tt.status != 0 {
+				if !HasStatus(err, http.StatusForbidden, tt.status) || !errors.Is(err, ErrUnexpectedStatus) {
+					t.Errorf("GetJSON: got %v, want status %d", err, tt.status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetJSON: %v", err)
+			}
+			if repo.Name != tt.want {
+				t.Errorf("got %q, want %q", repo.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestFiles(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	errRead := errors.New("read failed")
+	listed := 0
+	files := NewFiles(
+		func(context.Context) ([]string, error) {
+			listed++
+			return []string{"README.md", "src/main.go", "src/main_test.go"}, nil
+		},
+		func(_ context.Context, filename string) ([]byte, error) {
+			switch filename {
+			case "README.md":
+				return []byte("# repo"), nil
+			case "broken":
+				return nil, errRead
+			default:
+				return nil, &StatusError{URL: filename, StatusCode: http.StatusNotFound}
+			}
+		})
+
+	for i := 0; i < 2; i++ {
+		got, err := files.List(ctx, func(f string) (bool, error) {
+			return f != "README.md", nil
+		})
+		if err != nil {
+			t.Fatalf("List: %v", err)
+		}
+		if diff := cmp.Diff([]string{"src/main.go", "src/main_test.go"}, got); diff != "" {
+			t.Errorf("List mismatch (-want +got):\n%s", diff)
+		}
+	}
+	if listed != 1 {
+		t.Errorf("files listed %d times, want 1", listed)
+	}
+
+	if content, err := files.Read(ctx, "README.md"); err != nil || string(content) != "# repo" {
+		t.Errorf("Read: got %q, %v", content, err)
+	}
+	if _, err := files.Read(ctx, "missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Read: got %v, want %v", err, os.ErrNotExist)
+	}
+	if _, err := files.Read(ctx, "broken"); !errors.Is(err, sce.ErrScorecardInternal) {
+		t.Errorf("Read: got %v, want %v", err, sce.ErrScorecardInternal)
+	}
+}
+
+func TestFiles_ListError(t *testing.T) {
+	t.Parallel()
+	errList := sce.WithMessage(sce.ErrScorecardInternal, "list failed")
+	files := NewFiles(
+		func(context.Context) ([]string, error) { return nil, errList },
+		func(context.Context, string) ([]byte, error) { return nil, nil })
+	_, err := files.List(context.Background(), func(string) (bool, error) { return true, nil })
+	if !errors.Is(err, sce.ErrScorecardInternal) {
+		t.Errorf("List: got %v, want %v", err, sce.ErrScorecardInternal)
+	}
+}
+
+func TestFiles_ListCancelled(t *testing.T) {
+	t.Parallel()
+	files := NewFiles(
+		func(ctx context.Context) ([]string, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return []string{"README.md"}, nil
+		},
+		func(context.Context, string) ([]byte, error) { return nil, nil })
+	all := func(string) (bool, error) { return true, nil }
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := files.List(cancelled, all); !errors.Is(err, context.Canceled) {
+		t.Errorf("List: got %v, want %v", err, context.Canceled)
+	}
+	// The listing interrupted by the first caller is run again for the next.
+	got, err := files.List(context.Background(), all)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if diff := cmp.Diff([]string{"README.md"}, got); diff != "" {
+		t.Errorf("List mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestOnce(t *testing.T) {
+	t.Parallel()
+	var once Once
+	calls := 0
+	fetch := func(ctx context.Context) func() error {
+		return func() error {
+			calls++
+			return ctx.Err()
+		}
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := once.Do(cancelled, fetch(cancelled)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Do: got %v, want %v", err, context.Canceled)
+	}
+	// The next caller retries the fetch its predecessor's ctx interrupted.
+	ctx := context.Background()
+	if err := once.Do(ctx, fetch(ctx)); err != nil {
+		t.Errorf("Do: %v", err)
+	}
+	if err := once.Do(cancelled, fetch(cancelled)); err != nil {
+		t.Errorf("Do after completed fetch: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("got %d fetches, want 2", calls)
+	}
+
+	// An error from a fetch whose ctx is live is kept for later callers.
+	var failed Once
+	errFetch := sce.WithMessage(sce.ErrScorecardInternal, "fetch failed")
+	for i := 0; i < 2; i++ {
+		err := failed.Do(ctx, func() error {
+			calls++
+			return errFetch
+		})
+		if !errors.Is(err, sce.ErrScorecardInternal) {
+			t.Errorf("Do: got %v, want %v", err, sce.ErrScorecardInternal)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("got %d fetches, want 3", calls)
+	}
+}
+
+func TestRepoError(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{name: "not found", err: &StatusError{URL: "repo", StatusCode: http.StatusNotFound}, want: sce.ErrRepoNotFound},
+		{name: "server error", err: &StatusError{URL: "repo", StatusCode: http.StatusBadGateway}, want: sce.ErrRepoUnreachable},
+		{name: "network error", err: errors.New("connection reset"), want: sce.ErrRepoUnreachable},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := RepoError(tt.err, "get repo"); !errors.Is(err, tt.want) {
+				t.Errorf("RepoError: got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergedBy(t *testing.T) {
+	t.Parallel()
+	prs := []clients.PullRequest{
+		{Number: 1},
+		{Number: 2, MergeCommit: clients.Commit{SHA: "abc123"}},
+		{Number: 3, MergeCommit: clients.Commit{SHA: "def"}},
+	}
+	tests := []struct {
+		name string
+		sha  string
+		want int
+	}{
+		{name: "full SHA", sha: "abc123", want: 2},
+		{name: "abbreviated SHA", sha: "def456", want: 3},
+		{name: "direct push", sha: "012345"},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := MergedBy(tt.sha, prs)
+			switch {
+			case tt.want == 0 && got != nil:
+				t.Errorf("got PR %d, want none", got.Number)
+			case tt.want != 0 && (got == nil || got.Number != tt.want):
+				t.Errorf("got %v, want PR %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapePath(t *testing.T) {
+	t.Parallel()
+	if got, want := EscapePath("docs/a b/c#d.md"), "docs/a%20b/c%23d.md"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
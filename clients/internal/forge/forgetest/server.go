@@ -0,0 +1,43 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package forgetest serves canned responses of forge APIs for tests.
+package forgetest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// NewServer returns a server answering requests with responses, keyed by
+// what route returns for the request. route may also set response headers.
+// Requests for other keys get a 404. "{{server}}" in responses is replaced
+// with the URL of the server.
+func NewServer(t *testing.T, responses map[string]string,
+	route func(w http.ResponseWriter, r *http.Request) string) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[route(w, r)]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(strings.ReplaceAll(body, "{{server}}", server.URL))) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
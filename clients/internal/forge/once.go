@@ -0,0 +1,51 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package forge
+
+import (
+	"context"
+	"sync"
+)
+
+// Once is a sync.Once for the data a RepoClient fetches once and shares with
+// its views, see clients.CallContextClient. The data is fetched with the
+// context of the first caller, so a fetch which fails because that context is
+// done, e.g. because the check calling first timed out, is run again by the
+// next caller instead of failing the other checks.
+type Once struct {
+	mu   sync.Mutex
+	done bool
+	err  error
+}
+
+// Do calls fetch unless a previous call completed, and returns the error of
+// the fetch which completed, or of this call's fetch if its ctx is done.
+// Concurrent calls wait for the fetch in progress. The fetched data may be
+// rewritten by the next caller until a fetch completes, so callers must only
+// read it if Do returns nil.
+func (o *Once) Do(ctx context.Context, fetch func() error) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.done {
+		return o.err
+	}
+	err := fetch()
+	if err != nil && ctx.Err() != nil {
+		return err
+	}
+	o.done = true
+	o.err = err
+	return err
+}
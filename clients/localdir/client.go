@@ -25,37 +25,85 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
-	"sync"
 
 	"go.uber.org/zap"
 
 	clients "github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/internal/forge"
 )
 
 var errInputRepoType = errors.New("input repo should be of type repoLocal")
 
 //nolint:govet
 type localDirClient struct {
-	logger   *zap.Logger
-	ctx      context.Context
-	path     string
-	once     sync.Once
-	errFiles error
-	files    []string
+	logger *zap.Logger
+	ctx    context.Context
+	path   string
+	// files is shared with the views returned by WithCallContext.
+	files *fileList
+	// git is nil if the directory is not a git repository.
+	git *gitRepo
+	// origin is the client a view returned by WithCallContext was made from.
+	origin *localDirClient
+}
+
+// fileList is the list of the files of the directory, made once.
+type fileList struct {
+	once  forge.Once
+	files []string
 }
 
 // InitRepo sets up the local repo.
-func (client *localDirClient) InitRepo(inputRepo clients.Repo) error {
+func (client *localDirClient) InitRepo(inputRepo clients.Repo, commitSHA string) error {
+	if client.origin != nil {
+		return client.origin.InitRepo(inputRepo, commitSHA)
+	}
 	localRepo, ok := inputRepo.(*repoLocal)
 	if !ok {
 		return fmt.Errorf("%w: %v", errInputRepoType, inputRepo)
 	}
+	// The directory is analyzed as is, so there is no other commit to check out.
+	if commitSHA != clients.HeadSHA {
+		return fmt.Errorf("InitRepo at commit %s: %w", commitSHA, clients.ErrUnsupportedFeature)
+	}
 
 	client.path = strings.TrimPrefix(localRepo.URI(), "file://")
 
+	repo, err := openGitRepo(client.path)
+	if err != nil {
+		return err
+	}
+	client.git = repo
+
 	return nil
 }
 
+// WithCallContext implements clients.CallContextClient: the files of the
+// directory are no longer listed once ctx is done.
+func (client *localDirClient) WithCallContext(ctx context.Context) clients.RepoClient {
+	view := *client
+	view.ctx = ctx
+	view.origin = client
+	if client.origin != nil {
+		view.origin = client.origin
+	}
+	return &view
+}
+
+// SupportsFeature implements clients.FeatureReporter.
+// A local directory only has file contents, and the commits and
+// default branch of its git repo, if any.
+func (client *localDirClient) SupportsFeature(f clients.Feature) bool {
+	switch f {
+	case clients.FeatureFileContents:
+		return true
+	case clients.FeatureCommits, clients.FeatureBranches:
+		return client.git != nil
+	default:
+		return false
+	}
+}
+
 // URI implements RepoClient.URI.
 func (client *localDirClient) URI() string {
 	return fmt.Sprintf("file://%s", client.path)
@@ -81,12 +129,15 @@ func trimPrefix(pathfn, clientPath string) string {
 	return strings.TrimPrefix(cleanPath, prefix)
 }
 
-func listFiles(clientPath string) ([]string, error) {
+func listFiles(ctx context.Context, clientPath string) ([]string, error) {
 	files := []string{}
 	err := filepath.Walk(clientPath, func(pathfn string, info fs.FileInfo, err error) error {
 		if err != nil {
 			return fmt.Errorf("failure accessing path %q: %w", pathfn, err)
 		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
 		// Skip directories.
 		d, err := isDir(pathfn)
@@ -133,10 +184,15 @@ func applyPredicate(clientFiles []string,
 
 // ListFiles implements RepoClient.ListFiles.
 func (client *localDirClient) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
-	client.once.Do(func() {
-		client.files, client.errFiles = listFiles(client.path)
+	err := client.files.once.Do(client.ctx, func() error {
+		var err error
+		client.files.files, err = listFiles(client.ctx, client.path)
+		return err
 	})
-	return applyPredicate(client.files, client.errFiles, predicate)
+	if err != nil {
+		return nil, err
+	}
+	return applyPredicate(client.files.files, nil, predicate)
 }
 
 func getFileContent(clientpath, filename string) ([]byte, error) {
@@ -165,13 +221,37 @@ func (client *localDirClient) ListBranches() ([]*clients.BranchRef, error) {
 }
 
 // GetDefaultBranch implements RepoClient.GetDefaultBranch.
+// Only the name of the branch is known.
 func (client *localDirClient) GetDefaultBranch() (*clients.BranchRef, error) {
-	return nil, fmt.Errorf("GetDefaultBranch: %w", clients.ErrUnsupportedFeature)
+	if client.git == nil {
+		return nil, fmt.Errorf("GetDefaultBranch: %w", clients.ErrUnsupportedFeature)
+	}
+	name, err := client.git.defaultBranch()
+	if err != nil {
+		return nil, err
+	}
+	return &clients.BranchRef{Name: &name}, nil
+}
+
+// GetBranch implements RepoClient.GetBranch.
+func (client *localDirClient) GetBranch(name string) (*clients.BranchRef, error) {
+	return nil, fmt.Errorf("GetBranch: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListBranchUpdates implements RepoClient.ListBranchUpdates.
+func (client *localDirClient) ListBranchUpdates(branch string) ([]clients.BranchUpdate, error) {
+	if client.git == nil {
+		return nil, fmt.Errorf("ListBranchUpdates: %w", clients.ErrUnsupportedFeature)
+	}
+	return client.git.listBranchUpdates(branch)
 }
 
 // ListCommits implements RepoClient.ListCommits.
 func (client *localDirClient) ListCommits() ([]clients.Commit, error) {
-	return nil, fmt.Errorf("ListCommits: %w", clients.ErrUnsupportedFeature)
+	if client.git == nil {
+		return nil, fmt.Errorf("ListCommits: %w", clients.ErrUnsupportedFeature)
+	}
+	return client.git.listCommits()
 }
 
 // ListIssues implements RepoClient.ListIssues.
@@ -184,6 +264,16 @@ func (client *localDirClient) ListReleases() ([]clients.Release, error) {
 	return nil, fmt.Errorf("ListReleases: %w", clients.ErrUnsupportedFeature)
 }
 
+// ListTags implements RepoClient.ListTags.
+func (client *localDirClient) ListTags() ([]clients.Tag, error) {
+	return nil, fmt.Errorf("ListTags: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListBranchesForCommit implements RepoClient.ListBranchesForCommit.
+func (client *localDirClient) ListBranchesForCommit(sha string) ([]string, error) {
+	return nil, fmt.Errorf("ListBranchesForCommit: %w", clients.ErrUnsupportedFeature)
+}
+
 // ListContributors implements RepoClient.ListContributors.
 func (client *localDirClient) ListContributors() ([]clients.Contributor, error) {
 	return nil, fmt.Errorf("ListContributors: %w", clients.ErrUnsupportedFeature)
@@ -204,6 +294,21 @@ func (client *localDirClient) ListStatuses(ref string) ([]clients.Status, error)
 	return nil, fmt.Errorf("ListStatuses: %w", clients.ErrUnsupportedFeature)
 }
 
+// ListAttestations implements RepoClient.ListAttestations.
+func (client *localDirClient) ListAttestations(subjectDigest string) ([]clients.Attestation, error) {
+	return nil, fmt.Errorf("ListAttestations: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListContainerImages implements RepoClient.ListContainerImages.
+func (client *localDirClient) ListContainerImages() ([]clients.ContainerImage, error) {
+	return nil, fmt.Errorf("ListContainerImages: %w", clients.ErrUnsupportedFeature)
+}
+
+// ListSecurityAdvisories implements RepoClient.ListSecurityAdvisories.
+func (client *localDirClient) ListSecurityAdvisories() ([]clients.SecurityAdvisory, error) {
+	return nil, fmt.Errorf("ListSecurityAdvisories: %w", clients.ErrUnsupportedFeature)
+}
+
 // Search implements RepoClient.Search.
 func (client *localDirClient) Search(request clients.SearchRequest) (clients.SearchResponse, error) {
 	return clients.SearchResponse{}, fmt.Errorf("Search: %w", clients.ErrUnsupportedFeature)
@@ -218,5 +323,6 @@ func CreateLocalDirClient(ctx context.Context, logger *zap.Logger) clients.RepoC
 	return &localDirClient{
 		ctx:    ctx,
 		logger: logger,
+		files:  &fileList{},
 	}
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/ossf/scorecard/v3/clients"
 	"github.com/ossf/scorecard/v3/clients/githubrepo"
 )
 
@@ -81,7 +82,7 @@ func TestClient_CreationAndCaching(t *testing.T) {
 			}
 
 			client := CreateLocalDirClient(ctx, logger)
-			if err := client.InitRepo(repo); err != nil {
+			if err := client.InitRepo(repo, clients.HeadSHA); err != nil {
 				t.Errorf("InitRepo: %v", err)
 			}
 
@@ -179,7 +180,7 @@ func TestClient_GetFileListAndContent(t *testing.T) {
 
 			// Test ListFiles API.
 			for _, listfiletest := range testcase.listfileTests {
-				files, e := listFiles(testcase.inputFolder)
+				files, e := listFiles(context.Background(), testcase.inputFolder)
 				matchedFiles, err := applyPredicate(files, e, listfiletest.predicate)
 				if !errors.Is(err, listfiletest.err) {
 					t.Errorf("test failed: expected - %v, got - %v", listfiletest.err, err)
@@ -205,3 +206,78 @@ func TestClient_GetFileListAndContent(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_InitRepoAtCommit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		commitSHA string
+		err       error
+	}{
+		{
+			name:      "head",
+			commitSHA: clients.HeadSHA,
+			err:       nil,
+		},
+		{
+			name:      "specific commit",
+			commitSHA: "68bc59901773ab4c051dfcea0cc4201a1567ab32",
+			err:       clients.ErrUnsupportedFeature,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, err := githubrepo.NewLogger(zapcore.DebugLevel)
+			if err != nil {
+				t.Errorf("githubrepo.NewLogger: %v", err)
+			}
+			// nolint
+			defer logger.Sync() // Flushes buffer, if any.
+
+			repo, err := MakeLocalDirRepo("file://testdata/repo0")
+			if err != nil {
+				t.Fatalf("MakeLocalDirRepo: %v", err)
+			}
+
+			client := CreateLocalDirClient(context.Background(), logger)
+			if err := client.InitRepo(repo, tt.commitSHA); !errors.Is(err, tt.err) {
+				t.Errorf("InitRepo: %v, expected %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestClient_WithCallContext(t *testing.T) {
+	t.Parallel()
+	logger, err := githubrepo.NewLogger(zapcore.DebugLevel)
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	client := CreateLocalDirClient(context.Background(), logger)
+	repo, err := MakeLocalDirRepo("file://testdata/repo0")
+	if err != nil {
+		t.Fatalf("MakeLocalDirRepo: %v", err)
+	}
+	// Views initialize the client they were made from.
+	if err := clients.WithCallContext(context.Background(), client).InitRepo(repo, clients.HeadSHA); err != nil {
+		t.Fatalf("InitRepo: %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	all := func(string) (bool, error) { return true, nil }
+	if _, err := clients.WithCallContext(cancelled, client).ListFiles(all); !errors.Is(err, context.Canceled) {
+		t.Errorf("ListFiles: got %v, want %v", err, context.Canceled)
+	}
+	// The listing interrupted in the view is made again by the client.
+	files, err := client.ListFiles(all)
+	if err != nil {
+		t.Fatalf("ListFiles: %v", err)
+	}
+	if len(files) == 0 {
+		t.Errorf("ListFiles: got no files")
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdir
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	clients "github.com/ossf/scorecard/v3/clients"
+)
+
+// Number of commits of the default branch returned by ListCommits, as for GitHub repos.
+const commitsToAnalyze = 30
+
+var (
+	// HEAD is detached after actions/checkout of a pull request, so only the
+	// checks which need the name of the default branch cannot run.
+	errDetachedHead = fmt.Errorf("%w: HEAD is not a branch", clients.ErrUnsupportedFeature)
+
+	// Titles of merge commits created by GitHub, Gitea and Bitbucket,
+	// and of squash merges created by GitHub.
+	pullRequestRegex = regexp.MustCompile(`(?i)pull request #(\d+)|\(#(\d+)\)`)
+	// Trailer of merge commits created by GitLab.
+	mergeRequestRegex = regexp.MustCompile(`(?m)^See merge request \S+!(\d+)$`)
+)
+
+// gitRepo gives access to the history of a local git repository.
+type gitRepo struct {
+	repo *git.Repository
+	// dir is the git directory, which holds the reflogs.
+	dir  string
+	bare bool
+}
+
+// openGitRepo opens the git repository at path.
+// It returns nil if path is not a git repository.
+func openGitRepo(path string) (*gitRepo, error) {
+	repo, err := git.PlainOpen(path)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("git.PlainOpen: %w", err)
+	}
+	cfg, err := repo.Config()
+	if err != nil {
+		return nil, fmt.Errorf("repo.Config: %w", err)
+	}
+	ret := &gitRepo{repo: repo, dir: filepath.Join(path, git.GitDirName), bare: cfg.Core.IsBare}
+	if ret.bare {
+		ret.dir = path
+	}
+	return ret, nil
+}
+
+// remotes returns the names of the remotes, starting with origin.
+func (r *gitRepo) remotes() ([]string, error) {
+	remotes, err := r.repo.Remotes()
+	if err != nil {
+		return nil, fmt.Errorf("repo.Remotes: %w", err)
+	}
+	ret := []string{}
+	for _, remote := range remotes {
+		name := remote.Config().Name
+		if name == git.DefaultRemoteName {
+			ret = append([]string{name}, ret...)
+			continue
+		}
+		ret = append(ret, name)
+	}
+	return ret, nil
+}
+
+// defaultBranch returns the default branch of the remote if known, or the checked out branch.
+func (r *gitRepo) defaultBranch() (string, error) {
+	remotes, err := r.remotes()
+	if err != nil {
+		return "", err
+	}
+	for _, remote := range remotes {
+		ref, err := r.repo.Reference(plumbing.NewRemoteHEADReferenceName(remote), false)
+		if err != nil || ref.Type() != plumbing.SymbolicReference {
+			continue
+		}
+		return strings.TrimPrefix(ref.Target().String(), fmt.Sprintf("refs/remotes/%s/", remote)), nil
+	}
+
+	head, err := r.repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return "", fmt.Errorf("repo.Reference: %w", err)
+	}
+	if head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		return "", errDetachedHead
+	}
+	return head.Target().Short(), nil
+}
+
+// branchRefs returns the names of the refs tracking the branch on the forge:
+// the remote-tracking refs, or the branch itself for bare repositories, which are
+// typically mirrors.
+func (r *gitRepo) branchRefs(branch string) ([]plumbing.ReferenceName, error) {
+	if r.bare {
+		return []plumbing.ReferenceName{plumbing.NewBranchReferenceName(branch)}, nil
+	}
+	remotes, err := r.remotes()
+	if err != nil {
+		return nil, err
+	}
+	ret := []plumbing.ReferenceName{}
+	for _, remote := range remotes {
+		ret = append(ret, plumbing.NewRemoteReferenceName(remote, branch))
+	}
+	return ret, nil
+}
+
+// head returns the latest commit of the default branch or, if HEAD is detached
+// and the default branch of the remotes is unknown, the checked out commit.
+func (r *gitRepo) head() (plumbing.Hash, error) {
+	branch, err := r.defaultBranch()
+	if errors.Is(err, errDetachedHead) {
+		head, err := r.repo.Head()
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("repo.Head: %w", err)
+		}
+		return head.Hash(), nil
+	}
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	refs, err := r.branchRefs(branch)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	// Local commits which were not pushed yet are only used as a fallback.
+	refs = append(refs, plumbing.NewBranchReferenceName(branch))
+
+	var head *plumbing.Reference
+	for _, name := range refs {
+		if head, err = r.repo.Reference(name, true); err == nil {
+			break
+		}
+	}
+	if head == nil {
+		return plumbing.ZeroHash, fmt.Errorf("repo.Reference: %w", err)
+	}
+	return head.Hash(), nil
+}
+
+// listCommits returns the latest commits of the first-parent history of the default branch.
+func (r *gitRepo) listCommits() ([]clients.Commit, error) {
+	hash, err := r.head()
+	if err != nil {
+		return nil, err
+	}
+	commit, err := r.repo.CommitObject(hash)
+	if err != nil {
+		return nil, fmt.Errorf("repo.CommitObject: %w", err)
+	}
+
+	ret := []clients.Commit{}
+	for len(ret) < commitsToAnalyze {
+		ret = append(ret, commitFrom(commit))
+		if commit.NumParents() == 0 {
+			break
+		}
+		commit, err = commit.Parent(0)
+		// The history of shallow clones is truncated.
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("commit.Parent: %w", err)
+		}
+	}
+	return ret, nil
+}
+
+func commitFrom(commit *object.Commit) clients.Commit {
+	ret := clients.Commit{
+		CommittedDate: commit.Committer.When,
+		Message:       commit.Message,
+		SHA:           commit.Hash.String(),
+	}
+	for _, parent := range commit.ParentHashes {
+		ret.ParentSHAs = append(ret.ParentSHAs, parent.String())
+	}
+	if number := pullRequestNumber(commit.Message); number > 0 {
+		ret.AssociatedMergeRequest = &clients.PullRequest{
+			Number:      number,
+			MergedAt:    commit.Committer.When,
+			MergeCommit: clients.Commit{SHA: ret.SHA},
+		}
+	}
+	return ret
+}
+
+// pullRequestNumber returns the number of the PR which introduced a commit,
+// as recorded by the forge in the commit message, or 0 if there is none.
+func pullRequestNumber(message string) int {
+	title := strings.SplitN(message, "\n", 2)[0]
+	match := pullRequestRegex.FindStringSubmatch(title)
+	if match == nil {
+		match = mergeRequestRegex.FindStringSubmatch(message)
+	}
+	if match == nil {
+		return 0
+	}
+	for _, m := range match[1:] {
+		if number, err := strconv.Atoi(m); err == nil {
+			return number
+		}
+	}
+	return 0
+}
+
+// listBranchUpdates returns the updates of the branch found in the reflogs of
+// the refs tracking it, most recent first.
+func (r *gitRepo) listBranchUpdates(branch string) ([]clients.BranchUpdate, error) {
+	refs, err := r.branchRefs(branch)
+	if err != nil {
+		return nil, err
+	}
+	ret := []clients.BranchUpdate{}
+	for _, ref := range refs {
+		updates, err := r.readRefLog(ref)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, updates...)
+	}
+	return ret, nil
+}
+
+// readRefLog parses the reflog of a ref. Each line has the format
+// `<old sha> <new sha> <name> <<email>> <timestamp> <timezone>\t<message>`.
+func (r *gitRepo) readRefLog(ref plumbing.ReferenceName) ([]clients.BranchUpdate, error) {
+	f, err := os.Open(filepath.Join(r.dir, "logs", filepath.FromSlash(ref.String())))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+
+	ret := []clients.BranchUpdate{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.SplitN(scanner.Text(), "\t", 2)
+		fields := strings.Fields(line[0])
+		if len(fields) < 4 {
+			continue
+		}
+		update := clients.BranchUpdate{
+			OldSHA: fields[0],
+			NewSHA: fields[1],
+		}
+		if len(line) > 1 {
+			update.Message = line[1]
+		}
+		if ts, err := strconv.ParseInt(fields[len(fields)-2], 10, 64); err == nil {
+			update.UpdatedAt = time.Unix(ts, 0)
+		}
+		update.Forced = r.isForced(&update)
+		// Most recent first.
+		ret = append([]clients.BranchUpdate{update}, ret...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanner.Scan: %w", err)
+	}
+	return ret, nil
+}
+
+// isForced returns whether an update rewrote history. git fetch records forced
+// updates of remote-tracking refs, other updates are checked against the history
+// when both commits are still available.
+func (r *gitRepo) isForced(update *clients.BranchUpdate) bool {
+	if strings.Contains(update.Message, "forced-update") {
+		return true
+	}
+	if plumbing.NewHash(update.OldSHA).IsZero() {
+		return false
+	}
+	old, err := r.repo.CommitObject(plumbing.NewHash(update.OldSHA))
+	if err != nil {
+		return false
+	}
+	updated, err := r.repo.CommitObject(plumbing.NewHash(update.NewSHA))
+	if err != nil {
+		return false
+	}
+	isAncestor, err := old.IsAncestor(updated)
+	return err == nil && !isAncestor
+}
@@ -0,0 +1,247 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localdir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+// newGitRepo creates a git repository in a temporary directory with the given
+// commits on branch main, which is also tracked as origin/main.
+func newGitRepo(t *testing.T, messages []string) (string, []plumbing.Hash) {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("git.PlainInit: %v", err)
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{
+		Name: git.DefaultRemoteName,
+		URLs: []string{"https://example.com/owner/repo"},
+	}); err != nil {
+		t.Fatalf("repo.CreateRemote: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("repo.Worktree: %v", err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, "refs/heads/main")); err != nil {
+		t.Fatalf("SetReference: %v", err)
+	}
+
+	hashes := []plumbing.Hash{}
+	for i, message := range messages {
+		if err := os.WriteFile(filepath.Join(dir, "file"), []byte(fmt.Sprint(i)), 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+		if _, err := wt.Add("file"); err != nil {
+			t.Fatalf("wt.Add: %v", err)
+		}
+		hash, err := wt.Commit(message, &git.CommitOptions{
+			Author: &object.Signature{Name: "dev", Email: "dev@example.com", When: time.Unix(int64(i), 0)},
+		})
+		if err != nil {
+			t.Fatalf("wt.Commit: %v", err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	head := hashes[len(hashes)-1]
+	for _, ref := range []*plumbing.Reference{
+		plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "main"), head),
+		plumbing.NewSymbolicReference(plumbing.NewRemoteHEADReferenceName("origin"), "refs/remotes/origin/main"),
+	} {
+		if err := repo.Storer.SetReference(ref); err != nil {
+			t.Fatalf("SetReference: %v", err)
+		}
+	}
+	return dir, hashes
+}
+
+func initClient(t *testing.T, dir string) clients.RepoClient {
+	t.Helper()
+	repo, err := MakeLocalDirRepo("file://" + dir)
+	if err != nil {
+		t.Fatalf("MakeLocalDirRepo: %v", err)
+	}
+	client := CreateLocalDirClient(context.Background(), zap.NewNop())
+	if err := client.InitRepo(repo, clients.HeadSHA); err != nil {
+		t.Fatalf("InitRepo: %v", err)
+	}
+	return client
+}
+
+func TestClient_GitHistory(t *testing.T) {
+	t.Parallel()
+	dir, hashes := newGitRepo(t, []string{
+		"Initial commit",
+		"Merge pull request #12 from owner/branch\n\nAdd feature",
+		"Fix typo",
+		"Add other feature (#13)",
+		"Merge branch 'fix' into 'main'\n\nSee merge request group/project!14",
+	})
+	client := initClient(t, dir)
+	if !client.(clients.FeatureReporter).SupportsFeature(clients.FeatureCommits) {
+		t.Errorf("SupportsFeature(%s): got false, expected true", clients.FeatureCommits)
+	}
+
+	branch, err := client.GetDefaultBranch()
+	if err != nil {
+		t.Fatalf("GetDefaultBranch: %v", err)
+	}
+	if *branch.Name != "main" {
+		t.Errorf("GetDefaultBranch: got %s, expected main", *branch.Name)
+	}
+
+	commits, err := client.ListCommits()
+	if err != nil {
+		t.Fatalf("ListCommits: %v", err)
+	}
+	got := map[string]int{}
+	for _, c := range commits {
+		got[c.SHA] = 0
+		if c.AssociatedMergeRequest != nil {
+			got[c.SHA] = c.AssociatedMergeRequest.Number
+		}
+	}
+	expected := map[string]int{
+		hashes[0].String(): 0,
+		hashes[1].String(): 12,
+		hashes[2].String(): 0,
+		hashes[3].String(): 13,
+		hashes[4].String(): 14,
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("ListCommits: PR numbers mismatch (-want +got):\n%s", diff)
+	}
+	if commits[0].SHA != hashes[4].String() {
+		t.Errorf("ListCommits: got %s first, expected %s", commits[0].SHA, hashes[4])
+	}
+}
+
+func TestClient_DetachedHead(t *testing.T) {
+	t.Parallel()
+	dir, hashes := newGitRepo(t, []string{"Initial commit", "Add feature (#12)", "Fix typo"})
+	// As after actions/checkout of a pull request: HEAD is a commit, and
+	// the default branch of origin is unknown.
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("git.PlainOpen: %v", err)
+	}
+	if err := repo.Storer.RemoveReference(plumbing.NewRemoteHEADReferenceName("origin")); err != nil {
+		t.Fatalf("RemoveReference: %v", err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.HEAD, hashes[1])); err != nil {
+		t.Fatalf("SetReference: %v", err)
+	}
+	client := initClient(t, dir)
+
+	if _, err := client.GetDefaultBranch(); !errors.Is(err, clients.ErrUnsupportedFeature) {
+		t.Errorf("GetDefaultBranch: got %v, expected %v", err, clients.ErrUnsupportedFeature)
+	}
+	commits, err := client.ListCommits()
+	if err != nil {
+		t.Fatalf("ListCommits: %v", err)
+	}
+	got := []string{}
+	for _, c := range commits {
+		got = append(got, c.SHA)
+	}
+	expected := []string{hashes[1].String(), hashes[0].String()}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("ListCommits mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_ListBranchUpdates(t *testing.T) {
+	t.Parallel()
+	dir, hashes := newGitRepo(t, []string{"first", "second", "third"})
+	zero := plumbing.ZeroHash.String()
+	reflog := fmt.Sprintf(
+		"%s %s dev <dev@example.com> 1 +0000\tfetch: storing head\n"+
+			"%s %s dev <dev@example.com> 2 +0000\tfetch: fast-forward\n"+
+			"%s %s dev <dev@example.com> 3 +0000\tfetch: forced-update\n",
+		zero, hashes[0], hashes[0], hashes[2], hashes[2], hashes[1])
+	logDir := filepath.Join(dir, ".git", "logs", "refs", "remotes", "origin")
+	if err := os.MkdirAll(logDir, 0o700); err != nil {
+		t.Fatalf("os.MkdirAll: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(logDir, "main"), []byte(reflog), 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	client := initClient(t, dir)
+
+	updates, err := client.ListBranchUpdates("main")
+	if err != nil {
+		t.Fatalf("ListBranchUpdates: %v", err)
+	}
+	expected := []clients.BranchUpdate{
+		{
+			UpdatedAt: time.Unix(3, 0), OldSHA: hashes[2].String(), NewSHA: hashes[1].String(),
+			Message: "fetch: forced-update", Forced: true,
+		},
+		{
+			UpdatedAt: time.Unix(2, 0), OldSHA: hashes[0].String(), NewSHA: hashes[2].String(),
+			Message: "fetch: fast-forward",
+		},
+		{
+			UpdatedAt: time.Unix(1, 0), OldSHA: zero, NewSHA: hashes[0].String(),
+			Message: "fetch: storing head",
+		},
+	}
+	if diff := cmp.Diff(expected, updates); diff != "" {
+		t.Errorf("ListBranchUpdates mismatch (-want +got):\n%s", diff)
+	}
+
+	// History rewrites are also detected without the message of git fetch.
+	if got := initClient(t, dir).(*localDirClient).git.isForced(&clients.BranchUpdate{
+		OldSHA: hashes[2].String(), NewSHA: hashes[1].String(),
+	}); !got {
+		t.Errorf("isForced: got false, expected true")
+	}
+}
+
+func TestClient_NotGitRepo(t *testing.T) {
+	t.Parallel()
+	client := initClient(t, t.TempDir())
+	if _, err := client.ListCommits(); !errors.Is(err, clients.ErrUnsupportedFeature) {
+		t.Errorf("ListCommits: got %v, expected %v", err, clients.ErrUnsupportedFeature)
+	}
+	if _, err := client.ListBranchUpdates("main"); !errors.Is(err, clients.ErrUnsupportedFeature) {
+		t.Errorf("ListBranchUpdates: got %v, expected %v", err, clients.ErrUnsupportedFeature)
+	}
+	reporter := client.(clients.FeatureReporter)
+	if reporter.SupportsFeature(clients.FeatureCommits) {
+		t.Errorf("SupportsFeature(%s): got true, expected false", clients.FeatureCommits)
+	}
+	if !reporter.SupportsFeature(clients.FeatureFileContents) {
+		t.Errorf("SupportsFeature(%s): got false, expected true", clients.FeatureFileContents)
+	}
+}
@@ -63,6 +63,21 @@ func (mr *MockRepoClientMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockRepoClient)(nil).Close))
 }
 
+// GetBranch mocks base method.
+func (m *MockRepoClient) GetBranch(name string) (*clients.BranchRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranch", name)
+	ret0, _ := ret[0].(*clients.BranchRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranch indicates an expected call of GetBranch.
+func (mr *MockRepoClientMockRecorder) GetBranch(name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranch", reflect.TypeOf((*MockRepoClient)(nil).GetBranch), name)
+}
+
 // GetDefaultBranch mocks base method.
 func (m *MockRepoClient) GetDefaultBranch() (*clients.BranchRef, error) {
 	m.ctrl.T.Helper()
@@ -94,17 +109,17 @@ func (mr *MockRepoClientMockRecorder) GetFileContent(filename interface{}) *gomo
 }
 
 // InitRepo mocks base method.
-func (m *MockRepoClient) InitRepo(repo clients.Repo) error {
+func (m *MockRepoClient) InitRepo(repo clients.Repo, commitSHA string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InitRepo", repo)
+	ret := m.ctrl.Call(m, "InitRepo", repo, commitSHA)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // InitRepo indicates an expected call of InitRepo.
-func (mr *MockRepoClientMockRecorder) InitRepo(repo interface{}) *gomock.Call {
+func (mr *MockRepoClientMockRecorder) InitRepo(repo, commitSHA interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitRepo", reflect.TypeOf((*MockRepoClient)(nil).InitRepo), repo)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitRepo", reflect.TypeOf((*MockRepoClient)(nil).InitRepo), repo, commitSHA)
 }
 
 // IsArchived mocks base method.
@@ -122,6 +137,36 @@ func (mr *MockRepoClientMockRecorder) IsArchived() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsArchived", reflect.TypeOf((*MockRepoClient)(nil).IsArchived))
 }
 
+// ListAttestations mocks base method.
+func (m *MockRepoClient) ListAttestations(subjectDigest string) ([]clients.Attestation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAttestations", subjectDigest)
+	ret0, _ := ret[0].([]clients.Attestation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAttestations indicates an expected call of ListAttestations.
+func (mr *MockRepoClientMockRecorder) ListAttestations(subjectDigest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAttestations", reflect.TypeOf((*MockRepoClient)(nil).ListAttestations), subjectDigest)
+}
+
+// ListBranchUpdates mocks base method.
+func (m *MockRepoClient) ListBranchUpdates(branch string) ([]clients.BranchUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBranchUpdates", branch)
+	ret0, _ := ret[0].([]clients.BranchUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBranchUpdates indicates an expected call of ListBranchUpdates.
+func (mr *MockRepoClientMockRecorder) ListBranchUpdates(branch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranchUpdates", reflect.TypeOf((*MockRepoClient)(nil).ListBranchUpdates), branch)
+}
+
 // ListBranches mocks base method.
 func (m *MockRepoClient) ListBranches() ([]*clients.BranchRef, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +182,21 @@ func (mr *MockRepoClientMockRecorder) ListBranches() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranches", reflect.TypeOf((*MockRepoClient)(nil).ListBranches))
 }
 
+// ListBranchesForCommit mocks base method.
+func (m *MockRepoClient) ListBranchesForCommit(sha string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBranchesForCommit", sha)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBranchesForCommit indicates an expected call of ListBranchesForCommit.
+func (mr *MockRepoClientMockRecorder) ListBranchesForCommit(sha interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBranchesForCommit", reflect.TypeOf((*MockRepoClient)(nil).ListBranchesForCommit), sha)
+}
+
 // ListCheckRunsForRef mocks base method.
 func (m *MockRepoClient) ListCheckRunsForRef(ref string) ([]clients.CheckRun, error) {
 	m.ctrl.T.Helper()
@@ -167,6 +227,21 @@ func (mr *MockRepoClientMockRecorder) ListCommits() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommits", reflect.TypeOf((*MockRepoClient)(nil).ListCommits))
 }
 
+// ListContainerImages mocks base method.
+func (m *MockRepoClient) ListContainerImages() ([]clients.ContainerImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContainerImages")
+	ret0, _ := ret[0].([]clients.ContainerImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContainerImages indicates an expected call of ListContainerImages.
+func (mr *MockRepoClientMockRecorder) ListContainerImages() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContainerImages", reflect.TypeOf((*MockRepoClient)(nil).ListContainerImages))
+}
+
 // ListContributors mocks base method.
 func (m *MockRepoClient) ListContributors() ([]clients.Contributor, error) {
 	m.ctrl.T.Helper()
@@ -242,6 +317,21 @@ func (mr *MockRepoClientMockRecorder) ListReleases() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReleases", reflect.TypeOf((*MockRepoClient)(nil).ListReleases))
 }
 
+// ListSecurityAdvisories mocks base method.
+func (m *MockRepoClient) ListSecurityAdvisories() ([]clients.SecurityAdvisory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSecurityAdvisories")
+	ret0, _ := ret[0].([]clients.SecurityAdvisory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSecurityAdvisories indicates an expected call of ListSecurityAdvisories.
+func (mr *MockRepoClientMockRecorder) ListSecurityAdvisories() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSecurityAdvisories", reflect.TypeOf((*MockRepoClient)(nil).ListSecurityAdvisories))
+}
+
 // ListStatuses mocks base method.
 func (m *MockRepoClient) ListStatuses(ref string) ([]clients.Status, error) {
 	m.ctrl.T.Helper()
@@ -272,6 +362,21 @@ func (mr *MockRepoClientMockRecorder) ListSuccessfulWorkflowRuns(filename interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSuccessfulWorkflowRuns", reflect.TypeOf((*MockRepoClient)(nil).ListSuccessfulWorkflowRuns), filename)
 }
 
+// ListTags mocks base method.
+func (m *MockRepoClient) ListTags() ([]clients.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags")
+	ret0, _ := ret[0].([]clients.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockRepoClientMockRecorder) ListTags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockRepoClient)(nil).ListTags))
+}
+
 // Search mocks base method.
 func (m *MockRepoClient) Search(request clients.SearchRequest) (clients.SearchResponse, error) {
 	m.ctrl.T.Helper()
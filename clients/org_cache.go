@@ -0,0 +1,96 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"sync"
+	"time"
+)
+
+// sharedOrgCacheTTL bounds how long org-level data is shared between the repos
+// scanned by a long running process, e.g. a cron worker.
+const sharedOrgCacheTTL = 24 * time.Hour
+
+var sharedOrgCache = NewOrgCache(sharedOrgCacheTTL)
+
+// SharedOrgCache returns the OrgCache shared by all the repos scanned by this process.
+func SharedOrgCache() *OrgCache {
+	return sharedOrgCache
+}
+
+// OrgCache caches org-level data, e.g. org memberships or the contents of the
+// org's `.github` repo, so that scanning many repos of an org only fetches it once.
+type OrgCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*orgCacheEntry
+	// nextSweep is when the expired entries are next removed, so that
+	// the cache of a long running process does not grow without bound.
+	nextSweep time.Time
+}
+
+type orgCacheEntry struct {
+	once   sync.Once
+	value  interface{}
+	err    error
+	expiry time.Time
+}
+
+// NewOrgCache returns an OrgCache whose entries expire after ttl.
+func NewOrgCache(ttl time.Duration) *OrgCache {
+	return &OrgCache{
+		ttl:     ttl,
+		entries: make(map[string]*orgCacheEntry),
+	}
+}
+
+// Get returns the value cached for key, calling load on a miss.
+// Concurrent calls for the same key share a single load.
+// Errors are not cached.
+func (c *OrgCache) Get(key string, load func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	now := time.Now()
+	if !now.Before(c.nextSweep) {
+		c.sweep(now)
+	}
+	entry, ok := c.entries[key]
+	if !ok || now.After(entry.expiry) {
+		entry = &orgCacheEntry{expiry: now.Add(c.ttl)}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.value, entry.err = load()
+	})
+	if entry.err != nil {
+		c.mu.Lock()
+		if c.entries[key] == entry {
+			delete(c.entries, key)
+		}
+		c.mu.Unlock()
+	}
+	return entry.value, entry.err
+}
+
+// sweep removes the entries expired at now. c.mu must be held.
+func (c *OrgCache) sweep(now time.Time) {
+	for key, entry := range c.entries {
+		if now.After(entry.expiry) {
+			delete(c.entries, key)
+		}
+	}
+	c.nextSweep = now.Add(c.ttl)
+}
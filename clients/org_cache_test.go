@@ -0,0 +1,88 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var errLoad = errors.New("load error")
+
+func TestOrgCache(t *testing.T) {
+	t.Parallel()
+	cache := NewOrgCache(time.Hour)
+	loads := 0
+	load := func() (interface{}, error) {
+		loads++
+		return loads, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		v, err := cache.Get("org", load)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if v != 1 {
+			t.Errorf("expected cached value 1, got %v", v)
+		}
+	}
+
+	if _, err := cache.Get("other", func() (interface{}, error) { return nil, errLoad }); !errors.Is(err, errLoad) {
+		t.Errorf("expected errLoad, got %v", err)
+	}
+	v, err := cache.Get("other", load)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if v != 2 {
+		t.Errorf("expected errors not to be cached, got %v", v)
+	}
+}
+
+func TestOrgCacheExpiry(t *testing.T) {
+	t.Parallel()
+	cache := NewOrgCache(0)
+	loads := 0
+	load := func() (interface{}, error) {
+		loads++
+		return loads, nil
+	}
+	//nolint:errcheck
+	cache.Get("org", load)
+	time.Sleep(time.Millisecond)
+	//nolint:errcheck
+	cache.Get("org", load)
+	if loads != 2 {
+		t.Errorf("expected expired entry to be reloaded, got %d loads", loads)
+	}
+}
+
+func TestOrgCacheSweep(t *testing.T) {
+	t.Parallel()
+	cache := NewOrgCache(0)
+	load := func() (interface{}, error) {
+		return nil, nil
+	}
+	for _, key := range []string{"org1", "org2", "org3"} {
+		//nolint:errcheck
+		cache.Get(key, load)
+		time.Sleep(time.Millisecond)
+	}
+	if len(cache.entries) != 1 {
+		t.Errorf("expected expired entries to be removed, got %d entries", len(cache.entries))
+	}
+}
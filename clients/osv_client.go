@@ -0,0 +1,236 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients/githubrepo/roundtripper"
+)
+
+const (
+	osvAPI = "https://api.osv.dev/v1"
+	// osvMaxBatchSize is the maximum number of queries of a querybatch request.
+	osvMaxBatchSize = 1000
+	osvCVSSv3       = "CVSS_V3"
+)
+
+var errOSVStatus = errors.New("unexpected OSV status")
+
+type osvQuery struct {
+	Commit    string      `json:"commit,omitempty"`
+	Package   *osvPackage `json:"package,omitempty"`
+	Version   string      `json:"version,omitempty"`
+	PageToken string      `json:"page_token,omitempty"`
+}
+
+type osvPackage struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+type osvBatchQuery struct {
+	Queries []osvQuery `json:"queries"`
+}
+
+type osvVuln struct {
+	ID       string `json:"id"`
+	Severity []struct {
+		Type  string `json:"type"`
+		Score string `json:"score"`
+	} `json:"severity"`
+	DatabaseSpecific struct {
+		Severity string `json:"severity"`
+	} `json:"database_specific"`
+}
+
+type osvResponse struct {
+	Vulns []osvVuln `json:"vulns"`
+}
+
+type osvBatchResponse struct {
+	Results []struct {
+		Vulns []struct {
+			ID string `json:"id"`
+		} `json:"vulns"`
+		NextPageToken string `json:"next_page_token"`
+	} `json:"results"`
+}
+
+// osvClient implements the VulnerabilitiesClient interface using https://osv.dev.
+type osvClient struct {
+	// baseURL defaults to osvAPI.
+	baseURL string
+}
+
+// HasUnfixedVulnerabilities implements VulnerabilitiesClient.HasUnfixedVulnerabilities.
+func (v osvClient) HasUnfixedVulnerabilities(ctx context.Context, commit string) (VulnerabilitiesResponse, error) {
+	var osvResp osvResponse
+	if err := v.do(ctx, http.MethodPost, "/query", &osvQuery{Commit: commit}, &osvResp); err != nil {
+		return VulnerabilitiesResponse{}, err
+	}
+
+	ret := VulnerabilitiesResponse{}
+	for i := range osvResp.Vulns {
+		ret.Vulnerabilities = append(ret.Vulnerabilities, Vulnerability{
+			ID:       osvResp.Vulns[i].ID,
+			Severity: osvSeverity(&osvResp.Vulns[i]),
+		})
+	}
+	return ret, nil
+}
+
+// QueryDependencies implements VulnerabilitiesClient.QueryDependencies.
+// The batch API only returns the IDs of the vulnerabilities, so the
+// details of each of them are then fetched once to get their severity.
+// The vulnerabilities of a dependency with many of them are paginated,
+// so its query is sent again with the next page token until there is none.
+func (v osvClient) QueryDependencies(ctx context.Context, deps []Dependency) ([]VulnerabilitiesResponse, error) {
+	type pendingQuery struct {
+		pageToken string
+		dep       int
+	}
+	ret := make([]VulnerabilitiesResponse, len(deps))
+	severities := map[string]string{}
+	pending := make([]pendingQuery, len(deps))
+	for i := range deps {
+		pending[i].dep = i
+	}
+	for len(pending) > 0 {
+		n := len(pending)
+		if n > osvMaxBatchSize {
+			n = osvMaxBatchSize
+		}
+		queries := pending[:n]
+		pending = pending[n:]
+		batch := osvBatchQuery{}
+		for _, q := range queries {
+			d := deps[q.dep]
+			batch.Queries = append(batch.Queries, osvQuery{
+				Package:   &osvPackage{Name: d.Name, Ecosystem: d.Ecosystem},
+				Version:   d.Version,
+				PageToken: q.pageToken,
+			})
+		}
+		var batchResp osvBatchResponse
+		if err := v.do(ctx, http.MethodPost, "/querybatch", &batch, &batchResp); err != nil {
+			return nil, err
+		}
+		for j, result := range batchResp.Results {
+			if j >= len(queries) {
+				break
+			}
+			i := queries[j].dep
+			if result.NextPageToken != "" {
+				pending = append(pending, pendingQuery{dep: i, pageToken: result.NextPageToken})
+			}
+			for _, vuln := range result.Vulns {
+				severity, ok := severities[vuln.ID]
+				if !ok {
+					var details osvVuln
+					if err := v.do(ctx, http.MethodGet, "/vulns/"+url.PathEscape(vuln.ID), nil, &details); err != nil {
+						return nil, err
+					}
+					severity = osvSeverity(&details)
+					severities[vuln.ID] = severity
+				}
+				ret[i].Vulnerabilities = append(ret[i].Vulnerabilities, Vulnerability{
+					ID:       vuln.ID,
+					Severity: severity,
+				})
+			}
+		}
+	}
+	return ret, nil
+}
+
+// osvSeverity returns the severity of vuln, as given by its database, e.g. by
+// GitHub advisories, or else computed from its CVSS v3 vector.
+func osvSeverity(vuln *osvVuln) string {
+	switch s := strings.ToUpper(vuln.DatabaseSpecific.Severity); s {
+	case SeverityCritical, SeverityHigh, SeverityModerate, SeverityLow:
+		return s
+	case "MEDIUM":
+		return SeverityModerate
+	}
+	for _, s := range vuln.Severity {
+		if s.Type != osvCVSSv3 {
+			continue
+		}
+		score, err := cvssV3BaseScore(s.Score)
+		if err != nil {
+			continue
+		}
+		switch {
+		case score >= 9:
+			return SeverityCritical
+		case score >= 7:
+			return SeverityHigh
+		case score >= 4:
+			return SeverityModerate
+		case score > 0:
+			return SeverityLow
+		}
+	}
+	return ""
+}
+
+// do sends a request with the JSON encoding of body, if any, to the OSV API
+// at path and decodes the response into v.
+func (v osvClient) do(ctx context.Context, method, path string, body, resp interface{}) error {
+	baseURL := v.baseURL
+	if baseURL == "" {
+		baseURL = osvAPI
+	}
+	var reqBody io.Reader
+	if body != nil {
+		query, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error during json.Marshal: %w", err)
+		}
+		reqBody = bytes.NewReader(query)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("error during http.NewRequestWithContext: %w", err)
+	}
+
+	// Use our own http client as the one from the RepoClient adds GitHub tokens to the headers.
+	httpClient := &http.Client{Transport: roundtripper.WithCassette(roundtripper.BaseTransport())}
+	httpResp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error during http.Do: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %d", errOSVStatus, httpResp.StatusCode)
+	}
+
+	decoder := json.NewDecoder(httpResp.Body)
+	if err := decoder.Decode(resp); err != nil {
+		return fmt.Errorf("error during decoder.Decode: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOSVQueryDependencies(t *testing.T) {
+	t.Parallel()
+	details := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/querybatch":
+			var batch osvBatchQuery
+			if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if len(batch.Queries) == 1 && batch.Queries[0].PageToken == "page-2" {
+				fmt.Fprint(w, `{"results": [{"vulns": [{"id": "GHSA-3"}]}]}`)
+				return
+			}
+			if len(batch.Queries) != 3 || batch.Queries[0].Package.Ecosystem != EcosystemNPM {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprint(w, `{"results": [
+				{"vulns": [{"id": "GHSA-1"}, {"id": "GO-2"}], "next_page_token": "page-2"},
+				{},
+				{"vulns": [{"id": "GHSA-1"}]}
+			]}`)
+		case "/vulns/GHSA-1":
+			details++
+			fmt.Fprint(w, `{"id": "GHSA-1", "database_specific": {"severity": "CRITICAL"}}`)
+		case "/vulns/GHSA-3":
+			details++
+			fmt.Fprint(w, `{"id": "GHSA-3", "database_specific": {"severity": "LOW"}}`)
+		case "/vulns/GO-2":
+			details++
+			fmt.Fprint(w, `{"id": "GO-2", "severity": [
+				{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:U/C:L/I:N/A:N"}
+			]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client := osvClient{baseURL: server.URL}
+
+	deps := []Dependency{
+		{Ecosystem: EcosystemNPM, Name: "a", Version: "1.0.0"},
+		{Ecosystem: EcosystemNPM, Name: "b", Version: "1.0.0"},
+		{Ecosystem: EcosystemNPM, Name: "c", Version: "1.0.0"},
+	}
+	got, err := client.QueryDependencies(context.Background(), deps)
+	if err != nil {
+		t.Fatalf("QueryDependencies: %v", err)
+	}
+	want := []VulnerabilitiesResponse{
+		{Vulnerabilities: []Vulnerability{
+			{ID: "GHSA-1", Severity: SeverityCritical},
+			{ID: "GO-2", Severity: SeverityModerate},
+			{ID: "GHSA-3", Severity: SeverityLow},
+		}},
+		{},
+		{Vulnerabilities: []Vulnerability{{ID: "GHSA-1", Severity: SeverityCritical}}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("QueryDependencies mismatch (-want +got):\n%s", diff)
+	}
+	if details != 3 {
+		t.Errorf("fetched the details of %d vulnerabilities, want 3", details)
+	}
+
+	if _, err := client.QueryDependencies(context.Background(), deps[:1]); !errors.Is(err, errOSVStatus) {
+		t.Errorf("QueryDependencies error = %v, want %v", err, errOSVStatus)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import "context"
+
+// PackagesClient looks up the packages that language ecosystems
+// list as published from a repo.
+type PackagesClient interface {
+	ListPackages(ctx context.Context, repoURI string) ([]Package, error)
+}
+
+// DependentsCounter is implemented by PackagesClients which can count
+// the packages depending on a package.
+type DependentsCounter interface {
+	CountDependents(ctx context.Context, p Package) (int, error)
+}
+
+// DefaultPackagesClient returns a new deps.dev Packages client.
+func DefaultPackagesClient() PackagesClient {
+	return depsDevClient{}
+}
+
+// Package is a package published from a repo.
+type Package struct {
+	// System is the package ecosystem, e.g. NPM, PYPI or CARGO.
+	System string
+	Name   string
+}
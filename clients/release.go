@@ -26,4 +26,6 @@ type Release struct {
 type ReleaseAsset struct {
 	Name string
 	URL  string
+	// Digest is the digest of the asset, e.g. sha256:..., if the forge reports it.
+	Digest string
 }
@@ -20,9 +20,14 @@ import "errors"
 // ErrUnsupportedFeature indicates an API that is not supported by the client.
 var ErrUnsupportedFeature = errors.New("unsupported feature")
 
+// HeadSHA is the commitSHA passed to InitRepo to analyze the default branch's latest commit.
+const HeadSHA = "HEAD"
+
 // RepoClient interface is used by Scorecard checks to access a repo.
 type RepoClient interface {
-	InitRepo(repo Repo) error
+	// InitRepo sets up the client to analyze repo at commitSHA,
+	// which may also be a tag or HeadSHA.
+	InitRepo(repo Repo, commitSHA string) error
 	URI() string
 	IsArchived() (bool, error)
 	ListFiles(predicate func(string) (bool, error)) ([]string, error)
@@ -30,13 +35,29 @@ type RepoClient interface {
 	ListMergedPRs() ([]PullRequest, error)
 	ListBranches() ([]*BranchRef, error)
 	GetDefaultBranch() (*BranchRef, error)
+	// GetBranch returns the branch named name, following renames:
+	// the returned branch has its current name.
+	GetBranch(name string) (*BranchRef, error)
+	// ListBranchUpdates lists the recorded updates of a branch, most recent first.
+	ListBranchUpdates(branch string) ([]BranchUpdate, error)
 	ListCommits() ([]Commit, error)
 	ListIssues() ([]Issue, error)
 	ListReleases() ([]Release, error)
+	// ListAttestations lists the attestations the forge stores for the
+	// artifact with digest subjectDigest, e.g. sha256:...
+	ListAttestations(subjectDigest string) ([]Attestation, error)
+	// ListContainerImages lists the latest version of the container images
+	// the forge hosts for the repo.
+	ListContainerImages() ([]ContainerImage, error)
+	// ListTags lists the most recent annotated tags.
+	ListTags() ([]Tag, error)
+	// ListBranchesForCommit lists the branches the commit was merged onto.
+	ListBranchesForCommit(sha string) ([]string, error)
 	ListContributors() ([]Contributor, error)
 	ListSuccessfulWorkflowRuns(filename string) ([]WorkflowRun, error)
 	ListCheckRunsForRef(ref string) ([]CheckRun, error)
 	ListStatuses(ref string) ([]Status, error)
+	ListSecurityAdvisories() ([]SecurityAdvisory, error)
 	Search(request SearchRequest) (SearchResponse, error)
 	Close() error
 }
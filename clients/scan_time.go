@@ -0,0 +1,39 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"time"
+)
+
+type scanTimeKey struct{}
+
+// WithScanTime returns a copy of ctx for which the scan happens at t: the checks
+// and clients comparing dates to the current time use t instead, so that a
+// replayed scan scores the same.
+func WithScanTime(ctx context.Context, t time.Time) context.Context {
+	return context.WithValue(ctx, scanTimeKey{}, t)
+}
+
+// ScanTime returns the time set with WithScanTime, or the current time.
+func ScanTime(ctx context.Context) time.Time {
+	if ctx != nil {
+		if t, ok := ctx.Value(scanTimeKey{}).(time.Time); ok {
+			return t
+		}
+	}
+	return time.Now()
+}
@@ -0,0 +1,33 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestScanTime(t *testing.T) {
+	t.Parallel()
+	before := time.Now()
+	if got := ScanTime(context.Background()); got.Before(before) {
+		t.Errorf("ScanTime() = %v, want the current time", got)
+	}
+	pinned := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	if got := ScanTime(WithScanTime(context.Background(), pinned)); !got.Equal(pinned) {
+		t.Errorf("ScanTime() = %v, want %v", got, pinned)
+	}
+}
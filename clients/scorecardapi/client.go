@@ -0,0 +1,174 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scorecardapi retrieves the results published to the public Scorecard API,
+// to use cached scores instead of scoring repositories again.
+package scorecardapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/clients/githubrepo/roundtripper"
+)
+
+// DefaultURL is the URL of the public Scorecard API.
+const DefaultURL = "https://api.securityscorecards.dev"
+
+var (
+	// ErrNotFound is returned when no result was published for the repository,
+	// or for the requested commit.
+	ErrNotFound = errors.New("no published result")
+	// ErrInvalidRepo is returned for repositories not of the form host/owner/repo.
+	ErrInvalidRepo = errors.New("invalid repository")
+
+	errAPIStatus = errors.New("unexpected Scorecard API status")
+)
+
+// Repo is the repository and commit a result is about.
+type Repo struct {
+	Name   string `json:"name"`
+	Commit string `json:"commit"`
+}
+
+// Scorecard is the version of Scorecard a result was computed with.
+type Scorecard struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+}
+
+// Documentation describes a check.
+type Documentation struct {
+	Short string `json:"short"`
+	URL   string `json:"url"`
+}
+
+// Check is the result of a check.
+type Check struct {
+	Name          string        `json:"name"`
+	Reason        string        `json:"reason"`
+	Details       []string      `json:"details"`
+	Documentation Documentation `json:"documentation"`
+	Score         int           `json:"score"`
+}
+
+// Result is a published result, in the format of --format=json.
+type Result struct {
+	Date      string    `json:"date"`
+	Repo      Repo      `json:"repo"`
+	Scorecard Scorecard `json:"scorecard"`
+	Checks    []Check   `json:"checks"`
+	Metadata  []string  `json:"metadata"`
+	Score     float64   `json:"score"`
+	// Raw is the result document as published, with the fields not decoded above.
+	Raw json.RawMessage `json:"-"`
+}
+
+// Options select which of the published results of a repository is returned.
+// The API only serves the latest result of a repository, or that of a commit,
+// so there is no option to select the result published on a given day.
+type Options struct {
+	// Commit is the SHA of the commit the result must be about, the latest
+	// result is returned if empty.
+	Commit string
+}
+
+// Client retrieves published results.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient returns a client of the Scorecard API at baseURL, DefaultURL if empty.
+func NewClient(baseURL string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultURL
+	}
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		// Results are public, so no GitHub token is sent.
+		httpClient: &http.Client{Transport: roundtripper.BaseTransport()},
+	}
+}
+
+// GetResult returns the published result of repo, e.g. github.com/owner/repo.
+func (c *Client) GetResult(ctx context.Context, repo string, opts Options) (*Result, error) {
+	project, err := projectPath(repo)
+	if err != nil {
+		return nil, err
+	}
+	q := url.Values{}
+	if opts.Commit != "" {
+		q.Set("commit", opts.Commit)
+	}
+	u := fmt.Sprintf("%s/projects/%s", c.baseURL, project)
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error during http.NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error during http.Do: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, project)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d", errAPIStatus, resp.StatusCode)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error during io.ReadAll: %w", err)
+	}
+	var ret Result
+	if err := json.Unmarshal(raw, &ret); err != nil {
+		return nil, fmt.Errorf("error during json.Unmarshal: %w", err)
+	}
+	ret.Raw = raw
+	return &ret, nil
+}
+
+// projectPath normalizes repo, with or without a scheme, to host/owner/repo.
+// Repositories without a host are assumed to be on github.com.
+func projectPath(repo string) (string, error) {
+	s := strings.TrimPrefix(strings.TrimPrefix(repo, "https://"), "http://")
+	s = strings.TrimSuffix(strings.Trim(s, "/"), ".git")
+	parts := strings.Split(s, "/")
+	const owner = 2
+	if len(parts) == owner && !strings.Contains(parts[0], ".") {
+		parts = append([]string{"github.com"}, parts...)
+	}
+	if len(parts) != owner+1 {
+		return "", fmt.Errorf("%w: %s, expected host/owner/repo", ErrInvalidRepo, repo)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return "", fmt.Errorf("%w: %s, expected host/owner/repo", ErrInvalidRepo, repo)
+		}
+	}
+	return strings.Join(parts, "/"), nil
+}
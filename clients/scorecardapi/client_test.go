@@ -0,0 +1,102 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorecardapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestGetResult(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/projects/github.com/owner/broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if r.URL.Path != "/projects/github.com/owner/repo" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		q := r.URL.Query()
+		commit := "latest"
+		if q.Get("commit") != "" {
+			commit = q.Get("commit")
+		}
+		fmt.Fprintf(w, `{
+			"date": "2023-01-10",
+			"repo": {"name": "github.com/owner/repo", "commit": %q},
+			"scorecard": {"version": "v4.0.0", "commit": "abc"},
+			"score": 7.5,
+			"checks": [{"name": "Code-Review", "score": 8, "reason": "8 out of 10 commits reviewed",
+				"details": null, "documentation": {"short": "Reviews", "url": "https://example.com"}}],
+			"metadata": [],
+			"capabilities": {"forge": "GitHub"}
+		}`, commit)
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient(server.URL + "/")
+	ctx := context.Background()
+
+	tests := []struct {
+		err        error
+		name       string
+		repo       string
+		opts       Options
+		wantCommit string
+	}{
+		{name: "latest", repo: "github.com/owner/repo", wantCommit: "latest"},
+		{name: "owner/repo", repo: "owner/repo", wantCommit: "latest"},
+		{name: "URL", repo: "https://github.com/owner/repo.git", wantCommit: "latest"},
+		{name: "commit", repo: "github.com/owner/repo", opts: Options{Commit: "0123"}, wantCommit: "0123"},
+		{name: "unknown repo", repo: "github.com/owner/unknown", err: ErrNotFound},
+		{name: "server error", repo: "github.com/owner/broken", err: errAPIStatus},
+		{name: "invalid repo", repo: "github.com/owner", err: ErrInvalidRepo},
+	}
+	for _, tt := range tests {
+		got, err := client.GetResult(ctx, tt.repo, tt.opts)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: GetResult error = %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if tt.err != nil {
+			continue
+		}
+		want := &Result{
+			Date:      "2023-01-10",
+			Repo:      Repo{Name: "github.com/owner/repo", Commit: tt.wantCommit},
+			Scorecard: Scorecard{Version: "v4.0.0", Commit: "abc"},
+			Score:     7.5,
+			Checks: []Check{{
+				Name: "Code-Review", Score: 8, Reason: "8 out of 10 commits reviewed",
+				Documentation: Documentation{Short: "Reviews", URL: "https://example.com"},
+			}},
+			Metadata: []string{},
+		}
+		if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Result{}, "Raw")); diff != "" {
+			t.Errorf("%s: GetResult mismatch (-want +got):\n%s", tt.name, diff)
+		}
+		if len(got.Raw) == 0 {
+			t.Errorf("%s: GetResult did not keep the raw result", tt.name)
+		}
+	}
+}
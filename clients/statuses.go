@@ -14,8 +14,11 @@
 
 package clients
 
+import "time"
+
 // Status for a Git object/ref.
 type Status struct {
+	UpdatedAt time.Time
 	State     string
 	Context   string
 	URL       string
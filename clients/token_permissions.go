@@ -0,0 +1,55 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+// PermissionAdmin is the permission to administer the repo,
+// e.g. to read all its branch protection settings.
+const PermissionAdmin = "admin"
+
+// TokenPermissions describes the access the token of a RepoClient has to the repo.
+type TokenPermissions struct {
+	// Known is false if the forge did not report the token's permissions.
+	Known bool
+	Admin bool
+	// Scopes are the OAuth scopes of a classic token, nil if not reported.
+	Scopes []string
+}
+
+// Missing returns the permissions in required the token does not have.
+// It returns nil if the token's permissions are unknown.
+func (p TokenPermissions) Missing(required []string) []string {
+	if !p.Known {
+		return nil
+	}
+	var ret []string
+	for _, r := range required {
+		if r == PermissionAdmin && !p.Admin {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+// TokenPermissionsReporter is implemented by RepoClients which can report
+// the permissions of their token once InitRepo succeeded.
+type TokenPermissionsReporter interface {
+	TokenPermissions() TokenPermissions
+}
+
+// VisibilityReporter is implemented by RepoClients which can report
+// whether the repo is private once InitRepo succeeded.
+type VisibilityReporter interface {
+	IsPrivate() bool
+}
@@ -0,0 +1,37 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import "time"
+
+// Upstream is the repo a fork or a mirror was created from.
+type Upstream struct {
+	// PushedAt is when the upstream was last updated, zero if unknown.
+	PushedAt time.Time
+	// URI is the upstream repo, e.g. github.com/owner/repo,
+	// or the URL mirrored for mirrors of repos hosted elsewhere.
+	URI string
+	// Archived is set if the upstream is archived.
+	Archived bool
+	// Mirror is set if the repo is a read-only mirror of the upstream, instead of a fork.
+	Mirror bool
+}
+
+// UpstreamReporter is implemented by RepoClients which can report
+// whether the repo is a fork or a mirror once InitRepo succeeded.
+type UpstreamReporter interface {
+	// Upstream returns the upstream of the repo, or nil if it is neither a fork nor a mirror.
+	Upstream() *Upstream
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import "context"
+
+// VulnerabilitiesClient checks for vulnerabilities in vuln DBs.
+type VulnerabilitiesClient interface {
+	HasUnfixedVulnerabilities(ctx context.Context, commit string) (VulnerabilitiesResponse, error)
+	// QueryDependencies returns the vulnerabilities affecting each of deps, in the same order.
+	QueryDependencies(ctx context.Context, deps []Dependency) ([]VulnerabilitiesResponse, error)
+}
+
+// DefaultVulnerabilitiesClient returns a new OSV Vulnerabilities client.
+func DefaultVulnerabilitiesClient() VulnerabilitiesClient {
+	return osvClient{}
+}
+
+// VulnerabilitiesResponse is the response from the vuln DBs.
+type VulnerabilitiesResponse struct {
+	Vulnerabilities []Vulnerability
+}
+
+// Vulnerability uniquely identifies a reported security vuln.
+type Vulnerability struct {
+	ID string
+	// Severity is one of the Severity* constants, or empty if unknown.
+	Severity string
+}
+
+// Severities of a Vulnerability.
+const (
+	SeverityCritical = "CRITICAL"
+	SeverityHigh     = "HIGH"
+	SeverityModerate = "MODERATE"
+	SeverityLow      = "LOW"
+)
+
+// Ecosystems of a Dependency, as named by https://ossf.github.io/osv-schema.
+const (
+	EcosystemGo       = "Go"
+	EcosystemNPM      = "npm"
+	EcosystemPyPI     = "PyPI"
+	EcosystemCratesIO = "crates.io"
+)
+
+// Dependency is a version of a package, e.g. as pinned by a lockfile.
+type Dependency struct {
+	Ecosystem string
+	Name      string
+	Version   string
+}
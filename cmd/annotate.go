@@ -0,0 +1,96 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v3/checks"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+var (
+	annotateRepo    string
+	annotateFinding string
+	annotateCheck   string
+	annotateState   string
+	annotateReason  string
+)
+
+//nolint:gochecknoinits
+func init() {
+	annotateCmd.Flags().StringVar(&annotationsFile, "annotations", "", "file storing the annotations")
+	annotateCmd.Flags().StringVar(&annotateRepo, "repo", "", "repository the finding belongs to")
+	annotateCmd.Flags().StringVar(&annotateFinding, "finding", "", "ID of the finding to annotate")
+	annotateCmd.Flags().StringVar(&annotateCheck, "check", "", "check which reported the finding")
+	annotateCmd.Flags().StringVar(&annotateState, "state", "",
+		fmt.Sprintf("triage state. allowed values are [%s, %s, %s]",
+			pkg.TriageAcceptedRisk, pkg.TriageFixInProgress, pkg.TriageFalsePositive))
+	annotateCmd.Flags().StringVar(&annotateReason, "reason", "", "justification for the triage state")
+	rootCmd.AddCommand(annotateCmd)
+}
+
+var annotateCmd = &cobra.Command{
+	Use:   "annotate",
+	Short: "Attach a triage state to a finding",
+	Long: `Attach a triage state to a finding reported by a check.
+Finding IDs are shown next to warnings with --show-details.
+Pass the same file to --annotations when running scorecard, scorecard serve
+or scorecard diff: findings marked accepted-risk or false-positive are then
+no longer reported as warnings or new findings.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if annotationsFile == "" || annotateRepo == "" || annotateFinding == "" || annotateCheck == "" {
+			log.Fatal("--annotations, --repo, --finding and --check are required")
+		}
+		if _, exists := checks.AllChecks[annotateCheck]; !exists {
+			log.Fatalf("invalid check name: %s", annotateCheck)
+		}
+		state, err := pkg.ParseTriageState(annotateState)
+		if err != nil {
+			log.Fatal(err)
+		}
+		repo, _, err := parseRepo(annotateRepo)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		store := pkg.NewFileResultStore(annotationsFile)
+		if err := store.Annotate(repo.URI(), pkg.Annotation{
+			FindingID: annotateFinding,
+			Check:     annotateCheck,
+			State:     state,
+			Reason:    annotateReason,
+			Date:      time.Now().UTC(),
+		}); err != nil {
+			log.Fatalf("Annotate: %v", err)
+		}
+	},
+}
+
+// storedAnnotations returns the annotations of repo in --annotations, if set.
+func storedAnnotations(repo string) ([]pkg.Annotation, error) {
+	if annotationsFile == "" {
+		return nil, nil
+	}
+	annotations, err := pkg.NewFileResultStore(annotationsFile).Annotations(repo)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read annotations: %w", err)
+	}
+	return annotations, nil
+}
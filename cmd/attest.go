@@ -0,0 +1,160 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v3/attestation"
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/githubrepo"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+var (
+	attestRepo   string
+	attestPolicy string
+	attestCommit string
+	attestKey    string
+	attestOutput string
+)
+
+//nolint:gochecknoinits
+func init() {
+	attestCmd.Flags().StringVar(&attestRepo, "repo", "", "repository to attest")
+	attestCmd.Flags().StringVar(&attestPolicy, "policy", "", "policy the repository must meet")
+	attestCmd.Flags().StringVar(&attestCommit, "commit", clients.HeadSHA,
+		"commit SHA to attest, instead of the latest commit on the default branch")
+	attestCmd.Flags().StringVar(&attestKey, "key", "",
+		"PEM-encoded ECDSA private key, or gcpkms://projects/.../cryptoKeyVersions/N for a Cloud KMS key")
+	attestCmd.Flags().StringVar(&attestOutput, "output", "", "file to write the attestation to, stdout by default")
+	rootCmd.AddCommand(attestCmd)
+}
+
+var attestCmd = &cobra.Command{
+	Use:   "attest --repo=<repo_url> --policy=<file> --key=<key>",
+	Short: "Attest that a repository meets a policy",
+	Long: `Run the checks enforced by a policy and, if the repository meets the policy,
+write a signed in-toto attestation in a DSSE envelope asserting it does at the
+analyzed commit. Exits with status 1 without an attestation otherwise.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if attestRepo == "" || attestPolicy == "" || attestKey == "" {
+			log.Fatal("--repo, --policy and --key are required")
+		}
+		policy, err := readPolicy(attestPolicy)
+		if err != nil {
+			log.Fatalf("readPolicy: %v", err)
+		}
+
+		ctx := context.Background()
+		signer, err := loadSigner(ctx, attestKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		logger, err := githubrepo.NewLogger(*logLevel)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// nolint
+		defer logger.Sync() // Flushes buffer, if any.
+
+		repoURI, repoClient, ossFuzzRepoClient, ciiClient, vulnsClient, packagesClient,
+			repoType, err := getRepoAccessors(ctx, attestRepo, false, logger)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer repoClient.Close()
+		if ossFuzzRepoClient != nil {
+			defer ossFuzzRepoClient.Close()
+		}
+
+		checkDocs, err := docs.Read()
+		if err != nil {
+			log.Fatalf("cannot read yaml file: %v", err)
+		}
+		supportedChecks, err := getSupportedChecks(repoType, checkDocs)
+		if err != nil {
+			log.Fatalf("cannot read supported checks: %v", err)
+		}
+		enabledChecks, err := getEnabledChecks(policy, nil, checkDocs, supportedChecks, repoType)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		result, err := pkg.RunScorecards(ctx, repoURI, attestCommit, false, enabledChecks,
+			repoClient, ossFuzzRepoClient, ciiClient, vulnsClient, packagesClient, policyOptions(policy)...)
+		if err != nil {
+			log.Fatalf("RunScorecards: %v", err)
+		}
+
+		evaluation := attestation.Evaluate(&result, policy)
+		if !evaluation.Passed {
+			for _, c := range evaluation.Checks {
+				if !c.Passed {
+					fmt.Fprintf(os.Stderr, "%s: score %d is below %d\n", c.Name, c.Score, c.MinScore)
+				}
+			}
+			fmt.Fprintf(os.Stderr, "%s does not meet the policy at %s\n", result.Repo.Name, result.Repo.CommitSHA)
+			os.Exit(1)
+		}
+
+		statement, err := attestation.NewStatement(&result, evaluation)
+		if err != nil {
+			log.Fatal(err)
+		}
+		envelope, err := attestation.Sign(ctx, statement, signer)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := writeAttestation(envelope); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// loadSigner returns a signer for a PEM-encoded private key file or a gcpkms:// key reference.
+func loadSigner(ctx context.Context, key string) (attestation.Signer, error) {
+	if attestation.IsKMSKey(key) {
+		//nolint:wrapcheck
+		return attestation.NewKMSSigner(ctx, key)
+	}
+	//nolint:wrapcheck
+	return attestation.LoadKeySigner(key)
+}
+
+func writeAttestation(envelope *attestation.Envelope) error {
+	var w io.Writer = os.Stdout
+	if attestOutput != "" {
+		f, err := os.Create(attestOutput)
+		if err != nil {
+			return fmt.Errorf("os.Create: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := json.NewEncoder(w).Encode(envelope); err != nil {
+		return fmt.Errorf("encoding attestation: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+var changesFrom string
+
+//nolint:gochecknoinits
+func init() {
+	changesCmd.Flags().StringVar(&changesFrom, "from", "",
+		"list the scoring changes released after this version, e.g. v4.0")
+	rootCmd.AddCommand(changesCmd)
+}
+
+var changesCmd = &cobra.Command{
+	Use:   "changes",
+	Short: "List changes to the checks' scoring logic",
+	Long: `List changes to the checks' scoring logic released after a given version,
+to tell apart score changes caused by scorecard from those caused by the repo.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		from, err := parseRelease(changesFrom)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		checkDocs, err := docs.Read()
+		if err != nil {
+			log.Fatalf("cannot read yaml file: %v", err)
+		}
+		if err := printChanges(checkDocs.GetChecks(), from, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// printChanges writes the changes to the checks released after from, sorted by check name.
+func printChanges(checks []docs.CheckDoc, from [3]int, w io.Writer) error {
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].GetName() < checks[j].GetName()
+	})
+	for _, c := range checks {
+		for _, change := range c.GetChanges() {
+			release, err := parseRelease(change.Release)
+			if err != nil {
+				return fmt.Errorf("%s: %w", c.GetName(), err)
+			}
+			if !releaseLess(from, release) {
+				continue
+			}
+			fmt.Fprintf(w, "%s v%d (%s): %s\n", c.GetName(), change.Version, change.Release, change.Description)
+		}
+	}
+	return nil
+}
+
+// parseRelease parses versions of the form vX[.Y[.Z]].
+// An empty version is lower than any release.
+func parseRelease(v string) ([3]int, error) {
+	var ret [3]int
+	if v == "" {
+		return ret, nil
+	}
+	parts := strings.Split(strings.TrimPrefix(v, "v"), ".")
+	if len(parts) > len(ret) {
+		return ret, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid version: %s", v))
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return ret, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid version: %s", v))
+		}
+		ret[i] = n
+	}
+	return ret, nil
+}
+
+func releaseLess(x, y [3]int) bool {
+	for i := range x {
+		if x[i] != y[i] {
+			return x[i] < y[i]
+		}
+	}
+	return false
+}
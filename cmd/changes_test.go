@@ -0,0 +1,133 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// versionedCheck is a check doc with only a name and scoring changes.
+type versionedCheck struct {
+	docs.CheckDoc
+	name    string
+	changes []docs.CheckChange
+}
+
+func (c versionedCheck) GetName() string {
+	return c.name
+}
+
+func (c versionedCheck) GetChanges() []docs.CheckChange {
+	return c.changes
+}
+
+func TestParseRelease(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err     error
+		version string
+		want    [3]int
+	}{
+		{version: "", want: [3]int{}},
+		{version: "v4", want: [3]int{4, 0, 0}},
+		{version: "v4.1", want: [3]int{4, 1, 0}},
+		{version: "4.1.2", want: [3]int{4, 1, 2}},
+		{version: "v4.1.2.3", err: sce.ErrScorecardInternal},
+		{version: "v4.x", err: sce.ErrScorecardInternal},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.version, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseRelease(tt.version)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("parseRelease(%q) error = %v, want %v", tt.version, err, tt.err)
+			}
+			if tt.err == nil && got != tt.want {
+				t.Errorf("parseRelease(%q) = %v, want %v", tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintChanges(t *testing.T) {
+	t.Parallel()
+	checks := func() []docs.CheckDoc {
+		return []docs.CheckDoc{
+			versionedCheck{name: "Maintained", changes: []docs.CheckChange{
+				{Version: 2, Release: "v4.2", Description: "forks and mirrors"},
+			}},
+			versionedCheck{name: "Binary-Artifacts"},
+			versionedCheck{name: "CI-Tests", changes: []docs.CheckChange{
+				{Version: 2, Release: "v4.0", Description: "ignore CI results after merge"},
+				{Version: 3, Release: "v4.1.1", Description: "sample PRs"},
+			}},
+		}
+	}
+	tests := []struct {
+		err    error
+		name   string
+		from   [3]int
+		checks []docs.CheckDoc
+		want   string
+	}{
+		{
+			name:   "all changes",
+			checks: checks(),
+			want: "CI-Tests v2 (v4.0): ignore CI results after merge\n" +
+				"CI-Tests v3 (v4.1.1): sample PRs\n" +
+				"Maintained v2 (v4.2): forks and mirrors\n",
+		},
+		{
+			name:   "changes released after a version",
+			from:   [3]int{4, 1, 0},
+			checks: checks(),
+			want: "CI-Tests v3 (v4.1.1): sample PRs\n" +
+				"Maintained v2 (v4.2): forks and mirrors\n",
+		},
+		{
+			name:   "no newer changes",
+			from:   [3]int{4, 2, 0},
+			checks: checks(),
+		},
+		{
+			name: "invalid release",
+			checks: []docs.CheckDoc{
+				versionedCheck{name: "Maintained", changes: []docs.CheckChange{{Version: 2, Release: "next"}}},
+			},
+			err: sce.ErrScorecardInternal,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			if err := printChanges(tt.checks, tt.from, &buf); !errors.Is(err, tt.err) {
+				t.Fatalf("printChanges() error = %v, want %v", err, tt.err)
+			}
+			if diff := cmp.Diff(tt.want, buf.String()); diff != "" {
+				t.Errorf("printChanges() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
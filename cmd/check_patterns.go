@@ -0,0 +1,115 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const tagPrefix = "tag:"
+
+// expandCheckPatterns turns the --checks arguments into check names.
+// Arguments are check names, globs (e.g. `*`, `Signed-*`) or tags
+// (e.g. `tag:no-admin`), applied in order. Arguments prefixed with `-`
+// remove the checks they match; if the first argument is one of those,
+// the others are removed from all supported checks.
+// Globs and tags only match the checks supported by the repo type, while
+// an unsupported check requested by name is an error.
+func expandCheckPatterns(patterns []string, checkDocs docs.Doc,
+	supportedChecks []string, repoType string) ([]string, error) {
+	var candidates []string
+	for name := range getAllChecks() {
+		if isSupportedCheck(supportedChecks, name) {
+			candidates = append(candidates, name)
+		}
+	}
+	sort.Strings(candidates)
+
+	selected := make(map[string]bool)
+	if len(patterns) > 0 && strings.HasPrefix(patterns[0], "-") {
+		for _, name := range candidates {
+			selected[name] = true
+		}
+	}
+
+	for _, p := range patterns {
+		exclude := strings.HasPrefix(p, "-")
+		pattern := strings.TrimPrefix(p, "-")
+
+		var matches []string
+		switch {
+		case strings.HasPrefix(pattern, tagPrefix):
+			tag := strings.TrimPrefix(pattern, tagPrefix)
+			for _, name := range candidates {
+				if checkHasTag(checkDocs, name, tag) {
+					matches = append(matches, name)
+				}
+			}
+		case strings.ContainsAny(pattern, "*?["):
+			for _, name := range candidates {
+				matched, err := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+				if err != nil {
+					return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid glob %q: %v", pattern, err))
+				}
+				if matched {
+					matches = append(matches, name)
+				}
+			}
+		default:
+			if !isSupportedCheck(supportedChecks, pattern) {
+				return nil, sce.WithMessage(sce.ErrScorecardInternal,
+					fmt.Sprintf("repo type %s: unsupported check: %s", repoType, pattern))
+			}
+			matches = []string{pattern}
+		}
+		if len(matches) == 0 && !exclude {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("no checks match %s", pattern))
+		}
+
+		for _, name := range matches {
+			if exclude {
+				delete(selected, name)
+			} else {
+				selected[name] = true
+			}
+		}
+	}
+
+	ret := make([]string, 0, len(selected))
+	for name := range selected {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+func checkHasTag(checkDocs docs.Doc, name, tag string) bool {
+	c, err := checkDocs.GetCheck(name)
+	if err != nil {
+		return false
+	}
+	for _, t := range c.GetTags() {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/checks"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+)
+
+func without(names []string, remove ...string) []string {
+	var ret []string
+	for _, n := range names {
+		if !isSupportedCheck(remove, n) {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+func TestExpandCheckPatterns(t *testing.T) {
+	t.Parallel()
+	checkDocs, err := docs.Read()
+	if err != nil {
+		t.Fatalf("docs.Read: %v", err)
+	}
+	githubChecks, err := getSupportedChecks(repoTypeGitHub, checkDocs)
+	if err != nil {
+		t.Fatalf("getSupportedChecks: %v", err)
+	}
+	localChecks, err := getSupportedChecks(repoTypeLocal, checkDocs)
+	if err != nil {
+		t.Fatalf("getSupportedChecks: %v", err)
+	}
+	all, err := expandCheckPatterns([]string{"*"}, checkDocs, githubChecks, repoTypeGitHub)
+	if err != nil {
+		t.Fatalf("expandCheckPatterns: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		patterns  []string
+		supported []string
+		repoType  string
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:     "name",
+			patterns: []string{checks.CheckCodeReview},
+			want:     []string{checks.CheckCodeReview},
+		},
+		{
+			name:     "glob is case insensitive",
+			patterns: []string{"signed-*"},
+			want:     []string{checks.CheckSignedReleases},
+		},
+		{
+			name:     "all but one",
+			patterns: []string{"*", "-" + checks.CheckCIIBestPractices},
+			want:     without(all, checks.CheckCIIBestPractices),
+		},
+		{
+			name:     "leading exclusion starts from all checks",
+			patterns: []string{"-" + checks.CheckCIIBestPractices},
+			want:     without(all, checks.CheckCIIBestPractices),
+		},
+		{
+			name:     "tag",
+			patterns: []string{"tag:admin-required"},
+			want:     []string{checks.CheckBranchProtection},
+		},
+		{
+			name:     "tag exclusion",
+			patterns: []string{"-tag:admin-required"},
+			want:     without(all, checks.CheckBranchProtection),
+		},
+		{
+			name:      "globs skip unsupported checks",
+			patterns:  []string{"*", "-tag:dependencies", "-tag:infrastructure", "-tag:source-code"},
+			supported: localChecks,
+			repoType:  repoTypeLocal,
+			want:      []string{checks.CheckVulnerabilities},
+		},
+		{
+			name:      "unsupported name",
+			patterns:  []string{checks.CheckCodeReview},
+			supported: localChecks,
+			repoType:  repoTypeLocal,
+			wantErr:   true,
+		},
+		{
+			name:     "unknown tag",
+			patterns: []string{"tag:unknown"},
+			wantErr:  true,
+		},
+		{
+			name:     "unknown name",
+			patterns: []string{"Unknown-Check"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid glob",
+			patterns: []string{"["},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			supported, repoType := githubChecks, repoTypeGitHub
+			if tt.supported != nil {
+				supported, repoType = tt.supported, tt.repoType
+			}
+			got, err := expandCheckPatterns(tt.patterns, checkDocs, supported, repoType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandCheckPatterns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("expandCheckPatterns() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// configEnvPrefix prefixes the environment variables overriding the flags,
+// e.g. SCORECARD_FORMAT=json for --format=json.
+const configEnvPrefix = "SCORECARD_"
+
+const configFlag = "config"
+
+var errInvalidConfigValue = errors.New("must be a value or a list of values")
+
+// flagEnvVar returns the environment variable overriding a flag.
+func flagEnvVar(name string) string {
+	return configEnvPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// applyConfig sets the flags which were not set on the command line from their
+// environment variables, then from the YAML config file, if any, whose keys are
+// the names of the flags. Lists set repeatable flags, like repo, once per item.
+func applyConfig(fs *pflag.FlagSet, configFile string, lookupEnv func(string) (string, bool)) error {
+	var setErr error
+	fs.VisitAll(func(f *pflag.Flag) {
+		if setErr != nil || f.Changed || f.Name == configFlag {
+			return
+		}
+		if value, ok := lookupEnv(flagEnvVar(f.Name)); ok {
+			if err := fs.Set(f.Name, value); err != nil {
+				setErr = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s: %v", flagEnvVar(f.Name), err))
+			}
+		}
+	})
+	if setErr != nil || configFile == "" {
+		return setErr
+	}
+
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.ReadFile: %v", err))
+	}
+	var config map[string]interface{}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s: yaml.Unmarshal: %v", configFile, err))
+	}
+	// Apply the keys in order, so errors are reported deterministically.
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f := fs.Lookup(name)
+		if f == nil || name == configFlag {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s: unknown flag '%s'", configFile, name))
+		}
+		if f.Changed {
+			continue
+		}
+		values, err := configValues(config[name])
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s: %s: %v", configFile, name, err))
+		}
+		for _, value := range values {
+			if err := fs.Set(name, value); err != nil {
+				return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s: %s: %v", configFile, name, err))
+			}
+		}
+	}
+	return nil
+}
+
+// configValues returns the flag values of a config file value: one for a
+// scalar, one per item for a list.
+func configValues(v interface{}) ([]string, error) {
+	switch v := v.(type) {
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			itemValues, err := configValues(item)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, itemValues...)
+		}
+		return values, nil
+	case map[string]interface{}:
+		return nil, errInvalidConfigValue
+	case nil:
+		return nil, nil
+	default:
+		return []string{fmt.Sprint(v)}, nil
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/spf13/pflag"
+)
+
+func TestApplyConfig(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		config  string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "config file",
+			config: `
+repo:
+  - github.com/owner/a
+  - github.com/owner/b
+checks: [Code-Review, Fuzzing]
+format: json
+show-details: true
+workers: 8
+`,
+			want: map[string]string{
+				"repo":         "[github.com/owner/a,github.com/owner/b]",
+				"checks":       "[Code-Review,Fuzzing]",
+				"format":       "json",
+				"show-details": "true",
+				"workers":      "8",
+			},
+		},
+		{
+			name:   "environment overrides config file",
+			env:    map[string]string{"SCORECARD_FORMAT": "sarif", "SCORECARD_SHOW_DETAILS": "false"},
+			config: "format: json\nshow-details: true\nworkers: 8\n",
+			want: map[string]string{
+				"format":       "sarif",
+				"show-details": "false",
+				"workers":      "8",
+			},
+		},
+		{
+			name:   "command line overrides environment and config file",
+			args:   []string{"--format=csv", "--checks=Fuzzing"},
+			env:    map[string]string{"SCORECARD_FORMAT": "sarif"},
+			config: "format: json\nchecks: [Code-Review]\n",
+			want: map[string]string{
+				"format": "csv",
+				"checks": "[Fuzzing]",
+			},
+		},
+		{
+			name:    "unknown flag",
+			config:  "formats: json\n",
+			wantErr: true,
+		},
+		{
+			name:    "nested value",
+			config:  "format:\n  name: json\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid value",
+			config:  "workers: many\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid environment variable",
+			env:     map[string]string{"SCORECARD_WORKERS": "many"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fs := pflag.NewFlagSet("scorecard", pflag.ContinueOnError)
+			fs.StringArray("repo", nil, "")
+			fs.StringSlice("checks", nil, "")
+			fs.String("format", "default", "")
+			fs.Bool("show-details", false, "")
+			fs.Int("workers", 4, "")
+			fs.String(configFlag, "", "")
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			configFile := ""
+			if tt.config != "" {
+				configFile = filepath.Join(t.TempDir(), "scorecard.yml")
+				if err := os.WriteFile(configFile, []byte(tt.config), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			lookupEnv := func(name string) (string, bool) {
+				v, ok := tt.env[name]
+				return v, ok
+			}
+			err := applyConfig(fs, configFile, lookupEnv)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got := map[string]string{}
+			fs.Visit(func(f *pflag.Flag) {
+				got[f.Name] = f.Value.String()
+			})
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
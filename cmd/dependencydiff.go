@@ -0,0 +1,140 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v3/clients/githubrepo"
+	"github.com/ossf/scorecard/v3/dependencydiff"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+)
+
+var (
+	diffRepo     string
+	diffBase     string
+	diffHead     string
+	diffMinScore float64
+	diffChecks   []string
+)
+
+//nolint:gochecknoinits
+func init() {
+	dependencyDiffCmd.Flags().StringVar(&diffRepo, "repo", "", "GitHub repository the refs belong to")
+	dependencyDiffCmd.Flags().StringVar(&diffBase, "base", "", "base ref of the change, e.g. main")
+	dependencyDiffCmd.Flags().StringVar(&diffHead, "head", "", "head ref of the change, e.g. a PR branch")
+	dependencyDiffCmd.Flags().Float64Var(&diffMinScore, "min-score", 5,
+		"report added or updated dependencies with an aggregate score below this value as regressions")
+	dependencyDiffCmd.Flags().StringSliceVar(&diffChecks, "checks", []string{},
+		"checks to run on each dependency, all checks supporting GitHub repos by default")
+	rootCmd.AddCommand(dependencyDiffCmd)
+}
+
+type dependencyDiffCheck struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+type dependencyDiffResult struct {
+	ChangeType       string                `json:"changeType"`
+	Ecosystem        string                `json:"ecosystem"`
+	Manifest         string                `json:"manifest"`
+	Name             string                `json:"name"`
+	Version          string                `json:"version,omitempty"`
+	PreviousVersion  string                `json:"previousVersion,omitempty"`
+	SourceRepository string                `json:"sourceRepository,omitempty"`
+	Score            *float64              `json:"score,omitempty"`
+	Checks           []dependencyDiffCheck `json:"checks,omitempty"`
+	Regression       bool                  `json:"regression"`
+}
+
+var dependencyDiffCmd = &cobra.Command{
+	Use:   "dependencydiff --repo=<repo_url> --base=<ref> --head=<ref>",
+	Short: "Run checks on the dependencies changed between two refs",
+	Long: `Run checks on the dependencies added or updated between two refs of a GitHub
+repository, as reported by the GitHub dependency review API, and report the ones
+scoring below --min-score. Exits with status 4 if any regression is found.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if diffRepo == "" || diffBase == "" || diffHead == "" {
+			log.Fatal("--repo, --base and --head are required")
+		}
+		logger, err := githubrepo.NewLogger(*logLevel)
+		if err != nil {
+			log.Fatalf("unable to construct logger: %v", err)
+		}
+		//nolint:errcheck
+		defer logger.Sync() // flushes buffer, if any
+
+		checkDocs, err := docs.Read()
+		if err != nil {
+			log.Fatalf("cannot read yaml file: %v", err)
+		}
+		supportedChecks, err := getSupportedChecks(repoTypeGitHub, checkDocs)
+		if err != nil {
+			log.Fatalf("cannot read supported checks: %v", err)
+		}
+		enabledChecks, err := getEnabledChecks(nil, diffChecks, checkDocs, supportedChecks, repoTypeGitHub)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		results, err := dependencydiff.GetDependencyDiffResults(context.Background(),
+			diffRepo, diffBase, diffHead, enabledChecks, diffMinScore, logger)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		report := make([]dependencyDiffResult, 0, len(results))
+		regression := false
+		for i := range results {
+			r := &results[i]
+			out := dependencyDiffResult{
+				ChangeType:       string(r.ChangeType),
+				Ecosystem:        r.Ecosystem,
+				Manifest:         r.Manifest,
+				Name:             r.Name,
+				Version:          r.Version,
+				PreviousVersion:  r.PreviousVersion,
+				SourceRepository: r.SourceRepository,
+				Regression:       r.Regression,
+			}
+			if r.ScorecardResult != nil {
+				score := r.AggregateScore
+				out.Score = &score
+				for _, c := range r.ScorecardResult.Checks {
+					out.Checks = append(out.Checks, dependencyDiffCheck{Name: c.Name, Score: c.Score})
+				}
+			}
+			regression = regression || r.Regression
+			report = append(report, out)
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("encoding report: %v", err)
+		}
+		if regression {
+			fmt.Fprintln(os.Stderr, "dependency changes introduce score regressions")
+			os.Exit(exitRegression)
+		}
+	},
+}
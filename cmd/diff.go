@@ -0,0 +1,109 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+var diffFailOnRegression bool
+
+//nolint:gochecknoinits
+func init() {
+	diffCmd.Flags().BoolVar(&diffFailOnRegression, "fail-on-regression", false,
+		"exit with status 4 if any check score went down")
+	diffCmd.Flags().StringVar(&annotationsFile, "annotations", "",
+		"file storing triage annotations, see `scorecard annotate`. Triaged findings are not reported as new")
+	rootCmd.AddCommand(diffCmd)
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff old.json new.json",
+	Short: "Compare two JSON results of the same repository",
+	Long: `Compare two results written with --format=json and list the checks whose
+score improved, regressed or whose reason changed, to track drift between scans.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		oldFile, err := os.Open(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer oldFile.Close()
+		newFile, err := os.Open(args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer newFile.Close()
+
+		diff, err := pkg.DiffJSONResults(oldFile, newFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		annotations, err := storedAnnotations(diff.Repo)
+		if err != nil {
+			log.Fatal(err)
+		}
+		diff.ApplyAnnotations(annotations)
+		if err := diff.AsString(os.Stdout); err != nil {
+			log.Fatalf("Failed to output diff: %v", err)
+		}
+		if diffFailOnRegression && diff.HasRegressions() {
+			fmt.Fprintln(os.Stderr, "some check scores regressed")
+			os.Exit(exitRegression)
+		}
+	},
+}
+
+// compareToBaseline writes how the checks of repoResult changed versus the JSON
+// results in baselineFile to w, and returns whether any check score went down.
+func compareToBaseline(repoResult *pkg.ScorecardResult, checkDocs docs.Doc, baselineFile string,
+	w io.Writer) (bool, error) {
+	baseline, err := os.Open(baselineFile)
+	if err != nil {
+		return false, fmt.Errorf("cannot open baseline: %w", err)
+	}
+	defer baseline.Close()
+
+	var current bytes.Buffer
+	// With the details, to list the findings the baseline did not report.
+	if err := repoResult.AsJSON2(true /*showDetails*/, *logLevel, checkDocs, &current); err != nil {
+		return false, fmt.Errorf("cannot encode results: %w", err)
+	}
+	diff, err := pkg.DiffJSONResults(baseline, &current)
+	if err != nil {
+		return false, fmt.Errorf("cannot compare results: %w", err)
+	}
+	annotations, err := storedAnnotations(diff.Repo)
+	if err != nil {
+		return false, err
+	}
+	diff.ApplyAnnotations(annotations)
+	if _, err := fmt.Fprintln(w, "\nCHANGES VERSUS BASELINE\n-----------------------"); err != nil {
+		return false, fmt.Errorf("cannot write diff: %w", err)
+	}
+	if err := diff.AsString(w); err != nil {
+		return false, fmt.Errorf("cannot write diff: %w", err)
+	}
+	return diff.HasRegressions(), nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ossf/scorecard/v3/checker"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+func TestCompareToBaseline(t *testing.T) {
+	t.Parallel()
+	checkDocs, err := docs.Read()
+	if err != nil {
+		t.Fatalf("docs.Read: %v", err)
+	}
+	baselineFile := filepath.Join(t.TempDir(), "baseline.json")
+	baseline := `{
+  "repo": {"name": "github.com/owner/repo", "commit": "1111111"},
+  "checks": [
+    {"name": "Code-Review", "score": 8, "reason": "8 out of 10 commits reviewed"},
+    {"name": "Fuzzing", "score": 0, "reason": "project is not fuzzed"}
+  ]
+}`
+	if err := os.WriteFile(baselineFile, []byte(baseline), 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		codeReview    int
+		wantRegressed bool
+	}{
+		{
+			name:       "existing debt",
+			codeReview: 8,
+		},
+		{
+			name:          "new debt",
+			codeReview:    5,
+			wantRegressed: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := &pkg.ScorecardResult{
+				Repo: pkg.RepoInfo{Name: "github.com/owner/repo", CommitSHA: "2222222"},
+				Checks: []checker.CheckResult{
+					{Name: "Code-Review", Score: tt.codeReview, Reason: "8 out of 10 commits reviewed"},
+					{Name: "Fuzzing", Score: 0, Reason: "project is not fuzzed"},
+				},
+			}
+			var out bytes.Buffer
+			regressed, err := compareToBaseline(result, checkDocs, baselineFile, &out)
+			if err != nil {
+				t.Fatalf("compareToBaseline: %v", err)
+			}
+			if regressed != tt.wantRegressed {
+				t.Errorf("compareToBaseline() = %v, want %v", regressed, tt.wantRegressed)
+			}
+			if got := strings.Contains(out.String(), "regressed Code-Review"); got != tt.wantRegressed {
+				t.Errorf("regression listed = %v, want %v:\n%s", got, tt.wantRegressed, out.String())
+			}
+		})
+	}
+
+	if _, err := compareToBaseline(&pkg.ScorecardResult{}, checkDocs,
+		filepath.Join(t.TempDir(), "missing.json"), &bytes.Buffer{}); err == nil {
+		t.Error("compareToBaseline() with a missing baseline succeeded, want error")
+	}
+}
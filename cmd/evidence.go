@@ -0,0 +1,309 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/githubrepo/roundtripper"
+	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+// evidenceManifest is the file of an evidence bundle describing the scan it was recorded for.
+const evidenceManifest = "manifest.json"
+
+// evidenceBundle is the manifest of a directory of recorded API interactions
+// from which a scan is replayed to reproduce its results byte for byte.
+type evidenceBundle struct {
+	ScanTime       time.Time               `json:"scanTime"`
+	Repo           string                  `json:"repo"`
+	Commit         string                  `json:"commit"`
+	ResolvedCommit string                  `json:"resolvedCommit,omitempty"`
+	Scorecard      evidenceScorecard       `json:"scorecard"`
+	Checks         []string                `json:"checks,omitempty"`
+	Evidence       []roundtripper.Evidence `json:"evidence"`
+}
+
+type evidenceScorecard struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+}
+
+func currentScorecard() evidenceScorecard {
+	return evidenceScorecard{Version: pkg.GetTagVersion(), Commit: pkg.GetCommit()}
+}
+
+// setupEvidence records the scan's API interactions to --record-evidence,
+// or replays the scan recorded in --replay-evidence, setting its repo, commit
+// and checks. Either way, the returned bundle pins the time of the scan.
+func setupEvidence(repoList []string) (*evidenceBundle, error) {
+	if recordEvidenceDir != "" && replayEvidenceDir != "" {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			"--record-evidence cannot be used with --replay-evidence")
+	}
+	if org != "" || local != "" || npm != "" || pypi != "" || rubygems != "" || image != "" || len(repoList) > 1 {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			"--record-evidence and --replay-evidence only support scoring a single --repo")
+	}
+
+	if recordEvidenceDir != "" {
+		if len(repoList) == 0 {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, "--record-evidence requires --repo")
+		}
+		if err := os.MkdirAll(recordEvidenceDir, 0o755); err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.MkdirAll: %v", err))
+		}
+		if err := roundtripper.UseCassette(recordEvidenceDir, false); err != nil {
+			return nil, fmt.Errorf("recording evidence: %w", err)
+		}
+		return &evidenceBundle{
+			ScanTime:  time.Now().UTC(),
+			Repo:      repoList[0],
+			Commit:    commitSHA,
+			Scorecard: currentScorecard(),
+		}, nil
+	}
+
+	bundle, err := readEvidenceManifest(replayEvidenceDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := bundle.checkReplayArgs(repoList, commitSHA); err != nil {
+		return nil, err
+	}
+	if bundle.Scorecard != currentScorecard() {
+		fmt.Fprintf(os.Stderr, "warning: the evidence was recorded by Scorecard %s (%s), results may differ\n",
+			bundle.Scorecard.Version, bundle.Scorecard.Commit)
+	}
+	if err := roundtripper.UseCassette(replayEvidenceDir, true); err != nil {
+		return nil, fmt.Errorf("replaying evidence: %w", err)
+	}
+	commitSHA = bundle.Commit
+	if len(checksToRun) == 0 {
+		checksToRun = bundle.Checks
+	}
+	return bundle, nil
+}
+
+// checkReplayArgs returns an error if the repo or commit set by flags differ
+// from the ones the bundle was recorded for.
+func (b *evidenceBundle) checkReplayArgs(repoList []string, commit string) error {
+	if len(repoList) == 1 && repoList[0] != b.Repo {
+		return sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("the evidence was recorded for --repo=%s, not %s", b.Repo, repoList[0]))
+	}
+	if commit != clients.HeadSHA && commit != b.Commit {
+		return sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("the evidence was recorded for --commit=%s, not %s", b.Commit, commit))
+	}
+	return nil
+}
+
+// recordChecks sets the checks the bundle is recorded for.
+func (b *evidenceBundle) recordChecks(enabledChecks checker.CheckNameToFnMap) {
+	b.Checks = make([]string, 0, len(enabledChecks))
+	for checkName := range enabledChecks {
+		b.Checks = append(b.Checks, checkName)
+	}
+	sort.Strings(b.Checks)
+}
+
+// checkReplayChecks returns an error if some of the enabled checks were not
+// recorded. Replaying a subset of the checks helps debugging them, but only
+// replaying all of them reproduces the results.
+func (b *evidenceBundle) checkReplayChecks(enabledChecks checker.CheckNameToFnMap) error {
+	for checkName := range enabledChecks {
+		if !isSupportedCheck(b.Checks, checkName) {
+			return sce.WithMessage(sce.ErrScorecardInternal,
+				fmt.Sprintf("the evidence was recorded for --checks=%s, not %s", strings.Join(b.Checks, ","), checkName))
+		}
+	}
+	return nil
+}
+
+func readEvidenceManifest(dir string) (*evidenceBundle, error) {
+	content, err := os.ReadFile(filepath.Join(dir, evidenceManifest))
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.ReadFile: %v", err))
+	}
+	var bundle evidenceBundle
+	if err := json.Unmarshal(content, &bundle); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Unmarshal: %v", err))
+	}
+	if bundle.Repo == "" || bundle.ScanTime.IsZero() {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("%s is not an evidence manifest", filepath.Join(dir, evidenceManifest)))
+	}
+	return &bundle, nil
+}
+
+// writeEvidenceManifest lists the interactions recorded in dir in its manifest,
+// with the timestamps and ETags of the data the APIs served.
+func writeEvidenceManifest(dir string, bundle *evidenceBundle) error {
+	evidence, err := roundtripper.ListEvidence(dir)
+	if err != nil {
+		return fmt.Errorf("listing evidence: %w", err)
+	}
+	bundle.Evidence = evidence
+	content, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.MarshalIndent: %v", err))
+	}
+	if err := os.WriteFile(filepath.Join(dir, evidenceManifest), append(content, '\n'), 0o600); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.WriteFile: %v", err))
+	}
+	return nil
+}
+
+// openEvidenceArchives sets up --export-evidence to record the scan's evidence
+// to a temporary directory, unless --record-evidence is set, and extracts
+// --from-evidence to a temporary directory to replay it from. The returned
+// function removes the temporary directories.
+func openEvidenceArchives() (func(), error) {
+	if exportEvidence != "" && (replayEvidenceDir != "" || fromEvidence != "") {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			"--export-evidence cannot be used with --replay-evidence or --from-evidence")
+	}
+	if fromEvidence != "" && replayEvidenceDir != "" {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, "--from-evidence cannot be used with --replay-evidence")
+	}
+	cleanup := func() {}
+	if (exportEvidence != "" && recordEvidenceDir == "") || fromEvidence != "" {
+		dir, err := os.MkdirTemp("", "scorecard-evidence")
+		if err != nil {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.MkdirTemp: %v", err))
+		}
+		cleanup = func() { os.RemoveAll(dir) }
+		if fromEvidence != "" {
+			if err := readEvidenceArchive(fromEvidence, dir); err != nil {
+				cleanup()
+				return nil, err
+			}
+			replayEvidenceDir = dir
+		} else {
+			recordEvidenceDir = dir
+		}
+	}
+	return cleanup, nil
+}
+
+// writeEvidenceArchive archives the evidence bundle in dir to a zstd-compressed tarball at path.
+func writeEvidenceArchive(dir, path string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.ReadDir: %v", err))
+	}
+	out, err := os.Create(path)
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.Create: %v", err))
+	}
+	defer out.Close()
+	zw, err := zstd.NewWriter(out)
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("zstd.NewWriter: %v", err))
+	}
+	tw := tar.NewWriter(zw)
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.ReadFile: %v", err))
+		}
+		// Archives of the same evidence are identical.
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     e.Name(),
+			Mode:     0o600,
+			Size:     int64(len(content)),
+			ModTime:  time.Unix(0, 0),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("tar.WriteHeader: %v", err))
+		}
+		if _, err := tw.Write(content); err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("tar.Write: %v", err))
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("tar.Close: %v", err))
+	}
+	if err := zw.Close(); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("zstd.Close: %v", err))
+	}
+	if err := out.Close(); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.Close: %v", err))
+	}
+	return nil
+}
+
+// readEvidenceArchive extracts the evidence bundle archived at path to dir.
+func readEvidenceArchive(path, dir string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.Open: %v", err))
+	}
+	defer in.Close()
+	zr, err := zstd.NewReader(in)
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("zstd.NewReader: %v", err))
+	}
+	defer zr.Close()
+	tr := tar.NewReader(zr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s is not an evidence archive: %v", path, err))
+		}
+		// Evidence bundles are flat directories.
+		if header.Typeflag != tar.TypeReg || filepath.Base(header.Name) != header.Name || header.Name == ".." {
+			return sce.WithMessage(sce.ErrScorecardInternal,
+				fmt.Sprintf("%s: unexpected entry %q in evidence archive", path, header.Name))
+		}
+		out, err := os.OpenFile(filepath.Join(dir, header.Name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.OpenFile: %v", err))
+		}
+		// nolint: gosec
+		// Evidence archives are trusted like the binary replaying them.
+		_, err = io.Copy(out, tr)
+		out.Close()
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s is not an evidence archive: %v", path, err))
+		}
+	}
+	if _, err := readEvidenceManifest(dir); err != nil {
+		return err
+	}
+	return nil
+}
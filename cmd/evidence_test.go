@@ -0,0 +1,245 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"archive/tar"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/githubrepo/roundtripper"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+func TestEvidenceManifest(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	bundle := &evidenceBundle{
+		ScanTime:  time.Date(2023, 1, 2, 15, 4, 5, 6, time.UTC),
+		Repo:      "github.com/owner/repo",
+		Commit:    clients.HeadSHA,
+		Scorecard: evidenceScorecard{Version: "v1.2.3", Commit: "abc"},
+		Checks:    []string{"Code-Review", "Maintained"},
+	}
+	if err := writeEvidenceManifest(dir, bundle); err != nil {
+		t.Fatalf("writeEvidenceManifest: %v", err)
+	}
+	got, err := readEvidenceManifest(dir)
+	if err != nil {
+		t.Fatalf("readEvidenceManifest: %v", err)
+	}
+	want := *bundle
+	want.Evidence = []roundtripper.Evidence{}
+	if diff := cmp.Diff(&want, got); diff != "" {
+		t.Errorf("manifest mismatch (-want +got):\n%s", diff)
+	}
+
+	// A directory without a manifest is not an evidence bundle.
+	if err := os.WriteFile(filepath.Join(dir, evidenceManifest), []byte(`{}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readEvidenceManifest(dir); !errors.Is(err, sce.ErrScorecardInternal) {
+		t.Errorf("readEvidenceManifest() error = %v, want %v", err, sce.ErrScorecardInternal)
+	}
+	if _, err := readEvidenceManifest(t.TempDir()); !errors.Is(err, sce.ErrScorecardInternal) {
+		t.Errorf("readEvidenceManifest() error = %v, want %v", err, sce.ErrScorecardInternal)
+	}
+}
+
+func TestCheckReplayArgs(t *testing.T) {
+	t.Parallel()
+	bundle := &evidenceBundle{
+		Repo:   "github.com/owner/repo",
+		Commit: "abc",
+	}
+	tests := []struct {
+		name    string
+		repos   []string
+		commit  string
+		wantErr bool
+	}{
+		{
+			name:   "defaults",
+			commit: clients.HeadSHA,
+		},
+		{
+			name:   "same args",
+			repos:  []string{"github.com/owner/repo"},
+			commit: "abc",
+		},
+		{
+			name:    "other repo",
+			repos:   []string{"github.com/owner/other"},
+			commit:  clients.HeadSHA,
+			wantErr: true,
+		},
+		{
+			name:    "other commit",
+			commit:  "def",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := bundle.checkReplayArgs(tt.repos, tt.commit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkReplayArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckReplayChecks(t *testing.T) {
+	t.Parallel()
+	bundle := &evidenceBundle{}
+	bundle.recordChecks(checker.CheckNameToFnMap{"Maintained": nil, "Code-Review": nil})
+	if diff := cmp.Diff([]string{"Code-Review", "Maintained"}, bundle.Checks); diff != "" {
+		t.Errorf("recorded checks mismatch (-want +got):\n%s", diff)
+	}
+	tests := []struct {
+		checks  checker.CheckNameToFnMap
+		name    string
+		wantErr bool
+	}{
+		{
+			name:   "recorded checks",
+			checks: checker.CheckNameToFnMap{"Maintained": nil, "Code-Review": nil},
+		},
+		{
+			name:   "subset",
+			checks: checker.CheckNameToFnMap{"Maintained": nil},
+		},
+		{
+			name:    "unrecorded check",
+			checks:  checker.CheckNameToFnMap{"Maintained": nil, "Vulnerabilities": nil},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := bundle.checkReplayChecks(tt.checks)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkReplayChecks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvidenceArchive(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	if err := writeEvidenceManifest(dir, &evidenceBundle{
+		ScanTime: time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC),
+		Repo:     "github.com/owner/repo",
+		Commit:   clients.HeadSHA,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "0123456789abcdef-0.json"), []byte(`{"url": "x"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "bundle.tar.zst")
+	if err := writeEvidenceArchive(dir, archive); err != nil {
+		t.Fatalf("writeEvidenceArchive: %v", err)
+	}
+	extracted := t.TempDir()
+	if err := readEvidenceArchive(archive, extracted); err != nil {
+		t.Fatalf("readEvidenceArchive: %v", err)
+	}
+	for _, name := range []string{evidenceManifest, "0123456789abcdef-0.json"} {
+		want, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(filepath.Join(extracted, name))
+		if err != nil {
+			t.Fatalf("extracted %s: %v", name, err)
+		}
+		if diff := cmp.Diff(string(want), string(got)); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", name, diff)
+		}
+	}
+
+	// Archives of the same evidence are identical.
+	again := filepath.Join(t.TempDir(), "bundle.tar.zst")
+	if err := writeEvidenceArchive(extracted, again); err != nil {
+		t.Fatalf("writeEvidenceArchive: %v", err)
+	}
+	want, err := os.ReadFile(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(again)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(want) != string(got) {
+		t.Error("archiving the same evidence twice gave different archives")
+	}
+}
+
+func TestReadEvidenceArchiveRejectsPaths(t *testing.T) {
+	t.Parallel()
+	archive := filepath.Join(t.TempDir(), "bundle.tar.zst")
+	out, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw, err := zstd.NewWriter(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := tar.NewWriter(zw)
+	content := []byte("{}")
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg, Name: "../escaped.json", Mode: 0o600, Size: int64(len(content)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	out.Close()
+
+	dir := filepath.Join(t.TempDir(), "bundle")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := readEvidenceArchive(archive, dir); !errors.Is(err, sce.ErrScorecardInternal) {
+		t.Errorf("readEvidenceArchive() error = %v, want %v", err, sce.ErrScorecardInternal)
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "escaped.json")); !os.IsNotExist(err) {
+		t.Errorf("archive entry escaped the bundle directory: %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients/scorecardapi"
+)
+
+var (
+	fetchRepo   string
+	fetchCommit string
+	fetchAPIURL string
+	fetchFormat string
+)
+
+//nolint:gochecknoinits
+func init() {
+	fetchCmd.Flags().StringVar(&fetchRepo, "repo", "", "repository to fetch the results of, e.g. github.com/owner/repo")
+	fetchCmd.Flags().StringVar(&fetchCommit, "commit", "", "commit SHA the results must be about")
+	fetchCmd.Flags().StringVar(&fetchAPIURL, "api-url", scorecardapi.DefaultURL, "URL of the Scorecard API")
+	fetchCmd.Flags().StringVar(&fetchFormat, "format", formatDefault,
+		"output format allowed values are [default, json]")
+	rootCmd.AddCommand(fetchCmd)
+}
+
+var fetchCmd = &cobra.Command{
+	Use:   "fetch --repo=<repo>",
+	Short: "Fetch the published results of a repository",
+	Long: `Fetch the results of a repository published to the Scorecard API, instead of
+scoring it again. The latest results are fetched, unless --commit is set, as
+the API serves no results for past dates. With --format=json, the results are
+written as published, so they can be compared with scorecard diff or passed to
+--baseline.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if fetchRepo == "" {
+			log.Fatal("--repo is required")
+		}
+		if fetchFormat != formatDefault && fetchFormat != formatJSON {
+			log.Fatalf("unsupported format: %s", fetchFormat)
+		}
+		client := scorecardapi.NewClient(fetchAPIURL)
+		result, err := client.GetResult(context.Background(), fetchRepo,
+			scorecardapi.Options{Commit: fetchCommit})
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := writeFetchedResult(result, fetchFormat, os.Stdout); err != nil {
+			log.Fatalf("failed to output results: %v", err)
+		}
+	},
+}
+
+func writeFetchedResult(result *scorecardapi.Result, format string, w io.Writer) error {
+	if format == formatJSON {
+		if _, err := fmt.Fprintln(w, string(bytes.TrimSpace(result.Raw))); err != nil {
+			return fmt.Errorf("writing results: %w", err)
+		}
+		return nil
+	}
+	commit := result.Repo.Commit
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	fmt.Fprintf(w, "RESULTS: %s at %s, published %s\n-------\n", result.Repo.Name, commit, result.Date)
+	fmt.Fprintf(w, "Aggregate score: %s / %d\n", scoreToString(result.Score), checker.MaxResultScore)
+	for _, c := range result.Checks {
+		score := "?"
+		if c.Score != checker.InconclusiveResultScore {
+			score = fmt.Sprint(c.Score)
+		}
+		fmt.Fprintf(w, "  %-2s  %s: %s\n", score, c.Name, c.Reason)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/clients/scorecardapi"
+)
+
+func TestWriteFetchedResult(t *testing.T) {
+	t.Parallel()
+	result := &scorecardapi.Result{
+		Date:  "2023-01-10",
+		Repo:  scorecardapi.Repo{Name: "github.com/owner/repo", Commit: "0123456789"},
+		Score: 7.5,
+		Checks: []scorecardapi.Check{
+			{Name: "Code-Review", Score: 8, Reason: "8 out of 10 commits reviewed"},
+			{Name: "Fuzzing", Score: -1, Reason: "internal error"},
+		},
+		Raw: []byte(`{"repo": {"name": "github.com/owner/repo"}}` + "\n"),
+	}
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{
+			name:   "default",
+			format: formatDefault,
+			want: `RESULTS: github.com/owner/repo at 0123456, published 2023-01-10
+-------
+Aggregate score: 7.5 / 10
+  8   Code-Review: 8 out of 10 commits reviewed
+  ?   Fuzzing: internal error
+`,
+		},
+		{
+			name:   "json",
+			format: formatJSON,
+			want:   `{"repo": {"name": "github.com/owner/repo"}}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got bytes.Buffer
+			if err := writeFetchedResult(result, tt.format, &got); err != nil {
+				t.Fatalf("writeFetchedResult: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got.String()); diff != "" {
+				t.Errorf("writeFetchedResult() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/githubrepo"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+const historyDateLayout = "2006-01-02"
+
+var (
+	historyRepo     string
+	historySince    string
+	historyUntil    string
+	historyInterval string
+	historyChecks   []string
+	historyFormat   string
+)
+
+//nolint:gochecknoinits
+func init() {
+	historyCmd.Flags().StringVar(&historyRepo, "repo", "", "GitHub repository to score, e.g. github.com/owner/repo")
+	historyCmd.Flags().StringVar(&historySince, "since", "", "date of the first interval, as YYYY-MM-DD")
+	historyCmd.Flags().StringVar(&historyUntil, "until", "", "date after which to stop, as YYYY-MM-DD, today by default")
+	historyCmd.Flags().StringVar(&historyInterval, "interval", "month", "length of the intervals: day, week or month")
+	historyCmd.Flags().StringSliceVar(&historyChecks, "checks", []string{},
+		"checks to run at each interval, all checks supporting GitHub repos by default")
+	historyCmd.Flags().StringVar(&historyFormat, "format", formatDefault,
+		"output format allowed values are [default, json, csv]")
+	rootCmd.AddCommand(historyCmd)
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history --repo=<repo> --since=<date>",
+	Short: "Score a repository over time",
+	Long: `Score the latest commit of the default branch of a GitHub repository at the
+end of each interval since a date, and write the scores as a time series.
+Checks based on the repository settings, such as Branch-Protection, reflect
+their current state at every interval.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if historyRepo == "" || historySince == "" {
+			log.Fatal("--repo and --since are required")
+		}
+		if historyFormat != formatDefault && historyFormat != formatJSON && historyFormat != formatCSV {
+			log.Fatalf("unsupported format: %s", historyFormat)
+		}
+		since, err := time.Parse(historyDateLayout, historySince)
+		if err != nil {
+			log.Fatalf("invalid --since: %v", err)
+		}
+		until := time.Now().UTC()
+		if historyUntil != "" {
+			if until, err = time.Parse(historyDateLayout, historyUntil); err != nil {
+				log.Fatalf("invalid --until: %v", err)
+			}
+		}
+		starts, ends, err := historyIntervals(since, until, historyInterval)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		logger, err := githubrepo.NewLogger(*logLevel)
+		if err != nil {
+			log.Fatalf("unable to construct logger: %v", err)
+		}
+		//nolint:errcheck
+		defer logger.Sync() // flushes buffer, if any
+
+		ctx := context.Background()
+		h, err := scoreHistory(ctx, logger, starts, ends)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := h.write(historyFormat, os.Stdout); err != nil {
+			log.Fatalf("failed to output history: %v", err)
+		}
+	},
+}
+
+type historyCheck struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+// historyPoint is the result of the interval starting on Date,
+// for the latest commit at its end.
+type historyPoint struct {
+	Date   string         `json:"date"`
+	Commit string         `json:"commit"`
+	Score  float64        `json:"score"`
+	Checks []historyCheck `json:"checks"`
+	Error  string         `json:"error,omitempty"`
+}
+
+type history struct {
+	Repo   string         `json:"repo"`
+	Points []historyPoint `json:"points"`
+}
+
+// historyIntervals splits the time from since to until in intervals of
+// a day, week or month, and returns when each one starts and ends.
+// The last interval ends at until.
+func historyIntervals(since, until time.Time, interval string) (starts, ends []time.Time, err error) {
+	var step func(t time.Time, n int) time.Time
+	switch interval {
+	case "day":
+		step = func(t time.Time, n int) time.Time { return t.AddDate(0, 0, n) }
+	case "week":
+		step = func(t time.Time, n int) time.Time { return t.AddDate(0, 0, 7*n) }
+	case "month":
+		step = func(t time.Time, n int) time.Time {
+			// Past the end of a shorter month, use its last day instead of overflowing.
+			ret := t.AddDate(0, n, 0)
+			if ret.Day() != t.Day() {
+				ret = ret.AddDate(0, 0, -ret.Day())
+			}
+			return ret
+		}
+	default:
+		return nil, nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("invalid interval: %s, expected day, week or month", interval))
+	}
+	if !since.Before(until) {
+		return nil, nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("%s is not before %s", since.Format(historyDateLayout), until.Format(historyDateLayout)))
+	}
+	// Steps are computed from since, so that months keep the same day.
+	for i := 0; step(since, i).Before(until); i++ {
+		end := step(since, i+1)
+		if end.After(until) {
+			end = until
+		}
+		starts = append(starts, step(since, i))
+		ends = append(ends, end)
+	}
+	return starts, ends, nil
+}
+
+func scoreHistory(ctx context.Context, logger *zap.Logger, starts, ends []time.Time) (*history, error) {
+	repoURI, err := githubrepo.MakeGithubRepo(historyRepo)
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	commits, err := githubrepo.ListCommitsAt(ctx, logger, repoURI, ends)
+	if err != nil {
+		return nil, fmt.Errorf("listing commits: %w", err)
+	}
+
+	checkDocs, err := docs.Read()
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("docs.Read: %v", err))
+	}
+	supportedChecks, err := getSupportedChecks(repoTypeGitHub, checkDocs)
+	if err != nil {
+		return nil, err
+	}
+	enabledChecks, err := getEnabledChecks(nil, historyChecks, checkDocs, supportedChecks, repoTypeGitHub)
+	if err != nil {
+		return nil, err
+	}
+	ossFuzzRepoClient, err := githubrepo.CreateOssFuzzRepoClient(ctx, logger)
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	defer ossFuzzRepoClient.Close()
+
+	score := func(sha string, at time.Time) (*pkg.ScorecardResult, error) {
+		fmt.Fprintf(os.Stderr, "Scoring [%s] at %s\n", repoURI.URI(), sha)
+		// Score as of the end of the interval, e.g. for Maintained to look
+		// at the activity before it rather than before today.
+		scanCtx := clients.WithScanTime(ctx, at)
+		repoClient := githubrepo.CreateGithubRepoClient(scanCtx, logger)
+		defer repoClient.Close()
+		repoResult, err := pkg.RunScorecards(scanCtx, repoURI, sha, false, enabledChecks,
+			repoClient, ossFuzzRepoClient, clients.DefaultCIIBestPracticesClient(),
+			clients.DefaultVulnerabilitiesClient(), clients.DefaultPackagesClient())
+		if err != nil {
+			//nolint:wrapcheck
+			return nil, err
+		}
+		return &repoResult, nil
+	}
+	return buildHistory(repoURI.URI(), starts, ends, commits, score, checkDocs, logger)
+}
+
+// buildHistory scores the commit of each interval as of the end of the
+// interval. A commit spanning several intervals is scored for each of them,
+// as scores based on dates, such as Maintained, change over time. Intervals
+// before the first commit are skipped.
+func buildHistory(repo string, starts, ends []time.Time, commits []string,
+	score func(sha string, at time.Time) (*pkg.ScorecardResult, error), checkDocs docs.Doc,
+	logger *zap.Logger) (*history, error) {
+	h := history{Repo: repo, Points: []historyPoint{}}
+	for i, sha := range commits {
+		if sha == "" {
+			continue
+		}
+		point := historyPoint{Date: starts[i].Format(historyDateLayout), Commit: sha, Checks: []historyCheck{}}
+		repoResult, err := score(sha, ends[i])
+		if err != nil {
+			// Keep going: one unscorable commit should not fail the whole series.
+			logger.Warn(fmt.Sprintf("scoring %s: %v", sha, err))
+			point.Score = checker.InconclusiveResultScore
+			point.Error = err.Error()
+		} else {
+			if point.Score, err = repoResult.GetAggregateScore(checkDocs); err != nil {
+				return nil, fmt.Errorf("GetAggregateScore: %w", err)
+			}
+			for j := range repoResult.Checks {
+				point.Checks = append(point.Checks, historyCheck{
+					Name:  repoResult.Checks[j].Name,
+					Score: repoResult.Checks[j].Score,
+				})
+			}
+		}
+		h.Points = append(h.Points, point)
+	}
+	return &h, nil
+}
+
+func (h *history) write(format string, w io.Writer) error {
+	switch format {
+	case formatJSON:
+		if err := json.NewEncoder(w).Encode(h); err != nil {
+			return fmt.Errorf("encoding history: %w", err)
+		}
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		rows := [][]string{{"date", "commit", "check", "score"}}
+		for _, p := range h.Points {
+			for _, c := range p.Checks {
+				rows = append(rows, []string{p.Date, p.Commit, c.Name, fmt.Sprintf("%d", c.Score)})
+			}
+			rows = append(rows, []string{p.Date, p.Commit, "Aggregate", fmt.Sprintf("%.1f", p.Score)})
+		}
+		if err := cw.WriteAll(rows); err != nil {
+			return fmt.Errorf("csv.WriteAll: %w", err)
+		}
+	default:
+		fmt.Fprintf(w, "HISTORY: %s\n-------\n", h.Repo)
+		for _, p := range h.Points {
+			commit := p.Commit
+			if len(commit) > 7 {
+				commit = commit[:7]
+			}
+			fmt.Fprintf(w, "  %s  %s  %s\n", p.Date, commit, scoreToString(p.Score))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/checker"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse(historyDateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestHistoryIntervals(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		since      string
+		until      string
+		interval   string
+		wantStarts []string
+		wantEnds   []string
+		wantErr    bool
+	}{
+		{
+			name:       "months",
+			since:      "2023-01-31",
+			until:      "2023-04-15",
+			interval:   "month",
+			wantStarts: []string{"2023-01-31", "2023-02-28", "2023-03-31"},
+			wantEnds:   []string{"2023-02-28", "2023-03-31", "2023-04-15"},
+		},
+		{
+			name:       "weeks",
+			since:      "2023-01-01",
+			until:      "2023-01-15",
+			interval:   "week",
+			wantStarts: []string{"2023-01-01", "2023-01-08"},
+			wantEnds:   []string{"2023-01-08", "2023-01-15"},
+		},
+		{
+			name:       "days",
+			since:      "2023-01-01",
+			until:      "2023-01-02",
+			interval:   "day",
+			wantStarts: []string{"2023-01-01"},
+			wantEnds:   []string{"2023-01-02"},
+		},
+		{
+			name:     "invalid interval",
+			since:    "2023-01-01",
+			until:    "2023-02-01",
+			interval: "year",
+			wantErr:  true,
+		},
+		{
+			name:     "since after until",
+			since:    "2023-02-01",
+			until:    "2023-01-01",
+			interval: "day",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			starts, ends, err := historyIntervals(day(tt.since), day(tt.until), tt.interval)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("historyIntervals() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			format := func(times []time.Time) []string {
+				var ret []string
+				for _, t := range times {
+					ret = append(ret, t.Format(historyDateLayout))
+				}
+				return ret
+			}
+			if diff := cmp.Diff(tt.wantStarts, format(starts)); diff != "" {
+				t.Errorf("starts mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantEnds, format(ends)); diff != "" {
+				t.Errorf("ends mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildHistory(t *testing.T) {
+	t.Parallel()
+	checkDocs, err := docs.Read()
+	if err != nil {
+		t.Fatalf("docs.Read: %v", err)
+	}
+	starts := []time.Time{day("2023-01-01"), day("2023-02-01"), day("2023-03-01"), day("2023-04-01")}
+	ends := []time.Time{day("2023-02-01"), day("2023-03-01"), day("2023-04-01"), day("2023-04-15")}
+	commits := []string{"", "aaaaaaaaaa", "aaaaaaaaaa", "bbbbbbbbbb"}
+	var scored []string
+	score := func(sha string, at time.Time) (*pkg.ScorecardResult, error) {
+		scored = append(scored, sha+"@"+at.Format(historyDateLayout))
+		if sha == "bbbbbbbbbb" {
+			return nil, errors.New("no tarball")
+		}
+		// The score of a date-based check changes over time for the same commit.
+		score := 8
+		if at.After(day("2023-03-15")) {
+			score = 5
+		}
+		return &pkg.ScorecardResult{
+			Repo: pkg.RepoInfo{Name: "github.com/owner/repo", CommitSHA: sha},
+			Checks: []checker.CheckResult{
+				{Name: "Code-Review", Score: score},
+			},
+		}, nil
+	}
+
+	h, err := buildHistory("github.com/owner/repo", starts, ends, commits, score, checkDocs, zap.NewNop())
+	if err != nil {
+		t.Fatalf("buildHistory: %v", err)
+	}
+	wantScored := []string{"aaaaaaaaaa@2023-03-01", "aaaaaaaaaa@2023-04-01", "bbbbbbbbbb@2023-04-15"}
+	if diff := cmp.Diff(wantScored, scored); diff != "" {
+		t.Errorf("scored commits mismatch (-want +got):\n%s", diff)
+	}
+	want := &history{
+		Repo: "github.com/owner/repo",
+		Points: []historyPoint{
+			{Date: "2023-02-01", Commit: "aaaaaaaaaa", Score: 8, Checks: []historyCheck{{Name: "Code-Review", Score: 8}}},
+			{Date: "2023-03-01", Commit: "aaaaaaaaaa", Score: 5, Checks: []historyCheck{{Name: "Code-Review", Score: 5}}},
+			{
+				Date: "2023-04-01", Commit: "bbbbbbbbbb", Score: checker.InconclusiveResultScore,
+				Checks: []historyCheck{}, Error: "no tarball",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, h); diff != "" {
+		t.Errorf("buildHistory() mismatch (-want +got):\n%s", diff)
+	}
+
+	var got bytes.Buffer
+	if err := h.write(formatDefault, &got); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	wantText := `HISTORY: github.com/owner/repo
+-------
+  2023-02-01  aaaaaaa  8.0
+  2023-03-01  aaaaaaa  5.0
+  2023-04-01  bbbbbbb  ?
+`
+	if diff := cmp.Diff(wantText, got.String()); diff != "" {
+		t.Errorf("write() mismatch (-want +got):\n%s", diff)
+	}
+
+	got.Reset()
+	if err := h.write(formatCSV, &got); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	wantCSV := `date,commit,check,score
+2023-02-01,aaaaaaaaaa,Code-Review,8
+2023-02-01,aaaaaaaaaa,Aggregate,8.0
+2023-03-01,aaaaaaaaaa,Code-Review,5
+2023-03-01,aaaaaaaaaa,Aggregate,5.0
+2023-04-01,bbbbbbbbbb,Aggregate,-1.0
+`
+	if diff := cmp.Diff(wantCSV, got.String()); diff != "" {
+		t.Errorf("write() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// The OCI annotations, also used as image labels, pointing to the source
+// of an image, see https://github.com/opencontainers/image-spec/blob/main/annotations.md.
+const (
+	imageSourceAnnotation   = "org.opencontainers.image.source"
+	imageRevisionAnnotation = "org.opencontainers.image.revision"
+)
+
+// resolveImage returns the source repo of a container image and the commit it
+// was built from, if known, read from the annotations of its manifest or else
+// from the labels of its config. Registries are accessed with the credentials
+// of the docker config, e.g. for private images.
+func resolveImage(image string, opts ...crane.Option) (repo, commit string, err error) {
+	manifest, err := crane.Manifest(image, opts...)
+	if err != nil {
+		return "", "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("crane.Manifest: %v", err))
+	}
+	// Both image manifests and indexes, e.g. of multi-platform images, have annotations.
+	var m struct {
+		Annotations map[string]string `json:"annotations"`
+	}
+	if err := json.Unmarshal(manifest, &m); err != nil {
+		return "", "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Unmarshal: %v", err))
+	}
+	source, commit := m.Annotations[imageSourceAnnotation], m.Annotations[imageRevisionAnnotation]
+
+	if source == "" {
+		config, err := crane.Config(image, opts...)
+		if err != nil {
+			return "", "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("crane.Config: %v", err))
+		}
+		var c v1.ConfigFile
+		if err := json.Unmarshal(config, &c); err != nil {
+			return "", "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Unmarshal: %v", err))
+		}
+		source, commit = c.Config.Labels[imageSourceAnnotation], c.Config.Labels[imageRevisionAnnotation]
+	}
+	if source == "" {
+		return "", "", sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("image %s has no %s annotation or label", image, imageSourceAnnotation))
+	}
+	repo, err = imageSourceRepo(source)
+	if err != nil {
+		return "", "", err
+	}
+	return repo, commit, nil
+}
+
+// imageSourceRepo turns the source URL of an image, e.g.
+// https://github.com/owner/repo.git, into a repo like github.com/owner/repo.
+func imageSourceRepo(source string) (string, error) {
+	if repo, ok := normalizeGitHubRepo(source); ok {
+		return repo, nil
+	}
+	u, err := url.Parse(strings.TrimPrefix(strings.TrimSpace(source), "git+"))
+	if err != nil || u.Host == "" || strings.Trim(u.Path, "/") == "" {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid image source: %s", source))
+	}
+	return u.Host + "/" + strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"), nil
+}
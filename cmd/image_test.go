@@ -0,0 +1,117 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+)
+
+func TestResolveImage(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	push := func(name string, labels, annotations map[string]string) string {
+		img, err := random.Image(1024, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := img.ConfigFile()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg.Config.Labels = labels
+		img, err = mutate.ConfigFile(img, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if annotations != nil {
+			//nolint:forcetypeassert // mutate.Annotations returns an image for an image.
+			img = mutate.Annotations(img, annotations).(v1.Image)
+		}
+		ref := fmt.Sprintf("%s/%s:latest", u.Host, name)
+		if err := crane.Push(img, ref); err != nil {
+			t.Fatalf("crane.Push: %v", err)
+		}
+		return ref
+	}
+
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		wantRepo    string
+		wantCommit  string
+		wantErr     bool
+	}{
+		{
+			name: "annotations",
+			annotations: map[string]string{
+				imageSourceAnnotation:   "https://github.com/owner/app",
+				imageRevisionAnnotation: "1111111111111111111111111111111111111111",
+			},
+			// Annotations take precedence over labels.
+			labels:     map[string]string{imageSourceAnnotation: "https://github.com/owner/other"},
+			wantRepo:   "github.com/owner/app",
+			wantCommit: "1111111111111111111111111111111111111111",
+		},
+		{
+			name: "labels",
+			labels: map[string]string{
+				imageSourceAnnotation:   "https://gitlab.com/owner/app.git",
+				imageRevisionAnnotation: "2222222222222222222222222222222222222222",
+			},
+			wantRepo:   "gitlab.com/owner/app",
+			wantCommit: "2222222222222222222222222222222222222222",
+		},
+		{
+			name:     "no revision",
+			labels:   map[string]string{imageSourceAnnotation: "https://github.com/owner/app.git"},
+			wantRepo: "github.com/owner/app",
+		},
+		{
+			name:    "no source",
+			labels:  map[string]string{"maintainer": "owner"},
+			wantErr: true,
+		},
+	}
+	for i, tt := range tests {
+		ref := push(fmt.Sprintf("app%d", i), tt.labels, tt.annotations)
+		repo, commit, err := resolveImage(ref)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("%s: resolveImage() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+		if repo != tt.wantRepo || commit != tt.wantCommit {
+			t.Errorf("%s: resolveImage() = %q, %q, want %q, %q", tt.name, repo, commit, tt.wantRepo, tt.wantCommit)
+		}
+	}
+
+	if _, _, err := resolveImage(u.Host + "/missing:latest"); err == nil {
+		t.Error("resolveImage() of a missing image succeeded")
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients/githubrepo"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/pkg"
+	spol "github.com/ossf/scorecard/v3/policy"
+)
+
+type orgRepoSummary struct {
+	Repo  string  `json:"repo"`
+	Score float64 `json:"score"`
+	Error string  `json:"error,omitempty"`
+}
+
+type orgSummary struct {
+	Org string `json:"org"`
+	// AverageScore is the average aggregate score of the repos scored successfully,
+	// leaving out those with no conclusive check.
+	AverageScore float64          `json:"averageScore"`
+	Repos        []orgRepoSummary `json:"repos"`
+}
+
+// parseOrg accepts an organization as github.com/org or org.
+func parseOrg(input string) (string, error) {
+	s := strings.TrimPrefix(strings.TrimPrefix(input, "https://"), "http://")
+	s = strings.Trim(strings.TrimPrefix(s, "github.com/"), "/")
+	if s == "" || strings.Contains(s, "/") {
+		return "", sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("invalid org: %s, expected github.com/<org>", input))
+	}
+	return s, nil
+}
+
+// filterRepos keeps the repos whose names match any of the include globs,
+// or all repos if there are none, and none of the exclude globs.
+func filterRepos(repos, includes, excludes []string) ([]string, error) {
+	matchAny := func(name string, globs []string) (bool, error) {
+		for _, g := range globs {
+			matched, err := path.Match(g, name)
+			if err != nil {
+				return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid glob %q: %v", g, err))
+			}
+			if matched {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	var ret []string
+	for _, r := range repos {
+		name := path.Base(r)
+		if len(includes) > 0 {
+			included, err := matchAny(name, includes)
+			if err != nil {
+				return nil, err
+			}
+			if !included {
+				continue
+			}
+		}
+		excluded, err := matchAny(name, excludes)
+		if err != nil {
+			return nil, err
+		}
+		if !excluded {
+			ret = append(ret, r)
+		}
+	}
+	return ret, nil
+}
+
+// scoreOrg runs the checks on each repo of an organization concurrently,
+// writing each result as it completes followed by an organization summary.
+// It returns the exit code of the run, that of the results with the most
+// severe one.
+func scoreOrg(ctx context.Context, logger *zap.Logger, orgInput string, policy *spol.ScorecardPolicy) (int, error) {
+	if format != formatDefault && format != formatJSON {
+		return exitOK, sce.WithMessage(sce.ErrScorecardInternal, "--org only supports the default and json formats")
+	}
+	orgName, err := parseOrg(orgInput)
+	if err != nil {
+		return exitOK, err
+	}
+	repos, err := githubrepo.ListOrgRepos(ctx, logger, orgName)
+	if err != nil {
+		return exitOK, fmt.Errorf("listing repos: %w", err)
+	}
+	repos, err = filterRepos(repos, orgIncludes, orgExcludes)
+	if err != nil {
+		return exitOK, err
+	}
+
+	checkDocs, err := docs.Read()
+	if err != nil {
+		return exitOK, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("docs.Read: %v", err))
+	}
+	// Each worker sets the summary of its repos only.
+	summary := orgSummary{Org: "github.com/" + orgName, Repos: make([]orgRepoSummary, len(repos))}
+	write := func(i int, repoResult *pkg.ScorecardResult) ([]byte, error) {
+		score, err := repoResult.GetAggregateScore(checkDocs)
+		if err != nil {
+			return nil, fmt.Errorf("GetAggregateScore: %w", err)
+		}
+		var out bytes.Buffer
+		if format == formatDefault {
+			fmt.Fprintf(&out, "\nRESULTS: %s\n-------\n", repos[i])
+		}
+		if err := writeResult(repoResult, checkDocs, policy, &out); err != nil {
+			return nil, fmt.Errorf("failed to output results: %w", err)
+		}
+		summary.Repos[i] = orgRepoSummary{Repo: repos[i], Score: score}
+		return out.Bytes(), nil
+	}
+	writeError := func(i int, uri string, err error) []byte {
+		summary.Repos[i] = orgRepoSummary{Repo: uri, Score: -1, Error: err.Error()}
+		return nil
+	}
+	code, err := scoreBatch(ctx, logger, repos, policy, checkDocs, write, writeError)
+	if err != nil {
+		return exitOK, err
+	}
+
+	summary.AverageScore = averageScore(summary.Repos)
+	if err := writeOrgSummary(&summary); err != nil {
+		return exitOK, err
+	}
+	return code, nil
+}
+
+// averageScore returns the average aggregate score of the repos which were
+// scored and had at least one conclusive check, or 0 if there are none.
+func averageScore(repos []orgRepoSummary) float64 {
+	sum, scored := 0.0, 0
+	for _, r := range repos {
+		if r.Error != "" || r.Score == checker.InconclusiveResultScore {
+			continue
+		}
+		sum += r.Score
+		scored++
+	}
+	if scored == 0 {
+		return 0
+	}
+	return sum / float64(scored)
+}
+
+func writeOrgSummary(summary *orgSummary) error {
+	if format == formatJSON {
+		if err := json.NewEncoder(os.Stdout).Encode(summary); err != nil {
+			return fmt.Errorf("encoding summary: %w", err)
+		}
+		return nil
+	}
+	fmt.Printf("\nSUMMARY: %s\n-------\n", summary.Org)
+	fmt.Printf("Average score: %s\n", scoreToString(summary.AverageScore))
+	for _, r := range summary.Repos {
+		if r.Error != "" {
+			fmt.Printf("  ?     %s: %s\n", r.Repo, r.Error)
+			continue
+		}
+		fmt.Printf("  %-4s  %s\n", scoreToString(r.Score), r.Repo)
+	}
+	return nil
+}
+
+func scoreToString(s float64) string {
+	if s == checker.InconclusiveResultScore {
+		return "?"
+	}
+	return fmt.Sprintf("%.1f", s)
+}
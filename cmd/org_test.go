@@ -0,0 +1,152 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/checker"
+)
+
+func TestParseOrg(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "github.com/myorg", want: "myorg"},
+		{input: "https://github.com/myorg/", want: "myorg"},
+		{input: "myorg", want: "myorg"},
+		{input: "github.com/myorg/repo", wantErr: true},
+		{input: "github.com/", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseOrg(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOrg(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseOrg(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterRepos(t *testing.T) {
+	t.Parallel()
+	repos := []string{
+		"github.com/myorg/api",
+		"github.com/myorg/api-docs",
+		"github.com/myorg/web",
+		"github.com/myorg/sandbox-test",
+	}
+	tests := []struct {
+		name     string
+		includes []string
+		excludes []string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name: "no filters",
+			want: repos,
+		},
+		{
+			name:     "include",
+			includes: []string{"api*"},
+			want:     []string{"github.com/myorg/api", "github.com/myorg/api-docs"},
+		},
+		{
+			name:     "exclude",
+			excludes: []string{"*-docs", "sandbox-*"},
+			want:     []string{"github.com/myorg/api", "github.com/myorg/web"},
+		},
+		{
+			name:     "include and exclude",
+			includes: []string{"api*", "web"},
+			excludes: []string{"*-docs"},
+			want:     []string{"github.com/myorg/api", "github.com/myorg/web"},
+		},
+		{
+			name:     "invalid glob",
+			includes: []string{"["},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := filterRepos(repos, tt.includes, tt.excludes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("filterRepos() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("filterRepos() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAverageScore(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		repos []orgRepoSummary
+		want  float64
+	}{
+		{
+			name: "no repos",
+		},
+		{
+			name: "scored repos",
+			repos: []orgRepoSummary{
+				{Repo: "github.com/o/a", Score: 4},
+				{Repo: "github.com/o/b", Score: 8},
+			},
+			want: 6,
+		},
+		{
+			name: "failed and inconclusive repos are skipped",
+			repos: []orgRepoSummary{
+				{Repo: "github.com/o/a", Score: 4},
+				{Repo: "github.com/o/b", Score: -1, Error: "not found"},
+				{Repo: "github.com/o/c", Score: checker.InconclusiveResultScore},
+			},
+			want: 4,
+		},
+		{
+			name: "only inconclusive repos",
+			repos: []orgRepoSummary{
+				{Repo: "github.com/o/a", Score: checker.InconclusiveResultScore},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := averageScore(tt.repos); got != tt.want {
+				t.Errorf("averageScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
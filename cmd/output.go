@@ -0,0 +1,83 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ossf/scorecard/v3/attestation"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/pkg"
+	"github.com/ossf/scorecard/v3/storage"
+)
+
+// openResultBucket opens the bucket results are persisted to, nil if none is configured.
+func openResultBucket(ctx context.Context) (storage.Bucket, error) {
+	switch {
+	case outputDir != "" && outputBucket != "":
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, "--output-dir cannot be used with --output-bucket")
+	case outputDir != "":
+		//nolint:wrapcheck
+		return storage.OpenDir(outputDir)
+	case outputBucket != "":
+		//nolint:wrapcheck
+		return storage.OpenBucket(ctx, outputBucket)
+	default:
+		return nil, nil
+	}
+}
+
+// persistResult writes the detailed JSON result to bucket, named by repo, commit and date.
+// The results restricted to a directory of the repo are named by the repo followed by it.
+// The format does not depend on --format, so stored results can be compared over time.
+// With --sign-key, the DSSE envelope of the result is written next to it, with a .sig suffix.
+func persistResult(ctx context.Context, bucket storage.Bucket, repoResult *pkg.ScorecardResult,
+	checkDocs docs.Doc) error {
+	if bucket == nil {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := repoResult.AsJSON2(true /*showDetails*/, zapcore.InfoLevel, checkDocs, &buf); err != nil {
+		return fmt.Errorf("AsJSON2: %w", err)
+	}
+	key := storage.ResultKey(path.Join(repoResult.Repo.Name, repoResult.Repo.Path),
+		repoResult.Repo.CommitSHA, repoResult.Date)
+	if err := bucket.Write(ctx, key, buf.Bytes()); err != nil {
+		return fmt.Errorf("writing %s: %w", key, err)
+	}
+	if resultSigner == nil {
+		return nil
+	}
+	envelope, err := attestation.SignResult(ctx, buf.Bytes(), resultSigner)
+	if err != nil {
+		//nolint:wrapcheck
+		return err
+	}
+	sig, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("encoding signed result: %w", err)
+	}
+	if err := bucket.Write(ctx, key+".sig", sig); err != nil {
+		return fmt.Errorf("writing %s.sig: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	"github.com/ossf/scorecard/v3/pkg"
+	"github.com/ossf/scorecard/v3/storage"
+)
+
+func TestPersistResult(t *testing.T) {
+	t.Parallel()
+	checkDocs, err := docs.Read()
+	if err != nil {
+		t.Fatalf("docs.Read: %v", err)
+	}
+	dir := t.TempDir()
+	bucket, err := storage.OpenDir(dir)
+	if err != nil {
+		t.Fatalf("storage.OpenDir: %v", err)
+	}
+	t.Cleanup(func() { bucket.Close() })
+
+	result := &pkg.ScorecardResult{
+		Repo: pkg.RepoInfo{Name: "github.com/ossf/scorecard", CommitSHA: "sha"},
+		Date: time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+	if err := persistResult(context.Background(), bucket, result, checkDocs); err != nil {
+		t.Fatalf("persistResult: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "github.com", "ossf", "scorecard", "2021-10-01-sha.json"))
+	if err != nil {
+		t.Fatalf("os.ReadFile: %v", err)
+	}
+	var got struct {
+		Repo struct {
+			Name   string `json:"name"`
+			Commit string `json:"commit"`
+		} `json:"repo"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if got.Repo.Name != "github.com/ossf/scorecard" || got.Repo.Commit != "sha" {
+		t.Errorf("unexpected repo in result: %+v", got.Repo)
+	}
+
+	if err := persistResult(context.Background(), nil, result, checkDocs); err != nil {
+		t.Errorf("persistResult without bucket: %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ossf/scorecard/v3/clients/githubrepo/roundtripper"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const (
+	npmRegistryURL = "https://registry.npmjs.org"
+	pypiURL        = "https://pypi.org"
+	rubyGemsURL    = "https://rubygems.org"
+)
+
+var errPackageNotFound = errors.New("package not found")
+
+// resolvePackage returns the GitHub repository of the package passed
+// to at most one of --npm, --pypi or --rubygems, or "" if none was passed.
+func resolvePackage(npmPackage, pypiPackage, rubyGemsPackage string) (string, error) {
+	set := 0
+	for _, p := range []string{npmPackage, pypiPackage, rubyGemsPackage} {
+		if p != "" {
+			set++
+		}
+	}
+	switch {
+	case set > 1:
+		return "", sce.WithMessage(sce.ErrScorecardInternal,
+			"only one of --npm, --pypi or --rubygems can be used")
+	case npmPackage != "":
+		return fetchGitRepositoryFromNPM(npmRegistryURL, npmPackage)
+	case pypiPackage != "":
+		return fetchGitRepositoryFromPYPI(pypiURL, pypiPackage)
+	case rubyGemsPackage != "":
+		return fetchGitRepositoryFromRubyGems(rubyGemsURL, rubyGemsPackage)
+	}
+	return "", nil
+}
+
+type npmPackageResults struct {
+	// Repository is either a URL or an object with a url field.
+	Repository json.RawMessage `json:"repository"`
+	Homepage   string          `json:"homepage"`
+}
+
+type pypiPackageResults struct {
+	Info struct {
+		ProjectUrls map[string]string `json:"project_urls"`
+		HomePage    string            `json:"home_page"`
+	} `json:"info"`
+}
+
+type rubyGemsPackageResults struct {
+	SourceCodeURI string `json:"source_code_uri"`
+	HomepageURI   string `json:"homepage_uri"`
+}
+
+// Gets the GitHub repository URL for the npm package.
+func fetchGitRepositoryFromNPM(baseURL, packageName string) (string, error) {
+	v := &npmPackageResults{}
+	if err := getPackageJSON(fmt.Sprintf("%s/%s", baseURL, url.PathEscape(packageName)), v); err != nil {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("npm package %s: %v", packageName, err))
+	}
+
+	var candidates []string
+	var repository struct {
+		URL string `json:"url"`
+	}
+	var repositoryURL string
+	if err := json.Unmarshal(v.Repository, &repository); err == nil {
+		candidates = append(candidates, repository.URL)
+	} else if err := json.Unmarshal(v.Repository, &repositoryURL); err == nil {
+		candidates = append(candidates, repositoryURL)
+	}
+	candidates = append(candidates, v.Homepage)
+	return firstGitHubRepo("npm", packageName, candidates)
+}
+
+// Gets the GitHub repository URL for the pypi package.
+func fetchGitRepositoryFromPYPI(baseURL, packageName string) (string, error) {
+	v := &pypiPackageResults{}
+	if err := getPackageJSON(fmt.Sprintf("%s/pypi/%s/json", baseURL, url.PathEscape(packageName)), v); err != nil {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("pypi package %s: %v", packageName, err))
+	}
+
+	// project_urls keys are free-form, so try the common names for the
+	// source repository first.
+	var candidates []string
+	for _, key := range []string{"Source", "Source Code", "Code", "Repository", "Homepage"} {
+		candidates = append(candidates, v.Info.ProjectUrls[key])
+	}
+	candidates = append(candidates, v.Info.HomePage)
+	return firstGitHubRepo("pypi", packageName, candidates)
+}
+
+// Gets the GitHub repository URL for the rubygems package.
+func fetchGitRepositoryFromRubyGems(baseURL, packageName string) (string, error) {
+	v := &rubyGemsPackageResults{}
+	if err := getPackageJSON(fmt.Sprintf("%s/api/v1/gems/%s.json", baseURL, url.PathEscape(packageName)), v); err != nil {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ruby gem %s: %v", packageName, err))
+	}
+	return firstGitHubRepo("ruby gem", packageName, []string{v.SourceCodeURI, v.HomepageURI})
+}
+
+//nolint:noctx
+func getPackageJSON(u string, v interface{}) error {
+	const timeout = 10
+	client := &http.Client{
+		Timeout:   timeout * time.Second,
+		Transport: roundtripper.BaseTransport(),
+	}
+	resp, err := client.Get(u)
+	if err != nil {
+		return fmt.Errorf("failed to get package json: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errPackageNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("failed to get package json: %s", resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse package json: %w", err)
+	}
+	return nil
+}
+
+func firstGitHubRepo(kind, packageName string, candidates []string) (string, error) {
+	for _, c := range candidates {
+		if repo, ok := normalizeGitHubRepo(c); ok {
+			return repo, nil
+		}
+	}
+	return "", sce.WithMessage(sce.ErrScorecardInternal,
+		fmt.Sprintf("could not find GitHub source repo for %s package: %s", kind, packageName))
+}
+
+// normalizeGitHubRepo turns the repository URLs found in package metadata,
+// e.g. git+https://github.com/owner/repo.git or git@github.com:owner/repo,
+// into github.com/owner/repo.
+func normalizeGitHubRepo(raw string) (string, bool) {
+	s := strings.TrimSpace(raw)
+	s = strings.TrimPrefix(s, "git+")
+	if strings.HasPrefix(s, "git@github.com:") {
+		s = "https://github.com/" + strings.TrimPrefix(s, "git@github.com:")
+	}
+	if strings.HasPrefix(s, "github:") {
+		s = "https://github.com/" + strings.TrimPrefix(s, "github:")
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return "", false
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	if host != "github.com" {
+		return "", false
+	}
+	// Drop anything after owner/repo, e.g. /tree/main.
+	const minParts = 2
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < minParts || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+	return fmt.Sprintf("github.com/%s/%s", parts[0], strings.TrimSuffix(parts[1], ".git")), true
+}
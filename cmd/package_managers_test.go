@@ -0,0 +1,126 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNormalizeGitHubRepo(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		raw  string
+		want string
+		ok   bool
+	}{
+		{name: "https", raw: "https://github.com/owner/repo", want: "github.com/owner/repo", ok: true},
+		{name: "git+https", raw: "git+https://github.com/owner/repo.git", want: "github.com/owner/repo", ok: true},
+		{name: "git protocol", raw: "git://github.com/owner/repo.git", want: "github.com/owner/repo", ok: true},
+		{name: "ssh", raw: "git@github.com:owner/repo.git", want: "github.com/owner/repo", ok: true},
+		{name: "npm shorthand", raw: "github:owner/repo", want: "github.com/owner/repo", ok: true},
+		{name: "www", raw: "https://www.github.com/owner/repo/", want: "github.com/owner/repo", ok: true},
+		{name: "subpath", raw: "https://github.com/owner/repo/tree/main/pkg", want: "github.com/owner/repo", ok: true},
+		{name: "owner only", raw: "https://github.com/owner"},
+		{name: "other host", raw: "https://gitlab.com/owner/repo"},
+		{name: "empty"},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := normalizeGitHubRepo(tt.raw)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("normalizeGitHubRepo(%q) = %q, %v, want %q, %v", tt.raw, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestFetchGitRepository(t *testing.T) {
+	t.Parallel()
+	responses := map[string]string{
+		"/@scope%2Fpkg":                   `{"repository": {"type": "git", "url": "git+https://github.com/owner/npm.git"}}`,
+		"/string-repo":                    `{"repository": "github:owner/string"}`,
+		"/homepage-only":                  `{"homepage": "https://github.com/owner/homepage#readme"}`,
+		"/pypi/pkg/json":                  `{"info": {"project_urls": {"Source Code": "https://github.com/owner/pypi"}}}`,
+		"/pypi/homepage/json":             `{"info": {"project_urls": {"Docs": "https://docs.example.com"}, "home_page": "https://github.com/owner/home"}}`,
+		"/api/v1/gems/gem.json":           `{"source_code_uri": "https://github.com/owner/gem/tree/v1.0"}`,
+		"/api/v1/gems/not-on-github.json": `{"source_code_uri": "https://gitlab.com/owner/gem"}`,
+		"/api/v1/gems/homepage-gem.json":  `{"homepage_uri": "https://github.com/owner/homegem"}`,
+		"/api/v1/gems/invalid-json.json":  `{`,
+		"/pypi/server-error/json":         "",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pypi/server-error/json" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body, ok := responses[r.URL.EscapedPath()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		//nolint:errcheck
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name    string
+		fetch   func(baseURL, packageName string) (string, error)
+		pkg     string
+		want    string
+		wantErr bool
+	}{
+		{name: "npm scoped", fetch: fetchGitRepositoryFromNPM, pkg: "@scope/pkg", want: "github.com/owner/npm"},
+		{name: "npm string repository", fetch: fetchGitRepositoryFromNPM, pkg: "string-repo", want: "github.com/owner/string"},
+		{name: "npm homepage", fetch: fetchGitRepositoryFromNPM, pkg: "homepage-only", want: "github.com/owner/homepage"},
+		{name: "npm not found", fetch: fetchGitRepositoryFromNPM, pkg: "missing", wantErr: true},
+		{name: "pypi", fetch: fetchGitRepositoryFromPYPI, pkg: "pkg", want: "github.com/owner/pypi"},
+		{name: "pypi home page", fetch: fetchGitRepositoryFromPYPI, pkg: "homepage", want: "github.com/owner/home"},
+		{name: "pypi server error", fetch: fetchGitRepositoryFromPYPI, pkg: "server-error", wantErr: true},
+		{name: "rubygems", fetch: fetchGitRepositoryFromRubyGems, pkg: "gem", want: "github.com/owner/gem"},
+		{name: "rubygems homepage", fetch: fetchGitRepositoryFromRubyGems, pkg: "homepage-gem", want: "github.com/owner/homegem"},
+		{name: "rubygems not on github", fetch: fetchGitRepositoryFromRubyGems, pkg: "not-on-github", wantErr: true},
+		{name: "rubygems invalid json", fetch: fetchGitRepositoryFromRubyGems, pkg: "invalid-json", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.fetch(server.URL, tt.pkg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fetch(%q) error = %v, wantErr %v", tt.pkg, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("fetch(%q) = %q, want %q", tt.pkg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolvePackage(t *testing.T) {
+	t.Parallel()
+	if _, err := resolvePackage("foo", "bar", ""); err == nil {
+		t.Error("expected error when several package managers are set")
+	}
+	got, err := resolvePackage("", "", "")
+	if err != nil || got != "" {
+		t.Errorf("resolvePackage() = %q, %v, want \"\", nil", got, err)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ossf/scorecard/v3/clients/githubrepo/roundtripper"
+)
+
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// progressReporter writes the progress of a scan to stderr with --progress:
+// the checks completed per repo, the repos completed, an ETA and the GitHub
+// API quota left, so that scanning big orgs does not leave a silent terminal
+// for minutes. Its methods do nothing on a nil reporter.
+type progressReporter struct {
+	mu         sync.Mutex
+	w          io.Writer
+	now        func() time.Time
+	quota      func() (int, bool)
+	start      time.Time
+	checksDone map[string]int
+	repos      int
+	checks     int
+	reposDone  int
+	frame      int
+	// On a terminal, each update overwrites the previous one,
+	// until a repo is done. pending is set while a line is unfinished.
+	tty     bool
+	pending bool
+}
+
+// newProgress returns the reporter of a scan of repos running checks each,
+// or nil without --progress.
+func newProgress(repos, checks int) *progressReporter {
+	if !showProgress {
+		return nil
+	}
+	tty := false
+	if fi, err := os.Stderr.Stat(); err == nil {
+		tty = fi.Mode()&os.ModeCharDevice != 0
+	}
+	return newProgressReporter(os.Stderr, tty, repos, checks)
+}
+
+func newProgressReporter(w io.Writer, tty bool, repos, checks int) *progressReporter {
+	return &progressReporter{
+		w:          w,
+		tty:        tty,
+		now:        time.Now,
+		quota:      roundtripper.RemainingQuota,
+		start:      time.Now(),
+		checksDone: make(map[string]int),
+		repos:      repos,
+		checks:     checks,
+	}
+}
+
+func (p *progressReporter) checkDone(repo, checkName string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checksDone[repo]++
+	p.print(fmt.Sprintf("%s: %d/%d checks (%d%%), %s done",
+		repo, p.checksDone[repo], p.checks, percent(p.checksDone[repo], p.checks), checkName))
+}
+
+// repoDone records that repo was scored, or failed to.
+func (p *progressReporter) repoDone(repo string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.checksDone, repo)
+	p.reposDone++
+	p.print(fmt.Sprintf("%s done", repo))
+	p.endLine()
+}
+
+// finish ends the last update on a terminal.
+func (p *progressReporter) finish() {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.endLine()
+}
+
+func (p *progressReporter) endLine() {
+	if p.pending {
+		fmt.Fprintln(p.w)
+		p.pending = false
+	}
+}
+
+func (p *progressReporter) print(status string) {
+	parts := []string{spinnerFrames[p.frame%len(spinnerFrames)]}
+	p.frame++
+	if p.repos > 1 {
+		parts = append(parts, fmt.Sprintf("[%d/%d repos]", p.reposDone, p.repos))
+	}
+	parts = append(parts, status)
+	if eta, ok := p.eta(); ok {
+		parts = append(parts, fmt.Sprintf("ETA %s", eta))
+	}
+	if remaining, ok := p.quota(); ok {
+		parts = append(parts, fmt.Sprintf("%d API requests left", remaining))
+	}
+	line := strings.Join(parts, " ")
+	if p.tty {
+		// Clear the rest of the previous update.
+		fmt.Fprintf(p.w, "\r%s\x1b[K", line)
+		p.pending = true
+	} else {
+		fmt.Fprintln(p.w, line)
+	}
+}
+
+// eta extrapolates the time left from the checks completed so far.
+func (p *progressReporter) eta() (time.Duration, bool) {
+	total := p.repos * p.checks
+	done := p.reposDone * p.checks
+	for _, n := range p.checksDone {
+		done += n
+	}
+	if total == 0 || done == 0 || done >= total {
+		return 0, false
+	}
+	elapsed := p.now().Sub(p.start)
+	left := time.Duration(float64(elapsed) * float64(total-done) / float64(done))
+	return left.Round(time.Second), true
+}
+
+func percent(n, total int) int {
+	if total == 0 {
+		return 100
+	}
+	return n * 100 / total
+}
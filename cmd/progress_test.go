@@ -0,0 +1,79 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProgressReporter(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	p := newProgressReporter(&w, false, 2, 2)
+	start := time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+	now := start
+	p.start = start
+	p.now = func() time.Time { return now }
+	quota := 0
+	p.quota = func() (int, bool) { return quota, quota > 0 }
+
+	now = start.Add(10 * time.Second)
+	p.checkDone("github.com/o/a", "Code-Review")
+	quota = 4200
+	now = start.Add(20 * time.Second)
+	p.checkDone("github.com/o/a", "Fuzzing")
+	p.repoDone("github.com/o/a")
+	now = start.Add(30 * time.Second)
+	p.checkDone("github.com/o/b", "Fuzzing")
+	p.repoDone("github.com/o/b")
+	p.finish()
+
+	want := []string{
+		"| [0/2 repos] github.com/o/a: 1/2 checks (50%), Code-Review done ETA 30s",
+		"/ [0/2 repos] github.com/o/a: 2/2 checks (100%), Fuzzing done ETA 20s 4200 API requests left",
+		"- [1/2 repos] github.com/o/a done ETA 20s 4200 API requests left",
+		"\\ [1/2 repos] github.com/o/b: 1/2 checks (50%), Fuzzing done ETA 10s 4200 API requests left",
+		"| [2/2 repos] github.com/o/b done 4200 API requests left",
+	}
+	got := strings.Split(strings.TrimSuffix(w.String(), "\n"), "\n")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProgressReporterTerminal(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	p := newProgressReporter(&w, true, 1, 1)
+	p.quota = func() (int, bool) { return 0, false }
+	p.checkDone("github.com/o/a", "Fuzzing")
+	p.finish()
+	if got, want := w.String(), "\r| github.com/o/a: 1/1 checks (100%), Fuzzing done\x1b[K\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestProgressReporterNil(t *testing.T) {
+	t.Parallel()
+	var p *progressReporter
+	p.checkDone("github.com/o/a", "Fuzzing")
+	p.repoDone("github.com/o/a")
+	p.finish()
+}
@@ -0,0 +1,251 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/pkg"
+	spol "github.com/ossf/scorecard/v3/policy"
+)
+
+// repoErrorResult is written instead of the result of a repo which failed to score.
+type repoErrorResult struct {
+	Repo struct {
+		Name string `json:"name"`
+	} `json:"repo"`
+	Error string `json:"error"`
+}
+
+// getRepos returns the repos given with --repo and those listed in repoFile,
+// one per line. Blank lines and lines starting with # are ignored.
+func getRepos(repoFlags []string, repoFile string) ([]string, error) {
+	repos := append([]string{}, repoFlags...)
+	if repoFile == "" {
+		return repos, nil
+	}
+	f, err := os.Open(repoFile)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.Open: %v", err))
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		repos = append(repos, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("reading %s: %v", repoFile, err))
+	}
+	return repos, nil
+}
+
+// scoreConcurrently calls score for each repo and its index with a pool of
+// workers, and writes what it returns to w as soon as it completes, one repo
+// at a time.
+func scoreConcurrently(repos []string, workers int, score func(i int, repo string) []byte, w io.Writer) error {
+	if workers < 1 {
+		workers = 1
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var writeErr error
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				out := score(i, repos[i])
+				mu.Lock()
+				if writeErr == nil {
+					if _, err := w.Write(out); err != nil {
+						writeErr = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("writing result: %v", err))
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range repos {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return writeErr
+}
+
+// forgeChecks are the checks run on the repos of a forge.
+type forgeChecks struct {
+	supported []string
+	enabled   checker.CheckNameToFnMap
+}
+
+// scoreBatch runs the checks on repos, which may be on any forge, with a pool
+// of --workers workers. write returns the output of each result, written to
+// stdout as soon as the repo is scored, while the repos which fail to score are
+// passed to writeError instead. The workers share the OSS-Fuzz repo client and
+// the GitHub rate limit, and wait together for it to reset.
+// It returns the exit code of the run, that of the results with the most severe one.
+func scoreBatch(ctx context.Context, logger *zap.Logger, repos []string, policy *spol.ScorecardPolicy,
+	checkDocs docs.Doc, write func(i int, repoResult *pkg.ScorecardResult) ([]byte, error),
+	writeError func(i int, uri string, err error) []byte) (int, error) {
+	checksByForge := make(map[string]forgeChecks)
+	maxChecks := 0
+	listed := false
+	for _, uri := range repos {
+		repo, repoType, err := parseRepo(uri)
+		if err != nil {
+			// Reported as the repo fails to score.
+			continue
+		}
+		listed = listed || listedPublicly(repo, repoType)
+		if _, ok := checksByForge[repoType]; ok {
+			continue
+		}
+		supportedChecks, err := getSupportedChecks(repoType, checkDocs)
+		if err != nil {
+			return exitOK, err
+		}
+		enabledChecks, err := getEnabledChecks(policy, checksToRun, checkDocs, supportedChecks, repoType)
+		if err != nil {
+			return exitOK, err
+		}
+		checksByForge[repoType] = forgeChecks{supported: supportedChecks, enabled: enabledChecks}
+		if len(enabledChecks) > maxChecks {
+			maxChecks = len(enabledChecks)
+		}
+	}
+
+	var ossFuzzRepoClient clients.RepoClient
+	if listed {
+		var err error
+		ossFuzzRepoClient, err = createOssFuzzRepoClient(ctx, fast, logger)
+		if err != nil {
+			return exitOK, err
+		}
+		defer ossFuzzRepoClient.Close()
+	}
+
+	resultBucket, err := openResultBucket(ctx)
+	if err != nil {
+		return exitOK, err
+	}
+	if resultBucket != nil {
+		defer resultBucket.Close()
+	}
+
+	progress := newProgress(len(repos), maxChecks)
+	defer progress.finish()
+	opts := runOptions(policy, progress)
+	var exitCodes repoExitCodes
+	score := func(i int, uri string) []byte {
+		repoResult, err := scoreBatchRepo(ctx, logger, uri, ossFuzzRepoClient, checksByForge, policy, opts)
+		progress.repoDone(uri)
+		var out []byte
+		if err == nil {
+			out, err = write(i, repoResult)
+		}
+		if err == nil {
+			err = persistResult(ctx, resultBucket, repoResult, checkDocs)
+		}
+		if err != nil {
+			// Keep going: one broken repo should not fail the others.
+			logger.Warn(fmt.Sprintf("scoring %s: %v", uri, err))
+			exitCodes.addError()
+			return writeError(i, uri, err)
+		}
+		exitCodes.add(repoResult, failOnInconclusive, os.Stderr)
+		return out
+	}
+	if err := scoreConcurrently(repos, workers, score, os.Stdout); err != nil {
+		return exitOK, err
+	}
+	return exitCodes.code, nil
+}
+
+// scoreBatchRepo runs the checks of its forge on the repo at uri.
+func scoreBatchRepo(ctx context.Context, logger *zap.Logger, uri string, ossFuzzRepoClient clients.RepoClient,
+	checksByForge map[string]forgeChecks, policy *spol.ScorecardPolicy,
+	opts []pkg.Option) (*pkg.ScorecardResult, error) {
+	repo, repoType, err := parseRepo(uri)
+	if err != nil {
+		return nil, err
+	}
+	if !listedPublicly(repo, repoType) {
+		ossFuzzRepoClient = nil
+	}
+	forge := checksByForge[repoType]
+	repoClient, ciiClient, vulnsClient, packagesClient := getRepoClients(ctx, repo, repoType, fast, logger)
+	repoResult, err := pkg.RunScorecards(ctx, repo, clients.HeadSHA, false, forge.enabled,
+		repoClient, ossFuzzRepoClient, ciiClient, vulnsClient, packagesClient, opts...)
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	if err := finalizeResult(&repoResult, repoType, forge.supported, policy); err != nil {
+		return nil, err
+	}
+	applyDependents(ctx, &repoResult, packagesClient)
+	return &repoResult, nil
+}
+
+// scoreRepos runs the checks on several repos concurrently, streaming each
+// result as one line of JSON (NDJSON) as it completes. Repos which fail to
+// score get a line with their error, rather than failing the others.
+// It returns the exit code of the run, that of the results with the most severe one.
+func scoreRepos(ctx context.Context, logger *zap.Logger, repos []string,
+	policy *spol.ScorecardPolicy) (int, error) {
+	if format != formatJSON {
+		return exitOK, sce.WithMessage(sce.ErrScorecardInternal, "multiple repos only support the json format")
+	}
+	checkDocs, err := docs.Read()
+	if err != nil {
+		return exitOK, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("docs.Read: %v", err))
+	}
+	write := func(i int, repoResult *pkg.ScorecardResult) ([]byte, error) {
+		var out bytes.Buffer
+		// With --sign-key, the line is the envelope of the signed result.
+		err := writeResult(repoResult, checkDocs, policy, &out)
+		return out.Bytes(), err
+	}
+	writeError := func(i int, uri string, err error) []byte {
+		var out bytes.Buffer
+		var e repoErrorResult
+		e.Repo.Name = uri
+		e.Error = err.Error()
+		//nolint:errcheck // Encoding this struct cannot fail.
+		json.NewEncoder(&out).Encode(e)
+		return out.Bytes()
+	}
+	return scoreBatch(ctx, logger, repos, policy, checkDocs, write, writeError)
+}
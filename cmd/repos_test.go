@@ -0,0 +1,94 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetRepos(t *testing.T) {
+	t.Parallel()
+	repoFile := filepath.Join(t.TempDir(), "repos.txt")
+	content := `# Repos to scan.
+github.com/ossf/scorecard
+
+  github.com/ossf/scorecard-action  
+`
+	if err := os.WriteFile(repoFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	got, err := getRepos([]string{"github.com/owner/repo"}, repoFile)
+	if err != nil {
+		t.Fatalf("getRepos: %v", err)
+	}
+	want := []string{"github.com/owner/repo", "github.com/ossf/scorecard", "github.com/ossf/scorecard-action"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("getRepos() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := getRepos(nil, filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("getRepos() with a missing file succeeded, want error")
+	}
+}
+
+func TestScoreConcurrently(t *testing.T) {
+	t.Parallel()
+	repos := []string{"a", "b", "c", "d", "e", "f", "g"}
+	const workers = 3
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	score := func(i int, repo string) []byte {
+		if repos[i] != repo {
+			t.Errorf("score(%d, %q), want repo %q", i, repo, repos[i])
+		}
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return []byte(`{"repo": "` + repo + `"}` + "\n")
+	}
+
+	var out bytes.Buffer
+	if err := scoreConcurrently(repos, workers, score, &out); err != nil {
+		t.Fatalf("scoreConcurrently: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	sort.Strings(lines)
+	want := make([]string, len(repos))
+	for i, r := range repos {
+		want[i] = `{"repo": "` + r + `"}`
+	}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("scoreConcurrently() output mismatch (-want +got):\n%s", diff)
+	}
+	if maxInFlight > workers {
+		t.Errorf("%d repos scored concurrently, want at most %d", maxInFlight, workers)
+	}
+}
@@ -16,58 +16,138 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	goflag "flag"
 	"fmt"
+	"io"
 	"log"
-	"net/http"
 	"os"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
+	"github.com/ossf/scorecard/v3/attestation"
 	"github.com/ossf/scorecard/v3/checker"
 	"github.com/ossf/scorecard/v3/checks"
 	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/azuredevopsrepo"
+	"github.com/ossf/scorecard/v3/clients/bitbucketrepo"
+	"github.com/ossf/scorecard/v3/clients/gitearepo"
 	"github.com/ossf/scorecard/v3/clients/githubrepo"
+	"github.com/ossf/scorecard/v3/clients/githubrepo/roundtripper"
 	"github.com/ossf/scorecard/v3/clients/localdir"
 	docs "github.com/ossf/scorecard/v3/docs/checks"
 	sce "github.com/ossf/scorecard/v3/errors"
 	"github.com/ossf/scorecard/v3/pkg"
 	spol "github.com/ossf/scorecard/v3/policy"
+	"github.com/ossf/scorecard/v3/probes"
 )
 
 var (
-	repo        string
+	repos       []string
 	raw         bool
 	local       string
 	checksToRun []string
 	metaData    []string
 	// This one has to use goflag instead of pflag because it's defined by zap.
-	logLevel    = zap.LevelFlag("verbosity", zap.InfoLevel, "override the default log level")
+	logLevel    = zap.LevelFlag("verbosity", zap.InfoLevel, "log level and details shown: debug, info or warn")
 	format      string
 	npm         string
+	image       string
 	pypi        string
 	rubygems    string
 	showDetails bool
 	policyFile  string
+	fast        bool
+	redact      bool
+	private     bool
+	probesToRun []string
+	commitSHA   string
+	org         string
+	orgIncludes []string
+	orgExcludes []string
+	githubAPI   string
+	// Results are persisted to one of these, if set.
+	outputDir    string
+	outputBucket string
+	// Shared with the annotate command.
+	annotationsFile string
+	// Excludes the findings annotated in the repo's .scorecard.yml from policies.
+	excludeAnnotated bool
+	// JSON results the checks are compared against, to spot regressions.
+	baselineFile string
+	// Several repos are scored concurrently by this many workers.
+	repoFile string
+	workers  int
+	// Exits with exitInconclusive if a check had nothing to score.
+	failOnInconclusive bool
+	// Reports the progress of the scan to stderr.
+	showProgress bool
+	// YAML file setting the flags, see applyConfig.
+	configFile string
+	// Environment variable holding the GitHub token, instead of githubTokenEnv.
+	tokenEnv string
+	// Counts the dependents of the packages published from the repo.
+	dependents bool
+	// Looks up the dependencies pinned by the repo's lockfiles in the Vulnerabilities check.
+	dependencyVulns bool
+	// Restricts the file-based checks to this directory of the repo, e.g. a monorepo component.
+	subPath string
+	// Signs JSON results with this key, see resultSigner.
+	signKey string
+	// Records the scan's API interactions to, or replays them from, an evidence bundle.
+	recordEvidenceDir string
+	replayEvidenceDir string
+	// Archives the evidence bundle to, or extracts it from, a .tar.zst file.
+	exportEvidence string
+	fromEvidence   string
+	// Fails the API calls of a repo's scan past this number, zero for no budget.
+	maxAPICalls int
+	// Fails the checks which do not complete in this time, zero for no timeout.
+	checkTimeout time.Duration
 )
 
+// resultSigner signs the JSON results written with --sign-key.
+var resultSigner attestation.Signer
+
+// githubTokenEnv is the first environment variable the GitHub clients read the token from.
+const githubTokenEnv = "GITHUB_AUTH_TOKEN"
+
+// Exit codes of a run which wrote its results, see exitCode.
+// Status 1 is left to the errors which prevent writing them, e.g. log.Fatal.
 const (
-	formatJSON    = "json"
-	formatSarif   = "sarif"
-	formatDefault = "default"
+	exitOK           = 0
+	exitCheckError   = 2
+	exitInconclusive = 3
+	exitRegression   = 4
+)
+
+const (
+	formatJSON     = "json"
+	formatCSV      = "csv"
+	formatHTML     = "html"
+	formatMarkdown = "markdown"
+	formatProbe    = "probe"
+	formatRaw      = "raw"
+	formatSarif    = "sarif"
+	formatDefault  = "default"
 )
 
 // These strings must be the same as the ones used in
 // checks.yaml for the "repos" field.
 const (
-	repoTypeLocal  = "local"
-	repoTypeGitHub = "GitHub"
+	repoTypeLocal       = "local"
+	repoTypeGitHub      = "GitHub"
+	repoTypeGitea       = "Gitea"
+	repoTypeBitbucket   = "Bitbucket"
+	repoTypeAzureDevOps = "AzureDevOps"
 )
 
 const (
@@ -78,7 +158,16 @@ const (
 	scorecardShort = "Security Scorecards"
 )
 
-func readPolicy() (*spol.ScorecardPolicy, error) {
+// rawChecks are the checks which collect raw results for --format=raw.
+var rawChecks = map[string]bool{
+	checks.CheckBinaryArtifacts:   true,
+	checks.CheckBranchProtection:  true,
+	checks.CheckDangerousWorkflow: true,
+	checks.CheckSecurityPolicy:    true,
+	checks.CheckSignedReleases:    true,
+}
+
+func readPolicy(policyFile string) (*spol.ScorecardPolicy, error) {
 	if policyFile != "" {
 		data, err := os.ReadFile(policyFile)
 		if err != nil {
@@ -95,6 +184,34 @@ func readPolicy() (*spol.ScorecardPolicy, error) {
 	return nil, nil
 }
 
+// policyOptions returns the options of RunScorecards declared in the policy, if any.
+func policyOptions(sp *spol.ScorecardPolicy) []pkg.Option {
+	var opts []pkg.Option
+	if bp, ok := sp.GetPolicies()[checks.CheckBranchProtection]; ok && len(bp.GetRequiredStatusChecks()) > 0 {
+		opts = append(opts, pkg.WithRequiredStatusChecks(bp.GetRequiredStatusChecks()))
+	}
+	return opts
+}
+
+// runOptions returns the options of RunScorecards declared in the policy and
+// set by flags, reporting the completed checks to progress.
+func runOptions(sp *spol.ScorecardPolicy, progress *progressReporter) []pkg.Option {
+	opts := append(policyOptions(sp),
+		pkg.WithCheckTimeout(checkTimeout),
+		pkg.WithSubPath(subPath),
+		pkg.WithAPICallBudget(maxAPICalls),
+		pkg.WithCheckProgress(progress.checkDone))
+	// A check selected with --checks which cannot run on the repo fails
+	// fast, instead of being skipped like the checks run by default.
+	if len(checksToRun) > 0 {
+		opts = append(opts, pkg.WithFailOnUnsupported())
+	}
+	if dependencyVulns {
+		opts = append(opts, pkg.WithDependencyVulnerabilities())
+	}
+	return opts
+}
+
 func checksHavePolicies(sp *spol.ScorecardPolicy, enabledChecks checker.CheckNameToFnMap) bool {
 	for checkName := range enabledChecks {
 		_, exists := sp.Policies[checkName]
@@ -107,7 +224,7 @@ func checksHavePolicies(sp *spol.ScorecardPolicy, enabledChecks checker.CheckNam
 }
 
 func getSupportedChecks(r string, checkDocs docs.Doc) ([]string, error) {
-	allChecks := checks.AllChecks
+	allChecks := getAllChecks()
 	supportedChecks := []string{}
 	for check := range allChecks {
 		c, e := checkDocs.GetCheck(check)
@@ -135,7 +252,10 @@ func isSupportedCheck(names []string, name string) bool {
 
 func getAllChecks() checker.CheckNameToFnMap {
 	// Returns the full list of checks, given any environment variable constraints.
-	possibleChecks := checks.AllChecks
+	possibleChecks := checker.CheckNameToFnMap{}
+	for name, fn := range checks.AllChecks {
+		possibleChecks[name] = fn
+	}
 	// TODO: Remove this to enable the DANGEROUS_WORKFLOW by default in the next release.
 	if _, dangerousWorkflowCheck := os.LookupEnv("ENABLE_DANGEROUS_WORKFLOW"); !dangerousWorkflowCheck {
 		delete(possibleChecks, checks.CheckDangerousWorkflow)
@@ -144,22 +264,25 @@ func getAllChecks() checker.CheckNameToFnMap {
 	if _, licenseflowCheck := os.LookupEnv("ENABLE_LICENSE"); !licenseflowCheck {
 		delete(possibleChecks, checks.CheckLicense)
 	}
+	// TODO: Remove this to enable the SECURITY_ADVISORIES check by default in the next release.
+	if _, securityAdvisoriesCheck := os.LookupEnv("ENABLE_SECURITY_ADVISORIES"); !securityAdvisoriesCheck {
+		delete(possibleChecks, checks.CheckSecurityAdvisories)
+	}
 	return possibleChecks
 }
 
-func getEnabledChecks(sp *spol.ScorecardPolicy, argsChecks []string,
+func getEnabledChecks(sp *spol.ScorecardPolicy, argsChecks []string, checkDocs docs.Doc,
 	supportedChecks []string, repoType string) (checker.CheckNameToFnMap, error) {
 	enabledChecks := checker.CheckNameToFnMap{}
 
 	switch {
 	case len(argsChecks) != 0:
 		// Populate checks to run with the CLI arguments.
-		for _, checkName := range argsChecks {
-			if !isSupportedCheck(supportedChecks, checkName) {
-				return enabledChecks,
-					sce.WithMessage(sce.ErrScorecardInternal,
-						fmt.Sprintf("repo type %s: unsupported check: %s", repoType, checkName))
-			}
+		checkNames, err := expandCheckPatterns(argsChecks, checkDocs, supportedChecks, repoType)
+		if err != nil {
+			return enabledChecks, err
+		}
+		for _, checkName := range checkNames {
 			if !enableCheck(checkName, &enabledChecks) {
 				return enabledChecks,
 					sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid check: %s", checkName))
@@ -204,42 +327,114 @@ func getEnabledChecks(sp *spol.ScorecardPolicy, argsChecks []string,
 
 func validateFormat(format string) bool {
 	switch format {
-	case "json", "sarif", "default":
+	case "json", "csv", "markdown", "html", "probe", "raw", "sarif", "default":
 		return true
 	default:
-		return false
+		_, err := pkg.LookupFormatPlugin(format)
+		return err == nil
 	}
 }
 
-func getRepoAccessors(ctx context.Context, uri string, logger *zap.Logger) (
+func getRepoAccessors(ctx context.Context, uri string, fast bool, logger *zap.Logger) (
 	repo clients.Repo,
 	repoClient clients.RepoClient,
 	ossFuzzRepoClient clients.RepoClient,
 	ciiClient clients.CIIBestPracticesClient,
+	vulnsClient clients.VulnerabilitiesClient,
+	packagesClient clients.PackagesClient,
 	repoType string,
 	err error) {
-	var localRepo, githubRepo clients.Repo
-	var errLocal, errGitHub error
-	if localRepo, errLocal = localdir.MakeLocalDirRepo(uri); errLocal == nil {
-		// Local directory.
-		repoType = repoTypeLocal
-		repo = localRepo
-		repoClient = localdir.CreateLocalDirClient(ctx, logger)
+	repo, repoType, err = parseRepo(uri)
+	if err != nil {
 		return
 	}
-	if githubRepo, errGitHub = githubrepo.MakeGithubRepo(uri); errGitHub == nil {
+	repoClient, ciiClient, vulnsClient, packagesClient = getRepoClients(ctx, repo, repoType, fast, logger)
+	if listedPublicly(repo, repoType) {
+		ossFuzzRepoClient, err = createOssFuzzRepoClient(ctx, fast, logger)
+	}
+	return
+}
+
+// parseRepo returns the repo at uri and the type of its forge.
+func parseRepo(uri string) (clients.Repo, string, error) {
+	localRepo, errLocal := localdir.MakeLocalDirRepo(uri)
+	if errLocal == nil {
+		// Local directory.
+		return localRepo, repoTypeLocal, nil
+	}
+	githubRepo, errGitHub := githubrepo.MakeGithubRepo(uri)
+	if errGitHub == nil {
 		// GitHub URL.
-		repoType = repoTypeGitHub
-		repo = githubRepo
-		repoClient = githubrepo.CreateGithubRepoClient(ctx, logger)
+		return githubRepo, repoTypeGitHub, nil
+	}
+	giteaRepo, errGitea := gitearepo.MakeGiteaRepo(uri)
+	if errGitea == nil {
+		// Gitea or Forgejo URL, e.g., on Codeberg.
+		return giteaRepo, repoTypeGitea, nil
+	}
+	bitbucketRepo, errBitbucket := bitbucketrepo.MakeBitbucketRepo(uri)
+	if errBitbucket == nil {
+		// Bitbucket Cloud URL.
+		return bitbucketRepo, repoTypeBitbucket, nil
+	}
+	azureRepo, errAzure := azuredevopsrepo.MakeAzureDevOpsRepo(uri)
+	if errAzure == nil {
+		// Azure Repos URL.
+		return azureRepo, repoTypeAzureDevOps, nil
+	}
+	return nil, "", sce.WithMessage(sce.ErrScorecardInternal,
+		fmt.Sprintf("unsupported URI: %s: [%v, %v, %v, %v, %v]",
+			uri, errLocal, errGitHub, errGitea, errBitbucket, errAzure))
+}
+
+// listedPublicly returns whether repo may be listed in public databases like
+// OSS-Fuzz or the CII Best Practices. Repos on GitHub Enterprise Server are not.
+func listedPublicly(repo clients.Repo, repoType string) bool {
+	return repoType == repoTypeGitHub && !githubrepo.IsEnterpriseRepo(repo)
+}
+
+// getRepoClients returns the clients to score repo with, but for the OSS-Fuzz
+// repo client which is expensive to create, see createOssFuzzRepoClient.
+func getRepoClients(ctx context.Context, repo clients.Repo, repoType string, fast bool, logger *zap.Logger) (
+	repoClient clients.RepoClient,
+	ciiClient clients.CIIBestPracticesClient,
+	vulnsClient clients.VulnerabilitiesClient,
+	packagesClient clients.PackagesClient) {
+	vulnsClient = clients.DefaultVulnerabilitiesClient()
+	if listedPublicly(repo, repoType) {
 		ciiClient = clients.DefaultCIIBestPracticesClient()
-		ossFuzzRepoClient, err = githubrepo.CreateOssFuzzRepoClient(ctx, logger)
-		return
+		packagesClient = clients.DefaultPackagesClient()
+	}
+	switch repoType {
+	case repoTypeLocal:
+		repoClient = localdir.CreateLocalDirClient(ctx, logger)
+	case repoTypeGitHub:
+		if fast {
+			repoClient = githubrepo.CreateFastGithubRepoClient(ctx, logger)
+		} else {
+			repoClient = githubrepo.CreateGithubRepoClient(ctx, logger)
+		}
+	case repoTypeGitea:
+		repoClient = gitearepo.CreateGiteaRepoClient(ctx, logger)
+	case repoTypeBitbucket:
+		repoClient = bitbucketrepo.CreateBitbucketRepoClient(ctx, logger)
+	case repoTypeAzureDevOps:
+		repoClient = azuredevopsrepo.CreateAzureDevOpsRepoClient(ctx, logger)
 	}
-	err = sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("unspported URI: %s: [%v, %v]", uri, errLocal, errGitHub))
 	return
 }
 
+// createOssFuzzRepoClient returns the client of the OSS-Fuzz repo, which the
+// repos listed publicly can share.
+func createOssFuzzRepoClient(ctx context.Context, fast bool, logger *zap.Logger) (clients.RepoClient, error) {
+	//nolint:wrapcheck
+	if fast {
+		return githubrepo.CreateFastOssFuzzRepoClient(ctx, logger)
+	}
+	//nolint:wrapcheck
+	return githubrepo.CreateOssFuzzRepoClient(ctx, logger)
+}
+
 func getURI(repo, local string) (string, error) {
 	if repo != "" && local != "" {
 		return "", sce.WithMessage(sce.ErrScorecardInternal,
@@ -255,85 +450,227 @@ var rootCmd = &cobra.Command{
 	Use:   scorecardUse,
 	Short: scorecardShort,
 	Long:  scorecardLong,
+	// Every subcommand sends requests through the base transport, so its
+	// configuration is checked before any of them runs.
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := roundtripper.CheckNetworkConfig(); err != nil {
+			fatal(err)
+		}
+	},
 	Run: func(cmd *cobra.Command, args []string) {
+		defer runExitHooks()
+		if configFile == "" {
+			configFile = os.Getenv(flagEnvVar(configFlag))
+		}
+		if err := applyConfig(cmd.Flags(), configFile, os.LookupEnv); err != nil {
+			fatal(err)
+		}
+
 		// UPGRADEv4: remove.
 		var v4 bool
 		_, v4 = os.LookupEnv("SCORECARD_V4")
 
 		if format == formatSarif && !v4 {
-			log.Fatal("sarif not supported yet")
+			fatal("sarif not supported yet")
 		}
 
 		if policyFile != "" && !v4 {
-			log.Fatal("policy not supported yet")
+			fatal("policy not supported yet")
 		}
 
 		if local != "" && !v4 {
-			log.Fatal("--local option not supported yet")
+			fatal("--local option not supported yet")
+		}
+
+		if githubAPI != "" {
+			githubrepo.SetAPIURL(githubAPI)
+		}
+		if tokenEnv != "" {
+			token, ok := os.LookupEnv(tokenEnv)
+			if !ok {
+				fatalf("--token-env: %s is not set", tokenEnv)
+			}
+			if err := os.Setenv(githubTokenEnv, token); err != nil {
+				fatal(err)
+			}
 		}
 
 		var v6 bool
 		_, v6 = os.LookupEnv("SCORECARD_V6")
 		if raw && !v6 {
-			log.Fatal("--raw option not supported yet")
+			fatal("--raw option not supported yet")
 		}
 
 		// Validate format.
 		if !validateFormat(format) {
-			log.Fatalf("unsupported format '%s'", format)
+			fatalf("unsupported format '%s'", format)
+		}
+		if signKey != "" {
+			if format != formatJSON {
+				fatal("--sign-key is only supported with --format=json")
+			}
+			signer, err := loadSigner(context.Background(), signKey)
+			if err != nil {
+				fatal(err)
+			}
+			resultSigner = signer
 		}
 
-		policy, err := readPolicy()
+		// Running individual probes implies the probe format.
+		if len(probesToRun) > 0 && format == formatDefault {
+			format = formatProbe
+		}
+		if len(probesToRun) > 0 && format != formatProbe {
+			fatal("--probes is only supported with --format=probe")
+		}
+		if format == formatProbe && len(probesToRun) == 0 {
+			probesToRun = probes.All()
+		}
+
+		policy, err := readPolicy(policyFile)
 		if err != nil {
-			log.Fatalf("readPolicy: %v", err)
+			fatalf("readPolicy: %v", err)
 		}
 
-		// Get the URI.
-		uri, err := getURI(repo, local)
+		repoList, err := getRepos(repos, repoFile)
 		if err != nil {
-			log.Fatal(err)
-		}
-		if npm != "" {
-			if git, err := fetchGitRepositoryFromNPM(npm); err != nil {
-				log.Fatal(err)
-			} else {
-				if err := cmd.Flags().Set("repo", git); err != nil {
-					log.Fatal(err)
-				}
+			fatal(err)
+		}
+		var evidence *evidenceBundle
+		if exportEvidence != "" || fromEvidence != "" {
+			cleanup, err := openEvidenceArchives()
+			if err != nil {
+				fatal(err)
 			}
-		} else if pypi != "" {
-			if git, err := fetchGitRepositoryFromPYPI(pypi); err != nil {
-				log.Fatal(err)
-			} else {
-				if err := cmd.Flags().Set("repo", git); err != nil {
-					log.Fatal(err)
-				}
+			atExit(cleanup)
+		}
+		if recordEvidenceDir != "" || replayEvidenceDir != "" {
+			evidence, err = setupEvidence(repoList)
+			if err != nil {
+				fatal(err)
 			}
-		} else if rubygems != "" {
-			if git, err := fetchGitRepositoryFromRubyGems(rubygems); err != nil {
-				log.Fatal(err)
-			} else {
-				if err := cmd.Flags().Set("repo", git); err != nil {
-					log.Fatal(err)
-				}
+			repoList = []string{evidence.Repo}
+		}
+
+		if org != "" {
+			if len(repoList) > 0 || local != "" || npm != "" || pypi != "" || rubygems != "" || image != "" {
+				fatal("--org cannot be used with --repo, --local, --npm, --pypi, --rubygems or --image")
 			}
-		} else {
-			if err := cmd.MarkFlagRequired("repo"); err != nil {
-				log.Fatal(err)
+			logger, err := githubrepo.NewLogger(*logLevel)
+			if err != nil {
+				fatal(err)
+			}
+			// nolint
+			defer logger.Sync() // Flushes buffer, if any.
+			if baselineFile != "" {
+				fatal("--baseline cannot be used with --org")
+			}
+			if subPath != "" {
+				fatal("--path cannot be used with --org")
+			}
+			if commitSHA != clients.HeadSHA {
+				fatal("--commit cannot be used with --org")
+			}
+			code, err := scoreOrg(context.Background(), logger, org, policy)
+			if err != nil {
+				fatal(err)
+			}
+			if code != exitOK {
+				exit(code)
+			}
+			return
+		}
+
+		if len(repoList) > 1 {
+			if local != "" || npm != "" || pypi != "" || rubygems != "" || image != "" {
+				fatal("multiple repos cannot be used with --local, --npm, --pypi, --rubygems or --image")
+			}
+			if baselineFile != "" {
+				fatal("--baseline cannot be used with multiple repos")
+			}
+			if subPath != "" {
+				fatal("--path cannot be used with multiple repos")
+			}
+			if commitSHA != clients.HeadSHA {
+				fatal("--commit cannot be used with multiple repos")
+			}
+			// Results are streamed as one JSON line per repo.
+			if format == formatDefault {
+				format = formatJSON
+			}
+			logger, err := githubrepo.NewLogger(*logLevel)
+			if err != nil {
+				fatal(err)
+			}
+			// nolint
+			defer logger.Sync() // Flushes buffer, if any.
+			code, err := scoreRepos(context.Background(), logger, repoList, policy)
+			if err != nil {
+				fatal(err)
+			}
+			if code != exitOK {
+				exit(code)
+			}
+			return
+		}
+		var repo string
+		if len(repoList) == 1 {
+			repo = repoList[0]
+		}
+
+		// Get the URI.
+		uri, err := getURI(repo, local)
+		if err != nil {
+			fatal(err)
+		}
+		pkgURI, err := resolvePackage(npm, pypi, rubygems)
+		if err != nil {
+			fatal(err)
+		}
+		switch {
+		case pkgURI != "" && uri != "":
+			fatal("--repo and --local cannot be used with --npm, --pypi or --rubygems")
+		case image != "" && (pkgURI != "" || uri != ""):
+			fatal("--image cannot be used with --repo, --local, --npm, --pypi or --rubygems")
+		case pkgURI != "":
+			uri = pkgURI
+		case image != "":
+			var revision string
+			uri, revision, err = resolveImage(image)
+			if err != nil {
+				fatal(err)
 			}
+			// Score the revision the image was built from, unless --commit is set.
+			if revision != "" && commitSHA == clients.HeadSHA {
+				commitSHA = revision
+			}
+		case uri == "":
+			fatal("one of --repo, --local, --npm, --pypi, --rubygems or --image is required")
 		}
 
 		ctx := context.Background()
+		if evidence != nil {
+			ctx = clients.WithScanTime(ctx, evidence.ScanTime)
+		}
 		logger, err := githubrepo.NewLogger(*logLevel)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
 		}
 		// nolint
 		defer logger.Sync() // Flushes buffer, if any.
 
-		repoURI, repoClient, ossFuzzRepoClient, ciiClient, repoType, err := getRepoAccessors(ctx, uri, logger)
+		resultBucket, err := openResultBucket(ctx)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
+		}
+		if resultBucket != nil {
+			defer resultBucket.Close()
+		}
+
+		repoURI, repoClient, ossFuzzRepoClient, ciiClient, vulnsClient, packagesClient,
+			repoType, err := getRepoAccessors(ctx, uri, fast, logger)
+		if err != nil {
+			fatal(err)
 		}
 		defer repoClient.Close()
 		if ossFuzzRepoClient != nil {
@@ -343,39 +680,92 @@ var rootCmd = &cobra.Command{
 		// Read docs.
 		checkDocs, err := docs.Read()
 		if err != nil {
-			log.Fatalf("cannot read yaml file: %v", err)
+			fatalf("cannot read yaml file: %v", err)
 		}
 
 		supportedChecks, err := getSupportedChecks(repoType, checkDocs)
 		if err != nil {
-			log.Fatalf("cannot read supported checks: %v", err)
+			fatalf("cannot read supported checks: %v", err)
 		}
 
-		enabledChecks, err := getEnabledChecks(policy, checksToRun, supportedChecks, repoType)
+		enabledChecks, err := getEnabledChecks(policy, checksToRun, checkDocs, supportedChecks, repoType)
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
+		}
+		if format == formatProbe {
+			// Probes consume raw results, so only run the checks they need.
+			requiredChecks, err := probes.RequiredChecks(probesToRun)
+			if err != nil {
+				fatal(err)
+			}
+			enabledChecks, err = getEnabledChecks(nil, requiredChecks, checkDocs, supportedChecks, repoType)
+			if err != nil {
+				fatal(err)
+			}
+		}
+		if format == formatRaw {
+			// Only run the checks which collect raw results.
+			for checkName := range enabledChecks {
+				if !rawChecks[checkName] {
+					delete(enabledChecks, checkName)
+				}
+			}
+		}
+
+		if evidence != nil {
+			if recordEvidenceDir != "" {
+				evidence.recordChecks(enabledChecks)
+			} else if err := evidence.checkReplayChecks(enabledChecks); err != nil {
+				fatal(err)
+			}
 		}
 
 		if format == formatDefault {
+			if fast {
+				fmt.Fprintln(os.Stderr, "Running in --fast mode: checks needing file contents, "+
+					"releases or contributors are skipped or have reduced fidelity")
+			}
 			for checkName := range enabledChecks {
 				fmt.Fprintf(os.Stderr, "Starting [%s]\n", checkName)
 			}
 		}
 
 		if raw && format != "json" {
-			log.Fatalf("only json format is supported")
+			fatalf("only json format is supported")
+		}
+		rawResults := raw || format == formatProbe || format == formatRaw
+		if redact && rawResults {
+			fatalf("--redact does not support raw results")
+		}
+		if baselineFile != "" && rawResults {
+			fatalf("--baseline does not support raw results")
 		}
 
-		repoResult, err := pkg.RunScorecards(ctx, repoURI, raw, enabledChecks, repoClient, ossFuzzRepoClient, ciiClient)
+		progress := newProgress(1, len(enabledChecks))
+		repoResult, err := pkg.RunScorecards(ctx, repoURI, commitSHA, rawResults, enabledChecks,
+			repoClient, ossFuzzRepoClient, ciiClient, vulnsClient, packagesClient, runOptions(policy, progress)...)
+		progress.repoDone(repoURI.URI())
+		progress.finish()
 		if err != nil {
-			log.Fatal(err)
+			fatal(err)
+		}
+		if err := finalizeResult(&repoResult, repoType, supportedChecks, policy); err != nil {
+			fatal(err)
+		}
+		if !rawResults {
+			applyDependents(ctx, &repoResult, packagesClient)
+		}
+		if recordEvidenceDir != "" {
+			evidence.ResolvedCommit = repoResult.Repo.CommitSHA
+			if err := writeEvidenceManifest(recordEvidenceDir, evidence); err != nil {
+				fatalf("Failed to record evidence: %v", err)
+			}
+			if exportEvidence != "" {
+				if err := writeEvidenceArchive(recordEvidenceDir, exportEvidence); err != nil {
+					fatalf("Failed to export evidence: %v", err)
+				}
+			}
 		}
-		repoResult.Metadata = append(repoResult.Metadata, metaData...)
-
-		// Sort them by name
-		sort.Slice(repoResult.Checks, func(i, j int) bool {
-			return repoResult.Checks[i].Name < repoResult.Checks[j].Name
-		})
 
 		if format == formatDefault {
 			for checkName := range enabledChecks {
@@ -384,134 +774,299 @@ var rootCmd = &cobra.Command{
 			fmt.Println("\nRESULTS\n-------")
 		}
 
-		switch format {
-		case formatDefault:
-			err = repoResult.AsString(showDetails, *logLevel, checkDocs, os.Stdout)
-		case formatSarif:
-			// TODO: support config files and update checker.MaxResultScore.
-			err = repoResult.AsSARIF(showDetails, *logLevel, os.Stdout, checkDocs, policy)
-		case formatJSON:
-			if raw {
-				err = repoResult.AsRawJSON(os.Stdout)
-			} else {
-				err = repoResult.AsJSON2(showDetails, *logLevel, checkDocs, os.Stdout)
+		err = writeResult(&repoResult, checkDocs, policy, os.Stdout)
+		if err != nil {
+			fatalf("Failed to output results: %v", err)
+		}
+		if err := persistResult(ctx, resultBucket, &repoResult, checkDocs); err != nil {
+			fatalf("Failed to persist results: %v", err)
+		}
+		regressed := false
+		if baselineFile != "" {
+			regressed, err = compareToBaseline(&repoResult, checkDocs, baselineFile, os.Stderr)
+			if err != nil {
+				fatalf("Failed to compare results to the baseline: %v", err)
 			}
-
-		default:
-			err = sce.WithMessage(sce.ErrScorecardInternal,
-				fmt.Sprintf("invalid format flag: %v. Expected [default, json]", format))
+			regressed = regressed && policy.GetFailOnRegression()
 		}
-		if err != nil {
-			log.Fatalf("Failed to output results: %v", err)
+		if code := exitCode(&repoResult, regressed, failOnInconclusive, os.Stderr); code != exitOK {
+			exit(code)
 		}
 	},
 }
 
-type npmSearchResults struct {
-	Objects []struct {
-		Package struct {
-			Links struct {
-				Repository string `json:"repository"`
-			} `json:"links"`
-		} `json:"package"`
-	} `json:"objects"`
+// exitHooks are run before Run exits the process, which skips deferred calls.
+var exitHooks []func()
+
+// atExit registers f to run before Run exits, e.g. to remove temporary files.
+func atExit(f func()) {
+	exitHooks = append(exitHooks, f)
 }
 
-type pypiSearchResults struct {
-	Info struct {
-		ProjectUrls struct {
-			Source string `json:"Source"`
-		} `json:"project_urls"`
-	} `json:"info"`
+func runExitHooks() {
+	for i := len(exitHooks) - 1; i >= 0; i-- {
+		exitHooks[i]()
+	}
+	exitHooks = nil
 }
 
-type rubyGemsSearchResults struct {
-	SourceCodeURI string `json:"source_code_uri"`
+// exit runs the exit hooks and exits with code.
+func exit(code int) {
+	runExitHooks()
+	os.Exit(code)
 }
 
-// Execute runs the Scorecard commandline.
-func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+// fatal is log.Fatal, running the exit hooks first.
+func fatal(v ...interface{}) {
+	runExitHooks()
+	log.Fatal(v...)
+}
+
+// fatalf is log.Fatalf, running the exit hooks first.
+func fatalf(format string, v ...interface{}) {
+	runExitHooks()
+	log.Fatalf(format, v...)
+}
+
+// exitCode returns the exit status of a run which wrote its results, telling
+// apart the checks which failed to run from the ones which had nothing to score.
+func exitCode(repoResult *pkg.ScorecardResult, regressed, failOnInconclusive bool, w io.Writer) int {
+	var errored, inconclusive []string
+	for i := range repoResult.Checks {
+		check := &repoResult.Checks[i]
+		switch check.State() {
+		case checker.ResultRuntimeError:
+			errored = append(errored, check.Name)
+		case checker.ResultInconclusive:
+			inconclusive = append(inconclusive, check.Name)
+		case checker.ResultScored, checker.ResultNotApplicable:
+		}
+	}
+	switch {
+	case len(errored) > 0:
+		fmt.Fprintf(w, "some checks failed to run: %s\n", strings.Join(errored, ", "))
+		return exitCheckError
+	case regressed:
+		fmt.Fprintln(w, "some check scores regressed versus the baseline")
+		return exitRegression
+	case failOnInconclusive && len(inconclusive) > 0:
+		fmt.Fprintf(w, "some checks were inconclusive: %s\n", strings.Join(inconclusive, ", "))
+		return exitInconclusive
+	default:
+		return exitOK
+	}
+}
+
+// exitCodeSeverity orders the exit codes as exitCode checks for them.
+var exitCodeSeverity = map[int]int{
+	exitOK:           0,
+	exitInconclusive: 1,
+	exitRegression:   2,
+	exitCheckError:   3,
+}
+
+// repoExitCodes combines the exit statuses of the repos scored by one run,
+// which exits with the most severe of them.
+type repoExitCodes struct {
+	mu   sync.Mutex
+	code int
+}
+
+// add records the exit status of a result which was written, telling w
+// why the repo did not exit with exitOK, if so.
+func (c *repoExitCodes) add(repoResult *pkg.ScorecardResult, failOnInconclusive bool, w io.Writer) {
+	var msg bytes.Buffer
+	code := exitCode(repoResult, false, failOnInconclusive, &msg)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if msg.Len() > 0 {
+		fmt.Fprintf(w, "%s: %s", repoResult.Repo.Name, msg.String())
 	}
+	c.raise(code)
 }
 
-// Gets the GitHub repository URL for the npm package.
-//nolint:noctx
-func fetchGitRepositoryFromNPM(packageName string) (string, error) {
-	npmSearchURL := "https://registry.npmjs.org/-/v1/search?text=%s&size=1"
-	const timeout = 10
-	client := &http.Client{
-		Timeout: timeout * time.Second,
+// addError records a repo which failed to score, as a check error.
+func (c *repoExitCodes) addError() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.raise(exitCheckError)
+}
+
+func (c *repoExitCodes) raise(code int) {
+	if exitCodeSeverity[code] > exitCodeSeverity[c.code] {
+		c.code = code
 	}
-	resp, err := client.Get(fmt.Sprintf(npmSearchURL, packageName))
-	if err != nil {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to get npm package json: %v", err))
+}
+
+// finalizeResult records the metadata, capabilities, annotations and suppressions
+// of a result and sorts its checks.
+func finalizeResult(repoResult *pkg.ScorecardResult, repoType string, supportedChecks []string,
+	policy *spol.ScorecardPolicy) error {
+	repoResult.Metadata = append(repoResult.Metadata, metaData...)
+
+	// Record the checks which cannot run on this type of repo,
+	// so results from different forges can be compared honestly.
+	repoResult.Capabilities.Forge = repoType
+	repoResult.Capabilities.Fast = fast
+	for checkName := range getAllChecks() {
+		if !isSupportedCheck(supportedChecks, checkName) {
+			repoResult.Capabilities.Checks[checkName] = pkg.CapabilityUnsupported
+		}
+	}
+	// Private repos are also detected by RunScorecards, when the forge reports it.
+	if private && !repoResult.Repo.Private {
+		if err := repoResult.ApplyPrivateRepo(); err != nil {
+			return fmt.Errorf("cannot apply private repo expectations: %w", err)
+		}
 	}
 
-	defer resp.Body.Close()
-	v := &npmSearchResults{}
-	err = json.NewDecoder(resp.Body).Decode(v)
+	printDegradedChecks(repoResult)
+
+	if excludeAnnotated {
+		repoResult.ApplyAnnotations(repoResult.RepoAnnotations)
+	}
+	annotations, err := storedAnnotations(repoResult.Repo.Name)
 	if err != nil {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to parse npm package json: %v", err))
+		return err
 	}
-	if len(v.Objects) == 0 {
-		return "", sce.WithMessage(sce.ErrScorecardInternal,
-			fmt.Sprintf("could not find source repo for npm package: %s", packageName))
+	repoResult.ApplyAnnotations(annotations)
+	repoResult.SuppressFindings(policy.GetSuppressions())
+
+	// Sort them by name
+	sort.Slice(repoResult.Checks, func(i, j int) bool {
+		return repoResult.Checks[i].Name < repoResult.Checks[j].Name
+	})
+
+	printScanStats(repoResult, os.Stderr)
+
+	if redact {
+		repoResult.Redact()
 	}
-	return v.Objects[0].Package.Links.Repository, nil
+	return nil
 }
 
-// Gets the GitHub repository URL for the pypi package.
-//nolint:noctx
-func fetchGitRepositoryFromPYPI(packageName string) (string, error) {
-	pypiSearchURL := "https://pypi.org/pypi/%s/json"
-	const timeout = 10
-	client := &http.Client{
-		Timeout: timeout * time.Second,
+// printScanStats reports the API calls and wall time of the scan and its checks
+// at the debug log level, and always warns if --max-api-calls was exceeded.
+func printScanStats(repoResult *pkg.ScorecardResult, w io.Writer) {
+	stats := repoResult.Stats
+	if logLevel.Enabled(zapcore.DebugLevel) {
+		for i := range repoResult.Checks {
+			check := &repoResult.Checks[i]
+			fmt.Fprintf(w, "%s: %s made %d API calls in %v\n", repoResult.Repo.Name, check.Name,
+				check.APICalls, check.Duration.Round(time.Millisecond))
+		}
+		fmt.Fprintf(w, "%s: scan made %d API calls in %v\n", repoResult.Repo.Name, stats.APICalls,
+			stats.Duration.Round(time.Millisecond))
 	}
-	resp, err := client.Get(fmt.Sprintf(pypiSearchURL, packageName))
-	if err != nil {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to get pypi package json: %v", err))
+	if stats.APICallBudgetExceeded {
+		fmt.Fprintf(w, "%s: exceeded the budget of %d API calls, the checks making further calls failed\n",
+			repoResult.Repo.Name, maxAPICalls)
 	}
+}
 
-	defer resp.Body.Close()
-	v := &pypiSearchResults{}
-	err = json.NewDecoder(resp.Body).Decode(v)
-	if err != nil {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to parse pypi package json: %v", err))
+// applyDependents counts the dependents of the packages published from the repo
+// with --dependents. deps.dev being unavailable does not fail the run, it is
+// recorded in the metadata of the result.
+func applyDependents(ctx context.Context, repoResult *pkg.ScorecardResult, packagesClient clients.PackagesClient) {
+	if !dependents || packagesClient == nil {
+		return
 	}
-	if v.Info.ProjectUrls.Source == "" {
-		return "", sce.WithMessage(sce.ErrScorecardInternal,
-			fmt.Sprintf("could not find source repo for pypi package: %s", packageName))
+	if err := repoResult.ApplyDependents(ctx, packagesClient); err != nil {
+		repoResult.Metadata = append(repoResult.Metadata, fmt.Sprintf("ignored dependents: %v", err))
 	}
-	return v.Info.ProjectUrls.Source, nil
 }
 
-// Gets the GitHub repository URL for the rubygems package.
-//nolint:noctx
-func fetchGitRepositoryFromRubyGems(packageName string) (string, error) {
-	rubyGemsSearchURL := "https://rubygems.org/api/v1/gems/%s.json"
-	const timeout = 10
-	client := &http.Client{
-		Timeout: timeout * time.Second,
+// printDegradedChecks tells the user which checks the repo client or the
+// token's permissions made skip or score with reduced fidelity, and why.
+func printDegradedChecks(repoResult *pkg.ScorecardResult) {
+	names := make([]string, 0, len(repoResult.Capabilities.Reasons))
+	for name := range repoResult.Capabilities.Reasons {
+		names = append(names, name)
 	}
-	resp, err := client.Get(fmt.Sprintf(rubyGemsSearchURL, packageName))
-	if err != nil {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to get ruby gem json: %v", err))
+	sort.Strings(names)
+	for _, name := range names {
+		outcome := "scored with reduced fidelity"
+		if repoResult.Capabilities.Checks[name] == pkg.CapabilityUnsupported {
+			outcome = "skipped"
+		}
+		fmt.Fprintf(os.Stderr, "%s: %s %s: %s\n", repoResult.Repo.Name, name, outcome,
+			repoResult.Capabilities.Reasons[name])
 	}
+}
+
+// writeResult writes a result in the requested format.
+func writeResult(repoResult *pkg.ScorecardResult, checkDocs docs.Doc,
+	policy *spol.ScorecardPolicy, w io.Writer) error {
+	var err error
+	switch format {
+	case formatDefault:
+		err = repoResult.AsString(showDetails, *logLevel, checkDocs, w)
+	case formatCSV:
+		err = repoResult.AsCSV(checkDocs, w)
+	case formatMarkdown:
+		err = repoResult.AsMarkdown(showDetails, *logLevel, checkDocs, w)
+	case formatHTML:
+		err = repoResult.AsHTML(showDetails, *logLevel, checkDocs, w)
+	case formatProbe:
+		err = repoResult.AsProbe(probesToRun, w)
+	case formatRaw:
+		err = repoResult.AsRawJSON(w)
+	case formatSarif:
+		// TODO: support config files and update checker.MaxResultScore.
+		err = repoResult.AsSARIF(showDetails, *logLevel, w, checkDocs, policy)
+	case formatJSON:
+		if resultSigner != nil {
+			return writeSignedResult(repoResult, checkDocs, resultSigner, w)
+		}
+		if raw {
+			err = repoResult.AsRawJSON(w)
+		} else {
+			err = repoResult.AsJSON2(showDetails, *logLevel, checkDocs, w)
+		}
+
+	default:
+		var plugin string
+		plugin, err = pkg.LookupFormatPlugin(format)
+		if err == nil {
+			err = repoResult.AsPlugin(plugin, showDetails, *logLevel, checkDocs, w)
+		}
+	}
+	//nolint:wrapcheck
+	return err
+}
 
-	defer resp.Body.Close()
-	v := &rubyGemsSearchResults{}
-	err = json.NewDecoder(resp.Body).Decode(v)
+// writeSignedResult writes the JSON result in a DSSE envelope signed by signer,
+// which scorecard verify-result checks.
+func writeSignedResult(repoResult *pkg.ScorecardResult, checkDocs docs.Doc, signer attestation.Signer,
+	w io.Writer) error {
+	var result bytes.Buffer
+	var err error
+	if raw {
+		err = repoResult.AsRawJSON(&result)
+	} else {
+		err = repoResult.AsJSON2(showDetails, *logLevel, checkDocs, &result)
+	}
+	if err != nil {
+		//nolint:wrapcheck
+		return err
+	}
+	envelope, err := attestation.SignResult(context.Background(), result.Bytes(), signer)
 	if err != nil {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("failed to parse ruby gem json: %v", err))
+		//nolint:wrapcheck
+		return err
 	}
-	if v.SourceCodeURI == "" {
-		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("could not find source repo for ruby gem: %v", err))
+	if err := json.NewEncoder(w).Encode(envelope); err != nil {
+		return fmt.Errorf("encoding signed result: %w", err)
+	}
+	return nil
+}
+
+// Execute runs the Scorecard commandline.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
-	return v.SourceCodeURI, nil
 }
 
 // Enables checks by name.
@@ -531,8 +1086,19 @@ func enableCheck(checkName string, enabledChecks *checker.CheckNameToFnMap) bool
 func init() {
 	// Add the zap flag manually
 	rootCmd.PersistentFlags().AddGoFlagSet(goflag.CommandLine)
-	rootCmd.Flags().StringVar(&repo, "repo", "", "repository to check")
+	rootCmd.Flags().StringArrayVar(&repos, "repo", []string{},
+		"repository to check, repeat to check several GitHub repositories concurrently")
+	rootCmd.Flags().StringVar(&repoFile, "repo-file", "",
+		"file listing GitHub repositories to check concurrently, one per line")
+	rootCmd.Flags().IntVar(&workers, "workers", 4,
+		"number of repositories checked concurrently with --repo-file or several --repo")
 	rootCmd.Flags().StringVar(&local, "local", "", "local folder to check")
+	rootCmd.Flags().StringVar(&org, "org", "",
+		"GitHub organization to check, e.g. github.com/myorg: all its non-archived repos are checked")
+	rootCmd.Flags().StringSliceVar(&orgIncludes, "include", []string{},
+		"with --org, only check repos whose names match one of these globs")
+	rootCmd.Flags().StringSliceVar(&orgExcludes, "exclude", []string{},
+		"with --org, skip repos whose names match one of these globs")
 	rootCmd.Flags().StringVar(
 		&npm, "npm", "",
 		"npm package to check, given that the npm package has a GitHub repository")
@@ -542,8 +1108,13 @@ func init() {
 	rootCmd.Flags().StringVar(
 		&rubygems, "rubygems", "",
 		"rubygems package to check, given that the rubygems package has a GitHub repository")
+	rootCmd.Flags().StringVar(
+		&image, "image", "",
+		"container image to check, e.g. ghcr.io/owner/app:tag, whose source repo and commit are read from its "+
+			imageSourceAnnotation+" and "+imageRevisionAnnotation+" annotations or labels")
 	rootCmd.Flags().StringVar(&format, "format", formatDefault,
-		"output format. allowed values are [default, sarif, json]")
+		"output format. allowed values are [default, sarif, json, csv, markdown, html, probe, raw] "+
+			"or x for a scorecard-format-x executable on the PATH")
 	rootCmd.Flags().StringSliceVar(
 		&metaData, "metadata", []string{}, "metadata for the project. It can be multiple separated by commas")
 	rootCmd.Flags().BoolVar(&showDetails, "show-details", false, "show extra details about each check")
@@ -552,8 +1123,72 @@ func init() {
 		checkNames = append(checkNames, checkName)
 	}
 	rootCmd.Flags().StringSliceVar(&checksToRun, "checks", []string{},
-		fmt.Sprintf("Checks to run. Possible values are: %s", strings.Join(checkNames, ",")))
+		fmt.Sprintf("Checks to run, as names, globs, tag:<tag>, or any of these prefixed with - to exclude them. "+
+			"Possible names are: %s", strings.Join(checkNames, ",")))
 	rootCmd.Flags().StringVar(&policyFile, "policy", "", "policy to enforce")
+	rootCmd.Flags().StringVar(&subPath, "path", "",
+		"directory of the repo, e.g. a monorepo component, the checks analyzing files are restricted to")
+	rootCmd.Flags().StringVar(&signKey, "sign-key", "",
+		"PEM-encoded ECDSA private key, or gcpkms://projects/.../cryptoKeyVersions/N for a Cloud KMS key, "+
+			"to sign the JSON results with, see scorecard verify-result")
+	rootCmd.Flags().StringVar(&recordEvidenceDir, "record-evidence", "",
+		"directory to record the API responses the scan used to, with a manifest of their timestamps and ETags")
+	rootCmd.Flags().StringVar(&replayEvidenceDir, "replay-evidence", "",
+		"directory recorded by --record-evidence to replay the scan from, reproducing its results without API calls")
+	rootCmd.Flags().StringVar(&exportEvidence, "export-evidence", "",
+		"file to archive the evidence of the scan to, like --record-evidence, e.g. bundle.tar.zst")
+	rootCmd.Flags().StringVar(&fromEvidence, "from-evidence", "",
+		"archive written by --export-evidence to replay the scan from, like --replay-evidence")
+	rootCmd.Flags().StringVar(&commitSHA, "commit", clients.HeadSHA,
+		"commit SHA or tag to analyze, instead of the latest commit on the default branch")
+	rootCmd.Flags().StringSliceVar(&probesToRun, "probes", []string{},
+		fmt.Sprintf("Probes to run, implies --format=probe. Possible values are: %s", strings.Join(probes.All(), ",")))
+	rootCmd.Flags().StringVar(&baselineFile, "baseline", "",
+		"JSON results to compare the checks against, listing the ones which regressed. "+
+			"Fails the run on regressions if the policy sets failOnRegression")
+	rootCmd.Flags().StringVar(&configFile, configFlag, "",
+		"YAML file setting any of these flags by name, e.g. format: json. Flags are also set by environment variables, "+
+			"e.g. "+flagEnvVar("format")+"=json. The command line overrides them, and they override the file")
+	rootCmd.Flags().StringVar(&tokenEnv, "token-env", "",
+		"environment variable to read the GitHub token from, instead of "+githubTokenEnv)
+	rootCmd.Flags().BoolVar(&dependents, "dependents", false,
+		"add the packages published from the repo, their ecosystems and their number of dependents "+
+			"according to deps.dev to the json results")
+	rootCmd.Flags().BoolVar(&dependencyVulns, "dependency-vulnerabilities", false,
+		"also look up the dependencies pinned by the repo's go.sum, package-lock.json, requirements.txt and "+
+			"Cargo.lock files in OSV in the Vulnerabilities check")
+	rootCmd.Flags().BoolVar(&showProgress, "progress", false,
+		"report the checks completed per repo, an ETA and the GitHub API quota left to stderr")
+	rootCmd.Flags().BoolVar(&failOnInconclusive, "fail-on-inconclusive", false,
+		fmt.Sprintf("exit with status %d if a check had nothing to score. Checks which failed to run "+
+			"always exit with status %d", exitInconclusive, exitCheckError))
+	rootCmd.Flags().StringVar(&annotationsFile, "annotations", "",
+		"file storing triage annotations, see `scorecard annotate`")
+	rootCmd.Flags().IntVar(&maxAPICalls, "max-api-calls", 0,
+		"fail the checks making API calls once this many were made for a repo, instead of exhausting the "+
+			"token's quota. Zero for no budget. --verbosity debug reports the calls made per check")
+	rootCmd.Flags().DurationVar(&checkTimeout, "check-timeout", pkg.DefaultCheckTimeout,
+		"fail the checks which do not complete in this time, e.g. because of a hung API call, with a runtime "+
+			"error while the other checks complete normally. Zero for no timeout")
+	rootCmd.Flags().BoolVar(&fast, "fast", false,
+		"only use repo metadata APIs, skipping file contents, releases and contributors")
+	rootCmd.Flags().BoolVar(&private, "private", false,
+		"score the repo as a private repo, whose checks for evidence only public repos can have are not applicable "+
+			"instead of 0. Private GitHub, Gitea, Bitbucket and Azure DevOps repos are detected automatically")
+	rootCmd.Flags().BoolVar(&excludeAnnotated, "exclude-annotated", false,
+		"treat the warnings the maintainers annotated as wont-fix or not-applicable in the repo's "+
+			pkg.MaintainerAnnotationsFile+" as info, so they do not show up as SARIF results")
+	rootCmd.Flags().BoolVar(&redact, "redact", false,
+		"remove the details of the checks, which may reveal file paths, branch names and code snippets, "+
+			"keeping only scores and reasons")
+	rootCmd.Flags().StringVar(&outputDir, "output-dir", "",
+		"directory to also write the detailed JSON result to, as <repo>/<date>-<commit>.json")
+	rootCmd.Flags().StringVar(&outputBucket, "output-bucket", "",
+		"bucket URL to also write the detailed JSON result to, e.g. gs://bucket, s3://bucket?region=us-east-1 "+
+			"or file:///dir, as <repo>/<date>-<commit>.json")
+	rootCmd.Flags().StringVar(&githubAPI, "github-api-url", "",
+		"REST API URL of a GitHub Enterprise Server instance, e.g. https://ghe.example.com/api/v3. "+
+			"Defaults to $GITHUB_API_URL, or https://$GH_HOST/api/v3")
 
 	var v6 bool
 	_, v6 = os.LookupEnv("SCORECARD_V6")
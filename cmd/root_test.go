@@ -0,0 +1,181 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/checker"
+	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+	scored := checker.CreateMinScoreResult("Fuzzing", "project is not fuzzed")
+	inconclusive := checker.CreateInconclusiveResult("Signed-Releases", "no releases found")
+	notApplicable := checker.CreateNotApplicableResult("CII-Best-Practices", "not applicable to private repos")
+	errored := checker.CreateRuntimeErrorResult("Vulnerabilities",
+		sce.WithMessage(sce.ErrScorecardInternal, "osv.dev unreachable"))
+	tests := []struct {
+		name               string
+		checks             []checker.CheckResult
+		regressed          bool
+		failOnInconclusive bool
+		want               int
+		wantMsg            string
+	}{
+		{
+			name:   "scored and not applicable",
+			checks: []checker.CheckResult{scored, notApplicable},
+			want:   exitOK,
+		},
+		{
+			name:   "inconclusive",
+			checks: []checker.CheckResult{scored, inconclusive},
+			want:   exitOK,
+		},
+		{
+			name:               "fail on inconclusive",
+			checks:             []checker.CheckResult{scored, inconclusive, notApplicable},
+			failOnInconclusive: true,
+			want:               exitInconclusive,
+			wantMsg:            "some checks were inconclusive: Signed-Releases",
+		},
+		{
+			name:      "regressed",
+			checks:    []checker.CheckResult{scored, inconclusive},
+			regressed: true,
+			want:      exitRegression,
+			wantMsg:   "regressed",
+		},
+		{
+			name:               "runtime error",
+			checks:             []checker.CheckResult{errored, inconclusive},
+			regressed:          true,
+			failOnInconclusive: true,
+			want:               exitCheckError,
+			wantMsg:            "some checks failed to run: Vulnerabilities",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			result := pkg.ScorecardResult{Checks: tt.checks}
+			if got := exitCode(&result, tt.regressed, tt.failOnInconclusive, &w); got != tt.want {
+				t.Errorf("exitCode() = %d, want %d", got, tt.want)
+			}
+			if !strings.Contains(w.String(), tt.wantMsg) {
+				t.Errorf("exitCode() printed %q, want %q", w.String(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestRepoExitCodes(t *testing.T) {
+	t.Parallel()
+	scored := checker.CreateMinScoreResult("Fuzzing", "project is not fuzzed")
+	inconclusive := checker.CreateInconclusiveResult("Signed-Releases", "no releases found")
+	errored := checker.CreateRuntimeErrorResult("Vulnerabilities",
+		sce.WithMessage(sce.ErrScorecardInternal, "osv.dev unreachable"))
+	tests := []struct {
+		name               string
+		repos              [][]checker.CheckResult
+		failOnInconclusive bool
+		want               int
+		wantMsg            string
+	}{
+		{
+			name:  "all scored",
+			repos: [][]checker.CheckResult{{scored}, {scored, inconclusive}},
+			want:  exitOK,
+		},
+		{
+			name:               "one inconclusive",
+			repos:              [][]checker.CheckResult{{scored}, {scored, inconclusive}},
+			failOnInconclusive: true,
+			want:               exitInconclusive,
+			wantMsg:            "repo1: some checks were inconclusive: Signed-Releases",
+		},
+		{
+			name:               "runtime error before inconclusive",
+			repos:              [][]checker.CheckResult{{errored}, {inconclusive}, {scored}},
+			failOnInconclusive: true,
+			want:               exitCheckError,
+			wantMsg:            "repo0: some checks failed to run: Vulnerabilities",
+		},
+		{
+			name:               "runtime error after inconclusive",
+			repos:              [][]checker.CheckResult{{inconclusive}, {errored}, {scored}},
+			failOnInconclusive: true,
+			want:               exitCheckError,
+			wantMsg:            "repo1: some checks failed to run: Vulnerabilities",
+		},
+		{
+			name:  "every repo failed to score",
+			repos: [][]checker.CheckResult{nil, nil},
+			want:  exitCheckError,
+		},
+		{
+			name:               "one repo failed to score",
+			repos:              [][]checker.CheckResult{{inconclusive}, nil, {scored}},
+			failOnInconclusive: true,
+			want:               exitCheckError,
+			wantMsg:            "repo0: some checks were inconclusive: Signed-Releases",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			var exitCodes repoExitCodes
+			for i, checks := range tt.repos {
+				// A nil repo failed to score.
+				if checks == nil {
+					exitCodes.addError()
+					continue
+				}
+				result := pkg.ScorecardResult{Checks: checks}
+				result.Repo.Name = fmt.Sprintf("repo%d", i)
+				exitCodes.add(&result, tt.failOnInconclusive, &w)
+			}
+			if exitCodes.code != tt.want {
+				t.Errorf("exit code = %d, want %d", exitCodes.code, tt.want)
+			}
+			if !strings.Contains(w.String(), tt.wantMsg) {
+				t.Errorf("printed %q, want %q", w.String(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestExitHooks(t *testing.T) {
+	var ran []int
+	atExit(func() { ran = append(ran, 1) })
+	atExit(func() { ran = append(ran, 2) })
+	runExitHooks()
+	runExitHooks()
+	if diff := cmp.Diff([]int{2, 1}, ran); diff != "" {
+		t.Errorf("exit hooks mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/githubrepo"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	"github.com/ossf/scorecard/v3/pkg"
+	"github.com/ossf/scorecard/v3/sbom"
+)
+
+var (
+	sbomInput  string
+	sbomChecks []string
+)
+
+//nolint:gochecknoinits
+func init() {
+	sbomCmd.Flags().StringVar(&sbomInput, "input", "", "CycloneDX or SPDX JSON SBOM to enrich")
+	sbomCmd.Flags().StringSliceVar(&sbomChecks, "checks", []string{},
+		"checks to run on each component, all checks supporting GitHub repos by default")
+	rootCmd.AddCommand(sbomCmd)
+}
+
+var sbomCmd = &cobra.Command{
+	Use:   "sbom --input=<file>",
+	Short: "Embed Scorecard results in a CycloneDX or SPDX SBOM",
+	Long: `Run checks on the GitHub source repository of each component of a CycloneDX
+or SPDX JSON SBOM, and write the SBOM to stdout with the results embedded as
+CycloneDX properties and external references, or SPDX annotations.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if sbomInput == "" {
+			log.Fatal("--input is required")
+		}
+		content, err := os.ReadFile(sbomInput)
+		if err != nil {
+			log.Fatalf("cannot read SBOM: %v", err)
+		}
+		logger, err := githubrepo.NewLogger(*logLevel)
+		if err != nil {
+			log.Fatalf("unable to construct logger: %v", err)
+		}
+		//nolint:errcheck
+		defer logger.Sync() // flushes buffer, if any
+
+		checkDocs, err := docs.Read()
+		if err != nil {
+			log.Fatalf("cannot read yaml file: %v", err)
+		}
+		supportedChecks, err := getSupportedChecks(repoTypeGitHub, checkDocs)
+		if err != nil {
+			log.Fatalf("cannot read supported checks: %v", err)
+		}
+		enabledChecks, err := getEnabledChecks(nil, sbomChecks, checkDocs, supportedChecks, repoTypeGitHub)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ctx := context.Background()
+		ossFuzzRepoClient, err := githubrepo.CreateOssFuzzRepoClient(ctx, logger)
+		if err != nil {
+			log.Fatalf("githubrepo.CreateOssFuzzRepoClient: %v", err)
+		}
+		defer ossFuzzRepoClient.Close()
+		score := func(ctx context.Context, repoURI string) (*pkg.ScorecardResult, error) {
+			repo, err := githubrepo.MakeGithubRepo(repoURI)
+			if err != nil {
+				return nil, fmt.Errorf("githubrepo.MakeGithubRepo: %w", err)
+			}
+			repoClient := githubrepo.CreateGithubRepoClient(ctx, logger)
+			defer repoClient.Close()
+			result, err := pkg.RunScorecards(ctx, repo, clients.HeadSHA, false, enabledChecks,
+				repoClient, ossFuzzRepoClient, clients.DefaultCIIBestPracticesClient(),
+				clients.DefaultVulnerabilitiesClient(), clients.DefaultPackagesClient())
+			if err != nil {
+				return nil, fmt.Errorf("pkg.RunScorecards: %w", err)
+			}
+			return &result, nil
+		}
+
+		enriched, _, err := sbom.Enrich(ctx, content, score, checkDocs, logger)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if _, err := os.Stdout.Write(enriched); err != nil {
+			log.Fatalf("writing SBOM: %v", err)
+		}
+	},
+}
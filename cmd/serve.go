@@ -15,30 +15,58 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"html/template"
 	"log"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"go.opencensus.io/plugin/ochttp"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
 
-	"github.com/ossf/scorecard/v3/checks"
 	"github.com/ossf/scorecard/v3/clients"
 	"github.com/ossf/scorecard/v3/clients/githubrepo"
+	githubstats "github.com/ossf/scorecard/v3/clients/githubrepo/stats"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
 	"github.com/ossf/scorecard/v3/pkg"
+	"github.com/ossf/scorecard/v3/stats"
+)
+
+var serveCacheTTL time.Duration
+
+const (
+	projectsPrefix    = "/projects/"
+	badgeSVGResource  = "badge"
+	badgeJSONResource = "badge.json"
 )
 
 //nolint:gochecknoinits
 func init() {
+	serveCmd.Flags().DurationVar(&serveCacheTTL, "cache-ttl", time.Hour,
+		"how long results served under /projects/ are cached, 0 disables caching")
+	serveCmd.Flags().StringVar(&annotationsFile, "annotations", "",
+		"file storing triage annotations, see `scorecard annotate`")
 	rootCmd.AddCommand(serveCmd)
 }
 
 var serveCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Serve the scorecard program over http",
-	Long:  ``,
+	Long: `Serve the scorecard program over http.
+
+GET /?repo={host}/{owner}/{repo} renders the results as HTML, or as JSON when
+the request has a Content-Type of application/json.
+GET /projects/{host}/{owner}/{repo} returns the results as JSON, cached for
+--cache-ttl. Appending /badge returns an SVG badge of the aggregate score, and
+/badge.json returns it as shields.io endpoint JSON.
+GET /metrics returns the metrics of the server in the Prometheus text format.
+Traces are exported when OTEL_EXPORTER_OTLP_ENDPOINT is set.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		logger, err := githubrepo.NewLogger(*logLevel)
 		if err != nil {
@@ -51,31 +79,34 @@ var serveCmd = &cobra.Command{
 		if err != nil {
 			sugar.Panic(err)
 		}
+		checkDocs, err := docs.Read()
+		if err != nil {
+			sugar.Panic(err)
+		}
+		cache := newResultCache(serveCacheTTL)
+
+		metrics, err := startServeMetrics()
+		if err != nil {
+			sugar.Panic(err)
+		}
+		http.Handle("/metrics", metrics)
+		stopTraces, err := stats.StartTraceExporter()
+		if err != nil {
+			sugar.Panic(err)
+		}
+		defer stopTraces()
 
 		http.HandleFunc("/", func(rw http.ResponseWriter, r *http.Request) {
 			repoParam := r.URL.Query().Get("repo")
-			const length = 3
-			s := strings.SplitN(repoParam, "/", length)
-			if len(s) != length {
-				rw.WriteHeader(http.StatusBadRequest)
-			}
-			repo, err := githubrepo.MakeGithubRepo(repoParam)
-			if err != nil {
-				rw.WriteHeader(http.StatusBadRequest)
-			}
-			ctx := r.Context()
-			repoClient := githubrepo.CreateGithubRepoClient(ctx, logger)
-			ossFuzzRepoClient, err := githubrepo.CreateOssFuzzRepoClient(ctx, logger)
-			if err != nil {
-				sugar.Error(err)
-				rw.WriteHeader(http.StatusInternalServerError)
+			if _, _, err := parseServedRepo(repoParam); err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
 			}
-			defer ossFuzzRepoClient.Close()
-			ciiClient := clients.DefaultCIIBestPracticesClient()
-			repoResult, err := pkg.RunScorecards(ctx, repo, false, checks.AllChecks, repoClient, ossFuzzRepoClient, ciiClient)
+			repoResult, err := scoreRepo(r.Context(), logger, repoParam, checkDocs)
 			if err != nil {
 				sugar.Error(err)
 				rw.WriteHeader(http.StatusInternalServerError)
+				return
 			}
 
 			if r.Header.Get("Content-Type") == "application/json" {
@@ -89,18 +120,137 @@ var serveCmd = &cobra.Command{
 				sugar.Warn(err)
 			}
 		})
+		http.HandleFunc(projectsPrefix, func(rw http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				rw.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			project, resource, err := parseProjectPath(r.URL.Path)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+			repoResult, err := cache.getOrScore(project, func() (*pkg.ScorecardResult, error) {
+				// The result is shared by the concurrent requests for the project,
+				// so scoring it is not cancelled with the request which started it.
+				return scoreRepo(context.Background(), logger, project, checkDocs)
+			})
+			if err != nil {
+				sugar.Error(err)
+				rw.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			switch resource {
+			case badgeSVGResource:
+				rw.Header().Set("Content-Type", "image/svg+xml")
+				err = repoResult.AsBadgeSVG(checkDocs, rw)
+			case badgeJSONResource:
+				rw.Header().Set("Content-Type", "application/json")
+				err = repoResult.AsBadgeJSON(checkDocs, rw)
+			default:
+				rw.Header().Set("Content-Type", "application/json")
+				err = repoResult.AsJSON(showDetails, *logLevel, rw)
+			}
+			if err != nil {
+				sugar.Error(err)
+			}
+		})
 		port := os.Getenv("PORT")
 		if port == "" {
 			port = "8080"
 		}
 		fmt.Printf("Listening on localhost:%s\n", port)
-		err = http.ListenAndServe(fmt.Sprintf("0.0.0.0:%s", port), nil)
+		err = http.ListenAndServe(fmt.Sprintf("0.0.0.0:%s", port), &ochttp.Handler{})
 		if err != nil {
 			log.Fatal("ListenAndServe ", err)
 		}
 	},
 }
 
+// startServeMetrics registers the views served on /metrics.
+func startServeMetrics() (*stats.PrometheusExporter, error) {
+	if err := view.Register(
+		&stats.CheckRuntime,
+		&stats.CheckDuration,
+		&stats.CheckErrorCount,
+		&stats.OutgoingHTTPRequests,
+		&githubstats.GithubTokens); err != nil {
+		return nil, fmt.Errorf("view.Register: %w", err)
+	}
+	exporter := stats.NewPrometheusExporter()
+	view.RegisterExporter(exporter)
+	return exporter, nil
+}
+
+// parseProjectPath splits /projects/{host}/{owner}/{repo}[/{resource}] into
+// the repo and the optional resource. The repo may be on any supported forge,
+// e.g. /projects/dev.azure.com/{organization}/{project}/_git/{repo}.
+func parseProjectPath(path string) (project, resource string, err error) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, projectsPrefix), "/"), "/")
+	const minLength = 3
+	if n := len(parts); n > minLength && (parts[n-1] == badgeSVGResource || parts[n-1] == badgeJSONResource) {
+		resource = parts[n-1]
+		parts = parts[:n-1]
+	}
+	project = strings.Join(parts, "/")
+	_, repoType, err := parseServedRepo(project)
+	if err != nil {
+		return "", "", err
+	}
+	// Paths below a GitHub repo, like /projects/github.com/{owner}/{repo}/checks,
+	// parse as a GitHub repo too.
+	if repoType == repoTypeGitHub && len(parts) != minLength {
+		return "", "", sce.WithMessage(sce.ErrorInvalidURL,
+			fmt.Sprintf("expected /projects/{host}/{owner}/{repo}[/badge|/badge.json], got: %s", path))
+	}
+	return project, resource, nil
+}
+
+// parseServedRepo parses the repo of a request like parseRepo, but for local
+// directories, which are not scored for HTTP clients.
+func parseServedRepo(uri string) (clients.Repo, string, error) {
+	repo, repoType, err := parseRepo(uri)
+	if err != nil || repoType == repoTypeLocal {
+		return nil, "", sce.WithMessage(sce.ErrorInvalidURL, fmt.Sprintf("unsupported repo: %s", uri))
+	}
+	return repo, repoType, nil
+}
+
+func scoreRepo(ctx context.Context, logger *zap.Logger, uri string, checkDocs docs.Doc) (*pkg.ScorecardResult, error) {
+	repo, repoType, err := parseServedRepo(uri)
+	if err != nil {
+		return nil, err
+	}
+	supportedChecks, err := getSupportedChecks(repoType, checkDocs)
+	if err != nil {
+		return nil, err
+	}
+	enabledChecks, err := getEnabledChecks(nil, nil, checkDocs, supportedChecks, repoType)
+	if err != nil {
+		return nil, err
+	}
+	var ossFuzzRepoClient clients.RepoClient
+	if listedPublicly(repo, repoType) {
+		ossFuzzRepoClient, err = createOssFuzzRepoClient(ctx, false, logger)
+		if err != nil {
+			return nil, fmt.Errorf("githubrepo.CreateOssFuzzRepoClient: %w", err)
+		}
+		defer ossFuzzRepoClient.Close()
+	}
+	repoClient, ciiClient, vulnsClient, packagesClient := getRepoClients(ctx, repo, repoType, false, logger)
+	repoResult, err := pkg.RunScorecards(ctx, repo, clients.HeadSHA, false, enabledChecks,
+		repoClient, ossFuzzRepoClient, ciiClient, vulnsClient, packagesClient)
+	if err != nil {
+		return nil, fmt.Errorf("pkg.RunScorecards: %w", err)
+	}
+	annotations, err := storedAnnotations(repoResult.Repo.Name)
+	if err != nil {
+		return nil, err
+	}
+	repoResult.ApplyAnnotations(annotations)
+	return &repoResult, nil
+}
+
 const tpl = `
 <!DOCTYPE html>
 <html>
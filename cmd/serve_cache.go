@@ -0,0 +1,99 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+type cachedResult struct {
+	result  *pkg.ScorecardResult
+	expires time.Time
+}
+
+// resultCache holds scorecard results for a fixed TTL, keyed by repo.
+type resultCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	now      func() time.Time
+	entries  map[string]cachedResult
+	inflight singleflight.Group
+}
+
+func newResultCache(ttl time.Duration) *resultCache {
+	return &resultCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cachedResult),
+	}
+}
+
+func (c *resultCache) get(key string) (*pkg.ScorecardResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.result, true
+}
+
+func (c *resultCache) put(key string, result *pkg.ScorecardResult) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cachedResult{
+		result:  result,
+		expires: c.now().Add(c.ttl),
+	}
+}
+
+// getOrScore returns the result cached for key, or else the one score returns.
+// Concurrent calls for a key which is not cached share a single call to score.
+func (c *resultCache) getOrScore(key string,
+	score func() (*pkg.ScorecardResult, error)) (*pkg.ScorecardResult, error) {
+	if result, ok := c.get(key); ok {
+		return result, nil
+	}
+	v, err, _ := c.inflight.Do(key, func() (interface{}, error) {
+		// The result may have been put since get missed it.
+		if result, ok := c.get(key); ok {
+			return result, nil
+		}
+		result, err := score()
+		if err != nil {
+			return nil, err
+		}
+		c.put(key, result)
+		return result, nil
+	})
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	//nolint:forcetypeassert // The only values returned above are results.
+	return v.(*pkg.ScorecardResult), nil
+}
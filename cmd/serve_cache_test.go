@@ -0,0 +1,214 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+func TestResultCache(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		ttl     time.Duration
+		elapsed time.Duration
+		want    bool
+	}{
+		{
+			name:    "fresh entry",
+			ttl:     time.Hour,
+			elapsed: time.Minute,
+			want:    true,
+		},
+		{
+			name:    "expired entry",
+			ttl:     time.Hour,
+			elapsed: 2 * time.Hour,
+			want:    false,
+		},
+		{
+			name:    "caching disabled",
+			ttl:     0,
+			elapsed: 0,
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+			now := start
+			c := newResultCache(tt.ttl)
+			c.now = func() time.Time { return now }
+
+			result := &pkg.ScorecardResult{}
+			c.put("github.com/owner/repo", result)
+			now = start.Add(tt.elapsed)
+
+			got, ok := c.get("github.com/owner/repo")
+			if ok != tt.want {
+				t.Fatalf("get() ok = %v, want %v", ok, tt.want)
+			}
+			if ok && got != result {
+				t.Errorf("get() returned a different result")
+			}
+		})
+	}
+}
+
+func TestResultCacheGetOrScore(t *testing.T) {
+	t.Parallel()
+	c := newResultCache(time.Hour)
+	errScore := sce.WithMessage(sce.ErrScorecardInternal, "score failed")
+	if _, err := c.getOrScore("github.com/owner/repo", func() (*pkg.ScorecardResult, error) {
+		return nil, errScore
+	}); !errors.Is(err, sce.ErrScorecardInternal) {
+		t.Fatalf("getOrScore() error = %v, want %v", err, sce.ErrScorecardInternal)
+	}
+
+	// Failures are not cached, and the concurrent requests which follow
+	// share one scoring of the repo.
+	var calls int32
+	release := make(chan struct{})
+	result := &pkg.ScorecardResult{}
+	score := func() (*pkg.ScorecardResult, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return result, nil
+	}
+	const requests = 10
+	var wg sync.WaitGroup
+	results := make([]*pkg.ScorecardResult, requests)
+	for i := 0; i < requests; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := c.getOrScore("github.com/owner/repo", score)
+			if err != nil {
+				t.Errorf("getOrScore() error = %v", err)
+			}
+			results[i] = got
+		}()
+	}
+	close(release)
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("score called %d times, want 1", calls)
+	}
+	for i, got := range results {
+		if got != result {
+			t.Errorf("request %d got a different result", i)
+		}
+	}
+}
+
+func TestParseProjectPath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		path         string
+		want         string
+		wantResource string
+		wantErr      bool
+	}{
+		{
+			name: "github project",
+			path: "/projects/github.com/ossf/scorecard",
+			want: "github.com/ossf/scorecard",
+		},
+		{
+			name: "trailing slash",
+			path: "/projects/github.com/ossf/scorecard/",
+			want: "github.com/ossf/scorecard",
+		},
+		{
+			name:    "missing repo",
+			path:    "/projects/github.com/ossf",
+			wantErr: true,
+		},
+		{
+			name:         "svg badge",
+			path:         "/projects/github.com/ossf/scorecard/badge",
+			want:         "github.com/ossf/scorecard",
+			wantResource: "badge",
+		},
+		{
+			name:         "shields.io badge",
+			path:         "/projects/github.com/ossf/scorecard/badge.json",
+			want:         "github.com/ossf/scorecard",
+			wantResource: "badge.json",
+		},
+		{
+			name:    "unknown resource",
+			path:    "/projects/github.com/ossf/scorecard/checks",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported host",
+			path:    "/projects/gitlab.com/ossf/scorecard",
+			wantErr: true,
+		},
+		{
+			name: "gitea project",
+			path: "/projects/codeberg.org/forgejo/forgejo",
+			want: "codeberg.org/forgejo/forgejo",
+		},
+		{
+			name:         "bitbucket badge",
+			path:         "/projects/bitbucket.org/atlassian/python-bitbucket/badge",
+			want:         "bitbucket.org/atlassian/python-bitbucket",
+			wantResource: "badge",
+		},
+		{
+			name: "azure devops project",
+			path: "/projects/dev.azure.com/org/project/_git/repo",
+			want: "dev.azure.com/org/project/_git/repo",
+		},
+		{
+			name:         "repo named like a resource",
+			path:         "/projects/github.com/ossf/badge",
+			want:         "github.com/ossf/badge",
+			wantResource: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, resource, err := parseProjectPath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseProjectPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, sce.ErrorInvalidURL) {
+				t.Errorf("parseProjectPath() error = %v, want %v", err, sce.ErrorInvalidURL)
+			}
+			if got != tt.want {
+				t.Errorf("parseProjectPath() = %q, want %q", got, tt.want)
+			}
+			if resource != tt.wantResource {
+				t.Errorf("parseProjectPath() resource = %q, want %q", resource, tt.wantResource)
+			}
+		})
+	}
+}
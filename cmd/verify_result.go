@@ -0,0 +1,121 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ossf/scorecard/v3/attestation"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+var (
+	verifyResultKey  string
+	verifyResultRepo string
+)
+
+//nolint:gochecknoinits
+func init() {
+	verifyResultCmd.Flags().StringVar(&verifyResultKey, "key", "",
+		"PEM-encoded ECDSA public key, or gcpkms://projects/.../cryptoKeyVersions/N for a Cloud KMS key")
+	verifyResultCmd.Flags().StringVar(&verifyResultRepo, "repo", "",
+		"repository the results must be about, e.g. github.com/owner/repo")
+	rootCmd.AddCommand(verifyResultCmd)
+}
+
+var verifyResultCmd = &cobra.Command{
+	Use:   "verify-result --key=<key> [file]",
+	Short: "Verify the signature of JSON results",
+	Long: `Verify that JSON results signed with --sign-key, read from a file or stdin,
+were signed by a key and have not been modified since, and write them to stdout.
+Several results, one signed result per line, are verified in turn. Exits with
+status 1 if any of them cannot be verified.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if verifyResultKey == "" {
+			log.Fatal("--key is required")
+		}
+		key, err := attestation.LoadPublicKey(context.Background(), verifyResultKey)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var r io.Reader = os.Stdin
+		if len(args) == 1 {
+			f, err := os.Open(args[0])
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer f.Close()
+			r = f
+		}
+		if err := verifyResults(r, key, verifyResultRepo, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	},
+}
+
+// verifyResults writes the results of the signed results in r to w, after checking
+// that they were signed by key and, if repo is set, that they are about repo.
+func verifyResults(r io.Reader, key *ecdsa.PublicKey, repo string, w io.Writer) error {
+	decoder := json.NewDecoder(r)
+	verified := 0
+	for {
+		var envelope attestation.Envelope
+		err := decoder.Decode(&envelope)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("decoding signed result: %v", err))
+		}
+		result, err := attestation.VerifyResult(&envelope, key)
+		if err != nil {
+			//nolint:wrapcheck
+			return err
+		}
+		if repo != "" {
+			var info struct {
+				Repo struct {
+					Name string `json:"name"`
+				} `json:"repo"`
+			}
+			if err := json.Unmarshal(result, &info); err != nil {
+				return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Unmarshal: %v", err))
+			}
+			if info.Repo.Name != repo {
+				return sce.WithMessage(sce.ErrScorecardInternal,
+					fmt.Sprintf("the result is about %s, not %s", info.Repo.Name, repo))
+			}
+		}
+		if _, err := w.Write(result); err != nil {
+			return fmt.Errorf("writing result: %w", err)
+		}
+		verified++
+	}
+	if verified == 0 {
+		return sce.WithMessage(sce.ErrScorecardInternal, "no signed result found")
+	}
+	return nil
+}
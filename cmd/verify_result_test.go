@@ -0,0 +1,157 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/attestation"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+func TestVerifyResults(t *testing.T) {
+	t.Parallel()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	sign := func(result string) string {
+		envelope, err := attestation.SignResult(context.Background(), []byte(result), attestation.NewKeySigner(key, "test"))
+		if err != nil {
+			t.Fatalf("SignResult: %v", err)
+		}
+		data, err := json.Marshal(envelope)
+		if err != nil {
+			t.Fatalf("json.Marshal: %v", err)
+		}
+		return string(data) + "\n"
+	}
+	resultA := `{"repo":{"name":"github.com/owner/a"},"score":5}` + "\n"
+	resultB := `{"repo":{"name":"github.com/owner/b"},"score":7}` + "\n"
+
+	tests := []struct {
+		name    string
+		input   string
+		key     *ecdsa.PublicKey
+		repo    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "several results",
+			input: sign(resultA) + sign(resultB),
+			key:   &key.PublicKey,
+			want:  resultA + resultB,
+		},
+		{
+			name:  "expected repo",
+			input: sign(resultA),
+			key:   &key.PublicKey,
+			repo:  "github.com/owner/a",
+			want:  resultA,
+		},
+		{
+			name:    "other repo",
+			input:   sign(resultA),
+			key:     &key.PublicKey,
+			repo:    "github.com/owner/b",
+			wantErr: true,
+		},
+		{
+			name:    "other key",
+			input:   sign(resultA),
+			key:     &other.PublicKey,
+			wantErr: true,
+		},
+		{
+			name:    "unsigned result",
+			input:   resultA,
+			key:     &key.PublicKey,
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			key:     &key.PublicKey,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got bytes.Buffer
+			err := verifyResults(strings.NewReader(tt.input), tt.key, tt.repo, &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifyResults() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got.String()); diff != "" {
+				t.Errorf("verifyResults() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWriteSignedResult(t *testing.T) {
+	t.Parallel()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	checkDocs, err := docs.Read()
+	if err != nil {
+		t.Fatalf("docs.Read: %v", err)
+	}
+	signer := attestation.NewKeySigner(key, "test")
+
+	// Results of several repos are streamed one envelope per line.
+	var signed bytes.Buffer
+	for _, repo := range []string{"github.com/owner/a", "github.com/owner/b"} {
+		var result pkg.ScorecardResult
+		result.Repo.Name = repo
+		if err := writeSignedResult(&result, checkDocs, signer, &signed); err != nil {
+			t.Fatalf("writeSignedResult: %v", err)
+		}
+	}
+	if lines := strings.Count(signed.String(), "\n"); lines != 2 {
+		t.Errorf("got %d lines, want 2", lines)
+	}
+
+	var got bytes.Buffer
+	if err := verifyResults(&signed, &key.PublicKey, "", &got); err != nil {
+		t.Fatalf("verifyResults: %v", err)
+	}
+	for _, repo := range []string{"github.com/owner/a", "github.com/owner/b"} {
+		if !strings.Contains(got.String(), `"name":"`+repo+`"`) {
+			t.Errorf("verified results %q lack %s", got.String(), repo)
+		}
+	}
+}
@@ -90,6 +90,11 @@ func getBucketSummary(ctx context.Context, bucketURL string) (*bucketSummary, er
 }
 
 func isCompleted(expected, created int, completionThreshold float64) bool {
+	// The controller writes the shard metadata only after publishing all requests,
+	// so until then the number of expected shards is unknown.
+	if expected <= 0 {
+		return false
+	}
 	completedPercentage := float64(created) / float64(expected)
 	return completedPercentage >= completionThreshold
 }
@@ -116,21 +121,28 @@ func transferDataToBq(ctx context.Context,
 		if webhookURL == "" {
 			continue
 		}
-		// nolint: noctx, gosec // variable URL is ok here.
-		resp, err := http.Post(webhookURL, "application/json", bytes.NewBuffer(shards.shardMetadata))
-		if err != nil {
-			return fmt.Errorf("error during http.Post to %s: %w", webhookURL, err)
-		}
-		defer resp.Body.Close()
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("error reading resp.Body: %w", err)
+		if err := notifyWebhook(webhookURL, shards.shardMetadata); err != nil {
+			return err
 		}
-		log.Printf("Returned status: %s %s", resp.Status, body)
 	}
 	return nil
 }
 
+func notifyWebhook(webhookURL string, shardMetadata []byte) error {
+	// nolint: noctx, gosec // variable URL is ok here.
+	resp, err := http.Post(webhookURL, "application/json", bytes.NewBuffer(shardMetadata))
+	if err != nil {
+		return fmt.Errorf("error during http.Post to %s: %w", webhookURL, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading resp.Body: %w", err)
+	}
+	log.Printf("Returned status: %s %s", resp.Status, body)
+	return nil
+}
+
 func getBQConfig() (projectID, datasetName, tableName string, err error) {
 	projectID, err = config.GetProjectID()
 	if err != nil {
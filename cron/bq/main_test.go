@@ -0,0 +1,66 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "testing"
+
+func TestIsCompleted(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		expected  int
+		created   int
+		threshold float64
+		want      bool
+	}{
+		{
+			name:      "all shards created",
+			expected:  10,
+			created:   10,
+			threshold: 0.99,
+			want:      true,
+		},
+		{
+			name:      "above threshold",
+			expected:  100,
+			created:   99,
+			threshold: 0.99,
+			want:      true,
+		},
+		{
+			name:      "below threshold",
+			expected:  100,
+			created:   50,
+			threshold: 0.99,
+			want:      false,
+		},
+		{
+			name:      "metadata not yet written",
+			expected:  0,
+			created:   5,
+			threshold: 0.99,
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isCompleted(tt.expected, tt.created, tt.threshold); got != tt.want {
+				t.Errorf("isCompleted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -42,12 +42,16 @@ const (
 	requestSubscriptionURL string = "SCORECARD_REQUEST_SUBSCRIPTION_URL"
 	bigqueryDataset        string = "SCORECARD_BIGQUERY_DATASET"
 	bigqueryTable          string = "SCORECARD_BIGQUERY_TABLE"
+	bigqueryCheckRowsTable string = "SCORECARD_BIGQUERY_CHECK_ROWS_TABLE"
 	completionThreshold    string = "SCORECARD_COMPLETION_THRESHOLD"
 	shardSize              string = "SCORECARD_SHARD_SIZE"
 	webhookURL             string = "SCORECARD_WEBHOOK_URL"
 	metricExporter         string = "SCORECARD_METRIC_EXPORTER"
+	prometheusPort         string = "SCORECARD_PROMETHEUS_PORT"
 	ciiDataBucketURL       string = "SCORECARD_CII_DATA_BUCKET_URL"
 	blacklistedChecks      string = "SCORECARD_BLACKLISTED_CHECKS"
+	resultSigningKey       string = "SCORECARD_RESULT_SIGNING_KEY"
+	resultSignatureBucket  string = "SCORECARD_RESULT_SIGNATURE_BUCKET_URL"
 
 	bigqueryTableV2       string = "SCORECARD_BIGQUERY_TABLEV2"
 	resultDataBucketURLV2 string = "SCORECARD_DATA_BUCKET_URLV2"
@@ -70,12 +74,16 @@ type config struct {
 	RequestSubscriptionURL string  `yaml:"request-subscription-url"`
 	BigQueryDataset        string  `yaml:"bigquery-dataset"`
 	BigQueryTable          string  `yaml:"bigquery-table"`
+	BigQueryCheckRowsTable string  `yaml:"bigquery-check-rows-table"`
 	CompletionThreshold    float32 `yaml:"completion-threshold"`
 	WebhookURL             string  `yaml:"webhook-url"`
 	CIIDataBucketURL       string  `yaml:"cii-data-bucket-url"`
 	BlacklistedChecks      string  `yaml:"blacklisted-checks"`
 	MetricExporter         string  `yaml:"metric-exporter"`
+	PrometheusPort         int     `yaml:"prometheus-port"`
 	ShardSize              int     `yaml:"shard-size"`
+	ResultSigningKey       string  `yaml:"result-signing-key"`
+	ResultSignatureBucket  string  `yaml:"result-signature-bucket-url"`
 	// UPGRADEv2: to remove.
 	ResultDataBucketURLV2 string `yaml:"result-data-bucket-url-v2"`
 	BigQueryTableV2       string `yaml:"bigquery-table-v2"`
@@ -180,6 +188,12 @@ func GetBigQueryTable() (string, error) {
 	return getStringConfigValue(bigqueryTable, configYAML, "BigQueryTable", "bigquery-table")
 }
 
+// GetBigQueryCheckRowsTable returns the table name to export results to, one row per check.
+func GetBigQueryCheckRowsTable() (string, error) {
+	return getStringConfigValue(bigqueryCheckRowsTable, configYAML,
+		"BigQueryCheckRowsTable", "bigquery-check-rows-table")
+}
+
 // GetCompletionThreshold returns fraction of shards to be populated before transferring cron job results.
 func GetCompletionThreshold() (float64, error) {
 	return getFloat64ConfigValue(completionThreshold, configYAML, "CompletionThreshold", "completion-threshold")
@@ -220,6 +234,25 @@ func GetCIIDataBucketURL() (string, error) {
 	return url, nil
 }
 
+// GetResultSigningKey returns the gcpkms:// reference of the key the results are signed with, if any.
+func GetResultSigningKey() (string, error) {
+	key, err := getStringConfigValue(resultSigningKey, configYAML, "ResultSigningKey", "result-signing-key")
+	if err != nil && !errors.Is(err, ErrorEmptyConfigValue) {
+		return key, err
+	}
+	return key, nil
+}
+
+// GetResultSignatureBucketURL returns the bucket URL where the signed results are stored, if any.
+func GetResultSignatureBucketURL() (string, error) {
+	url, err := getStringConfigValue(resultSignatureBucket, configYAML,
+		"ResultSignatureBucket", "result-signature-bucket-url")
+	if err != nil && !errors.Is(err, ErrorEmptyConfigValue) {
+		return url, err
+	}
+	return url, nil
+}
+
 // GetBlacklistedChecks returns a list of checks which are not to be run.
 func GetBlacklistedChecks() ([]string, error) {
 	checks, err := getStringConfigValue(blacklistedChecks, configYAML, "BlacklistedChecks", "blacklisted-checks")
@@ -233,3 +266,8 @@ func GetBlacklistedChecks() ([]string, error) {
 func GetMetricExporter() (string, error) {
 	return getStringConfigValue(metricExporter, configYAML, "MetricExporter", "metric-exporter")
 }
+
+// GetPrometheusPort returns the port serving /metrics with the prometheus exporter.
+func GetPrometheusPort() (int, error) {
+	return getIntConfigValue(prometheusPort, configYAML, "PrometheusPort", "prometheus-port")
+}
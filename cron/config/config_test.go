@@ -30,12 +30,14 @@ const (
 	prodSubscription               = "gcppubsub://projects/openssf/subscriptions/scorecard-batch-worker"
 	prodBigQueryDataset            = "scorecardcron"
 	prodBigQueryTable              = "scorecard"
+	prodCheckRowsTable             = "scorecard-checks"
 	prodCompletionThreshold        = 0.99
 	prodWebhookURL                 = ""
 	prodCIIDataBucket              = "gs://ossf-scorecard-cii-data"
-	prodBlacklistedChecks          = "SAST,CI-Tests,Contributors,Dangerous-Workflow"
+	prodBlacklistedChecks          = "SAST,CI-Tests,Contributors,Dangerous-Workflow,Security-Advisories"
 	prodShardSize           int    = 10
 	prodMetricExporter      string = "stackdriver"
+	prodPrometheusPort      int    = 9090
 	// UPGRADEv2: to remove.
 	prodBucketV2        = "gs://ossf-scorecard-data2"
 	prodBigQueryTableV2 = "scorecard-v2"
@@ -66,12 +68,14 @@ func TestYAMLParsing(t *testing.T) {
 				RequestSubscriptionURL: prodSubscription,
 				BigQueryDataset:        prodBigQueryDataset,
 				BigQueryTable:          prodBigQueryTable,
+				BigQueryCheckRowsTable: prodCheckRowsTable,
 				CompletionThreshold:    prodCompletionThreshold,
 				WebhookURL:             prodWebhookURL,
 				CIIDataBucketURL:       prodCIIDataBucket,
 				BlacklistedChecks:      prodBlacklistedChecks,
 				ShardSize:              prodShardSize,
 				MetricExporter:         prodMetricExporter,
+				PrometheusPort:         prodPrometheusPort,
 				// UPGRADEv2: to remove.
 				ResultDataBucketURLV2: prodBucketV2,
 				BigQueryTableV2:       prodBigQueryTableV2,
@@ -345,3 +349,42 @@ func TestGetMetricExporter(t *testing.T) {
 		}
 	})
 }
+
+//nolint:paralleltest // Since os.Setenv is used.
+func TestGetPrometheusPort(t *testing.T) {
+	t.Run("GetPrometheusPort", func(t *testing.T) {
+		os.Unsetenv(prometheusPort)
+		port, err := GetPrometheusPort()
+		if err != nil {
+			t.Errorf("failed to get production prometheus port from config: %v", err)
+		}
+		if port != prodPrometheusPort {
+			t.Errorf("test failed: expected - %d, got = %d", prodPrometheusPort, port)
+		}
+	})
+}
+
+//nolint:paralleltest // Since os.Setenv is used.
+func TestGetResultSigning(t *testing.T) {
+	t.Run("GetResultSigningKey", func(t *testing.T) {
+		os.Unsetenv(resultSigningKey)
+		key, err := GetResultSigningKey()
+		if err != nil {
+			t.Errorf("failed to get production result signing key from config: %v", err)
+		}
+		if key != "" {
+			t.Errorf("test failed: expected - empty, got = %s", key)
+		}
+	})
+	t.Run("GetResultSignatureBucketURL", func(t *testing.T) {
+		const bucket = "gs://ossf-scorecard-signatures"
+		t.Setenv(resultSignatureBucket, bucket)
+		got, err := GetResultSignatureBucketURL()
+		if err != nil {
+			t.Errorf("failed to get result signature bucket from env: %v", err)
+		}
+		if got != bucket {
+			t.Errorf("test failed: expected - %s, got = %s", bucket, got)
+		}
+	})
+}
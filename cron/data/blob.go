@@ -20,9 +20,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"gocloud.dev/blob"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	// Needed to link in GCP drivers.
 	_ "gocloud.dev/blob/gcsblob"
@@ -115,6 +117,55 @@ func WriteToBlobStore(ctx context.Context, bucketURL, filename string, data []by
 	return nil
 }
 
+// PendingShards returns the number of shards of the job started at jobTime
+// which have no results in bucketURL yet, i.e. the queue depth of the job.
+// It returns -1 until the controller has written the shard metadata of the job.
+func PendingShards(ctx context.Context, bucketURL string, jobTime time.Time) (int, error) {
+	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return 0, fmt.Errorf("error from blob.OpenBucket: %w", err)
+	}
+	defer bucket.Close()
+
+	expected := -1
+	created := 0
+	iter := bucket.List(&blob.ListOptions{Prefix: GetBlobFilename("", jobTime)})
+	for {
+		next, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("error during iter.Next: %w", err)
+		}
+		_, filename, err := ParseBlobFilename(next.Key)
+		if err != nil {
+			return 0, fmt.Errorf("error parsing Blob key: %w", err)
+		}
+		switch {
+		case strings.HasPrefix(filename, "shard-"):
+			created++
+		case filename == config.ShardMetadataFilename:
+			keyData, err := bucket.ReadAll(ctx, next.Key)
+			if err != nil {
+				return 0, fmt.Errorf("error during bucket.ReadAll: %w", err)
+			}
+			var metadata ShardMetadata
+			if err := protojson.Unmarshal(keyData, &metadata); err != nil {
+				return 0, fmt.Errorf("error parsing data as ShardMetadata: %w", err)
+			}
+			expected = int(metadata.GetNumShard())
+		}
+	}
+	if expected < 0 {
+		return -1, nil
+	}
+	if created > expected {
+		return 0, nil
+	}
+	return expected - created, nil
+}
+
 // GetBlobFilename returns a blob key for a shard. Takes Time object and filename as input.
 func GetBlobFilename(filename string, datetime time.Time) string {
 	return datetime.Format(filePrefixFormat) + filename
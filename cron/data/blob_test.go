@@ -15,9 +15,14 @@
 package data
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
+
+	// Needed to link in the file driver.
+	_ "gocloud.dev/blob/fileblob"
 )
 
 const (
@@ -112,3 +117,42 @@ func TestParseBlobFilename(t *testing.T) {
 		})
 	}
 }
+
+func TestPendingShards(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	bucketURL := "file://" + t.TempDir()
+	jobTime := time.Date(2021, 6, 9, 16, 55, 3, 0, time.UTC)
+	pending := func(want int) {
+		t.Helper()
+		got, err := PendingShards(ctx, bucketURL, jobTime)
+		if err != nil {
+			t.Fatalf("PendingShards: %v", err)
+		}
+		if got != want {
+			t.Errorf("PendingShards() = %d, want %d", got, want)
+		}
+	}
+	write := func(key string, data []byte) {
+		t.Helper()
+		if err := WriteToBlobStore(ctx, bucketURL, key, data); err != nil {
+			t.Fatalf("WriteToBlobStore: %v", err)
+		}
+	}
+
+	// The number of shards is unknown until the controller is done publishing.
+	write(GetBlobFilename("shard-0000000", jobTime), nil)
+	pending(-1)
+
+	write(GetShardMetadataFilename(jobTime), []byte(`{"numShard": 3}`))
+	pending(2)
+
+	// Shards of other jobs are not counted.
+	write(GetBlobFilename("shard-0000001", jobTime.Add(time.Hour)), nil)
+	pending(2)
+
+	for i := 1; i < 3; i++ {
+		write(GetBlobFilename(fmt.Sprintf("shard-%07d", i), jobTime), nil)
+	}
+	pending(0)
+}
@@ -0,0 +1,137 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	// Used to embed the BigQuery schema of CheckRow.
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+)
+
+//go:embed check_rows.schema.json
+var checkRowSchema []byte
+
+// CheckRow is a row of the BigQuery table results are exported to:
+// the result of one check for one repo on one scan date.
+// The table schema is documented in check_rows.schema.json.
+type CheckRow struct {
+	Date             civil.Date `bigquery:"date" json:"date"`
+	Repo             string     `bigquery:"repo" json:"repo"`
+	Commit           string     `bigquery:"commit" json:"commit"`
+	ScorecardVersion string     `bigquery:"scorecard_version" json:"scorecard_version"`
+	ScorecardCommit  string     `bigquery:"scorecard_commit" json:"scorecard_commit"`
+	AggregateScore   float64    `bigquery:"aggregate_score" json:"aggregate_score"`
+	Check            string     `bigquery:"check" json:"check"`
+	Score            int        `bigquery:"score" json:"score"`
+	Reason           string     `bigquery:"reason" json:"reason"`
+	Details          []string   `bigquery:"details" json:"details"`
+	DocumentationURL string     `bigquery:"documentation_url" json:"documentation_url"`
+}
+
+// jsonResult is a result in the JSON format written by `scorecard --format=json`,
+// `--output-dir` and the cron worker. Only exported fields are decoded.
+type jsonResult struct {
+	Date string `json:"date"`
+	Repo struct {
+		Name   string `json:"name"`
+		Commit string `json:"commit"`
+	} `json:"repo"`
+	Scorecard struct {
+		Version string `json:"version"`
+		Commit  string `json:"commit"`
+	} `json:"scorecard"`
+	AggregateScore float64 `json:"score"`
+	Checks         []struct {
+		Name          string   `json:"name"`
+		Score         int      `json:"score"`
+		Reason        string   `json:"reason"`
+		Details       []string `json:"details"`
+		Documentation struct {
+			URL string `json:"url"`
+		} `json:"documentation"`
+	} `json:"checks"`
+}
+
+var errMissingRepo = errors.New("result has no repo name")
+
+// CheckRowSchema returns the BigQuery schema of CheckRow, with the description of each field.
+func CheckRowSchema() (bigquery.Schema, error) {
+	schema, err := bigquery.SchemaFromJSON(checkRowSchema)
+	if err != nil {
+		return nil, fmt.Errorf("error during bigquery.SchemaFromJSON: %w", err)
+	}
+	return schema, nil
+}
+
+// ExportCheckRows transforms the JSON results read from `in` into CheckRows.
+// `in` may hold several results, e.g., one per line like cron shards do.
+func ExportCheckRows(in io.Reader) ([]CheckRow, error) {
+	var rows []CheckRow
+	decoder := json.NewDecoder(in)
+	for {
+		var result jsonResult
+		err := decoder.Decode(&result)
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error during decoder.Decode: %w", err)
+		}
+		if result.Repo.Name == "" {
+			return nil, errMissingRepo
+		}
+		date, err := civil.ParseDate(result.Date)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing date of %s: %w", result.Repo.Name, err)
+		}
+		for _, check := range result.Checks {
+			details := check.Details
+			// BigQuery does not load nulls in repeated fields.
+			if details == nil {
+				details = []string{}
+			}
+			rows = append(rows, CheckRow{
+				Date:             date,
+				Repo:             result.Repo.Name,
+				Commit:           result.Repo.Commit,
+				ScorecardVersion: result.Scorecard.Version,
+				ScorecardCommit:  result.Scorecard.Commit,
+				AggregateScore:   result.AggregateScore,
+				Check:            check.Name,
+				Score:            check.Score,
+				Reason:           check.Reason,
+				Details:          details,
+				DocumentationURL: check.Documentation.URL,
+			})
+		}
+	}
+}
+
+// WriteCheckRows writes `rows` to `out` as newline-delimited JSON, the format BigQuery loads.
+func WriteCheckRows(out io.Writer, rows []CheckRow) error {
+	encoder := json.NewEncoder(out)
+	for i := range rows {
+		if err := encoder.Encode(&rows[i]); err != nil {
+			return fmt.Errorf("error during encoder.Encode: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main implements the job exporting stored results to BigQuery,
+// one row per repo per check per scan date.
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	// Needed to export results written with --output-dir.
+	_ "gocloud.dev/blob/fileblob"
+
+	"github.com/ossf/scorecard/v3/cron/config"
+	"github.com/ossf/scorecard/v3/cron/data"
+)
+
+const partitionDateFormat = "20060102"
+
+var (
+	bucketURL = flag.String("bucket", "",
+		"URL of the bucket storing JSON results, defaults to the cron job's v2 bucket")
+	days = flag.Int("days", 0,
+		"only export results scanned in the last days, 0 to export all of them")
+)
+
+// isResultKey returns whether key names results, rather than cron job metadata like the shard count.
+func isResultKey(key string) bool {
+	return !strings.HasPrefix(path.Base(key), ".")
+}
+
+// keyDate returns the scan date in key, so old results can be skipped without reading them.
+// Cron job shards are prefixed with the job time, which the worker uses as the scan date,
+// and results written with --output-dir start with their scan date.
+func keyDate(key string) (civil.Date, bool) {
+	if t, _, err := data.ParseBlobFilename(key); err == nil {
+		return civil.DateOf(t), true
+	}
+	const dateLen = len("2006-01-02")
+	if base := path.Base(key); len(base) >= dateLen {
+		if d, err := civil.ParseDate(base[:dateLen]); err == nil {
+			return d, true
+		}
+	}
+	return civil.Date{}, false
+}
+
+// readCheckRows reads the results stored in bucketURL, grouped by scan date.
+func readCheckRows(ctx context.Context, bucketURL string, since civil.Date) (map[civil.Date][]data.CheckRow, error) {
+	keys, err := data.GetBlobKeys(ctx, bucketURL)
+	if err != nil {
+		return nil, fmt.Errorf("error getting BlobKeys: %w", err)
+	}
+	rowsByDate := make(map[civil.Date][]data.CheckRow)
+	for _, key := range keys {
+		if !isResultKey(key) {
+			continue
+		}
+		if d, ok := keyDate(key); ok && d.Before(since) {
+			continue
+		}
+		keyData, err := data.GetBlobContent(ctx, bucketURL, key)
+		if err != nil {
+			return nil, fmt.Errorf("error during GetBlobContent: %w", err)
+		}
+		rows, err := data.ExportCheckRows(bytes.NewReader(keyData))
+		if err != nil {
+			return nil, fmt.Errorf("error exporting %s: %w", key, err)
+		}
+		for i := range rows {
+			if rows[i].Date.Before(since) {
+				continue
+			}
+			rowsByDate[rows[i].Date] = append(rowsByDate[rows[i].Date], rows[i])
+		}
+	}
+	return rowsByDate, nil
+}
+
+// loadCheckRows replaces the partition of each scan date with its rows.
+// Loading is idempotent, so the job can be rerun after a failure.
+func loadCheckRows(ctx context.Context, projectID, datasetName, tableName string,
+	rowsByDate map[civil.Date][]data.CheckRow) error {
+	schema, err := data.CheckRowSchema()
+	if err != nil {
+		return fmt.Errorf("error getting CheckRowSchema: %w", err)
+	}
+	bqClient, err := bigquery.NewClient(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to create bigquery client: %w", err)
+	}
+	defer bqClient.Close()
+
+	dates := make([]civil.Date, 0, len(rowsByDate))
+	for date := range rowsByDate {
+		dates = append(dates, date)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	for _, date := range dates {
+		var buf bytes.Buffer
+		if err := data.WriteCheckRows(&buf, rowsByDate[date]); err != nil {
+			return fmt.Errorf("error during WriteCheckRows: %w", err)
+		}
+		source := bigquery.NewReaderSource(&buf)
+		source.SourceFormat = bigquery.JSON
+		source.Schema = schema
+
+		partition := date.In(time.UTC).Format(partitionDateFormat)
+		table := bqClient.Dataset(datasetName).Table(fmt.Sprintf("%s$%s", tableName, partition))
+		loader := table.LoaderFrom(source)
+		loader.WriteDisposition = bigquery.WriteTruncate
+		loader.TimePartitioning = &bigquery.TimePartitioning{Field: "date"}
+
+		job, err := loader.Run(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create load job: %w", err)
+		}
+		log.Printf("Job created for %s: %s", date, job.ID())
+		status, err := job.Wait(ctx)
+		if err != nil {
+			return fmt.Errorf("error during job.Wait: %w", err)
+		}
+		if status.Err() != nil {
+			return fmt.Errorf("job returned error status: %w", status.Err())
+		}
+	}
+	return nil
+}
+
+func main() {
+	flag.Parse()
+	ctx := context.Background()
+
+	if *bucketURL == "" {
+		url, err := config.GetResultDataBucketURLV2()
+		if err != nil {
+			panic(err)
+		}
+		*bucketURL = url
+	}
+	var since civil.Date
+	if *days > 0 {
+		since = civil.DateOf(time.Now().UTC()).AddDays(-*days)
+	}
+	projectID, err := config.GetProjectID()
+	if err != nil {
+		panic(err)
+	}
+	datasetName, err := config.GetBigQueryDataset()
+	if err != nil {
+		panic(err)
+	}
+	tableName, err := config.GetBigQueryCheckRowsTable()
+	if err != nil {
+		panic(err)
+	}
+
+	rowsByDate, err := readCheckRows(ctx, *bucketURL, since)
+	if err != nil {
+		panic(err)
+	}
+	if err := loadCheckRows(ctx, projectID, datasetName, tableName, rowsByDate); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cloud.google.com/go/civil"
+)
+
+func TestKeyDate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		key  string
+		want civil.Date
+		ok   bool
+	}{
+		{key: "2021.10.01/120000/shard-0000000", want: civil.Date{Year: 2021, Month: 10, Day: 1}, ok: true},
+		{key: "github.com/ossf/scorecard/2021-10-02-sha.json", want: civil.Date{Year: 2021, Month: 10, Day: 2}, ok: true},
+		{key: "results.json"},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.key, func(t *testing.T) {
+			t.Parallel()
+			got, ok := keyDate(tt.key)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("keyDate(%q) = %v, %v, want %v, %v", tt.key, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestReadCheckRows(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	results, err := os.ReadFile("../testdata/results.json")
+	if err != nil {
+		t.Fatalf("os.ReadFile: %v", err)
+	}
+	files := map[string][]byte{
+		"2021.10.02/000000/shard-0000000":         results,
+		"github.com/ossf/old/2021-09-01-sha.json": []byte(`{"date": "2021-09-01", "repo": {"name": "github.com/ossf/old"}, "checks": [{"name": "Fuzzing"}]}`),
+		"2021.10.01/000000/.shard_metadata":       []byte(`{"shardLoc": "gs://bucket", "numShard": 1}`),
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("os.MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(p, content, 0o600); err != nil {
+			t.Fatalf("os.WriteFile: %v", err)
+		}
+	}
+	bucketURL := "file://" + filepath.ToSlash(dir)
+
+	tests := []struct {
+		name  string
+		since civil.Date
+		want  map[civil.Date]int
+	}{
+		{
+			name: "all dates",
+			want: map[civil.Date]int{
+				{Year: 2021, Month: 9, Day: 1}:  1,
+				{Year: 2021, Month: 10, Day: 1}: 2,
+				{Year: 2021, Month: 10, Day: 2}: 1,
+			},
+		},
+		{
+			name:  "since",
+			since: civil.Date{Year: 2021, Month: 10, Day: 2},
+			want: map[civil.Date]int{
+				{Year: 2021, Month: 10, Day: 2}: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rowsByDate, err := readCheckRows(context.Background(), bucketURL, tt.since)
+			if err != nil {
+				t.Fatalf("readCheckRows: %v", err)
+			}
+			if len(rowsByDate) != len(tt.want) {
+				t.Errorf("got %d dates, want %d", len(rowsByDate), len(tt.want))
+			}
+			for date, n := range tt.want {
+				if got := len(rowsByDate[date]); got != n {
+					t.Errorf("got %d rows for %s, want %d", got, date, n)
+				}
+			}
+		})
+	}
+}
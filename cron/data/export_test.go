@@ -0,0 +1,131 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package data
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestExportCheckRows(t *testing.T) {
+	t.Parallel()
+	in, err := os.Open("testdata/results.json")
+	if err != nil {
+		t.Fatalf("os.Open: %v", err)
+	}
+	defer in.Close()
+	got, err := ExportCheckRows(in)
+	if err != nil {
+		t.Fatalf("ExportCheckRows: %v", err)
+	}
+	docURL := "https://github.com/ossf/scorecard/blob/scsha/docs/checks.md#"
+	want := []CheckRow{
+		{
+			Date:             civil.Date{Year: 2021, Month: 10, Day: 1},
+			Repo:             "github.com/ossf/scorecard",
+			Commit:           "sha1",
+			ScorecardVersion: "v3.0.0",
+			ScorecardCommit:  "scsha",
+			AggregateScore:   7.5,
+			Check:            "Branch-Protection",
+			Score:            8,
+			Reason:           "branch protection is not maximal on development and all release branches",
+			Details:          []string{"Info: branch protection is enabled"},
+			DocumentationURL: docURL + "branch-protection",
+		},
+		{
+			Date:             civil.Date{Year: 2021, Month: 10, Day: 1},
+			Repo:             "github.com/ossf/scorecard",
+			Commit:           "sha1",
+			ScorecardVersion: "v3.0.0",
+			ScorecardCommit:  "scsha",
+			AggregateScore:   7.5,
+			Check:            "Fuzzing",
+			Score:            -1,
+			Reason:           "internal error",
+			Details:          []string{},
+			DocumentationURL: docURL + "fuzzing",
+		},
+		{
+			Date:             civil.Date{Year: 2021, Month: 10, Day: 2},
+			Repo:             "github.com/ossf/other",
+			Commit:           "sha2",
+			ScorecardVersion: "v3.0.0",
+			ScorecardCommit:  "scsha",
+			AggregateScore:   10,
+			Check:            "Binary-Artifacts",
+			Score:            10,
+			Reason:           "no binaries found in the repo",
+			Details:          []string{},
+			DocumentationURL: docURL + "binary-artifacts",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ExportCheckRows() mismatch (-want +got):\n%s", diff)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCheckRows(&buf, got); err != nil {
+		t.Fatalf("WriteCheckRows: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d", len(lines), len(want))
+	}
+	if !strings.Contains(lines[1], `"date":"2021-10-01"`) || !strings.Contains(lines[1], `"details":[]`) {
+		t.Errorf("unexpected row: %s", lines[1])
+	}
+}
+
+func TestExportCheckRowsErrors(t *testing.T) {
+	t.Parallel()
+	for _, in := range []string{
+		`{"date": "2021-10-01", "checks": []}`,
+		`{"date": "yesterday", "repo": {"name": "github.com/ossf/scorecard"}}`,
+		`{"date": `,
+	} {
+		if _, err := ExportCheckRows(strings.NewReader(in)); err == nil {
+			t.Errorf("ExportCheckRows(%s): expected error", in)
+		}
+	}
+}
+
+// TestCheckRowSchema checks the documented schema matches CheckRow.
+func TestCheckRowSchema(t *testing.T) {
+	t.Parallel()
+	got, err := CheckRowSchema()
+	if err != nil {
+		t.Fatalf("CheckRowSchema: %v", err)
+	}
+	for _, field := range got {
+		if field.Description == "" {
+			t.Errorf("field %s is not documented", field.Name)
+		}
+	}
+	want, err := bigquery.InferSchema(CheckRow{})
+	if err != nil {
+		t.Fatalf("bigquery.InferSchema: %v", err)
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(bigquery.FieldSchema{}, "Description")); diff != "" {
+		t.Errorf("schema mismatch (-CheckRow +check_rows.schema.json):\n%s", diff)
+	}
+}
@@ -57,6 +57,8 @@ type jsonCheckResultV2 struct {
 	Reason  string                   `json:"reason"`
 	Name    string                   `json:"name"`
 	Doc     jsonCheckDocumentationV2 `json:"documentation"`
+	// State tells a score of -1 apart: inconclusive, not-applicable or runtime-error.
+	State string `json:"state"`
 }
 
 type jsonRepoV2 struct {
@@ -161,6 +163,7 @@ func AsJSON2(r *pkg.ScorecardResult, showDetails bool,
 			},
 			Reason: checkResult.Reason,
 			Score:  checkResult.Score,
+			State:  string(checkResult.State()),
 		}
 		if showDetails {
 			for i := range checkResult.Details2 {
@@ -37,6 +37,7 @@ const (
 	stackdriverTimeoutMinutes               = 10
 	stackDriver                exporterType = "stackdriver"
 	printer                    exporterType = "printer"
+	prometheus                 exporterType = "prometheus"
 )
 
 // Exporter interface is a custom wrapper to represent an opencensus exporter.
@@ -58,6 +59,8 @@ func GetExporter() (Exporter, error) {
 		return newStackDriverExporter()
 	case printer:
 		return new(printerExporter), nil
+	case prometheus:
+		return newPrometheusExporter()
 	default:
 		return nil, fmt.Errorf("%w: %s", errorUndefinedExporter, exporter)
 	}
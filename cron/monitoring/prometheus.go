@@ -0,0 +1,72 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"go.opencensus.io/stats/view"
+
+	"github.com/ossf/scorecard/v3/cron/config"
+	"github.com/ossf/scorecard/v3/stats"
+)
+
+const prometheusShutdownTimeout = 5 * time.Second
+
+// prometheusExporter serves the views on /metrics, for Prometheus to scrape.
+type prometheusExporter struct {
+	*stats.PrometheusExporter
+	server *http.Server
+}
+
+func newPrometheusExporter() (*prometheusExporter, error) {
+	port, err := config.GetPrometheusPort()
+	if err != nil {
+		return nil, fmt.Errorf("error getting PrometheusPort: %w", err)
+	}
+	exporter := stats.NewPrometheusExporter()
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter)
+	return &prometheusExporter{
+		PrometheusExporter: exporter,
+		server:             &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: mux},
+	}, nil
+}
+
+func (pe *prometheusExporter) StartMetricsExporter() error {
+	view.RegisterExporter(pe)
+	go func() {
+		if err := pe.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("error serving metrics: %v", err)
+		}
+	}()
+	return nil
+}
+
+func (pe *prometheusExporter) StopMetricsExporter() {
+	view.UnregisterExporter(pe)
+	ctx, cancel := context.WithTimeout(context.Background(), prometheusShutdownTimeout)
+	defer cancel()
+	if err := pe.server.Shutdown(ctx); err != nil {
+		log.Printf("error stopping metrics server: %v", err)
+	}
+}
+
+func (pe *prometheusExporter) Flush() {}
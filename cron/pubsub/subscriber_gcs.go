@@ -79,6 +79,15 @@ func (subscriber *gcsSubscriber) extendAckDeadline() {
 	}
 }
 
+// stopExtendingAckDeadline stops extendAckDeadline, unless the message was already acked or nacked.
+func (subscriber *gcsSubscriber) stopExtendingAckDeadline() {
+	select {
+	case <-subscriber.done:
+	default:
+		close(subscriber.done)
+	}
+}
+
 func (subscriber *gcsSubscriber) SynchronousPull() (*data.ScorecardBatchRequest, error) {
 	numReceivedMessages := 0
 	var msgToProcess *pubsubpb.ReceivedMessage
@@ -119,19 +128,18 @@ func (subscriber *gcsSubscriber) Ack() {
 		Subscription: subscriber.subscriptionURL,
 		AckIds:       []string{subscriber.recvdAckID},
 	})
-	close(subscriber.done)
+	subscriber.stopExtendingAckDeadline()
 	if err != nil {
 		log.Fatal(err)
 	}
 }
 
 func (subscriber *gcsSubscriber) Nack() {
-	// Stop extending Ack deadline.
-	close(subscriber.done)
+	subscriber.stopExtendingAckDeadline()
 }
 
 func (subscriber *gcsSubscriber) Close() error {
-	close(subscriber.done)
+	subscriber.stopExtendingAckDeadline()
 	err := subscriber.client.Close()
 	if err != nil {
 		return fmt.Errorf("error during Close: %w", err)
@@ -0,0 +1,30 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pubsub
+
+import "testing"
+
+func TestStopExtendingAckDeadline(t *testing.T) {
+	t.Parallel()
+	subscriber := &gcsSubscriber{done: make(chan bool)}
+	// Nack followed by Close, e.g. when a worker releases its shard and drains.
+	subscriber.Nack()
+	subscriber.stopExtendingAckDeadline()
+	select {
+	case <-subscriber.done:
+	default:
+		t.Error("done is not closed")
+	}
+}
@@ -18,22 +18,30 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	// nolint:gosec
 	_ "net/http/pprof"
 
+	opencensusstats "go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/ossf/scorecard/v3/attestation"
 	"github.com/ossf/scorecard/v3/checker"
 	"github.com/ossf/scorecard/v3/checks"
 	"github.com/ossf/scorecard/v3/clients"
 	"github.com/ossf/scorecard/v3/clients/githubrepo"
+	"github.com/ossf/scorecard/v3/clients/githubrepo/roundtripper"
 	githubstats "github.com/ossf/scorecard/v3/clients/githubrepo/stats"
 	"github.com/ossf/scorecard/v3/cron/config"
 	"github.com/ossf/scorecard/v3/cron/data"
@@ -48,11 +56,43 @@ import (
 
 var ignoreRuntimeErrors = flag.Bool("ignoreRuntimeErrors", false, "if set to true any runtime errors will be ignored")
 
+// errWorkerDraining is returned when a shard is abandoned because the worker is shutting down.
+var errWorkerDraining = errors.New("worker is draining")
+
+// errSigningConfig is returned when only one of the result signing options is set.
+var errSigningConfig = errors.New("result-signing-key and result-signature-bucket-url must be set together")
+
+// resultSigner returns the signer of the results and the bucket the signed results
+// are written to, or a nil signer if results are not signed.
+func resultSigner(ctx context.Context) (attestation.Signer, string, error) {
+	key, err := config.GetResultSigningKey()
+	if err != nil {
+		return nil, "", fmt.Errorf("error during GetResultSigningKey: %w", err)
+	}
+	bucketURL, err := config.GetResultSignatureBucketURL()
+	if err != nil {
+		return nil, "", fmt.Errorf("error during GetResultSignatureBucketURL: %w", err)
+	}
+	if key == "" && bucketURL == "" {
+		return nil, "", nil
+	}
+	if key == "" || bucketURL == "" {
+		return nil, "", errSigningConfig
+	}
+	signer, err := attestation.NewKMSSigner(ctx, key)
+	if err != nil {
+		return nil, "", fmt.Errorf("error during NewKMSSigner: %w", err)
+	}
+	return signer, bucketURL, nil
+}
+
 func processRequest(ctx context.Context,
 	batchRequest *data.ScorecardBatchRequest, checksToRun checker.CheckNameToFnMap,
 	bucketURL, bucketURL2 string, checkDocs docs.Doc,
+	signer attestation.Signer, signatureBucketURL string,
 	repoClient clients.RepoClient, ossFuzzRepoClient clients.RepoClient,
-	ciiClient clients.CIIBestPracticesClient, logger *zap.Logger) error {
+	ciiClient clients.CIIBestPracticesClient, vulnsClient clients.VulnerabilitiesClient,
+	packagesClient clients.PackagesClient, progress *workerProgress, logger *zap.Logger) error {
 	filename := data.GetBlobFilename(
 		fmt.Sprintf("shard-%07d", batchRequest.GetShardNum()),
 		batchRequest.GetJobTime().AsTime())
@@ -74,8 +114,15 @@ func processRequest(ctx context.Context,
 
 	var buffer bytes.Buffer
 	var buffer2 bytes.Buffer
+	// Signed results, one DSSE envelope per line.
+	var signatures bytes.Buffer
+	progress.startShard(batchRequest.GetShardNum(), len(batchRequest.GetRepos()))
 	// TODO: run Scorecard for each repo in a separate thread.
 	for _, repo := range batchRequest.GetRepos() {
+		// Give up on the shard so that another worker can pick it up.
+		if progress.isDraining() {
+			return errWorkerDraining
+		}
 		logger.Info(fmt.Sprintf("Running Scorecard for repo: %s", *repo.Url))
 		repo, err := githubrepo.MakeGithubRepo(*repo.Url)
 		if err != nil {
@@ -83,8 +130,9 @@ func processRequest(ctx context.Context,
 			continue
 		}
 		repo.AppendMetadata(repo.Metadata()...)
-		result, err := pkg.RunScorecards(ctx, repo, false, checksToRun, repoClient, ossFuzzRepoClient, ciiClient)
-		if errors.Is(err, sce.ErrRepoUnreachable) {
+		result, err := pkg.RunScorecards(ctx, repo, clients.HeadSHA, false, checksToRun,
+			repoClient, ossFuzzRepoClient, ciiClient, vulnsClient, packagesClient)
+		if errors.Is(err, sce.ErrRepoUnreachable) || errors.Is(err, sce.ErrRepoNotFound) {
 			// Not accessible repo - continue.
 			continue
 		}
@@ -108,9 +156,22 @@ func processRequest(ctx context.Context,
 			return fmt.Errorf("error during result.AsJSON: %w", err)
 		}
 
-		if err := format.AsJSON2(&result, true /*showDetails*/, zapcore.InfoLevel, checkDocs, &buffer2); err != nil {
+		var result2 bytes.Buffer
+		if err := format.AsJSON2(&result, true /*showDetails*/, zapcore.InfoLevel, checkDocs, &result2); err != nil {
 			return fmt.Errorf("error during result.AsJSON2: %w", err)
 		}
+		buffer2.Write(result2.Bytes())
+		if signer != nil {
+			envelope, err := attestation.SignResult(ctx, result2.Bytes(), signer)
+			if err != nil {
+				return fmt.Errorf("error during SignResult: %w", err)
+			}
+			if err := json.NewEncoder(&signatures).Encode(envelope); err != nil {
+				return fmt.Errorf("error encoding signed result: %w", err)
+			}
+		}
+		progress.repoDone()
+		opencensusstats.Record(ctx, stats.ReposProcessed.M(1))
 	}
 	if err := data.WriteToBlobStore(ctx, bucketURL, filename, buffer.Bytes()); err != nil {
 		return fmt.Errorf("error during WriteToBlobStore: %w", err)
@@ -120,6 +181,12 @@ func processRequest(ctx context.Context,
 		return fmt.Errorf("error during WriteToBlobStore2: %w", err)
 	}
 
+	if signer != nil {
+		if err := data.WriteToBlobStore(ctx, signatureBucketURL, filename, signatures.Bytes()); err != nil {
+			return fmt.Errorf("error during WriteToBlobStore of the signed results: %w", err)
+		}
+	}
+
 	logger.Info(fmt.Sprintf("Write to shard file successful: %s", filename))
 
 	return nil
@@ -136,19 +203,50 @@ func startMetricsExporter() (monitoring.Exporter, error) {
 
 	if err := view.Register(
 		&stats.CheckRuntime,
+		&stats.CheckDuration,
 		&stats.CheckErrorCount,
 		&stats.OutgoingHTTPRequests,
+		&stats.ShardsProcessedCount,
+		&stats.ReposProcessedCount,
+		&stats.ShardsPending,
 		&githubstats.GithubTokens); err != nil {
 		return nil, fmt.Errorf("error during view.Register: %w", err)
 	}
 	return exporter, nil
 }
 
+func recordShard(ctx context.Context, status string) {
+	ctx, err := tag.New(ctx, tag.Upsert(stats.ShardStatus, status))
+	if err != nil {
+		return
+	}
+	opencensusstats.Record(ctx, stats.ShardsProcessed.M(1))
+}
+
+// recordPendingShards reports the shards of the job of req still without results,
+// the queue depth autoscalers scale the workers on.
+func recordPendingShards(ctx context.Context, bucketURL string, req *data.ScorecardBatchRequest,
+	progress *workerProgress, logger *zap.Logger) {
+	pending, err := data.PendingShards(ctx, bucketURL, req.GetJobTime().AsTime())
+	if err != nil {
+		logger.Warn(fmt.Sprintf("error during PendingShards: %v", err))
+		return
+	}
+	progress.setPendingShards(pending)
+	if pending >= 0 {
+		opencensusstats.Record(ctx, stats.PendingShards.M(int64(pending)))
+	}
+}
+
 func main() {
 	ctx := context.Background()
 
 	flag.Parse()
 
+	if err := roundtripper.CheckNetworkConfig(); err != nil {
+		panic(err)
+	}
+
 	checkDocs, err := docs.Read()
 	if err != nil {
 		panic(err)
@@ -158,6 +256,12 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	// On SIGTERM stop pulling new shards and abandon the current one, so that
+	// workers can run on preemptible capacity. The subscriber keeps using ctx,
+	// which it still needs to release the shard and shut down once drained.
+	drainCtx, stop := signal.NotifyContext(ctx, syscall.SIGTERM, os.Interrupt)
+	defer stop()
+	progress := newWorkerProgress(drainCtx)
 	subscriber, err := pubsub.CreateSubscriber(ctx, subscriptionURL)
 	if err != nil {
 		panic(err)
@@ -183,12 +287,19 @@ func main() {
 		panic(err)
 	}
 
+	signer, signatureBucketURL, err := resultSigner(ctx)
+	if err != nil {
+		panic(err)
+	}
+
 	logger, err := githubrepo.NewLogger(zap.InfoLevel)
 	if err != nil {
 		panic(err)
 	}
 	repoClient := githubrepo.CreateGithubRepoClient(ctx, logger)
 	ciiClient := clients.BlobCIIBestPracticesClient(ciiDataBucketURL)
+	vulnsClient := clients.DefaultVulnerabilitiesClient()
+	packagesClient := clients.DefaultPackagesClient()
 	ossFuzzRepoClient, err := githubrepo.CreateOssFuzzRepoClient(ctx, logger)
 	if err != nil {
 		panic(err)
@@ -201,7 +312,14 @@ func main() {
 	}
 	defer exporter.StopMetricsExporter()
 
-	// Exposed for monitoring runtime profiles
+	stopTraces, err := stats.StartTraceExporter()
+	if err != nil {
+		panic(err)
+	}
+	defer stopTraces()
+
+	// Exposed for monitoring runtime profiles and worker progress.
+	http.Handle("/progress", progress)
 	go func() {
 		logger.Fatal(fmt.Sprintf("%v", http.ListenAndServe(":8080", nil)))
 	}()
@@ -210,31 +328,88 @@ func main() {
 	for _, check := range blacklistedChecks {
 		delete(checksToRun, check)
 	}
-	for {
-		req, err := subscriber.SynchronousPull()
+	process := func(req *data.ScorecardBatchRequest) error {
+		err := processRequest(ctx, req, checksToRun,
+			bucketURL, bucketURL2, checkDocs, signer, signatureBucketURL,
+			repoClient, ossFuzzRepoClient, ciiClient, vulnsClient, packagesClient, progress, logger)
+		recordPendingShards(ctx, bucketURL, req, progress, logger)
+		if err == nil {
+			// nolint: errcheck // flushes buffer
+			logger.Sync()
+			exporter.Flush()
+		}
+		return err
+	}
+	if err := processShards(ctx, drainCtx, subscriber, progress, process, logger); err != nil {
+		panic(err)
+	}
+	// nolint: errcheck // flushes buffer
+	logger.Sync()
+	exporter.Flush()
+	err = subscriber.Close()
+	if err != nil {
+		panic(err)
+	}
+}
+
+// processShards processes the shards pulled from subscriber until the
+// subscription has no more messages or drainCtx is done. A shard abandoned
+// while draining is nacked so that another worker picks it up, results
+// written before draining are skipped by BlobExists on redelivery.
+func processShards(ctx, drainCtx context.Context, subscriber pubsub.Subscriber, progress *workerProgress,
+	process func(*data.ScorecardBatchRequest) error, logger *zap.Logger) error {
+	for !progress.isDraining() {
+		req, err := pullShard(drainCtx, subscriber)
 		if err != nil {
-			panic(err)
+			return err
 		}
-		logger.Info("Received message from subscription")
 		if req == nil {
-			logger.Warn("subscription returned nil message during Receive, exiting")
+			if !progress.isDraining() {
+				logger.Warn("subscription returned nil message during Receive, exiting")
+			}
 			break
 		}
-		if err := processRequest(ctx, req, checksToRun,
-			bucketURL, bucketURL2, checkDocs,
-			repoClient, ossFuzzRepoClient, ciiClient, logger); err != nil {
+		logger.Info("Received message from subscription")
+		err = process(req)
+		progress.finishShard()
+		switch {
+		case errors.Is(err, errWorkerDraining):
+			logger.Info("worker is draining, releasing shard")
+			recordShard(ctx, "nack")
+			subscriber.Nack()
+		case err != nil:
 			logger.Warn(fmt.Sprintf("error processing request: %v", err))
 			// Nack the message so that another worker can retry.
+			recordShard(ctx, "nack")
 			subscriber.Nack()
-			continue
+		default:
+			recordShard(ctx, "ack")
+			subscriber.Ack()
 		}
-		// nolint: errcheck // flushes buffer
-		logger.Sync()
-		exporter.Flush()
-		subscriber.Ack()
 	}
-	err = subscriber.Close()
-	if err != nil {
-		panic(err)
+	if progress.isDraining() {
+		logger.Info("worker is draining, exiting")
+	}
+	return nil
+}
+
+// pullShard pulls the next shard, or returns nil once drainCtx is done. A shard
+// pulled after that is left unacked, to be redelivered once its deadline expires.
+func pullShard(drainCtx context.Context, subscriber pubsub.Subscriber) (*data.ScorecardBatchRequest, error) {
+	type pulled struct {
+		req *data.ScorecardBatchRequest
+		err error
+	}
+	ch := make(chan pulled, 1)
+	go func() {
+		req, err := subscriber.SynchronousPull()
+		ch <- pulled{req: req, err: err}
+	}()
+	select {
+	case <-drainCtx.Done():
+		return nil, nil
+	case p := <-ch:
+		//nolint:wrapcheck
+		return p.req, p.err
 	}
 }
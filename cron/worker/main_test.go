@@ -0,0 +1,170 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/cron/data"
+)
+
+var errProcess = errors.New("process failed")
+
+// fakeSubscriber serves shards, then signals idle and blocks until closed like an idle subscription.
+type fakeSubscriber struct {
+	idle   chan struct{}
+	closed chan struct{}
+	shards []*data.ScorecardBatchRequest
+	events []string
+}
+
+func (s *fakeSubscriber) SynchronousPull() (*data.ScorecardBatchRequest, error) {
+	if len(s.shards) == 0 {
+		close(s.idle)
+		<-s.closed
+		return nil, nil
+	}
+	req := s.shards[0]
+	s.shards = s.shards[1:]
+	return req, nil
+}
+
+func (s *fakeSubscriber) Ack() {
+	s.events = append(s.events, "ack")
+}
+
+func (s *fakeSubscriber) Nack() {
+	s.events = append(s.events, "nack")
+}
+
+func (s *fakeSubscriber) Close() error {
+	close(s.closed)
+	return nil
+}
+
+func TestProcessShards(t *testing.T) {
+	t.Parallel()
+	shard := func(num int32) *data.ScorecardBatchRequest {
+		return &data.ScorecardBatchRequest{ShardNum: &num}
+	}
+	tests := []struct {
+		// process processes a shard, stop drains the worker.
+		process    func(req *data.ScorecardBatchRequest, stop func()) error
+		name       string
+		wantEvents []string
+		shards     int
+		wantShards int
+	}{
+		{
+			name:   "drain while idle",
+			shards: 0,
+			process: func(req *data.ScorecardBatchRequest, stop func()) error {
+				return nil
+			},
+		},
+		{
+			name:   "ack processed shards",
+			shards: 2,
+			process: func(req *data.ScorecardBatchRequest, stop func()) error {
+				if req.GetShardNum() == 1 {
+					stop()
+				}
+				return nil
+			},
+			wantEvents: []string{"ack", "ack"},
+			wantShards: 2,
+		},
+		{
+			name:   "nack failed shards",
+			shards: 2,
+			process: func(req *data.ScorecardBatchRequest, stop func()) error {
+				if req.GetShardNum() == 1 {
+					stop()
+				}
+				return errProcess
+			},
+			wantEvents: []string{"nack", "nack"},
+			wantShards: 2,
+		},
+		{
+			name:   "release the shard abandoned while draining",
+			shards: 3,
+			process: func(req *data.ScorecardBatchRequest, stop func()) error {
+				if req.GetShardNum() == 1 {
+					stop()
+					return errWorkerDraining
+				}
+				return nil
+			},
+			wantEvents: []string{"ack", "nack"},
+			wantShards: 2,
+		},
+		{
+			name:   "ack the shard completed while draining",
+			shards: 3,
+			process: func(req *data.ScorecardBatchRequest, stop func()) error {
+				if req.GetShardNum() == 0 {
+					stop()
+				}
+				return nil
+			},
+			wantEvents: []string{"ack"},
+			wantShards: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			drainCtx, stop := context.WithCancel(ctx)
+			defer stop()
+			subscriber := &fakeSubscriber{idle: make(chan struct{}), closed: make(chan struct{})}
+			for i := 0; i < tt.shards; i++ {
+				subscriber.shards = append(subscriber.shards, shard(int32(i)))
+			}
+			progress := newWorkerProgress(drainCtx)
+			process := func(req *data.ScorecardBatchRequest) error {
+				return tt.process(req, stop)
+			}
+			if tt.shards == 0 {
+				// Drain once the worker waits for a shard.
+				go func() {
+					<-subscriber.idle
+					stop()
+				}()
+			}
+
+			if err := processShards(ctx, drainCtx, subscriber, progress, process, zap.NewNop()); err != nil {
+				t.Fatalf("processShards: %v", err)
+			}
+			if diff := cmp.Diff(tt.wantEvents, subscriber.events); diff != "" {
+				t.Errorf("events mismatch (-want +got):\n%s", diff)
+			}
+			if progress.ShardsProcessed != tt.wantShards {
+				t.Errorf("ShardsProcessed = %d, want %d", progress.ShardsProcessed, tt.wantShards)
+			}
+			// The subscriber is shut down once drained, unblocking the abandoned pull.
+			if err := subscriber.Close(); err != nil {
+				t.Errorf("Close: %v", err)
+			}
+		})
+	}
+}
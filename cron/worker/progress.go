@@ -0,0 +1,88 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+)
+
+// workerProgress tracks the state of a worker so that external autoscalers
+// and orchestrators can decide when it is safe to scale down.
+type workerProgress struct {
+	mu              sync.Mutex
+	drainCtx        context.Context
+	ShardNum        int32 `json:"shardNum"`
+	ReposTotal      int   `json:"reposTotal"`
+	ReposProcessed  int   `json:"reposProcessed"`
+	ShardsProcessed int   `json:"shardsProcessed"`
+	InFlight        bool  `json:"inFlight"`
+	Draining        bool  `json:"draining"`
+	// PendingShards is the number of shards of the job without results, or -1 if unknown.
+	PendingShards int `json:"pendingShards"`
+}
+
+// newWorkerProgress returns a workerProgress which reports draining once drainCtx is done.
+func newWorkerProgress(drainCtx context.Context) *workerProgress {
+	return &workerProgress{drainCtx: drainCtx, PendingShards: -1}
+}
+
+func (p *workerProgress) startShard(shardNum int32, numRepos int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ShardNum = shardNum
+	p.ReposTotal = numRepos
+	p.ReposProcessed = 0
+	p.InFlight = true
+}
+
+func (p *workerProgress) repoDone() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ReposProcessed++
+}
+
+func (p *workerProgress) finishShard() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ShardsProcessed++
+	p.InFlight = false
+}
+
+func (p *workerProgress) setPendingShards(pending int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.PendingShards = pending
+}
+
+func (p *workerProgress) isDraining() bool {
+	return p.drainCtx.Err() != nil
+}
+
+// ServeHTTP writes the current progress as JSON.
+// Draining workers respond with 503 so that they are taken out of rotation.
+func (p *workerProgress) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Draining = p.isDraining()
+	w.Header().Set("Content-Type", "application/json")
+	if p.Draining {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	// nolint: errcheck
+	json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,83 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// progressJSON is the progress served to autoscalers.
+type progressJSON struct {
+	ShardNum        int32 `json:"shardNum"`
+	ReposTotal      int   `json:"reposTotal"`
+	ReposProcessed  int   `json:"reposProcessed"`
+	ShardsProcessed int   `json:"shardsProcessed"`
+	InFlight        bool  `json:"inFlight"`
+	Draining        bool  `json:"draining"`
+	PendingShards   int   `json:"pendingShards"`
+}
+
+func TestWorkerProgress(t *testing.T) {
+	t.Parallel()
+	drainCtx, stop := context.WithCancel(context.Background())
+	defer stop()
+	progress := newWorkerProgress(drainCtx)
+
+	serve := func(wantStatus int) progressJSON {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		progress.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/progress", nil))
+		if rec.Code != wantStatus {
+			t.Errorf("status = %d, want %d", rec.Code, wantStatus)
+		}
+		var got progressJSON
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("decoding progress: %v", err)
+		}
+		return got
+	}
+	compare := func(want, got progressJSON) {
+		t.Helper()
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("progress mismatch (-want +got):\n%s", diff)
+		}
+	}
+
+	progress.startShard(7, 3)
+	progress.repoDone()
+	compare(progressJSON{ShardNum: 7, ReposTotal: 3, ReposProcessed: 1, InFlight: true, PendingShards: -1},
+		serve(http.StatusOK))
+
+	progress.finishShard()
+	progress.setPendingShards(5)
+	progress.startShard(8, 2)
+	compare(progressJSON{ShardNum: 8, ReposTotal: 2, ShardsProcessed: 1, InFlight: true, PendingShards: 5},
+		serve(http.StatusOK))
+
+	// Draining workers are taken out of rotation.
+	stop()
+	if !progress.isDraining() {
+		t.Error("isDraining() = false after drainCtx is done")
+	}
+	progress.finishShard()
+	compare(progressJSON{ShardNum: 8, ReposTotal: 2, ShardsProcessed: 2, Draining: true, PendingShards: 5},
+		serve(http.StatusServiceUnavailable))
+}
@@ -0,0 +1,192 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dependencydiff runs Scorecard checks on the dependencies changed
+// between two refs of a GitHub repository.
+package dependencydiff
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v38/github"
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/githubrepo"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+// ChangeType is the type of change made to a dependency.
+type ChangeType string
+
+const (
+	// Added is a dependency that was not present in the base ref.
+	Added ChangeType = "added"
+	// Removed is a dependency that is not present in the head ref.
+	Removed ChangeType = "removed"
+	// Updated is a dependency whose version changed.
+	Updated ChangeType = "updated"
+)
+
+// DependencyCheckResult is the result of running Scorecard on a changed dependency.
+type DependencyCheckResult struct {
+	ChangeType      ChangeType
+	Ecosystem       string
+	Manifest        string
+	Name            string
+	Version         string
+	PreviousVersion string
+	// SourceRepository is the repository the dependency is built from, if known.
+	SourceRepository string
+	// ScorecardResult is nil for removed dependencies and dependencies
+	// without a known GitHub source repository.
+	ScorecardResult *pkg.ScorecardResult
+	AggregateScore  float64
+	// Regression is set if the PR adds or updates a dependency
+	// whose aggregate score is below the minimum score.
+	Regression bool
+}
+
+// scoreFn runs Scorecard on a repository.
+type scoreFn func(ctx context.Context, repo clients.Repo) (pkg.ScorecardResult, error)
+
+// GetDependencyDiffResults runs the checks on the dependencies added or updated
+// between the base and head refs of the GitHub repository repoURI.
+func GetDependencyDiffResults(ctx context.Context, repoURI, base, head string,
+	checksToRun checker.CheckNameToFnMap, minScore float64, logger *zap.Logger) ([]DependencyCheckResult, error) {
+	repo, err := githubrepo.MakeGithubRepo(repoURI)
+	if err != nil {
+		return nil, fmt.Errorf("githubrepo.MakeGithubRepo: %w", err)
+	}
+	owner, name, err := ownerAndName(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	ghClient := github.NewClient(&http.Client{
+		Transport: githubrepo.NewTransport(ctx, logger),
+	})
+	deps, err := fetchDependencyDiff(ctx, ghClient, owner, name, base, head)
+	if err != nil {
+		return nil, err
+	}
+
+	checkDocs, err := docs.Read()
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("docs.Read: %v", err))
+	}
+
+	ossFuzzRepoClient, err := githubrepo.CreateOssFuzzRepoClient(ctx, logger)
+	if err != nil {
+		return nil, fmt.Errorf("githubrepo.CreateOssFuzzRepoClient: %w", err)
+	}
+	defer ossFuzzRepoClient.Close()
+	score := func(ctx context.Context, repo clients.Repo) (pkg.ScorecardResult, error) {
+		repoClient := githubrepo.CreateGithubRepoClient(ctx, logger)
+		defer repoClient.Close()
+		//nolint:wrapcheck
+		return pkg.RunScorecards(ctx, repo, clients.HeadSHA, false, checksToRun,
+			repoClient, ossFuzzRepoClient, clients.DefaultCIIBestPracticesClient(),
+			clients.DefaultVulnerabilitiesClient(), clients.DefaultPackagesClient())
+	}
+
+	return evaluate(ctx, classify(deps), score, checkDocs, minScore)
+}
+
+func ownerAndName(repo clients.Repo) (string, string, error) {
+	// URI is of the form github.com/owner/name.
+	const length = 3
+	parts := strings.Split(repo.URI(), "/")
+	if len(parts) != length {
+		return "", "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid repo: %s", repo.URI()))
+	}
+	return parts[1], parts[2], nil
+}
+
+type dependencyKey struct {
+	ecosystem, manifest, name string
+}
+
+// classify pairs the removed and added versions of the same dependency
+// into a single update.
+func classify(deps []dependency) []DependencyCheckResult {
+	var results []DependencyCheckResult
+	index := make(map[dependencyKey]int)
+	for i := range deps {
+		d := &deps[i]
+		key := dependencyKey{d.Ecosystem, d.Manifest, d.Name}
+		pos, seen := index[key]
+		if !seen {
+			index[key] = len(results)
+			results = append(results, DependencyCheckResult{
+				Ecosystem: d.Ecosystem,
+				Manifest:  d.Manifest,
+				Name:      d.Name,
+			})
+			pos = len(results) - 1
+		}
+		r := &results[pos]
+		switch d.ChangeType {
+		case string(Added):
+			r.Version = d.Version
+			if d.SourceRepositoryURL != nil {
+				r.SourceRepository = *d.SourceRepositoryURL
+			}
+		case string(Removed):
+			r.PreviousVersion = d.Version
+		}
+		switch {
+		case r.Version != "" && r.PreviousVersion != "":
+			r.ChangeType = Updated
+		case r.PreviousVersion != "":
+			r.ChangeType = Removed
+		default:
+			r.ChangeType = Added
+		}
+	}
+	return results
+}
+
+func evaluate(ctx context.Context, results []DependencyCheckResult, score scoreFn,
+	checkDocs docs.Doc, minScore float64) ([]DependencyCheckResult, error) {
+	for i := range results {
+		r := &results[i]
+		if r.ChangeType == Removed || r.SourceRepository == "" {
+			continue
+		}
+		repo, err := githubrepo.MakeGithubRepo(r.SourceRepository)
+		if err != nil {
+			// Only dependencies hosted on GitHub are supported.
+			continue
+		}
+		result, err := score(ctx, repo)
+		if err != nil {
+			return nil, fmt.Errorf("scoring %s: %w", r.SourceRepository, err)
+		}
+		aggregate, err := result.GetAggregateScore(checkDocs)
+		if err != nil {
+			return nil, fmt.Errorf("GetAggregateScore: %w", err)
+		}
+		r.ScorecardResult = &result
+		r.AggregateScore = aggregate
+		r.Regression = aggregate != checker.InconclusiveResultScore && aggregate < minScore
+	}
+	return results, nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencydiff
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-github/v38/github"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+func strptr(s string) *string {
+	return &s
+}
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		deps []dependency
+		want []DependencyCheckResult
+	}{
+		{
+			name: "added",
+			deps: []dependency{
+				{
+					ChangeType: "added", Ecosystem: "npm", Manifest: "package.json", Name: "foo", Version: "1.0.0",
+					SourceRepositoryURL: strptr("https://github.com/owner/foo"),
+				},
+			},
+			want: []DependencyCheckResult{
+				{
+					ChangeType: Added, Ecosystem: "npm", Manifest: "package.json", Name: "foo", Version: "1.0.0",
+					SourceRepository: "https://github.com/owner/foo",
+				},
+			},
+		},
+		{
+			name: "updated",
+			deps: []dependency{
+				{ChangeType: "removed", Ecosystem: "npm", Manifest: "package.json", Name: "foo", Version: "1.0.0"},
+				{ChangeType: "added", Ecosystem: "npm", Manifest: "package.json", Name: "foo", Version: "2.0.0"},
+			},
+			want: []DependencyCheckResult{
+				{
+					ChangeType: Updated, Ecosystem: "npm", Manifest: "package.json", Name: "foo",
+					Version: "2.0.0", PreviousVersion: "1.0.0",
+				},
+			},
+		},
+		{
+			name: "different manifests",
+			deps: []dependency{
+				{ChangeType: "removed", Ecosystem: "npm", Manifest: "a/package.json", Name: "foo", Version: "1.0.0"},
+				{ChangeType: "added", Ecosystem: "npm", Manifest: "b/package.json", Name: "foo", Version: "2.0.0"},
+			},
+			want: []DependencyCheckResult{
+				{ChangeType: Removed, Ecosystem: "npm", Manifest: "a/package.json", Name: "foo", PreviousVersion: "1.0.0"},
+				{ChangeType: Added, Ecosystem: "npm", Manifest: "b/package.json", Name: "foo", Version: "2.0.0"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.want, classify(tt.deps)); diff != "" {
+				t.Errorf("classify() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+	checkDocs, err := docs.Read()
+	if err != nil {
+		t.Fatalf("docs.Read: %v", err)
+	}
+	scores := map[string]int{
+		"github.com/owner/good": 8,
+		"github.com/owner/bad":  2,
+	}
+	score := func(ctx context.Context, repo clients.Repo) (pkg.ScorecardResult, error) {
+		s, ok := scores[repo.URI()]
+		if !ok {
+			return pkg.ScorecardResult{}, errors.New("unexpected repo")
+		}
+		return pkg.ScorecardResult{
+			Checks: []checker.CheckResult{{Name: "Code-Review", Score: s}},
+		}, nil
+	}
+
+	results := []DependencyCheckResult{
+		{ChangeType: Added, Name: "good", SourceRepository: "https://github.com/owner/good"},
+		{ChangeType: Updated, Name: "bad", SourceRepository: "https://github.com/owner/bad"},
+		{ChangeType: Removed, Name: "removed", SourceRepository: "https://github.com/owner/removed"},
+		{ChangeType: Added, Name: "unknown"},
+		{ChangeType: Added, Name: "gitlab", SourceRepository: "https://gitlab.com/owner/repo"},
+	}
+	got, err := evaluate(context.Background(), results, score, checkDocs, 5)
+	if err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+
+	want := []struct {
+		scored     bool
+		score      float64
+		regression bool
+	}{
+		{scored: true, score: 8},
+		{scored: true, score: 2, regression: true},
+		{},
+		{},
+		{},
+	}
+	for i, w := range want {
+		if scored := got[i].ScorecardResult != nil; scored != w.scored {
+			t.Errorf("%s: scored = %v, want %v", got[i].Name, scored, w.scored)
+		}
+		if got[i].AggregateScore != w.score {
+			t.Errorf("%s: AggregateScore = %v, want %v", got[i].Name, got[i].AggregateScore, w.score)
+		}
+		if got[i].Regression != w.regression {
+			t.Errorf("%s: Regression = %v, want %v", got[i].Name, got[i].Regression, w.regression)
+		}
+	}
+}
+
+func TestFetchDependencyDiff(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/dependency-graph/compare/main...feature" {
+			http.NotFound(w, r)
+			return
+		}
+		//nolint:errcheck
+		w.Write([]byte(`[{"change_type": "added", "ecosystem": "npm", "manifest": "package.json",
+			"name": "foo", "version": "1.0.0", "source_repository_url": "https://github.com/owner/foo"}]`))
+	}))
+	defer server.Close()
+
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	client.BaseURL = baseURL
+
+	got, err := fetchDependencyDiff(context.Background(), client, "owner", "repo", "main", "feature")
+	if err != nil {
+		t.Fatalf("fetchDependencyDiff: %v", err)
+	}
+	want := []dependency{
+		{
+			ChangeType: "added", Ecosystem: "npm", Manifest: "package.json", Name: "foo", Version: "1.0.0",
+			SourceRepositoryURL: strptr("https://github.com/owner/foo"),
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("fetchDependencyDiff() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := fetchDependencyDiff(context.Background(), client, "owner", "other", "main", "feature"); err == nil {
+		t.Error("expected error for unknown repo")
+	}
+}
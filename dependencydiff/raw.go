@@ -0,0 +1,52 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependencydiff
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v38/github"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// dependency is a changed dependency, as returned by the GitHub dependency review API.
+// See https://docs.github.com/en/rest/dependency-graph/dependency-review.
+type dependency struct {
+	ChangeType          string  `json:"change_type"`
+	Manifest            string  `json:"manifest"`
+	Ecosystem           string  `json:"ecosystem"`
+	Name                string  `json:"name"`
+	Version             string  `json:"version"`
+	PackageURL          string  `json:"package_url"`
+	SourceRepositoryURL *string `json:"source_repository_url"`
+}
+
+// fetchDependencyDiff returns the dependencies changed between the base and head refs.
+func fetchDependencyDiff(ctx context.Context, client *github.Client,
+	owner, repo, base, head string) ([]dependency, error) {
+	u := fmt.Sprintf("repos/%s/%s/dependency-graph/compare/%s...%s", owner, repo, base, head)
+	req, err := client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("NewRequest: %v", err))
+	}
+	var deps []dependency
+	if _, err := client.Do(ctx, req, &deps); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("dependency review API: %v", err))
+	}
+	return deps, nil
+}
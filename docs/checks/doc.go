@@ -31,5 +31,17 @@ type CheckDoc interface {
 	GetRemediation() []string
 	GetTags() []string
 	GetSupportedRepoTypes() []string
+	GetRequiredAPIs() []string
 	GetDocumentationURL(commitish string) string
+	GetVersion() int
+	GetChanges() []CheckChange
+}
+
+// CheckChange describes a change to the scoring logic of a check.
+type CheckChange struct {
+	// Version of the check's scoring logic introduced by the change.
+	Version int
+	// Release of scorecard which first shipped the change.
+	Release     string
+	Description string
 }
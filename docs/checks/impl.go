@@ -118,6 +118,19 @@ func (c *CheckDocImpl) GetSupportedRepoTypes() []string {
 	return l
 }
 
+// GetRequiredAPIs returns the list of RepoClient
+// methods the check calls.
+func (c *CheckDocImpl) GetRequiredAPIs() []string {
+	if strings.TrimSpace(c.internalCheck.APIs) == "" {
+		return nil
+	}
+	l := strings.Split(c.internalCheck.APIs, ",")
+	for i := range l {
+		l[i] = strings.TrimSpace(l[i])
+	}
+	return l
+}
+
 // GetTags returns the list of tags or the check.
 func (c *CheckDocImpl) GetTags() []string {
 	l := strings.Split(c.internalCheck.Tags, ",")
@@ -135,3 +148,24 @@ func (c *CheckDocImpl) GetDocumentationURL(commitish string) string {
 	}
 	return fmt.Sprintf(c.internalCheck.URL, com)
 }
+
+// GetVersion returns the version of the check's scoring logic.
+func (c *CheckDocImpl) GetVersion() int {
+	if c.internalCheck.Version == 0 {
+		return 1
+	}
+	return c.internalCheck.Version
+}
+
+// GetChanges returns the changes to the check's scoring logic.
+func (c *CheckDocImpl) GetChanges() []CheckChange {
+	l := make([]CheckChange, len(c.internalCheck.Changes))
+	for i, v := range c.internalCheck.Changes {
+		l[i] = CheckChange{
+			Version:     v.Version,
+			Release:     v.Release,
+			Description: v.Description,
+		}
+	}
+	return l
+}
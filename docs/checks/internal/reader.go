@@ -27,14 +27,24 @@ import (
 //go:embed checks.yaml
 var checksYAML []byte
 
+// Change stores a change to a check's scoring logic.
+type Change struct {
+	Version     int    `yaml:"version"`
+	Release     string `yaml:"release"`
+	Description string `yaml:"description"`
+}
+
 // Check stores a check's information.
 // nolint:govet
 type Check struct {
+	Version     int      `yaml:"version"`
+	Changes     []Change `yaml:"changes"`
 	Risk        string   `yaml:"risk"`
 	Short       string   `yaml:"short"`
 	Description string   `yaml:"description"`
 	Tags        string   `yaml:"tags"`
 	Repos       string   `yaml:"repos"`
+	APIs        string   `yaml:"apis"`
 	Remediation []string `yaml:"remediation"`
 	Name        string   `yaml:"-"`
 	URL         string   `yaml:"-"`
@@ -30,28 +30,37 @@ import (
 
 var (
 	allowedRisks     = map[string]bool{"Critical": true, "High": true, "Medium": true, "Low": true}
-	allowedRepoTypes = map[string]bool{"GitHub": true, "local": true}
-	supportedAPIs    = map[string][]string{
+	allowedRepoTypes = map[string]bool{
+		"GitHub": true, "Gitea": true, "Bitbucket": true, "AzureDevOps": true, "local": true,
+	}
+	supportedAPIs = map[string][]string{
 		// InitRepo is supported for local repos in general. However, in the context of checks,
 		// this is only used to look up remote data, e.g. in Fuzzing check.
 		// So we only have "GitHub" supported.
 		"InitRepo":                   {"GitHub"},
-		"URI":                        {"GitHub", "local"},
-		"IsArchived":                 {"GitHub"},
-		"ListFiles":                  {"GitHub", "local"},
-		"GetFileContent":             {"GitHub", "local"},
-		"ListMergedPRs":              {"GitHub"},
-		"ListBranches":               {"GitHub"},
-		"GetDefaultBranch":           {"GitHub"},
-		"ListCommits":                {"GitHub"},
-		"ListIssues":                 {"GitHub"},
-		"ListReleases":               {"GitHub"},
+		"URI":                        {"GitHub", "Gitea", "Bitbucket", "AzureDevOps", "local"},
+		"IsArchived":                 {"GitHub", "Gitea", "Bitbucket", "AzureDevOps"},
+		"ListFiles":                  {"GitHub", "Gitea", "Bitbucket", "AzureDevOps", "local"},
+		"GetFileContent":             {"GitHub", "Gitea", "Bitbucket", "AzureDevOps", "local"},
+		"ListMergedPRs":              {"GitHub", "Gitea", "Bitbucket", "AzureDevOps"},
+		"ListBranches":               {"GitHub", "Gitea", "Bitbucket", "AzureDevOps"},
+		"GetBranch":                  {"GitHub", "Gitea", "Bitbucket", "AzureDevOps"},
+		"GetDefaultBranch":           {"GitHub", "Gitea", "Bitbucket", "AzureDevOps", "local"},
+		"ListBranchUpdates":          {"local"},
+		"ListCommits":                {"GitHub", "Gitea", "Bitbucket", "AzureDevOps", "local"},
+		"ListIssues":                 {"GitHub", "Gitea", "Bitbucket"},
+		"ListReleases":               {"GitHub", "Gitea", "Bitbucket", "AzureDevOps"},
+		"ListAttestations":           {"GitHub"},
+		"ListContainerImages":        {"GitHub"},
+		"ListTags":                   {"GitHub"},
+		"ListBranchesForCommit":      {"GitHub"},
 		"ListContributors":           {"GitHub"},
-		"ListSuccessfulWorkflowRuns": {"GitHub"},
+		"ListSuccessfulWorkflowRuns": {"GitHub"}, // Checks only look for GitHub workflows.
 		"ListCheckRunsForRef":        {"GitHub"},
-		"ListStatuses":               {"GitHub"},
+		"ListStatuses":               {"GitHub", "Gitea", "Bitbucket", "AzureDevOps"},
+		"ListSecurityAdvisories":     {"GitHub"},
 		"Search":                     {"GitHub", "local"},
-		"Close":                      {"GitHub", "local"},
+		"Close":                      {"GitHub", "Gitea", "Bitbucket", "AzureDevOps", "local"},
 	}
 )
 
@@ -150,6 +159,16 @@ func supportedInterfacesFromImplementation(checkName string, checkFiles map[stri
 	if checkName == checks.CheckCIIBestPractices || checkName == checks.CheckFuzzing {
 		return []string{"GitHub"}, nil
 	}
+	// Special case. The git history is used instead
+	// when the branch protection settings are not available.
+	if checkName == checks.CheckBranchProtection {
+		return []string{"GitHub", "Gitea", "Bitbucket", "AzureDevOps", "local"}, nil
+	}
+	// Special case. The attestations and container images are only looked
+	// up on GitHub, the releases are scored on every forge.
+	if checkName == checks.CheckSignedReleases {
+		return []string{"GitHub", "Gitea", "Bitbucket", "AzureDevOps"}, nil
+	}
 
 	// Create our map.
 	s := make(map[string]bool)
@@ -228,6 +247,56 @@ func validateAPINames() error {
 	return nil
 }
 
+// Validate that a check lists the APIs its implementation calls.
+func validateRequiredAPIs(checkName string, apis []string, checkFiles map[string]string) error {
+	for _, api := range apis {
+		if _, exists := supportedAPIs[api]; !exists {
+			//nolint:goerr113
+			return fmt.Errorf("%s: unknown API: %s", checkName, api)
+		}
+	}
+
+	pathfn, exists := checkFiles[checkName]
+	if !exists {
+		//nolint:goerr113
+		return fmt.Errorf("check %s does not exists", checkName)
+	}
+	content, err := os.ReadFile(pathfn)
+	if err != nil {
+		return fmt.Errorf("os.ReadFile: %s: %w", pathfn, err)
+	}
+	for api := range supportedAPIs {
+		re := regexp.MustCompile(fmt.Sprintf(`\.%s\(`, api))
+		if re.Match(content) && !contains(apis, api) {
+			//nolint:goerr113
+			return fmt.Errorf("%s: %s calls %s, which is not listed in apis", checkName, pathfn, api)
+		}
+	}
+	return nil
+}
+
+// The version of a check must be the one introduced by its latest change.
+// Version 1 is the initial scoring logic and has no change entry.
+func validateVersion(c docs.CheckDoc) error {
+	latest := 1
+	for _, change := range c.GetChanges() {
+		if change.Release == "" || change.Description == "" {
+			//nolint:goerr113
+			return fmt.Errorf("change to version %d is missing a release or description", change.Version)
+		}
+		if change.Version <= latest {
+			//nolint:goerr113
+			return fmt.Errorf("changes are not listed in increasing version order: %d", change.Version)
+		}
+		latest = change.Version
+	}
+	if c.GetVersion() != latest {
+		//nolint:goerr113
+		return fmt.Errorf("version is %d, but the latest change is for version %d", c.GetVersion(), latest)
+	}
+	return nil
+}
+
 func main() {
 	m, err := docs.Read()
 	if err != nil {
@@ -265,6 +334,7 @@ func main() {
 		if len(c.GetTags()) == 0 {
 			panic(fmt.Sprintf("tags for checkName: %s is empty", check))
 		}
+		validateTags(c)
 		r := c.GetRisk()
 		if _, exists := allowedRisks[r]; !exists {
 			panic(fmt.Sprintf("risk for checkName: %s is invalid: '%s'", check, r))
@@ -279,6 +349,14 @@ func main() {
 			}
 		}
 
+		if err := validateVersion(c); err != nil {
+			panic(fmt.Sprintf("validateVersion: %s: %v", check, err))
+		}
+
+		if err := validateRequiredAPIs(check, c.GetRequiredAPIs(), checkFiles); err != nil {
+			panic(fmt.Sprintf("validateRequiredAPIs: %v", err))
+		}
+
 		// Validate that the check only calls API the interface supports.
 		if err := validateRepoTypeAPIs(check, repoTypes, checkFiles); err != nil {
 			panic(fmt.Sprintf("validateRepoTypeAPIs: %v", err))
@@ -290,3 +368,23 @@ func main() {
 		}
 	}
 }
+
+// validateTags checks the tags `--checks=tag:<tag>` relies on are consistent.
+func validateTags(c docs.CheckDoc) {
+	tags := make(map[string]bool)
+	for _, t := range c.GetTags() {
+		tags[t] = true
+	}
+	if tags["admin-required"] == tags["no-admin"] {
+		panic(fmt.Sprintf("tags for checkName: %s must contain one of admin-required or no-admin", c.GetName()))
+	}
+	readsCode := false
+	for _, api := range c.GetRequiredAPIs() {
+		if api == "GetFileContent" {
+			readsCode = true
+		}
+	}
+	if tags["code"] != readsCode {
+		panic(fmt.Sprintf("tags for checkName: %s must contain code if and only if it calls GetFileContent", c.GetName()))
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+)
+
+// versionedCheck is a check doc with only a version and scoring changes.
+type versionedCheck struct {
+	docs.CheckDoc
+	changes []docs.CheckChange
+	version int
+}
+
+func (c versionedCheck) GetVersion() int {
+	return c.version
+}
+
+func (c versionedCheck) GetChanges() []docs.CheckChange {
+	return c.changes
+}
+
+func TestValidateVersion(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		check   versionedCheck
+		wantErr bool
+	}{
+		{
+			name:  "initial scoring logic",
+			check: versionedCheck{version: 1},
+		},
+		{
+			name: "valid history",
+			check: versionedCheck{version: 3, changes: []docs.CheckChange{
+				{Version: 2, Release: "v4.0", Description: "first change"},
+				{Version: 3, Release: "v4.1", Description: "second change"},
+			}},
+		},
+		{
+			name: "missing version with changes",
+			check: versionedCheck{changes: []docs.CheckChange{
+				{Version: 2, Release: "v4.0", Description: "first change"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "version not bumped for the latest change",
+			check: versionedCheck{version: 2, changes: []docs.CheckChange{
+				{Version: 2, Release: "v4.0", Description: "first change"},
+				{Version: 3, Release: "v4.1", Description: "second change"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "non-monotonic versions",
+			check: versionedCheck{version: 3, changes: []docs.CheckChange{
+				{Version: 3, Release: "v4.1", Description: "second change"},
+				{Version: 2, Release: "v4.0", Description: "first change"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "repeated version",
+			check: versionedCheck{version: 2, changes: []docs.CheckChange{
+				{Version: 2, Release: "v4.0", Description: "first change"},
+				{Version: 2, Release: "v4.1", Description: "second change"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "change to the initial version",
+			check: versionedCheck{version: 1, changes: []docs.CheckChange{
+				{Version: 1, Release: "v4.0", Description: "first change"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "missing release",
+			check: versionedCheck{version: 2, changes: []docs.CheckChange{
+				{Version: 2, Description: "first change"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "missing description",
+			check: versionedCheck{version: 2, changes: []docs.CheckChange{
+				{Version: 2, Release: "v4.0"},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := validateVersion(tt.check); (err != nil) != tt.wantErr {
+				t.Errorf("validateVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@
 package errors
 
 import (
+	"context"
 	"errors"
 	"fmt"
 )
@@ -23,12 +24,28 @@ import (
 var (
 	ErrScorecardInternal = errors.New("internal error")
 	ErrRepoUnreachable   = errors.New("repo unreachable")
+	// ErrRepoNotFound indicates the repo, or the branch or commit to score,
+	// does not exist or is not visible with the credentials used.
+	ErrRepoNotFound = errors.New("repo not found")
+	// ErrRepoUnsupportedHost indicates the repo's host is unsupported.
+	ErrRepoUnsupportedHost = errors.New("unsupported host")
 	// ErrorUnsupportedHost indicates the repo's host is unsupported.
-	ErrorUnsupportedHost = errors.New("unsupported host")
+	//
+	// Deprecated: use ErrRepoUnsupportedHost.
+	ErrorUnsupportedHost = ErrRepoUnsupportedHost
 	// ErrorInvalidURL indicates the repo's full URL was not passed.
 	ErrorInvalidURL = errors.New("invalid repo flag")
 	// ErrorShellParsing indicates there was an error when parsing shell code.
 	ErrorShellParsing = errors.New("error parsing shell code")
+	// ErrScoreInconclusive indicates a check had nothing to score,
+	// e.g. a repo without releases for Signed-Releases.
+	ErrScoreInconclusive = errors.New("inconclusive score")
+	// ErrNotApplicable indicates a check does not apply to the repo,
+	// e.g. a check for evidence only public repos can have.
+	ErrNotApplicable = errors.New("not applicable")
+	// ErrMissingPermissions indicates the credentials lack a permission
+	// a check needs.
+	ErrMissingPermissions = errors.New("missing permissions")
 )
 
 // WithMessage wraps any of the errors listed above.
@@ -42,13 +59,63 @@ func WithMessage(e error, msg string) error {
 	return fmt.Errorf("%w", e)
 }
 
+// Wrap wraps any of the errors listed above like WithMessage, keeping
+// cause in the chain of wrapped errors, so that errors.Is and errors.As
+// also match the cause. Its message is "e: msg: cause", msg being optional.
+func Wrap(e, cause error, msg string) error {
+	return &wrappedError{err: e, cause: cause, msg: msg}
+}
+
+type wrappedError struct {
+	err   error
+	cause error
+	msg   string
+}
+
+func (w *wrappedError) Error() string {
+	ret := w.err.Error()
+	if w.msg != "" {
+		ret += ": " + w.msg
+	}
+	if w.cause != nil {
+		ret += ": " + w.cause.Error()
+	}
+	return ret
+}
+
+func (w *wrappedError) Is(target error) bool {
+	return errors.Is(w.err, target)
+}
+
+func (w *wrappedError) Unwrap() error {
+	return w.cause
+}
+
+// IsTransient returns whether err may not happen again if retried later,
+// as opposed to an error of the check or of the repo, such as ErrRepoNotFound.
+func IsTransient(err error) bool {
+	return errors.Is(err, ErrRepoUnreachable) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, context.Canceled)
+}
+
 // GetName returns the name of the error.
 func GetName(err error) string {
 	switch {
+	case errors.Is(err, ErrMissingPermissions):
+		return "ErrMissingPermissions"
+	case errors.Is(err, ErrScoreInconclusive):
+		return "ErrScoreInconclusive"
+	case errors.Is(err, ErrNotApplicable):
+		return "ErrNotApplicable"
 	case errors.Is(err, ErrScorecardInternal):
 		return "ErrScorecardInternal"
 	case errors.Is(err, ErrRepoUnreachable):
 		return "ErrRepoUnreachable"
+	case errors.Is(err, ErrRepoNotFound):
+		return "ErrRepoNotFound"
+	case errors.Is(err, ErrRepoUnsupportedHost):
+		return "ErrRepoUnsupportedHost"
 	case errors.Is(err, ErrorShellParsing):
 		return "ErrorShellParsing"
 	default:
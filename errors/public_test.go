@@ -0,0 +1,107 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+	errCause := errors.New("403 Forbidden")
+	tests := []struct {
+		name     string
+		err      error
+		wantMsg  string
+		wantName string
+		is       []error
+		isNot    []error
+	}{
+		{
+			name:     "message and cause",
+			err:      Wrap(ErrScorecardInternal, errCause, "ListCommits"),
+			wantMsg:  "internal error: ListCommits: 403 Forbidden",
+			wantName: "ErrScorecardInternal",
+			is:       []error{ErrScorecardInternal, errCause},
+			isNot:    []error{ErrRepoUnreachable},
+		},
+		{
+			name:     "cause only",
+			err:      Wrap(ErrRepoUnreachable, errCause, ""),
+			wantMsg:  "repo unreachable: 403 Forbidden",
+			wantName: "ErrRepoUnreachable",
+			is:       []error{ErrRepoUnreachable, errCause},
+		},
+		{
+			name:     "typed cause",
+			err:      fmt.Errorf("check: %w", Wrap(ErrScorecardInternal, WithMessage(ErrMissingPermissions, "admin"), "")),
+			wantMsg:  "check: internal error: missing permissions: admin",
+			wantName: "ErrMissingPermissions",
+			is:       []error{ErrScorecardInternal, ErrMissingPermissions},
+		},
+		{
+			name:     "deprecated name",
+			err:      WithMessage(ErrorUnsupportedHost, "gitlab.com"),
+			wantMsg:  "unsupported host: gitlab.com",
+			wantName: "ErrRepoUnsupportedHost",
+			is:       []error{ErrRepoUnsupportedHost},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.err.Error(); got != tt.wantMsg {
+				t.Errorf("Error() = %q, want %q", got, tt.wantMsg)
+			}
+			if got := GetName(tt.err); got != tt.wantName {
+				t.Errorf("GetName() = %q, want %q", got, tt.wantName)
+			}
+			for _, target := range tt.is {
+				if !errors.Is(tt.err, target) {
+					t.Errorf("errors.Is(%v) = false, want true", target)
+				}
+			}
+			for _, target := range tt.isNot {
+				if errors.Is(tt.err, target) {
+					t.Errorf("errors.Is(%v) = true, want false", target)
+				}
+			}
+		})
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: WithMessage(ErrRepoUnreachable, "github.com/owner/repo"), want: true},
+		{err: WithMessage(ErrRepoNotFound, "github.com/owner/repo"), want: false},
+		{err: Wrap(ErrScorecardInternal, context.DeadlineExceeded, "check timed out after 10m"), want: true},
+		{err: WithMessage(ErrScorecardInternal, "invalid Dockerfile"), want: false},
+		{err: WithMessage(ErrScoreInconclusive, "no releases found"), want: false},
+		{err: nil, want: false},
+	}
+	for _, tt := range tests {
+		if got := IsTransient(tt.err); got != tt.want {
+			t.Errorf("IsTransient(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
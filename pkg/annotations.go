@@ -0,0 +1,249 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/checker"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// TriageState is the state attached to a finding, see checker.TriageState.
+type TriageState = checker.TriageState
+
+// The triage states users attach with `scorecard annotate`.
+const (
+	TriageAcceptedRisk  = checker.TriageAcceptedRisk
+	TriageFixInProgress = checker.TriageFixInProgress
+	TriageFalsePositive = checker.TriageFalsePositive
+)
+
+// The triage states maintainers attach in their MaintainerAnnotationsFile.
+const (
+	TriageAcknowledged  = checker.TriageAcknowledged
+	TriageWontFix       = checker.TriageWontFix
+	TriageNotApplicable = checker.TriageNotApplicable
+)
+
+var errInvalidTriageState = errors.New("invalid triage state")
+
+// ParseTriageState validates a triage state string.
+func ParseTriageState(s string) (TriageState, error) {
+	switch TriageState(s) {
+	case TriageAcceptedRisk, TriageFixInProgress, TriageFalsePositive,
+		TriageAcknowledged, TriageWontFix, TriageNotApplicable:
+		return TriageState(s), nil
+	default:
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%v: %s", errInvalidTriageState, s))
+	}
+}
+
+// suppresses returns true if findings in this state
+// should no longer be reported as warnings.
+func suppresses(s TriageState) bool {
+	switch s {
+	case TriageAcceptedRisk, TriageFalsePositive, TriageWontFix, TriageNotApplicable:
+		return true
+	default:
+		return false
+	}
+}
+
+// Annotation attaches a triage state to findings, see checker.Annotation.
+type Annotation = checker.Annotation
+
+// ResultStore persists annotations on stored results.
+type ResultStore interface {
+	Annotations(repo string) ([]Annotation, error)
+	Annotate(repo string, a Annotation) error
+}
+
+// FileResultStore is a ResultStore backed by a local JSON file,
+// keyed by repo name.
+type FileResultStore struct {
+	path string
+}
+
+// NewFileResultStore returns a ResultStore which persists annotations in `path`.
+func NewFileResultStore(path string) *FileResultStore {
+	return &FileResultStore{path: path}
+}
+
+func (s *FileResultStore) read() (map[string][]Annotation, error) {
+	ret := make(map[string][]Annotation)
+	content, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ret, nil
+	}
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.ReadFile: %v", err))
+	}
+	if err := json.Unmarshal(content, &ret); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Unmarshal: %v", err))
+	}
+	return ret, nil
+}
+
+// Annotations implements ResultStore.Annotations.
+func (s *FileResultStore) Annotations(repo string) ([]Annotation, error) {
+	all, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	return all[repo], nil
+}
+
+// Annotate implements ResultStore.Annotate.
+// An existing annotation for the same finding is replaced.
+func (s *FileResultStore) Annotate(repo string, a Annotation) error {
+	if _, err := ParseTriageState(string(a.State)); err != nil {
+		return err
+	}
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	annotations := []Annotation{}
+	for _, e := range all[repo] {
+		if e.FindingID != a.FindingID {
+			annotations = append(annotations, e)
+		}
+	}
+	all[repo] = append(annotations, a)
+
+	content, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.MarshalIndent: %v", err))
+	}
+	//nolint:gomnd
+	if err := os.WriteFile(s.path, content, 0o600); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("os.WriteFile: %v", err))
+	}
+	return nil
+}
+
+// FindingID returns a stable identifier for a detail of a check.
+// Details from the message catalog are identified by their message ID and
+// parameters rather than their text, so that rewording a message does not
+// change the identifier. Line numbers are not part of the identifier either,
+// so that it survives unrelated edits to the file.
+func FindingID(checkName string, d *checker.CheckDetail) string {
+	attributes := []string{string(d.Msg.Finding)}
+	if m := d.Msg.Message; m != nil {
+		attributes = append(attributes, string(m.ID))
+		keys := make([]string, 0, len(m.Params))
+		for k := range m.Params {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			attributes = append(attributes, k+"="+m.Params[k])
+		}
+	} else {
+		attributes = append(attributes, d.Msg.Text)
+	}
+	return checker.NewFindingID(checkName, "", d.Msg.Path, attributes...)
+}
+
+// ApplyAnnotations attaches the annotations to the warnings they match, the
+// first matching annotation winning, and downgrades the ones triaged as
+// accepted-risk, false-positive, wont-fix or not-applicable to info, so they
+// are no longer SARIF results or new findings of a diff. Scores are unchanged,
+// so a check scoring below its policy still fails it.
+func (r *ScorecardResult) ApplyAnnotations(annotations []Annotation) {
+	r.applyAnnotations(annotations, true)
+}
+
+// applyAnnotations attaches the annotations to the warnings they match, and
+// only downgrades them if suppress is set, e.g. for the annotations of the
+// repo itself, which the caller may not trust to exclude findings.
+func (r *ScorecardResult) applyAnnotations(annotations []Annotation, suppress bool) {
+	if len(annotations) == 0 {
+		return
+	}
+	for i := range r.Checks {
+		check := &r.Checks[i]
+		for j := range check.Details2 {
+			d := &check.Details2[j]
+			if d.Type != checker.DetailWarn {
+				continue
+			}
+			for k := range annotations {
+				if !annotationMatches(&annotations[k], check.Name, d) {
+					continue
+				}
+				a := annotations[k]
+				d.Annotation = &a
+				if suppress && suppresses(a.State) {
+					d.Type = checker.DetailInfo
+				}
+				break
+			}
+		}
+	}
+}
+
+func annotationMatches(a *Annotation, checkName string, d *checker.CheckDetail) bool {
+	if a.Check != checkName {
+		return false
+	}
+	if a.FindingID == "" && len(a.Paths) == 0 {
+		return true
+	}
+	for _, p := range a.Paths {
+		if matchAnnotationPath(p, d.Msg.Path) {
+			return true
+		}
+	}
+	return a.FindingID != "" && a.FindingID == FindingID(checkName, d)
+}
+
+func matchAnnotationPath(pattern, p string) bool {
+	if p == "" {
+		return false
+	}
+	if dir := strings.TrimSuffix(pattern, "/**"); dir != pattern {
+		return strings.HasPrefix(p, dir+"/")
+	}
+	matched, err := path.Match(pattern, p)
+	return err == nil && matched
+}
+
+// SuppressFindings accepts the risk of the findings whose ID is in ids, e.g.
+// the suppressions of the policy file, with ApplyAnnotations. The ids are
+// recorded in r.Suppressions so that probe findings can be marked as suppressed too.
+func (r *ScorecardResult) SuppressFindings(ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	// Finding IDs are derived from the check name, so an ID only matches the check reporting it.
+	var annotations []Annotation
+	for i := range r.Checks {
+		for _, id := range ids {
+			annotations = append(annotations, Annotation{
+				FindingID: id, Check: r.Checks[i].Name, State: TriageAcceptedRisk, Reason: "suppressed by the policy",
+			})
+		}
+	}
+	r.ApplyAnnotations(annotations)
+	r.Suppressions = append(r.Suppressions, ids...)
+}
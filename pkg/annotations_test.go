@@ -0,0 +1,132 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ossf/scorecard/v3/checker"
+)
+
+func TestAnnotations(t *testing.T) {
+	t.Parallel()
+	warn := func(path, text string) checker.CheckDetail {
+		return checker.CheckDetail{
+			Type: checker.DetailWarn,
+			Msg:  checker.LogMessage{Path: path, Text: text, Offset: 1, Version: 3},
+		}
+	}
+	result := ScorecardResult{
+		Repo: RepoInfo{Name: "github.com/foo/bar"},
+		Checks: []checker.CheckResult{
+			{
+				Name: "Binary-Artifacts",
+				Details2: []checker.CheckDetail{
+					warn("bin/accepted", "binary detected"),
+					warn("bin/in-progress", "binary detected"),
+					warn("bin/untriaged", "binary detected"),
+				},
+			},
+		},
+	}
+	details := result.Checks[0].Details2
+
+	store := NewFileResultStore(filepath.Join(t.TempDir(), "annotations.json"))
+	for _, a := range []Annotation{
+		// Overwritten below.
+		{FindingID: FindingID("Binary-Artifacts", &details[0]), Check: "Binary-Artifacts", State: TriageFixInProgress},
+		{FindingID: FindingID("Binary-Artifacts", &details[0]), Check: "Binary-Artifacts", State: TriageAcceptedRisk},
+		{FindingID: FindingID("Binary-Artifacts", &details[1]), Check: "Binary-Artifacts", State: TriageFixInProgress},
+	} {
+		if err := store.Annotate(result.Repo.Name, a); err != nil {
+			t.Fatalf("Annotate: %v", err)
+		}
+	}
+	if err := store.Annotate(result.Repo.Name, Annotation{State: "ignored"}); err == nil {
+		t.Errorf("expected error for invalid state")
+	}
+
+	annotations, err := store.Annotations(result.Repo.Name)
+	if err != nil {
+		t.Fatalf("Annotations: %v", err)
+	}
+	if len(annotations) != 2 {
+		t.Fatalf("expected 2 annotations, got %d", len(annotations))
+	}
+	if other, err := store.Annotations("github.com/foo/baz"); err != nil || len(other) != 0 {
+		t.Errorf("expected no annotations for another repo, got %v, %v", other, err)
+	}
+
+	// Line numbers are not part of the finding ID.
+	moved := details[0]
+	moved.Msg.Offset = 42
+	if FindingID("Binary-Artifacts", &moved) != FindingID("Binary-Artifacts", &details[0]) {
+		t.Errorf("finding ID depends on the line number")
+	}
+
+	result.ApplyAnnotations(annotations)
+	expected := []checker.DetailType{checker.DetailInfo, checker.DetailWarn, checker.DetailWarn}
+	for i, d := range result.Checks[0].Details2 {
+		if d.Type != expected[i] {
+			t.Errorf("%s: expected type %v, got %v", d.Msg.Path, expected[i], d.Type)
+		}
+	}
+}
+
+func TestSuppressFindings(t *testing.T) {
+	t.Parallel()
+	warn := func(path string, msg *checker.Message) checker.CheckDetail {
+		return checker.CheckDetail{
+			Type: checker.DetailWarn,
+			Msg:  checker.LogMessage{Path: path, Text: msg.String(), Message: msg, Offset: 1, Version: 3},
+		}
+	}
+	notSigned := checker.NewMessage(checker.MsgSignedReleasesNotSigned, checker.MessageParams{"release": "v1"})
+	result := ScorecardResult{
+		Checks: []checker.CheckResult{
+			{
+				Name: "Signed-Releases",
+				Details2: []checker.CheckDetail{
+					warn("https://example.com/v1", notSigned),
+					warn("https://example.com/v2", notSigned),
+				},
+			},
+		},
+	}
+	details := result.Checks[0].Details2
+
+	// Rewording a message does not change the finding ID.
+	reworded := details[0]
+	reworded.Msg.Text = "release v1 has no signature"
+	if FindingID("Signed-Releases", &reworded) != FindingID("Signed-Releases", &details[0]) {
+		t.Errorf("finding ID depends on the text of catalog messages")
+	}
+
+	id := FindingID("Signed-Releases", &details[1])
+	result.SuppressFindings([]string{id})
+	expected := []checker.DetailType{checker.DetailWarn, checker.DetailInfo}
+	for i, d := range result.Checks[0].Details2 {
+		if d.Type != expected[i] {
+			t.Errorf("%s: expected type %v, got %v", d.Msg.Path, expected[i], d.Type)
+		}
+	}
+	if a := result.Checks[0].Details2[1].Annotation; a == nil || a.State != TriageAcceptedRisk {
+		t.Errorf("expected the suppressed finding to be annotated as accepted-risk, got %v", a)
+	}
+	if len(result.Suppressions) != 1 || result.Suppressions[0] != id {
+		t.Errorf("expected suppressions [%s], got %v", id, result.Suppressions)
+	}
+}
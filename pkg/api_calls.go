@@ -0,0 +1,37 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import "time"
+
+// ScanStats is the cost of a scan, e.g. to tune the API quota of batch scans.
+type ScanStats struct {
+	// APICalls are the API calls made, retries included.
+	APICalls int
+	// Duration is the wall time of the scan.
+	Duration time.Duration
+	// APICallBudgetExceeded is set if API calls were refused for exceeding
+	// the budget set with WithAPICallBudget, failing the checks making them.
+	APICallBudgetExceeded bool
+}
+
+// WithAPICallBudget fails the API calls made once max calls were made for
+// the repo, zero for no limit. The checks making them fail with runtime
+// errors, and ScanStats.APICallBudgetExceeded is set.
+func WithAPICallBudget(max int) Option {
+	return func(o *runOptions) {
+		o.apiCallBudget = max
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ossf/scorecard/v3/checker"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+const badgeLabel = "openssf scorecard"
+
+// shieldsBadge is the JSON schema of a shields.io endpoint badge.
+// See https://shields.io/endpoint.
+type shieldsBadge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// badgeColor maps an aggregate score to a shields.io color name.
+func badgeColor(score float64) string {
+	switch {
+	case score == checker.InconclusiveResultScore:
+		return "lightgrey"
+	case score < 4:
+		return "red"
+	case score < 6:
+		return "orange"
+	case score < 8:
+		return "yellow"
+	default:
+		return "brightgreen"
+	}
+}
+
+// badgeHex maps a shields.io color name to the hex value used in SVG badges.
+var badgeHex = map[string]string{
+	"lightgrey":   "#9f9f9f",
+	"red":         "#e05d44",
+	"orange":      "#fe7d37",
+	"yellow":      "#dfb317",
+	"brightgreen": "#4c1",
+}
+
+func (r *ScorecardResult) badge(checkDocs docs.Doc) (shieldsBadge, error) {
+	score, err := r.GetAggregateScore(checkDocs)
+	if err != nil {
+		return shieldsBadge{}, err
+	}
+	message := fmt.Sprintf("%.1f", score)
+	if score == checker.InconclusiveResultScore {
+		message = "?"
+	}
+	return shieldsBadge{
+		SchemaVersion: 1,
+		Label:         badgeLabel,
+		Message:       message,
+		Color:         badgeColor(score),
+	}, nil
+}
+
+// AsBadgeJSON writes the aggregate score as shields.io endpoint JSON.
+func (r *ScorecardResult) AsBadgeJSON(checkDocs docs.Doc, writer io.Writer) error {
+	b, err := r.badge(checkDocs)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(writer).Encode(b); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("encoder.Encode: %v", err))
+	}
+	return nil
+}
+
+// Character widths used to size the SVG badge, in pixels.
+const (
+	badgeCharWidth = 7
+	badgePadding   = 10
+)
+
+const badgeSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[3]s: %[4]s">` +
+	`<title>%[3]s: %[4]s</title>` +
+	`<rect width="%[2]d" height="20" fill="#555"/>` +
+	`<rect x="%[2]d" width="%[5]d" height="20" fill="%[6]s"/>` +
+	`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` +
+	`<text x="%[7]d" y="14">%[3]s</text>` +
+	`<text x="%[8]d" y="14">%[4]s</text>` +
+	`</g></svg>
+`
+
+// AsBadgeSVG writes the aggregate score as an SVG badge.
+func (r *ScorecardResult) AsBadgeSVG(checkDocs docs.Doc, writer io.Writer) error {
+	b, err := r.badge(checkDocs)
+	if err != nil {
+		return err
+	}
+	labelWidth := len(b.Label)*badgeCharWidth + badgePadding
+	messageWidth := len(b.Message)*badgeCharWidth + badgePadding
+	if _, err := fmt.Fprintf(writer, badgeSVG,
+		labelWidth+messageWidth, labelWidth, b.Label, b.Message, messageWidth, badgeHex[b.Color],
+		labelWidth/2, labelWidth+messageWidth/2); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("fmt.Fprintf: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ossf/scorecard/v3/checker"
+)
+
+func TestAsBadgeJSON(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		checks   []checker.CheckResult
+		expected string
+	}{
+		{
+			name: "high score",
+			checks: []checker.CheckResult{
+				{Name: "Check-Name", Score: 9},
+			},
+			expected: `{"schemaVersion":1,"label":"openssf scorecard","message":"9.0","color":"brightgreen"}` + "\n",
+		},
+		{
+			name: "medium score",
+			checks: []checker.CheckResult{
+				{Name: "Check-Name", Score: 10},
+				{Name: "Check-Name2", Score: 0},
+			},
+			expected: `{"schemaVersion":1,"label":"openssf scorecard","message":"6.0","color":"yellow"}` + "\n",
+		},
+		{
+			name: "low score",
+			checks: []checker.CheckResult{
+				{Name: "Check-Name", Score: 3},
+			},
+			expected: `{"schemaVersion":1,"label":"openssf scorecard","message":"3.0","color":"red"}` + "\n",
+		},
+		{
+			name: "inconclusive",
+			checks: []checker.CheckResult{
+				{Name: "Check-Name", Score: checker.InconclusiveResultScore},
+			},
+			expected: `{"schemaVersion":1,"label":"openssf scorecard","message":"?","color":"lightgrey"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := ScorecardResult{Checks: tt.checks}
+			var out bytes.Buffer
+			if err := result.AsBadgeJSON(jsonMockDocRead(), &out); err != nil {
+				t.Fatalf("AsBadgeJSON: %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("AsBadgeJSON() = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestAsBadgeSVG(t *testing.T) {
+	t.Parallel()
+	result := ScorecardResult{
+		Checks: []checker.CheckResult{{Name: "Check-Name", Score: 5}},
+	}
+	var out bytes.Buffer
+	if err := result.AsBadgeSVG(jsonMockDocRead(), &out); err != nil {
+		t.Fatalf("AsBadgeSVG: %v", err)
+	}
+	svg := out.String()
+	for _, want := range []string{"<svg ", "openssf scorecard: 5.0", "#fe7d37", "</svg>"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("AsBadgeSVG() = %q, missing %q", svg, want)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+// CapabilityMode describes how much of a check could run against a forge.
+type CapabilityMode string
+
+const (
+	// CapabilityFull means every RepoClient API used by the check was supported.
+	CapabilityFull CapabilityMode = "full"
+	// CapabilityPartial means the check completed, but some of the
+	// RepoClient APIs it used are not supported by the forge.
+	CapabilityPartial CapabilityMode = "partial"
+	// CapabilityUnsupported means the check could not run on the forge.
+	CapabilityUnsupported CapabilityMode = "unsupported"
+)
+
+// CapabilityMatrix records, for a single run, which checks ran in full,
+// partial or unsupported mode on the forge hosting the repo.
+type CapabilityMatrix struct {
+	Forge  string
+	Checks map[string]CapabilityMode
+	// Reasons explains why checks did not run in full mode, when known.
+	Reasons map[string]string
+	// Fast is set when the run only used repo metadata APIs, see `--fast`.
+	Fast bool
+}
+
+// capabilityRecorder wraps a RepoClient and counts the calls
+// which failed because the forge does not support them.
+type capabilityRecorder struct {
+	clients.RepoClient
+	mu          sync.Mutex
+	unsupported int
+}
+
+func (r *capabilityRecorder) record(err error) {
+	if !errors.Is(err, clients.ErrUnsupportedFeature) {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.unsupported++
+}
+
+func (r *capabilityRecorder) mode(result *checker.CheckResult) CapabilityMode {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case errors.Is(result.Error2, clients.ErrUnsupportedFeature):
+		return CapabilityUnsupported
+	// Most checks re-wrap client errors as ErrScorecardInternal,
+	// so also treat a failed check that hit an unsupported API as unsupported.
+	case result.Error2 != nil && r.unsupported > 0:
+		return CapabilityUnsupported
+	case r.unsupported > 0:
+		return CapabilityPartial
+	default:
+		return CapabilityFull
+	}
+}
+
+// IsArchived implements RepoClient.IsArchived.
+func (r *capabilityRecorder) IsArchived() (bool, error) {
+	ret, err := r.RepoClient.IsArchived()
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListFiles implements RepoClient.ListFiles.
+func (r *capabilityRecorder) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
+	ret, err := r.RepoClient.ListFiles(predicate)
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// GetFileContent implements RepoClient.GetFileContent.
+func (r *capabilityRecorder) GetFileContent(filename string) ([]byte, error) {
+	ret, err := r.RepoClient.GetFileContent(filename)
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListMergedPRs implements RepoClient.ListMergedPRs.
+func (r *capabilityRecorder) ListMergedPRs() ([]clients.PullRequest, error) {
+	ret, err := r.RepoClient.ListMergedPRs()
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListBranches implements RepoClient.ListBranches.
+func (r *capabilityRecorder) ListBranches() ([]*clients.BranchRef, error) {
+	ret, err := r.RepoClient.ListBranches()
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// GetDefaultBranch implements RepoClient.GetDefaultBranch.
+func (r *capabilityRecorder) GetDefaultBranch() (*clients.BranchRef, error) {
+	ret, err := r.RepoClient.GetDefaultBranch()
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// GetBranch implements RepoClient.GetBranch.
+func (r *capabilityRecorder) GetBranch(name string) (*clients.BranchRef, error) {
+	ret, err := r.RepoClient.GetBranch(name)
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListCommits implements RepoClient.ListCommits.
+func (r *capabilityRecorder) ListCommits() ([]clients.Commit, error) {
+	ret, err := r.RepoClient.ListCommits()
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListIssues implements RepoClient.ListIssues.
+func (r *capabilityRecorder) ListIssues() ([]clients.Issue, error) {
+	ret, err := r.RepoClient.ListIssues()
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListReleases implements RepoClient.ListReleases.
+func (r *capabilityRecorder) ListReleases() ([]clients.Release, error) {
+	ret, err := r.RepoClient.ListReleases()
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListTags implements RepoClient.ListTags.
+func (r *capabilityRecorder) ListTags() ([]clients.Tag, error) {
+	ret, err := r.RepoClient.ListTags()
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListBranchesForCommit implements RepoClient.ListBranchesForCommit.
+func (r *capabilityRecorder) ListBranchesForCommit(sha string) ([]string, error) {
+	ret, err := r.RepoClient.ListBranchesForCommit(sha)
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListContributors implements RepoClient.ListContributors.
+func (r *capabilityRecorder) ListContributors() ([]clients.Contributor, error) {
+	ret, err := r.RepoClient.ListContributors()
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListSuccessfulWorkflowRuns implements RepoClient.ListSuccessfulWorkflowRuns.
+func (r *capabilityRecorder) ListSuccessfulWorkflowRuns(filename string) ([]clients.WorkflowRun, error) {
+	ret, err := r.RepoClient.ListSuccessfulWorkflowRuns(filename)
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListCheckRunsForRef implements RepoClient.ListCheckRunsForRef.
+func (r *capabilityRecorder) ListCheckRunsForRef(ref string) ([]clients.CheckRun, error) {
+	ret, err := r.RepoClient.ListCheckRunsForRef(ref)
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListStatuses implements RepoClient.ListStatuses.
+func (r *capabilityRecorder) ListStatuses(ref string) ([]clients.Status, error) {
+	ret, err := r.RepoClient.ListStatuses(ref)
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListBranchUpdates implements RepoClient.ListBranchUpdates.
+func (r *capabilityRecorder) ListBranchUpdates(branch string) ([]clients.BranchUpdate, error) {
+	ret, err := r.RepoClient.ListBranchUpdates(branch)
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListAttestations implements RepoClient.ListAttestations.
+func (r *capabilityRecorder) ListAttestations(subjectDigest string) ([]clients.Attestation, error) {
+	ret, err := r.RepoClient.ListAttestations(subjectDigest)
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListContainerImages implements RepoClient.ListContainerImages.
+func (r *capabilityRecorder) ListContainerImages() ([]clients.ContainerImage, error) {
+	ret, err := r.RepoClient.ListContainerImages()
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// ListSecurityAdvisories implements RepoClient.ListSecurityAdvisories.
+func (r *capabilityRecorder) ListSecurityAdvisories() ([]clients.SecurityAdvisory, error) {
+	ret, err := r.RepoClient.ListSecurityAdvisories()
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
+
+// Search implements RepoClient.Search.
+func (r *capabilityRecorder) Search(request clients.SearchRequest) (clients.SearchResponse, error) {
+	ret, err := r.RepoClient.Search(request)
+	r.record(err)
+	//nolint:wrapcheck
+	return ret, err
+}
@@ -0,0 +1,88 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+func TestCapabilityRecorderMode(t *testing.T) {
+	t.Parallel()
+	errUnsupported := fmt.Errorf("ListReleases: %w", clients.ErrUnsupportedFeature)
+	errRuntime := errors.New("500 Internal Server Error")
+	tests := []struct {
+		releasesErr error
+		name        string
+		result      checker.CheckResult
+		want        CapabilityMode
+	}{
+		{
+			name:   "every API supported",
+			result: checker.CheckResult{Name: "Signed-Releases", Score: 8},
+			want:   CapabilityFull,
+		},
+		{
+			name:        "runtime error of a supported API",
+			releasesErr: errRuntime,
+			result:      checker.CreateRuntimeErrorResult("Signed-Releases", errRuntime),
+			want:        CapabilityFull,
+		},
+		{
+			name:        "check completed despite an unsupported API",
+			releasesErr: errUnsupported,
+			result:      checker.CheckResult{Name: "Signed-Releases", Score: 8},
+			want:        CapabilityPartial,
+		},
+		{
+			name:        "check failed with the unsupported API error",
+			releasesErr: errUnsupported,
+			result:      checker.CreateRuntimeErrorResult("Signed-Releases", errUnsupported),
+			want:        CapabilityUnsupported,
+		},
+		{
+			name:        "check re-wrapped the unsupported API error",
+			releasesErr: errUnsupported,
+			result: checker.CreateRuntimeErrorResult("Signed-Releases",
+				sce.WithMessage(sce.ErrScorecardInternal, errUnsupported.Error())),
+			want: CapabilityUnsupported,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			repoClient := mockrepo.NewMockRepoClient(ctrl)
+			repoClient.EXPECT().ListReleases().Return(nil, tt.releasesErr)
+			repoClient.EXPECT().ListCommits().Return(nil, nil)
+
+			recorder := &capabilityRecorder{RepoClient: repoClient}
+			recorder.ListReleases() //nolint:errcheck
+			recorder.ListCommits()  //nolint:errcheck
+			if got := recorder.mode(&tt.result); got != tt.want {
+				t.Errorf("mode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -28,13 +28,22 @@ func textToMarkdown(s string) string {
 }
 
 // DetailToString turns a detail information into a string.
+// It returns an empty string if the detail is below logLevel.
 func DetailToString(d *checker.CheckDetail, logLevel zapcore.Level) string {
+	if !ShowDetail(d, logLevel) {
+		return ""
+	}
+	s := detailToString(d)
+	if d.Annotation != nil {
+		s = fmt.Sprintf("%s [%s: %s]", s, d.Annotation.State, d.Annotation.Reason)
+	}
+	return s
+}
+
+func detailToString(d *checker.CheckDetail) string {
 	// UPGRADEv3: remove switch statement.
 	switch d.Msg.Version {
 	case 3:
-		if d.Type == checker.DetailDebug && logLevel != zapcore.DebugLevel {
-			return ""
-		}
 		switch {
 		case d.Msg.Path != "" && d.Msg.Offset != 0:
 			return fmt.Sprintf("%s: %s: %s:%d", typeToString(d.Type), d.Msg.Text, d.Msg.Path, d.Msg.Offset)
@@ -44,24 +53,106 @@ func DetailToString(d *checker.CheckDetail, logLevel zapcore.Level) string {
 			return fmt.Sprintf("%s: %s", typeToString(d.Type), d.Msg.Text)
 		}
 	default:
-		if d.Type == checker.DetailDebug && logLevel != zapcore.DebugLevel {
-			return ""
-		}
 		return fmt.Sprintf("%s: %s", typeToString(d.Type), d.Msg.Text)
 	}
 }
 
-func detailsToString(details []checker.CheckDetail, logLevel zapcore.Level) (string, bool) {
-	// UPGRADEv2: change to make([]string, len(details)).
-	var sa []string
+// ShowDetail returns whether a detail is shown at the given verbosity:
+// debug shows all details, info hides debug ones and warn only shows warnings.
+func ShowDetail(d *checker.CheckDetail, logLevel zapcore.Level) bool {
+	return logLevel.Enabled(detailLevel(d.Type))
+}
+
+func detailLevel(cd checker.DetailType) zapcore.Level {
+	switch cd {
+	case checker.DetailDebug:
+		return zapcore.DebugLevel
+	case checker.DetailInfo:
+		return zapcore.InfoLevel
+	default:
+		return zapcore.WarnLevel
+	}
+}
+
+// detailGroupThreshold is the number of details of a checker.DetailGroup
+// from which text formats summarize them in a single line.
+const detailGroupThreshold = 10
+
+// aggregatedDetails are the lines of a group of details summarized by one line.
+type aggregatedDetails struct {
+	summary string
+	lines   []string
+}
+
+func detailsToString(checkName string, details []checker.CheckDetail, logLevel zapcore.Level) (string, bool) {
+	sa, _ := detailsToLines(checkName, details, logLevel)
+	return strings.Join(sa, "\n"), len(sa) > 0
+}
+
+// detailsToLines returns the lines of the details shown at logLevel. Unless
+// logLevel is debug, large groups of details are summarized by a single line,
+// at the position of their first detail, and returned in aggregated.
+func detailsToLines(checkName string, details []checker.CheckDetail,
+	logLevel zapcore.Level) (lines []string, aggregated []aggregatedDetails) {
+	var shown []checker.CheckDetail
 	for i := range details {
-		v := details[i]
-		s := DetailToString(&v, logLevel)
-		if s != "" {
-			sa = append(sa, s)
+		if ShowDetail(&details[i], logLevel) {
+			shown = append(shown, details[i])
 		}
 	}
-	return strings.Join(sa, "\n"), len(sa) > 0
+
+	// summaries maps the index of the first detail of a large group to the group.
+	summaries := make(map[int]int)
+	skipped := make(map[int]bool)
+	if logLevel != zapcore.DebugLevel {
+		for _, g := range checker.GroupDetails(shown) {
+			g := g
+			if g.Count() < detailGroupThreshold {
+				continue
+			}
+			summaries[g.Indexes[0]] = len(aggregated)
+			a := aggregatedDetails{summary: detailGroupSummary(&g)}
+			for _, i := range g.Indexes {
+				skipped[i] = true
+				a.lines = append(a.lines, detailLine(checkName, &shown[i], logLevel))
+			}
+			aggregated = append(aggregated, a)
+		}
+	}
+
+	// UPGRADEv2: change to make([]string, len(details)).
+	for i := range shown {
+		if j, ok := summaries[i]; ok {
+			lines = append(lines, aggregated[j].summary)
+		}
+		if !skipped[i] {
+			lines = append(lines, detailLine(checkName, &shown[i], logLevel))
+		}
+	}
+	return lines, aggregated
+}
+
+func detailLine(checkName string, d *checker.CheckDetail, logLevel zapcore.Level) string {
+	s := DetailToString(d, logLevel)
+	// Show the finding ID, so warnings can be triaged with `scorecard annotate`.
+	if d.Type == checker.DetailWarn {
+		s = fmt.Sprintf("%s (finding: %s)", s, FindingID(checkName, d))
+	}
+	return s
+}
+
+// detailGroupSummary describes a group of details in a single line,
+// e.g. "Warn: unpinned-dependency: 87 findings across 12 files".
+func detailGroupSummary(g *checker.DetailGroup) string {
+	what := g.Text
+	if g.Finding != "" {
+		what = string(g.Finding)
+	}
+	s := fmt.Sprintf("%s: %s: %d findings", typeToString(g.Type), what, g.Count())
+	if len(g.Paths) > 0 {
+		s = fmt.Sprintf("%s across %d files", s, len(g.Paths))
+	}
+	return s
 }
 
 func typeToString(cd checker.DetailType) string {
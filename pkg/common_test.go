@@ -0,0 +1,166 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ossf/scorecard/v3/checker"
+)
+
+func TestDetailToString(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		detail   checker.CheckDetail
+		logLevel zapcore.Level
+		expected string
+	}{
+		{
+			name: "debug shown at debug level",
+			detail: checker.CheckDetail{
+				Type: checker.DetailDebug,
+				Msg:  checker.LogMessage{Text: "debug message", Version: 3},
+			},
+			logLevel: zapcore.DebugLevel,
+			expected: "Debug: debug message",
+		},
+		{
+			name: "debug hidden at info level",
+			detail: checker.CheckDetail{
+				Type: checker.DetailDebug,
+				Msg:  checker.LogMessage{Text: "debug message", Version: 3},
+			},
+			logLevel: zapcore.InfoLevel,
+			expected: "",
+		},
+		{
+			name: "info hidden at warn level",
+			detail: checker.CheckDetail{
+				Type: checker.DetailInfo,
+				Msg:  checker.LogMessage{Text: "info message", Path: "src/file.go", Version: 3},
+			},
+			logLevel: zapcore.WarnLevel,
+			expected: "",
+		},
+		{
+			name: "warn shown at warn level",
+			detail: checker.CheckDetail{
+				Type: checker.DetailWarn,
+				Msg:  checker.LogMessage{Text: "warn message", Path: "src/file.go", Offset: 3, Version: 3},
+			},
+			logLevel: zapcore.WarnLevel,
+			expected: "Warn: warn message: src/file.go:3",
+		},
+		{
+			name: "warn hidden at error level",
+			detail: checker.CheckDetail{
+				Type: checker.DetailWarn,
+				Msg:  checker.LogMessage{Text: "warn message"},
+			},
+			logLevel: zapcore.ErrorLevel,
+			expected: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := DetailToString(&tt.detail, tt.logLevel); got != tt.expected {
+				t.Errorf("DetailToString() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// groupedDetails returns 12 unpinned dependencies across 3 workflows,
+// between an info and 2 identical warnings.
+func groupedDetails() []checker.CheckDetail {
+	details := []checker.CheckDetail{
+		{Type: checker.DetailInfo, Msg: checker.LogMessage{Text: "pinned", Version: 3}},
+	}
+	for i := 0; i < 12; i++ {
+		details = append(details, checker.CheckDetail{
+			Type: checker.DetailWarn,
+			Msg: checker.LogMessage{
+				Text:    fmt.Sprintf("dependency not pinned by hash: 'action%d'", i),
+				Path:    fmt.Sprintf(".github/workflows/workflow%d.yml", i%3),
+				Offset:  i + 1,
+				Finding: checker.FindingUnpinnedDependency,
+				Version: 3,
+			},
+		})
+	}
+	for i := 0; i < 2; i++ {
+		details = append(details, checker.CheckDetail{
+			Type: checker.DetailWarn,
+			Msg:  checker.LogMessage{Text: "no provenance", Version: 3},
+		})
+	}
+	return details
+}
+
+func TestDetailsToLines(t *testing.T) {
+	t.Parallel()
+	details := groupedDetails()
+	var unpinned []string
+	for i := 1; i <= 12; i++ {
+		unpinned = append(unpinned, detailLine("Check", &details[i], zapcore.InfoLevel))
+	}
+	noProvenance := fmt.Sprintf("Warn: no provenance (finding: %s)", FindingID("Check", &details[13]))
+
+	tests := []struct {
+		name           string
+		wantLines      []string
+		wantAggregated []aggregatedDetails
+		logLevel       zapcore.Level
+	}{
+		{
+			name:     "large groups are summarized",
+			logLevel: zapcore.InfoLevel,
+			wantLines: []string{
+				"Info: pinned",
+				"Warn: unpinned-dependency: 12 findings across 3 files",
+				noProvenance,
+				noProvenance,
+			},
+			wantAggregated: []aggregatedDetails{
+				{summary: "Warn: unpinned-dependency: 12 findings across 3 files", lines: unpinned},
+			},
+		},
+		{
+			name:      "debug lists all details",
+			logLevel:  zapcore.DebugLevel,
+			wantLines: append(append([]string{"Info: pinned"}, unpinned...), noProvenance, noProvenance),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			lines, aggregated := detailsToLines("Check", details, tt.logLevel)
+			if diff := cmp.Diff(tt.wantLines, lines); diff != "" {
+				t.Errorf("lines mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantAggregated, aggregated, cmp.AllowUnexported(aggregatedDetails{})); diff != "" {
+				t.Errorf("aggregated mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
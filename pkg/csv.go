@@ -0,0 +1,52 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// csvAggregateName is the check name of the row holding the aggregate score.
+const csvAggregateName = "Aggregate"
+
+// AsCSV exports results as CSV: one row per check, followed by a row with the
+// aggregate score. Inconclusive scores are written as -1.
+func (r *ScorecardResult) AsCSV(checkDocs docs.Doc, writer io.Writer) error {
+	score, err := r.GetAggregateScore(checkDocs)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(writer)
+	rows := [][]string{{"repo", "commit", "check", "score", "reason"}}
+	for i := range r.Checks {
+		check := &r.Checks[i]
+		rows = append(rows, []string{
+			r.Repo.Name, r.Repo.CommitSHA, check.Name, fmt.Sprintf("%d", check.Score), check.Reason,
+		})
+	}
+	rows = append(rows, []string{
+		r.Repo.Name, r.Repo.CommitSHA, csvAggregateName, fmt.Sprintf("%.1f", score), "",
+	})
+	if err := w.WriteAll(rows); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("csv.WriteAll: %v", err))
+	}
+	return nil
+}
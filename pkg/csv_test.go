@@ -0,0 +1,70 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ossf/scorecard/v3/checker"
+)
+
+func TestCSVOutput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		result   ScorecardResult
+		expected string
+	}{
+		{
+			name: "checks and aggregate",
+			result: ScorecardResult{
+				Repo: RepoInfo{
+					Name:      "github.com/org/name",
+					CommitSHA: "68bc59901773ab4c051dfcea0cc4201a1567ab32",
+				},
+				Checks: []checker.CheckResult{
+					{Name: "Check-Name", Score: 5, Reason: "half of it, with a comma"},
+					{Name: "Check-Name2", Score: checker.InconclusiveResultScore, Reason: "inconclusive"},
+				},
+			},
+			expected: "repo,commit,check,score,reason\n" +
+				"github.com/org/name,68bc59901773ab4c051dfcea0cc4201a1567ab32,Check-Name,5,\"half of it, with a comma\"\n" +
+				"github.com/org/name,68bc59901773ab4c051dfcea0cc4201a1567ab32,Check-Name2,-1,inconclusive\n" +
+				"github.com/org/name,68bc59901773ab4c051dfcea0cc4201a1567ab32,Aggregate,5.0,\n",
+		},
+		{
+			name: "no checks",
+			result: ScorecardResult{
+				Repo: RepoInfo{Name: "github.com/org/name"},
+			},
+			expected: "repo,commit,check,score,reason\n" +
+				"github.com/org/name,,Aggregate,-1.0,\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			if err := tt.result.AsCSV(jsonMockDocRead(), &out); err != nil {
+				t.Fatalf("AsCSV: %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("AsCSV() = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
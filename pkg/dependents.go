@@ -0,0 +1,78 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// maxDependentsPackages bounds the packages whose dependents are counted,
+// e.g. for monorepos publishing hundreds of packages.
+const maxDependentsPackages = 20
+
+// Dependents are the packages published from a repo and the packages
+// depending on them, so consumers can prioritize remediation by blast radius.
+type Dependents struct {
+	Packages []PackageDependents
+	// Ecosystems are the sorted systems of the packages, e.g. NPM.
+	Ecosystems []string
+	// Count is the sum of the dependents of the packages.
+	Count int
+}
+
+// PackageDependents is a package published from a repo and its dependents.
+type PackageDependents struct {
+	clients.Package
+	Dependents int
+}
+
+// ApplyDependents records the packages published from the repo of r and their
+// dependents, counted with client if it is a clients.DependentsCounter.
+// Repos which publish no packages have no Dependents.
+func (r *ScorecardResult) ApplyDependents(ctx context.Context, client clients.PackagesClient) error {
+	packages, err := client.ListPackages(ctx, r.Repo.Name)
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListPackages: %v", err))
+	}
+	if len(packages) == 0 {
+		return nil
+	}
+	counter, canCount := client.(clients.DependentsCounter)
+	ret := Dependents{}
+	ecosystems := make(map[string]bool)
+	for i, p := range packages {
+		d := PackageDependents{Package: p}
+		if canCount && i < maxDependentsPackages {
+			d.Dependents, err = counter.CountDependents(ctx, p)
+			if err != nil {
+				return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("CountDependents: %s: %v", p.Name, err))
+			}
+		}
+		ret.Packages = append(ret.Packages, d)
+		ret.Count += d.Dependents
+		if !ecosystems[p.System] {
+			ecosystems[p.System] = true
+			ret.Ecosystems = append(ret.Ecosystems, p.System)
+		}
+	}
+	sort.Strings(ret.Ecosystems)
+	r.Dependents = &ret
+	return nil
+}
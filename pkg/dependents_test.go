@@ -0,0 +1,112 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+var errDepsDev = errors.New("deps.dev unavailable")
+
+type fakeDependentsClient struct {
+	dependents map[string]int
+	packages   []clients.Package
+	err        error
+}
+
+func (c fakeDependentsClient) ListPackages(ctx context.Context, repoURI string) ([]clients.Package, error) {
+	return c.packages, nil
+}
+
+func (c fakeDependentsClient) CountDependents(ctx context.Context, p clients.Package) (int, error) {
+	return c.dependents[p.Name], c.err
+}
+
+type fakePackagesClient struct {
+	packages []clients.Package
+}
+
+func (c fakePackagesClient) ListPackages(ctx context.Context, repoURI string) ([]clients.Package, error) {
+	return c.packages, nil
+}
+
+func TestApplyDependents(t *testing.T) {
+	t.Parallel()
+	packages := []clients.Package{
+		{System: "PYPI", Name: "pkg"},
+		{System: "NPM", Name: "@owner/pkg"},
+		{System: "NPM", Name: "@owner/cli"},
+	}
+	tests := []struct {
+		client  clients.PackagesClient
+		want    *jsonDependentsV2
+		wantErr error
+		name    string
+	}{
+		{
+			name: "dependents",
+			client: fakeDependentsClient{
+				packages:   packages,
+				dependents: map[string]int{"pkg": 3, "@owner/pkg": 40},
+			},
+			want: &jsonDependentsV2{
+				Count:      43,
+				Ecosystems: []string{"NPM", "PYPI"},
+				Packages: []jsonPackageDependentsV2{
+					{System: "PYPI", Name: "pkg", Dependents: 3},
+					{System: "NPM", Name: "@owner/pkg", Dependents: 40},
+					{System: "NPM", Name: "@owner/cli", Dependents: 0},
+				},
+			},
+		},
+		{
+			name:   "client cannot count dependents",
+			client: fakePackagesClient{packages: packages[:1]},
+			want: &jsonDependentsV2{
+				Ecosystems: []string{"PYPI"},
+				Packages:   []jsonPackageDependentsV2{{System: "PYPI", Name: "pkg"}},
+			},
+		},
+		{
+			name:   "no packages",
+			client: fakeDependentsClient{},
+		},
+		{
+			name:    "error",
+			client:  fakeDependentsClient{packages: packages, err: errDepsDev},
+			wantErr: errDepsDev,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := ScorecardResult{Repo: RepoInfo{Name: "github.com/owner/repo"}}
+			err := r.ApplyDependents(context.Background(), tt.client)
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Fatalf("ApplyDependents() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, dependentsToJSON(r.Dependents)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/checker"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// CheckChange is how a check's result changed between two runs.
+type CheckChange string
+
+const (
+	// CheckImproved means the score went up.
+	CheckImproved CheckChange = "improved"
+	// CheckRegressed means the score went down.
+	CheckRegressed CheckChange = "regressed"
+	// CheckReasonChanged means the score is the same or was inconclusive
+	// in one of the runs, but the reason changed.
+	CheckReasonChanged CheckChange = "changed"
+	// CheckAdded means the check only ran in the new run.
+	CheckAdded CheckChange = "added"
+	// CheckRemoved means the check only ran in the old run.
+	CheckRemoved CheckChange = "removed"
+	// CheckUnchanged means the score and reason are the same.
+	CheckUnchanged CheckChange = "unchanged"
+)
+
+// CheckDiff compares the results of a check in two runs.
+type CheckDiff struct {
+	Name      string
+	Change    CheckChange
+	OldScore  int
+	NewScore  int
+	OldReason string
+	NewReason string
+	// NewFindings are the warnings the old run did not report, if both runs
+	// were written with their details.
+	NewFindings []FindingDiff
+}
+
+// FindingDiff is a warning reported by the new run only.
+type FindingDiff struct {
+	ID   string
+	Text string
+}
+
+// ResultDiff compares two JSON result documents of the same repo.
+type ResultDiff struct {
+	Repo      string
+	OldCommit string
+	NewCommit string
+	OldScore  float64
+	NewScore  float64
+	// Checks are sorted by name.
+	Checks []CheckDiff
+}
+
+// HasRegressions returns true if any check score went down.
+func (d *ResultDiff) HasRegressions() bool {
+	for i := range d.Checks {
+		if d.Checks[i].Change == CheckRegressed {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeJSONResult(r io.Reader) (*jsonScorecardResultV2, error) {
+	var ret jsonScorecardResultV2
+	if err := json.NewDecoder(r).Decode(&ret); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("decoding result: %v", err))
+	}
+	return &ret, nil
+}
+
+// DiffJSONResults compares two result documents written by AsJSON2.
+func DiffJSONResults(oldResult, newResult io.Reader) (*ResultDiff, error) {
+	o, err := decodeJSONResult(oldResult)
+	if err != nil {
+		return nil, err
+	}
+	n, err := decodeJSONResult(newResult)
+	if err != nil {
+		return nil, err
+	}
+	if o.Repo.Name != n.Repo.Name {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("results are for different repos: %s and %s", o.Repo.Name, n.Repo.Name))
+	}
+	if o.Repo.Path != n.Repo.Path {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("results are for different paths of %s: %q and %q", n.Repo.Name, o.Repo.Path, n.Repo.Path))
+	}
+
+	ret := &ResultDiff{
+		Repo:      n.Repo.Name,
+		OldCommit: o.Repo.Commit,
+		NewCommit: n.Repo.Commit,
+		OldScore:  float64(o.AggregateScore),
+		NewScore:  float64(n.AggregateScore),
+	}
+	oldChecks := make(map[string]*jsonCheckResultV2, len(o.Checks))
+	oldHasDetails := false
+	for i := range o.Checks {
+		oldChecks[o.Checks[i].Name] = &o.Checks[i]
+		oldHasDetails = oldHasDetails || len(o.Checks[i].StructuredDetails) > 0
+	}
+	for i := range n.Checks {
+		nc := &n.Checks[i]
+		oc, ok := oldChecks[nc.Name]
+		if !ok {
+			ret.Checks = append(ret.Checks, CheckDiff{
+				Name: nc.Name, Change: CheckAdded,
+				OldScore: checker.InconclusiveResultScore, NewScore: nc.Score, NewReason: nc.Reason,
+			})
+			continue
+		}
+		delete(oldChecks, nc.Name)
+		cd := CheckDiff{
+			Name:      nc.Name,
+			Change:    compareChecks(oc, nc),
+			OldScore:  oc.Score,
+			NewScore:  nc.Score,
+			OldReason: oc.Reason,
+			NewReason: nc.Reason,
+		}
+		if oldHasDetails {
+			cd.NewFindings = newFindings(oc, nc)
+		}
+		ret.Checks = append(ret.Checks, cd)
+	}
+	for _, oc := range oldChecks {
+		ret.Checks = append(ret.Checks, CheckDiff{
+			Name: oc.Name, Change: CheckRemoved,
+			OldScore: oc.Score, NewScore: checker.InconclusiveResultScore, OldReason: oc.Reason,
+		})
+	}
+	sort.Slice(ret.Checks, func(i, j int) bool {
+		return ret.Checks[i].Name < ret.Checks[j].Name
+	})
+	return ret, nil
+}
+
+// newFindings returns the warnings of n whose finding ID is not in o. Findings
+// triaged in o, and so reported as info, are not new.
+func newFindings(o, n *jsonCheckResultV2) []FindingDiff {
+	seen := make(map[string]bool)
+	for i := range o.StructuredDetails {
+		seen[o.StructuredDetails[i].ID] = true
+	}
+	var ret []FindingDiff
+	for i := range n.StructuredDetails {
+		d := &n.StructuredDetails[i]
+		if d.ID == "" || seen[d.ID] || d.Type != typeToString(checker.DetailWarn) {
+			continue
+		}
+		ret = append(ret, FindingDiff{ID: d.ID, Text: d.Text})
+	}
+	return ret
+}
+
+// ApplyAnnotations drops the new findings triaged since the results were written,
+// in the states ScorecardResult.ApplyAnnotations downgrades to info. Only the
+// annotations of single findings apply, since the diff has no paths.
+func (d *ResultDiff) ApplyAnnotations(annotations []Annotation) {
+	states := make(map[string]TriageState)
+	for _, a := range annotations {
+		states[a.Check+"/"+a.FindingID] = a.State
+	}
+	for i := range d.Checks {
+		c := &d.Checks[i]
+		var kept []FindingDiff
+		for _, f := range c.NewFindings {
+			if !suppresses(states[c.Name+"/"+f.ID]) {
+				kept = append(kept, f)
+			}
+		}
+		c.NewFindings = kept
+	}
+}
+
+func compareChecks(o, n *jsonCheckResultV2) CheckChange {
+	inconclusive := o.Score == checker.InconclusiveResultScore || n.Score == checker.InconclusiveResultScore
+	switch {
+	case o.Score == n.Score && o.Reason == n.Reason:
+		return CheckUnchanged
+	case inconclusive || o.Score == n.Score:
+		// A check becoming inconclusive is not a regression of the repo.
+		return CheckReasonChanged
+	case n.Score > o.Score:
+		return CheckImproved
+	default:
+		return CheckRegressed
+	}
+}
+
+// AsString writes the checks which changed in a human-readable form.
+func (d *ResultDiff) AsString(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%s: %s -> %s\naggregate score: %s -> %s\n", d.Repo,
+		shortCommit(d.OldCommit), shortCommit(d.NewCommit),
+		scoreToString(d.OldScore), scoreToString(d.NewScore)); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("fmt.Fprintf: %v", err))
+	}
+	for i := range d.Checks {
+		c := &d.Checks[i]
+		var line string
+		switch c.Change {
+		case CheckUnchanged:
+			if len(c.NewFindings) == 0 {
+				continue
+			}
+			line = fmt.Sprintf("%-9s %s: %s\n", c.Change, c.Name, intScoreToString(c.NewScore))
+		case CheckAdded:
+			line = fmt.Sprintf("%-9s %s: %s (%s)\n", c.Change, c.Name, intScoreToString(c.NewScore), c.NewReason)
+		case CheckRemoved:
+			line = fmt.Sprintf("%-9s %s: %s (%s)\n", c.Change, c.Name, intScoreToString(c.OldScore), c.OldReason)
+		default:
+			line = fmt.Sprintf("%-9s %s: %s -> %s\n", c.Change, c.Name,
+				intScoreToString(c.OldScore), intScoreToString(c.NewScore))
+			if c.OldReason != c.NewReason {
+				line += fmt.Sprintf("          was: %s\n          now: %s\n", c.OldReason, c.NewReason)
+			}
+		}
+		for _, f := range c.NewFindings {
+			line += fmt.Sprintf("          new: %s\n", f.Text)
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("io.WriteString: %v", err))
+		}
+	}
+	return nil
+}
+
+func intScoreToString(s int) string {
+	if s == checker.InconclusiveResultScore {
+		return "?"
+	}
+	return fmt.Sprintf("%d", s)
+}
+
+func shortCommit(sha string) string {
+	const length = 7
+	if len(sha) > length {
+		return sha[:length]
+	}
+	return sha
+}
+
+// AsMarkdown writes the scores of all checks and how they changed as a Markdown table,
+// suitable for PR comments.
+func (d *ResultDiff) AsMarkdown(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Scorecard results for %s\n\n", d.Repo)
+	fmt.Fprintf(&b, "Aggregate score: **%s / %d** (%s versus `%s`)\n\n", scoreToString(d.NewScore),
+		checker.MaxResultScore, scoreDelta(d.OldScore, d.NewScore), shortCommit(d.OldCommit))
+	b.WriteString("| Check | Score | Change | Reason |\n")
+	b.WriteString("| --- | --- | --- | --- |\n")
+	for i := range d.Checks {
+		c := &d.Checks[i]
+		score, reason := c.NewScore, c.NewReason
+		var change string
+		switch c.Change {
+		case CheckUnchanged:
+		case CheckAdded, CheckReasonChanged:
+			change = string(c.Change)
+		case CheckRemoved:
+			change = string(c.Change)
+			score, reason = c.OldScore, c.OldReason
+		case CheckImproved, CheckRegressed:
+			change = fmt.Sprintf("%+d", c.NewScore-c.OldScore)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", c.Name, intScoreToString(score), change,
+			markdownEscapeCell(reason))
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("io.WriteString: %v", err))
+	}
+	return nil
+}
+
+func scoreDelta(o, n float64) string {
+	if o == checker.InconclusiveResultScore || n == checker.InconclusiveResultScore {
+		return "?"
+	}
+	return fmt.Sprintf("%+.1f", n-o)
+}
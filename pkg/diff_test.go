@@ -0,0 +1,213 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const diffOldResult = `{
+  "repo": {"name": "github.com/owner/repo", "commit": "1111111111111111111111111111111111111111"},
+  "score": 6.0,
+  "checks": [
+    {"name": "Binary-Artifacts", "score": 10, "reason": "no binaries found in the repo"},
+    {"name": "Code-Review", "score": 8, "reason": "8 out of 10 commits reviewed"},
+    {"name": "Fuzzing", "score": 0, "reason": "project is not fuzzed"},
+    {"name": "License", "score": 10, "reason": "license file detected"},
+    {"name": "Maintained", "score": 10, "reason": "30 commits in the last 90 days"},
+    {"name": "Packaging", "score": -1, "reason": "no published package detected"}
+  ]
+}`
+
+const diffNewResult = `{
+  "repo": {"name": "github.com/owner/repo", "commit": "2222222222222222222222222222222222222222"},
+  "score": 5.5,
+  "checks": [
+    {"name": "Binary-Artifacts", "score": 10, "reason": "no binaries found in the repo"},
+    {"name": "Code-Review", "score": 5, "reason": "5 out of 10 commits reviewed"},
+    {"name": "Fuzzing", "score": 10, "reason": "project is fuzzed in OSS-Fuzz"},
+    {"name": "Maintained", "score": 10, "reason": "25 commits in the last 90 days"},
+    {"name": "Packaging", "score": 10, "reason": "publishing workflow detected"},
+    {"name": "SAST", "score": 7, "reason": "SAST tool detected"}
+  ]
+}`
+
+func TestDiffJSONResults(t *testing.T) {
+	t.Parallel()
+	got, err := DiffJSONResults(strings.NewReader(diffOldResult), strings.NewReader(diffNewResult))
+	if err != nil {
+		t.Fatalf("DiffJSONResults: %v", err)
+	}
+	want := &ResultDiff{
+		Repo:      "github.com/owner/repo",
+		OldCommit: "1111111111111111111111111111111111111111",
+		NewCommit: "2222222222222222222222222222222222222222",
+		OldScore:  6,
+		NewScore:  5.5,
+		Checks: []CheckDiff{
+			{
+				Name: "Binary-Artifacts", Change: CheckUnchanged, OldScore: 10, NewScore: 10,
+				OldReason: "no binaries found in the repo", NewReason: "no binaries found in the repo",
+			},
+			{
+				Name: "Code-Review", Change: CheckRegressed, OldScore: 8, NewScore: 5,
+				OldReason: "8 out of 10 commits reviewed", NewReason: "5 out of 10 commits reviewed",
+			},
+			{
+				Name: "Fuzzing", Change: CheckImproved, OldScore: 0, NewScore: 10,
+				OldReason: "project is not fuzzed", NewReason: "project is fuzzed in OSS-Fuzz",
+			},
+			{
+				Name: "License", Change: CheckRemoved, OldScore: 10, NewScore: -1,
+				OldReason: "license file detected",
+			},
+			{
+				Name: "Maintained", Change: CheckReasonChanged, OldScore: 10, NewScore: 10,
+				OldReason: "30 commits in the last 90 days", NewReason: "25 commits in the last 90 days",
+			},
+			{
+				Name: "Packaging", Change: CheckReasonChanged, OldScore: -1, NewScore: 10,
+				OldReason: "no published package detected", NewReason: "publishing workflow detected",
+			},
+			{
+				Name: "SAST", Change: CheckAdded, OldScore: -1, NewScore: 7,
+				NewReason: "SAST tool detected",
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("DiffJSONResults() mismatch (-want +got):\n%s", diff)
+	}
+	if !got.HasRegressions() {
+		t.Error("HasRegressions() = false, want true")
+	}
+
+	var out bytes.Buffer
+	if err := got.AsString(&out); err != nil {
+		t.Fatalf("AsString: %v", err)
+	}
+	wantOut := `github.com/owner/repo: 1111111 -> 2222222
+aggregate score: 6.0 -> 5.5
+regressed Code-Review: 8 -> 5
+          was: 8 out of 10 commits reviewed
+          now: 5 out of 10 commits reviewed
+improved  Fuzzing: 0 -> 10
+          was: project is not fuzzed
+          now: project is fuzzed in OSS-Fuzz
+removed   License: 10 (license file detected)
+changed   Maintained: 10 -> 10
+          was: 30 commits in the last 90 days
+          now: 25 commits in the last 90 days
+changed   Packaging: ? -> 10
+          was: no published package detected
+          now: publishing workflow detected
+added     SAST: 7 (SAST tool detected)
+`
+	if diff := cmp.Diff(wantOut, out.String()); diff != "" {
+		t.Errorf("AsString() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiffJSONResultsFindings(t *testing.T) {
+	t.Parallel()
+	const oldResult = `{
+  "repo": {"name": "github.com/owner/repo"},
+  "checks": [
+    {"name": "Binary-Artifacts", "score": 8, "reason": "binaries present in source code", "structuredDetails": [
+      {"id": "old", "type": "Warn", "text": "binary detected: bin/old"},
+      {"id": "triaged", "type": "Info", "text": "binary detected: bin/triaged"}
+    ]}
+  ]
+}`
+	const newResult = `{
+  "repo": {"name": "github.com/owner/repo"},
+  "checks": [
+    {"name": "Binary-Artifacts", "score": 8, "reason": "binaries present in source code", "structuredDetails": [
+      {"id": "old", "type": "Warn", "text": "binary detected: bin/old"},
+      {"id": "triaged", "type": "Warn", "text": "binary detected: bin/triaged"},
+      {"id": "accepted", "type": "Warn", "text": "binary detected: bin/accepted"},
+      {"id": "new", "type": "Warn", "text": "binary detected: bin/new"}
+    ]}
+  ]
+}`
+	d, err := DiffJSONResults(strings.NewReader(oldResult), strings.NewReader(newResult))
+	if err != nil {
+		t.Fatalf("DiffJSONResults: %v", err)
+	}
+	// Triaged after the new result was written.
+	d.ApplyAnnotations([]Annotation{
+		{FindingID: "accepted", Check: "Binary-Artifacts", State: TriageAcceptedRisk},
+		{FindingID: "new", Check: "Binary-Artifacts", State: TriageFixInProgress},
+	})
+	want := []FindingDiff{{ID: "new", Text: "binary detected: bin/new"}}
+	if diff := cmp.Diff(want, d.Checks[0].NewFindings); diff != "" {
+		t.Errorf("NewFindings mismatch (-want +got):\n%s", diff)
+	}
+
+	var out bytes.Buffer
+	if err := d.AsString(&out); err != nil {
+		t.Fatalf("AsString: %v", err)
+	}
+	wantOut := `github.com/owner/repo:  -> 
+aggregate score: 0.0 -> 0.0
+unchanged Binary-Artifacts: 8
+          new: binary detected: bin/new
+`
+	if diff := cmp.Diff(wantOut, out.String()); diff != "" {
+		t.Errorf("AsString() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiffJSONResultsErrors(t *testing.T) {
+	t.Parallel()
+	other := strings.Replace(diffNewResult, "github.com/owner/repo", "github.com/owner/other", 1)
+	if _, err := DiffJSONResults(strings.NewReader(diffOldResult), strings.NewReader(other)); err == nil {
+		t.Error("expected error for results of different repos")
+	}
+	if _, err := DiffJSONResults(strings.NewReader(diffOldResult), strings.NewReader("{")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+}
+
+func TestResultDiffAsMarkdown(t *testing.T) {
+	t.Parallel()
+	d, err := DiffJSONResults(strings.NewReader(diffOldResult), strings.NewReader(diffNewResult))
+	if err != nil {
+		t.Fatalf("DiffJSONResults: %v", err)
+	}
+	var out bytes.Buffer
+	if err := d.AsMarkdown(&out); err != nil {
+		t.Fatalf("AsMarkdown: %v", err)
+	}
+	wantOut := "## Scorecard results for github.com/owner/repo\n\n" +
+		"Aggregate score: **5.5 / 10** (-0.5 versus `1111111`)\n\n" +
+		`| Check | Score | Change | Reason |
+| --- | --- | --- | --- |
+| Binary-Artifacts | 10 |  | no binaries found in the repo |
+| Code-Review | 5 | -3 | 5 out of 10 commits reviewed |
+| Fuzzing | 10 | +10 | project is fuzzed in OSS-Fuzz |
+| License | 10 | removed | license file detected |
+| Maintained | 10 | changed | 25 commits in the last 90 days |
+| Packaging | 10 | changed | publishing workflow detected |
+| SAST | 7 | added | SAST tool detected |
+`
+	if diff := cmp.Diff(wantOut, out.String()); diff != "" {
+		t.Errorf("AsMarkdown() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/checks"
+	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/githubrepo"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+// This example scores a GitHub repo with all checks. It needs a token in
+// GITHUB_AUTH_TOKEN, see https://github.com/ossf/scorecard#authentication.
+func ExampleRunScorecards() {
+	ctx := context.Background()
+	logger, err := githubrepo.NewLogger(zap.InfoLevel)
+	if err != nil {
+		log.Fatal(err)
+	}
+	repo, err := githubrepo.MakeGithubRepo("github.com/ossf/scorecard")
+	if err != nil {
+		log.Fatal(err)
+	}
+	repoClient := githubrepo.CreateGithubRepoClient(ctx, logger)
+	ossFuzzRepoClient, err := githubrepo.CreateOssFuzzRepoClient(ctx, logger)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer ossFuzzRepoClient.Close()
+
+	result, err := pkg.RunScorecards(ctx, repo, clients.HeadSHA, false /*raw*/, checks.AllChecks,
+		repoClient, ossFuzzRepoClient, clients.DefaultCIIBestPracticesClient(),
+		clients.DefaultVulnerabilitiesClient(), clients.DefaultPackagesClient())
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, check := range result.Checks {
+		fmt.Printf("%s: %d\n", check.Name, check.Score)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+
+	"go.uber.org/zap/zapcore"
+
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// FormatPluginPrefix is the prefix of executables implementing external output formats.
+// `--format=x` resolves to an executable named `scorecard-format-x` on the PATH.
+const FormatPluginPrefix = "scorecard-format-"
+
+// formatPluginName restricts format names to characters which cannot
+// escape the executable name, e.g. into a path.
+var formatPluginName = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// LookupFormatPlugin returns the path of the executable implementing format.
+func LookupFormatPlugin(format string) (string, error) {
+	if !formatPluginName.MatchString(format) {
+		return "", sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("invalid format %q: only a-z, 0-9 and - are allowed", format))
+	}
+	path, err := exec.LookPath(FormatPluginPrefix + format)
+	if err != nil {
+		return "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("exec.LookPath: %v", err))
+	}
+	return path, nil
+}
+
+// AsPlugin runs the format plugin at path with the JSON results on its stdin
+// and copies its stdout to writer.
+func (r *ScorecardResult) AsPlugin(path string, showDetails bool,
+	logLevel zapcore.Level, checkDocs docs.Doc, writer io.Writer) error {
+	var input bytes.Buffer
+	if err := r.AsJSON2(showDetails, logLevel, checkDocs, &input); err != nil {
+		return err
+	}
+
+	// nolint: gosec // path is resolved from the PATH by LookupFormatPlugin.
+	cmd := exec.Command(path)
+	cmd.Stdin = &input
+	cmd.Stdout = writer
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%s: %v", path, err))
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFormatPlugin(t *testing.T) {
+	dir := t.TempDir()
+	script := "#!/bin/sh\ncat\n"
+	// nolint: gosec // the plugin must be executable.
+	if err := os.WriteFile(filepath.Join(dir, FormatPluginPrefix+"echo"), []byte(script), 0o755); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	// Keep the rest of PATH so the plugin script can run cat.
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	if _, err := LookupFormatPlugin("missing"); err == nil {
+		t.Errorf("expected error for missing plugin")
+	}
+	for _, format := range []string{"", "../echo", "/bin/sh", "Echo", "echo.sh"} {
+		if _, err := LookupFormatPlugin(format); err == nil {
+			t.Errorf("expected error for format %q", format)
+		}
+	}
+	plugin, err := LookupFormatPlugin("echo")
+	if err != nil {
+		t.Fatalf("LookupFormatPlugin: %v", err)
+	}
+
+	result := ScorecardResult{
+		Repo: RepoInfo{Name: "org/name"},
+	}
+	var out bytes.Buffer
+	if err := result.AsPlugin(plugin, false, zapcore.InfoLevel, jsonMockDocRead(), &out); err != nil {
+		t.Fatalf("AsPlugin: %v", err)
+	}
+	var got jsonScorecardResultV2
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if got.Repo.Name != "org/name" {
+		t.Errorf("expected repo org/name, got %s", got.Repo.Name)
+	}
+}
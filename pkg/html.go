@@ -0,0 +1,228 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ossf/scorecard/v3/checker"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+type htmlReport struct {
+	Repo     string
+	Commit   string
+	Date     string
+	Version  string
+	Score    string
+	MaxScore int
+	Percent  int
+	Color    string
+	Checks   []htmlCheck
+}
+
+type htmlCheck struct {
+	Name    string
+	Score   string
+	Percent int
+	Color   string
+	Reason  string
+	Risk    string
+	URL     string
+	Lines   []string
+	Groups  []htmlDetailGroup
+	// DocRemediation is the remediation of the check, for checks which did not get
+	// the maximum score. It is shown when the details have no remediation.
+	DocRemediation []string
+	Remediations   []htmlRemediation
+}
+
+// htmlDetailGroup is a large group of similar details, collapsed under its summary.
+type htmlDetailGroup struct {
+	Summary string
+	Lines   []string
+}
+
+type htmlRemediation struct {
+	Detail string
+	Text   string
+	Steps  []string
+	URL    string
+}
+
+// htmlScore returns the score, its percentage of checker.MaxResultScore
+// and its badge color.
+func htmlScore(score float64) (string, int, string) {
+	color := badgeHex[badgeColor(score)]
+	if score == checker.InconclusiveResultScore {
+		return "?", 0, color
+	}
+	//nolint:gomnd
+	return scoreToString(score), int(score * 100 / checker.MaxResultScore), color
+}
+
+// AsHTML exports results as a standalone HTML report, with a gauge per score,
+// a collapsible section per check with its details, if shown, and links to
+// the remediation of the checks. The report has no external dependencies, so
+// it can be attached to tickets or sent by email.
+func (r *ScorecardResult) AsHTML(showDetails bool, logLevel zapcore.Level,
+	checkDocs docs.Doc, writer io.Writer) error {
+	score, err := r.GetAggregateScore(checkDocs)
+	if err != nil {
+		return err
+	}
+
+	report := htmlReport{
+		Repo:     r.Repo.Name,
+		Commit:   r.Repo.CommitSHA,
+		Date:     r.Date.Format("2006-01-02"),
+		Version:  r.Scorecard.Version,
+		MaxScore: checker.MaxResultScore,
+	}
+	report.Score, report.Percent, report.Color = htmlScore(score)
+	for i := range r.Checks {
+		check := &r.Checks[i]
+		cdoc, e := checkDocs.GetCheck(check.Name)
+		if e != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetCheck: %s: %v", check.Name, e))
+		}
+		c := htmlCheck{
+			Name:   check.Name,
+			Reason: check.Reason,
+			Risk:   cdoc.GetRisk(),
+			URL:    cdoc.GetDocumentationURL(r.Scorecard.CommitSHA),
+		}
+		c.Score, c.Percent, c.Color = htmlScore(float64(check.Score))
+		if check.Score != checker.InconclusiveResultScore {
+			c.Score = strconv.Itoa(check.Score)
+		}
+		if check.State() == checker.ResultScored && check.Score < checker.MaxResultScore {
+			c.DocRemediation = cdoc.GetRemediation()
+		}
+		if showDetails {
+			var aggregated []aggregatedDetails
+			c.Lines, aggregated = detailsToLines(check.Name, check.Details2, logLevel)
+			for _, a := range aggregated {
+				c.Groups = append(c.Groups, htmlDetailGroup{Summary: a.summary, Lines: a.lines})
+			}
+			for j := range check.Details2 {
+				d := &check.Details2[j]
+				if d.Msg.Remediation == nil || !ShowDetail(d, logLevel) {
+					continue
+				}
+				c.Remediations = append(c.Remediations, htmlRemediation{
+					Detail: d.Msg.Text,
+					Text:   d.Msg.Remediation.Text,
+					Steps:  d.Msg.Remediation.Steps,
+					URL:    d.Msg.Remediation.URL,
+				})
+			}
+		}
+		report.Checks = append(report.Checks, c)
+	}
+
+	if err := htmlTemplate.Execute(writer, report); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("template.Execute: %v", err))
+	}
+	return nil
+}
+
+// htmlTemplate is the HTML report. Gauges are inline SVGs and styles are
+// inline, so the report renders without network access.
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<title>Scorecard results for {{.Repo}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; }
+body { margin: 2em auto; max-width: 60em; color: #24292f; }
+header { display: flex; align-items: center; gap: 2em; }
+.gauge text { font-size: 24px; font-weight: bold; }
+.meta { color: #57606a; }
+details.check { border: 1px solid #d0d7de; border-radius: 6px; margin: .5em 0; padding: .5em 1em; }
+details.check > summary { cursor: pointer; display: flex; align-items: center; gap: 1em; }
+.name { font-weight: bold; min-width: 14em; }
+.score { min-width: 3em; text-align: right; }
+.risk { color: #57606a; font-size: smaller; }
+pre { background: #f6f8fa; padding: .5em; overflow-x: auto; }
+</style>
+</head>
+<body>
+<header>
+<svg class="gauge" width="120" height="120" viewBox="0 0 120 120" role="img" aria-label="Aggregate score: {{.Score}}">
+<circle cx="60" cy="60" r="50" fill="none" stroke="#eaeef2" stroke-width="12"/>
+<circle cx="60" cy="60" r="50" fill="none" stroke="{{.Color}}" stroke-width="12" pathLength="100"
+ stroke-dasharray="{{.Percent}} 100" transform="rotate(-90 60 60)"/>
+<text x="60" y="68" text-anchor="middle">{{.Score}}</text>
+</svg>
+<div>
+<h1>Scorecard results for {{.Repo}}</h1>
+<p class="meta">Aggregate score: {{.Score}} / {{.MaxScore}}{{if .Commit}}<br>Commit: <code>{{.Commit}}</code>{{end}}<br>
+Date: {{.Date}}{{if .Version}}<br>Scorecard version: {{.Version}}{{end}}</p>
+</div>
+</header>
+<main>
+{{- range .Checks}}
+<details class="check">
+<summary>
+<svg width="100" height="10" viewBox="0 0 100 10" role="img" aria-label="Score: {{.Score}}">
+<rect width="100" height="10" rx="3" fill="#eaeef2"/>
+<rect width="{{.Percent}}" height="10" rx="3" fill="{{.Color}}"/>
+</svg>
+<span class="score">{{.Score}}</span>
+<span class="name">{{.Name}}</span>
+<span>{{.Reason}}</span>
+</summary>
+<p class="risk">Risk: {{.Risk}} &middot; <a href="{{.URL}}">Documentation</a></p>
+{{- if .Lines}}
+<pre>{{range .Lines}}{{.}}
+{{end}}</pre>
+{{- end}}
+{{- range .Groups}}
+<details>
+<summary>{{.Summary}}</summary>
+<pre>{{range .Lines}}{{.}}
+{{end}}</pre>
+</details>
+{{- end}}
+{{- if .Remediations}}
+<h3>Remediation</h3>
+<ul>
+{{- range .Remediations}}
+<li>{{.Detail}}: {{.Text}}{{if .URL}} (<a href="{{.URL}}">link</a>){{end}}
+{{- if .Steps}}<ol>{{range .Steps}}<li>{{.}}</li>{{end}}</ol>{{end}}</li>
+{{- end}}
+</ul>
+{{- else if .DocRemediation}}
+<h3>Remediation</h3>
+<ul>
+{{- range .DocRemediation}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</details>
+{{- end}}
+</main>
+</body>
+</html>
+`))
@@ -0,0 +1,109 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ossf/scorecard/v3/checker"
+)
+
+func TestHTMLOutput(t *testing.T) {
+	t.Parallel()
+	result := ScorecardResult{
+		Repo: RepoInfo{
+			Name:      "github.com/org/name",
+			CommitSHA: "68bc59901773ab4c051dfcea0cc4201a1567ab32",
+		},
+		Checks: []checker.CheckResult{
+			{
+				Name:   "Check-Name",
+				Score:  5,
+				Reason: "half <b>of</b> it",
+				Details2: []checker.CheckDetail{
+					{Type: checker.DetailInfo, Msg: checker.LogMessage{Text: "info message"}},
+					{
+						Type: checker.DetailWarn,
+						Msg: checker.LogMessage{
+							Text: "token permissions too broad",
+							Remediation: &checker.Remediation{
+								Text: "Restrict the permissions",
+								URL:  "https://example.com/settings",
+							},
+						},
+					},
+				},
+			},
+			{Name: "Check-Name2", Score: 3, Reason: "low score"},
+			{Name: "Check-Name3", Score: checker.InconclusiveResultScore, Reason: "inconclusive"},
+		},
+	}
+	tests := []struct {
+		name        string
+		showDetails bool
+		contains    []string
+		excludes    []string
+	}{
+		{
+			name: "without details",
+			contains: []string{
+				"<title>Scorecard results for github.com/org/name</title>",
+				`aria-label="Aggregate score: 4.2"`,
+				`stroke-dasharray="42 100"`,
+				"<code>68bc59901773ab4c051dfcea0cc4201a1567ab32</code>",
+				// Reasons are escaped.
+				"half &lt;b&gt;of&lt;/b&gt; it",
+				`<rect width="50" height="10" rx="3" fill="#fe7d37"/>`,
+				`<span class="score">?</span>`,
+				`<a href="https://github.com/ossf/scorecard/blob/main/docs/checks.md#check-name2">Documentation</a>`,
+				// The remediation of the check is shown for checks below the maximum score.
+				"<li>not-used1</li>",
+			},
+			excludes: []string{"info message", "Restrict the permissions"},
+		},
+		{
+			name:        "with details",
+			showDetails: true,
+			contains: []string{
+				"<pre>Info: info message\nWarn: token permissions too broad",
+				`<li>token permissions too broad: Restrict the permissions (<a href="https://example.com/settings">link</a>)`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			if err := result.AsHTML(tt.showDetails, zapcore.InfoLevel, jsonMockDocRead(), &out); err != nil {
+				t.Fatalf("AsHTML: %v", err)
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(out.String(), s) {
+					t.Errorf("AsHTML() does not contain %q:\n%s", s, out.String())
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(out.String(), s) {
+					t.Errorf("AsHTML() contains %q:\n%s", s, out.String())
+				}
+			}
+		})
+	}
+}
@@ -18,9 +18,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 
 	"go.uber.org/zap/zapcore"
 
+	"github.com/ossf/scorecard/v3/checker"
 	docs "github.com/ossf/scorecard/v3/docs/checks"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
@@ -53,11 +55,118 @@ type jsonCheckResultV2 struct {
 	Reason  string                   `json:"reason"`
 	Name    string                   `json:"name"`
 	Doc     jsonCheckDocumentationV2 `json:"documentation"`
+	// ReasonID and ReasonParams identify the reason in checker.MessageCatalog(),
+	// so that UIs can localize or re-render it.
+	ReasonID     string            `json:"reasonId,omitempty"`
+	ReasonParams map[string]string `json:"reasonParams,omitempty"`
+	// State tells a score of -1 apart: inconclusive, not-applicable or runtime-error.
+	State string `json:"state"`
+	// Version of the check's scoring logic.
+	Version      int               `json:"version,omitempty"`
+	Remediations []jsonRemediation `json:"remediations,omitempty"`
+	// StructuredDetails holds the same details as Details, with their fields
+	// kept separate so consumers can render them.
+	StructuredDetails []jsonDetail `json:"structuredDetails,omitempty"`
+	// DetailGroups counts the details reporting the same kind of finding.
+	DetailGroups []jsonDetailGroup `json:"detailGroups,omitempty"`
+	// Stats is only reported at the debug log level, since it varies between runs.
+	Stats *jsonStatsV2 `json:"stats,omitempty"`
+}
+
+type jsonStatsV2 struct {
+	APICalls              int   `json:"apiCalls"`
+	DurationMs            int64 `json:"durationMs"`
+	APICallBudgetExceeded bool  `json:"apiCallBudgetExceeded,omitempty"`
+}
+
+// statsToJSON returns nil for results that were never measured, e.g. ones
+// built in tests or loaded from a previous run.
+func statsToJSON(s ScanStats) *jsonStatsV2 {
+	if s == (ScanStats{}) {
+		return nil
+	}
+	return &jsonStatsV2{
+		APICalls:              s.APICalls,
+		DurationMs:            s.Duration.Milliseconds(),
+		APICallBudgetExceeded: s.APICallBudgetExceeded,
+	}
+}
+
+// jsonDetailGroup summarizes repeated details, see checker.GroupDetails.
+type jsonDetailGroup struct {
+	Type    string `json:"type"`
+	Text    string `json:"text"`
+	Finding string `json:"finding,omitempty"`
+	Count   int    `json:"count"`
+	Files   int    `json:"files,omitempty"`
+}
+
+// detailGroupsToJSON returns the groups of more than one detail.
+func detailGroupsToJSON(details []checker.CheckDetail) []jsonDetailGroup {
+	var ret []jsonDetailGroup
+	for _, g := range checker.GroupDetails(details) {
+		g := g
+		if g.Count() < 2 {
+			continue
+		}
+		ret = append(ret, jsonDetailGroup{
+			Type:    typeToString(g.Type),
+			Text:    g.Text,
+			Finding: string(g.Finding),
+			Count:   g.Count(),
+			Files:   len(g.Paths),
+		})
+	}
+	return ret
+}
+
+type jsonDetail struct {
+	// ID is the FindingID of warnings.
+	ID         string          `json:"id,omitempty"`
+	Type       string          `json:"type"`
+	Text       string          `json:"text"`
+	Path       string          `json:"path,omitempty"`
+	Offset     int             `json:"offset,omitempty"`
+	EndOffset  int             `json:"endOffset,omitempty"`
+	Snippet    string          `json:"snippet,omitempty"`
+	Finding    string          `json:"finding,omitempty"`
+	Annotation *jsonAnnotation `json:"annotation,omitempty"`
+	// MessageID and Params identify the text in checker.MessageCatalog().
+	MessageID string            `json:"messageId,omitempty"`
+	Params    map[string]string `json:"params,omitempty"`
+}
+
+// jsonAnnotation is the triage annotation of a detail, see ScorecardResult.ApplyAnnotations.
+type jsonAnnotation struct {
+	State  string `json:"state"`
+	Reason string `json:"reason"`
+}
+
+type jsonRemediation struct {
+	// Detail is the detail the remediation applies to.
+	Detail string   `json:"detail"`
+	Text   string   `json:"text"`
+	Steps  []string `json:"steps,omitempty"`
+	Effort string   `json:"effort,omitempty"`
+	URL    string   `json:"url,omitempty"`
+	Patch  string   `json:"patch,omitempty"`
+}
+
+func remediationToJSON(detail string, rem *checker.Remediation) jsonRemediation {
+	return jsonRemediation{
+		Detail: detail,
+		Text:   rem.Text,
+		Steps:  rem.Steps,
+		Effort: string(rem.Effort),
+		URL:    rem.URL,
+		Patch:  rem.Patch,
+	}
 }
 
 type jsonRepoV2 struct {
 	Name   string `json:"name"`
 	Commit string `json:"commit"`
+	Path   string `json:"path,omitempty"`
 }
 
 type jsonScorecardV2 struct {
@@ -65,6 +174,18 @@ type jsonScorecardV2 struct {
 	Commit  string `json:"commit"`
 }
 
+type jsonCheckCapabilityV2 struct {
+	Name   string `json:"name"`
+	Mode   string `json:"mode"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type jsonCapabilitiesV2 struct {
+	Forge  string                  `json:"forge"`
+	Checks []jsonCheckCapabilityV2 `json:"checks"`
+	Fast   bool                    `json:"fast,omitempty"`
+}
+
 type jsonFloatScore float64
 
 func (s jsonFloatScore) MarshalJSON() ([]byte, error) {
@@ -80,6 +201,60 @@ type jsonScorecardResultV2 struct {
 	AggregateScore jsonFloatScore      `json:"score"`
 	Checks         []jsonCheckResultV2 `json:"checks"`
 	Metadata       []string            `json:"metadata"`
+	Capabilities   *jsonCapabilitiesV2 `json:"capabilities,omitempty"`
+	Dependents     *jsonDependentsV2   `json:"dependents,omitempty"`
+	Stats          *jsonStatsV2        `json:"stats,omitempty"`
+}
+
+type jsonPackageDependentsV2 struct {
+	System     string `json:"system"`
+	Name       string `json:"name"`
+	Dependents int    `json:"dependents"`
+}
+
+type jsonDependentsV2 struct {
+	Count      int                       `json:"count"`
+	Ecosystems []string                  `json:"ecosystems"`
+	Packages   []jsonPackageDependentsV2 `json:"packages"`
+}
+
+func dependentsToJSON(d *Dependents) *jsonDependentsV2 {
+	if d == nil {
+		return nil
+	}
+	ret := jsonDependentsV2{
+		Count:      d.Count,
+		Ecosystems: d.Ecosystems,
+	}
+	for _, p := range d.Packages {
+		ret.Packages = append(ret.Packages, jsonPackageDependentsV2{
+			System:     p.System,
+			Name:       p.Name,
+			Dependents: p.Dependents,
+		})
+	}
+	return &ret
+}
+
+func capabilitiesToJSON(m *CapabilityMatrix) *jsonCapabilitiesV2 {
+	if len(m.Checks) == 0 {
+		return nil
+	}
+	ret := jsonCapabilitiesV2{
+		Forge: m.Forge,
+		Fast:  m.Fast,
+	}
+	for name, mode := range m.Checks {
+		ret.Checks = append(ret.Checks, jsonCheckCapabilityV2{
+			Name:   name,
+			Mode:   string(mode),
+			Reason: m.Reasons[name],
+		})
+	}
+	sort.Slice(ret.Checks, func(i, j int) bool {
+		return ret.Checks[i].Name < ret.Checks[j].Name
+	})
+	return &ret
 }
 
 // AsJSON exports results as JSON for new detail format.
@@ -100,12 +275,14 @@ func (r *ScorecardResult) AsJSON(showDetails bool, logLevel zapcore.Level, write
 			Confidence: checkResult.Confidence,
 		}
 		if showDetails {
+			var shown []checker.CheckDetail
 			for i := range checkResult.Details2 {
 				d := checkResult.Details2[i]
 				m := DetailToString(&d, logLevel)
 				if m == "" {
 					continue
 				}
+				shown = append(shown, d)
 				tmpResult.Details = append(tmpResult.Details, m)
 			}
 		}
@@ -130,6 +307,7 @@ func (r *ScorecardResult) AsJSON2(showDetails bool,
 		Repo: jsonRepoV2{
 			Name:   r.Repo.Name,
 			Commit: r.Repo.CommitSHA,
+			Path:   r.Repo.Path,
 		},
 		Scorecard: jsonScorecardV2{
 			Version: r.Scorecard.Version,
@@ -138,6 +316,12 @@ func (r *ScorecardResult) AsJSON2(showDetails bool,
 		Date:           r.Date.Format("2006-01-02"),
 		Metadata:       r.Metadata,
 		AggregateScore: jsonFloatScore(score),
+		Capabilities:   capabilitiesToJSON(&r.Capabilities),
+		Dependents:     dependentsToJSON(r.Dependents),
+	}
+	showStats := logLevel.Enabled(zapcore.DebugLevel)
+	if showStats {
+		out.Stats = statsToJSON(r.Stats)
 	}
 
 	//nolint
@@ -153,18 +337,56 @@ func (r *ScorecardResult) AsJSON2(showDetails bool,
 				URL:   doc.GetDocumentationURL(r.Scorecard.CommitSHA),
 				Short: doc.GetShort(),
 			},
-			Reason: checkResult.Reason,
-			Score:  checkResult.Score,
+			Reason:  checkResult.Reason,
+			Score:   checkResult.Score,
+			State:   string(checkResult.State()),
+			Version: doc.GetVersion(),
+		}
+		if m := checkResult.ReasonMessage; m != nil {
+			tmpResult.ReasonID = string(m.ID)
+			tmpResult.ReasonParams = m.Params
 		}
 		if showDetails {
+			var shown []checker.CheckDetail
 			for i := range checkResult.Details2 {
 				d := checkResult.Details2[i]
 				m := DetailToString(&d, logLevel)
 				if m == "" {
 					continue
 				}
+				shown = append(shown, d)
 				tmpResult.Details = append(tmpResult.Details, m)
+				detail := jsonDetail{
+					Type:      typeToString(d.Type),
+					Text:      d.Msg.Text,
+					Path:      d.Msg.Path,
+					Offset:    d.Msg.Offset,
+					EndOffset: d.Msg.EndOffset,
+					Snippet:   d.Msg.Snippet,
+					Finding:   string(d.Msg.Finding),
+				}
+				if d.Type == checker.DetailWarn {
+					detail.ID = FindingID(checkResult.Name, &d)
+				}
+				if d.Annotation != nil {
+					detail.Annotation = &jsonAnnotation{State: string(d.Annotation.State), Reason: d.Annotation.Reason}
+				}
+				if d.Msg.Message != nil {
+					detail.MessageID = string(d.Msg.Message.ID)
+					detail.Params = d.Msg.Message.Params
+				}
+				tmpResult.StructuredDetails = append(tmpResult.StructuredDetails, detail)
+				if d.Msg.Remediation != nil {
+					tmpResult.Remediations = append(tmpResult.Remediations, remediationToJSON(m, d.Msg.Remediation))
+				}
 			}
+			tmpResult.DetailGroups = detailGroupsToJSON(shown)
+		}
+		if showStats {
+			tmpResult.Stats = statsToJSON(ScanStats{
+				APICalls: checkResult.APICalls,
+				Duration: checkResult.Duration,
+			})
 		}
 		out.Checks = append(out.Checks, tmpResult)
 	}
@@ -43,7 +43,103 @@ type jsonRawResults struct {
 	Binaries []jsonFiles `json:"binaries"`
 	// List of security policy files found in the repo.
 	// Note: we return one at most.
-	SecurityPolicies []jsonFiles `json:"security-policies"`
+	SecurityPolicies []jsonSecurityPolicy `json:"security-policies"`
+	// Declarations of the repo's SECURITY-INSIGHTS.yml, if any.
+	SecurityInsights *jsonSecurityInsights `json:"security-insights,omitempty"`
+	// Protection settings of the default and release branches.
+	BranchProtections jsonBranchProtections `json:"branch-protections"`
+	// List of dangerous patterns found in GitHub workflows.
+	DangerousWorkflows []jsonDangerousWorkflow `json:"dangerous-workflows"`
+	// List of recent releases with assets.
+	Releases []jsonRelease `json:"releases"`
+}
+
+// Settings which could not be read, e.g., without an admin token, are omitted.
+type jsonBranchProtection struct {
+	Name                         string   `json:"name"`
+	Protected                    *bool    `json:"protected,omitempty"`
+	AllowDeletions               *bool    `json:"allow-deletions,omitempty"`
+	AllowForcePushes             *bool    `json:"allow-force-pushes,omitempty"`
+	RequireLinearHistory         *bool    `json:"require-linear-history,omitempty"`
+	RequiresSignatures           *bool    `json:"requires-signatures,omitempty"`
+	EnforceAdmins                *bool    `json:"enforce-admins,omitempty"`
+	RequiredApprovingReviewCount *int32   `json:"required-approving-review-count,omitempty"`
+	DismissStaleReviews          *bool    `json:"dismiss-stale-reviews,omitempty"`
+	RequireCodeOwnerReviews      *bool    `json:"require-code-owner-reviews,omitempty"`
+	UpToDateBeforeMerge          *bool    `json:"up-to-date-before-merge,omitempty"`
+	RequiresStatusChecks         *bool    `json:"requires-status-checks,omitempty"`
+	StatusCheckContexts          []string `json:"status-check-contexts,omitempty"`
+	RequiredWorkflows            []string `json:"required-workflows,omitempty"`
+	RequiresMergeQueue           *bool    `json:"requires-merge-queue,omitempty"`
+}
+
+type jsonBranchProtections struct {
+	Branches []jsonBranchProtection `json:"branches"`
+	// Settings of the default branch inferred from its history,
+	// when they could not be read.
+	Inferred *jsonBranchInference `json:"inferred,omitempty"`
+	// Evidence found in the git history alone, for repos without
+	// a branch protection API.
+	History *jsonBranchHistory `json:"history,omitempty"`
+	// Bot merging PRs once reviewed, instead of requiring approving reviews.
+	MergeBot *jsonMergeBot `json:"merge-bot,omitempty"`
+}
+
+type jsonMergeBot struct {
+	Name     string   `json:"name"`
+	Evidence []string `json:"evidence"`
+}
+
+type jsonBranchInference struct {
+	Branch             string `json:"branch"`
+	ForcePushesBlocked bool   `json:"force-pushes-blocked"`
+	ReviewsRequired    bool   `json:"reviews-required"`
+	PullRequests       int    `json:"pull-requests"`
+}
+
+type jsonBranchHistory struct {
+	Branch        string `json:"branch"`
+	Commits       int    `json:"commits"`
+	DirectPushes  int    `json:"direct-pushes"`
+	BranchUpdates int    `json:"branch-updates"`
+	ForcePushes   int    `json:"force-pushes"`
+}
+
+type jsonDangerousWorkflow struct {
+	Type    string `json:"type"`
+	Path    string `json:"path"`
+	Line    int    `json:"line,omitempty"`
+	Snippet string `json:"snippet,omitempty"`
+}
+
+type jsonReleaseAsset struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+type jsonRelease struct {
+	Tag    string             `json:"tag"`
+	URL    string             `json:"url,omitempty"`
+	Assets []jsonReleaseAsset `json:"assets"`
+}
+
+type jsonSecurityPolicy struct {
+	Path        string `json:"path"`
+	HasContact  bool   `json:"has-contact"`
+	HasTimeline bool   `json:"has-timeline"`
+}
+
+type jsonSecurityInsights struct {
+	Path               string                     `json:"path"`
+	SecurityContacts   []string                   `json:"security-contacts,omitempty"`
+	SecurityPolicy     string                     `json:"security-policy,omitempty"`
+	DistributionPoints []string                   `json:"distribution-points,omitempty"`
+	SecurityTools      []jsonSecurityInsightsTool `json:"security-tools,omitempty"`
+}
+
+type jsonSecurityInsightsTool struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
 }
 
 //nolint:unparam
@@ -59,12 +155,112 @@ func (r *jsonScorecardRawResult) addBinaryArtifactRawResults(ba *checker.BinaryA
 
 //nolint:unparam
 func (r *jsonScorecardRawResult) addSecurityPolicyRawResults(ba *checker.SecurityPolicyData) error {
-	r.Results.SecurityPolicies = []jsonFiles{}
+	r.Results.SecurityPolicies = []jsonSecurityPolicy{}
 	for _, v := range ba.Files {
-		r.Results.SecurityPolicies = append(r.Results.SecurityPolicies, jsonFiles{
-			Path: v.Path,
+		r.Results.SecurityPolicies = append(r.Results.SecurityPolicies, jsonSecurityPolicy{
+			Path:        v.File.Path,
+			HasContact:  v.HasContact,
+			HasTimeline: v.HasTimeline,
 		})
 	}
+	if si := ba.Insights; si != nil {
+		r.Results.SecurityInsights = &jsonSecurityInsights{
+			Path:               si.File.Path,
+			SecurityContacts:   si.SecurityContacts,
+			SecurityPolicy:     si.SecurityPolicy,
+			DistributionPoints: si.DistributionPoints,
+		}
+		for _, t := range si.SecurityTools {
+			r.Results.SecurityInsights.SecurityTools = append(r.Results.SecurityInsights.SecurityTools,
+				jsonSecurityInsightsTool{Type: t.Type, Name: t.Name})
+		}
+	}
+	return nil
+}
+
+//nolint:unparam
+func (r *jsonScorecardRawResult) addBranchProtectionRawResults(bp *checker.BranchProtectionsData) error {
+	r.Results.BranchProtections = jsonBranchProtections{
+		Branches: []jsonBranchProtection{},
+	}
+	if bp.Inferred != nil {
+		r.Results.BranchProtections.Inferred = &jsonBranchInference{
+			Branch:             bp.Inferred.Branch,
+			ForcePushesBlocked: bp.Inferred.ForcePushesBlocked,
+			ReviewsRequired:    bp.Inferred.ReviewsRequired,
+			PullRequests:       bp.Inferred.PullRequests,
+		}
+	}
+	if bp.History != nil {
+		r.Results.BranchProtections.History = &jsonBranchHistory{
+			Branch:        bp.History.Branch,
+			Commits:       bp.History.Commits,
+			DirectPushes:  bp.History.DirectPushes,
+			BranchUpdates: bp.History.BranchUpdates,
+			ForcePushes:   bp.History.ForcePushes,
+		}
+	}
+	if bp.MergeBot != nil {
+		r.Results.BranchProtections.MergeBot = &jsonMergeBot{
+			Name:     bp.MergeBot.Name,
+			Evidence: bp.MergeBot.Evidence,
+		}
+	}
+	for i := range bp.Branches {
+		b := &bp.Branches[i]
+		rule := &b.BranchProtectionRule
+		jb := jsonBranchProtection{
+			Protected:                    b.Protected,
+			AllowDeletions:               rule.AllowDeletions,
+			AllowForcePushes:             rule.AllowForcePushes,
+			RequireLinearHistory:         rule.RequireLinearHistory,
+			RequiresSignatures:           rule.RequiresSignatures,
+			EnforceAdmins:                rule.EnforceAdmins,
+			RequiredApprovingReviewCount: rule.RequiredPullRequestReviews.RequiredApprovingReviewCount,
+			DismissStaleReviews:          rule.RequiredPullRequestReviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      rule.RequiredPullRequestReviews.RequireCodeOwnerReviews,
+			UpToDateBeforeMerge:          rule.CheckRules.UpToDateBeforeMerge,
+			RequiresStatusChecks:         rule.CheckRules.RequiresStatusChecks,
+			StatusCheckContexts:          rule.CheckRules.Contexts,
+			RequiredWorkflows:            rule.CheckRules.RequiredWorkflows,
+			RequiresMergeQueue:           rule.RequiresMergeQueue,
+		}
+		if b.Name != nil {
+			jb.Name = *b.Name
+		}
+		r.Results.BranchProtections.Branches = append(r.Results.BranchProtections.Branches, jb)
+	}
+	return nil
+}
+
+//nolint:unparam
+func (r *jsonScorecardRawResult) addDangerousWorkflowRawResults(dw *checker.DangerousWorkflowData) error {
+	r.Results.DangerousWorkflows = []jsonDangerousWorkflow{}
+	for _, w := range dw.Workflows {
+		r.Results.DangerousWorkflows = append(r.Results.DangerousWorkflows, jsonDangerousWorkflow{
+			Type:    string(w.Type),
+			Path:    w.File.Path,
+			Line:    w.File.Offset,
+			Snippet: w.File.Snippet,
+		})
+	}
+	return nil
+}
+
+//nolint:unparam
+func (r *jsonScorecardRawResult) addSignedReleasesRawResults(sr *checker.SignedReleasesData) error {
+	r.Results.Releases = []jsonRelease{}
+	for _, release := range sr.Releases {
+		jr := jsonRelease{
+			Tag:    release.TagName,
+			URL:    release.URL,
+			Assets: []jsonReleaseAsset{},
+		}
+		for _, a := range release.Assets {
+			jr.Assets = append(jr.Assets, jsonReleaseAsset{Name: a.Name, URL: a.URL})
+		}
+		r.Results.Releases = append(r.Results.Releases, jr)
+	}
 	return nil
 }
 
@@ -78,6 +274,21 @@ func (r *jsonScorecardRawResult) fillJSONRawResults(raw *checker.RawResults) err
 	if err := r.addSecurityPolicyRawResults(&raw.SecurityPolicyResults); err != nil {
 		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
 	}
+
+	// Branch-Protection.
+	if err := r.addBranchProtectionRawResults(&raw.BranchProtectionResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
+	// Dangerous-Workflow.
+	if err := r.addDangerousWorkflowRawResults(&raw.DangerousWorkflowResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
+
+	// Signed-Releases.
+	if err := r.addSignedReleasesRawResults(&raw.SignedReleasesResults); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, err.Error())
+	}
 	return nil
 }
 
@@ -88,6 +299,7 @@ func (r *ScorecardResult) AsRawJSON(writer io.Writer) error {
 		Repo: jsonRepoV2{
 			Name:   r.Repo.Name,
 			Commit: r.Repo.CommitSHA,
+			Path:   r.Repo.Path,
 		},
 		Scorecard: jsonScorecardV2{
 			Version: r.Scorecard.Version,
@@ -0,0 +1,149 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+func TestRawJSONOutput(t *testing.T) {
+	t.Parallel()
+	branch := "main"
+	protected := true
+	forcePushes := false
+	reviews := int32(2)
+	result := ScorecardResult{
+		Repo: RepoInfo{
+			Name:      "github.com/org/name",
+			CommitSHA: "68bc59901773ab4c051dfcea0cc4201a1567ab32",
+		},
+		RawResults: checker.RawResults{
+			BinaryArtifactResults: checker.BinaryArtifactData{
+				Files: []checker.File{{Path: "a.exe"}},
+			},
+			BranchProtectionResults: checker.BranchProtectionsData{
+				Branches: []clients.BranchRef{
+					{
+						Name:      &branch,
+						Protected: &protected,
+						BranchProtectionRule: clients.BranchProtectionRule{
+							AllowForcePushes: &forcePushes,
+							RequiredPullRequestReviews: clients.PullRequestReviewRule{
+								RequiredApprovingReviewCount: &reviews,
+							},
+						},
+					},
+				},
+				Inferred: &checker.BranchProtectionInference{
+					Branch:             "main",
+					ForcePushesBlocked: true,
+					PullRequests:       3,
+				},
+			},
+			DangerousWorkflowResults: checker.DangerousWorkflowData{
+				Workflows: []checker.DangerousWorkflow{
+					{
+						Type: checker.DangerousWorkflowScriptInjection,
+						File: checker.File{
+							Path:    ".github/workflows/ci.yml",
+							Offset:  12,
+							Snippet: "${{ github.event.issue.title }}",
+						},
+					},
+				},
+			},
+			SecurityPolicyResults: checker.SecurityPolicyData{
+				Insights: &checker.SecurityInsightsData{
+					File:             checker.File{Path: "SECURITY-INSIGHTS.yml"},
+					SecurityContacts: []string{"security@example.com"},
+					SecurityTools:    []checker.SecurityInsightsTool{{Type: "sast", Name: "CodeQL"}},
+				},
+			},
+			SignedReleasesResults: checker.SignedReleasesData{
+				Releases: []clients.Release{
+					{
+						TagName: "v1.0.0",
+						URL:     "https://github.com/org/name/releases/tag/v1.0.0",
+						Assets: []clients.ReleaseAsset{
+							{Name: "bin.tar.gz", URL: "https://example.com/bin.tar.gz"},
+							{Name: "bin.tar.gz.sig", URL: "https://example.com/bin.tar.gz.sig"},
+						},
+					},
+				},
+			},
+		},
+	}
+	expected := jsonRawResults{
+		Binaries:         []jsonFiles{{Path: "a.exe"}},
+		SecurityPolicies: []jsonSecurityPolicy{},
+		SecurityInsights: &jsonSecurityInsights{
+			Path:             "SECURITY-INSIGHTS.yml",
+			SecurityContacts: []string{"security@example.com"},
+			SecurityTools:    []jsonSecurityInsightsTool{{Type: "sast", Name: "CodeQL"}},
+		},
+		BranchProtections: jsonBranchProtections{
+			Branches: []jsonBranchProtection{
+				{
+					Name:                         "main",
+					Protected:                    &protected,
+					AllowForcePushes:             &forcePushes,
+					RequiredApprovingReviewCount: &reviews,
+				},
+			},
+			Inferred: &jsonBranchInference{
+				Branch:             "main",
+				ForcePushesBlocked: true,
+				PullRequests:       3,
+			},
+		},
+		DangerousWorkflows: []jsonDangerousWorkflow{
+			{
+				Type:    "script-injection",
+				Path:    ".github/workflows/ci.yml",
+				Line:    12,
+				Snippet: "${{ github.event.issue.title }}",
+			},
+		},
+		Releases: []jsonRelease{
+			{
+				Tag: "v1.0.0",
+				URL: "https://github.com/org/name/releases/tag/v1.0.0",
+				Assets: []jsonReleaseAsset{
+					{Name: "bin.tar.gz", URL: "https://example.com/bin.tar.gz"},
+					{Name: "bin.tar.gz.sig", URL: "https://example.com/bin.tar.gz.sig"},
+				},
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := result.AsRawJSON(&out); err != nil {
+		t.Fatalf("AsRawJSON: %v", err)
+	}
+	var got jsonScorecardRawResult
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if diff := cmp.Diff(expected, got.Results); diff != "" {
+		t.Errorf("AsRawJSON() mismatch (-want +got):\n%s", diff)
+	}
+}
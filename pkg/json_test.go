@@ -107,11 +107,13 @@ func TestJSONOutput(t *testing.T) {
 							{
 								Type: checker.DetailWarn,
 								Msg: checker.LogMessage{
-									Text:    "warn message",
-									Path:    "src/file1.cpp",
-									Type:    checker.FileTypeSource,
-									Offset:  5,
-									Snippet: "if (bad) {BUG();}",
+									Text:      "warn message",
+									Path:      "src/file1.cpp",
+									Type:      checker.FileTypeSource,
+									Offset:    5,
+									EndOffset: 7,
+									Snippet:   "if (bad) {BUG();}",
+									Finding:   checker.FindingInsecureDownload,
 									// UPGRADEv3: to remove.
 									Version: 3,
 								},
@@ -439,6 +441,158 @@ func TestJSONOutput(t *testing.T) {
 				Metadata: []string{},
 			},
 		},
+		{
+			name:        "check-7",
+			showDetails: true,
+			expected:    "./testdata/check7.json",
+			logLevel:    zapcore.WarnLevel,
+			result: ScorecardResult{
+				Repo: RepoInfo{
+					Name:      repoName,
+					CommitSHA: repoCommit,
+				},
+				Scorecard: ScorecardInfo{
+					Version:   scorecardVersion,
+					CommitSHA: scorecardCommit,
+				},
+				Date: date,
+				Checks: []checker.CheckResult{
+					{
+						Details2: []checker.CheckDetail{
+							{
+								Type: checker.DetailWarn,
+								Msg: checker.LogMessage{
+									Text: "'force pushes' enabled on branch 'main'",
+									Remediation: &checker.Remediation{
+										Text:   "Disable 'Allow force pushes' for branch 'main'",
+										Steps:  []string{"Disable 'Allow force pushes'", "Save changes"},
+										Effort: checker.RemediationEffortLow,
+										URL:    "https://github.com/org/name/settings/branches",
+									},
+									// UPGRADEv3: to remove.
+									Version: 3,
+								},
+							},
+						},
+						Score:  6,
+						Reason: "six score reason",
+						Name:   "Check-Name",
+					},
+				},
+				Metadata: []string{},
+			},
+		},
+		{
+			name:        "check-8",
+			showDetails: true,
+			expected:    "./testdata/check8.json",
+			logLevel:    zapcore.WarnLevel,
+			result: ScorecardResult{
+				Repo: RepoInfo{
+					Name:      repoName,
+					CommitSHA: repoCommit,
+				},
+				Scorecard: ScorecardInfo{
+					Version:   scorecardVersion,
+					CommitSHA: scorecardCommit,
+				},
+				Date: date,
+				Checks: []checker.CheckResult{
+					{
+						Details2: []checker.CheckDetail{
+							{
+								Type: checker.DetailWarn,
+								Msg: checker.LogMessage{
+									Text:    "dependency not pinned by hash: 'actions/checkout'",
+									Path:    ".github/workflows/main.yml",
+									Type:    checker.FileTypeSource,
+									Offset:  5,
+									Finding: checker.FindingUnpinnedDependency,
+									// UPGRADEv3: to remove.
+									Version: 3,
+								},
+							},
+							{
+								Type: checker.DetailWarn,
+								Msg: checker.LogMessage{
+									Text:    "dependency not pinned by hash: 'actions/setup-go'",
+									Path:    ".github/workflows/main.yml",
+									Type:    checker.FileTypeSource,
+									Offset:  7,
+									Finding: checker.FindingUnpinnedDependency,
+									// UPGRADEv3: to remove.
+									Version: 3,
+								},
+							},
+							{
+								Type: checker.DetailWarn,
+								Msg: checker.LogMessage{
+									Text:    "dependency not pinned by hash: 'actions/checkout'",
+									Path:    ".github/workflows/release.yml",
+									Type:    checker.FileTypeSource,
+									Offset:  9,
+									Finding: checker.FindingUnpinnedDependency,
+									// UPGRADEv3: to remove.
+									Version: 3,
+								},
+							},
+							{
+								Type: checker.DetailWarn,
+								Msg: checker.LogMessage{
+									Text: "warn message",
+									// UPGRADEv3: to remove.
+									Version: 3,
+								},
+							},
+						},
+						Score:  4,
+						Reason: "four score reason",
+						Name:   "Check-Name",
+					},
+				},
+				Metadata: []string{},
+			},
+		},
+		{
+			name:        "check-9",
+			showDetails: true,
+			expected:    "./testdata/check9.json",
+			logLevel:    zapcore.DebugLevel,
+			result: ScorecardResult{
+				Repo: RepoInfo{
+					Name:      repoName,
+					CommitSHA: repoCommit,
+				},
+				Scorecard: ScorecardInfo{
+					Version:   scorecardVersion,
+					CommitSHA: scorecardCommit,
+				},
+				Date: date,
+				Checks: []checker.CheckResult{
+					{
+						Details2: []checker.CheckDetail{
+							{
+								Type: checker.DetailWarn,
+								Msg: checker.LogMessage{
+									Text: "dependency not pinned by hash: 'golang:1.17'",
+									Path: "Dockerfile",
+									Type: checker.FileTypeSource,
+									Message: checker.NewMessage(checker.MsgPinnedDockerfileUnpinned,
+										checker.MessageParams{"name": "golang:1.17"}),
+									// UPGRADEv3: to remove.
+									Version: 3,
+								},
+							},
+						},
+						Score:         8,
+						Reason:        "dependency not pinned by hash detected",
+						ReasonMessage: checker.NewMessage(checker.MsgPinnedUnpinnedDetected, nil),
+						Name:          "Check-Name",
+					},
+				},
+				Metadata: []string{},
+			},
+		},
 	}
 
 	// Load the JSON schema.
@@ -516,3 +670,36 @@ func TestJSONOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestStatsToJSON(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		stats ScanStats
+		want  *jsonStatsV2
+	}{
+		{
+			name: "not measured",
+		},
+		{
+			name:  "measured",
+			stats: ScanStats{APICalls: 12, Duration: 1500 * time.Millisecond},
+			want:  &jsonStatsV2{APICalls: 12, DurationMs: 1500},
+		},
+		{
+			name:  "budget exceeded",
+			stats: ScanStats{APICalls: 101, Duration: time.Second, APICallBudgetExceeded: true},
+			want:  &jsonStatsV2{APICalls: 101, DurationMs: 1000, APICallBudgetExceeded: true},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := statsToJSON(tt.stats)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("statsToJSON() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// MaintainerAnnotationsFile is the file, at the root of a repo, where its
+// maintainers annotate findings.
+const MaintainerAnnotationsFile = ".scorecard.yml"
+
+var errInvalidAnnotation = errors.New("invalid annotation")
+
+// maintainerAnnotation annotates the warnings of a check in a
+// MaintainerAnnotationsFile. Without Paths or Findings, it applies to all
+// the warnings of the check.
+type maintainerAnnotation struct {
+	Check string   `yaml:"check"`
+	Paths []string `yaml:"paths"`
+	// Findings are finding IDs, as shown in the output.
+	Findings []string    `yaml:"findings"`
+	State    TriageState `yaml:"state"`
+	Reason   string      `yaml:"reason"`
+}
+
+type maintainerAnnotationsFile struct {
+	Annotations []maintainerAnnotation `yaml:"annotations"`
+}
+
+// ParseMaintainerAnnotations parses the content of a MaintainerAnnotationsFile,
+// with one Annotation per finding listed, and one for the paths.
+func ParseMaintainerAnnotations(content []byte) ([]Annotation, error) {
+	var f maintainerAnnotationsFile
+	if err := yaml.Unmarshal(content, &f); err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("yaml.Unmarshal: %v", err))
+	}
+	var ret []Annotation
+	for i, a := range f.Annotations {
+		switch a.State {
+		case TriageAcknowledged, TriageWontFix, TriageNotApplicable:
+		default:
+			return nil, sce.WithMessage(sce.ErrScorecardInternal,
+				fmt.Sprintf("%v: #%d: unknown state: %q", errInvalidAnnotation, i+1, a.State))
+		}
+		if a.Check == "" || a.Reason == "" {
+			return nil, sce.WithMessage(sce.ErrScorecardInternal,
+				fmt.Sprintf("%v: #%d: check and reason are required", errInvalidAnnotation, i+1))
+		}
+		for _, p := range a.Paths {
+			if _, err := path.Match(p, ""); err != nil {
+				return nil, sce.WithMessage(sce.ErrScorecardInternal,
+					fmt.Sprintf("%v: #%d: %s: %v", errInvalidAnnotation, i+1, p, err))
+			}
+		}
+		for _, id := range a.Findings {
+			ret = append(ret, Annotation{FindingID: id, Check: a.Check, State: a.State, Reason: a.Reason})
+		}
+		if len(a.Paths) > 0 || len(a.Findings) == 0 {
+			ret = append(ret, Annotation{Check: a.Check, Paths: a.Paths, State: a.State, Reason: a.Reason})
+		}
+	}
+	return ret, nil
+}
+
+// readMaintainerAnnotations returns the annotations of the repo, if it has a
+// MaintainerAnnotationsFile.
+func readMaintainerAnnotations(repoClient clients.RepoClient) ([]Annotation, error) {
+	files, err := repoClient.ListFiles(func(f string) (bool, error) {
+		return f == MaintainerAnnotationsFile, nil
+	})
+	if errors.Is(err, clients.ErrUnsupportedFeature) || len(files) == 0 {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListFiles: %v", err))
+	}
+	content, err := repoClient.GetFileContent(MaintainerAnnotationsFile)
+	if err != nil {
+		return nil, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetFileContent: %v", err))
+	}
+	return ParseMaintainerAnnotations(content)
+}
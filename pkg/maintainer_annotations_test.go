@@ -0,0 +1,194 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ossf/scorecard/v3/checker"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+)
+
+const testMaintainerAnnotations = `
+annotations:
+  - check: Binary-Artifacts
+    paths:
+      - testdata/**
+    state: not-applicable
+    reason: Test fixtures, not shipped.
+  - check: Binary-Artifacts
+    paths:
+      - "*.jar"
+    state: wont-fix
+    reason: Needed by the build.
+  - check: Pinned-Dependencies
+    state: acknowledged
+    reason: Pinning in progress.
+`
+
+func TestParseMaintainerAnnotations(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			content: testMaintainerAnnotations,
+		},
+		{
+			name:    "empty",
+			content: "",
+		},
+		{
+			name:    "unknown state",
+			content: "annotations:\n  - check: Fuzzing\n    state: ignored\n    reason: Not needed.\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing reason",
+			content: "annotations:\n  - check: Fuzzing\n    state: wont-fix\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid path",
+			content: "annotations:\n  - check: Fuzzing\n    paths: ['[']\n    state: wont-fix\n    reason: Why.\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid yaml",
+			content: "annotations: {",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseMaintainerAnnotations([]byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseMaintainerAnnotations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMaintainerAnnotations(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	repoClient := mockrepo.NewMockRepoClient(ctrl)
+	repoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+		func(predicate func(string) (bool, error)) ([]string, error) {
+			var ret []string
+			for _, f := range []string{"README.md", MaintainerAnnotationsFile} {
+				if match, err := predicate(f); err == nil && match {
+					ret = append(ret, f)
+				}
+			}
+			return ret, nil
+		})
+	repoClient.EXPECT().GetFileContent(MaintainerAnnotationsFile).Return([]byte(testMaintainerAnnotations), nil)
+	annotations, err := readMaintainerAnnotations(repoClient)
+	if err != nil {
+		t.Fatalf("readMaintainerAnnotations: %v", err)
+	}
+
+	warn := func(path string) checker.CheckDetail {
+		return checker.CheckDetail{
+			Type: checker.DetailWarn,
+			Msg:  checker.LogMessage{Path: path, Text: "binary detected", Version: 3},
+		}
+	}
+	result := ScorecardResult{
+		Checks: []checker.CheckResult{
+			{
+				Name: "Binary-Artifacts",
+				Details2: []checker.CheckDetail{
+					warn("testdata/a/tool.exe"),
+					warn("lib.jar"),
+					warn("bin/tool.exe"),
+				},
+			},
+			{
+				Name:     "Pinned-Dependencies",
+				Details2: []checker.CheckDetail{warn("Dockerfile")},
+			},
+		},
+	}
+	result.applyAnnotations(annotations, false)
+
+	var got []string
+	for _, check := range result.Checks {
+		for i := range check.Details2 {
+			got = append(got, DetailToString(&check.Details2[i], zapcore.InfoLevel))
+		}
+	}
+	want := []string{
+		"Warn: binary detected: testdata/a/tool.exe [not-applicable: Test fixtures, not shipped.]",
+		"Warn: binary detected: lib.jar [wont-fix: Needed by the build.]",
+		"Warn: binary detected: bin/tool.exe",
+		"Warn: binary detected: Dockerfile [acknowledged: Pinning in progress.]",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	// Acknowledged warnings are still reported.
+	result.ApplyAnnotations(annotations)
+	var types []checker.DetailType
+	for _, check := range result.Checks {
+		for _, d := range check.Details2 {
+			types = append(types, d.Type)
+		}
+	}
+	wantTypes := []checker.DetailType{checker.DetailInfo, checker.DetailInfo, checker.DetailWarn, checker.DetailWarn}
+	if diff := cmp.Diff(wantTypes, types); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMaintainerAnnotationsByFinding(t *testing.T) {
+	t.Parallel()
+	d := checker.CheckDetail{Type: checker.DetailWarn, Msg: checker.LogMessage{Text: "no SECURITY.md"}}
+	annotations, err := ParseMaintainerAnnotations([]byte(`
+annotations:
+  - check: Security-Policy
+    findings: [` + FindingID("Security-Policy", &d) + `]
+    state: wont-fix
+    reason: Reported in the README.
+`))
+	if err != nil {
+		t.Fatalf("ParseMaintainerAnnotations: %v", err)
+	}
+	result := ScorecardResult{
+		Checks: []checker.CheckResult{
+			{Name: "Security-Policy", Details2: []checker.CheckDetail{d}},
+			// Same detail, other check.
+			{Name: "Other", Details2: []checker.CheckDetail{d}},
+		},
+	}
+	result.applyAnnotations(annotations, false)
+	if result.Checks[0].Details2[0].Annotation == nil {
+		t.Error("finding was not annotated")
+	}
+	if result.Checks[1].Details2[0].Annotation != nil {
+		t.Error("finding of another check was annotated")
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ossf/scorecard/v3/checker"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// AsMarkdown exports results as a Markdown report suitable for GitHub issues and PR comments.
+// Details, if shown, are rendered in a collapsible section per check,
+// in which large groups of similar details are also collapsed.
+func (r *ScorecardResult) AsMarkdown(showDetails bool, logLevel zapcore.Level,
+	checkDocs docs.Doc, writer io.Writer) error {
+	score, err := r.GetAggregateScore(checkDocs)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Scorecard results for %s\n\n", r.Repo.Name)
+	if score == checker.InconclusiveResultScore {
+		b.WriteString("Aggregate score: **?**\n\n")
+	} else {
+		fmt.Fprintf(&b, "Aggregate score: **%s / %d**\n\n", scoreToString(score), checker.MaxResultScore)
+	}
+	if r.Repo.CommitSHA != "" {
+		fmt.Fprintf(&b, "Commit: `%s`\n\n", r.Repo.CommitSHA)
+	}
+
+	b.WriteString("| Score | Check | Reason |\n")
+	b.WriteString("| --- | --- | --- |\n")
+	var details strings.Builder
+	for i := range r.Checks {
+		check := &r.Checks[i]
+		cdoc, e := checkDocs.GetCheck(check.Name)
+		if e != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetCheck: %s: %v", check.Name, e))
+		}
+		checkScore := "?"
+		if check.Score != checker.InconclusiveResultScore {
+			checkScore = fmt.Sprintf("%d / %d", check.Score, checker.MaxResultScore)
+		}
+		fmt.Fprintf(&b, "| %s | [%s](%s) | %s |\n", checkScore, check.Name,
+			cdoc.GetDocumentationURL(r.Scorecard.CommitSHA), markdownEscapeCell(check.Reason))
+
+		if !showDetails {
+			continue
+		}
+		lines, aggregated := detailsToLines(check.Name, check.Details2, logLevel)
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&details, "<details>\n<summary>%s details</summary>\n\n```\n%s\n```\n\n",
+			check.Name, strings.Join(lines, "\n"))
+		// Summarized groups of details can be expanded to list them.
+		for _, a := range aggregated {
+			fmt.Fprintf(&details, "<details>\n<summary>%s</summary>\n\n```\n%s\n```\n\n</details>\n\n",
+				html.EscapeString(a.summary), strings.Join(a.lines, "\n"))
+		}
+		details.WriteString("</details>\n\n")
+	}
+	if details.Len() > 0 {
+		b.WriteString("\n")
+		b.WriteString(details.String())
+	}
+
+	if _, err := io.WriteString(writer, b.String()); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("io.WriteString: %v", err))
+	}
+	return nil
+}
+
+// markdownEscapeCell makes s safe to use in a Markdown table cell.
+func markdownEscapeCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ossf/scorecard/v3/checker"
+)
+
+func TestMarkdownOutput(t *testing.T) {
+	t.Parallel()
+	result := ScorecardResult{
+		Repo: RepoInfo{
+			Name:      "github.com/org/name",
+			CommitSHA: "68bc59901773ab4c051dfcea0cc4201a1567ab32",
+		},
+		Checks: []checker.CheckResult{
+			{
+				Name:   "Check-Name",
+				Score:  5,
+				Reason: "half | of it",
+				Details2: []checker.CheckDetail{
+					{Type: checker.DetailInfo, Msg: checker.LogMessage{Text: "info message"}},
+				},
+			},
+			{Name: "Check-Name2", Score: checker.InconclusiveResultScore, Reason: "inconclusive"},
+		},
+	}
+	tests := []struct {
+		name        string
+		showDetails bool
+		expected    string
+	}{
+		{
+			name: "without details",
+			expected: "## Scorecard results for github.com/org/name\n\n" +
+				"Aggregate score: **5.0 / 10**\n\n" +
+				"Commit: `68bc59901773ab4c051dfcea0cc4201a1567ab32`\n\n" +
+				"| Score | Check | Reason |\n" +
+				"| --- | --- | --- |\n" +
+				"| 5 / 10 | [Check-Name](https://github.com/ossf/scorecard/blob/main/docs/checks.md#check-name) | half \\| of it |\n" +
+				"| ? | [Check-Name2](https://github.com/ossf/scorecard/blob/main/docs/checks.md#check-name2) | inconclusive |\n",
+		},
+		{
+			name:        "with details",
+			showDetails: true,
+			expected: "## Scorecard results for github.com/org/name\n\n" +
+				"Aggregate score: **5.0 / 10**\n\n" +
+				"Commit: `68bc59901773ab4c051dfcea0cc4201a1567ab32`\n\n" +
+				"| Score | Check | Reason |\n" +
+				"| --- | --- | --- |\n" +
+				"| 5 / 10 | [Check-Name](https://github.com/ossf/scorecard/blob/main/docs/checks.md#check-name) | half \\| of it |\n" +
+				"| ? | [Check-Name2](https://github.com/ossf/scorecard/blob/main/docs/checks.md#check-name2) | inconclusive |\n" +
+				"\n<details>\n<summary>Check-Name details</summary>\n\n```\nInfo: info message\n```\n\n</details>\n\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			if err := result.AsMarkdown(tt.showDetails, zapcore.InfoLevel, jsonMockDocRead(), &out); err != nil {
+				t.Fatalf("AsMarkdown: %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("AsMarkdown() = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
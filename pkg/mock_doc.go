@@ -23,6 +23,7 @@ import (
 type mockCheck struct {
 	name, risk, short, description, url string
 	tags, remediation, repos            []string
+	version                             int
 }
 
 func (c *mockCheck) GetName() string {
@@ -61,10 +62,22 @@ func (c *mockCheck) GetSupportedRepoTypes() []string {
 	return l
 }
 
+func (c *mockCheck) GetRequiredAPIs() []string {
+	return nil
+}
+
 func (c *mockCheck) GetDocumentationURL(commitish string) string {
 	return c.url
 }
 
+func (c *mockCheck) GetVersion() int {
+	return c.version
+}
+
+func (c *mockCheck) GetChanges() []docs.CheckChange {
+	return nil
+}
+
 type mockDoc struct {
 	checks map[string]mockCheck
 }
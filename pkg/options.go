@@ -0,0 +1,73 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import "time"
+
+// DefaultCheckTimeout bounds each check so that a hung API call fails
+// the check instead of the whole run, see WithCheckTimeout.
+const DefaultCheckTimeout = 10 * time.Minute
+
+// Option configures a run of RunScorecards.
+type Option func(*runOptions)
+
+// runOptions are the settings of a run of RunScorecards.
+type runOptions struct {
+	checkTimeout              time.Duration
+	apiCallBudget             int
+	subPath                   string
+	failOnUnsupported         bool
+	progress                  CheckProgressFunc
+	requiredStatusChecks      []string
+	dependencyVulnerabilities bool
+}
+
+func newRunOptions(opts []Option) runOptions {
+	ret := runOptions{
+		checkTimeout: DefaultCheckTimeout,
+		progress:     func(repo, checkName string) {},
+	}
+	for _, opt := range opts {
+		opt(&ret)
+	}
+	return ret
+}
+
+// WithCheckTimeout sets the time each check has to complete, its retries
+// included, DefaultCheckTimeout by default. A check which runs out of time
+// fails with a runtime error while the other checks complete normally.
+// Zero disables the timeout.
+func WithCheckTimeout(d time.Duration) Option {
+	return func(o *runOptions) {
+		o.checkTimeout = d
+	}
+}
+
+// WithRequiredStatusChecks sets the status check context name patterns
+// (path.Match syntax) that Branch-Protection expects protected branches
+// to require.
+func WithRequiredStatusChecks(patterns []string) Option {
+	return func(o *runOptions) {
+		o.requiredStatusChecks = patterns
+	}
+}
+
+// WithDependencyVulnerabilities makes the Vulnerabilities check also look up
+// the dependencies pinned by the repo's lockfiles in the vulnerabilities DB.
+func WithDependencyVulnerabilities() Option {
+	return func(o *runOptions) {
+		o.dependencyVulnerabilities = true
+	}
+}
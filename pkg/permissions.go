@@ -0,0 +1,77 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// tagPermissions maps the checks.yaml tags declaring a
+// permission requirement to the permission.
+var tagPermissions = map[string]string{
+	"admin-required": clients.PermissionAdmin,
+}
+
+func requiredPermissions(checkDoc docs.CheckDoc) []string {
+	var ret []string
+	for _, t := range checkDoc.GetTags() {
+		if p, ok := tagPermissions[t]; ok {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// applyTokenPermissions degrades the checks needing permissions the token
+// does not have: a check which failed is marked inconclusive, with an
+// sce.ErrMissingPermissions error instead of its runtime error, and a check
+// which completed is marked partial since it may have scored with reduced
+// fidelity.
+func (r *ScorecardResult) applyTokenPermissions(perms clients.TokenPermissions) error {
+	checkDocs, err := docs.Read()
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("docs.Read: %v", err))
+	}
+	for i := range r.Checks {
+		result := &r.Checks[i]
+		checkDoc, err := checkDocs.GetCheck(result.Name)
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetCheck: %s: %v", result.Name, err))
+		}
+		missing := perms.Missing(requiredPermissions(checkDoc))
+		if len(missing) == 0 {
+			continue
+		}
+
+		reason := fmt.Sprintf("token lacks %s permission on the repo", strings.Join(missing, ", "))
+		r.Capabilities.Reasons[result.Name] = reason
+		if result.Error2 != nil {
+			*result = checker.CreateInconclusiveResult(result.Name, reason)
+			result.Error2 = sce.WithMessage(sce.ErrMissingPermissions, reason)
+			r.Capabilities.Checks[result.Name] = CapabilityUnsupported
+			continue
+		}
+		if r.Capabilities.Checks[result.Name] == CapabilityFull {
+			r.Capabilities.Checks[result.Name] = CapabilityPartial
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+func TestApplyTokenPermissions(t *testing.T) {
+	t.Parallel()
+	errRuntime := errors.New("403 Forbidden")
+	tests := []struct {
+		name       string
+		perms      clients.TokenPermissions
+		result     checker.CheckResult
+		wantMode   CapabilityMode
+		wantReason string
+		wantErr    error
+		wantScore  int
+	}{
+		{
+			name:      "unknown permissions",
+			result:    checker.CheckResult{Name: "Branch-Protection", Score: 3},
+			wantMode:  CapabilityFull,
+			wantScore: 3,
+		},
+		{
+			name:      "admin token",
+			perms:     clients.TokenPermissions{Known: true, Admin: true},
+			result:    checker.CheckResult{Name: "Branch-Protection", Score: 3},
+			wantMode:  CapabilityFull,
+			wantScore: 3,
+		},
+		{
+			name:       "reduced fidelity",
+			perms:      clients.TokenPermissions{Known: true},
+			result:     checker.CheckResult{Name: "Branch-Protection", Score: 3},
+			wantMode:   CapabilityPartial,
+			wantReason: "token lacks admin permission on the repo",
+			wantScore:  3,
+		},
+		{
+			name:       "runtime error becomes inconclusive",
+			perms:      clients.TokenPermissions{Known: true},
+			result:     checker.CreateRuntimeErrorResult("Branch-Protection", errRuntime),
+			wantMode:   CapabilityUnsupported,
+			wantReason: "token lacks admin permission on the repo",
+			wantScore:  checker.InconclusiveResultScore,
+			wantErr:    sce.ErrMissingPermissions,
+		},
+		{
+			name:      "check not needing admin",
+			perms:     clients.TokenPermissions{Known: true},
+			result:    checker.CreateRuntimeErrorResult("Code-Review", errRuntime),
+			wantMode:  CapabilityFull,
+			wantScore: checker.InconclusiveResultScore,
+			wantErr:   errRuntime,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := ScorecardResult{
+				Checks: []checker.CheckResult{tt.result},
+				Capabilities: CapabilityMatrix{
+					Checks:  map[string]CapabilityMode{tt.result.Name: CapabilityFull},
+					Reasons: map[string]string{},
+				},
+			}
+			if err := r.applyTokenPermissions(tt.perms); err != nil {
+				t.Fatalf("applyTokenPermissions: %v", err)
+			}
+			if got := r.Capabilities.Checks[tt.result.Name]; got != tt.wantMode {
+				t.Errorf("mode = %s, want %s", got, tt.wantMode)
+			}
+			if got := r.Capabilities.Reasons[tt.result.Name]; got != tt.wantReason {
+				t.Errorf("reason = %q, want %q", got, tt.wantReason)
+			}
+			if got := r.Checks[0].Score; got != tt.wantScore {
+				t.Errorf("score = %d, want %d", got, tt.wantScore)
+			}
+			if got := r.Checks[0].Error2; !errors.Is(got, tt.wantErr) {
+				t.Errorf("error = %v, want %v", got, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks"
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+// WithFailOnUnsupported makes RunScorecards fail, before running any check,
+// when a check needs RepoClient features the repo does not support. Otherwise
+// such checks are skipped and reported as CapabilityUnsupported. It suits runs
+// of checks the user asked for.
+func WithFailOnUnsupported() Option {
+	return func(o *runOptions) {
+		o.failOnUnsupported = true
+	}
+}
+
+// unsupportedChecks returns, for each of checksToRun needing features
+// repoClient does not support, why it cannot run.
+func unsupportedChecks(repoClient clients.RepoClient, checksToRun checker.CheckNameToFnMap) map[string]string {
+	reporter, ok := repoClient.(clients.FeatureReporter)
+	if !ok {
+		return nil
+	}
+	ret := make(map[string]string)
+	for checkName := range checksToRun {
+		var missing []string
+		for _, f := range checks.RequiredFeatures(checkName) {
+			if !reporter.SupportsFeature(f) {
+				missing = append(missing, string(f))
+			}
+		}
+		if len(missing) > 0 {
+			ret[checkName] = fmt.Sprintf("needs %s, which the repo client does not support",
+				strings.Join(missing, ", "))
+		}
+	}
+	return ret
+}
+
+// preflightError lists the checks which cannot run and why.
+func preflightError(unsupported map[string]string) error {
+	names := make([]string, 0, len(unsupported))
+	for name := range unsupported {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	reasons := make([]string, 0, len(names))
+	for _, name := range names {
+		reasons = append(reasons, fmt.Sprintf("%s %s", name, unsupported[name]))
+	}
+	return fmt.Errorf("%w: %s", clients.ErrUnsupportedFeature, strings.Join(reasons, "; "))
+}
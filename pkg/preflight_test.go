@@ -0,0 +1,115 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks"
+	"github.com/ossf/scorecard/v3/clients"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+)
+
+// noFilesRepoClient is a RepoClient without file contents, like --fast.
+type noFilesRepoClient struct {
+	*mockrepo.MockRepoClient
+}
+
+func (c noFilesRepoClient) SupportsFeature(f clients.Feature) bool {
+	return f != clients.FeatureFileContents
+}
+
+func TestRunScorecardsPreflight(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		wantErr      error
+		wantChecks   []string
+		capabilities map[string]CapabilityMode
+		reasons      map[string]string
+		name         string
+		fail         bool
+	}{
+		{
+			name:       "unsupported checks are skipped",
+			wantChecks: []string{checks.CheckCodeReview},
+			capabilities: map[string]CapabilityMode{
+				checks.CheckBinaryArtifacts: CapabilityUnsupported,
+				checks.CheckCodeReview:      CapabilityFull,
+			},
+			reasons: map[string]string{
+				checks.CheckBinaryArtifacts: "needs file contents, which the repo client does not support",
+			},
+		},
+		{
+			name:    "unsupported checks fail the run",
+			fail:    true,
+			wantErr: clients.ErrUnsupportedFeature,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			repo := mockrepo.NewMockRepo(ctrl)
+			repo.EXPECT().URI().Return("github.com/ossf/scorecard").AnyTimes()
+			mockClient := mockrepo.NewMockRepoClient(ctrl)
+			mockClient.EXPECT().InitRepo(repo, clients.HeadSHA).Return(nil)
+			mockClient.EXPECT().ListCommits().Return([]clients.Commit{{SHA: "sha"}}, nil)
+			mockClient.EXPECT().Close().Return(nil)
+			if !tt.fail {
+				// The repo has no .scorecard.yml.
+				mockClient.EXPECT().ListFiles(gomock.Any()).Return(nil, nil)
+			}
+
+			checksToRun := checker.CheckNameToFnMap{}
+			for _, name := range []string{checks.CheckBinaryArtifacts, checks.CheckCodeReview} {
+				name := name
+				checksToRun[name] = func(c *checker.CheckRequest) checker.CheckResult {
+					return checker.CreateMaxScoreResult(name, "done")
+				}
+			}
+			var opts []Option
+			if tt.fail {
+				opts = append(opts, WithFailOnUnsupported())
+			}
+			result, err := RunScorecards(context.Background(), repo, clients.HeadSHA, false, checksToRun,
+				noFilesRepoClient{mockClient}, nil, nil, nil, nil, opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RunScorecards() error = %v, want %v", err, tt.wantErr)
+			}
+			var gotChecks []string
+			for _, check := range result.Checks {
+				gotChecks = append(gotChecks, check.Name)
+			}
+			if diff := cmp.Diff(tt.wantChecks, gotChecks); diff != "" {
+				t.Errorf("checks mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.capabilities, result.Capabilities.Checks, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("capabilities mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.reasons, result.Capabilities.Reasons, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("reasons mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"fmt"
+
+	"github.com/ossf/scorecard/v3/checker"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// publicOnlyTag is the checks.yaml tag of the checks looking for evidence,
+// like an OSS-Fuzz project or a CII badge, which only public repos can have.
+const publicOnlyTag = "public-only"
+
+const privateRepoReason = "not applicable to private repos"
+
+// ApplyPrivateRepo adjusts the results of a private repo: the checks tagged
+// public-only which did not find evidence are marked not applicable, so they do
+// not count in the aggregate score, instead of scoring 0. Runtime errors are
+// left as they are: the check could not look for the evidence at all.
+func (r *ScorecardResult) ApplyPrivateRepo() error {
+	checkDocs, err := docs.Read()
+	if err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("docs.Read: %v", err))
+	}
+	r.Repo.Private = true
+	for i := range r.Checks {
+		result := &r.Checks[i]
+		checkDoc, err := checkDocs.GetCheck(result.Name)
+		if err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("GetCheck: %s: %v", result.Name, err))
+		}
+		if !hasTag(checkDoc, publicOnlyTag) || !lacksEvidence(result) {
+			continue
+		}
+		*result = checker.CreateNotApplicableResult(result.Name, privateRepoReason)
+		r.Capabilities.Checks[result.Name] = CapabilityUnsupported
+		r.Capabilities.Reasons[result.Name] = privateRepoReason
+	}
+	return nil
+}
+
+// lacksEvidence returns whether the check ran and scored 0 or could not
+// conclude, as opposed to failing with a runtime error.
+func lacksEvidence(result *checker.CheckResult) bool {
+	switch result.State() {
+	case checker.ResultScored:
+		return result.Score == checker.MinResultScore
+	case checker.ResultInconclusive:
+		return true
+	default:
+		return false
+	}
+}
+
+func hasTag(checkDoc docs.CheckDoc, tag string) bool {
+	for _, t := range checkDoc.GetTags() {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
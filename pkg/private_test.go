@@ -0,0 +1,109 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/ossf/scorecard/v3/checker"
+)
+
+func TestApplyPrivateRepo(t *testing.T) {
+	t.Parallel()
+	result := ScorecardResult{
+		Checks: []checker.CheckResult{
+			{Name: "CII-Best-Practices", Score: checker.MinResultScore, Reason: "no badge detected"},
+			{Name: "Fuzzing", Score: checker.MaxResultScore, Reason: "project uses ClusterFuzzLite"},
+			{Name: "Binary-Artifacts", Score: checker.MinResultScore, Reason: "binaries present in source code"},
+		},
+		Capabilities: CapabilityMatrix{
+			Checks: map[string]CapabilityMode{
+				"CII-Best-Practices": CapabilityFull,
+				"Fuzzing":            CapabilityFull,
+				"Binary-Artifacts":   CapabilityFull,
+			},
+			Reasons: map[string]string{},
+		},
+	}
+	if err := result.ApplyPrivateRepo(); err != nil {
+		t.Fatalf("ApplyPrivateRepo: %v", err)
+	}
+
+	want := ScorecardResult{
+		Repo: RepoInfo{Private: true},
+		Checks: []checker.CheckResult{
+			checker.CreateNotApplicableResult("CII-Best-Practices", privateRepoReason),
+			// Evidence found in a private repo still counts.
+			{Name: "Fuzzing", Score: checker.MaxResultScore, Reason: "project uses ClusterFuzzLite"},
+			// Checks which apply to all repos are unchanged.
+			{Name: "Binary-Artifacts", Score: checker.MinResultScore, Reason: "binaries present in source code"},
+		},
+		Capabilities: CapabilityMatrix{
+			Checks: map[string]CapabilityMode{
+				"CII-Best-Practices": CapabilityUnsupported,
+				"Fuzzing":            CapabilityFull,
+				"Binary-Artifacts":   CapabilityFull,
+			},
+			Reasons: map[string]string{"CII-Best-Practices": privateRepoReason},
+		},
+	}
+	if diff := cmp.Diff(want, result); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestApplyPrivateRepo_ResultStates(t *testing.T) {
+	t.Parallel()
+	errRuntime := errors.New("API rate limit exceeded")
+	tests := []struct {
+		name   string
+		result checker.CheckResult
+		want   checker.CheckResult
+	}{
+		{
+			name:   "inconclusive",
+			result: checker.CreateInconclusiveResult("Fuzzing", "no fuzzer found"),
+			want:   checker.CreateNotApplicableResult("Fuzzing", privateRepoReason),
+		},
+		{
+			name:   "runtime error",
+			result: checker.CreateRuntimeErrorResult("Fuzzing", errRuntime),
+			want:   checker.CreateRuntimeErrorResult("Fuzzing", errRuntime),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := ScorecardResult{
+				Checks: []checker.CheckResult{tt.result},
+				Capabilities: CapabilityMatrix{
+					Checks:  map[string]CapabilityMode{"Fuzzing": CapabilityFull},
+					Reasons: map[string]string{},
+				},
+			}
+			if err := result.ApplyPrivateRepo(); err != nil {
+				t.Fatalf("ApplyPrivateRepo: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, result.Checks[0], cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/probes"
+)
+
+type jsonProbeResult struct {
+	Date      string           `json:"date"`
+	Repo      jsonRepoV2       `json:"repo"`
+	Scorecard jsonScorecardV2  `json:"scorecard"`
+	Findings  []probes.Finding `json:"findings"`
+}
+
+// AsProbe runs the named probes against the raw results
+// and exports the findings as JSON.
+func (r *ScorecardResult) AsProbe(probeNames []string, writer io.Writer) error {
+	findings, err := probes.Run(&r.RawResults, probeNames)
+	if err != nil {
+		//nolint:wrapcheck
+		return err
+	}
+	suppressed := make(map[string]bool)
+	for _, id := range r.Suppressions {
+		suppressed[id] = true
+	}
+	for i := range findings {
+		findings[i].Suppressed = suppressed[findings[i].ID]
+	}
+
+	out := jsonProbeResult{
+		Repo: jsonRepoV2{
+			Name:   r.Repo.Name,
+			Commit: r.Repo.CommitSHA,
+			Path:   r.Repo.Path,
+		},
+		Scorecard: jsonScorecardV2{
+			Version: r.Scorecard.Version,
+			Commit:  r.Scorecard.CommitSHA,
+		},
+		Date:     r.Date.Format("2006-01-02"),
+		Findings: findings,
+	}
+
+	encoder := json.NewEncoder(writer)
+	if err := encoder.Encode(out); err != nil {
+		return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("encoder.Encode: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ossf/scorecard/v3/checker"
+)
+
+func TestProbeOutput(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		probes   []string
+		result   ScorecardResult
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:   "binary artifact finding",
+			probes: []string{"freeOfBinaryArtifacts"},
+			result: ScorecardResult{
+				Repo: RepoInfo{
+					Name:      "github.com/org/name",
+					CommitSHA: "68bc59901773ab4c051dfcea0cc4201a1567ab32",
+				},
+				RawResults: checker.RawResults{
+					BinaryArtifactResults: checker.BinaryArtifactData{
+						Files: []checker.File{{Path: "a.exe"}},
+					},
+				},
+			},
+			expected: `{"date":"0001-01-01","repo":{"name":"github.com/org/name",` +
+				`"commit":"68bc59901773ab4c051dfcea0cc4201a1567ab32"},"scorecard":{"version":"","commit":""},` +
+				`"findings":[{"id":"79da805242c3","probe":"freeOfBinaryArtifacts","outcome":"Negative",` +
+				`"message":"binary detected","location":"a.exe"}]}` + "\n",
+		},
+		{
+			name:   "suppressed finding",
+			probes: []string{"freeOfBinaryArtifacts"},
+			result: ScorecardResult{
+				Repo: RepoInfo{
+					Name:      "github.com/org/name",
+					CommitSHA: "68bc59901773ab4c051dfcea0cc4201a1567ab32",
+				},
+				RawResults: checker.RawResults{
+					BinaryArtifactResults: checker.BinaryArtifactData{
+						Files: []checker.File{{Path: "a.exe"}, {Path: "b.exe"}},
+					},
+				},
+				Suppressions: []string{"79da805242c3"},
+			},
+			expected: `{"date":"0001-01-01","repo":{"name":"github.com/org/name",` +
+				`"commit":"68bc59901773ab4c051dfcea0cc4201a1567ab32"},"scorecard":{"version":"","commit":""},` +
+				`"findings":[{"id":"79da805242c3","probe":"freeOfBinaryArtifacts","outcome":"Negative",` +
+				`"message":"binary detected","location":"a.exe","suppressed":true},` +
+				`{"id":"302211774eab","probe":"freeOfBinaryArtifacts","outcome":"Negative",` +
+				`"message":"binary detected","location":"b.exe"}]}` + "\n",
+		},
+		{
+			name:    "unknown probe",
+			probes:  []string{"doesNotExist"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			err := tt.result.AsProbe(tt.probes, &out)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("AsProbe: got err %v, wantErr %v", err, tt.wantErr)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("AsProbe() = %q, want %q", out.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+// CheckProgressFunc is called by RunScorecards as each check of repo completes.
+// It may be called concurrently.
+type CheckProgressFunc func(repo, checkName string)
+
+// WithCheckProgress reports each completed check to fn, e.g. to show the
+// progress of long scans.
+func WithCheckProgress(fn CheckProgressFunc) Option {
+	return func(o *runOptions) {
+		if fn != nil {
+			o.progress = fn
+		}
+	}
+}
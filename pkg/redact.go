@@ -0,0 +1,28 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import "github.com/ossf/scorecard/v3/checker"
+
+// Redact removes the details of the checks and the raw results, which may
+// reveal file paths, branch names and code snippets of the repo, so that
+// results of private repos can be shared. Scores and reasons are kept.
+func (r *ScorecardResult) Redact() {
+	for i := range r.Checks {
+		r.Checks[i].Details = nil
+		r.Checks[i].Details2 = nil
+	}
+	r.RawResults = checker.RawResults{}
+}
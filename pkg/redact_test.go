@@ -0,0 +1,71 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+	branch := "internal-release"
+	result := ScorecardResult{
+		Repo: RepoInfo{Name: "github.com/org/private"},
+		Checks: []checker.CheckResult{
+			{
+				Name:    "Binary-Artifacts",
+				Score:   9,
+				Reason:  "binaries present in source code",
+				Details: []string{"binary detected: secret/tool.exe"},
+				Details2: []checker.CheckDetail{
+					{
+						Type: checker.DetailWarn,
+						Msg:  checker.LogMessage{Text: "binary detected", Path: "secret/tool.exe", Snippet: "MZ"},
+					},
+				},
+			},
+			{
+				Name:   "Branch-Protection",
+				Score:  checker.InconclusiveResultScore,
+				Reason: "internal error",
+			},
+		},
+		RawResults: checker.RawResults{
+			BinaryArtifactResults: checker.BinaryArtifactData{
+				Files: []checker.File{{Path: "secret/tool.exe"}},
+			},
+			BranchProtectionResults: checker.BranchProtectionsData{
+				Branches: []clients.BranchRef{{Name: &branch}},
+			},
+		},
+	}
+	want := ScorecardResult{
+		Repo: RepoInfo{Name: "github.com/org/private"},
+		Checks: []checker.CheckResult{
+			{Name: "Binary-Artifacts", Score: 9, Reason: "binaries present in source code"},
+			{Name: "Branch-Protection", Score: checker.InconclusiveResultScore, Reason: "internal error"},
+		},
+	}
+
+	result.Redact()
+	if diff := cmp.Diff(want, result); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -65,6 +65,10 @@ type location struct {
 	// We may populate it later if we can indicate which config file
 	// line was violated by the failing check.
 	Message *text `json:"message,omitempty"`
+	// Remediation of the detail at this location, if any.
+	remediation *checker.Remediation
+	// FindingID of the detail at this location, if any.
+	findingID string
 }
 
 //nolint
@@ -76,6 +80,10 @@ type relatedLocation struct {
 
 type partialFingerprints map[string]string
 
+// findingFingerprint is the partial fingerprint holding the FindingID of a result,
+// so that result management systems can track it across runs.
+const findingFingerprint = "scorecardFindingId/v1"
+
 type defaultConfig struct {
 	// "none", "note", "warning", "error",
 	// https://github.com/oasis-tcs/sarif-spec/blob/master/Schemata/sarif-schema-2.1.0.json#L1566.
@@ -124,6 +132,7 @@ type tool struct {
 type result struct {
 	RuleID           string            `json:"ruleId"`
 	Level            string            `json:"level,omitempty"` // Optional.
+	Kind             string            `json:"kind,omitempty"`  // Optional, defaults to "fail".
 	RuleIndex        int               `json:"ruleIndex"`
 	Message          text              `json:"message"`
 	Locations        []location        `json:"locations,omitempty"`
@@ -132,6 +141,13 @@ type result struct {
 	// https://docs.oasis-open.org/sarif/sarif/v2.1.0/cs01/sarif-v2.1.0-cs01.html#_Toc16012457.
 	// Not supported by GitHub, but possibly useful.
 	PartialFingerprints partialFingerprints `json:"partialFingerprints,omitempty"`
+	Properties          *resultProperties   `json:"properties,omitempty"`
+}
+
+type resultProperties struct {
+	Remediations []jsonRemediation `json:"remediations,omitempty"`
+	// State of the check when it did not score the repo, see checker.ResultState.
+	State string `json:"state,omitempty"`
 }
 
 type automationDetails struct {
@@ -240,6 +256,10 @@ func detailToRegion(details *checker.CheckDetail) region {
 			StartLine: &line,
 			Snippet:   snippet,
 		}
+		if details.Msg.EndOffset > line {
+			endLine := details.Msg.EndOffset
+			reg.EndLine = &endLine
+		}
 	case checker.FileTypeText:
 		// Offset of 0 is acceptable here.
 		reg = region{
@@ -273,7 +293,7 @@ func shouldAddLocation(detail *checker.CheckDetail, showDetails bool,
 	}
 }
 
-func detailsToLocations(details []checker.CheckDetail,
+func detailsToLocations(checkName string, details []checker.CheckDetail,
 	showDetails bool, minScore, score int) []location {
 	locs := []location{}
 
@@ -300,7 +320,9 @@ func detailsToLocations(details []checker.CheckDetail,
 					URIBaseID: "%SRCROOT%",
 				},
 			},
-			Message: &text{Text: d.Msg.Text},
+			Message:     &text{Text: d.Msg.Text},
+			remediation: d.Msg.Remediation,
+			findingID:   FindingID(checkName, &d),
 		}
 
 		// Set the region depending on the file type.
@@ -436,6 +458,40 @@ func createSARIFCheckResult(pos int, checkID, message string, loc *location) res
 	}
 }
 
+// setResultState marks the result of a check which did not score the repo,
+// because it was inconclusive or failed to run, as "open": the check could
+// not determine whether the policy is violated. Its level then defaults to "none".
+func setResultState(cr *result, state checker.ResultState) {
+	if state == checker.ResultScored {
+		return
+	}
+	cr.Kind = "open"
+	if cr.Properties == nil {
+		cr.Properties = &resultProperties{}
+	}
+	cr.Properties.State = string(state)
+}
+
+// detailsToRemediations returns the remediations of the warnings
+// which have no location, e.g., repo settings.
+func detailsToRemediations(details []checker.CheckDetail, showDetails bool) *resultProperties {
+	if !showDetails {
+		return nil
+	}
+	var ret []jsonRemediation
+	for i := range details {
+		d := details[i]
+		if d.Type != checker.DetailWarn || d.Msg.Remediation == nil {
+			continue
+		}
+		ret = append(ret, remediationToJSON(d.Msg.Text, d.Msg.Remediation))
+	}
+	if len(ret) == 0 {
+		return nil
+	}
+	return &resultProperties{Remediations: ret}
+}
+
 func getCheckPolicyInfo(policy *spol.ScorecardPolicy, name string) (minScore int, enabled bool, err error) {
 	policies := policy.GetPolicies()
 	if _, exists := policies[name]; !exists {
@@ -552,12 +608,13 @@ func (r *ScorecardResult) AsSARIF(showDetails bool, logLevel zapcore.Level,
 			continue
 		}
 
-		// Skip check that do not violate the policy.
-		if check.Score >= minScore {
+		// Skip check that do not violate the policy or do not apply to the repo.
+		state := check.State()
+		if check.Score >= minScore || state == checker.ResultNotApplicable {
 			continue
 		}
 
-		// Unclear what to use for PartialFingerprints.
+		// PartialFingerprints are set to the FindingID of the details, which leaves out line numbers.
 		// GitHub only uses `primaryLocationLineHash`, which is not properly defined
 		// and Appendix B of https://docs.oasis-open.org/sarif/sarif/v2.1.0/cs01/sarif-v2.1.0-cs01.html
 		// warns about using line number for fingerprints:
@@ -567,7 +624,7 @@ func (r *ScorecardResult) AsSARIF(showDetails bool, logLevel zapcore.Level,
 		// would change, and the result management system would erroneously report it as a new result."
 
 		// Create locations.
-		locs := detailsToLocations(check.Details2, showDetails, minScore, check.Score)
+		locs := detailsToLocations(check.Name, check.Details2, showDetails, minScore, check.Score)
 
 		// Add default location if no locations are present.
 		// Note: GitHub needs at least one location to show the results.
@@ -578,11 +635,20 @@ func (r *ScorecardResult) AsSARIF(showDetails bool, logLevel zapcore.Level,
 			locs = addDefaultLocation(locs, "no file available")
 			// Use the `reason` as message.
 			cr := createSARIFCheckResult(RuleIndex, sarifCheckID, check.Reason, &locs[0])
+			cr.Properties = detailsToRemediations(check.Details2, showDetails)
+			setResultState(&cr, state)
 			run.Results = append(run.Results, cr)
 		} else {
 			for _, loc := range locs {
 				// Use the location's message (check's detail's message) as message.
 				cr := createSARIFCheckResult(RuleIndex, sarifCheckID, loc.Message.Text, &loc)
+				cr.PartialFingerprints = partialFingerprints{findingFingerprint: loc.findingID}
+				if loc.remediation != nil {
+					cr.Properties = &resultProperties{
+						Remediations: []jsonRemediation{remediationToJSON(loc.Message.Text, loc.remediation)},
+					}
+				}
+				setResultState(&cr, state)
 				run.Results = append(run.Results, cr)
 			}
 		}
@@ -16,14 +16,17 @@ package pkg
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"go.uber.org/zap/zapcore"
 
 	"github.com/ossf/scorecard/v3/checker"
+	sce "github.com/ossf/scorecard/v3/errors"
 	spol "github.com/ossf/scorecard/v3/policy"
 )
 
@@ -802,3 +805,155 @@ func TestSARIFOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestDetailsToRemediations(t *testing.T) {
+	t.Parallel()
+	rem := &checker.Remediation{
+		Text:   "Enable 'Include administrators' for branch 'main'",
+		Effort: checker.RemediationEffortLow,
+	}
+	details := []checker.CheckDetail{
+		{Type: checker.DetailInfo, Msg: checker.LogMessage{Text: "info message", Remediation: rem}},
+		{Type: checker.DetailWarn, Msg: checker.LogMessage{Text: "warn without remediation"}},
+		{Type: checker.DetailWarn, Msg: checker.LogMessage{Text: "warn message", Remediation: rem}},
+	}
+	tests := []struct {
+		name        string
+		showDetails bool
+		expected    *resultProperties
+	}{
+		{
+			name:        "only warnings with remediations",
+			showDetails: true,
+			expected: &resultProperties{
+				Remediations: []jsonRemediation{
+					{
+						Detail: "warn message",
+						Text:   "Enable 'Include administrators' for branch 'main'",
+						Effort: "Low",
+					},
+				},
+			},
+		},
+		{
+			name:        "details not shown",
+			showDetails: false,
+			expected:    nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := detailsToRemediations(details, tt.showDetails)
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSARIFResultStates(t *testing.T) {
+	t.Parallel()
+	enforced := func() *spol.CheckPolicy {
+		return &spol.CheckPolicy{Score: checker.MaxResultScore, Mode: spol.CheckPolicy_ENFORCED}
+	}
+	policy := spol.ScorecardPolicy{
+		Version: 1,
+		Policies: map[string]*spol.CheckPolicy{
+			"Check-Name":  enforced(),
+			"Check-Name2": enforced(),
+			"Check-Name3": enforced(),
+		},
+	}
+	result := ScorecardResult{
+		Checks: []checker.CheckResult{
+			checker.CreateRuntimeErrorResult("Check-Name",
+				sce.WithMessage(sce.ErrScorecardInternal, "API unreachable")),
+			checker.CreateNotApplicableResult("Check-Name2", "not applicable to private repos"),
+			checker.CreateInconclusiveResult("Check-Name3", "no releases found"),
+		},
+	}
+	var buf bytes.Buffer
+	if err := result.AsSARIF(false, zapcore.InfoLevel, &buf, sarifMockDocRead(), &policy); err != nil {
+		t.Fatalf("AsSARIF: %v", err)
+	}
+	var got sarif210
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	states := map[string]string{}
+	for _, r := range got.Runs {
+		for _, res := range r.Results {
+			if res.Kind != "open" || res.Properties == nil {
+				t.Errorf("result %s: kind %q, properties %v, want open with a state", res.RuleID, res.Kind, res.Properties)
+				continue
+			}
+			states[res.RuleID] = res.Properties.State
+		}
+	}
+	want := map[string]string{
+		"CheckNameID":  string(checker.ResultRuntimeError),
+		"CheckName3ID": string(checker.ResultInconclusive),
+	}
+	if diff := cmp.Diff(want, states); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDetailToRegion(t *testing.T) {
+	t.Parallel()
+	line := 5
+	endLine := 7
+	tests := []struct {
+		name     string
+		msg      checker.LogMessage
+		expected region
+	}{
+		{
+			name: "single line",
+			msg: checker.LogMessage{
+				Type:    checker.FileTypeSource,
+				Offset:  5,
+				Snippet: "curl -s bla | bash",
+			},
+			expected: region{
+				StartLine: &line,
+				Snippet:   &text{Text: "curl -s bla | bash"},
+			},
+		},
+		{
+			name: "end line same as start line",
+			msg: checker.LogMessage{
+				Type:      checker.FileTypeSource,
+				Offset:    5,
+				EndOffset: 5,
+			},
+			expected: region{
+				StartLine: &line,
+			},
+		},
+		{
+			name: "multiple lines",
+			msg: checker.LogMessage{
+				Type:      checker.FileTypeSource,
+				Offset:    5,
+				EndOffset: 7,
+			},
+			expected: region{
+				StartLine: &line,
+				EndLine:   &endLine,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := detailToRegion(&checker.CheckDetail{Msg: tt.msg})
+			if diff := cmp.Diff(tt.expected, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
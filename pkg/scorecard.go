@@ -13,6 +13,12 @@
 // limitations under the License.
 
 // Package pkg defines fns for running Scorecard checks on a Repo.
+//
+// RunScorecards is the entrypoint for Go programs embedding Scorecard: it
+// runs checks on a repo and returns a ScorecardResult, which the As* methods
+// format like the CLI does. RunScorecards and the exported fields of
+// ScorecardResult follow the semantic versioning of the module: they only
+// change incompatibly in a new major version. See ExampleRunScorecards.
 package pkg
 
 import (
@@ -22,39 +28,93 @@ import (
 	"sync"
 	"time"
 
+	"go.opencensus.io/trace"
+
 	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks"
 	"github.com/ossf/scorecard/v3/clients"
+	"github.com/ossf/scorecard/v3/clients/githubrepo/roundtripper"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
 
 func runEnabledChecks(ctx context.Context,
 	repo clients.Repo, raw *checker.RawResults, checksToRun checker.CheckNameToFnMap,
 	repoClient clients.RepoClient, ossFuzzRepoClient clients.RepoClient, ciiClient clients.CIIBestPracticesClient,
-	resultsCh chan checker.CheckResult) {
+	vulnsClient clients.VulnerabilitiesClient, packagesClient clients.PackagesClient,
+	capabilities map[string]CapabilityMode, opts *runOptions, resultsCh chan checker.CheckResult) {
 	request := checker.CheckRequest{
-		Ctx:         ctx,
-		RepoClient:  repoClient,
-		OssFuzzRepo: ossFuzzRepoClient,
-		CIIClient:   ciiClient,
-		Repo:        repo,
-		RawResults:  raw,
+		Ctx:                       ctx,
+		RepoClient:                repoClient,
+		OssFuzzRepo:               ossFuzzRepoClient,
+		CIIClient:                 ciiClient,
+		VulnerabilitiesClient:     vulnsClient,
+		PackagesClient:            packagesClient,
+		Repo:                      repo,
+		RawResults:                raw,
+		RequiredStatusChecks:      opts.requiredStatusChecks,
+		DependencyVulnerabilities: opts.dependencyVulnerabilities,
 	}
+	if reporter, ok := repoClient.(clients.UpstreamReporter); ok {
+		request.Upstream = reporter.Upstream()
+	}
+	subPath := opts.subPath
 	wg := sync.WaitGroup{}
+	// running counts the checks still running, including those abandoned at
+	// their timeout, which must return before the clients are closed.
+	running := sync.WaitGroup{}
+	mu := sync.Mutex{}
 	for checkName, checkFn := range checksToRun {
 		checkName := checkName
 		checkFn := checkFn
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			// Each check counts its own API calls.
+			apiCalls := roundtripper.NewAPICallCounter(0)
+			checkCtx := roundtripper.WithAPICallCounter(ctx, apiCalls)
+			checkRepoClient := clients.WithCallContext(checkCtx, repoClient)
+			// Each check gets its own recorder so that unsupported
+			// APIs are attributed to the check which used them.
+			recorder := &capabilityRecorder{RepoClient: checkRepoClient}
+			scoped := subPath != "" && checks.SupportsSubPath(checkName)
+			if scoped {
+				recorder.RepoClient = &subPathClient{RepoClient: checkRepoClient, dir: subPath}
+			}
+			checkRequest := request
+			checkRequest.RepoClient = recorder
+			if raw != nil {
+				// A check abandoned at its timeout may still be filling
+				// its raw results, so they are only merged if it completed.
+				checkRequest.RawResults = &checker.RawResults{}
+			}
+			if ossFuzzRepoClient != nil {
+				checkRequest.OssFuzzRepo = clients.WithCallContext(checkCtx, ossFuzzRepoClient)
+			}
 			runner := checker.Runner{
 				Repo:         repo.URI(),
 				CheckName:    checkName,
-				CheckRequest: request,
+				CheckRequest: checkRequest,
+				Timeout:      opts.checkTimeout,
+				Running:      &running,
 			}
-			resultsCh <- runner.Run(ctx, checkFn)
+			result := runner.Run(checkCtx, checkFn)
+			result.APICalls = apiCalls.Calls()
+			if scoped {
+				fileDetailsRelativeToRepo(subPath, &result)
+			}
+
+			mu.Lock()
+			capabilities[checkName] = recorder.mode(&result)
+			if raw != nil && result.Error2 == nil {
+				raw.Merge(checkRequest.RawResults)
+			}
+			mu.Unlock()
+			opts.progress(repo.URI(), checkName)
+			resultsCh <- result
 		}()
 	}
 	wg.Wait()
+	running.Wait()
 	close(resultsCh)
 }
 
@@ -71,46 +131,144 @@ func getRepoCommitHash(r clients.RepoClient) (string, error) {
 	return "no commits found", nil
 }
 
-// RunScorecards runs enabled Scorecard checks on a Repo.
+// RunScorecards runs checksToRun on repo at commitSHA, which may be
+// clients.HeadSHA for the latest commit of the default branch, and returns
+// their results. checks.AllChecks contains all checks.
+//
+// repoClient must be able to access repo; it is initialized by RunScorecards
+// and closed before it returns, once all checks returned. A check which timed
+// out returns at its next call of repoClient, which then fails, so repoClient
+// should be a clients.CallContextClient to also interrupt the calls in flight.
+// The other clients are optional: checks needing a nil client are
+// inconclusive or ignore the data it provides.
+//
+// With raw set, checks which support it fill ScorecardResult.RawResults
+// instead of scoring. Errors of individual checks are reported in their
+// CheckResult; an error is only returned if the repo cannot be accessed.
+// opts configure the run, e.g. WithCheckTimeout or WithSubPath.
 func RunScorecards(ctx context.Context,
 	repo clients.Repo,
+	commitSHA string,
 	raw bool,
 	checksToRun checker.CheckNameToFnMap,
 	repoClient clients.RepoClient,
 	ossFuzzRepoClient clients.RepoClient,
-	ciiClient clients.CIIBestPracticesClient) (ScorecardResult, error) {
-	if err := repoClient.InitRepo(repo); err != nil {
+	ciiClient clients.CIIBestPracticesClient,
+	vulnsClient clients.VulnerabilitiesClient,
+	packagesClient clients.PackagesClient,
+	opts ...Option) (ScorecardResult, error) {
+	options := newRunOptions(opts)
+	ctx, span := trace.StartSpan(ctx, "RunScorecards")
+	defer span.End()
+	span.AddAttributes(trace.StringAttribute("repo", repo.URI()))
+	start := time.Now()
+	apiCalls := roundtripper.NewAPICallCounter(options.apiCallBudget)
+	ctx = roundtripper.WithAPICallCounter(ctx, apiCalls)
+
+	if err := clients.WithCallContext(ctx, repoClient).InitRepo(repo, commitSHA); err != nil {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
 		// No need to call sce.WithMessage() since InitRepo will do that for us.
 		//nolint:wrapcheck
 		return ScorecardResult{}, err
 	}
 	defer repoClient.Close()
+	// The API calls made outside of checks count against the repo too.
+	scanRepoClient := clients.WithCallContext(ctx, repoClient)
+
+	repoCommitSHA, err := getRepoCommitHash(scanRepoClient)
+	if err != nil {
+		return ScorecardResult{}, err
+	}
 
-	commitSHA, err := getRepoCommitHash(repoClient)
+	subPath, err := cleanSubPath(options.subPath)
 	if err != nil {
 		return ScorecardResult{}, err
 	}
+	options.subPath = subPath
+	if subPath != "" {
+		exists, err := subPathExists(scanRepoClient, subPath)
+		if err != nil {
+			return ScorecardResult{}, err
+		}
+		if !exists {
+			return ScorecardResult{}, sce.WithMessage(sce.ErrorInvalidURL,
+				fmt.Sprintf("path %s has no files in the repo", subPath))
+		}
+	}
+
+	// Checks needing features the repo does not support would only fail
+	// with internal errors, so they are not run.
+	unsupported := unsupportedChecks(repoClient, checksToRun)
+	if len(unsupported) > 0 && options.failOnUnsupported {
+		return ScorecardResult{}, preflightError(unsupported)
+	}
 
 	ret := ScorecardResult{
 		Repo: RepoInfo{
 			Name:      repo.URI(),
-			CommitSHA: commitSHA,
+			CommitSHA: repoCommitSHA,
+			Path:      subPath,
 		},
 		Scorecard: ScorecardInfo{
 			Version:   GetSemanticVersion(),
 			CommitSHA: GetCommit(),
 		},
-		Date: time.Now(),
+		Date: clients.ScanTime(ctx),
+		Capabilities: CapabilityMatrix{
+			Checks:  make(map[string]CapabilityMode),
+			Reasons: make(map[string]string),
+		},
+	}
+	if len(unsupported) > 0 {
+		supported := checker.CheckNameToFnMap{}
+		for checkName, fn := range checksToRun {
+			if reason, ok := unsupported[checkName]; ok {
+				ret.Capabilities.Checks[checkName] = CapabilityUnsupported
+				ret.Capabilities.Reasons[checkName] = reason
+				options.progress(repo.URI(), checkName)
+				continue
+			}
+			supported[checkName] = fn
+		}
+		checksToRun = supported
 	}
+
 	resultsCh := make(chan checker.CheckResult)
 	if raw {
-		go runEnabledChecks(ctx, repo, &ret.RawResults, checksToRun, repoClient, ossFuzzRepoClient, ciiClient, resultsCh)
+		go runEnabledChecks(ctx, repo, &ret.RawResults, checksToRun, repoClient, ossFuzzRepoClient, ciiClient,
+			vulnsClient, packagesClient, ret.Capabilities.Checks, &options, resultsCh)
 	} else {
-		go runEnabledChecks(ctx, repo, nil, checksToRun, repoClient, ossFuzzRepoClient, ciiClient, resultsCh)
+		go runEnabledChecks(ctx, repo, nil, checksToRun, repoClient, ossFuzzRepoClient, ciiClient,
+			vulnsClient, packagesClient, ret.Capabilities.Checks, &options, resultsCh)
 	}
 
 	for result := range resultsCh {
 		ret.Checks = append(ret.Checks, result)
 	}
+
+	// A malformed file must not fail the run, since any repo can have one.
+	annotations, err := readMaintainerAnnotations(scanRepoClient)
+	if err != nil {
+		ret.Metadata = append(ret.Metadata, fmt.Sprintf("ignored %s: %v", MaintainerAnnotationsFile, err))
+	}
+	// Attached for display only: excluding them is up to the caller, see RepoAnnotations.
+	ret.RepoAnnotations = annotations
+	ret.applyAnnotations(annotations, false)
+
+	if reporter, ok := repoClient.(clients.TokenPermissionsReporter); ok {
+		if err := ret.applyTokenPermissions(reporter.TokenPermissions()); err != nil {
+			return ScorecardResult{}, err
+		}
+	}
+	if reporter, ok := repoClient.(clients.VisibilityReporter); ok && reporter.IsPrivate() {
+		if err := ret.ApplyPrivateRepo(); err != nil {
+			return ScorecardResult{}, err
+		}
+	}
+	ret.Stats = ScanStats{
+		APICalls:              apiCalls.Calls(),
+		Duration:              time.Since(start),
+		APICallBudgetExceeded: apiCalls.Exceeded(),
+	}
 	return ret, nil
 }
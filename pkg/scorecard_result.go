@@ -38,16 +38,31 @@ type ScorecardInfo struct {
 type RepoInfo struct {
 	Name      string
 	CommitSHA string
+	// Private is set for private repos, see ApplyPrivateRepo.
+	Private bool
+	// Path is the directory the file-based checks were restricted to, if any, see WithSubPath.
+	Path string
 }
 
 // ScorecardResult struct is returned on a successful Scorecard run.
 type ScorecardResult struct {
-	Repo       RepoInfo
-	Date       time.Time
-	Scorecard  ScorecardInfo
-	Checks     []checker.CheckResult
-	RawResults checker.RawResults
-	Metadata   []string
+	Repo         RepoInfo
+	Date         time.Time
+	Scorecard    ScorecardInfo
+	Checks       []checker.CheckResult
+	RawResults   checker.RawResults
+	Metadata     []string
+	Capabilities CapabilityMatrix
+	// Dependents is set by ApplyDependents.
+	Dependents *Dependents
+	// RepoAnnotations are read from the repo's MaintainerAnnotationsFile. They are
+	// attached to the warnings they match, which callers trusting the repo can
+	// exclude with ApplyAnnotations.
+	RepoAnnotations []Annotation
+	// Suppressions is set by SuppressFindings.
+	Suppressions []string
+	// Stats is the cost of the scan, see also the APICalls and Duration of each check.
+	Stats ScanStats
 }
 
 func scoreToString(s float64) string {
@@ -129,7 +144,7 @@ func (r *ScorecardResult) AsString(showDetails bool, logLevel zapcore.Level,
 		x[1] = row.Name
 		x[2] = row.Reason
 		if showDetails {
-			details, show := detailsToString(row.Details2, logLevel)
+			details, show := detailsToString(row.Name, row.Details2, logLevel)
 			if show {
 				x[3] = details
 			}
@@ -0,0 +1,149 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// RunScorecards is part of the stable API of the module,
+// its signature must not change within a major version.
+var _ func(context.Context, clients.Repo, string, bool, checker.CheckNameToFnMap,
+	clients.RepoClient, clients.RepoClient, clients.CIIBestPracticesClient,
+	clients.VulnerabilitiesClient, clients.PackagesClient, ...Option) (ScorecardResult, error) = RunScorecards
+
+func TestRunScorecardsCheckTimeout(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	repo := mockrepo.NewMockRepo(ctrl)
+	repo.EXPECT().URI().Return("github.com/ossf/scorecard").AnyTimes()
+	repoClient := mockrepo.NewMockRepoClient(ctrl)
+	repoClient.EXPECT().InitRepo(repo, clients.HeadSHA).Return(nil)
+	repoClient.EXPECT().ListCommits().Return([]clients.Commit{{SHA: "sha"}}, nil)
+	repoClient.EXPECT().Close().Return(nil)
+	repoClient.EXPECT().IsArchived().Return(false, nil)
+	// The repo has no .scorecard.yml.
+	repoClient.EXPECT().ListFiles(gomock.Any()).Return(nil, nil)
+
+	checksToRun := checker.CheckNameToFnMap{
+		// Hung only returns after its timeout, like a check whose API call
+		// in flight is interrupted, and still fills its raw results.
+		"Hung": func(c *checker.CheckRequest) checker.CheckResult {
+			<-c.Ctx.Done()
+			time.Sleep(50 * time.Millisecond)
+			c.RawResults.BinaryArtifactResults.Files = []checker.File{{Path: "hung"}}
+			return checker.CreateMaxScoreResult("Hung", "done")
+		},
+		"Cancelled": func(c *checker.CheckRequest) checker.CheckResult {
+			<-c.Ctx.Done()
+			if _, err := c.RepoClient.ListReleases(); err != nil {
+				return checker.CreateRuntimeErrorResult("Cancelled", err)
+			}
+			return checker.CreateMaxScoreResult("Cancelled", "done")
+		},
+		"Fast": func(c *checker.CheckRequest) checker.CheckResult {
+			if _, err := c.RepoClient.IsArchived(); err != nil {
+				return checker.CreateRuntimeErrorResult("Fast", err)
+			}
+			c.RawResults.SecurityPolicyResults.Files = []checker.SecurityPolicyFile{{File: checker.File{Path: "fast"}}}
+			return checker.CreateMaxScoreResult("Fast", "done")
+		},
+	}
+
+	result, err := RunScorecards(context.Background(), repo, clients.HeadSHA, true, checksToRun,
+		repoClient, nil, nil, nil, nil, WithCheckTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("RunScorecards: %v", err)
+	}
+	sort.Slice(result.Checks, func(i, j int) bool {
+		return result.Checks[i].Name < result.Checks[j].Name
+	})
+	if len(result.Checks) != 3 {
+		t.Fatalf("got %d checks, want 3", len(result.Checks))
+	}
+	for _, check := range result.Checks {
+		switch check.Name {
+		case "Fast":
+			if check.Error2 != nil || check.Score != checker.MaxResultScore {
+				t.Errorf("%s: got score %d, error %v, want max score", check.Name, check.Score, check.Error2)
+			}
+		default:
+			if !errors.Is(check.Error2, sce.ErrScorecardInternal) ||
+				check.Score != checker.InconclusiveResultScore {
+				t.Errorf("%s: got score %d, error %v, want a runtime error", check.Name, check.Score, check.Error2)
+			}
+		}
+	}
+	// Only the raw results of the checks which completed are kept.
+	if len(result.RawResults.BinaryArtifactResults.Files) != 0 {
+		t.Errorf("got the raw results of a check which timed out")
+	}
+	if len(result.RawResults.SecurityPolicyResults.Files) != 1 {
+		t.Errorf("got %d security policy files, want 1", len(result.RawResults.SecurityPolicyResults.Files))
+	}
+}
+
+func TestRunScorecardsProgress(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	repo := mockrepo.NewMockRepo(ctrl)
+	repo.EXPECT().URI().Return("github.com/ossf/scorecard").AnyTimes()
+	repoClient := mockrepo.NewMockRepoClient(ctrl)
+	repoClient.EXPECT().InitRepo(repo, clients.HeadSHA).Return(nil)
+	repoClient.EXPECT().ListCommits().Return([]clients.Commit{{SHA: "sha"}}, nil)
+	repoClient.EXPECT().Close().Return(nil)
+	repoClient.EXPECT().ListFiles(gomock.Any()).Return(nil, nil)
+
+	checksToRun := checker.CheckNameToFnMap{}
+	for _, name := range []string{"Check-A", "Check-B", "Check-C"} {
+		name := name
+		checksToRun[name] = func(c *checker.CheckRequest) checker.CheckResult {
+			return checker.CreateMaxScoreResult(name, "done")
+		}
+	}
+	var mu sync.Mutex
+	var completed []string
+	progress := WithCheckProgress(func(repo, checkName string) {
+		mu.Lock()
+		defer mu.Unlock()
+		completed = append(completed, repo+" "+checkName)
+	})
+	if _, err := RunScorecards(context.Background(), repo, clients.HeadSHA, false, checksToRun,
+		repoClient, nil, nil, nil, nil, progress); err != nil {
+		t.Fatalf("RunScorecards: %v", err)
+	}
+	sort.Strings(completed)
+	want := []string{
+		"github.com/ossf/scorecard Check-A",
+		"github.com/ossf/scorecard Check-B",
+		"github.com/ossf/scorecard Check-C",
+	}
+	if diff := cmp.Diff(want, completed); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// WithSubPath restricts the checks analyzing the files of the code, see
+// checks.SupportsSubPath, to the directory subPath of the repo, e.g. a
+// component of a monorepo. The other checks still apply to the whole repo.
+func WithSubPath(subPath string) Option {
+	return func(o *runOptions) {
+		o.subPath = subPath
+	}
+}
+
+// cleanSubPath returns subPath relative to the root of the repo, or an
+// error if it is outside of the repo.
+func cleanSubPath(subPath string) (string, error) {
+	dir := path.Clean(strings.TrimPrefix(subPath, "./"))
+	if dir == "." {
+		return "", nil
+	}
+	if path.IsAbs(dir) || dir == ".." || strings.HasPrefix(dir, "../") {
+		return "", sce.WithMessage(sce.ErrorInvalidURL, fmt.Sprintf("path %s is outside of the repo", subPath))
+	}
+	return dir, nil
+}
+
+// workflowsDir holds the GitHub workflows, which only run from the root of the repo.
+const workflowsDir = ".github/workflows/"
+
+// subPathClient exposes the files under dir as if dir was the root of the repo.
+// The workflows of the repo stay at its root: they run for every directory,
+// while those under dir never run.
+type subPathClient struct {
+	clients.RepoClient
+	dir string
+}
+
+// ListFiles implements RepoClient.ListFiles.
+func (c *subPathClient) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
+	prefix := c.dir + "/"
+	files, err := c.RepoClient.ListFiles(func(f string) (bool, error) {
+		switch {
+		case strings.HasPrefix(f, workflowsDir):
+			return predicate(f)
+		case strings.HasPrefix(f, prefix+workflowsDir), !strings.HasPrefix(f, prefix):
+			return false, nil
+		default:
+			return predicate(strings.TrimPrefix(f, prefix))
+		}
+	})
+	if err != nil {
+		//nolint:wrapcheck
+		return nil, err
+	}
+	ret := make([]string, 0, len(files))
+	for _, f := range files {
+		ret = append(ret, strings.TrimPrefix(f, prefix))
+	}
+	return ret, nil
+}
+
+// GetFileContent implements RepoClient.GetFileContent.
+func (c *subPathClient) GetFileContent(filename string) ([]byte, error) {
+	if strings.HasPrefix(filename, workflowsDir) {
+		//nolint:wrapcheck
+		return c.RepoClient.GetFileContent(filename)
+	}
+	//nolint:wrapcheck
+	return c.RepoClient.GetFileContent(path.Join(c.dir, filename))
+}
+
+// subPathExists reports whether the directory dir of the repo has files.
+func subPathExists(repoClient clients.RepoClient, dir string) (bool, error) {
+	files, err := repoClient.ListFiles(func(f string) (bool, error) {
+		return strings.HasPrefix(f, dir+"/"), nil
+	})
+	if errors.Is(err, clients.ErrUnsupportedFeature) {
+		// The checks restricted to dir cannot run anyway.
+		return true, nil
+	}
+	if err != nil {
+		return false, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("ListFiles: %v", err))
+	}
+	return len(files) > 0, nil
+}
+
+// fileDetailsRelativeToRepo makes the paths of the files of the details of
+// a check run on dir, with a subPathClient, relative to the root of the repo.
+// Workflows already are.
+func fileDetailsRelativeToRepo(dir string, result *checker.CheckResult) {
+	for i := range result.Details2 {
+		msg := &result.Details2[i].Msg
+		if msg.Path == "" || msg.Type == checker.FileTypeNone || msg.Type == checker.FileTypeURL ||
+			strings.HasPrefix(msg.Path, workflowsDir) {
+			continue
+		}
+		msg.Path = path.Join(dir, msg.Path)
+	}
+}
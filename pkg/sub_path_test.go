@@ -0,0 +1,249 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pkg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks"
+	"github.com/ossf/scorecard/v3/clients"
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+var errNoSuchFile = errors.New("no such file")
+
+func TestCleanSubPath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		err     error
+		subPath string
+		want    string
+	}{
+		{subPath: "services/payments", want: "services/payments"},
+		{subPath: "./services/payments/", want: "services/payments"},
+		{subPath: "services/../payments", want: "payments"},
+		{subPath: ".", want: ""},
+		{subPath: "../payments", err: sce.ErrorInvalidURL},
+		{subPath: "/services", err: sce.ErrorInvalidURL},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.subPath, func(t *testing.T) {
+			t.Parallel()
+			got, err := cleanSubPath(tt.subPath)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("cleanSubPath error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("cleanSubPath = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunScorecardsSubPath(t *testing.T) {
+	t.Parallel()
+	files := []string{"README.md", "services/payments/SECURITY.md", "services/payments/main.go", "services/other/main.go"}
+	tests := []struct {
+		err         error
+		name        string
+		subPath     string
+		wantScoped  []string
+		wantOther   []string
+		wantDetails []string
+	}{
+		{
+			name:        "sub path",
+			subPath:     "services/payments",
+			wantScoped:  []string{"SECURITY.md", "main.go"},
+			wantOther:   files,
+			wantDetails: []string{"services/payments/SECURITY.md", "https://example.com"},
+		},
+		{
+			name:        "whole repo",
+			wantScoped:  files,
+			wantOther:   files,
+			wantDetails: []string{"README.md", "https://example.com"},
+		},
+		{
+			name:    "missing sub path",
+			subPath: "services/missing",
+			err:     sce.ErrorInvalidURL,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			repo := mockrepo.NewMockRepo(ctrl)
+			repo.EXPECT().URI().Return("github.com/org/mono").AnyTimes()
+			repoClient := mockrepo.NewMockRepoClient(ctrl)
+			repoClient.EXPECT().InitRepo(repo, clients.HeadSHA).Return(nil)
+			repoClient.EXPECT().ListCommits().Return([]clients.Commit{{SHA: "sha"}}, nil)
+			repoClient.EXPECT().Close().Return(nil)
+			repoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+				func(predicate func(string) (bool, error)) ([]string, error) {
+					var ret []string
+					for _, f := range files {
+						if ok, _ := predicate(f); ok {
+							ret = append(ret, f)
+						}
+					}
+					return ret, nil
+				}).AnyTimes()
+			repoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(
+				func(f string) ([]byte, error) {
+					for _, file := range files {
+						if f == file {
+							return []byte(f), nil
+						}
+					}
+					return nil, errNoSuchFile
+				}).AnyTimes()
+
+			var scoped, other []string
+			listFiles := func(c *checker.CheckRequest, name string, seen *[]string) checker.CheckResult {
+				var err error
+				*seen, err = c.RepoClient.ListFiles(func(string) (bool, error) { return true, nil })
+				if err != nil {
+					return checker.CreateRuntimeErrorResult(name, err)
+				}
+				if _, err := c.RepoClient.GetFileContent((*seen)[0]); err != nil {
+					return checker.CreateRuntimeErrorResult(name, err)
+				}
+				c.Dlogger.Warn3(&checker.LogMessage{Path: (*seen)[0], Type: checker.FileTypeSource, Text: "file"})
+				return checker.CreateMaxScoreResult(name, "done")
+			}
+			checksToRun := checker.CheckNameToFnMap{
+				checks.CheckSecurityPolicy: func(c *checker.CheckRequest) checker.CheckResult {
+					res := listFiles(c, checks.CheckSecurityPolicy, &scoped)
+					c.Dlogger.Info3(&checker.LogMessage{Path: "https://example.com", Type: checker.FileTypeURL, Text: "url"})
+					return res
+				},
+				"Other": func(c *checker.CheckRequest) checker.CheckResult {
+					return listFiles(c, "Other", &other)
+				},
+			}
+
+			result, err := RunScorecards(context.Background(), repo, clients.HeadSHA, false, checksToRun,
+				repoClient, nil, nil, nil, nil, WithSubPath(tt.subPath))
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("RunScorecards error = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if result.Repo.Path != tt.subPath {
+				t.Errorf("Repo.Path = %q, want %q", result.Repo.Path, tt.subPath)
+			}
+			if diff := cmp.Diff(tt.wantScoped, scoped); diff != "" {
+				t.Errorf("files of %s mismatch (-want +got):\n%s", checks.CheckSecurityPolicy, diff)
+			}
+			if diff := cmp.Diff(tt.wantOther, other); diff != "" {
+				t.Errorf("files of Other mismatch (-want +got):\n%s", diff)
+			}
+			for _, check := range result.Checks {
+				if check.Name != checks.CheckSecurityPolicy {
+					continue
+				}
+				var paths []string
+				for _, d := range check.Details2 {
+					paths = append(paths, d.Msg.Path)
+				}
+				if diff := cmp.Diff(tt.wantDetails, paths); diff != "" {
+					t.Errorf("detail paths mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestRunScorecardsSubPathWorkflows(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		".github/workflows/ci.yml": `on: push
+permissions: write-all
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`,
+		// GitHub never runs the workflows of a sub directory.
+		"services/payments/.github/workflows/ci.yml": `on: push
+permissions: read-all
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`,
+		"services/payments/main.go": "package main",
+	}
+	ctrl := gomock.NewController(t)
+	repo := mockrepo.NewMockRepo(ctrl)
+	repo.EXPECT().URI().Return("github.com/org/mono").AnyTimes()
+	repoClient := mockrepo.NewMockRepoClient(ctrl)
+	repoClient.EXPECT().InitRepo(repo, clients.HeadSHA).Return(nil)
+	repoClient.EXPECT().ListCommits().Return([]clients.Commit{{SHA: "sha"}}, nil)
+	repoClient.EXPECT().Close().Return(nil)
+	repoClient.EXPECT().ListFiles(gomock.Any()).DoAndReturn(
+		func(predicate func(string) (bool, error)) ([]string, error) {
+			var ret []string
+			for f := range files {
+				if ok, _ := predicate(f); ok {
+					ret = append(ret, f)
+				}
+			}
+			return ret, nil
+		}).AnyTimes()
+	repoClient.EXPECT().GetFileContent(gomock.Any()).DoAndReturn(
+		func(f string) ([]byte, error) {
+			content, ok := files[f]
+			if !ok {
+				return nil, errNoSuchFile
+			}
+			return []byte(content), nil
+		}).AnyTimes()
+
+	checksToRun := checker.CheckNameToFnMap{
+		checks.CheckTokenPermissions: checks.AllChecks[checks.CheckTokenPermissions],
+	}
+	result, err := RunScorecards(context.Background(), repo, clients.HeadSHA, false, checksToRun,
+		repoClient, nil, nil, nil, nil, WithSubPath("services/payments"))
+	if err != nil {
+		t.Fatalf("RunScorecards: %v", err)
+	}
+	if len(result.Checks) != 1 {
+		t.Fatalf("got %d checks, want 1", len(result.Checks))
+	}
+	check := result.Checks[0]
+	if check.Score == checker.MaxResultScore {
+		t.Errorf("score = %d, want the write permissions of the root workflow to lower it", check.Score)
+	}
+	for _, d := range check.Details2 {
+		if d.Msg.Path != "" && d.Msg.Path != ".github/workflows/ci.yml" {
+			t.Errorf("detail on %s, want only the root workflow", d.Msg.Path)
+		}
+	}
+}
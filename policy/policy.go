@@ -15,11 +15,14 @@
 package policy
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"path"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/ossf/scorecard/v3/checker"
 	"github.com/ossf/scorecard/v3/checks"
 	sce "github.com/ossf/scorecard/v3/errors"
 )
@@ -30,6 +33,9 @@ var (
 	errInvalidScore   = errors.New("invalid score")
 	errInvalidMode    = errors.New("invalid mode")
 	errRepeatingCheck = errors.New("check has multiple definitions")
+	errInvalidOption  = errors.New("option not supported by check")
+	errInvalidPattern = errors.New("invalid status check pattern")
+	errInvalidFinding = errors.New("invalid finding ID")
 )
 
 var allowedVersions = map[int]bool{1: true}
@@ -37,13 +43,16 @@ var allowedVersions = map[int]bool{1: true}
 var modes = map[string]bool{"enforced": true, "disabled": true}
 
 type checkPolicy struct {
-	Mode  string `yaml:"mode"`
-	Score int    `yaml:"score"`
+	Mode                 string   `yaml:"mode"`
+	RequiredStatusChecks []string `yaml:"required_status_checks"`
+	Score                int      `yaml:"score"`
 }
 
 type scorecardPolicy struct {
-	Policies map[string]checkPolicy `yaml:"policies"`
-	Version  int                    `yaml:"version"`
+	Policies         map[string]checkPolicy `yaml:"policies"`
+	Version          int                    `yaml:"version"`
+	FailOnRegression bool                   `yaml:"failOnRegression"`
+	Suppressions     []string               `yaml:"suppressions"`
 }
 
 func isAllowedVersion(v int) bool {
@@ -62,6 +71,31 @@ func modeToProto(m string) CheckPolicy_Mode {
 	}
 }
 
+func validateRequiredStatusChecks(check string, patterns []string) error {
+	if len(patterns) == 0 {
+		return nil
+	}
+	if check != checks.CheckBranchProtection {
+		return sce.WithMessage(sce.ErrScorecardInternal,
+			fmt.Sprintf("%v: required_status_checks: %v", errInvalidOption.Error(), check))
+	}
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%v: %v", errInvalidPattern.Error(), p))
+		}
+	}
+	return nil
+}
+
+func validateSuppressions(ids []string) error {
+	for _, id := range ids {
+		if _, err := hex.DecodeString(id); err != nil || len(id) != checker.FindingIDLength {
+			return sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("%v: %v", errInvalidFinding.Error(), id))
+		}
+	}
+	return nil
+}
+
 // ParseFromYAML parses a policy file and returns
 // a scorecardPolicy.
 func ParseFromYAML(b []byte) (*ScorecardPolicy, error) {
@@ -81,6 +115,12 @@ func ParseFromYAML(b []byte) (*ScorecardPolicy, error) {
 
 	// Set version.
 	retPolicy.Version = int32(sp.Version)
+	retPolicy.FailOnRegression = sp.FailOnRegression
+
+	if err := validateSuppressions(sp.Suppressions); err != nil {
+		return &retPolicy, err
+	}
+	retPolicy.Suppressions = sp.Suppressions
 
 	checksFound := make(map[string]bool)
 	for n, p := range sp.Policies {
@@ -103,10 +143,15 @@ func ParseFromYAML(b []byte) (*ScorecardPolicy, error) {
 		}
 		checksFound[n] = true
 
+		if err := validateRequiredStatusChecks(n, p.RequiredStatusChecks); err != nil {
+			return &retPolicy, err
+		}
+
 		// Add an entry to the policy.
 		retPolicy.Policies[n] = &CheckPolicy{
-			Score: int32(p.Score),
-			Mode:  modeToProto(p.Mode),
+			Score:                int32(p.Score),
+			Mode:                 modeToProto(p.Mode),
+			RequiredStatusChecks: p.RequiredStatusChecks,
 		}
 	}
 
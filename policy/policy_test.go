@@ -101,6 +101,66 @@ func TestPolicyRead(t *testing.T) {
 			filename: "./testdata/policy-multiple-defs.yaml",
 			err:      sce.ErrScorecardInternal,
 		},
+		{
+			name:     "required status checks",
+			filename: "./testdata/policy-status-checks.yaml",
+			err:      nil,
+			result: ScorecardPolicy{
+				Version: 1,
+				Policies: map[string]*CheckPolicy{
+					"Branch-Protection": &CheckPolicy{
+						Score:                5,
+						Mode:                 CheckPolicy_ENFORCED,
+						RequiredStatusChecks: []string{"*test*", "*lint*"},
+					},
+				},
+			},
+		},
+		{
+			name:     "fail on regression",
+			filename: "./testdata/policy-fail-on-regression.yaml",
+			err:      nil,
+			result: ScorecardPolicy{
+				Version:          1,
+				FailOnRegression: true,
+				Policies: map[string]*CheckPolicy{
+					"Code-Review": &CheckPolicy{
+						Score: 8,
+						Mode:  CheckPolicy_ENFORCED,
+					},
+				},
+			},
+		},
+		{
+			name:     "suppressions",
+			filename: "./testdata/policy-suppressions.yaml",
+			err:      nil,
+			result: ScorecardPolicy{
+				Version:      1,
+				Suppressions: []string{"79da805242c3", "302211774eab"},
+				Policies: map[string]*CheckPolicy{
+					"Binary-Artifacts": &CheckPolicy{
+						Score: 10,
+						Mode:  CheckPolicy_ENFORCED,
+					},
+				},
+			},
+		},
+		{
+			name:     "invalid suppression",
+			filename: "./testdata/policy-invalid-suppression.yaml",
+			err:      sce.ErrScorecardInternal,
+		},
+		{
+			name:     "required status checks on another check",
+			filename: "./testdata/policy-invalid-status-checks-check.yaml",
+			err:      sce.ErrScorecardInternal,
+		},
+		{
+			name:     "invalid required status check pattern",
+			filename: "./testdata/policy-invalid-status-checks-pattern.yaml",
+			err:      sce.ErrScorecardInternal,
+		},
 	}
 
 	for i := range tests {
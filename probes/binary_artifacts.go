@@ -0,0 +1,47 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probes
+
+import (
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks"
+)
+
+//nolint:gochecknoinits
+func init() {
+	registerProbe("freeOfBinaryArtifacts", checks.CheckBinaryArtifacts, freeOfBinaryArtifacts)
+}
+
+func freeOfBinaryArtifacts(raw *checker.RawResults) []Finding {
+	files := raw.BinaryArtifactResults.Files
+	if len(files) == 0 {
+		return []Finding{{
+			Probe:   "freeOfBinaryArtifacts",
+			Outcome: OutcomePositive,
+			Message: "no binaries found in the repo",
+		}}
+	}
+
+	findings := []Finding{}
+	for _, f := range files {
+		findings = append(findings, Finding{
+			Probe:    "freeOfBinaryArtifacts",
+			Outcome:  OutcomeNegative,
+			Message:  "binary detected",
+			Location: f.Path,
+		})
+	}
+	return findings
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probes
+
+import (
+	"fmt"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks"
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+//nolint:gochecknoinits
+func init() {
+	registerBranchProbe("blocksForcePush", blocksForcePush)
+	registerBranchProbe("blocksDeletion", blocksDeletion)
+	registerBranchProbe("enforcesAdmins", enforcesAdmins)
+	registerBranchProbe("requiresApproversForPullRequests", requiresApproversForPullRequests)
+	registerBranchProbe("requiresCodeOwnersReview", requiresCodeOwnersReview)
+	registerBranchProbe("dismissesStaleReviews", dismissesStaleReviews)
+	registerBranchProbe("requiresStatusChecks", requiresStatusChecks)
+	registerBranchProbe("requiresUpToDateBranches", requiresUpToDateBranches)
+	registerBranchProbe("requiresLinearHistory", requiresLinearHistory)
+	registerBranchProbe("requiresSignedCommits", requiresSignedCommits)
+}
+
+// registerBranchProbe registers a probe evaluated once per protected branch.
+func registerBranchProbe(name string, fn func(rule *clients.BranchProtectionRule) (Outcome, string)) {
+	registerProbe(name, checks.CheckBranchProtection, func(raw *checker.RawResults) []Finding {
+		branches := raw.BranchProtectionResults.Branches
+		if len(branches) == 0 {
+			return []Finding{{
+				Probe:   name,
+				Outcome: OutcomeNotAvailable,
+				Message: "unable to detect any development/release branches",
+			}}
+		}
+
+		findings := []Finding{}
+		for i := range branches {
+			branch := &branches[i]
+			f := Finding{
+				Probe:    name,
+				Location: *branch.Name,
+			}
+			if branch.Protected != nil && !*branch.Protected {
+				f.Outcome, f.Message = OutcomeNegative, "branch protection not enabled"
+			} else {
+				f.Outcome, f.Message = fn(&branch.BranchProtectionRule)
+			}
+			findings = append(findings, f)
+		}
+		return findings
+	})
+}
+
+// boolOutcome maps an optional setting to an outcome.
+// A nil value means the token could not read the setting.
+func boolOutcome(value *bool, want bool, setting string) (Outcome, string) {
+	switch {
+	case value == nil:
+		return OutcomeNotAvailable, fmt.Sprintf("unable to retrieve '%s'", setting)
+	case *value == want:
+		return OutcomePositive, fmt.Sprintf("'%s' is %s", setting, enabledString(*value))
+	default:
+		return OutcomeNegative, fmt.Sprintf("'%s' is %s", setting, enabledString(*value))
+	}
+}
+
+func enabledString(b bool) string {
+	if b {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+func blocksForcePush(rule *clients.BranchProtectionRule) (Outcome, string) {
+	return boolOutcome(rule.AllowForcePushes, false, "force pushes")
+}
+
+func blocksDeletion(rule *clients.BranchProtectionRule) (Outcome, string) {
+	return boolOutcome(rule.AllowDeletions, false, "allow deletion")
+}
+
+func enforcesAdmins(rule *clients.BranchProtectionRule) (Outcome, string) {
+	return boolOutcome(rule.EnforceAdmins, true, "include administrators")
+}
+
+func requiresApproversForPullRequests(rule *clients.BranchProtectionRule) (Outcome, string) {
+	count := rule.RequiredPullRequestReviews.RequiredApprovingReviewCount
+	if count == nil || *count == 0 {
+		return OutcomeNegative, "no approving reviews required"
+	}
+	return OutcomePositive, fmt.Sprintf("%d approving reviews required", *count)
+}
+
+func requiresCodeOwnersReview(rule *clients.BranchProtectionRule) (Outcome, string) {
+	return boolOutcome(rule.RequiredPullRequestReviews.RequireCodeOwnerReviews, true, "require code owner reviews")
+}
+
+func dismissesStaleReviews(rule *clients.BranchProtectionRule) (Outcome, string) {
+	return boolOutcome(rule.RequiredPullRequestReviews.DismissStaleReviews, true, "dismiss stale reviews")
+}
+
+func requiresStatusChecks(rule *clients.BranchProtectionRule) (Outcome, string) {
+	// `Requires status check to pass before merging` without any
+	// specific checks declared is equivalent to no status check at all.
+	// Workflows required by organization rulesets are status checks too.
+	n := len(rule.CheckRules.Contexts) + len(rule.CheckRules.RequiredWorkflows)
+	if n == 0 {
+		return OutcomeNegative, "no status checks required"
+	}
+	return OutcomePositive, fmt.Sprintf("%d status checks required", n)
+}
+
+func requiresUpToDateBranches(rule *clients.BranchProtectionRule) (Outcome, string) {
+	return boolOutcome(rule.CheckRules.UpToDateBeforeMerge, true, "require branches to be up to date")
+}
+
+func requiresLinearHistory(rule *clients.BranchProtectionRule) (Outcome, string) {
+	return boolOutcome(rule.RequireLinearHistory, true, "require linear history")
+}
+
+func requiresSignedCommits(rule *clients.BranchProtectionRule) (Outcome, string) {
+	return boolOutcome(rule.RequiresSignatures, true, "require signed commits")
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package probes contains small, individually runnable heuristics
+// which produce findings from the raw results of a check.
+package probes
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ossf/scorecard/v3/checker"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+// Outcome is the result of a probe.
+type Outcome string
+
+const (
+	// OutcomePositive indicates the repo follows the practice the probe looks for.
+	OutcomePositive Outcome = "Positive"
+	// OutcomeNegative indicates the repo does not follow the practice.
+	OutcomeNegative Outcome = "Negative"
+	// OutcomeNotAvailable indicates the data needed by the probe could not be retrieved,
+	// e.g., because the token is not an admin token.
+	OutcomeNotAvailable Outcome = "NotAvailable"
+)
+
+// Finding is a single result produced by a probe.
+type Finding struct {
+	// ID identifies the finding across scans, whatever its outcome.
+	ID      string  `json:"id"`
+	Probe   string  `json:"probe"`
+	Outcome Outcome `json:"outcome"`
+	Message string  `json:"message"`
+	// Location is the branch or file the finding applies to, if any.
+	Location string `json:"location,omitempty"`
+	// Suppressed is true if the finding is suppressed by the policy.
+	Suppressed bool `json:"suppressed,omitempty"`
+}
+
+// Probe is a heuristic run against the raw results of a check.
+type Probe struct {
+	Name string
+	// Check is the check whose raw results the probe consumes.
+	Check string
+	Run   func(raw *checker.RawResults) []Finding
+}
+
+var allProbes = map[string]Probe{}
+
+func registerProbe(name, check string, fn func(raw *checker.RawResults) []Finding) {
+	allProbes[name] = Probe{
+		Name:  name,
+		Check: check,
+		Run:   fn,
+	}
+}
+
+// All returns the names of all registered probes, sorted.
+func All() []string {
+	ret := make([]string, 0, len(allProbes))
+	for name := range allProbes {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// Get returns the probe registered under name.
+func Get(name string) (Probe, error) {
+	p, exists := allProbes[name]
+	if !exists {
+		return Probe{}, sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("invalid probe: %s", name))
+	}
+	return p, nil
+}
+
+// RequiredChecks returns the checks whose raw results are needed to run the probes.
+func RequiredChecks(names []string) ([]string, error) {
+	seen := make(map[string]bool)
+	ret := []string{}
+	for _, name := range names {
+		p, err := Get(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[p.Check] {
+			seen[p.Check] = true
+			ret = append(ret, p.Check)
+		}
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// Run runs the named probes against raw results, in the order given.
+func Run(raw *checker.RawResults, names []string) ([]Finding, error) {
+	findings := []Finding{}
+	for _, name := range names {
+		p, err := Get(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range p.Run(raw) {
+			f.ID = checker.NewFindingID(p.Check, p.Name, f.Location)
+			findings = append(findings, f)
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+	sce "github.com/ossf/scorecard/v3/errors"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	trueVal := true
+	falseVal := false
+	var twoReviews int32 = 2
+	main := "main"
+	release := "release"
+
+	tests := []struct {
+		name   string
+		probes []string
+		raw    checker.RawResults
+		want   []Finding
+	}{
+		{
+			name:   "force push blocked on one branch, unprotected release branch",
+			probes: []string{"blocksForcePush"},
+			raw: checker.RawResults{
+				BranchProtectionResults: checker.BranchProtectionsData{
+					Branches: []clients.BranchRef{
+						{
+							Name:      &main,
+							Protected: &trueVal,
+							BranchProtectionRule: clients.BranchProtectionRule{
+								AllowForcePushes: &falseVal,
+							},
+						},
+						{
+							Name:      &release,
+							Protected: &falseVal,
+						},
+					},
+				},
+			},
+			want: []Finding{
+				{
+					Probe:    "blocksForcePush",
+					Outcome:  OutcomePositive,
+					Message:  "'force pushes' is disabled",
+					Location: "main",
+				},
+				{
+					Probe:    "blocksForcePush",
+					Outcome:  OutcomeNegative,
+					Message:  "branch protection not enabled",
+					Location: "release",
+				},
+			},
+		},
+		{
+			name:   "admin settings not readable",
+			probes: []string{"enforcesAdmins", "requiresApproversForPullRequests"},
+			raw: checker.RawResults{
+				BranchProtectionResults: checker.BranchProtectionsData{
+					Branches: []clients.BranchRef{
+						{
+							Name:      &main,
+							Protected: &trueVal,
+							BranchProtectionRule: clients.BranchProtectionRule{
+								RequiredPullRequestReviews: clients.PullRequestReviewRule{
+									RequiredApprovingReviewCount: &twoReviews,
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []Finding{
+				{
+					Probe:    "enforcesAdmins",
+					Outcome:  OutcomeNotAvailable,
+					Message:  "unable to retrieve 'include administrators'",
+					Location: "main",
+				},
+				{
+					Probe:    "requiresApproversForPullRequests",
+					Outcome:  OutcomePositive,
+					Message:  "2 approving reviews required",
+					Location: "main",
+				},
+			},
+		},
+		{
+			name:   "no branches",
+			probes: []string{"requiresStatusChecks"},
+			want: []Finding{
+				{
+					Probe:   "requiresStatusChecks",
+					Outcome: OutcomeNotAvailable,
+					Message: "unable to detect any development/release branches",
+				},
+			},
+		},
+		{
+			name:   "binaries and security policy",
+			probes: []string{"freeOfBinaryArtifacts", "hasSecurityPolicy", "securityPolicyHasContact"},
+			raw: checker.RawResults{
+				BinaryArtifactResults: checker.BinaryArtifactData{
+					Files: []checker.File{{Path: "bin/tool.exe"}},
+				},
+				SecurityPolicyResults: checker.SecurityPolicyData{
+					Files: []checker.SecurityPolicyFile{
+						{File: checker.File{Path: "SECURITY.md"}, HasContact: true},
+					},
+				},
+			},
+			want: []Finding{
+				{
+					Probe:    "freeOfBinaryArtifacts",
+					Outcome:  OutcomeNegative,
+					Message:  "binary detected",
+					Location: "bin/tool.exe",
+				},
+				{
+					Probe:    "hasSecurityPolicy",
+					Outcome:  OutcomePositive,
+					Message:  "security policy file detected",
+					Location: "SECURITY.md",
+				},
+				{
+					Probe:    "securityPolicyHasContact",
+					Outcome:  OutcomePositive,
+					Message:  "security policy has contact information",
+					Location: "SECURITY.md",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Run(&tt.raw, tt.probes)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			// IDs are tested by TestRunFindingIDs.
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(Finding{}, "ID")); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRunFindingIDs(t *testing.T) {
+	t.Parallel()
+	raw := checker.RawResults{
+		SecurityPolicyResults: checker.SecurityPolicyData{
+			Files: []checker.SecurityPolicyFile{{File: checker.File{Path: "SECURITY.md"}}},
+		},
+	}
+	got, err := Run(&raw, []string{"hasSecurityPolicy", "securityPolicyHasContact"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d findings, want 2", len(got))
+	}
+	for _, f := range got {
+		if want := checker.NewFindingID("Security-Policy", f.Probe, "SECURITY.md"); f.ID != want {
+			t.Errorf("%s: ID = %q, want %q", f.Probe, f.ID, want)
+		}
+	}
+	// Findings of different probes at the same location are told apart.
+	if got[0].ID == got[1].ID {
+		t.Errorf("probes %s and %s have the same ID %q", got[0].Probe, got[1].Probe, got[0].ID)
+	}
+
+	// The ID does not depend on the outcome, so that a finding can be tracked until it is fixed.
+	raw.SecurityPolicyResults.Files[0].HasContact = true
+	fixed, err := Run(&raw, []string{"securityPolicyHasContact"})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if fixed[0].Outcome == got[1].Outcome || fixed[0].ID != got[1].ID {
+		t.Errorf("fixed finding: got %+v, want the ID of %+v with another outcome", fixed[0], got[1])
+	}
+}
+
+func TestRequiredChecks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		probes  []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:   "deduplicated and sorted",
+			probes: []string{"hasSecurityPolicy", "blocksForcePush", "blocksDeletion"},
+			want:   []string{"Branch-Protection", "Security-Policy"},
+		},
+		{
+			name:    "unknown probe",
+			probes:  []string{"blocksForcePush", "doesNotExist"},
+			wantErr: sce.ErrScorecardInternal,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := RequiredChecks(tt.probes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RequiredChecks: got err %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probes
+
+import (
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/checks"
+)
+
+//nolint:gochecknoinits
+func init() {
+	registerProbe("hasSecurityPolicy", checks.CheckSecurityPolicy, hasSecurityPolicy)
+	registerProbe("securityPolicyHasContact", checks.CheckSecurityPolicy, securityPolicyHasContact)
+}
+
+func hasSecurityPolicy(raw *checker.RawResults) []Finding {
+	files := raw.SecurityPolicyResults.Files
+	if len(files) == 0 {
+		return []Finding{{
+			Probe:   "hasSecurityPolicy",
+			Outcome: OutcomeNegative,
+			Message: "security policy file not detected",
+		}}
+	}
+
+	findings := []Finding{}
+	for i := range files {
+		findings = append(findings, Finding{
+			Probe:    "hasSecurityPolicy",
+			Outcome:  OutcomePositive,
+			Message:  "security policy file detected",
+			Location: files[i].File.Path,
+		})
+	}
+	return findings
+}
+
+func securityPolicyHasContact(raw *checker.RawResults) []Finding {
+	files := raw.SecurityPolicyResults.Files
+	if len(files) == 0 {
+		return []Finding{{
+			Probe:   "securityPolicyHasContact",
+			Outcome: OutcomeNotAvailable,
+			Message: "security policy file not detected",
+		}}
+	}
+
+	findings := []Finding{}
+	for i := range files {
+		f := Finding{
+			Probe:    "securityPolicyHasContact",
+			Outcome:  OutcomeNegative,
+			Message:  "security policy has no contact information",
+			Location: files[i].File.Path,
+		}
+		if files[i].HasContact {
+			f.Outcome, f.Message = OutcomePositive, "security policy has contact information"
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
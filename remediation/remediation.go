@@ -0,0 +1,142 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remediation builds machine-readable remediations for check warnings.
+package remediation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ossf/scorecard/v3/checker"
+	"github.com/ossf/scorecard/v3/clients"
+)
+
+//nolint:lll
+const (
+	securityPolicyDocURL = "https://docs.github.com/en/code-security/getting-started/adding-a-security-policy-to-your-repository"
+	dependabotDocURL     = "https://docs.github.com/en/code-security/supply-chain-security/keeping-your-dependencies-updated-automatically/enabling-and-disabling-dependabot-version-updates"
+	renovateDocURL       = "https://docs.renovatebot.com/getting-started/installing-onboarding/"
+
+	dependabotPatch = `--- /dev/null
++++ b/.github/dependabot.yml
+@@ -0,0 +1,6 @@
++version: 2
++updates:
++  - package-ecosystem: "github-actions"
++    directory: "/"
++    schedule:
++      interval: "weekly"
+`
+	renovatePatch = `--- /dev/null
++++ b/renovate.json
+@@ -0,0 +1,3 @@
++{
++  "extends": ["config:base"]
++}
+`
+)
+
+// Metadata holds the repo information used to link to its settings pages.
+// A nil *Metadata is valid and produces remediations without settings links.
+type Metadata struct {
+	repoURL string
+}
+
+// New returns the remediation metadata for repo.
+// Settings links are only available for GitHub repos.
+func New(repo clients.Repo) *Metadata {
+	if repo == nil || !strings.HasPrefix(repo.URI(), "github.com/") {
+		return &Metadata{}
+	}
+	return &Metadata{repoURL: "https://" + repo.URI()}
+}
+
+func (m *Metadata) settingsURL(page string) string {
+	if m == nil || m.repoURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/settings/%s", m.repoURL, page)
+}
+
+// BranchProtection returns the remediation for a branch protection setting.
+// setting is the name of the setting as shown in the GitHub UI.
+func (m *Metadata) BranchProtection(branch, setting string, enable bool) *checker.Remediation {
+	action := "Enable"
+	if !enable {
+		action = "Disable"
+	}
+	return &checker.Remediation{
+		Text: fmt.Sprintf("%s '%s' for branch '%s'", action, setting, branch),
+		Steps: []string{
+			fmt.Sprintf("Open the branch protection rule matching branch '%s', or add one", branch),
+			fmt.Sprintf("%s '%s'", action, setting),
+			"Save changes",
+		},
+		Effort: checker.RemediationEffortLow,
+		URL:    m.settingsURL("branches"),
+	}
+}
+
+// SecurityPolicyContent returns the remediation for a security policy missing some information.
+func SecurityPolicyContent(path, missing string) *checker.Remediation {
+	return &checker.Remediation{
+		Text: fmt.Sprintf("Add %s to %s", missing, path),
+		Steps: []string{
+			fmt.Sprintf("Edit %s", path),
+			fmt.Sprintf("Add %s", missing),
+		},
+		Effort: checker.RemediationEffortLow,
+		URL:    securityPolicyDocURL,
+	}
+}
+
+// BinaryArtifact returns the remediation for a binary checked into the repo.
+func BinaryArtifact(path string) *checker.Remediation {
+	return &checker.Remediation{
+		Text: fmt.Sprintf("Remove %s and build it from source", path),
+		Steps: []string{
+			fmt.Sprintf("Remove %s from the repository", path),
+			"Build the binary from source as part of the build or release process",
+		},
+		Effort: checker.RemediationEffortMedium,
+	}
+}
+
+// Dependabot returns the remediation for a missing dependabot configuration.
+func Dependabot() *checker.Remediation {
+	return &checker.Remediation{
+		Text: "Add a dependabot configuration file",
+		Steps: []string{
+			"Add .github/dependabot.yml listing the package ecosystems used by the project",
+		},
+		Effort: checker.RemediationEffortLow,
+		URL:    dependabotDocURL,
+		Patch:  dependabotPatch,
+	}
+}
+
+// Renovate returns the remediation for a missing renovatebot configuration.
+func Renovate() *checker.Remediation {
+	return &checker.Remediation{
+		Text: "Add a renovatebot configuration file",
+		Steps: []string{
+			"Install the Renovate app on the repository",
+			"Add renovate.json with the presets used by the project",
+		},
+		Effort: checker.RemediationEffortLow,
+		URL:    renovateDocURL,
+		Patch:  renovatePatch,
+	}
+}
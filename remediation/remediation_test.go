@@ -0,0 +1,68 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remediation
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	mockrepo "github.com/ossf/scorecard/v3/clients/mockclients"
+)
+
+func TestBranchProtectionURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		uri     string
+		wantURL string
+	}{
+		{
+			name:    "GitHub repo",
+			uri:     "github.com/ossf/scorecard",
+			wantURL: "https://github.com/ossf/scorecard/settings/branches",
+		},
+		{
+			name:    "local repo",
+			uri:     "file:///tmp/scorecard",
+			wantURL: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			repo := mockrepo.NewMockRepo(ctrl)
+			repo.EXPECT().URI().Return(tt.uri).AnyTimes()
+
+			r := New(repo).BranchProtection("main", "Allow force pushes", false)
+			if r.URL != tt.wantURL {
+				t.Errorf("URL = %q, want %q", r.URL, tt.wantURL)
+			}
+			if r.Text != "Disable 'Allow force pushes' for branch 'main'" {
+				t.Errorf("unexpected text %q", r.Text)
+			}
+		})
+	}
+}
+
+func TestNilMetadata(t *testing.T) {
+	t.Parallel()
+	var m *Metadata
+	if r := m.BranchProtection("main", "Include administrators", true); r.URL != "" {
+		t.Errorf("URL = %q, want empty", r.URL)
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sbom embeds Scorecard results in CycloneDX and SPDX SBOMs, so SBOM
+// pipelines can carry supply-chain scores alongside license data.
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/checker"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	sce "github.com/ossf/scorecard/v3/errors"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+// Format is the format of an SBOM.
+type Format string
+
+const (
+	// CycloneDX is the JSON format of https://cyclonedx.org.
+	CycloneDX Format = "cyclonedx"
+	// SPDX is the JSON format of https://spdx.dev.
+	SPDX Format = "spdx"
+)
+
+// propertyPrefix is the prefix of the names of the CycloneDX properties holding the results.
+const propertyPrefix = "ossf:scorecard:"
+
+// resultsURL is where the results of the weekly Scorecard scans of a repo are published.
+const resultsURL = "https://api.securityscorecards.dev/projects/"
+
+// ScoreFn runs Scorecard on a GitHub repository, e.g. github.com/owner/name.
+type ScoreFn func(ctx context.Context, repoURI string) (*pkg.ScorecardResult, error)
+
+type component = map[string]interface{}
+
+// Enrich adds the Scorecard results of the source repository of each component
+// of the JSON SBOM in content, and returns the enriched SBOM and its format.
+// Components without a GitHub source repository are left untouched, and so are
+// the components whose repository cannot be scored, after logging the error.
+func Enrich(ctx context.Context, content []byte, score ScoreFn, checkDocs docs.Doc,
+	logger *zap.Logger) ([]byte, Format, error) {
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	// Keep numbers, e.g. the BOM version, as they are.
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.Decode: %v", err))
+	}
+
+	e := enricher{
+		ctx:       ctx,
+		score:     score,
+		checkDocs: checkDocs,
+		logger:    logger,
+		results:   make(map[string]*repoResult),
+	}
+	var format Format
+	switch {
+	case doc["bomFormat"] == "CycloneDX":
+		format = CycloneDX
+		if metadata, ok := doc["metadata"].(map[string]interface{}); ok {
+			if root, ok := metadata["component"].(component); ok {
+				e.enrichCycloneDX(root)
+			}
+		}
+		e.enrichCycloneDXComponents(doc["components"])
+	case doc["spdxVersion"] != nil:
+		format = SPDX
+		packages, _ := doc["packages"].([]interface{})
+		for _, p := range packages {
+			if p, ok := p.(component); ok {
+				e.enrichSPDX(p)
+			}
+		}
+	default:
+		return nil, "", sce.WithMessage(sce.ErrScorecardInternal, "unknown SBOM format: expected CycloneDX or SPDX JSON")
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, "", sce.WithMessage(sce.ErrScorecardInternal, fmt.Sprintf("json.MarshalIndent: %v", err))
+	}
+	return append(out, '\n'), format, nil
+}
+
+// repoResult is the result of a repo, scored once however many components it is the source of.
+type repoResult struct {
+	result *pkg.ScorecardResult
+	score  float64
+}
+
+type enricher struct {
+	ctx       context.Context
+	score     ScoreFn
+	checkDocs docs.Doc
+	logger    *zap.Logger
+	results   map[string]*repoResult
+}
+
+// get returns the result of repo, or nil if it cannot be scored.
+func (e *enricher) get(repo string) *repoResult {
+	if r, scored := e.results[repo]; scored {
+		return r
+	}
+	e.results[repo] = nil
+	result, err := e.score(e.ctx, repo)
+	if err != nil {
+		e.logger.Warn(fmt.Sprintf("scoring %s: %v", repo, err))
+		return nil
+	}
+	score, err := result.GetAggregateScore(e.checkDocs)
+	if err != nil {
+		e.logger.Warn(fmt.Sprintf("scoring %s: %v", repo, err))
+		return nil
+	}
+	e.results[repo] = &repoResult{result: result, score: score}
+	return e.results[repo]
+}
+
+func (e *enricher) enrichCycloneDXComponents(components interface{}) {
+	list, _ := components.([]interface{})
+	for _, c := range list {
+		if c, ok := c.(component); ok {
+			e.enrichCycloneDX(c)
+		}
+	}
+}
+
+// enrichCycloneDX adds the results as properties of c, and a reference to the
+// published results of the repo as an external reference.
+func (e *enricher) enrichCycloneDX(c component) {
+	// Components may be nested, e.g. the modules of an application.
+	e.enrichCycloneDXComponents(c["components"])
+
+	repo := ""
+	refs, _ := c["externalReferences"].([]interface{})
+	for _, ref := range refs {
+		if ref, ok := ref.(component); ok && ref["type"] == "vcs" {
+			if u, ok := ref["url"].(string); ok && repo == "" {
+				repo = githubRepo(u)
+			}
+		}
+	}
+	if purl, ok := c["purl"].(string); ok && repo == "" {
+		repo = purlRepo(purl)
+	}
+	if repo == "" {
+		return
+	}
+	r := e.get(repo)
+	if r == nil {
+		return
+	}
+
+	properties, _ := c["properties"].([]interface{})
+	property := func(name, value string) {
+		properties = append(properties, component{"name": propertyPrefix + name, "value": value})
+	}
+	property("repo", repo)
+	property("commit", r.result.Repo.CommitSHA)
+	property("date", r.result.Date.Format("2006-01-02"))
+	property("version", r.result.Scorecard.Version)
+	property("score", scoreToString(r.score))
+	for _, check := range sortedChecks(r.result) {
+		property("check:"+check.Name, strconv.Itoa(check.Score))
+	}
+	c["properties"] = properties
+	c["externalReferences"] = append(refs, component{
+		"type":    "other",
+		"url":     resultsURL + repo,
+		"comment": "OpenSSF Scorecard results",
+	})
+}
+
+// spdxAnnotation is the comment of the SPDX annotations holding the results.
+type spdxAnnotation struct {
+	Repo    string         `json:"repo"`
+	Commit  string         `json:"commit"`
+	Date    string         `json:"date"`
+	Version string         `json:"version"`
+	Score   string         `json:"score"`
+	Checks  map[string]int `json:"checks"`
+	URL     string         `json:"url"`
+}
+
+// enrichSPDX adds the results to p as an annotation whose comment is JSON.
+func (e *enricher) enrichSPDX(p component) {
+	repo := ""
+	refs, _ := p["externalRefs"].([]interface{})
+	for _, ref := range refs {
+		if ref, ok := ref.(component); ok && ref["referenceType"] == "purl" {
+			if purl, ok := ref["referenceLocator"].(string); ok && repo == "" {
+				repo = purlRepo(purl)
+			}
+		}
+	}
+	for _, field := range []string{"downloadLocation", "homepage"} {
+		if u, ok := p[field].(string); ok && repo == "" {
+			repo = githubRepo(u)
+		}
+	}
+	if repo == "" {
+		return
+	}
+	r := e.get(repo)
+	if r == nil {
+		return
+	}
+
+	a := spdxAnnotation{
+		Repo:    repo,
+		Commit:  r.result.Repo.CommitSHA,
+		Date:    r.result.Date.Format("2006-01-02"),
+		Version: r.result.Scorecard.Version,
+		Score:   scoreToString(r.score),
+		Checks:  make(map[string]int),
+		URL:     resultsURL + repo,
+	}
+	for _, check := range r.result.Checks {
+		a.Checks[check.Name] = check.Score
+	}
+	comment, err := json.Marshal(a)
+	if err != nil {
+		// This never happens.
+		panic(err)
+	}
+	annotator := "Tool: scorecard"
+	if r.result.Scorecard.Version != "" {
+		annotator += "-" + r.result.Scorecard.Version
+	}
+	annotations, _ := p["annotations"].([]interface{})
+	p["annotations"] = append(annotations, component{
+		"annotationDate": r.result.Date.UTC().Format("2006-01-02T15:04:05Z"),
+		"annotationType": "OTHER",
+		"annotator":      annotator,
+		"comment":        string(comment),
+	})
+}
+
+func sortedChecks(r *pkg.ScorecardResult) []checker.CheckResult {
+	checks := append([]checker.CheckResult{}, r.Checks...)
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].Name < checks[j].Name
+	})
+	return checks
+}
+
+func scoreToString(s float64) string {
+	if s == checker.InconclusiveResultScore {
+		return "?"
+	}
+	return fmt.Sprintf("%.1f", s)
+}
+
+// githubRepo returns the GitHub repo of a URL, e.g. git+https://github.com/owner/name.git,
+// as github.com/owner/name, or "" if the URL is not a GitHub repo.
+func githubRepo(s string) string {
+	s = strings.TrimPrefix(s, "git+")
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil || !strings.EqualFold(u.Hostname(), "github.com") {
+		return ""
+	}
+	const parts = 2
+	path := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(path) < parts || path[0] == "" || path[1] == "" {
+		return ""
+	}
+	return "github.com/" + path[0] + "/" + strings.TrimSuffix(path[1], ".git")
+}
+
+// purlRepo returns the GitHub repo of a package URL, e.g. pkg:github/owner/name@v1
+// or pkg:golang/github.com/owner/name/v2@v2.0.0, or "" if the package is not from GitHub.
+// See https://github.com/package-url/purl-spec.
+func purlRepo(purl string) string {
+	// Drop the subpath, qualifiers and version.
+	for _, sep := range []string{"#", "?", "@"} {
+		purl = strings.SplitN(purl, sep, 2)[0]
+	}
+	switch {
+	case strings.HasPrefix(purl, "pkg:github/"):
+		return githubRepo("github.com/" + strings.TrimPrefix(purl, "pkg:github/"))
+	case strings.HasPrefix(purl, "pkg:golang/github.com/"):
+		return githubRepo(strings.TrimPrefix(purl, "pkg:golang/"))
+	default:
+		return ""
+	}
+}
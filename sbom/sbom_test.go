@@ -0,0 +1,156 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ossf/scorecard/v3/checker"
+	docs "github.com/ossf/scorecard/v3/docs/checks"
+	"github.com/ossf/scorecard/v3/pkg"
+)
+
+func TestGithubRepo(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "https://github.com/owner/name", want: "github.com/owner/name"},
+		{input: "git+https://github.com/owner/name.git", want: "github.com/owner/name"},
+		{input: "github.com/owner/name/tree/main", want: "github.com/owner/name"},
+		{input: "https://github.com/owner", want: ""},
+		{input: "https://gitlab.com/owner/name", want: ""},
+		{input: "NOASSERTION", want: ""},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			if got := githubRepo(tt.input); got != tt.want {
+				t.Errorf("githubRepo(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPurlRepo(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "pkg:github/owner/name@v1.0.0", want: "github.com/owner/name"},
+		{input: "pkg:golang/github.com/owner/name/v2@v2.0.0?type=module", want: "github.com/owner/name"},
+		{input: "pkg:golang/golang.org/x/mod@v0.5.0", want: ""},
+		{input: "pkg:npm/left-pad@1.3.0", want: ""},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			if got := purlRepo(tt.input); got != tt.want {
+				t.Errorf("purlRepo(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnrich(t *testing.T) {
+	t.Parallel()
+	checkDocs, err := docs.Read()
+	if err != nil {
+		t.Fatalf("docs.Read: %v", err)
+	}
+	scores := map[string]int{
+		"github.com/owner/app": 8,
+		"github.com/owner/lib": 3,
+	}
+	score := func(ctx context.Context, repo string) (*pkg.ScorecardResult, error) {
+		s, ok := scores[repo]
+		if !ok {
+			return nil, errors.New("repo not found")
+		}
+		return &pkg.ScorecardResult{
+			Repo:      pkg.RepoInfo{Name: repo, CommitSHA: "68bc59901773ab4c051dfcea0cc4201a1567ab32"},
+			Date:      time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC),
+			Scorecard: pkg.ScorecardInfo{Version: "v4.0.0"},
+			Checks: []checker.CheckResult{
+				{Name: "Code-Review", Score: s},
+				{Name: "Binary-Artifacts", Score: checker.MaxResultScore},
+			},
+		}, nil
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		format   Format
+		wantErr  bool
+	}{
+		{
+			name:     "CycloneDX",
+			input:    "./testdata/cyclonedx.json",
+			expected: "./testdata/cyclonedx.enriched.json",
+			format:   CycloneDX,
+		},
+		{
+			name:     "SPDX",
+			input:    "./testdata/spdx.json",
+			expected: "./testdata/spdx.enriched.json",
+			format:   SPDX,
+		},
+		{
+			name:    "not an SBOM",
+			input:   "./testdata/not-sbom.json",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			content, err := os.ReadFile(tt.input)
+			if err != nil {
+				t.Fatalf("cannot read file: %v", err)
+			}
+			got, format, err := Enrich(context.Background(), content, score, checkDocs, zap.NewNop())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Enrich: got err %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if format != tt.format {
+				t.Errorf("Enrich: got format %s, want %s", format, tt.format)
+			}
+			expected, err := os.ReadFile(tt.expected)
+			if err != nil {
+				t.Fatalf("cannot read file: %v", err)
+			}
+			if !bytes.Equal(got, expected) {
+				t.Errorf("Enrich() = %s, want %s", got, expected)
+			}
+		})
+	}
+}
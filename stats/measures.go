@@ -20,8 +20,20 @@ var (
 	// CheckRuntimeInSec measures the CPU runtime in seconds per check.
 	CheckRuntimeInSec = stats.Int64("CheckRuntimeInSec", "Measures the CPU runtime in seconds for a check",
 		stats.UnitSeconds)
+	// CheckDurationInMs measures the wall-clock duration in milliseconds per check.
+	CheckDurationInMs = stats.Int64("CheckDurationInMs", "Measures the duration in milliseconds for a check",
+		stats.UnitMilliseconds)
 	// CheckErrors measures the count of errors per check.
 	CheckErrors = stats.Int64("CheckErrors", "Measures the count of errors", stats.UnitDimensionless)
 	// HTTPRequests measures the count of HTTP requests.
 	HTTPRequests = stats.Int64("HTTPRequests", "Measures the count of HTTP requests", stats.UnitDimensionless)
+	// ShardsProcessed measures the count of shards processed by cron workers.
+	ShardsProcessed = stats.Int64("ShardsProcessed", "Measures the count of shards processed",
+		stats.UnitDimensionless)
+	// ReposProcessed measures the count of repos processed by cron workers.
+	ReposProcessed = stats.Int64("ReposProcessed", "Measures the count of repos processed",
+		stats.UnitDimensionless)
+	// PendingShards measures the number of shards of the current cron job without results.
+	PendingShards = stats.Int64("PendingShards", "Measures the number of shards pending",
+		stats.UnitDimensionless)
 )
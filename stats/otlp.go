@@ -0,0 +1,315 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/trace"
+)
+
+// Environment variables configuring the export of traces, as defined by OpenTelemetry:
+// https://opentelemetry.io/docs/specs/otel/protocol/exporter/.
+const (
+	otlpEndpoint       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	otlpTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	otlpHeaders        = "OTEL_EXPORTER_OTLP_HEADERS"
+	otelServiceName    = "OTEL_SERVICE_NAME"
+	otelSamplerArg     = "OTEL_TRACES_SAMPLER_ARG"
+)
+
+const (
+	otlpBatchSize     = 512
+	otlpFlushInterval = 5 * time.Second
+	otlpTimeout       = 10 * time.Second
+
+	// OTLP span kinds and status codes.
+	otlpKindInternal = 1
+	otlpKindServer   = 2
+	otlpKindClient   = 3
+	otlpStatusOK     = 1
+	otlpStatusError  = 2
+)
+
+var errOTLPStatus = errors.New("unexpected OTLP response status")
+
+// OTLPTraceExporter is an opencensus trace.Exporter sending spans to an
+// OpenTelemetry collector, with the OTLP/HTTP JSON protocol.
+type OTLPTraceExporter struct {
+	client      *http.Client
+	url         string
+	headers     map[string]string
+	serviceName string
+
+	mu    sync.Mutex
+	spans []*trace.SpanData
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// StartTraceExporter exports traces to the OpenTelemetry collector configured with
+// OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, if any.
+// The returned function flushes the remaining spans and stops exporting.
+func StartTraceExporter() (stop func(), err error) {
+	url := os.Getenv(otlpTracesEndpoint)
+	if url == "" {
+		if endpoint := os.Getenv(otlpEndpoint); endpoint != "" {
+			url = strings.TrimSuffix(endpoint, "/") + "/v1/traces"
+		}
+	}
+	if url == "" {
+		return func() {}, nil
+	}
+	fraction := 1.0
+	if arg := os.Getenv(otelSamplerArg); arg != "" {
+		fraction, err = strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", otelSamplerArg, err)
+		}
+	}
+	serviceName := os.Getenv(otelServiceName)
+	if serviceName == "" {
+		serviceName = prometheusNamespace
+	}
+	exporter := NewOTLPTraceExporter(url, parseOTLPHeaders(os.Getenv(otlpHeaders)), serviceName)
+	exporter.start()
+	trace.RegisterExporter(exporter)
+	trace.ApplyConfig(trace.Config{DefaultSampler: trace.ProbabilitySampler(fraction)})
+	return func() {
+		trace.UnregisterExporter(exporter)
+		exporter.stop()
+	}, nil
+}
+
+// NewOTLPTraceExporter returns an exporter sending spans to url, e.g., http://localhost:4318/v1/traces.
+func NewOTLPTraceExporter(url string, headers map[string]string, serviceName string) *OTLPTraceExporter {
+	return &OTLPTraceExporter{
+		client:      &http.Client{Timeout: otlpTimeout},
+		url:         url,
+		headers:     headers,
+		serviceName: serviceName,
+		done:        make(chan struct{}),
+	}
+}
+
+// parseOTLPHeaders parses headers in the "key1=value1,key2=value2" format.
+func parseOTLPHeaders(s string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		headers[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return headers
+}
+
+// ExportSpan implements trace.Exporter.ExportSpan.
+func (e *OTLPTraceExporter) ExportSpan(s *trace.SpanData) {
+	e.mu.Lock()
+	e.spans = append(e.spans, s)
+	full := len(e.spans) >= otlpBatchSize
+	e.mu.Unlock()
+	if full {
+		e.Flush()
+	}
+}
+
+// Flush sends the buffered spans. Errors are logged, as tracing must not fail scans.
+func (e *OTLPTraceExporter) Flush() {
+	e.mu.Lock()
+	spans := e.spans
+	e.spans = nil
+	e.mu.Unlock()
+	if len(spans) == 0 {
+		return
+	}
+	if err := e.send(spans); err != nil {
+		fmt.Fprintf(os.Stderr, "exporting %d spans: %v\n", len(spans), err)
+	}
+}
+
+func (e *OTLPTraceExporter) start() {
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		ticker := time.NewTicker(otlpFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				e.Flush()
+			case <-e.done:
+				return
+			}
+		}
+	}()
+}
+
+func (e *OTLPTraceExporter) stop() {
+	close(e.done)
+	e.wg.Wait()
+	e.Flush()
+}
+
+func (e *OTLPTraceExporter) send(spans []*trace.SpanData) error {
+	body, err := json.Marshal(e.request(spans))
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), otlpTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("client.Do: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%w: %s", errOTLPStatus, resp.Status)
+	}
+	return nil
+}
+
+// OTLP/HTTP JSON encoding of traces, see
+// https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/trace/v1/trace.proto.
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	BoolValue   *bool   `json:"boolValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+func (e *OTLPTraceExporter) request(spans []*trace.SpanData) *otlpRequest {
+	serviceName := e.serviceName
+	scopeSpans := otlpScopeSpans{Scope: otlpScope{Name: "github.com/ossf/scorecard"}}
+	for _, s := range spans {
+		scopeSpans.Spans = append(scopeSpans.Spans, otlpSpanFrom(s))
+	}
+	return &otlpRequest{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpAttribute{{Key: "service.name", Value: otlpValue{StringValue: &serviceName}}},
+				},
+				ScopeSpans: []otlpScopeSpans{scopeSpans},
+			},
+		},
+	}
+}
+
+func otlpSpanFrom(s *trace.SpanData) otlpSpan {
+	span := otlpSpan{
+		TraceID:           s.TraceID.String(),
+		SpanID:            s.SpanID.String(),
+		Name:              s.Name,
+		Kind:              otlpKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(s.StartTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.EndTime.UnixNano(), 10),
+		Status:            otlpStatus{Code: otlpStatusOK},
+	}
+	if s.ParentSpanID != (trace.SpanID{}) {
+		span.ParentSpanID = s.ParentSpanID.String()
+	}
+	switch s.SpanKind {
+	case trace.SpanKindServer:
+		span.Kind = otlpKindServer
+	case trace.SpanKindClient:
+		span.Kind = otlpKindClient
+	}
+	if s.Code != trace.StatusCodeOK {
+		span.Status = otlpStatus{Code: otlpStatusError, Message: s.Message}
+	}
+	for k, v := range s.Attributes {
+		var value otlpValue
+		switch v := v.(type) {
+		case string:
+			value.StringValue = &v
+		case bool:
+			value.BoolValue = &v
+		case int64:
+			i := strconv.FormatInt(v, 10)
+			value.IntValue = &i
+		default:
+			str := fmt.Sprint(v)
+			value.StringValue = &str
+		}
+		span.Attributes = append(span.Attributes, otlpAttribute{Key: k, Value: value})
+	}
+	sort.Slice(span.Attributes, func(i, j int) bool { return span.Attributes[i].Key < span.Attributes[j].Key })
+	return span
+}
@@ -0,0 +1,147 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opencensus.io/trace"
+)
+
+func TestParseOTLPHeaders(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]string
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  map[string]string{},
+		},
+		{
+			name:  "pairs",
+			input: "api-key=secret, x-tenant = scorecard,invalid",
+			want:  map[string]string{"api-key": "secret", "x-tenant": "scorecard"},
+		},
+		{
+			name:  "value with equal sign",
+			input: "authorization=Basic YQ==",
+			want:  map[string]string{"authorization": "Basic YQ=="},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.want, parseOTLPHeaders(tt.input)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOTLPTraceExporter(t *testing.T) {
+	t.Parallel()
+	requests := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("io.ReadAll: %v", err)
+		}
+		requests <- r
+		bodies <- body
+	}))
+	t.Cleanup(server.Close)
+
+	exporter := NewOTLPTraceExporter(server.URL+"/v1/traces", map[string]string{"api-key": "secret"}, "test")
+	start := time.Unix(1, 0)
+	exporter.ExportSpan(&trace.SpanData{
+		SpanContext: trace.SpanContext{
+			TraceID: trace.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+			SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+		},
+		ParentSpanID: trace.SpanID{8, 7, 6, 5, 4, 3, 2, 1},
+		Name:         "Check.Binary-Artifacts",
+		SpanKind:     trace.SpanKindClient,
+		StartTime:    start,
+		EndTime:      start.Add(time.Second),
+		Attributes:   map[string]interface{}{"score": int64(10), "repo": "github.com/ossf/scorecard"},
+		Status:       trace.Status{Code: trace.StatusCodeUnknown, Message: "failed"},
+	})
+	exporter.Flush()
+
+	r := <-requests
+	if r.URL.Path != "/v1/traces" {
+		t.Errorf("path: got %s, want /v1/traces", r.URL.Path)
+	}
+	if got := r.Header.Get("api-key"); got != "secret" {
+		t.Errorf("api-key header: got %q, want %q", got, "secret")
+	}
+	var got otlpRequest
+	if err := json.Unmarshal(<-bodies, &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	serviceName, repo, score := "test", "github.com/ossf/scorecard", "10"
+	want := otlpRequest{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpAttribute{{Key: "service.name", Value: otlpValue{StringValue: &serviceName}}},
+				},
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{Name: "github.com/ossf/scorecard"},
+						Spans: []otlpSpan{
+							{
+								TraceID:           "0102030405060708090a0b0c0d0e0f10",
+								SpanID:            "0102030405060708",
+								ParentSpanID:      "0807060504030201",
+								Name:              "Check.Binary-Artifacts",
+								Kind:              otlpKindClient,
+								StartTimeUnixNano: "1000000000",
+								EndTimeUnixNano:   "2000000000",
+								Attributes: []otlpAttribute{
+									{Key: "repo", Value: otlpValue{StringValue: &repo}},
+									{Key: "score", Value: otlpValue{IntValue: &score}},
+								},
+								Status: otlpStatus{Code: otlpStatusError, Message: "failed"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	// Nothing is sent without spans.
+	exporter.Flush()
+	select {
+	case <-requests:
+		t.Error("unexpected request without spans")
+	default:
+	}
+}
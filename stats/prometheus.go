@@ -0,0 +1,148 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opencensus.io/stats/view"
+)
+
+const prometheusNamespace = "scorecard"
+
+var (
+	invalidMetricChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+	labelValueEscaper  = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+)
+
+// PrometheusExporter is an opencensus view.Exporter serving the latest data
+// of the exported views in the Prometheus text format.
+type PrometheusExporter struct {
+	mu    sync.Mutex
+	views map[string]*view.Data
+}
+
+// NewPrometheusExporter returns an exporter to register with view.RegisterExporter
+// and serve on the /metrics path.
+func NewPrometheusExporter() *PrometheusExporter {
+	return &PrometheusExporter{views: make(map[string]*view.Data)}
+}
+
+// ExportView implements view.Exporter.ExportView.
+// Views are cumulative, so only their latest data is kept.
+func (e *PrometheusExporter) ExportView(viewData *view.Data) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.views[viewData.View.Name] = viewData
+}
+
+// ServeHTTP implements http.Handler.ServeHTTP.
+func (e *PrometheusExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	names := make([]string, 0, len(e.views))
+	for name := range e.views {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	views := make([]*view.Data, 0, len(names))
+	for _, name := range names {
+		views = append(views, e.views[name])
+	}
+	e.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	out := bufio.NewWriter(w)
+	for _, data := range views {
+		writePrometheusView(out, data)
+	}
+	//nolint:errcheck
+	out.Flush()
+}
+
+func prometheusName(name string) string {
+	return invalidMetricChars.ReplaceAllString(name, "_")
+}
+
+func writePrometheusView(out *bufio.Writer, data *view.Data) {
+	v := data.View
+	name := prometheusName(prometheusNamespace + "_" + v.Name)
+	var metricType string
+	switch v.Aggregation.Type {
+	case view.AggTypeCount:
+		metricType = "counter"
+	case view.AggTypeLastValue:
+		metricType = "gauge"
+	case view.AggTypeDistribution:
+		metricType = "histogram"
+	default:
+		metricType = "untyped"
+	}
+	fmt.Fprintf(out, "# HELP %s %s\n", name, strings.ReplaceAll(v.Description, "\n", " "))
+	fmt.Fprintf(out, "# TYPE %s %s\n", name, metricType)
+
+	rows := make([]*view.Row, len(data.Rows))
+	copy(rows, data.Rows)
+	sort.Slice(rows, func(i, j int) bool { return labels(rows[i], "") < labels(rows[j], "") })
+	for _, row := range rows {
+		switch agg := row.Data.(type) {
+		case *view.CountData:
+			fmt.Fprintf(out, "%s%s %d\n", name, labels(row, ""), agg.Value)
+		case *view.SumData:
+			fmt.Fprintf(out, "%s%s %s\n", name, labels(row, ""), formatFloat(agg.Value))
+		case *view.LastValueData:
+			fmt.Fprintf(out, "%s%s %s\n", name, labels(row, ""), formatFloat(agg.Value))
+		case *view.DistributionData:
+			// Prometheus buckets are cumulative.
+			var cumulative int64
+			for i, bound := range v.Aggregation.Buckets {
+				if i < len(agg.CountPerBucket) {
+					cumulative += agg.CountPerBucket[i]
+				}
+				le := fmt.Sprintf(`le="%s"`, formatFloat(bound))
+				fmt.Fprintf(out, "%s_bucket%s %d\n", name, labels(row, le), cumulative)
+			}
+			fmt.Fprintf(out, "%s_bucket%s %d\n", name, labels(row, `le="+Inf"`), agg.Count)
+			fmt.Fprintf(out, "%s_sum%s %s\n", name, labels(row, ""), formatFloat(agg.Sum()))
+			fmt.Fprintf(out, "%s_count%s %d\n", name, labels(row, ""), agg.Count)
+		}
+	}
+}
+
+// labels returns the labels of row, followed by extra if not empty.
+func labels(row *view.Row, extra string) string {
+	pairs := make([]string, 0, len(row.Tags)+1)
+	for _, t := range row.Tags {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, prometheusName(t.Key.Name()), labelValueEscaper.Replace(t.Value)))
+	}
+	sort.Strings(pairs)
+	if extra != "" {
+		pairs = append(pairs, extra)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stats
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	testMeasure = stats.Int64("TestMeasure", "Test measure", stats.UnitDimensionless)
+	testKey     = tag.MustNewKey("test.key")
+)
+
+func TestPrometheusExporter(t *testing.T) {
+	t.Parallel()
+	count := &view.View{
+		Name:        "TestCount",
+		Description: "Test count",
+		Measure:     testMeasure,
+		TagKeys:     []tag.Key{testKey},
+		Aggregation: view.Count(),
+	}
+	distribution := &view.View{
+		Name:        "TestDistribution",
+		Description: "Test distribution",
+		Measure:     testMeasure,
+		Aggregation: view.Distribution(1, 10),
+	}
+	worker := view.NewMeter()
+	worker.Start()
+	t.Cleanup(worker.Stop)
+	if err := worker.Register(count, distribution); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	for _, m := range []struct {
+		value int64
+		tag   string
+	}{
+		{value: 2, tag: `a"b`},
+		{value: 4, tag: "a"},
+		{value: 18, tag: "a"},
+	} {
+		ctx, err := tag.New(context.Background(), tag.Upsert(testKey, m.tag))
+		if err != nil {
+			t.Fatalf("tag.New: %v", err)
+		}
+		worker.Record(tag.FromContext(ctx), []stats.Measurement{testMeasure.M(m.value)}, nil)
+	}
+
+	exporter := NewPrometheusExporter()
+	for _, v := range []*view.View{count, distribution} {
+		rows, err := worker.RetrieveData(v.Name)
+		if err != nil {
+			t.Fatalf("RetrieveData: %v", err)
+		}
+		exporter.ExportView(&view.Data{View: v, Rows: rows})
+	}
+	rec := httptest.NewRecorder()
+	exporter.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	want := `# HELP scorecard_TestCount Test count
+# TYPE scorecard_TestCount counter
+scorecard_TestCount{test_key="a"} 2
+scorecard_TestCount{test_key="a\"b"} 1
+# HELP scorecard_TestDistribution Test distribution
+# TYPE scorecard_TestDistribution histogram
+scorecard_TestDistribution_bucket{le="1"} 0
+scorecard_TestDistribution_bucket{le="10"} 2
+scorecard_TestDistribution_bucket{le="+Inf"} 3
+scorecard_TestDistribution_sum 24
+scorecard_TestDistribution_count 3
+`
+	if diff := cmp.Diff(want, rec.Body.String()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -23,4 +23,10 @@ var (
 	ErrorName = tag.MustNewKey("errorName")
 	// RequestTag is the tag key for the request type.
 	RequestTag = tag.MustNewKey("requestTag")
+	// RequestHost is the tag key for the host an HTTP request is sent to.
+	RequestHost = tag.MustNewKey("requestHost")
+	// ResponseStatus is the tag key for the status code of an HTTP response, or "error".
+	ResponseStatus = tag.MustNewKey("responseStatus")
+	// ShardStatus is the tag key for the outcome of a shard, e.g. ack or nack.
+	ShardStatus = tag.MustNewKey("shardStatus")
 )
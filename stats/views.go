@@ -46,6 +46,16 @@ var (
 			1<<15),
 	}
 
+	// CheckDuration tracks wall-clock duration stats for checks.
+	CheckDuration = view.View{
+		Name:        "CheckDuration",
+		Description: "Duration in milliseconds per check",
+		Measure:     CheckDurationInMs,
+		TagKeys:     []tag.Key{CheckName},
+		//nolint:gomnd
+		Aggregation: view.Distribution(0, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000, 300000, 600000),
+	}
+
 	// CheckErrorCount tracks error count stats for checks.
 	CheckErrorCount = view.View{
 		Name:        "CheckErrorCount",
@@ -60,7 +70,32 @@ var (
 		Name:        "OutgoingHTTPRequests",
 		Description: "HTTPRequests made per check",
 		Measure:     HTTPRequests,
-		TagKeys:     []tag.Key{CheckName, RequestTag},
+		TagKeys:     []tag.Key{CheckName, RequestTag, RequestHost, ResponseStatus},
+		Aggregation: view.Count(),
+	}
+
+	// ShardsProcessedCount tracks shards processed by cron workers.
+	ShardsProcessedCount = view.View{
+		Name:        "ShardsProcessedCount",
+		Description: "Shards processed by outcome",
+		Measure:     ShardsProcessed,
+		TagKeys:     []tag.Key{ShardStatus},
+		Aggregation: view.Count(),
+	}
+
+	// ReposProcessedCount tracks repos processed by cron workers.
+	ReposProcessedCount = view.View{
+		Name:        "ReposProcessedCount",
+		Description: "Repos processed by cron workers",
+		Measure:     ReposProcessed,
 		Aggregation: view.Count(),
 	}
+
+	// ShardsPending tracks the queue depth of the cron job, for autoscalers.
+	ShardsPending = view.View{
+		Name:        "ShardsPending",
+		Description: "Shards of the current cron job without results",
+		Measure:     PendingShards,
+		Aggregation: view.LastValue(),
+	}
 )
@@ -0,0 +1,114 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package storage persists scorecard results to local directories and blob stores,
+// so results can be analyzed over time.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"gocloud.dev/blob"
+	"gocloud.dev/blob/fileblob"
+
+	// Needed to link in GCP drivers.
+	_ "gocloud.dev/blob/gcsblob"
+)
+
+// resultDateFormat sorts the results of a repo chronologically.
+const resultDateFormat = "2006-01-02"
+
+var errUnsupportedScheme = errors.New("unsupported bucket URL scheme")
+
+// Bucket stores data under keys.
+type Bucket interface {
+	// Write stores data under key, replacing any data stored there.
+	Write(ctx context.Context, key string, data []byte) error
+	// Close releases the resources of the bucket.
+	Close() error
+}
+
+// OpenBucket opens the bucket at bucketURL, e.g., gs://my-bucket,
+// s3://my-bucket?region=us-east-1 or file:///path/to/dir.
+// Keys are prefixed with the value of the "prefix" query parameter, if any.
+func OpenBucket(ctx context.Context, bucketURL string) (Bucket, error) {
+	u, err := url.Parse(bucketURL)
+	if err != nil {
+		return nil, fmt.Errorf("url.Parse: %w", err)
+	}
+	switch u.Scheme {
+	case "s3":
+		return openS3Bucket(u)
+	case "gs", "file":
+		bucket, err := blob.OpenBucket(ctx, bucketURL)
+		if err != nil {
+			return nil, fmt.Errorf("error from blob.OpenBucket: %w", err)
+		}
+		return &blobBucket{bucket: bucket}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedScheme, bucketURL)
+	}
+}
+
+// OpenDir opens the local directory dir as a bucket, creating it if needed.
+func OpenDir(dir string) (Bucket, error) {
+	bucket, err := fileblob.OpenBucket(dir, &fileblob.Options{
+		CreateDir: true,
+		// Only store the results, without sidecar metadata files.
+		Metadata: fileblob.MetadataDontWrite,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error from fileblob.OpenBucket: %w", err)
+	}
+	return &blobBucket{bucket: bucket}, nil
+}
+
+// ResultKey returns the key of the JSON result of repo at commit, scanned on date,
+// e.g., "github.com/ossf/scorecard/2021-10-01-<commit>.json".
+func ResultKey(repo, commit string, date time.Time) string {
+	// Local repos are named by their file:// URI.
+	if i := strings.Index(repo, "://"); i >= 0 {
+		repo = repo[i+len("://"):]
+	}
+	repo = strings.TrimPrefix(path.Clean("/"+repo), "/")
+	return fmt.Sprintf("%s/%s-%s.json", repo, date.UTC().Format(resultDateFormat), commit)
+}
+
+// blobBucket is a Bucket backed by the Go CDK, used for local directories and GCS.
+type blobBucket struct {
+	bucket *blob.Bucket
+}
+
+// Write implements Bucket.Write.
+func (b *blobBucket) Write(ctx context.Context, key string, data []byte) error {
+	opts := &blob.WriterOptions{ContentType: "application/json"}
+	if err := b.bucket.WriteAll(ctx, key, data, opts); err != nil {
+		return fmt.Errorf("error during bucket.WriteAll: %w", err)
+	}
+	return nil
+}
+
+// Close implements Bucket.Close.
+func (b *blobBucket) Close() error {
+	if err := b.bucket.Close(); err != nil {
+		return fmt.Errorf("error during bucket.Close: %w", err)
+	}
+	return nil
+}
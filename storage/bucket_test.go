@@ -0,0 +1,109 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestResultKey(t *testing.T) {
+	t.Parallel()
+	date := time.Date(2021, 10, 1, 23, 0, 0, 0, time.FixedZone("PDT", -7*60*60))
+	tests := []struct {
+		name     string
+		repo     string
+		expected string
+	}{
+		{
+			name:     "GitHub repo",
+			repo:     "github.com/ossf/scorecard",
+			expected: "github.com/ossf/scorecard/2021-10-02-sha.json",
+		},
+		{
+			name:     "local repo",
+			repo:     "file:///home/user/repo",
+			expected: "home/user/repo/2021-10-02-sha.json",
+		},
+		{
+			name:     "relative path",
+			repo:     "github.com/ossf/../../etc",
+			expected: "etc/2021-10-02-sha.json",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // Re-initializing variable so it is not changed while executing the closure below
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ResultKey(tt.repo, "sha", date); got != tt.expected {
+				t.Errorf("ResultKey() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOpenDir(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join(t.TempDir(), "results")
+	bucket, err := OpenDir(dir)
+	if err != nil {
+		t.Fatalf("OpenDir: %v", err)
+	}
+	t.Cleanup(func() { bucket.Close() })
+
+	const key = "github.com/ossf/scorecard/2021-10-01-sha.json"
+	if err := bucket.Write(context.Background(), key, []byte(`{"score": 10}`)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(key)))
+	if err != nil {
+		t.Fatalf("os.ReadFile: %v", err)
+	}
+	if string(got) != `{"score": 10}` {
+		t.Errorf("got %q", got)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "github.com", "ossf", "scorecard"))
+	if err != nil {
+		t.Fatalf("os.ReadDir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("got %d files, want only the result", len(entries))
+	}
+}
+
+func TestOpenBucket(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	bucket, err := OpenBucket(context.Background(), "file://"+filepath.ToSlash(dir)+"?prefix=scorecard/")
+	if err != nil {
+		t.Fatalf("OpenBucket: %v", err)
+	}
+	t.Cleanup(func() { bucket.Close() })
+	if err := bucket.Write(context.Background(), "result.json", []byte("{}")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "scorecard", "result.json")); err != nil {
+		t.Errorf("os.Stat: %v", err)
+	}
+
+	for _, bucketURL := range []string{"ftp://example.com/results", "s3://"} {
+		if _, err := OpenBucket(context.Background(), bucketURL); err == nil {
+			t.Errorf("OpenBucket(%q): expected error", bucketURL)
+		}
+	}
+}
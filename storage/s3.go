@@ -0,0 +1,97 @@
+// Copyright 2021 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+var errInvalidS3URL = errors.New("invalid S3 bucket URL")
+
+// s3Bucket is a Bucket backed by Amazon S3 or a compatible store, like MinIO.
+type s3Bucket struct {
+	client *s3.S3
+	bucket string
+	prefix string
+}
+
+// openS3Bucket opens an s3:// bucket URL. Credentials and the region are read
+// from the environment and shared config files, like the AWS CLI does. The
+// "region", "endpoint" and "s3ForcePathStyle" query parameters override them.
+func openS3Bucket(u *url.URL) (*s3Bucket, error) {
+	if u.Host == "" {
+		return nil, fmt.Errorf("%w: missing bucket name: %s", errInvalidS3URL, u)
+	}
+	q := u.Query()
+	config := aws.NewConfig()
+	if region := q.Get("region"); region != "" {
+		config = config.WithRegion(region)
+	}
+	if endpoint := q.Get("endpoint"); endpoint != "" {
+		config = config.WithEndpoint(endpoint)
+	}
+	if pathStyle := q.Get("s3ForcePathStyle"); pathStyle != "" {
+		v, err := strconv.ParseBool(pathStyle)
+		if err != nil {
+			return nil, fmt.Errorf("%w: s3ForcePathStyle: %v", errInvalidS3URL, err)
+		}
+		config = config.WithS3ForcePathStyle(v)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            *config,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error from session.NewSessionWithOptions: %w", err)
+	}
+	return newS3Bucket(sess, u.Host, q.Get("prefix")), nil
+}
+
+func newS3Bucket(p client.ConfigProvider, bucket, prefix string) *s3Bucket {
+	return &s3Bucket{
+		client: s3.New(p),
+		bucket: bucket,
+		prefix: prefix,
+	}
+}
+
+// Write implements Bucket.Write.
+func (b *s3Bucket) Write(ctx context.Context, key string, data []byte) error {
+	_, err := b.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(b.bucket),
+		Key:         aws.String(b.prefix + key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return fmt.Errorf("error during PutObject: %w", err)
+	}
+	return nil
+}
+
+// Close implements Bucket.Close.
+func (b *s3Bucket) Close() error {
+	return nil
+}
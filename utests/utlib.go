@@ -67,6 +67,9 @@ func (l *TestDetailLogger) Info3(msg *checker.LogMessage) {
 		Msg:  *msg,
 	}
 	cd.Msg.Version = 3
+	if cd.Msg.Text == "" && cd.Msg.Message != nil {
+		cd.Msg.Text = cd.Msg.Message.String()
+	}
 	l.messages = append(l.messages, cd)
 }
 
@@ -77,6 +80,9 @@ func (l *TestDetailLogger) Warn3(msg *checker.LogMessage) {
 		Msg:  *msg,
 	}
 	cd.Msg.Version = 3
+	if cd.Msg.Text == "" && cd.Msg.Message != nil {
+		cd.Msg.Text = cd.Msg.Message.String()
+	}
 	l.messages = append(l.messages, cd)
 }
 
@@ -87,6 +93,9 @@ func (l *TestDetailLogger) Debug3(msg *checker.LogMessage) {
 		Msg:  *msg,
 	}
 	cd.Msg.Version = 3
+	if cd.Msg.Text == "" && cd.Msg.Message != nil {
+		cd.Msg.Text = cd.Msg.Message.String()
+	}
 	l.messages = append(l.messages, cd)
 }
 